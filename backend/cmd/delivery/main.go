@@ -0,0 +1,64 @@
+// Command delivery consumes the notification queue and delivers each message itself: email
+// over SMTP, push through Expo, or, for local development, as JSON lines written to a file
+// or stdout (see config.Delivery). Results are written back to scheduled notifications.
+// It replaces the Lambda consumer and can run alongside it while that is phased out.
+package main
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"os/signal"
+	"skillspark/internal/config"
+	"skillspark/internal/delivery"
+	"skillspark/internal/models"
+	"skillspark/internal/sqs_client"
+	"skillspark/internal/storage/postgres"
+	"syscall"
+)
+
+func main() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	repo := postgres.NewRepository(context.Background(), cfg.DB)
+
+	// Close database connection when main exits
+	defer func() {
+		slog.Info("Closing database connection")
+		if err := repo.Close(); err != nil {
+			slog.Error("failed to close database", "error", err)
+		}
+	}()
+
+	sqsClient, err := sqs_client.NewClient(cfg.SQS)
+	if err != nil {
+		log.Fatalf("Failed to initialize SQS client: %v", err)
+	}
+
+	transports, closeTransports, err := delivery.TransportsFromConfig(cfg.Delivery)
+	if err != nil {
+		log.Fatalf("Failed to set up delivery transports: %v", err)
+	}
+	defer func() {
+		if err := closeTransports(); err != nil {
+			slog.Error("failed to close delivery transports", "error", err)
+		}
+	}()
+
+	outcomes := delivery.NewOutcomeRecorder(repo.Notification, repo.Guardian)
+	worker := delivery.NewWorker(sqsClient, repo.Notification, transports, outcomes, cfg.Delivery.MaxReceives)
+
+	// Stop polling on SIGINT or SIGTERM; messages already received are still delivered
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	slog.Info("Delivery worker started",
+		"email_transport", transports[models.NotificationTypeEmail].Name(),
+		"push_transport", transports[models.NotificationTypePush].Name(),
+	)
+	worker.Run(ctx)
+	slog.Info("Delivery worker shutdown complete")
+}
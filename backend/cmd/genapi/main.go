@@ -31,7 +31,7 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Failed to create S3 Client: %v\n", err)
 	}
 
-	notificationsService := notifications.NewService(nil, nil)
+	notificationsService := notifications.NewService(nil, nil, "")
 	translateClient := translations.NewClient(nil)
 	newStripeClient, err := stripeClient.NewStripeClient("")
 	if err != nil {
@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"log/slog"
 	"os"
@@ -11,13 +10,10 @@ import (
 	"skillspark/internal/service"
 	"syscall"
 	"time"
-
-	"github.com/joho/godotenv"
-	"github.com/sethvargo/go-envconfig"
 )
 
 func main() {
-	cfg, err := LoadConfig()
+	cfg, err := config.LoadConfig()
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
@@ -63,36 +59,3 @@ func main() {
 
 	slog.Info("Server shutdown complete")
 }
-
-func LoadConfig() (*config.Config, error) {
-	environment := os.Getenv("ENVIRONMENT")
-	testMode := os.Getenv("TEST_MODE")
-
-	var cfg config.Config
-	switch environment {
-	case "production":
-		// Load configuration from environment variables for production
-		err := envconfig.Process(context.Background(), &cfg)
-		if err != nil {
-			log.Fatalln("Error processing environment variables: ", err)
-		}
-	case "development":
-		log.Println("Loading configuration from environment variables for development")
-		// Load configuration from environment variables for development
-		err := godotenv.Overload("../.local.env")
-		if err != nil {
-			log.Fatalln("Error loading .local.env file: ", err)
-		}
-		err = envconfig.Process(context.Background(), &cfg)
-		if err != nil {
-			log.Fatalln("Error processing environment variables: ", err)
-		}
-	default:
-		log.Fatalln("Invalid environment name: ", environment, "The environment name must be one of either production or development")
-		return nil, fmt.Errorf("invalid environment name: %s", environment)
-	}
-
-	cfg.TestMode = testMode == "true"
-
-	return &cfg, nil
-}
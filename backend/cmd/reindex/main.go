@@ -0,0 +1,120 @@
+// Command reindex rebuilds or repairs the OpenSearch indices from Postgres.
+//
+//	reindex -mode full   builds a new versioned index with the explicit mapping, bulk
+//	                     loads every row, points the alias at it and deletes old builds
+//	                     beyond -keep
+//	reindex -mode sync   reindexes rows changed since -since, or since the newest document
+//	                     in the index, and removes documents of deleted rows
+//
+// -index picks the events, occurrences or organizations index; by default every index is
+// processed in turn. The indices are normally kept current by the notify_opensearch
+// triggers and the edge function; this is for a first build, mapping changes, and catching
+// up after missed calls.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"log/slog"
+	"os/signal"
+	"skillspark/internal/config"
+	"skillspark/internal/opensearch"
+	"skillspark/internal/storage"
+	"skillspark/internal/storage/postgres"
+	"syscall"
+	"time"
+)
+
+func main() {
+	mode := flag.String("mode", "sync", "full to rebuild the index, sync to bring it up to date")
+	only := flag.String("index", "", "events, occurrences or organizations to process one index instead of all of them")
+	since := flag.String("since", "", "sync rows changed after this RFC 3339 time instead of the newest document in the index")
+	batchSize := flag.Int("batch", 500, "documents per bulk request")
+	keep := flag.Int("keep", 1, "unused builds to keep after a full rebuild, for rolling back")
+	flag.Parse()
+
+	indices := opensearch.Indices
+	if *only != "" {
+		ix, ok := opensearch.IndexByAlias(*only)
+		if !ok {
+			log.Fatalf("Unknown -index %q, expected events, occurrences or organizations", *only)
+		}
+		indices = []opensearch.Index{ix}
+	}
+
+	var from *time.Time
+	if *since != "" {
+		parsed, err := time.Parse(time.RFC3339, *since)
+		if err != nil {
+			log.Fatalf("Invalid -since: %v", err)
+		}
+		from = &parsed
+	}
+	if *mode != "full" && *mode != "sync" {
+		log.Fatalf("Unknown -mode %q, expected full or sync", *mode)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	osClient, err := opensearch.NewClient(cfg.OpenSearch)
+	if err != nil {
+		log.Fatalf("Failed to initialize OpenSearch client: %v", err)
+	}
+	if osClient == nil {
+		log.Fatal("OPENSEARCH_URL is not set")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	repo := postgres.NewRepository(ctx, cfg.DB)
+	defer func() {
+		if err := repo.Close(); err != nil {
+			slog.Error("failed to close database", "error", err)
+		}
+	}()
+
+	for _, ix := range indices {
+		reindexer := opensearch.NewReindexer(osClient, ix, source(repo, ix), *batchSize)
+
+		switch *mode {
+		case "full":
+			result, err := reindexer.Rebuild(ctx, *keep)
+			if err != nil {
+				log.Fatalf("Rebuild of %s failed: %v", ix.Alias, err)
+			}
+			slog.Info("Rebuild complete",
+				"alias", ix.Alias,
+				"index", result.Index,
+				"indexed", result.Indexed,
+				"previous", result.Previous,
+				"deleted", result.Deleted,
+				"caught_up", result.CatchUp.Indexed,
+				"removed", result.CatchUp.Removed,
+			)
+
+		case "sync":
+			result, err := reindexer.Sync(ctx, from)
+			if err != nil {
+				log.Fatalf("Sync of %s failed: %v", ix.Alias, err)
+			}
+			slog.Info("Sync complete", "alias", ix.Alias, "since", result.Since, "indexed", result.Indexed, "removed", result.Removed)
+		}
+	}
+}
+
+// source returns where the documents of ix are read from
+func source(repo *storage.Repository, ix opensearch.Index) opensearch.DocumentSource {
+	switch ix.Alias {
+	case opensearch.Occurrences.Alias:
+		return opensearch.NewSource(repo.EventOccurrence.GetEventOccurrenceSearchDocuments, repo.EventOccurrence.GetScheduledEventOccurrenceIDs)
+	case opensearch.Organizations.Alias:
+		return opensearch.NewSource(repo.Organization.GetOrganizationSearchDocuments, repo.Organization.GetActiveOrganizationIDs)
+	default:
+		return opensearch.NewSource(repo.Event.GetEventSearchDocuments, repo.Event.GetAllEventIDs)
+	}
+}
@@ -0,0 +1,47 @@
+// Command segment stores the segmented Thai words that Postgres full-text search matches
+// against, for events written before the API segmented them or by seeds and scripts.
+//
+//	segment        segments events with Thai text but no words
+//	segment -all   segments every event again, e.g. after adding to the dictionary
+//
+// The API segments events as they are created and updated, so this only needs to run
+// after a migration, a seed or a dictionary change.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"log/slog"
+	"os/signal"
+	"skillspark/internal/config"
+	"skillspark/internal/storage/postgres"
+	"syscall"
+)
+
+func main() {
+	all := flag.Bool("all", false, "segment every event, not only those without words")
+	batchSize := flag.Int("batch", 500, "events per batch")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	repo := postgres.NewRepository(ctx, cfg.DB)
+	defer func() {
+		if err := repo.Close(); err != nil {
+			slog.Error("failed to close database", "error", err)
+		}
+	}()
+
+	updated, err := repo.Event.SegmentThaiWords(ctx, *all, *batchSize)
+	if err != nil {
+		log.Fatalf("Segmenting failed after %d events: %v", updated, err)
+	}
+	slog.Info("Segmenting complete", "updated", updated)
+}
@@ -0,0 +1,68 @@
+// Command worker runs the scheduled background jobs (payment capture, payment intent
+// creation, scheduled notifications, upload cleanup, recommendations) and relays the
+// outbox to SQS. It is deployed separately from the API server; jobs take a Postgres
+// advisory lock per run, so any number of workers can be running and each scheduled run
+// still happens once.
+package main
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"skillspark/internal/config"
+	"skillspark/internal/notification"
+	"skillspark/internal/s3_client"
+	"skillspark/internal/sqs_client"
+	"skillspark/internal/storage/postgres"
+	"skillspark/internal/stripeClient"
+	"skillspark/jobs"
+	"syscall"
+)
+
+func main() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	repo := postgres.NewRepository(context.Background(), cfg.DB)
+
+	// Close database connection when main exits
+	defer func() {
+		slog.Info("Closing database connection")
+		if err := repo.Close(); err != nil {
+			slog.Error("failed to close database", "error", err)
+		}
+	}()
+
+	sqsClient, err := sqs_client.NewClient(cfg.SQS)
+	if err != nil {
+		log.Fatalf("Failed to initialize SQS client: %v", err)
+	}
+	notifService := notification.NewService(repo, notification.UnsubscribeSignerFromConfig(cfg.Notification), cfg.Notification.PublicAPIURL)
+
+	s3Client, err := s3_client.NewClient(cfg.S3)
+	if err != nil {
+		log.Fatalf("Failed to initialize S3 client: %v", err)
+	}
+
+	sc, err := stripeClient.NewStripeClient("")
+	if err != nil {
+		log.Fatalf("Failed to initialize Stripe client: %v", err)
+	}
+
+	scheduler := jobs.NewJobScheduler(repo, sc, notifService, sqsClient, s3Client)
+	scheduler.Start()
+
+	// Wait for termination signal (SIGINT or SIGTERM)
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	<-quit
+
+	slog.Info("Shutting down worker, waiting for running jobs")
+	scheduler.Stop()
+	slog.Info("Worker shutdown complete")
+}
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// AdminRole is the app_metadata role of platform staff. App metadata can only be set with
+// the service role key, so users can't grant it to themselves.
+const AdminRole = "admin"
+
+// IsAdmin reports whether the claims belong to platform staff
+func (c *SupabaseClaims) IsAdmin() bool {
+	role, _ := c.AppMetadata["role"].(string)
+	return role == AdminRole
+}
+
+// RequireAdmin is an operation middleware that rejects requests whose JWT does not carry
+// the admin role, for routes that act across every guardian or organization
+func RequireAdmin(api huma.API) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		cookie, err := huma.ReadCookie(ctx, "jwt")
+		if err != nil || cookie.Value == "" {
+			if err := huma.WriteErr(api, ctx, http.StatusUnauthorized, "Token Not Found"); err != nil {
+				slog.Error("Failed to write error", "err", err)
+			}
+			return
+		}
+
+		claims, err := NewVerifier("").Verify(cookie.Value)
+		if err != nil {
+			if err := huma.WriteErr(api, ctx, http.StatusUnauthorized, "Invalid/Expired Token"); err != nil {
+				slog.Error("Failed to write error", "err", err)
+			}
+			return
+		}
+
+		if !claims.IsAdmin() {
+			if err := huma.WriteErr(api, ctx, http.StatusForbidden, "Admin access required"); err != nil {
+				slog.Error("Failed to write error", "err", err)
+			}
+			return
+		}
+
+		next(ctx)
+	}
+}
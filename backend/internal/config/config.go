@@ -2,11 +2,13 @@ package config
 
 // Config holds the entire application configuration
 type Config struct {
-	Application Application
-	DB          DB
-	Supabase    Supabase
-	TestMode    bool
-	S3          S3
-	SQS         SQS
-	OpenSearch  OpenSearch
+	Application  Application
+	DB           DB
+	Supabase     Supabase
+	TestMode     bool
+	S3           S3
+	SQS          SQS
+	OpenSearch   OpenSearch
+	Notification Notification
+	Delivery     Delivery
 }
@@ -0,0 +1,20 @@
+package config
+
+// Delivery configures the notification delivery worker (cmd/delivery)
+type Delivery struct {
+	// EmailTransport is "smtp" or "file"; PushTransport is "expo" or "file"
+	EmailTransport string `env:"DELIVERY_EMAIL_TRANSPORT, default=file"`
+	PushTransport  string `env:"DELIVERY_PUSH_TRANSPORT, default=file"`
+	// FilePath is where the file transport appends messages; stdout when empty
+	FilePath string `env:"DELIVERY_FILE_PATH"`
+	// MaxReceives is how many times a message is tried before it is marked failed
+	MaxReceives int `env:"DELIVERY_MAX_RECEIVES, default=5"`
+
+	SMTPHost     string `env:"SMTP_HOST"`
+	SMTPPort     int    `env:"SMTP_PORT, default=587"`
+	SMTPUsername string `env:"SMTP_USERNAME"`
+	SMTPPassword string `env:"SMTP_PASSWORD"`
+	SMTPFrom     string `env:"SMTP_FROM, default=SkillSpark <noreply@skillspark.app>"`
+
+	ExpoAccessToken string `env:"EXPO_ACCESS_TOKEN"`
+}
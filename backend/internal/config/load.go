@@ -0,0 +1,46 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/sethvargo/go-envconfig"
+)
+
+// LoadConfig reads the configuration for the environment named by ENVIRONMENT.
+// It is shared by the API server and the worker so both read the same settings.
+func LoadConfig() (*Config, error) {
+	environment := os.Getenv("ENVIRONMENT")
+	testMode := os.Getenv("TEST_MODE")
+
+	var cfg Config
+	switch environment {
+	case "production":
+		// Load configuration from environment variables for production
+		err := envconfig.Process(context.Background(), &cfg)
+		if err != nil {
+			log.Fatalln("Error processing environment variables: ", err)
+		}
+	case "development":
+		log.Println("Loading configuration from environment variables for development")
+		// Load configuration from environment variables for development
+		err := godotenv.Overload("../.local.env")
+		if err != nil {
+			log.Fatalln("Error loading .local.env file: ", err)
+		}
+		err = envconfig.Process(context.Background(), &cfg)
+		if err != nil {
+			log.Fatalln("Error processing environment variables: ", err)
+		}
+	default:
+		log.Fatalln("Invalid environment name: ", environment, "The environment name must be one of either production or development")
+		return nil, fmt.Errorf("invalid environment name: %s", environment)
+	}
+
+	cfg.TestMode = testMode == "true"
+
+	return &cfg, nil
+}
@@ -0,0 +1,8 @@
+package config
+
+type Notification struct {
+	// PublicAPIURL is where links in emails, such as unsubscribe links, point
+	PublicAPIURL string `env:"PUBLIC_API_URL, default=http://localhost:8080"`
+	// UnsubscribeSecret signs unsubscribe links; emails go out without one when it is unset
+	UnsubscribeSecret string `env:"NOTIFICATION_UNSUBSCRIBE_SECRET"`
+}
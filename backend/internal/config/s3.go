@@ -15,4 +15,10 @@ type S3 struct {
 	LocalStackRegion    string `env:"LOCALSTACK_REGION, default=us-east-1"`
 	LocalStackAccessKey string `env:"LOCALSTACK_ACCESS_KEY_ID, default=test"`
 	LocalStackSecretKey string `env:"LOCALSTACK_SECRET_ACCESS_KEY, default=test"`
+
+	// Public assets are served from PublicBaseURL (a CDN or a public bucket URL) instead of
+	// being presigned, so their URLs never change. A key is public when it starts with one
+	// of PublicPrefixes. Leaving PublicBaseURL empty presigns everything.
+	PublicBaseURL  string   `env:"AWS_S3_PUBLIC_BASE_URL"`
+	PublicPrefixes []string `env:"AWS_S3_PUBLIC_PREFIXES"`
 }
@@ -0,0 +1,58 @@
+package delivery
+
+import (
+	"fmt"
+	"skillspark/internal/config"
+	"skillspark/internal/models"
+)
+
+// TransportsFromConfig builds the transport for each channel named in cfg. The returned
+// close function releases the file transport's file, if one was opened.
+func TransportsFromConfig(cfg config.Delivery) (map[models.NotificationType]Transport, func() error, error) {
+	var file *FileTransport
+	closeFile := func() error { return nil }
+	fileTransport := func() (Transport, error) {
+		if file == nil {
+			var err error
+			file, closeFile, err = OpenFileTransport(cfg.FilePath)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return file, nil
+	}
+
+	transports := make(map[models.NotificationType]Transport, 2)
+
+	switch cfg.EmailTransport {
+	case "smtp":
+		smtpTransport, err := NewSMTPTransport(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
+		if err != nil {
+			return nil, nil, err
+		}
+		transports[models.NotificationTypeEmail] = smtpTransport
+	case "file", "":
+		t, err := fileTransport()
+		if err != nil {
+			return nil, nil, err
+		}
+		transports[models.NotificationTypeEmail] = t
+	default:
+		return nil, nil, fmt.Errorf("unknown email transport %q (want smtp or file)", cfg.EmailTransport)
+	}
+
+	switch cfg.PushTransport {
+	case "expo":
+		transports[models.NotificationTypePush] = NewExpoTransport(cfg.ExpoAccessToken)
+	case "file", "":
+		t, err := fileTransport()
+		if err != nil {
+			return nil, nil, err
+		}
+		transports[models.NotificationTypePush] = t
+	default:
+		return nil, nil, fmt.Errorf("unknown push transport %q (want expo or file)", cfg.PushTransport)
+	}
+
+	return transports, func() error { return closeFile() }, nil
+}
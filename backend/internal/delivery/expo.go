@@ -0,0 +1,217 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"skillspark/internal/models"
+	"time"
+)
+
+const (
+	expoPushURL     = "https://exp.host/--/api/v2/push/send"
+	expoReceiptsURL = "https://exp.host/--/api/v2/push/getReceipts"
+	// expoReceiptsBatch is the most receipt IDs Expo accepts per request
+	expoReceiptsBatch = 1000
+)
+
+// Expo ticket error codes
+const (
+	ExpoErrorDeviceNotRegistered = "DeviceNotRegistered"
+	ExpoErrorMessageTooBig       = "MessageTooBig"
+	ExpoErrorMessageRateExceeded = "MessageRateExceeded"
+)
+
+// ExpoTransport sends push notifications through Expo's push API
+type ExpoTransport struct {
+	url         string
+	receiptsURL string
+	accessToken string
+	client      *http.Client
+}
+
+// NewExpoTransport creates the transport. accessToken is only needed when the Expo project
+// has enhanced push security turned on; if empty, EXPO_ACCESS_TOKEN is used.
+func NewExpoTransport(accessToken string) *ExpoTransport {
+	if accessToken == "" {
+		accessToken = os.Getenv("EXPO_ACCESS_TOKEN")
+	}
+	return &ExpoTransport{
+		url:         expoPushURL,
+		receiptsURL: expoReceiptsURL,
+		accessToken: accessToken,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type expoPushMessage struct {
+	To    string          `json:"to"`
+	Title string          `json:"title,omitempty"`
+	Body  string          `json:"body"`
+	Data  json.RawMessage `json:"data,omitempty"`
+	Sound string          `json:"sound,omitempty"`
+}
+
+type expoPushResponse struct {
+	Data   []expoPushTicket `json:"data"`
+	Errors []struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+type expoPushTicket struct {
+	Status  string `json:"status"`
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	Details struct {
+		Error string `json:"error"`
+	} `json:"details"`
+}
+
+// ExpoTicketError is a push Expo refused. Code is one of the ExpoError constants, or
+// empty when Expo didn't give one.
+type ExpoTicketError struct {
+	Code    string
+	Message string
+}
+
+func (e *ExpoTicketError) Error() string {
+	return fmt.Sprintf("expo push failed (%s): %s", e.Code, e.Message)
+}
+
+func (t *ExpoTransport) Name() string { return "expo" }
+
+func (t *ExpoTransport) Send(ctx context.Context, message *models.NotificationMessage) (string, error) {
+	if message.RecipientPushToken == nil || *message.RecipientPushToken == "" {
+		return "", Permanent(fmt.Errorf("push token is required"))
+	}
+
+	push := expoPushMessage{
+		To:    *message.RecipientPushToken,
+		Body:  message.Body,
+		Sound: "default",
+	}
+	if message.Subject != nil {
+		push.Title = *message.Subject
+	}
+	// Expo only accepts a JSON object as data
+	if len(message.Metadata) > 0 && message.Metadata[0] == '{' {
+		push.Data = message.Metadata
+	}
+
+	body, err := t.post(ctx, t.url, []expoPushMessage{push})
+	if err != nil {
+		return "", err
+	}
+
+	var pushResp expoPushResponse
+	if err := json.Unmarshal(body, &pushResp); err != nil {
+		return "", fmt.Errorf("failed to decode expo response: %w", err)
+	}
+	if len(pushResp.Errors) > 0 {
+		return "", fmt.Errorf("expo API error %s: %s", pushResp.Errors[0].Code, pushResp.Errors[0].Message)
+	}
+	if len(pushResp.Data) == 0 {
+		return "", fmt.Errorf("expo API returned no ticket")
+	}
+
+	ticket := pushResp.Data[0]
+	if ticket.Status != "ok" {
+		ticketErr := &ExpoTicketError{Code: ticket.Details.Error, Message: ticket.Message}
+		if ticketErr.Code == ExpoErrorMessageRateExceeded {
+			return "", ticketErr
+		}
+		return "", Permanent(ticketErr)
+	}
+
+	return ticket.ID, nil
+}
+
+// ExpoReceipt is Expo's report on a push it accepted: "ok" once it reached Apple or Google,
+// otherwise an error with an ExpoError code in Details.Error
+type ExpoReceipt struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Details struct {
+		Error string `json:"error"`
+	} `json:"details"`
+}
+
+type expoReceiptsResponse struct {
+	Data   map[string]ExpoReceipt `json:"data"`
+	Errors []struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// GetReceipts fetches receipts for the given ticket IDs. Receipts Expo isn't ready to give
+// yet, or has already dropped, are missing from the result.
+func (t *ExpoTransport) GetReceipts(ctx context.Context, ticketIDs []string) (map[string]ExpoReceipt, error) {
+	receipts := make(map[string]ExpoReceipt, len(ticketIDs))
+	for start := 0; start < len(ticketIDs); start += expoReceiptsBatch {
+		end := min(start+expoReceiptsBatch, len(ticketIDs))
+
+		body, err := t.post(ctx, t.receiptsURL, map[string][]string{"ids": ticketIDs[start:end]})
+		if err != nil {
+			return nil, err
+		}
+
+		var receiptsResp expoReceiptsResponse
+		if err := json.Unmarshal(body, &receiptsResp); err != nil {
+			return nil, fmt.Errorf("failed to decode expo receipts: %w", err)
+		}
+		if len(receiptsResp.Errors) > 0 {
+			return nil, fmt.Errorf("expo API error %s: %s", receiptsResp.Errors[0].Code, receiptsResp.Errors[0].Message)
+		}
+		for id, receipt := range receiptsResp.Data {
+			receipts[id] = receipt
+		}
+	}
+	return receipts, nil
+}
+
+// post sends payload as JSON and returns the response body of a 2xx reply
+func (t *ExpoTransport) post(ctx context.Context, url string, payload any) ([]byte, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return nil, Permanent(fmt.Errorf("failed to encode expo request: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if t.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+t.accessToken)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call expo: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read expo response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err := fmt.Errorf("expo API returned status %d: %s", resp.StatusCode, body)
+		// 4xx is a bad request (or bad credentials) and will be refused again; 429 and
+		// 5xx are worth another try
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+			return nil, Permanent(err)
+		}
+		return nil, err
+	}
+	return body, nil
+}
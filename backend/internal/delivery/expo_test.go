@@ -0,0 +1,141 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"skillspark/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExpoTransport(t *testing.T, status int, response string, received *[]expoPushMessage) *ExpoTransport {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer expo-token", r.Header.Get("Authorization"))
+		if received != nil {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(received))
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	transport := NewExpoTransport("expo-token")
+	transport.url = server.URL
+	return transport
+}
+
+func TestExpoTransport_Send(t *testing.T) {
+	var received []expoPushMessage
+	transport := newTestExpoTransport(t, http.StatusOK, `{"data":[{"status":"ok","id":"ticket-1"}]}`, &received)
+
+	token := "ExponentPushToken[abc]"
+	title := "Starting soon"
+	ticketID, err := transport.Send(context.Background(), &models.NotificationMessage{
+		NotificationType:   models.NotificationTypePush,
+		RecipientPushToken: &token,
+		Subject:            &title,
+		Body:               "Robotics Club starts in 2 hours",
+		Metadata:           json.RawMessage(`{"type":"event_reminder"}`),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "ticket-1", ticketID)
+	require.Len(t, received, 1)
+	assert.Equal(t, token, received[0].To)
+	assert.Equal(t, title, received[0].Title)
+	assert.JSONEq(t, `{"type":"event_reminder"}`, string(received[0].Data))
+}
+
+func TestExpoTransport_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		response      string
+		wantPermanent bool
+		wantCode      string
+	}{
+		{
+			name:          "device not registered",
+			status:        http.StatusOK,
+			response:      `{"data":[{"status":"error","message":"not a registered push token","details":{"error":"DeviceNotRegistered"}}]}`,
+			wantPermanent: true,
+			wantCode:      ExpoErrorDeviceNotRegistered,
+		},
+		{
+			name:     "rate limited ticket",
+			status:   http.StatusOK,
+			response: `{"data":[{"status":"error","message":"slow down","details":{"error":"MessageRateExceeded"}}]}`,
+			wantCode: ExpoErrorMessageRateExceeded,
+		},
+		{
+			name:          "bad request",
+			status:        http.StatusBadRequest,
+			response:      `{"errors":[{"code":"VALIDATION_ERROR","message":"bad"}]}`,
+			wantPermanent: true,
+		},
+		{
+			name:     "too many requests",
+			status:   http.StatusTooManyRequests,
+			response: `{}`,
+		},
+		{
+			name:     "server error",
+			status:   http.StatusBadGateway,
+			response: `oops`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := newTestExpoTransport(t, tt.status, tt.response, nil)
+			token := "ExponentPushToken[abc]"
+
+			_, err := transport.Send(context.Background(), &models.NotificationMessage{
+				NotificationType:   models.NotificationTypePush,
+				RecipientPushToken: &token,
+				Body:               "hello",
+			})
+
+			require.Error(t, err)
+			assert.Equal(t, tt.wantPermanent, IsPermanent(err))
+			if tt.wantCode != "" {
+				var ticketErr *ExpoTicketError
+				require.ErrorAs(t, err, &ticketErr)
+				assert.Equal(t, tt.wantCode, ticketErr.Code)
+			}
+		})
+	}
+}
+
+func TestExpoTransport_GetReceipts(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			IDs []string `json:"ids"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		requested = append(requested, body.IDs...)
+		_, _ = w.Write([]byte(`{"data":{
+			"ticket-1":{"status":"ok"},
+			"ticket-2":{"status":"error","message":"not registered","details":{"error":"DeviceNotRegistered"}}
+		}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	transport := NewExpoTransport("expo-token")
+	transport.receiptsURL = server.URL
+
+	receipts, err := transport.GetReceipts(context.Background(), []string{"ticket-1", "ticket-2", "ticket-3"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ticket-1", "ticket-2", "ticket-3"}, requested)
+	require.Len(t, receipts, 2)
+	assert.Equal(t, "ok", receipts["ticket-1"].Status)
+	assert.Equal(t, ExpoErrorDeviceNotRegistered, receipts["ticket-2"].Details.Error)
+}
@@ -0,0 +1,62 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"skillspark/internal/models"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FileTransport writes each message as a line of JSON instead of sending it, for local
+// development and end-to-end tests. It handles both channels.
+type FileTransport struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+type fileRecord struct {
+	ID        string                      `json:"id"`
+	Delivered time.Time                   `json:"delivered_at"`
+	Message   *models.NotificationMessage `json:"message"`
+}
+
+func NewFileTransport(w io.Writer) *FileTransport {
+	return &FileTransport{w: w}
+}
+
+// OpenFileTransport appends to the file at path, or writes to stdout when path is empty.
+// The returned close function closes the file.
+func OpenFileTransport(path string) (*FileTransport, func() error, error) {
+	if path == "" {
+		return NewFileTransport(os.Stdout), func() error { return nil }, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open delivery file: %w", err)
+	}
+	return NewFileTransport(f), f.Close, nil
+}
+
+func (t *FileTransport) Name() string { return "file" }
+
+func (t *FileTransport) Send(ctx context.Context, message *models.NotificationMessage) (string, error) {
+	record := fileRecord{ID: uuid.NewString(), Delivered: time.Now().UTC(), Message: message}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return "", Permanent(fmt.Errorf("failed to encode message: %w", err))
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, err := t.w.Write(append(line, '\n')); err != nil {
+		return "", fmt.Errorf("failed to write message: %w", err)
+	}
+	return record.ID, nil
+}
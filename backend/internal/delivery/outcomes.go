@@ -0,0 +1,100 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"skillspark/internal/models"
+	"skillspark/internal/storage"
+)
+
+// ErrRecipientRejected is wrapped by transport errors where the provider refused the
+// address itself, such as an SMTP 550 for an unknown mailbox
+var ErrRecipientRejected = errors.New("recipient rejected")
+
+// OutcomeRecorder stores delivery outcomes reported by providers and stops sending where
+// it can't succeed: a hard bounce turns off the guardian's email, a spam complaint turns off
+// email for that notification's topic, and an unregistered push token is removed.
+type OutcomeRecorder struct {
+	notifications storage.NotificationRepository
+	guardians     storage.GuardianRepository
+}
+
+func NewOutcomeRecorder(notifications storage.NotificationRepository, guardians storage.GuardianRepository) *OutcomeRecorder {
+	return &OutcomeRecorder{
+		notifications: notifications,
+		guardians:     guardians,
+	}
+}
+
+// Record stores the outcome and applies its effect on the guardian
+func (r *OutcomeRecorder) Record(ctx context.Context, event *models.DeliveryOutcomeEvent) error {
+	matched, err := r.notifications.RecordNotificationOutcome(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	guardianID := event.GuardianID
+	var topic models.NotificationTopic
+	if matched != nil {
+		if guardianID == nil {
+			guardianID = matched.GuardianID
+		}
+		if matched.Topic != nil {
+			topic = *matched.Topic
+		}
+	}
+
+	switch event.Outcome {
+	case models.DeliveryOutcomeInvalidToken:
+		// the token is enough to find who to clear it from
+		cleared, err := r.guardians.ClearExpoPushToken(ctx, event.Recipient)
+		if err != nil {
+			return fmt.Errorf("failed to clear invalid push token: %w", err)
+		}
+		slog.Info("Cleared unregistered push token", "guardian_id", guardianID, "guardians", cleared)
+
+	case models.DeliveryOutcomeBounced:
+		if guardianID == nil {
+			slog.Warn("Email bounced for an unknown guardian", "provider", event.Provider, "provider_message_id", event.ProviderMessageID)
+			return nil
+		}
+		if err := r.guardians.DisableGuardianNotificationChannel(ctx, *guardianID, models.NotificationTypeEmail); err != nil {
+			return fmt.Errorf("failed to disable email after bounce: %w", err)
+		}
+		slog.Info("Disabled email after bounce", "guardian_id", *guardianID)
+
+	case models.DeliveryOutcomeComplained:
+		if guardianID == nil {
+			slog.Warn("Spam complaint for an unknown guardian", "provider", event.Provider, "provider_message_id", event.ProviderMessageID)
+			return nil
+		}
+		// without a topic there is no narrower choice to turn off than email as a whole
+		if topic == "" {
+			if err := r.guardians.DisableGuardianNotificationChannel(ctx, *guardianID, models.NotificationTypeEmail); err != nil {
+				return fmt.Errorf("failed to disable email after complaint: %w", err)
+			}
+		} else if err := r.guardians.UpdateGuardianNotificationPreferences(ctx, *guardianID, []models.NotificationPreference{
+			{Topic: topic, Channel: models.NotificationTypeEmail, Enabled: false},
+		}); err != nil {
+			return fmt.Errorf("failed to disable topic after complaint: %w", err)
+		}
+		slog.Info("Disabled email after spam complaint", "guardian_id", *guardianID, "topic", topic)
+	}
+
+	return nil
+}
+
+// OutcomeFromError maps a transport error to the outcome it reports, if it is one the
+// recorder acts on
+func OutcomeFromError(err error) (models.DeliveryOutcome, bool) {
+	var ticketErr *ExpoTicketError
+	if errors.As(err, &ticketErr) && ticketErr.Code == ExpoErrorDeviceNotRegistered {
+		return models.DeliveryOutcomeInvalidToken, true
+	}
+	if errors.Is(err, ErrRecipientRejected) {
+		return models.DeliveryOutcomeBounced, true
+	}
+	return "", false
+}
@@ -0,0 +1,115 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"skillspark/internal/models"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutcomeRecorder_Record(t *testing.T) {
+	guardianID := uuid.New()
+	notificationID := uuid.New()
+	topic := models.NotificationTopicEventReminders
+	token := "ExponentPushToken[abc]"
+
+	tests := []struct {
+		name      string
+		event     *models.DeliveryOutcomeEvent
+		matched   *models.OutcomeNotification
+		mockSetup func(*repomocks.MockGuardianRepository)
+	}{
+		{
+			name:    "delivered changes nothing",
+			event:   &models.DeliveryOutcomeEvent{Outcome: models.DeliveryOutcomeDelivered, Channel: models.NotificationTypeEmail, Recipient: "parent@example.com"},
+			matched: &models.OutcomeNotification{ID: notificationID, GuardianID: &guardianID},
+		},
+		{
+			name:    "bounce disables email for the matched guardian",
+			event:   &models.DeliveryOutcomeEvent{Outcome: models.DeliveryOutcomeBounced, Channel: models.NotificationTypeEmail, Recipient: "parent@example.com"},
+			matched: &models.OutcomeNotification{ID: notificationID, GuardianID: &guardianID},
+			mockSetup: func(m *repomocks.MockGuardianRepository) {
+				m.On("DisableGuardianNotificationChannel", mock.Anything, guardianID, models.NotificationTypeEmail).Return(nil).Once()
+			},
+		},
+		{
+			name:  "bounce of an unknown message is only recorded",
+			event: &models.DeliveryOutcomeEvent{Outcome: models.DeliveryOutcomeBounced, Channel: models.NotificationTypeEmail, Recipient: "parent@example.com"},
+		},
+		{
+			name:    "complaint disables the topic by email",
+			event:   &models.DeliveryOutcomeEvent{Outcome: models.DeliveryOutcomeComplained, Channel: models.NotificationTypeEmail, Recipient: "parent@example.com"},
+			matched: &models.OutcomeNotification{ID: notificationID, GuardianID: &guardianID, Topic: &topic},
+			mockSetup: func(m *repomocks.MockGuardianRepository) {
+				m.On("UpdateGuardianNotificationPreferences", mock.Anything, guardianID, []models.NotificationPreference{
+					{Topic: topic, Channel: models.NotificationTypeEmail, Enabled: false},
+				}).Return(nil).Once()
+			},
+		},
+		{
+			name:    "complaint without a topic disables email",
+			event:   &models.DeliveryOutcomeEvent{Outcome: models.DeliveryOutcomeComplained, Channel: models.NotificationTypeEmail, Recipient: "parent@example.com", GuardianID: &guardianID},
+			matched: nil,
+			mockSetup: func(m *repomocks.MockGuardianRepository) {
+				m.On("DisableGuardianNotificationChannel", mock.Anything, guardianID, models.NotificationTypeEmail).Return(nil).Once()
+			},
+		},
+		{
+			name:    "invalid token is cleared",
+			event:   &models.DeliveryOutcomeEvent{Outcome: models.DeliveryOutcomeInvalidToken, Channel: models.NotificationTypePush, Recipient: token},
+			matched: &models.OutcomeNotification{ID: notificationID, GuardianID: &guardianID},
+			mockSetup: func(m *repomocks.MockGuardianRepository) {
+				m.On("ClearExpoPushToken", mock.Anything, token).Return(int64(1), nil).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNotificationRepo := new(repomocks.MockNotificationRepository)
+			mockGuardianRepo := new(repomocks.MockGuardianRepository)
+			if tt.matched != nil {
+				mockNotificationRepo.On("RecordNotificationOutcome", mock.Anything, tt.event).Return(tt.matched, nil).Once()
+			} else {
+				mockNotificationRepo.On("RecordNotificationOutcome", mock.Anything, tt.event).Return(nil, nil).Once()
+			}
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockGuardianRepo)
+			}
+
+			err := NewOutcomeRecorder(mockNotificationRepo, mockGuardianRepo).Record(context.Background(), tt.event)
+
+			require.NoError(t, err)
+			mockNotificationRepo.AssertExpectations(t)
+			mockGuardianRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOutcomeFromError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		want    models.DeliveryOutcome
+		wantHit bool
+	}{
+		{name: "unregistered device", err: Permanent(&ExpoTicketError{Code: ExpoErrorDeviceNotRegistered}), want: models.DeliveryOutcomeInvalidToken, wantHit: true},
+		{name: "other ticket error", err: Permanent(&ExpoTicketError{Code: ExpoErrorMessageTooBig})},
+		{name: "rejected mailbox", err: Permanent(fmt.Errorf("%w: 550", ErrRecipientRejected)), want: models.DeliveryOutcomeBounced, wantHit: true},
+		{name: "network error", err: fmt.Errorf("connection reset")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := OutcomeFromError(tt.err)
+			assert.Equal(t, tt.wantHit, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"skillspark/internal/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SMTPTransport sends email through an SMTP relay. Messages carry a plain-text and, when
+// rendered, an HTML part, plus one-click List-Unsubscribe headers when the message has an
+// unsubscribe link.
+type SMTPTransport struct {
+	addr string
+	auth smtp.Auth
+	from *mail.Address
+	// sendMail is smtp.SendMail, swapped out in tests
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPTransport connects to host:port, authenticating when a username is given. from is
+// an address such as "SkillSpark <noreply@skillspark.app>".
+func NewSMTPTransport(host string, port int, username, password, from string) (*SMTPTransport, error) {
+	if host == "" {
+		return nil, fmt.Errorf("SMTP host is required")
+	}
+	fromAddr, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP from address %q: %w", from, err)
+	}
+
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPTransport{
+		addr:     net.JoinHostPort(host, strconv.Itoa(port)),
+		auth:     auth,
+		from:     fromAddr,
+		sendMail: smtp.SendMail,
+	}, nil
+}
+
+func (t *SMTPTransport) Name() string { return "smtp" }
+
+func (t *SMTPTransport) Send(ctx context.Context, message *models.NotificationMessage) (string, error) {
+	if message.RecipientEmail == nil || *message.RecipientEmail == "" {
+		return "", Permanent(fmt.Errorf("recipient email is required"))
+	}
+	to, err := mail.ParseAddress(*message.RecipientEmail)
+	if err != nil {
+		return "", Permanent(fmt.Errorf("invalid recipient email: %w", err))
+	}
+
+	messageID := fmt.Sprintf("<%s@%s>", uuid.NewString(), domainOf(t.from.Address))
+	body, err := t.buildMessage(to, messageID, message)
+	if err != nil {
+		return "", Permanent(err)
+	}
+
+	if err := t.sendMail(t.addr, t.auth, t.from.Address, []string{to.Address}, body); err != nil {
+		// 5xx replies (unknown mailbox, rejected content) won't succeed on a retry
+		var reply *textproto.Error
+		if errors.As(err, &reply) && reply.Code >= 500 {
+			if isMailboxRejection(reply.Code) {
+				return "", Permanent(fmt.Errorf("%w: %w", ErrRecipientRejected, err))
+			}
+			return "", Permanent(fmt.Errorf("SMTP server rejected message: %w", err))
+		}
+		return "", fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return messageID, nil
+}
+
+func (t *SMTPTransport) buildMessage(to *mail.Address, messageID string, message *models.NotificationMessage) ([]byte, error) {
+	subject := ""
+	if message.Subject != nil {
+		subject = *message.Subject
+	}
+
+	var buf bytes.Buffer
+	header := func(key, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+	}
+
+	parts := multipart.NewWriter(&buf)
+	header("From", t.from.String())
+	header("To", to.String())
+	header("Subject", mime.QEncoding.Encode("utf-8", subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", messageID)
+	header("MIME-Version", "1.0")
+	if message.UnsubscribeURL != nil && *message.UnsubscribeURL != "" {
+		header("List-Unsubscribe", "<"+*message.UnsubscribeURL+">")
+		header("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+	}
+	header("Content-Type", "multipart/alternative; boundary="+parts.Boundary())
+	buf.WriteString("\r\n")
+
+	if err := writePart(parts, "text/plain; charset=utf-8", message.Body); err != nil {
+		return nil, err
+	}
+	if message.HTMLBody != nil && *message.HTMLBody != "" {
+		if err := writePart(parts, "text/html; charset=utf-8", *message.HTMLBody); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build email: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writePart(parts *multipart.Writer, contentType string, content string) error {
+	part, err := parts.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build email: %w", err)
+	}
+
+	qp := quotedprintable.NewWriter(part)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return fmt.Errorf("failed to build email: %w", err)
+	}
+	return qp.Close()
+}
+
+// isMailboxRejection reports whether an SMTP reply code means the mailbox doesn't exist or
+// can't receive mail (RFC 5321: 550 unavailable, 551 not local, 553 name not allowed)
+func isMailboxRejection(code int) bool {
+	return code == 550 || code == 551 || code == 553
+}
+
+func domainOf(address string) string {
+	if at := strings.LastIndex(address, "@"); at >= 0 {
+		return address[at+1:]
+	}
+	return "localhost"
+}
@@ -0,0 +1,129 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"skillspark/internal/models"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type capturedMail struct {
+	addr string
+	from string
+	to   []string
+	msg  []byte
+}
+
+func newTestSMTPTransport(t *testing.T, sendErr error) (*SMTPTransport, *capturedMail) {
+	t.Helper()
+
+	transport, err := NewSMTPTransport("smtp.example.com", 587, "", "", "SkillSpark <noreply@skillspark.app>")
+	require.NoError(t, err)
+
+	captured := &capturedMail{}
+	transport.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		captured.addr, captured.from, captured.to, captured.msg = addr, from, to, msg
+		return sendErr
+	}
+	return transport, captured
+}
+
+func TestSMTPTransport_Send(t *testing.T) {
+	transport, captured := newTestSMTPTransport(t, nil)
+
+	recipient := "parent@example.com"
+	subject := "ยืนยันการลงทะเบียน: Robotics Club"
+	html := "<p>See you there</p>"
+	unsubscribe := "https://api.example.com/api/v1/notifications/unsubscribe?token=abc"
+
+	messageID, err := transport.Send(context.Background(), &models.NotificationMessage{
+		NotificationType: models.NotificationTypeEmail,
+		RecipientEmail:   &recipient,
+		Subject:          &subject,
+		Body:             "See you there",
+		HTMLBody:         &html,
+		UnsubscribeURL:   &unsubscribe,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "smtp.example.com:587", captured.addr)
+	assert.Equal(t, "noreply@skillspark.app", captured.from)
+	assert.Equal(t, []string{recipient}, captured.to)
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(captured.msg)))
+	require.NoError(t, err)
+	assert.Equal(t, messageID, msg.Header.Get("Message-ID"))
+	assert.True(t, strings.HasSuffix(messageID, "@skillspark.app>"))
+	assert.Equal(t, "<"+unsubscribe+">", msg.Header.Get("List-Unsubscribe"))
+	assert.Equal(t, "List-Unsubscribe=One-Click", msg.Header.Get("List-Unsubscribe-Post"))
+
+	decodedSubject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, subject, decodedSubject)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	var contentTypes, bodies []string
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		contentTypes = append(contentTypes, part.Header.Get("Content-Type"))
+		bodies = append(bodies, string(body))
+	}
+	assert.Equal(t, []string{"text/plain; charset=utf-8", "text/html; charset=utf-8"}, contentTypes)
+	assert.Equal(t, []string{"See you there", html}, bodies)
+}
+
+func TestSMTPTransport_Errors(t *testing.T) {
+	recipient := "parent@example.com"
+	invalid := "not an address"
+
+	tests := []struct {
+		name          string
+		recipient     *string
+		sendErr       error
+		wantPermanent bool
+		wantRejected  bool
+	}{
+		{name: "missing recipient", recipient: nil, wantPermanent: true},
+		{name: "invalid recipient", recipient: &invalid, wantPermanent: true},
+		{name: "mailbox rejected", recipient: &recipient, sendErr: &textproto.Error{Code: 550, Msg: "no such user"}, wantPermanent: true, wantRejected: true},
+		{name: "content rejected", recipient: &recipient, sendErr: &textproto.Error{Code: 554, Msg: "spam"}, wantPermanent: true},
+		{name: "server busy", recipient: &recipient, sendErr: &textproto.Error{Code: 421, Msg: "try again later"}, wantPermanent: false},
+		{name: "connection refused", recipient: &recipient, sendErr: io.ErrUnexpectedEOF, wantPermanent: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport, _ := newTestSMTPTransport(t, tt.sendErr)
+
+			_, err := transport.Send(context.Background(), &models.NotificationMessage{
+				NotificationType: models.NotificationTypeEmail,
+				RecipientEmail:   tt.recipient,
+				Body:             "hello",
+			})
+
+			require.Error(t, err)
+			assert.Equal(t, tt.wantPermanent, IsPermanent(err))
+			assert.Equal(t, tt.wantRejected, errors.Is(err, ErrRecipientRejected))
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Package delivery sends queued notifications to recipients. The Worker takes messages off
+// the notification queue and hands each one to the Transport registered for its channel.
+package delivery
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/models"
+)
+
+// Transport delivers a notification over one channel
+type Transport interface {
+	// Name identifies the transport in logs and in the notification's delivery record
+	Name() string
+	// Send delivers the message and returns the provider's id for it, if it has one.
+	// Errors that retrying can't fix should be wrapped with Permanent.
+	Send(ctx context.Context, message *models.NotificationMessage) (providerMessageID string, err error)
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as one retrying won't fix, such as a rejected address or an
+// unregistered device, so the message is failed straight away
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
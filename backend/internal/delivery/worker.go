@@ -0,0 +1,233 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/sqs_client"
+	"skillspark/internal/storage"
+	"time"
+)
+
+const (
+	receiveBatchSize = 10
+	receiveWait      = 20 * time.Second
+	// receiveErrorBackoff is how long to wait after the queue itself fails
+	receiveErrorBackoff = 5 * time.Second
+)
+
+// Worker consumes the notification queue. Each message is sent with the transport for its
+// channel and deleted once it is delivered or has failed for good; messages that fail
+// otherwise are left on the queue to come back after its visibility timeout, until they
+// have been tried maxReceives times. A message with a dedup ID is claimed in Postgres
+// before it is sent, and any other copy of it is dropped, since a standard queue and the
+// outbox relay can both hand out the same message more than once. Results are written back
+// to scheduled notifications, and failures that say the address is dead are passed to
+// outcomes, when set.
+type Worker struct {
+	queue       sqs_client.SQSConsumerInterface
+	repo        storage.NotificationRepository
+	transports  map[models.NotificationType]Transport
+	outcomes    *OutcomeRecorder
+	maxReceives int
+}
+
+func NewWorker(queue sqs_client.SQSConsumerInterface, repo storage.NotificationRepository, transports map[models.NotificationType]Transport, outcomes *OutcomeRecorder, maxReceives int) *Worker {
+	return &Worker{
+		queue:       queue,
+		repo:        repo,
+		transports:  transports,
+		outcomes:    outcomes,
+		maxReceives: max(maxReceives, 1),
+	}
+}
+
+// Run polls the queue until ctx is cancelled. Messages already received when that happens
+// are still handled, so nothing is left half sent.
+func (w *Worker) Run(ctx context.Context) {
+	for ctx.Err() == nil {
+		if _, err := w.Poll(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Error("Failed to receive notifications", "error", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(receiveErrorBackoff):
+			}
+		}
+	}
+}
+
+// Poll receives one batch of messages and handles each, returning how many it received
+func (w *Worker) Poll(ctx context.Context) (int, error) {
+	messages, err := w.queue.ReceiveMessages(ctx, receiveBatchSize, receiveWait)
+	if err != nil {
+		return 0, err
+	}
+
+	handleCtx := context.WithoutCancel(ctx)
+	for _, message := range messages {
+		w.handle(handleCtx, message)
+	}
+	return len(messages), nil
+}
+
+func (w *Worker) handle(ctx context.Context, received sqs_client.ReceivedMessage) {
+	var message models.NotificationMessage
+	if err := json.Unmarshal([]byte(received.Body), &message); err != nil {
+		// it will never decode, so there is no point in seeing it again
+		slog.Error("Dropping malformed notification message", "message_id", received.ID, "error", err)
+		w.delete(ctx, received)
+		return
+	}
+
+	if !w.claim(ctx, received, &message) {
+		return
+	}
+
+	transport, ok := w.transports[message.NotificationType]
+	if !ok {
+		w.fail(ctx, received, &message, "", Permanent(fmt.Errorf("no transport for notification type %q", message.NotificationType)))
+		return
+	}
+
+	providerID, err := transport.Send(ctx, &message)
+	if err != nil {
+		if IsPermanent(err) || received.ReceiveCount >= w.maxReceives {
+			w.fail(ctx, received, &message, transport.Name(), err)
+			return
+		}
+		slog.Warn("Notification delivery failed, will retry",
+			"message_id", received.ID,
+			"notification_id", message.NotificationID,
+			"transport", transport.Name(),
+			"attempt", received.ReceiveCount,
+			"error", err,
+		)
+		return
+	}
+
+	slog.Info("Notification delivered",
+		"message_id", received.ID,
+		"notification_id", message.NotificationID,
+		"transport", transport.Name(),
+		"provider_message_id", providerID,
+	)
+	result := &models.NotificationDeliveryResult{
+		Status:    models.NotificationStatusDelivered,
+		Transport: transport.Name(),
+	}
+	if providerID != "" {
+		result.ProviderMessageID = &providerID
+	}
+	w.record(ctx, &message, result)
+	w.delete(ctx, received)
+}
+
+// claim reports whether this copy of the message should be delivered. Duplicates are
+// deleted; a message whose claim can't be checked is left for a retry.
+func (w *Worker) claim(ctx context.Context, received sqs_client.ReceivedMessage, message *models.NotificationMessage) bool {
+	dedupID := received.DedupID
+	if dedupID == "" {
+		dedupID = message.DedupID
+	}
+	if dedupID == "" {
+		return true
+	}
+
+	claimed, err := w.repo.ClaimNotificationDelivery(ctx, dedupID, received.ID)
+	if err != nil {
+		slog.Error("Failed to claim notification delivery, will retry", "message_id", received.ID, "dedup_id", dedupID, "error", err)
+		return false
+	}
+	if !claimed {
+		slog.Info("Dropping duplicate notification message", "message_id", received.ID, "dedup_id", dedupID, "notification_id", message.NotificationID)
+		w.delete(ctx, received)
+		return false
+	}
+	return true
+}
+
+func (w *Worker) fail(ctx context.Context, received sqs_client.ReceivedMessage, message *models.NotificationMessage, transport string, err error) {
+	slog.Error("Notification delivery failed",
+		"message_id", received.ID,
+		"notification_id", message.NotificationID,
+		"transport", transport,
+		"attempt", received.ReceiveCount,
+		"permanent", IsPermanent(err),
+		"error", err,
+	)
+	reason := err.Error()
+	w.record(ctx, message, &models.NotificationDeliveryResult{
+		Status:    models.NotificationStatusFailed,
+		Transport: transport,
+		Error:     &reason,
+	})
+	w.recordOutcome(ctx, message, transport, err)
+	w.delete(ctx, received)
+}
+
+// recordOutcome reports a failure that means the recipient itself can't be reached, so the
+// address stops being used
+func (w *Worker) recordOutcome(ctx context.Context, message *models.NotificationMessage, transport string, err error) {
+	if w.outcomes == nil {
+		return
+	}
+	outcome, ok := OutcomeFromError(err)
+	if !ok {
+		return
+	}
+
+	var recipient string
+	switch message.NotificationType {
+	case models.NotificationTypeEmail:
+		if message.RecipientEmail != nil {
+			recipient = *message.RecipientEmail
+		}
+	case models.NotificationTypePush:
+		if message.RecipientPushToken != nil {
+			recipient = *message.RecipientPushToken
+		}
+	}
+	if recipient == "" {
+		return
+	}
+
+	detail := err.Error()
+	if err := w.outcomes.Record(ctx, &models.DeliveryOutcomeEvent{
+		Outcome:        outcome,
+		Channel:        message.NotificationType,
+		Provider:       transport,
+		NotificationID: message.NotificationID,
+		GuardianID:     message.GuardianID,
+		Recipient:      recipient,
+		Detail:         &detail,
+	}); err != nil {
+		slog.Error("Failed to record delivery outcome", "notification_id", message.NotificationID, "outcome", outcome, "error", err)
+	}
+}
+
+// record writes the result back to the scheduled notification, if the message came from one
+func (w *Worker) record(ctx context.Context, message *models.NotificationMessage, result *models.NotificationDeliveryResult) {
+	if message.NotificationID == nil {
+		return
+	}
+	if err := w.repo.RecordNotificationDelivery(ctx, *message.NotificationID, result); err != nil {
+		// a notification deleted since it was queued has nothing to update
+		if httpErr, ok := err.(*errs.HTTPError); ok && httpErr.Code == http.StatusNotFound {
+			return
+		}
+		slog.Error("Failed to record notification delivery", "notification_id", *message.NotificationID, "error", err)
+	}
+}
+
+func (w *Worker) delete(ctx context.Context, received sqs_client.ReceivedMessage) {
+	if err := w.queue.DeleteMessage(ctx, received.ReceiptHandle); err != nil {
+		slog.Error("Failed to delete notification message", "message_id", received.ID, "error", err)
+	}
+}
@@ -0,0 +1,320 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/sqs_client"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeQueue struct {
+	messages []sqs_client.ReceivedMessage
+	deleted  []string
+}
+
+func (q *fakeQueue) ReceiveMessages(ctx context.Context, maxMessages int, wait time.Duration) ([]sqs_client.ReceivedMessage, error) {
+	messages := q.messages
+	q.messages = nil
+	return messages, nil
+}
+
+func (q *fakeQueue) DeleteMessage(ctx context.Context, receiptHandle string) error {
+	q.deleted = append(q.deleted, receiptHandle)
+	return nil
+}
+
+type fakeTransport struct {
+	name string
+	err  error
+	sent []*models.NotificationMessage
+}
+
+func (t *fakeTransport) Name() string { return t.name }
+
+func (t *fakeTransport) Send(ctx context.Context, message *models.NotificationMessage) (string, error) {
+	t.sent = append(t.sent, message)
+	if t.err != nil {
+		return "", t.err
+	}
+	return "provider-1", nil
+}
+
+func queuedMessage(t *testing.T, notificationID *uuid.UUID, notificationType models.NotificationType, receiveCount int) sqs_client.ReceivedMessage {
+	t.Helper()
+
+	email := "parent@example.com"
+	body, err := json.Marshal(models.NotificationMessage{
+		NotificationID:   notificationID,
+		NotificationType: notificationType,
+		RecipientEmail:   &email,
+		Body:             "hello",
+	})
+	require.NoError(t, err)
+	return sqs_client.ReceivedMessage{ID: "m1", Body: string(body), ReceiptHandle: "r1", ReceiveCount: receiveCount}
+}
+
+func TestWorker_Poll(t *testing.T) {
+	notificationID := uuid.New()
+
+	tests := []struct {
+		name         string
+		message      func(t *testing.T) sqs_client.ReceivedMessage
+		sendErr      error
+		mockSetup    func(*repomocks.MockNotificationRepository)
+		wantSent     int
+		wantDeleted  bool
+		wantRecorded bool
+	}{
+		{
+			name: "delivered",
+			message: func(t *testing.T) sqs_client.ReceivedMessage {
+				return queuedMessage(t, &notificationID, models.NotificationTypeEmail, 1)
+			},
+			mockSetup: func(m *repomocks.MockNotificationRepository) {
+				m.On("RecordNotificationDelivery", mock.Anything, notificationID, mock.MatchedBy(func(result *models.NotificationDeliveryResult) bool {
+					return result.Status == models.NotificationStatusDelivered &&
+						result.Transport == "fake" &&
+						*result.ProviderMessageID == "provider-1" &&
+						result.Error == nil
+				})).Return(nil).Once()
+			},
+			wantSent:     1,
+			wantDeleted:  true,
+			wantRecorded: true,
+		},
+		{
+			name: "immediate notification is delivered without a write-back",
+			message: func(t *testing.T) sqs_client.ReceivedMessage {
+				return queuedMessage(t, nil, models.NotificationTypeEmail, 1)
+			},
+			mockSetup:   func(m *repomocks.MockNotificationRepository) {},
+			wantSent:    1,
+			wantDeleted: true,
+		},
+		{
+			name: "transient failure is left for a retry",
+			message: func(t *testing.T) sqs_client.ReceivedMessage {
+				return queuedMessage(t, &notificationID, models.NotificationTypeEmail, 1)
+			},
+			sendErr:     errors.New("connection reset"),
+			mockSetup:   func(m *repomocks.MockNotificationRepository) {},
+			wantSent:    1,
+			wantDeleted: false,
+		},
+		{
+			name: "transient failure on the last receive fails the notification",
+			message: func(t *testing.T) sqs_client.ReceivedMessage {
+				return queuedMessage(t, &notificationID, models.NotificationTypeEmail, 3)
+			},
+			sendErr: errors.New("connection reset"),
+			mockSetup: func(m *repomocks.MockNotificationRepository) {
+				m.On("RecordNotificationDelivery", mock.Anything, notificationID, mock.MatchedBy(func(result *models.NotificationDeliveryResult) bool {
+					return result.Status == models.NotificationStatusFailed && *result.Error == "connection reset"
+				})).Return(nil).Once()
+			},
+			wantSent:     1,
+			wantDeleted:  true,
+			wantRecorded: true,
+		},
+		{
+			name: "permanent failure fails straight away",
+			message: func(t *testing.T) sqs_client.ReceivedMessage {
+				return queuedMessage(t, &notificationID, models.NotificationTypeEmail, 1)
+			},
+			sendErr: Permanent(errors.New("no such user")),
+			mockSetup: func(m *repomocks.MockNotificationRepository) {
+				m.On("RecordNotificationDelivery", mock.Anything, notificationID, mock.MatchedBy(func(result *models.NotificationDeliveryResult) bool {
+					return result.Status == models.NotificationStatusFailed
+				})).Return(nil).Once()
+			},
+			wantSent:     1,
+			wantDeleted:  true,
+			wantRecorded: true,
+		},
+		{
+			name: "no transport for the channel",
+			message: func(t *testing.T) sqs_client.ReceivedMessage {
+				return queuedMessage(t, &notificationID, models.NotificationTypePush, 1)
+			},
+			mockSetup: func(m *repomocks.MockNotificationRepository) {
+				m.On("RecordNotificationDelivery", mock.Anything, notificationID, mock.MatchedBy(func(result *models.NotificationDeliveryResult) bool {
+					return result.Status == models.NotificationStatusFailed
+				})).Return(nil).Once()
+			},
+			wantDeleted:  true,
+			wantRecorded: true,
+		},
+		{
+			name: "malformed message is dropped",
+			message: func(t *testing.T) sqs_client.ReceivedMessage {
+				return sqs_client.ReceivedMessage{ID: "m1", Body: "{not json", ReceiptHandle: "r1", ReceiveCount: 1}
+			},
+			mockSetup:   func(m *repomocks.MockNotificationRepository) {},
+			wantDeleted: true,
+		},
+		{
+			name: "first copy of a deduplicated message is delivered",
+			message: func(t *testing.T) sqs_client.ReceivedMessage {
+				received := queuedMessage(t, nil, models.NotificationTypeEmail, 1)
+				received.DedupID = "registration_confirmed:1:email"
+				return received
+			},
+			mockSetup: func(m *repomocks.MockNotificationRepository) {
+				m.On("ClaimNotificationDelivery", mock.Anything, "registration_confirmed:1:email", "m1").Return(true, nil).Once()
+			},
+			wantSent:    1,
+			wantDeleted: true,
+		},
+		{
+			name: "duplicate copy is dropped without sending",
+			message: func(t *testing.T) sqs_client.ReceivedMessage {
+				received := queuedMessage(t, nil, models.NotificationTypeEmail, 1)
+				received.DedupID = "registration_confirmed:1:email"
+				return received
+			},
+			mockSetup: func(m *repomocks.MockNotificationRepository) {
+				m.On("ClaimNotificationDelivery", mock.Anything, "registration_confirmed:1:email", "m1").Return(false, nil).Once()
+			},
+			wantDeleted: true,
+		},
+		{
+			name: "dedup ID in the body is claimed when the attribute is missing",
+			message: func(t *testing.T) sqs_client.ReceivedMessage {
+				body, err := json.Marshal(models.NotificationMessage{
+					NotificationType: models.NotificationTypeEmail,
+					Body:             "hello",
+					DedupID:          "registration_confirmed:1:email",
+				})
+				require.NoError(t, err)
+				return sqs_client.ReceivedMessage{ID: "m1", Body: string(body), ReceiptHandle: "r1", ReceiveCount: 1}
+			},
+			mockSetup: func(m *repomocks.MockNotificationRepository) {
+				m.On("ClaimNotificationDelivery", mock.Anything, "registration_confirmed:1:email", "m1").Return(false, nil).Once()
+			},
+			wantDeleted: true,
+		},
+		{
+			name: "claim failure is left for a retry",
+			message: func(t *testing.T) sqs_client.ReceivedMessage {
+				received := queuedMessage(t, nil, models.NotificationTypeEmail, 1)
+				received.DedupID = "registration_confirmed:1:email"
+				return received
+			},
+			mockSetup: func(m *repomocks.MockNotificationRepository) {
+				m.On("ClaimNotificationDelivery", mock.Anything, "registration_confirmed:1:email", "m1").Return(false, assert.AnError).Once()
+			},
+			wantDeleted: false,
+		},
+		{
+			name: "notification deleted since it was queued",
+			message: func(t *testing.T) sqs_client.ReceivedMessage {
+				return queuedMessage(t, &notificationID, models.NotificationTypeEmail, 1)
+			},
+			mockSetup: func(m *repomocks.MockNotificationRepository) {
+				notFound := errs.NotFound("Notification", "id", notificationID)
+				m.On("RecordNotificationDelivery", mock.Anything, notificationID, mock.Anything).Return(&notFound).Once()
+			},
+			wantSent:     1,
+			wantDeleted:  true,
+			wantRecorded: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue := &fakeQueue{messages: []sqs_client.ReceivedMessage{tt.message(t)}}
+			transport := &fakeTransport{name: "fake", err: tt.sendErr}
+			mockRepo := new(repomocks.MockNotificationRepository)
+			tt.mockSetup(mockRepo)
+
+			worker := NewWorker(queue, mockRepo, map[models.NotificationType]Transport{
+				models.NotificationTypeEmail: transport,
+			}, nil, 3)
+
+			received, err := worker.Poll(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, 1, received)
+			assert.Len(t, transport.sent, tt.wantSent)
+			if tt.wantDeleted {
+				assert.Equal(t, []string{"r1"}, queue.deleted)
+			} else {
+				assert.Empty(t, queue.deleted)
+			}
+			if !tt.wantRecorded {
+				mockRepo.AssertNotCalled(t, "RecordNotificationDelivery", mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWorker_RecordsOutcomeOfRejectedRecipient(t *testing.T) {
+	notificationID := uuid.New()
+	guardianID := uuid.New()
+	email := "gone@example.com"
+	body, err := json.Marshal(models.NotificationMessage{
+		NotificationID:   &notificationID,
+		GuardianID:       &guardianID,
+		NotificationType: models.NotificationTypeEmail,
+		RecipientEmail:   &email,
+		Body:             "hello",
+	})
+	require.NoError(t, err)
+
+	queue := &fakeQueue{messages: []sqs_client.ReceivedMessage{{ID: "m1", Body: string(body), ReceiptHandle: "r1", ReceiveCount: 1}}}
+	transport := &fakeTransport{name: "smtp", err: Permanent(fmt.Errorf("%w: 550 no such user", ErrRecipientRejected))}
+	mockNotificationRepo := new(repomocks.MockNotificationRepository)
+	mockGuardianRepo := new(repomocks.MockGuardianRepository)
+	mockNotificationRepo.On("RecordNotificationDelivery", mock.Anything, notificationID, mock.Anything).Return(nil).Once()
+	mockNotificationRepo.On("RecordNotificationOutcome", mock.Anything, mock.MatchedBy(func(event *models.DeliveryOutcomeEvent) bool {
+		return event.Outcome == models.DeliveryOutcomeBounced && event.Recipient == email && event.Provider == "smtp"
+	})).Return(&models.OutcomeNotification{ID: notificationID, GuardianID: &guardianID}, nil).Once()
+	mockGuardianRepo.On("DisableGuardianNotificationChannel", mock.Anything, guardianID, models.NotificationTypeEmail).Return(nil).Once()
+
+	worker := NewWorker(queue, mockNotificationRepo, map[models.NotificationType]Transport{
+		models.NotificationTypeEmail: transport,
+	}, NewOutcomeRecorder(mockNotificationRepo, mockGuardianRepo), 3)
+
+	_, err = worker.Poll(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"r1"}, queue.deleted)
+	mockNotificationRepo.AssertExpectations(t)
+	mockGuardianRepo.AssertExpectations(t)
+}
+
+func TestFileTransport_WritesJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	transport := NewFileTransport(&buf)
+	email := "parent@example.com"
+
+	for range 2 {
+		id, err := transport.Send(context.Background(), &models.NotificationMessage{
+			NotificationType: models.NotificationTypeEmail,
+			RecipientEmail:   &email,
+			Body:             "hello",
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, id)
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	var record fileRecord
+	require.NoError(t, json.Unmarshal(lines[0], &record))
+	assert.Equal(t, "hello", record.Message.Body)
+	assert.Equal(t, email, *record.Message.RecipientEmail)
+}
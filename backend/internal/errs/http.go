@@ -50,6 +50,10 @@ func Conflict(title string, withKey string, withValue any) HTTPError {
 	return NewHTTPError(http.StatusConflict, fmt.Errorf("conflict: %s with %s='%s' already exists", title, withKey, withValue))
 }
 
+func TooManyRequests(msg string) HTTPError {
+	return NewHTTPError(http.StatusTooManyRequests, errors.New(msg))
+}
+
 func InvalidRequestData(errors map[string]string) HTTPError {
 	return HTTPError{
 		Code:    http.StatusUnprocessableEntity,
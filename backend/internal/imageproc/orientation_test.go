@@ -0,0 +1,45 @@
+package imageproc
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyOrientation(t *testing.T) {
+	// 3×2 with a red top-left pixel
+	src := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	src.SetNRGBA(0, 0, color.NRGBA{R: 255, A: 255})
+
+	tests := []struct {
+		orientation int
+		w, h        int
+		red         image.Point
+	}{
+		{1, 3, 2, image.Pt(0, 0)},
+		{2, 3, 2, image.Pt(2, 0)},
+		{3, 3, 2, image.Pt(2, 1)},
+		{4, 3, 2, image.Pt(0, 1)},
+		{5, 2, 3, image.Pt(0, 0)},
+		{6, 2, 3, image.Pt(1, 0)},
+		{7, 2, 3, image.Pt(1, 2)},
+		{8, 2, 3, image.Pt(0, 2)},
+	}
+
+	for _, tt := range tests {
+		out := applyOrientation(src, tt.orientation)
+		assert.Equal(t, tt.w, out.Bounds().Dx(), "orientation %d", tt.orientation)
+		assert.Equal(t, tt.h, out.Bounds().Dy(), "orientation %d", tt.orientation)
+		r, _, _, _ := out.At(tt.red.X, tt.red.Y).RGBA()
+		assert.Equal(t, uint32(0xFFFF), r, "orientation %d", tt.orientation)
+	}
+}
+
+func TestJpegOrientation_Malformed(t *testing.T) {
+	assert.Equal(t, 1, jpegOrientation(nil))
+	assert.Equal(t, 1, jpegOrientation([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0xFF, 0xFF}))
+	// an IFD offset pointing past the segment
+	assert.Equal(t, 1, exifOrientation([]byte("MM\x00\x2a\x00\x00\xff\xff")))
+}
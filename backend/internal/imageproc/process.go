@@ -0,0 +1,188 @@
+package imageproc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	originalJPEGQuality  = 90
+	renditionJPEGQuality = 82
+	renditionWebPQuality = 80
+)
+
+// ErrInvalidImage is wrapped by every error caused by the upload itself rather than by
+// the server, so handlers can answer with a 400
+var ErrInvalidImage = errors.New("invalid image")
+
+func invalid(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidImage, fmt.Sprintf(format, args...))
+}
+
+// Image is one encoded file ready to store
+type Image struct {
+	Data   []byte
+	Format Format
+	Width  int
+	Height int
+}
+
+// RenditionImage is an encoded rendition along with the rendition it was made for
+type RenditionImage struct {
+	Rendition
+	Image
+}
+
+// Processed is a validated upload: the original re-encoded without metadata, and its
+// renditions
+type Processed struct {
+	Original   Image
+	Renditions []RenditionImage
+}
+
+// Process validates an upload against the profile and prepares it for storage. The
+// format comes from the magic bytes, the size and dimensions are checked before the image
+// is decoded, and everything is re-encoded from the decoded pixels, which drops EXIF
+// (including GPS), XMP and ICC metadata. JPEG orientation is applied first so the output
+// is upright.
+//
+// The original keeps its dimensions and is stored as PNG when it has transparency, JPEG
+// otherwise. Each rendition is made as JPEG, drawn on white when the image has
+// transparency, and as lossy WebP, which keeps it. Renditions are never larger than the
+// original.
+func Process(data []byte, profile Profile) (*Processed, error) {
+	if len(data) == 0 {
+		return nil, invalid("file is empty")
+	}
+	if len(data) > profile.MaxBytes {
+		return nil, invalid("file is larger than %d MB", profile.MaxBytes>>20)
+	}
+
+	format, err := detectFormat(data)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := decodeConfig(format, bytes.NewReader(data))
+	if err != nil {
+		return nil, invalid("could not read %s header", format)
+	}
+	if config.Width*config.Height > profile.MaxPixels {
+		return nil, invalid("image is %dx%d, which is more than %d megapixels", config.Width, config.Height, profile.MaxPixels/1_000_000)
+	}
+	if min(config.Width, config.Height) < profile.MinSize {
+		return nil, invalid("image is %dx%d, but must be at least %dpx on each side", config.Width, config.Height, profile.MinSize)
+	}
+	if max(config.Width, config.Height) > profile.MaxSize {
+		return nil, invalid("image is %dx%d, but must be at most %dpx on each side", config.Width, config.Height, profile.MaxSize)
+	}
+
+	img, err := decode(format, bytes.NewReader(data))
+	if err != nil {
+		return nil, invalid("could not decode %s: %v", format, err)
+	}
+	if format == FormatJPEG {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	original, err := encodeOriginal(img)
+	if err != nil {
+		return nil, err
+	}
+
+	processed := &Processed{Original: *original}
+	for _, rendition := range profile.Renditions {
+		resized := resize(img, rendition.MaxSize)
+
+		jpg, err := encode(flatten(resized), FormatJPEG, renditionJPEGQuality)
+		if err != nil {
+			return nil, err
+		}
+		webp, err := encode(resized, FormatWebP, renditionWebPQuality)
+		if err != nil {
+			return nil, err
+		}
+		processed.Renditions = append(processed.Renditions,
+			RenditionImage{Rendition: rendition, Image: *jpg},
+			RenditionImage{Rendition: rendition, Image: *webp},
+		)
+	}
+
+	return processed, nil
+}
+
+func encodeOriginal(img image.Image) (*Image, error) {
+	if isOpaque(img) {
+		return encode(img, FormatJPEG, originalJPEGQuality)
+	}
+	return encode(img, FormatPNG, 0)
+}
+
+func encode(img image.Image, format Format, quality int) (*Image, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case FormatJPEG:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+	case FormatPNG:
+		err = png.Encode(&buf, img)
+	case FormatWebP:
+		err = encodeWebP(&buf, img, quality)
+	default:
+		err = fmt.Errorf("unsupported format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", format, err)
+	}
+
+	b := img.Bounds()
+	return &Image{Data: buf.Bytes(), Format: format, Width: b.Dx(), Height: b.Dy()}, nil
+}
+
+// resize scales the image so its longer side is at most maxSize, keeping the aspect ratio
+func resize(img image.Image, maxSize int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if max(w, h) <= maxSize {
+		return img
+	}
+
+	if w >= h {
+		h = max(1, h*maxSize/w)
+		w = maxSize
+	} else {
+		w = max(1, w*maxSize/h)
+		h = maxSize
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
+
+// flatten draws the image on white, since JPEG has no transparency
+func flatten(img image.Image) image.Image {
+	if isOpaque(img) {
+		return img
+	}
+
+	b := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Over)
+	return dst
+}
+
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
@@ -0,0 +1,216 @@
+package imageproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/webp"
+)
+
+var testProfile = Profile{
+	MaxBytes:  1 << 20,
+	MinSize:   16,
+	MaxSize:   2000,
+	MaxPixels: 2_000_000,
+	Renditions: []Rendition{
+		{Name: "small", MaxSize: 50},
+		{Name: "large", MaxSize: 500},
+	},
+}
+
+// testImage is a blue w×h image with a red top-left corner, so tests can tell which way
+// up it is
+func testImage(w, h int, alpha uint8) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.SetNRGBA(x, y, color.NRGBA{B: 255, A: alpha})
+		}
+	}
+	for y := range min(h, 16) {
+		for x := range min(w, 16) {
+			img.SetNRGBA(x, y, color.NRGBA{R: 255, A: alpha})
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}))
+	return buf.Bytes()
+}
+
+// withExif adds an APP1 segment holding the orientation and a GPS IFD pointer, the way
+// phone cameras write them
+func withExif(t *testing.T, data []byte, orientation uint16) []byte {
+	tiff := new(bytes.Buffer)
+	tiff.WriteString("MM")
+	_ = binary.Write(tiff, binary.BigEndian, uint16(42))
+	_ = binary.Write(tiff, binary.BigEndian, uint32(8))
+	_ = binary.Write(tiff, binary.BigEndian, uint16(2))
+	// orientation, SHORT, count 1
+	_ = binary.Write(tiff, binary.BigEndian, []uint16{0x0112, 3})
+	_ = binary.Write(tiff, binary.BigEndian, uint32(1))
+	_ = binary.Write(tiff, binary.BigEndian, []uint16{orientation, 0})
+	// GPS IFD pointer, LONG, count 1
+	_ = binary.Write(tiff, binary.BigEndian, []uint16{0x8825, 4})
+	_ = binary.Write(tiff, binary.BigEndian, []uint32{1, 0})
+	_ = binary.Write(tiff, binary.BigEndian, uint32(0))
+	tiff.WriteString("GPS 13.7563N 100.5018E")
+
+	segment := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	require.Equal(t, []byte{0xFF, 0xD8}, data[:2])
+	out := append([]byte{0xFF, 0xD8}, app1...)
+	return append(out, data[2:]...)
+}
+
+func TestProcess(t *testing.T) {
+	processed, err := Process(encodePNG(t, testImage(800, 400, 255)), testProfile)
+	require.NoError(t, err)
+
+	// an opaque PNG is stored as JPEG at its own size
+	assert.Equal(t, FormatJPEG, processed.Original.Format)
+	assert.Equal(t, 800, processed.Original.Width)
+	assert.Equal(t, 400, processed.Original.Height)
+	_, err = jpeg.Decode(bytes.NewReader(processed.Original.Data))
+	require.NoError(t, err)
+
+	require.Len(t, processed.Renditions, 4)
+	want := []struct {
+		name   string
+		format Format
+		w, h   int
+	}{
+		{"small", FormatJPEG, 50, 25},
+		{"small", FormatWebP, 50, 25},
+		{"large", FormatJPEG, 500, 250},
+		{"large", FormatWebP, 500, 250},
+	}
+	for i, w := range want {
+		rendition := processed.Renditions[i]
+		assert.Equal(t, w.name, rendition.Name)
+		assert.Equal(t, w.format, rendition.Format)
+
+		decoded, err := decode(rendition.Format, bytes.NewReader(rendition.Data))
+		require.NoError(t, err)
+		assert.Equal(t, w.w, decoded.Bounds().Dx(), "%s %s", w.name, w.format)
+		assert.Equal(t, w.h, decoded.Bounds().Dy(), "%s %s", w.name, w.format)
+	}
+}
+
+func TestProcess_NoUpscale(t *testing.T) {
+	processed, err := Process(encodePNG(t, testImage(40, 100, 255)), testProfile)
+	require.NoError(t, err)
+
+	for _, rendition := range processed.Renditions {
+		if rendition.Name == "large" {
+			assert.Equal(t, 40, rendition.Width)
+			assert.Equal(t, 100, rendition.Height)
+		} else {
+			assert.Equal(t, 20, rendition.Width)
+			assert.Equal(t, 50, rendition.Height)
+		}
+	}
+}
+
+func TestProcess_Transparency(t *testing.T) {
+	processed, err := Process(encodePNG(t, testImage(100, 100, 0)), testProfile)
+	require.NoError(t, err)
+
+	// the original keeps its transparency
+	assert.Equal(t, FormatPNG, processed.Original.Format)
+
+	// and the JPEG renditions are drawn on white
+	jpg, err := jpeg.Decode(bytes.NewReader(processed.Renditions[0].Data))
+	require.NoError(t, err)
+	r, g, b, _ := jpg.At(25, 25).RGBA()
+	assert.Greater(t, r>>8, uint32(240))
+	assert.Greater(t, g>>8, uint32(240))
+	assert.Greater(t, b>>8, uint32(240))
+
+	// while the WebP ones stay transparent
+	webpImage, err := webp.Decode(bytes.NewReader(processed.Renditions[1].Data))
+	require.NoError(t, err)
+	_, _, _, a := webpImage.At(25, 25).RGBA()
+	assert.Zero(t, a)
+}
+
+func TestProcess_StripsExifAndAppliesOrientation(t *testing.T) {
+	// stored sideways, to be turned 90° clockwise
+	data := withExif(t, encodeJPEG(t, testImage(200, 100, 255)), 6)
+	require.Equal(t, 6, jpegOrientation(data))
+
+	processed, err := Process(data, testProfile)
+	require.NoError(t, err)
+
+	assert.Equal(t, 100, processed.Original.Width)
+	assert.Equal(t, 200, processed.Original.Height)
+	for _, file := range append([]RenditionImage{{Image: processed.Original}}, processed.Renditions...) {
+		assert.NotContains(t, string(file.Data), "Exif")
+		assert.NotContains(t, string(file.Data), "GPS")
+	}
+
+	// the red corner moves from the top left to the top right
+	decoded, err := jpeg.Decode(bytes.NewReader(processed.Original.Data))
+	require.NoError(t, err)
+	r, _, b, _ := decoded.At(95, 4).RGBA()
+	assert.Greater(t, r, b)
+}
+
+func TestProcess_WebP(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, nativewebp.Encode(&buf, testImage(300, 300, 255), nil))
+
+	processed, err := Process(buf.Bytes(), testProfile)
+	require.NoError(t, err)
+	assert.Equal(t, 300, processed.Original.Width)
+}
+
+func TestProcess_Invalid(t *testing.T) {
+	gif := []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00")
+	truncated := encodePNG(t, testImage(100, 100, 255))[:40]
+
+	tests := []struct {
+		name    string
+		data    []byte
+		profile Profile
+		message string
+	}{
+		{name: "empty", data: nil, profile: testProfile, message: "file is empty"},
+		{name: "unsupported format", data: gif, profile: testProfile, message: "must be a JPEG, PNG or WebP image"},
+		{name: "text renamed to png", data: []byte("definitely not an image"), profile: testProfile, message: "must be a JPEG, PNG or WebP image"},
+		{name: "too many bytes", data: encodePNG(t, testImage(100, 100, 255)), profile: Profile{MaxBytes: 10}, message: "file is larger than"},
+		{name: "too small", data: encodePNG(t, testImage(100, 10, 255)), profile: testProfile, message: "must be at least 16px"},
+		{name: "too large", data: encodePNG(t, testImage(2001, 20, 255)), profile: testProfile, message: "must be at most 2000px"},
+		{name: "too many pixels", data: encodePNG(t, testImage(1500, 1500, 255)), profile: testProfile, message: "megapixels"},
+		{name: "corrupt", data: truncated, profile: testProfile, message: "could not decode png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processed, err := Process(tt.data, tt.profile)
+			require.ErrorIs(t, err, ErrInvalidImage)
+			assert.Contains(t, err.Error(), tt.message)
+			assert.Nil(t, processed)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package imageproc
+
+// Profile holds the limits and renditions for one kind of upload
+type Profile struct {
+	// MaxBytes bounds the uploaded file, before decoding
+	MaxBytes int
+	// MinSize is the smallest allowed shorter side and MaxSize the largest allowed longer side
+	MinSize int
+	MaxSize int
+	// MaxPixels is checked from the header before decoding, so a small file that claims a
+	// huge canvas is rejected without allocating it
+	MaxPixels  int
+	Renditions []Rendition
+}
+
+// Rendition is a resized copy generated on upload, as both JPEG and WebP
+type Rendition struct {
+	Name string
+	// MaxSize is the longest side in pixels
+	MaxSize int
+}
+
+var (
+	EventHeader = Profile{
+		MaxBytes:  10 << 20,
+		MinSize:   200,
+		MaxSize:   8000,
+		MaxPixels: 40_000_000,
+		Renditions: []Rendition{
+			{Name: "small", MaxSize: 480},
+			{Name: "medium", MaxSize: 960},
+			{Name: "large", MaxSize: 1600},
+		},
+	}
+
+	OrganizationPicture = Profile{
+		MaxBytes:  5 << 20,
+		MinSize:   96,
+		MaxSize:   6000,
+		MaxPixels: 25_000_000,
+		Renditions: []Rendition{
+			{Name: "small", MaxSize: 128},
+			{Name: "medium", MaxSize: 256},
+			{Name: "large", MaxSize: 512},
+		},
+	}
+
+	GuardianAvatar = Profile{
+		MaxBytes:  5 << 20,
+		MinSize:   64,
+		MaxSize:   6000,
+		MaxPixels: 25_000_000,
+		Renditions: []Rendition{
+			{Name: "small", MaxSize: 96},
+			{Name: "medium", MaxSize: 192},
+			{Name: "large", MaxSize: 384},
+		},
+	}
+
+	ReviewPhoto = Profile{
+		MaxBytes:  10 << 20,
+		MinSize:   200,
+		MaxSize:   8000,
+		MaxPixels: 40_000_000,
+		Renditions: []Rendition{
+			{Name: "small", MaxSize: 320},
+			{Name: "medium", MaxSize: 800},
+			{Name: "large", MaxSize: 1600},
+		},
+	}
+)
@@ -0,0 +1,207 @@
+package imageproc
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"skillspark/internal/models"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const originalName = "original"
+
+// renditionFormats are the formats every rendition is stored in
+var renditionFormats = []Format{FormatJPEG, FormatWebP}
+
+// Uploader stores one object and returns a presigned URL for it; s3_client.S3Interface
+// satisfies it
+type Uploader interface {
+	UploadImage(ctx context.Context, key *string, image_data []byte, contentType string) (*string, error)
+}
+
+// Deleter removes stored objects; s3_client.S3Interface satisfies it
+type Deleter interface {
+	DeleteObject(ctx context.Context, key string) error
+}
+
+// Signer presigns stored objects; s3_client.S3Interface satisfies it
+type Signer interface {
+	GeneratePresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+	GeneratePresignedURLs(ctx context.Context, keys []string, expiry time.Duration) (map[string]string, error)
+}
+
+// PresignedImage is a stored image's URL and the URLs of its renditions
+type PresignedImage struct {
+	URL        string
+	Renditions []models.ImageRendition
+}
+
+// Stored is a processed upload after it has been written to storage
+type Stored struct {
+	// Key is the original's key, which is what gets saved on the row
+	Key        string
+	URL        *string
+	Renditions []models.ImageRendition
+}
+
+// Store uploads a processed image under base. Every upload gets a new version folder,
+//
+//	<base>/<version>/original.jpg
+//	<base>/<version>/small.jpg
+//	<base>/<version>/small.webp
+//
+// so cached URLs for an earlier image never show the new one. Renditions are written
+// before the original, so a saved key always has its renditions. Once the new key is
+// saved, the image it replaced is removed with Delete.
+func Store(ctx context.Context, uploader Uploader, base string, processed *Processed) (*Stored, error) {
+	folder := base + "/" + uuid.NewString()
+
+	stored := &Stored{}
+	for _, rendition := range processed.Renditions {
+		key := renditionKey(folder, rendition.Name, rendition.Format)
+		url, err := uploader.UploadImage(ctx, &key, rendition.Data, rendition.Format.ContentType())
+		if err != nil {
+			return nil, err
+		}
+		stored.Renditions = append(stored.Renditions, newRendition(rendition.Rendition, rendition.Format, url))
+	}
+
+	stored.Key = renditionKey(folder, originalName, processed.Original.Format)
+	url, err := uploader.UploadImage(ctx, &stored.Key, processed.Original.Data, processed.Original.Format.ContentType())
+	if err != nil {
+		return nil, err
+	}
+	stored.URL = url
+
+	return stored, nil
+}
+
+// Delete removes the image stored at key and its renditions. It is called with the key an
+// upload replaced, after the new key is saved, so a failed save never loses the image in
+// use. Images uploaded before renditions existed only have the key itself.
+func Delete(ctx context.Context, deleter Deleter, profile Profile, key string) error {
+	if folder, ok := versionFolder(key); ok {
+		for _, rendition := range profile.Renditions {
+			for _, format := range renditionFormats {
+				if err := deleter.DeleteObject(ctx, renditionKey(folder, rendition.Name, format)); err != nil {
+					return fmt.Errorf("failed to delete rendition: %w", err)
+				}
+			}
+		}
+	}
+
+	if err := deleter.DeleteObject(ctx, key); err != nil {
+		return fmt.Errorf("failed to delete image: %w", err)
+	}
+	return nil
+}
+
+// PresignRenditions returns presigned URLs for the renditions of the image stored at key.
+// Images uploaded before renditions existed have none, so nil is returned for them.
+func PresignRenditions(ctx context.Context, signer Signer, profile Profile, key string, expiry time.Duration) ([]models.ImageRendition, error) {
+	keys := renditionKeys(profile, key)
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	urls, err := signer.GeneratePresignedURLs(ctx, keys, expiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign renditions: %w", err)
+	}
+
+	return renditionsFromURLs(profile, key, urls), nil
+}
+
+// PresignImages presigns many stored images and their renditions in one batch, for pages
+// that list many items. The result is keyed by the image's key; duplicates are signed once.
+func PresignImages(ctx context.Context, signer Signer, profile Profile, keys []string, expiry time.Duration) (map[string]PresignedImage, error) {
+	if len(keys) == 0 {
+		return map[string]PresignedImage{}, nil
+	}
+
+	images := make(map[string]PresignedImage, len(keys))
+	var all []string
+	for _, key := range keys {
+		if _, ok := images[key]; ok {
+			continue
+		}
+		images[key] = PresignedImage{}
+		all = append(all, key)
+		all = append(all, renditionKeys(profile, key)...)
+	}
+
+	urls, err := signer.GeneratePresignedURLs(ctx, all, expiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign images: %w", err)
+	}
+
+	for key := range images {
+		images[key] = PresignedImage{
+			URL:        urls[key],
+			Renditions: renditionsFromURLs(profile, key, urls),
+		}
+	}
+	return images, nil
+}
+
+// renditionKeys lists the keys of the renditions stored alongside key, in the order
+// renditionsFromURLs returns them. Legacy keys have none.
+func renditionKeys(profile Profile, key string) []string {
+	folder, ok := versionFolder(key)
+	if !ok {
+		return nil
+	}
+
+	var keys []string
+	for _, rendition := range profile.Renditions {
+		for _, format := range renditionFormats {
+			keys = append(keys, renditionKey(folder, rendition.Name, format))
+		}
+	}
+	return keys
+}
+
+func renditionsFromURLs(profile Profile, key string, urls map[string]string) []models.ImageRendition {
+	folder, ok := versionFolder(key)
+	if !ok {
+		return nil
+	}
+
+	var renditions []models.ImageRendition
+	for _, rendition := range profile.Renditions {
+		for _, format := range renditionFormats {
+			url := urls[renditionKey(folder, rendition.Name, format)]
+			renditions = append(renditions, newRendition(rendition, format, &url))
+		}
+	}
+	return renditions
+}
+
+// versionFolder returns the folder of an image stored by Store, or false for images
+// uploaded before renditions existed
+func versionFolder(key string) (string, bool) {
+	folder, name := path.Split(key)
+	if !strings.HasPrefix(name, originalName+".") {
+		return "", false
+	}
+	return strings.TrimSuffix(folder, "/"), true
+}
+
+func renditionKey(folder string, name string, format Format) string {
+	return folder + "/" + name + "." + format.Extension()
+}
+
+func newRendition(rendition Rendition, format Format, url *string) models.ImageRendition {
+	result := models.ImageRendition{
+		Name:    rendition.Name,
+		Format:  string(format),
+		MaxSize: rendition.MaxSize,
+	}
+	if url != nil {
+		result.URL = *url
+	}
+	return result
+}
@@ -0,0 +1,119 @@
+package imageproc
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStorage struct {
+	contentTypes map[string]string
+	batches      int
+	signed       []string
+	deleted      []string
+}
+
+func (f *fakeStorage) DeleteObject(ctx context.Context, key string) error {
+	f.deleted = append(f.deleted, key)
+	return nil
+}
+
+func (f *fakeStorage) UploadImage(ctx context.Context, key *string, image_data []byte, contentType string) (*string, error) {
+	f.contentTypes[*key] = contentType
+	url := "https://cdn.example.com/" + *key
+	return &url, nil
+}
+
+func (f *fakeStorage) GeneratePresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return "https://cdn.example.com/" + key, nil
+}
+
+func (f *fakeStorage) GeneratePresignedURLs(ctx context.Context, keys []string, expiry time.Duration) (map[string]string, error) {
+	f.signed = append(f.signed, keys...)
+	f.batches++
+	urls := map[string]string{}
+	for _, key := range keys {
+		urls[key] = "https://cdn.example.com/" + key
+	}
+	return urls, nil
+}
+
+func TestStore(t *testing.T) {
+	processed, err := Process(encodePNG(t, testImage(800, 400, 255)), testProfile)
+	require.NoError(t, err)
+
+	storage := &fakeStorage{contentTypes: map[string]string{}}
+	stored, err := Store(context.Background(), storage, "events/header-image/abc", processed)
+	require.NoError(t, err)
+
+	require.True(t, strings.HasPrefix(stored.Key, "events/header-image/abc/"))
+	require.True(t, strings.HasSuffix(stored.Key, "/original.jpg"))
+	assert.Equal(t, "https://cdn.example.com/"+stored.Key, *stored.URL)
+
+	folder := strings.TrimSuffix(stored.Key, "original.jpg")
+	assert.Equal(t, map[string]string{
+		folder + "original.jpg": "image/jpeg",
+		folder + "small.jpg":    "image/jpeg",
+		folder + "small.webp":   "image/webp",
+		folder + "large.jpg":    "image/jpeg",
+		folder + "large.webp":   "image/webp",
+	}, storage.contentTypes)
+
+	// the URLs presigned later match the ones returned by the upload
+	presigned, err := PresignRenditions(context.Background(), storage, testProfile, stored.Key, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, stored.Renditions, presigned)
+}
+
+func TestPresignRenditions_LegacyKey(t *testing.T) {
+	storage := &fakeStorage{contentTypes: map[string]string{}}
+	renditions, err := PresignRenditions(context.Background(), storage, testProfile, "events/header-image/abc", time.Hour)
+	require.NoError(t, err)
+	assert.Nil(t, renditions)
+}
+
+func TestPresignImages(t *testing.T) {
+	storage := &fakeStorage{contentTypes: map[string]string{}}
+	current := "events/header-image/a/v1/original.jpg"
+	legacy := "events/header-image/b"
+
+	images, err := PresignImages(context.Background(), storage, testProfile, []string{current, legacy, current}, time.Hour)
+	require.NoError(t, err)
+
+	// the whole page is signed in one call, without repeating keys
+	assert.Equal(t, 1, storage.batches)
+	assert.Len(t, storage.signed, 6)
+	require.Len(t, images, 2)
+
+	assert.Equal(t, "https://cdn.example.com/"+current, images[current].URL)
+	require.Len(t, images[current].Renditions, 4)
+	assert.Equal(t, "https://cdn.example.com/events/header-image/a/v1/small.jpg", images[current].Renditions[0].URL)
+	assert.Equal(t, "https://cdn.example.com/events/header-image/a/v1/small.webp", images[current].Renditions[1].URL)
+
+	assert.Equal(t, "https://cdn.example.com/"+legacy, images[legacy].URL)
+	assert.Nil(t, images[legacy].Renditions)
+}
+
+func TestDelete(t *testing.T) {
+	storage := &fakeStorage{}
+	require.NoError(t, Delete(context.Background(), storage, testProfile, "events/header-image/a/v1/original.jpg"))
+
+	// renditions go before the original
+	assert.Equal(t, []string{
+		"events/header-image/a/v1/small.jpg",
+		"events/header-image/a/v1/small.webp",
+		"events/header-image/a/v1/large.jpg",
+		"events/header-image/a/v1/large.webp",
+		"events/header-image/a/v1/original.jpg",
+	}, storage.deleted)
+}
+
+func TestDelete_LegacyKey(t *testing.T) {
+	storage := &fakeStorage{}
+	require.NoError(t, Delete(context.Background(), storage, testProfile, "events/header-image/b"))
+	assert.Equal(t, []string{"events/header-image/b"}, storage.deleted)
+}
@@ -0,0 +1,716 @@
+package imageproc
+
+// This file encodes images as lossy VP8 key frames, the bitstream inside a lossy WebP,
+// as specified in RFC 6386. It only uses what renditions need: 16x16 luma and 8x8 chroma
+// prediction, one segment, one token partition and the default token probabilities.
+
+import (
+	"image"
+)
+
+// token planes, as numbered in section 13.3
+const (
+	vp8PlaneYAfterY2 = 0
+	vp8PlaneY2       = 1
+	vp8PlaneUV       = 2
+)
+
+// whole-block prediction modes, in the order of their trees in section 11.2
+const (
+	vp8PredDC = iota
+	vp8PredV
+	vp8PredH
+	vp8PredTM
+)
+
+var (
+	// vp8Bands maps a coefficient's position to the band of probabilities it uses
+	vp8Bands = [17]int{0, 1, 2, 3, 6, 4, 5, 6, 6, 6, 6, 6, 6, 6, 6, 7, 0}
+	// vp8Zigzag is the order coefficients are written in
+	vp8Zigzag = [16]int{0, 1, 4, 8, 5, 2, 3, 6, 9, 12, 13, 10, 7, 11, 14, 15}
+	// vp8ExtraBitsProb are the probabilities of the extra bits of DCT_CAT3 to DCT_CAT6
+	vp8ExtraBitsProb = [4][]uint8{
+		{173, 148, 140},
+		{176, 155, 140, 135},
+		{180, 157, 141, 134, 130},
+		{254, 254, 243, 230, 196, 177, 153, 140, 133, 130, 129},
+	}
+)
+
+// vp8Quant is the DC and AC quantizer step of one kind of block
+type vp8Quant [2]int32
+
+// vp8Context holds whether the blocks bordering a macroblock had nonzero coefficients,
+// which chooses the probabilities of their neighbours' first token
+type vp8Context struct {
+	y    [4]uint8
+	u, v [2]uint8
+	y2   uint8
+}
+
+type vp8Macroblock struct {
+	yMode, uvMode int
+	skip          bool
+}
+
+type vp8Encoder struct {
+	width, height int
+	mbw, mbh      int
+
+	// the source planes and their reconstruction as the decoder will see it, padded to
+	// whole macroblocks
+	y, u, v           []uint8
+	ry, ru, rv        []uint8
+	yStride, uvStride int
+
+	qi         int
+	y1, y2, uv vp8Quant
+
+	top         []vp8Context
+	left        vp8Context
+	macroblocks []vp8Macroblock
+	tokens      boolEncoder
+}
+
+// encodeVP8 returns img as a VP8 key frame, from the frame tag through the token
+// partition. Quality runs from 0 to 100, like JPEG's.
+func encodeVP8(img *image.NRGBA, quality int) []byte {
+	e := newVP8Encoder(img)
+	e.setQuant(min(127, max(0, (100-quality)*127/100)))
+
+	for mby := 0; mby < e.mbh; mby++ {
+		e.left = vp8Context{}
+		for mbx := 0; mbx < e.mbw; mbx++ {
+			e.encodeMacroblock(mbx, mby)
+		}
+	}
+
+	first := e.header()
+	tokens := e.tokens.finish()
+
+	// a key frame, version 0, that is shown
+	tag := uint32(len(first))<<5 | 1<<4
+	frame := []byte{
+		byte(tag), byte(tag >> 8), byte(tag >> 16),
+		0x9d, 0x01, 0x2a,
+		byte(e.width), byte(e.width >> 8),
+		byte(e.height), byte(e.height >> 8),
+	}
+	frame = append(frame, first...)
+	return append(frame, tokens...)
+}
+
+// newVP8Encoder converts img to BT.601 YCbCr with 4:2:0 chroma, repeating the last row
+// and column to fill whole macroblocks
+func newVP8Encoder(img *image.NRGBA) *vp8Encoder {
+	b := img.Rect
+	e := &vp8Encoder{width: b.Dx(), height: b.Dy()}
+	e.mbw, e.mbh = (e.width+15)/16, (e.height+15)/16
+	e.yStride, e.uvStride = 16*e.mbw, 8*e.mbw
+	e.y = make([]uint8, e.yStride*16*e.mbh)
+	e.u = make([]uint8, e.uvStride*8*e.mbh)
+	e.v = make([]uint8, e.uvStride*8*e.mbh)
+	e.ry = make([]uint8, len(e.y))
+	e.ru = make([]uint8, len(e.u))
+	e.rv = make([]uint8, len(e.v))
+	e.top = make([]vp8Context, e.mbw)
+
+	rgb := func(x, y int) (int32, int32, int32) {
+		p := img.PixOffset(b.Min.X+min(x, e.width-1), b.Min.Y+min(y, e.height-1))
+		return int32(img.Pix[p]), int32(img.Pix[p+1]), int32(img.Pix[p+2])
+	}
+
+	for y := 0; y < 16*e.mbh; y++ {
+		for x := 0; x < e.yStride; x++ {
+			r, g, b := rgb(x, y)
+			e.y[y*e.yStride+x] = uint8((16839*r + 33059*g + 6420*b + 16<<16 + 1<<15) >> 16)
+		}
+	}
+	for y := 0; y < 8*e.mbh; y++ {
+		for x := 0; x < e.uvStride; x++ {
+			// chroma is taken from the sum of the four pixels it covers
+			var r, g, b int32
+			for _, d := range [4][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+				pr, pg, pb := rgb(2*x+d[0], 2*y+d[1])
+				r, g, b = r+pr, g+pg, b+pb
+			}
+			e.u[y*e.uvStride+x] = vp8ClipUV(-9719*r - 19081*g + 28800*b)
+			e.v[y*e.uvStride+x] = vp8ClipUV(28800*r - 24116*g - 4684*b)
+		}
+	}
+	return e
+}
+
+func vp8ClipUV(uv int32) uint8 {
+	return uint8(min(255, max(0, (uv+1<<17+128<<18)>>18)))
+}
+
+// setQuant sets the quantizer steps for index qi, as the decoder derives them in section
+// 14.1
+func (e *vp8Encoder) setQuant(qi int) {
+	e.qi = qi
+	e.y1 = vp8Quant{int32(vp8QuantDC[qi]), int32(vp8QuantAC[qi])}
+	e.y2 = vp8Quant{2 * int32(vp8QuantDC[qi]), max(8, int32(vp8QuantAC[qi])*155/100)}
+	e.uv = vp8Quant{int32(vp8QuantDC[min(qi, 117)]), int32(vp8QuantAC[qi])}
+}
+
+func (e *vp8Encoder) encodeMacroblock(mbx, mby int) {
+	var (
+		mb      vp8Macroblock
+		yLevels [16][16]int32
+		y2      [16]int32
+		uLevels [4][16]int32
+		vLevels [4][16]int32
+	)
+
+	// luma: the 16 DC coefficients go through the second-order transform (Y2)
+	x, y := 16*mbx, 16*mby
+	var pred [256]uint8
+	mb.yMode = vp8BestMode(pred[:], 16, e.y, e.ry, e.yStride, x, y)
+	var dcs [16]int32
+	for i := range 16 {
+		bx, by := x+4*(i%4), y+4*(i/4)
+		coeffs := vp8ForwardDCT(e.y, pred[:], e.yStride, 16, bx, by, bx-x, by-y)
+		dcs[i] = coeffs[0]
+		for j := 1; j < 16; j++ {
+			yLevels[i][j] = vp8QuantizeCoeff(coeffs[j], e.y1[1])
+		}
+	}
+	wht := vp8ForwardWHT(dcs)
+	for i := range 16 {
+		y2[i] = vp8QuantizeCoeff(wht[i], e.y2[min(i, 1)])
+	}
+	dcs = vp8InverseWHT(vp8Dequantize(&y2, e.y2))
+	for i := range 16 {
+		bx, by := x+4*(i%4), y+4*(i/4)
+		coeffs := vp8Dequantize(&yLevels[i], e.y1)
+		coeffs[0] = dcs[i]
+		vp8Reconstruct(e.ry, e.yStride, bx, by, pred[(by-y)*16+bx-x:], 16, &coeffs)
+	}
+
+	// chroma: both planes use the same mode
+	x, y = 8*mbx, 8*mby
+	var uPred, vPred [64]uint8
+	mb.uvMode = vp8BestChromaMode(uPred[:], vPred[:], e, x, y)
+	for i := range 4 {
+		bx, by := x+4*(i%2), y+4*(i/2)
+		for _, p := range []struct {
+			src, rec []uint8
+			pred     []uint8
+			levels   *[16]int32
+		}{
+			{e.u, e.ru, uPred[:], &uLevels[i]},
+			{e.v, e.rv, vPred[:], &vLevels[i]},
+		} {
+			coeffs := vp8ForwardDCT(p.src, p.pred, e.uvStride, 8, bx, by, bx-x, by-y)
+			for j := range 16 {
+				p.levels[j] = vp8QuantizeCoeff(coeffs[j], e.uv[min(j, 1)])
+			}
+			dq := vp8Dequantize(p.levels, e.uv)
+			vp8Reconstruct(p.rec, e.uvStride, bx, by, p.pred[(by-y)*8+bx-x:], 8, &dq)
+		}
+	}
+
+	mb.skip = vp8AllZero(y2) && vp8AllZero(yLevels[:]...) && vp8AllZero(uLevels[:]...) && vp8AllZero(vLevels[:]...)
+	e.macroblocks = append(e.macroblocks, mb)
+
+	top, left := &e.top[mbx], &e.left
+	if mb.skip {
+		*top, *left = vp8Context{}, vp8Context{}
+		return
+	}
+
+	nz := e.putCoeffs(vp8PlaneY2, left.y2+top.y2, &y2, 0)
+	left.y2, top.y2 = nz, nz
+	for i := range 16 {
+		bx, by := i%4, i/4
+		nz := e.putCoeffs(vp8PlaneYAfterY2, left.y[by]+top.y[bx], &yLevels[i], 1)
+		left.y[by], top.y[bx] = nz, nz
+	}
+	for i := range 4 {
+		bx, by := i%2, i/2
+		nz := e.putCoeffs(vp8PlaneUV, left.u[by]+top.u[bx], &uLevels[i], 0)
+		left.u[by], top.u[bx] = nz, nz
+	}
+	for i := range 4 {
+		bx, by := i%2, i/2
+		nz := e.putCoeffs(vp8PlaneUV, left.v[by]+top.v[bx], &vLevels[i], 0)
+		left.v[by], top.v[bx] = nz, nz
+	}
+}
+
+// vp8BestMode fills pred with the prediction of the n×n block at (x, y) that is closest
+// to the source, and returns its mode
+func vp8BestMode(pred []uint8, n int, src, rec []uint8, stride, x, y int) int {
+	best, bestErr := 0, -1
+	candidate := make([]uint8, n*n)
+	for mode := vp8PredDC; mode <= vp8PredTM; mode++ {
+		vp8Predict(candidate, n, mode, rec, stride, x, y)
+		if err := vp8SquaredError(candidate, n, src, stride, x, y); bestErr < 0 || err < bestErr {
+			best, bestErr = mode, err
+			copy(pred, candidate)
+		}
+	}
+	return best
+}
+
+func vp8BestChromaMode(uPred, vPred []uint8, e *vp8Encoder, x, y int) int {
+	best, bestErr := 0, -1
+	u, v := make([]uint8, 64), make([]uint8, 64)
+	for mode := vp8PredDC; mode <= vp8PredTM; mode++ {
+		vp8Predict(u, 8, mode, e.ru, e.uvStride, x, y)
+		vp8Predict(v, 8, mode, e.rv, e.uvStride, x, y)
+		err := vp8SquaredError(u, 8, e.u, e.uvStride, x, y) + vp8SquaredError(v, 8, e.v, e.uvStride, x, y)
+		if bestErr < 0 || err < bestErr {
+			best, bestErr = mode, err
+			copy(uPred, u)
+			copy(vPred, v)
+		}
+	}
+	return best
+}
+
+// vp8Predict fills pred with the n×n prediction of the block at (x, y) from the
+// reconstructed pixels above and left of it (section 12.2). Outside the frame the row
+// above reads as 127 and the column to the left as 129.
+func vp8Predict(pred []uint8, n, mode int, rec []uint8, stride, x, y int) {
+	top := func(i int) int32 {
+		if y == 0 {
+			return 127
+		}
+		return int32(rec[(y-1)*stride+x+i])
+	}
+	left := func(j int) int32 {
+		if x == 0 {
+			return 129
+		}
+		return int32(rec[(y+j)*stride+x-1])
+	}
+
+	switch mode {
+	case vp8PredDC:
+		// only the edges inside the frame count, and neither gives 128
+		shift := 3
+		if n == 16 {
+			shift = 4
+		}
+		var sum, count int32
+		if y > 0 {
+			for i := range n {
+				sum += top(i)
+			}
+			count++
+		}
+		if x > 0 {
+			for j := range n {
+				sum += left(j)
+			}
+			count++
+		}
+		dc := int32(128)
+		if count > 0 {
+			s := shift + int(count) - 1
+			dc = (sum + 1<<(s-1)) >> s
+		}
+		for i := range pred[:n*n] {
+			pred[i] = uint8(dc)
+		}
+	case vp8PredV:
+		for j := range n {
+			for i := range n {
+				pred[j*n+i] = uint8(top(i))
+			}
+		}
+	case vp8PredH:
+		for j := range n {
+			for i := range n {
+				pred[j*n+i] = uint8(left(j))
+			}
+		}
+	case vp8PredTM:
+		corner := int32(127)
+		if y > 0 {
+			corner = 129
+			if x > 0 {
+				corner = int32(rec[(y-1)*stride+x-1])
+			}
+		}
+		for j := range n {
+			for i := range n {
+				pred[j*n+i] = vp8Clip(left(j) + top(i) - corner)
+			}
+		}
+	}
+}
+
+func vp8SquaredError(pred []uint8, n int, src []uint8, stride, x, y int) int {
+	sum := 0
+	for j := range n {
+		for i := range n {
+			d := int(src[(y+j)*stride+x+i]) - int(pred[j*n+i])
+			sum += d * d
+		}
+	}
+	return sum
+}
+
+// vp8ForwardDCT transforms the difference between the 4×4 block of src at (x, y) and its
+// prediction, found at (px, py) in the predStride-wide pred
+func vp8ForwardDCT(src, pred []uint8, stride, predStride, x, y, px, py int) [16]int32 {
+	var tmp, out [16]int32
+	for j := range 4 {
+		var d [4]int32
+		for i := range 4 {
+			d[i] = int32(src[(y+j)*stride+x+i]) - int32(pred[(py+j)*predStride+px+i])
+		}
+		a1 := (d[0] + d[3]) * 8
+		b1 := (d[1] + d[2]) * 8
+		c1 := (d[1] - d[2]) * 8
+		d1 := (d[0] - d[3]) * 8
+		tmp[j*4+0] = a1 + b1
+		tmp[j*4+2] = a1 - b1
+		tmp[j*4+1] = (c1*2217 + d1*5352 + 14500) >> 12
+		tmp[j*4+3] = (d1*2217 - c1*5352 + 7500) >> 12
+	}
+	for i := range 4 {
+		a1 := tmp[i] + tmp[12+i]
+		b1 := tmp[4+i] + tmp[8+i]
+		c1 := tmp[4+i] - tmp[8+i]
+		d1 := tmp[i] - tmp[12+i]
+		out[i] = (a1 + b1 + 7) >> 4
+		out[8+i] = (a1 - b1 + 7) >> 4
+		out[4+i] = (c1*2217 + d1*5352 + 12000) >> 16
+		if d1 != 0 {
+			out[4+i]++
+		}
+		out[12+i] = (d1*2217 - c1*5352 + 51000) >> 16
+	}
+	return out
+}
+
+// vp8ForwardWHT transforms the DC coefficients of the 16 luma blocks, in raster order
+func vp8ForwardWHT(dcs [16]int32) [16]int32 {
+	var tmp, out [16]int32
+	for j := range 4 {
+		in := dcs[j*4 : j*4+4]
+		a1 := (in[0] + in[2]) * 4
+		d1 := (in[1] + in[3]) * 4
+		c1 := (in[1] - in[3]) * 4
+		b1 := (in[0] - in[2]) * 4
+		tmp[j*4+0] = a1 + d1
+		if a1 != 0 {
+			tmp[j*4+0]++
+		}
+		tmp[j*4+1] = b1 + c1
+		tmp[j*4+2] = b1 - c1
+		tmp[j*4+3] = a1 - d1
+	}
+	for i := range 4 {
+		a1 := tmp[i] + tmp[8+i]
+		d1 := tmp[4+i] + tmp[12+i]
+		c1 := tmp[4+i] - tmp[12+i]
+		b1 := tmp[i] - tmp[8+i]
+		for k, v := range [4]int32{a1 + d1, b1 + c1, b1 - c1, a1 - d1} {
+			if v < 0 {
+				v++
+			}
+			out[4*k+i] = (v + 3) >> 3
+		}
+	}
+	return out
+}
+
+// vp8InverseWHT is the decoder's inverse of vp8ForwardWHT (section 14.3)
+func vp8InverseWHT(in [16]int32) [16]int32 {
+	var m, out [16]int32
+	for i := range 4 {
+		a0 := in[i] + in[12+i]
+		a1 := in[4+i] + in[8+i]
+		a2 := in[4+i] - in[8+i]
+		a3 := in[i] - in[12+i]
+		m[i] = a0 + a1
+		m[8+i] = a0 - a1
+		m[4+i] = a3 + a2
+		m[12+i] = a3 - a2
+	}
+	for i := range 4 {
+		dc := m[i*4] + 3
+		a0 := dc + m[i*4+3]
+		a1 := m[i*4+1] + m[i*4+2]
+		a2 := m[i*4+1] - m[i*4+2]
+		a3 := dc - m[i*4+3]
+		out[i*4+0] = int32(int16((a0 + a1) >> 3))
+		out[i*4+1] = int32(int16((a3 + a2) >> 3))
+		out[i*4+2] = int32(int16((a0 - a1) >> 3))
+		out[i*4+3] = int32(int16((a3 - a2) >> 3))
+	}
+	return out
+}
+
+// vp8Reconstruct writes the prediction plus the inverse transform of coeffs to the 4×4
+// block of rec at (x, y), exactly as the decoder does (section 14.3)
+func vp8Reconstruct(rec []uint8, stride, x, y int, pred []uint8, predStride int, coeffs *[16]int32) {
+	const (
+		c1 = 85627 // 65536 * cos(pi/8) * sqrt(2)
+		c2 = 35468 // 65536 * sin(pi/8) * sqrt(2)
+	)
+	var m [4][4]int32
+	for i := range 4 {
+		a := coeffs[i] + coeffs[8+i]
+		b := coeffs[i] - coeffs[8+i]
+		c := (coeffs[4+i]*c2)>>16 - (coeffs[12+i]*c1)>>16
+		d := (coeffs[4+i]*c1)>>16 + (coeffs[12+i]*c2)>>16
+		m[i] = [4]int32{a + d, b + c, b - c, a - d}
+	}
+	for j := range 4 {
+		dc := m[0][j] + 4
+		a := dc + m[2][j]
+		b := dc - m[2][j]
+		c := (m[1][j]*c2)>>16 - (m[3][j]*c1)>>16
+		d := (m[1][j]*c1)>>16 + (m[3][j]*c2)>>16
+		for i, v := range [4]int32{a + d, b + c, b - c, a - d} {
+			rec[(y+j)*stride+x+i] = vp8Clip(int32(pred[j*predStride+i]) + v>>3)
+		}
+	}
+}
+
+// vp8QuantizeCoeff rounds a coefficient to a multiple of the step q, limited so the
+// decoder's 16-bit dequantized value can't overflow
+func vp8QuantizeCoeff(c, q int32) int32 {
+	limit := min(2048, 32767/q)
+	if c < 0 {
+		return -min(limit, (-c+q/2)/q)
+	}
+	return min(limit, (c+q/2)/q)
+}
+
+func vp8Dequantize(levels *[16]int32, q vp8Quant) [16]int32 {
+	var coeffs [16]int32
+	for i, level := range levels {
+		coeffs[i] = level * q[min(i, 1)]
+	}
+	return coeffs
+}
+
+func vp8AllZero(blocks ...[16]int32) bool {
+	for _, levels := range blocks {
+		for _, v := range levels {
+			if v != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func vp8Clip(v int32) uint8 {
+	return uint8(min(255, max(0, v)))
+}
+
+// putCoeffs writes one block's coefficients from first on, in zigzag order, as the tokens
+// of section 13.2. It returns whether any were nonzero, the context of the next block's
+// first token.
+func (e *vp8Encoder) putCoeffs(plane int, ctx uint8, levels *[16]int32, first int) uint8 {
+	probs := &vp8DefaultTokenProb[plane]
+	last := -1
+	for n := first; n < 16; n++ {
+		if levels[vp8Zigzag[n]] != 0 {
+			last = n
+		}
+	}
+
+	p := &probs[vp8Bands[first]][ctx]
+	if last < 0 {
+		e.tokens.putBit(false, p[0])
+		return 0
+	}
+	e.tokens.putBit(true, p[0])
+
+	for n := first; n <= last; n++ {
+		level := levels[vp8Zigzag[n]]
+		v := max(level, -level)
+		if v == 0 {
+			// a zero is never followed by the end of the block, so that isn't coded
+			e.tokens.putBit(false, p[1])
+			p = &probs[vp8Bands[n+1]][0]
+			continue
+		}
+		e.tokens.putBit(true, p[1])
+		e.putTokenValue(p, v)
+		e.tokens.putBit(level < 0, 128)
+
+		next := 2
+		if v == 1 {
+			next = 1
+		}
+		p = &probs[vp8Bands[n+1]][next]
+		if n < 15 {
+			e.tokens.putBit(n < last, p[0])
+		}
+	}
+	return 1
+}
+
+// putTokenValue writes the token for a nonzero magnitude v, and the extra bits of the
+// larger categories
+func (e *vp8Encoder) putTokenValue(p *[11]uint8, v int32) {
+	t := &e.tokens
+	if v == 1 {
+		t.putBit(false, p[2])
+		return
+	}
+	t.putBit(true, p[2])
+	switch {
+	case v <= 4:
+		t.putBit(false, p[3])
+		if v == 2 {
+			t.putBit(false, p[4])
+		} else {
+			t.putBit(true, p[4])
+			t.putBit(v == 4, p[5])
+		}
+	case v <= 10:
+		t.putBit(true, p[3])
+		t.putBit(false, p[6])
+		if v <= 6 {
+			t.putBit(false, p[7])
+			t.putBit(v == 6, 159)
+		} else {
+			t.putBit(true, p[7])
+			t.putBit((v-7)&2 != 0, 165)
+			t.putBit((v-7)&1 != 0, 145)
+		}
+	default:
+		t.putBit(true, p[3])
+		t.putBit(true, p[6])
+		cat := 3
+		switch {
+		case v < 19:
+			cat = 0
+		case v < 35:
+			cat = 1
+		case v < 67:
+			cat = 2
+		}
+		t.putBit(cat >= 2, p[8])
+		t.putBit(cat&1 != 0, p[9+cat/2])
+		extra := v - (3 + 8<<cat)
+		probs := vp8ExtraBitsProb[cat]
+		for i, prob := range probs {
+			t.putBit(extra>>(len(probs)-1-i)&1 != 0, prob)
+		}
+	}
+}
+
+// header returns the first partition: the frame header (section 9) followed by each
+// macroblock's skip flag and prediction modes
+func (e *vp8Encoder) header() []byte {
+	var h boolEncoder
+	h.putBit(false, 128) // color space
+	h.putBit(false, 128) // clamping type
+	h.putBit(false, 128) // no segmentation
+	h.putBit(false, 128) // normal loop filter
+	h.putLiteral(min(63, e.qi*2/3), 6)
+	h.putLiteral(0, 3)   // sharpness
+	h.putBit(false, 128) // no loop filter adjustments
+	h.putLiteral(0, 2)   // one token partition
+	h.putLiteral(e.qi, 7)
+	for range 5 {
+		h.putBit(false, 128) // no quantizer deltas
+	}
+	h.putBit(false, 128) // refresh_entropy_probs
+	for i := range vp8TokenUpdateProb {
+		for j := range vp8TokenUpdateProb[i] {
+			for k := range vp8TokenUpdateProb[i][j] {
+				for _, prob := range vp8TokenUpdateProb[i][j][k] {
+					h.putBit(false, prob)
+				}
+			}
+		}
+	}
+
+	coded := 0
+	for _, mb := range e.macroblocks {
+		if !mb.skip {
+			coded++
+		}
+	}
+	skipProb := uint8(min(254, max(1, coded*256/len(e.macroblocks))))
+	h.putBit(true, 128) // mb_no_coeff_skip
+	h.putLiteral(int(skipProb), 8)
+
+	for _, mb := range e.macroblocks {
+		h.putBit(mb.skip, skipProb)
+		h.putBit(true, 145) // 16x16 luma prediction
+		h.putBit(mb.yMode >= vp8PredH, 156)
+		if mb.yMode < vp8PredH {
+			h.putBit(mb.yMode == vp8PredV, 163)
+		} else {
+			h.putBit(mb.yMode == vp8PredTM, 128)
+		}
+		h.putBit(mb.uvMode != vp8PredDC, 142)
+		if mb.uvMode != vp8PredDC {
+			h.putBit(mb.uvMode != vp8PredV, 114)
+			if mb.uvMode != vp8PredV {
+				h.putBit(mb.uvMode == vp8PredTM, 183)
+			}
+		}
+	}
+	return h.finish()
+}
+
+// boolEncoder is the boolean entropy encoder of section 7.3
+type boolEncoder struct {
+	buf    []byte
+	rng    uint32
+	bottom uint32
+	// bits is how many more bits are shifted out before a byte is written
+	bits int
+}
+
+// putBit writes bit, which is false with probability prob/256
+func (e *boolEncoder) putBit(bit bool, prob uint8) {
+	if e.rng == 0 {
+		e.rng, e.bits = 255, 24
+	}
+	split := 1 + (e.rng-1)*uint32(prob)>>8
+	if bit {
+		e.bottom += split
+		e.rng -= split
+	} else {
+		e.rng = split
+	}
+	for e.rng < 128 {
+		e.rng <<= 1
+		if e.bottom&(1<<31) != 0 {
+			// carry into the bytes already written
+			i := len(e.buf) - 1
+			for ; e.buf[i] == 0xff; i-- {
+				e.buf[i] = 0
+			}
+			e.buf[i]++
+		}
+		e.bottom <<= 1
+		e.bits--
+		if e.bits == 0 {
+			e.buf = append(e.buf, byte(e.bottom>>24))
+			e.bottom &= 1<<24 - 1
+			e.bits = 8
+		}
+	}
+}
+
+// putLiteral writes the n low bits of v, most significant first, at even odds
+func (e *boolEncoder) putLiteral(v, n int) {
+	for i := n - 1; i >= 0; i-- {
+		e.putBit(v>>i&1 != 0, 128)
+	}
+}
+
+// finish pads the output so the decoder can read every bit written, and returns it
+func (e *boolEncoder) finish() []byte {
+	for range 32 {
+		e.putBit(false, 128)
+	}
+	return e.buf
+}
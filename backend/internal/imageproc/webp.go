@@ -0,0 +1,89 @@
+package imageproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"io"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+)
+
+// vp8MaxSize is the largest width or height a VP8 frame header can hold
+const vp8MaxSize = 1<<14 - 1
+
+// encodeWebP writes img as a lossy WebP. VP8 only carries color, so an image with
+// transparency gets its alpha channel in a separate chunk, compressed losslessly.
+func encodeWebP(w io.Writer, img image.Image, quality int) error {
+	b := img.Bounds()
+	if b.Dx() > vp8MaxSize || b.Dy() > vp8MaxSize {
+		return errors.New("image is too large for WebP")
+	}
+
+	nrgba := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(nrgba, nrgba.Bounds(), img, b.Min, draw.Src)
+
+	var chunks bytes.Buffer
+	if !nrgba.Opaque() {
+		alpha, err := encodeAlpha(nrgba)
+		if err != nil {
+			return err
+		}
+		// the extended header announces the alpha chunk and holds the canvas size
+		header := make([]byte, 10)
+		header[0] = 1 << 4
+		putUint24(header[4:], b.Dx()-1)
+		putUint24(header[7:], b.Dy()-1)
+		writeChunk(&chunks, "VP8X", header)
+		writeChunk(&chunks, "ALPH", alpha)
+	}
+	writeChunk(&chunks, "VP8 ", encodeVP8(nrgba, quality))
+
+	riff := make([]byte, 12, 12+chunks.Len())
+	copy(riff, "RIFF")
+	binary.LittleEndian.PutUint32(riff[4:], uint32(4+chunks.Len()))
+	copy(riff[8:], "WEBP")
+	_, err := w.Write(append(riff, chunks.Bytes()...))
+	return err
+}
+
+// encodeAlpha returns the ALPH chunk of img: its alpha values as a lossless WebP
+// bitstream, stored in the green channel and without the bitstream's own header
+func encodeAlpha(img *image.NRGBA) ([]byte, error) {
+	b := img.Bounds()
+	gray := image.NewGray(b)
+	for i := range gray.Pix {
+		gray.Pix[i] = img.Pix[4*i+3]
+	}
+
+	var buf bytes.Buffer
+	if err := nativewebp.Encode(&buf, gray, nil); err != nil {
+		return nil, err
+	}
+	// skip the RIFF and VP8L chunk headers, then the 5-byte bitstream header
+	const headerSize = 12 + 8 + 5
+	data := buf.Bytes()
+	if len(data) < headerSize {
+		return nil, errors.New("lossless alpha is truncated")
+	}
+
+	// compression method 1 (lossless), no filtering or preprocessing
+	return append([]byte{1}, data[headerSize:]...), nil
+}
+
+func writeChunk(buf *bytes.Buffer, fourCC string, data []byte) {
+	var header [8]byte
+	copy(header[:], fourCC)
+	binary.LittleEndian.PutUint32(header[4:], uint32(len(data)))
+	buf.Write(header[:])
+	buf.Write(data)
+	if len(data)%2 != 0 {
+		buf.WriteByte(0)
+	}
+}
+
+func putUint24(b []byte, v int) {
+	b[0], b[1], b[2] = byte(v), byte(v>>8), byte(v>>16)
+}
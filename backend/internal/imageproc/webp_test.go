@@ -0,0 +1,114 @@
+package imageproc
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/webp"
+)
+
+// photoImage is a w×h image with gradients, fine texture and hard edges, so every kind of
+// block and coefficient gets coded
+func photoImage(w, h int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			texture := 40 * math.Sin(float64(x)/3) * math.Cos(float64(y)/5)
+			c := color.NRGBA{
+				R: uint8(min(255, max(0, float64(x*255/w)+texture))),
+				G: uint8(min(255, max(0, float64(y*255/h)-texture))),
+				B: 128,
+				A: 255,
+			}
+			if (x/24+y/24)%2 == 0 {
+				c.B = 230
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+// psnr compares a w×h plane with the source plane it was encoded from
+func psnr(got []uint8, gotStride int, want []uint8, wantStride, w, h int) float64 {
+	var sum float64
+	for y := range h {
+		for x := range w {
+			d := float64(got[y*gotStride+x]) - float64(want[y*wantStride+x])
+			sum += d * d
+		}
+	}
+	if sum == 0 {
+		return math.Inf(1)
+	}
+	return 10 * math.Log10(255*255*float64(w*h)/sum)
+}
+
+func TestEncodeWebP(t *testing.T) {
+	// not a whole number of macroblocks, so the padding is cropped off again
+	src := photoImage(203, 117)
+
+	for _, quality := range []int{20, 80, 100} {
+		var buf bytes.Buffer
+		require.NoError(t, encodeWebP(&buf, src, quality))
+
+		decoded, err := webp.Decode(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err, "quality %d", quality)
+		ycbcr, ok := decoded.(*image.YCbCr)
+		require.True(t, ok, "an opaque image has no alpha chunk")
+		assert.Equal(t, src.Bounds(), ycbcr.Bounds())
+
+		want := newVP8Encoder(src)
+		minPSNR := 30.0
+		if quality < 50 {
+			minPSNR = 24
+		}
+		assert.Greater(t, psnr(ycbcr.Y, ycbcr.YStride, want.y, want.yStride, 203, 117), minPSNR, "luma at quality %d", quality)
+		assert.Greater(t, psnr(ycbcr.Cb, ycbcr.CStride, want.u, want.uvStride, 102, 59), minPSNR, "blue chroma at quality %d", quality)
+		assert.Greater(t, psnr(ycbcr.Cr, ycbcr.CStride, want.v, want.uvStride, 102, 59), minPSNR, "red chroma at quality %d", quality)
+	}
+}
+
+func TestEncodeWebP_SmallerAtLowerQuality(t *testing.T) {
+	src := photoImage(320, 240)
+
+	var low, high bytes.Buffer
+	require.NoError(t, encodeWebP(&low, src, 50))
+	require.NoError(t, encodeWebP(&high, src, 90))
+
+	assert.Less(t, low.Len(), high.Len())
+}
+
+func TestEncodeWebP_Alpha(t *testing.T) {
+	src := photoImage(64, 40)
+	for y := range 40 {
+		for x := range 20 {
+			src.Pix[src.PixOffset(x, y)+3] = 0
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, encodeWebP(&buf, src, 80))
+
+	decoded, err := webp.Decode(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	nycbcra, ok := decoded.(*image.NYCbCrA)
+	require.True(t, ok)
+	assert.Equal(t, src.Bounds(), nycbcra.Bounds())
+
+	// alpha is lossless
+	for y := range 40 {
+		for x := range 64 {
+			want := uint8(255)
+			if x < 20 {
+				want = 0
+			}
+			require.Equal(t, want, nycbcra.A[nycbcra.AOffset(x, y)], "alpha at %d,%d", x, y)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BroadcastTargetType is who a broadcast goes to: the guardians registered for one
+// occurrence, for any upcoming occurrence of an event, or for anything the organization runs
+type BroadcastTargetType string
+
+const (
+	BroadcastTargetEventOccurrence BroadcastTargetType = "event_occurrence"
+	BroadcastTargetEvent           BroadcastTargetType = "event"
+	BroadcastTargetOrganization    BroadcastTargetType = "organization"
+)
+
+type BroadcastStatus string
+
+const (
+	BroadcastStatusScheduled BroadcastStatus = "scheduled"
+	// BroadcastStatusSending means its notifications are being created
+	BroadcastStatusSending   BroadcastStatus = "sending"
+	BroadcastStatusSent      BroadcastStatus = "sent"
+	BroadcastStatusCancelled BroadcastStatus = "cancelled"
+)
+
+// Broadcast is a message from an organization to the families registered for its events
+type Broadcast struct {
+	ID             uuid.UUID           `json:"id" db:"id" doc:"Unique broadcast identifier"`
+	OrganizationID uuid.UUID           `json:"organization_id" db:"organization_id" doc:"ID of the sending organization"`
+	ManagerID      *uuid.UUID          `json:"manager_id,omitempty" db:"manager_id" doc:"ID of the manager who wrote the broadcast"`
+	TargetType     BroadcastTargetType `json:"target_type" db:"target_type" doc:"What the broadcast is sent to" enum:"event_occurrence,event,organization"`
+	TargetID       uuid.UUID           `json:"target_id" db:"target_id" doc:"ID of the occurrence, event or organization"`
+	SubjectEN      string              `json:"subject_en" db:"subject_en" doc:"English subject"`
+	BodyEN         string              `json:"body_en" db:"body_en" doc:"English message"`
+	SubjectTH      *string             `json:"subject_th,omitempty" db:"subject_th" doc:"Thai subject"`
+	BodyTH         *string             `json:"body_th,omitempty" db:"body_th" doc:"Thai message"`
+	ScheduledFor   time.Time           `json:"scheduled_for" db:"scheduled_for" doc:"When the broadcast is sent"`
+	Status         BroadcastStatus     `json:"status" db:"status" doc:"Broadcast status" enum:"scheduled,sending,sent,cancelled"`
+	RecipientCount *int                `json:"recipient_count,omitempty" db:"recipient_count" doc:"Number of guardians the broadcast was sent to"`
+	SentAt         *time.Time          `json:"sent_at,omitempty" db:"sent_at" doc:"When the broadcast was sent"`
+	CreatedAt      time.Time           `json:"created_at" db:"created_at" doc:"Timestamp when the broadcast was created"`
+	UpdatedAt      time.Time           `json:"updated_at" db:"updated_at" doc:"Timestamp when the broadcast was last updated"`
+}
+
+// Message returns the subject and body to send to a guardian reading lang ("en" or "th").
+// Guardians whose language the broadcast wasn't written in get the English version.
+func (b *Broadcast) Message(lang string) (subject string, body string) {
+	if lang == "th" && b.SubjectTH != nil && b.BodyTH != nil {
+		return *b.SubjectTH, *b.BodyTH
+	}
+	return b.SubjectEN, b.BodyEN
+}
+
+// BroadcastChannelStats counts a broadcast's notifications on one channel by delivery progress
+type BroadcastChannelStats struct {
+	Channel   NotificationType `json:"channel" db:"channel" doc:"Delivery channel" enum:"email,push"`
+	Pending   int              `json:"pending" db:"pending" doc:"Not sent yet"`
+	Sent      int              `json:"sent" db:"sent" doc:"Handed to the delivery queue"`
+	Skipped   int              `json:"skipped" db:"skipped" doc:"Not sent because the guardian turned the channel off"`
+	Delivered int              `json:"delivered" db:"delivered" doc:"Accepted by the email server or push service"`
+	Failed    int              `json:"failed" db:"failed" doc:"Could not be delivered"`
+	Bounced   int              `json:"bounced" db:"bounced" doc:"Rejected by the recipient's mail server or device"`
+}
+
+// BroadcastStats is how far a broadcast's delivery has got
+type BroadcastStats struct {
+	Recipients int                     `json:"recipients" doc:"Number of guardians the broadcast was sent to"`
+	Channels   []BroadcastChannelStats `json:"channels" doc:"Delivery progress per channel"`
+}
+
+// BroadcastWithStats is a broadcast with its delivery statistics
+type BroadcastWithStats struct {
+	Broadcast
+	Stats BroadcastStats `json:"stats" doc:"Delivery statistics"`
+}
+
+// CreateBroadcastData is the internal storage input for a new broadcast
+type CreateBroadcastData struct {
+	OrganizationID uuid.UUID
+	ManagerID      uuid.UUID
+	TargetType     BroadcastTargetType
+	TargetID       uuid.UUID
+	SubjectEN      string
+	BodyEN         string
+	SubjectTH      *string
+	BodyTH         *string
+	ScheduledFor   time.Time
+}
+
+type CreateBroadcastInput struct {
+	OrganizationID uuid.UUID `path:"organization_id" format:"uuid" doc:"ID of the organization"`
+	Body           struct {
+		ManagerID    uuid.UUID           `json:"manager_id" format:"uuid" doc:"ID of the manager sending the broadcast"`
+		TargetType   BroadcastTargetType `json:"target_type" enum:"event_occurrence,event,organization" doc:"Send to the registrants of an occurrence, of an event's upcoming occurrences, or of all the organization's upcoming occurrences"`
+		TargetID     *uuid.UUID          `json:"target_id,omitempty" format:"uuid" required:"false" doc:"ID of the occurrence or event; not needed for the organization"`
+		SubjectEN    string              `json:"subject_en" minLength:"1" maxLength:"120" doc:"English subject"`
+		BodyEN       string              `json:"body_en" minLength:"1" maxLength:"2000" doc:"English message"`
+		SubjectTH    *string             `json:"subject_th,omitempty" minLength:"1" maxLength:"120" required:"false" doc:"Thai subject, sent to guardians who use the app in Thai"`
+		BodyTH       *string             `json:"body_th,omitempty" minLength:"1" maxLength:"2000" required:"false" doc:"Thai message"`
+		ScheduledFor *time.Time          `json:"scheduled_for,omitempty" required:"false" doc:"When to send the broadcast; omit to send it now"`
+	}
+}
+
+type CreateBroadcastOutput struct {
+	Body Broadcast `json:"body"`
+}
+
+type GetBroadcastsInput struct {
+	OrganizationID uuid.UUID `path:"organization_id" format:"uuid" doc:"ID of the organization"`
+	Page           int       `query:"page" minimum:"1" default:"1" doc:"Page number (starts at 1)"`
+	PageSize       int       `query:"page_size" minimum:"1" maximum:"100" default:"20" doc:"Number of broadcasts per page"`
+}
+
+type GetBroadcastsOutput struct {
+	Body []Broadcast `json:"body"`
+}
+
+type GetBroadcastInput struct {
+	OrganizationID uuid.UUID `path:"organization_id" format:"uuid" doc:"ID of the organization"`
+	ID             uuid.UUID `path:"id" format:"uuid" doc:"ID of the broadcast"`
+}
+
+type GetBroadcastOutput struct {
+	Body BroadcastWithStats `json:"body"`
+}
+
+type CancelBroadcastInput struct {
+	OrganizationID uuid.UUID `path:"organization_id" format:"uuid" doc:"ID of the organization"`
+	ID             uuid.UUID `path:"id" format:"uuid" doc:"ID of the broadcast"`
+}
+
+type CancelBroadcastOutput struct {
+	Body Broadcast `json:"body"`
+}
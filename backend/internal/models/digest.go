@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DigestChild is a child with what the weekly digest needs to find activities for them
+type DigestChild struct {
+	ID        uuid.UUID `db:"id"`
+	Name      string    `db:"name"`
+	BirthYear int       `db:"birth_year"`
+	Interests []string  `db:"interests"`
+	// Latitude and Longitude are the child's school, used as where the family is; nil when
+	// the school has no location
+	Latitude  *float64 `db:"latitude"`
+	Longitude *float64 `db:"longitude"`
+}
+
+// Digest is one weekly digest sent to a guardian
+type Digest struct {
+	ID         uuid.UUID    `json:"id" db:"id"`
+	GuardianID uuid.UUID    `json:"guardian_id" db:"guardian_id"`
+	WeekStart  time.Time    `json:"week_start" db:"week_start"`
+	CreatedAt  time.Time    `json:"created_at" db:"created_at"`
+	Items      []DigestItem `json:"items" db:"-"`
+}
+
+// DigestItem is an event listed in a digest for one of the guardian's children, with how
+// often its link was followed
+type DigestItem struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	DigestID       uuid.UUID  `json:"digest_id" db:"digest_id"`
+	ChildID        *uuid.UUID `json:"child_id,omitempty" db:"child_id"`
+	EventID        uuid.UUID  `json:"event_id" db:"event_id"`
+	Position       int        `json:"position" db:"position"`
+	ClickCount     int        `json:"click_count" db:"click_count"`
+	FirstClickedAt *time.Time `json:"first_clicked_at,omitempty" db:"first_clicked_at"`
+	LastClickedAt  *time.Time `json:"last_clicked_at,omitempty" db:"last_clicked_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
+
+// CreateDigestData is the internal storage input for a digest; items keep their order.
+// IDs are chosen by the caller so the notifications can link to the items, and the
+// notifications and inbox item are stored in the same transaction as the digest.
+type CreateDigestData struct {
+	ID            uuid.UUID
+	GuardianID    uuid.UUID
+	WeekStart     time.Time
+	Items         []CreateDigestItemData
+	Notifications []CreateScheduledNotificationInput
+	InboxItem     *CreateInboxItemData
+}
+
+type CreateDigestItemData struct {
+	ID      uuid.UUID
+	ChildID uuid.UUID
+	EventID uuid.UUID
+}
+
+type OpenDigestItemInput struct {
+	ID uuid.UUID `path:"id" format:"uuid" doc:"ID of the digest item"`
+}
+
+// OpenDigestItemOutput redirects to the event in the app
+type OpenDigestItemOutput struct {
+	Status   int
+	Location string `header:"Location" doc:"App link to the event"`
+}
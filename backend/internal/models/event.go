@@ -20,6 +20,9 @@ type Event struct {
 	PresignedURL     *string   `json:"presigned_url"`
 	CreatedAt        time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
+
+	// resized copies of the header image; empty for images uploaded before renditions existed
+	HeaderImageRenditions []ImageRendition `json:"header_image_renditions,omitempty"`
 }
 
 type CreateDBBody struct {
@@ -75,7 +78,7 @@ type CreateEventFormData struct {
 	AgeRangeMin    int           `form:"age_range_min"`
 	AgeRangeMax    int           `form:"age_range_max"`
 	Category       []string      `form:"category"`
-	HeaderImage    huma.FormFile `form:"header_image" contentType:"image/png,image/jpeg"`
+	HeaderImage    huma.FormFile `form:"header_image" contentType:"image/png,image/jpeg,image/webp"`
 }
 
 type UpdateEventFormData struct {
@@ -85,7 +88,7 @@ type UpdateEventFormData struct {
 	AgeRangeMin    int           `form:"age_range_min"`
 	AgeRangeMax    int           `form:"age_range_max"`
 	Category       []string      `form:"category"`
-	HeaderImage    huma.FormFile `form:"header_image" contentType:"image/png,image/jpeg"`
+	HeaderImage    huma.FormFile `form:"header_image" contentType:"image/png,image/jpeg,image/webp"`
 }
 
 type CreateEventOutput struct {
@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"github.com/danielgtaylor/huma/v2"
 	"github.com/google/uuid"
 )
 
@@ -21,6 +22,10 @@ type Guardian struct {
 	EmailNotifications  bool      `json:"email_notifications" db:"email_notifications"`
 	CreatedAt           time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt           time.Time `json:"updated_at" db:"updated_at"`
+
+	ProfilePictureURL *string `json:"profile_picture_url,omitempty" db:"-"`
+	// resized copies of the profile picture; empty for pictures uploaded before renditions existed
+	ProfilePictureRenditions []ImageRendition `json:"profile_picture_renditions,omitempty" db:"-"`
 }
 
 type GetGuardianByIDInput struct {
@@ -80,9 +85,18 @@ type GetGuardianByIDOutput struct {
 	Body *Guardian `json:"body"`
 }
 
-type GuardianNotificationPreferences struct {
-	PushNotifications  bool `db:"push_notifications"`
-	EmailNotifications bool `db:"email_notifications"`
+// UpdateGuardianProfilePictureInput is the multipart form for uploading a guardian's avatar
+type UpdateGuardianProfilePictureInput struct {
+	ID      uuid.UUID `path:"id"`
+	RawBody huma.MultipartFormFiles[GuardianProfilePictureFormData]
+}
+
+type GuardianProfilePictureFormData struct {
+	ProfilePicture huma.FormFile `form:"profile_picture" contentType:"image/png,image/jpeg,image/webp" required:"true"`
+}
+
+type UpdateGuardianProfilePictureOutput struct {
+	Body *Guardian `json:"body"`
 }
 
 type CreateStripeCustomerInput struct {
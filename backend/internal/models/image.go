@@ -0,0 +1,10 @@
+package models
+
+// ImageRendition is a resized copy of an uploaded image. Clients should pick the smallest
+// rendition that covers the space they draw it in, preferring webp where supported.
+type ImageRendition struct {
+	Name    string `json:"name" doc:"Rendition name, e.g. small or large"`
+	Format  string `json:"format" enum:"jpeg,webp" doc:"Image format of the rendition"`
+	MaxSize int    `json:"max_size" doc:"Longest side of the rendition in pixels; smaller originals are not upscaled"`
+	URL     string `json:"url" doc:"Presigned URL of the rendition"`
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InboxLinkType is the kind of record an inbox item opens in the app
+type InboxLinkType string
+
+const (
+	InboxLinkRegistration    InboxLinkType = "registration"
+	InboxLinkEvent           InboxLinkType = "event"
+	InboxLinkEventOccurrence InboxLinkType = "event_occurrence"
+	InboxLinkReview          InboxLinkType = "review"
+	InboxLinkOrganization    InboxLinkType = "organization"
+)
+
+// InboxItem is a notification kept in a guardian's in-app inbox
+type InboxItem struct {
+	ID         uuid.UUID       `json:"id" db:"id" doc:"Unique inbox item identifier"`
+	GuardianID uuid.UUID       `json:"guardian_id" db:"guardian_id" doc:"ID of the guardian the item belongs to"`
+	Kind       string          `json:"kind" db:"kind" doc:"Kind of notification, e.g. event_reminder"`
+	Title      string          `json:"title" db:"title" doc:"Short title shown in the inbox"`
+	Body       string          `json:"body" db:"body" doc:"Notification text"`
+	LinkType   *InboxLinkType  `json:"link_type,omitempty" db:"link_type" doc:"Kind of record the item opens" enum:"registration,event,event_occurrence,review,organization"`
+	LinkID     *uuid.UUID      `json:"link_id,omitempty" db:"link_id" doc:"ID of the record the item opens"`
+	DeepLink   *string         `json:"deep_link,omitempty" db:"deep_link" doc:"App URL that opens the related record"`
+	Metadata   json.RawMessage `json:"metadata,omitempty" db:"metadata" doc:"Notification-specific data"`
+	VisibleAt  time.Time       `json:"visible_at" db:"visible_at" doc:"When the item appears in the inbox"`
+	ReadAt     *time.Time      `json:"read_at,omitempty" db:"read_at" doc:"When the guardian read the item"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at" doc:"Timestamp when the item was created"`
+}
+
+// CreateInboxItemData is the internal storage input for adding an item to a guardian's inbox
+type CreateInboxItemData struct {
+	GuardianID uuid.UUID
+	Kind       string
+	Title      string
+	Body       string
+	LinkType   *InboxLinkType
+	LinkID     *uuid.UUID
+	DeepLink   *string
+	Metadata   json.RawMessage
+	// VisibleAt holds scheduled notifications back until they are delivered; nil means now
+	VisibleAt *time.Time
+	// DedupKey keeps a notification sent again on retry to one item; nil never deduplicates
+	DedupKey *string
+}
+
+type GetInboxInput struct {
+	GuardianID uuid.UUID `path:"guardian_id" format:"uuid" doc:"ID of the guardian"`
+	UnreadOnly bool      `query:"unread_only" required:"false" doc:"Only return items that have not been read"`
+	Page       int       `query:"page" minimum:"1" default:"1" doc:"Page number (starts at 1)"`
+	PageSize   int       `query:"page_size" minimum:"1" maximum:"100" default:"20" doc:"Number of items per page"`
+}
+
+type GetInboxOutput struct {
+	Body []InboxItem `json:"body"`
+}
+
+type GetInboxUnreadCountInput struct {
+	GuardianID uuid.UUID `path:"guardian_id" format:"uuid" doc:"ID of the guardian"`
+}
+
+type GetInboxUnreadCountOutput struct {
+	Body struct {
+		UnreadCount int `json:"unread_count" doc:"Number of unread inbox items"`
+	} `json:"body"`
+}
+
+type MarkInboxItemReadInput struct {
+	GuardianID uuid.UUID `path:"guardian_id" format:"uuid" doc:"ID of the guardian"`
+	ID         uuid.UUID `path:"id" format:"uuid" doc:"ID of the inbox item"`
+}
+
+type MarkInboxItemReadOutput struct {
+	Body InboxItem `json:"body"`
+}
+
+type MarkAllInboxItemsReadInput struct {
+	GuardianID uuid.UUID `path:"guardian_id" format:"uuid" doc:"ID of the guardian"`
+}
+
+type MarkAllInboxItemsReadOutput struct {
+	Body struct {
+		Updated int `json:"updated" doc:"Number of items marked as read"`
+	} `json:"body"`
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobRunStatus string
+
+const (
+	JobRunStatusRunning         JobRunStatus = "running"
+	JobRunStatusSucceeded       JobRunStatus = "succeeded"
+	JobRunStatusPartiallyFailed JobRunStatus = "partially_failed"
+	JobRunStatusFailed          JobRunStatus = "failed"
+)
+
+type JobRunTrigger string
+
+const (
+	JobRunTriggerSchedule JobRunTrigger = "schedule"
+	JobRunTriggerManual   JobRunTrigger = "manual"
+)
+
+// JobRunItemError records why a single item (registration, notification, ...) failed in a run
+type JobRunItemError struct {
+	ItemID uuid.UUID `json:"item_id" doc:"ID of the item that failed"`
+	Error  string    `json:"error" doc:"Error message"`
+}
+
+type JobRun struct {
+	ID             uuid.UUID         `json:"id" db:"id" doc:"Unique job run identifier"`
+	JobName        string            `json:"job_name" db:"job_name" doc:"Name of the job"`
+	Trigger        JobRunTrigger     `json:"trigger" db:"trigger" doc:"What started the run" enum:"schedule,manual"`
+	DryRun         bool              `json:"dry_run" db:"dry_run" doc:"Whether the run only reported what it would do"`
+	Status         JobRunStatus      `json:"status" db:"status" doc:"Outcome of the run" enum:"running,succeeded,partially_failed,failed"`
+	ItemsProcessed int               `json:"items_processed" db:"items_processed" doc:"Number of items the run looked at"`
+	ItemsSucceeded int               `json:"items_succeeded" db:"items_succeeded" doc:"Number of items handled successfully"`
+	ItemsFailed    int               `json:"items_failed" db:"items_failed" doc:"Number of items that failed"`
+	ItemErrors     []JobRunItemError `json:"item_errors" db:"item_errors" doc:"Errors for the items that failed"`
+	Error          *string           `json:"error,omitempty" db:"error" doc:"Error that failed the whole run"`
+	ScheduledFor   *time.Time        `json:"scheduled_for,omitempty" db:"scheduled_for" doc:"Cron tick a scheduled run was for"`
+	StartedAt      time.Time         `json:"started_at" db:"started_at" doc:"Timestamp when the run started"`
+	FinishedAt     *time.Time        `json:"finished_at,omitempty" db:"finished_at" doc:"Timestamp when the run finished"`
+}
+
+// CreateJobRunData is the internal storage input for recording the start of a run
+type CreateJobRunData struct {
+	JobName string
+	Trigger JobRunTrigger
+	DryRun  bool
+}
+
+// FinishJobRunData is the internal storage input for recording the outcome of a run
+type FinishJobRunData struct {
+	ID             uuid.UUID
+	Status         JobRunStatus
+	ItemsProcessed int
+	ItemsSucceeded int
+	ItemsFailed    int
+	ItemErrors     []JobRunItemError
+	Error          *string
+}
+
+type GetAllJobRunsInput struct {
+	JobName  string `query:"job_name" required:"false" doc:"Only return runs of this job"`
+	Page     int    `query:"page" minimum:"1" default:"1" doc:"Page number (starts at 1)"`
+	PageSize int    `query:"page_size" minimum:"1" maximum:"100" default:"10" doc:"Number of items per page"`
+}
+
+type GetAllJobRunsOutput struct {
+	Body []JobRun `json:"body"`
+}
+
+type GetJobRunByIDInput struct {
+	ID uuid.UUID `path:"id" format:"uuid" doc:"Job run ID"`
+}
+
+type GetJobRunByIDOutput struct {
+	Body JobRun `json:"body"`
+}
+
+type TriggerJobInput struct {
+	JobName string `path:"job_name" enum:"capture_payments,check_push_receipts,create_payment_intents,send_broadcasts,send_scheduled_notifications,send_weekly_digest" doc:"Job to run"`
+	Body    struct {
+		DryRun bool `json:"dry_run,omitempty" required:"false" doc:"Report what the job would do without charging, cancelling or sending anything"`
+	} `json:"body"`
+}
+
+type TriggerJobOutput struct {
+	Body JobRun `json:"body"`
+}
@@ -20,8 +20,13 @@ type NotificationStatus string
 
 const (
 	NotificationStatusPending NotificationStatus = "pending"
-	NotificationStatusSent    NotificationStatus = "sent"
-	NotificationStatusFailed  NotificationStatus = "failed"
+	// NotificationStatusSent means the notification was handed to the delivery queue
+	NotificationStatusSent NotificationStatus = "sent"
+	// NotificationStatusDelivered means a transport (SMTP, Expo, ...) accepted it
+	NotificationStatusDelivered NotificationStatus = "delivered"
+	NotificationStatusFailed    NotificationStatus = "failed"
+	// NotificationStatusSkipped means the guardian turned the notification's channel off, so it was never queued
+	NotificationStatusSkipped NotificationStatus = "skipped"
 )
 
 // Notification represents a scheduled notification in the database
@@ -32,24 +37,39 @@ type Notification struct {
 	RecipientPushToken *string            `json:"recipient_push_token,omitempty" db:"recipient_push_token"`
 	Subject            *string            `json:"subject,omitempty" db:"subject"`
 	Body               string             `json:"body" db:"body"`
+	HTMLBody           *string            `json:"html_body,omitempty" db:"html_body"`
 	Metadata           json.RawMessage    `json:"metadata,omitempty" db:"metadata"`
 	ScheduledFor       time.Time          `json:"scheduled_for" db:"scheduled_for"`
 	SentAt             *time.Time         `json:"sent_at,omitempty" db:"sent_at"`
 	Status             NotificationStatus `json:"status" db:"status"`
 	GuardianID         *uuid.UUID         `json:"guardian_id,omitempty" db:"guardian_id"`
+	RegistrationID     *uuid.UUID         `json:"registration_id,omitempty" db:"registration_id"`
+	Topic              *NotificationTopic `json:"topic,omitempty" db:"topic"`
+	Urgent             bool               `json:"urgent" db:"urgent"`
+	ProviderMessageID  *string            `json:"provider_message_id,omitempty" db:"provider_message_id"`
 	CreatedAt          time.Time          `json:"created_at" db:"created_at"`
 	UpdatedAt          time.Time          `json:"updated_at" db:"updated_at"`
 }
 
 // NotificationMessage represents the payload structure sent to SQS
-// This is what the Lambda function will receive
+// This is what the delivery worker (or the Lambda function) will receive
 type NotificationMessage struct {
+	// NotificationID is set for scheduled notifications so the delivery result can be
+	// written back to them
+	NotificationID *uuid.UUID `json:"notification_id,omitempty"`
+	// GuardianID lets delivery outcomes (bounces, invalid tokens) be traced to the guardian
+	GuardianID         *uuid.UUID       `json:"guardian_id,omitempty"`
 	NotificationType   NotificationType `json:"notification_type"`
 	RecipientEmail     *string          `json:"recipient_email,omitempty"`
 	RecipientPushToken *string          `json:"recipient_push_token,omitempty"`
 	Subject            *string          `json:"subject,omitempty"`
 	Body               string           `json:"body"`
+	HTMLBody           *string          `json:"html_body,omitempty"`
 	Metadata           json.RawMessage  `json:"metadata,omitempty"`
+	// UnsubscribeURL is sent as the List-Unsubscribe header of emails
+	UnsubscribeURL *string `json:"unsubscribe_url,omitempty"`
+	// DedupID is the same for every copy of the message the relay publishes
+	DedupID string `json:"dedup_id,omitempty"`
 }
 
 // CreateScheduledNotificationInput is used internally to create a scheduled notification
@@ -59,9 +79,18 @@ type CreateScheduledNotificationInput struct {
 	RecipientPushToken *string
 	Subject            *string
 	Body               string
+	HTMLBody           *string
 	Metadata           json.RawMessage
 	ScheduledFor       time.Time
 	GuardianID         *uuid.UUID
+	// RegistrationID ties event reminders to their registration so they can be rescheduled or removed
+	RegistrationID *uuid.UUID
+	// Topic is checked against the guardian's preferences when the notification is sent
+	Topic NotificationTopic
+	// BroadcastID ties the notifications of an organization broadcast together for its statistics
+	BroadcastID *uuid.UUID
+	// Urgent notifications are sent during the guardian's quiet hours instead of waiting for them to end
+	Urgent bool
 }
 
 // SendNotificationInput is used internally to send an immediate notification
@@ -71,5 +100,29 @@ type SendNotificationInput struct {
 	RecipientPushToken *string
 	Subject            *string
 	Body               string
+	HTMLBody           *string
 	Metadata           json.RawMessage
+	// GuardianID and Topic, when set, have the guardian's preferences checked before
+	// sending and give emails an unsubscribe link
+	GuardianID *uuid.UUID
+	Topic      NotificationTopic
+	// NotificationID links the message to its scheduled notification. Scheduled notifications
+	// that are not Urgent are held back during the guardian's quiet hours.
+	NotificationID *uuid.UUID
+	Urgent         bool
+	// DedupID identifies the message across retries so it is queued and delivered once.
+	// Scheduled notifications default to one based on NotificationID; other messages get
+	// a random one unless the caller has a natural key.
+	DedupID string
+}
+
+// NotificationDeliveryResult is what the delivery worker writes back to a scheduled notification
+type NotificationDeliveryResult struct {
+	// Status is NotificationStatusDelivered or NotificationStatusFailed
+	Status NotificationStatus
+	// Transport names the transport that handled the notification, e.g. "smtp" or "expo"
+	Transport string
+	// ProviderMessageID is the transport's id for the message, when it returns one
+	ProviderMessageID *string
+	Error             *string
 }
@@ -0,0 +1,40 @@
+package models
+
+import "github.com/google/uuid"
+
+// DeliveryOutcome is what a provider reported about a notification after it was sent
+type DeliveryOutcome string
+
+const (
+	DeliveryOutcomeDelivered DeliveryOutcome = "delivered"
+	// DeliveryOutcomeBounced is a hard bounce: the address doesn't accept mail
+	DeliveryOutcomeBounced DeliveryOutcome = "bounced"
+	// DeliveryOutcomeComplained means the recipient marked the email as spam
+	DeliveryOutcomeComplained DeliveryOutcome = "complained"
+	// DeliveryOutcomeInvalidToken means the push token is no longer registered with Expo
+	DeliveryOutcomeInvalidToken DeliveryOutcome = "invalid_token"
+	// DeliveryOutcomeFailed is any other failure the provider reported
+	DeliveryOutcomeFailed DeliveryOutcome = "failed"
+)
+
+// DeliveryOutcomeEvent is one report from a provider (a webhook, an Expo receipt, or the
+// delivery worker itself). The notification is found by NotificationID, or failing that by
+// ProviderMessageID; GuardianID is used when the notification isn't in the database.
+type DeliveryOutcomeEvent struct {
+	Outcome           DeliveryOutcome
+	Channel           NotificationType
+	Provider          string
+	ProviderMessageID *string
+	NotificationID    *uuid.UUID
+	GuardianID        *uuid.UUID
+	// Recipient is the email address or push token the notification was sent to
+	Recipient string
+	Detail    *string
+}
+
+// OutcomeNotification is the scheduled notification a delivery outcome was matched to
+type OutcomeNotification struct {
+	ID         uuid.UUID          `db:"id"`
+	GuardianID *uuid.UUID         `db:"guardian_id"`
+	Topic      *NotificationTopic `db:"topic"`
+}
@@ -0,0 +1,154 @@
+package models
+
+import "github.com/google/uuid"
+
+// NotificationTopic groups notifications a guardian can opt in or out of per channel
+type NotificationTopic string
+
+const (
+	NotificationTopicEventReminders NotificationTopic = "event_reminders"
+	NotificationTopicRegistrations  NotificationTopic = "registrations"
+	NotificationTopicPayments       NotificationTopic = "payments"
+	// NotificationTopicOrganizationUpdates covers broadcasts from organizations a guardian is registered with
+	NotificationTopicOrganizationUpdates NotificationTopic = "organization_updates"
+	// NotificationTopicWeeklyDigest is the weekly email and push of new activities matching a family's children
+	NotificationTopicWeeklyDigest NotificationTopic = "weekly_digest"
+	NotificationTopicMarketing    NotificationTopic = "marketing"
+)
+
+// NotificationTopics lists every topic in the order they are shown to guardians
+var NotificationTopics = []NotificationTopic{
+	NotificationTopicEventReminders,
+	NotificationTopicRegistrations,
+	NotificationTopicPayments,
+	NotificationTopicOrganizationUpdates,
+	NotificationTopicWeeklyDigest,
+	NotificationTopicMarketing,
+}
+
+// NotificationChannels lists every channel a topic can be delivered on
+var NotificationChannels = []NotificationType{NotificationTypeEmail, NotificationTypePush}
+
+// defaultNotificationPreferences applies until a guardian changes a topic. The weekly digest
+// and marketing are opt-in.
+var defaultNotificationPreferences = map[NotificationTopic]map[NotificationType]bool{
+	NotificationTopicEventReminders:      {NotificationTypeEmail: true, NotificationTypePush: true},
+	NotificationTopicRegistrations:       {NotificationTypeEmail: true, NotificationTypePush: true},
+	NotificationTopicPayments:            {NotificationTypeEmail: true, NotificationTypePush: false},
+	NotificationTopicOrganizationUpdates: {NotificationTypeEmail: true, NotificationTypePush: true},
+	NotificationTopicWeeklyDigest:        {NotificationTypeEmail: false, NotificationTypePush: false},
+	NotificationTopicMarketing:           {NotificationTypeEmail: false, NotificationTypePush: false},
+}
+
+// NotificationPreference is one cell of a guardian's topic × channel preference matrix
+type NotificationPreference struct {
+	Topic   NotificationTopic `json:"topic" db:"topic" doc:"Notification topic" enum:"event_reminders,registrations,payments,organization_updates,weekly_digest,marketing"`
+	Channel NotificationType  `json:"channel" db:"channel" doc:"Delivery channel" enum:"email,push"`
+	Enabled bool              `json:"enabled" db:"enabled" doc:"Whether the guardian receives this topic on this channel"`
+}
+
+type GuardianNotificationPreferences struct {
+	PushNotifications  bool `db:"push_notifications"`
+	EmailNotifications bool `db:"email_notifications"`
+	QuietHours
+	// Topics holds the guardian's own choices; missing cells fall back to the defaults
+	Topics map[NotificationTopic]map[NotificationType]bool `db:"-"`
+}
+
+// Allows reports whether a notification on topic may be sent on channel. The global channel
+// switches override every topic. Notifications without a topic only follow the global switches.
+func (p GuardianNotificationPreferences) Allows(topic NotificationTopic, channel NotificationType) bool {
+	switch channel {
+	case NotificationTypeEmail:
+		if !p.EmailNotifications {
+			return false
+		}
+	case NotificationTypePush:
+		if !p.PushNotifications {
+			return false
+		}
+	}
+
+	if topic == "" {
+		return true
+	}
+	if enabled, ok := p.Topics[topic][channel]; ok {
+		return enabled
+	}
+	if enabled, ok := defaultNotificationPreferences[topic][channel]; ok {
+		return enabled
+	}
+	return true
+}
+
+// Matrix lists the guardian's effective choice for every topic and channel, ignoring the
+// global switches so turning one back on restores the guardian's earlier choices
+func (p GuardianNotificationPreferences) Matrix() []NotificationPreference {
+	matrix := make([]NotificationPreference, 0, len(NotificationTopics)*len(NotificationChannels))
+	for _, topic := range NotificationTopics {
+		for _, channel := range NotificationChannels {
+			enabled, ok := p.Topics[topic][channel]
+			if !ok {
+				enabled = defaultNotificationPreferences[topic][channel]
+			}
+			matrix = append(matrix, NotificationPreference{Topic: topic, Channel: channel, Enabled: enabled})
+		}
+	}
+	return matrix
+}
+
+// QuietHours is the daily window in which a guardian only gets urgent notifications; others
+// wait until it ends. Start and End are wall-clock times in Timezone, and a window whose End
+// is before its Start runs over midnight.
+type QuietHours struct {
+	Timezone string `json:"timezone" db:"timezone" doc:"IANA timezone the guardian lives in" example:"Asia/Bangkok"`
+	Enabled  bool   `json:"enabled" db:"quiet_hours_enabled" doc:"Whether quiet hours apply"`
+	Start    string `json:"start" db:"quiet_hours_start" pattern:"^([01][0-9]|2[0-3]):[0-5][0-9]$" doc:"Start of quiet hours, HH:MM" example:"21:00"`
+	End      string `json:"end" db:"quiet_hours_end" pattern:"^([01][0-9]|2[0-3]):[0-5][0-9]$" doc:"End of quiet hours, HH:MM" example:"07:00"`
+}
+
+type NotificationPreferencesBody struct {
+	PushNotifications  bool                     `json:"push_notifications" doc:"Global push notification switch"`
+	EmailNotifications bool                     `json:"email_notifications" doc:"Global email notification switch"`
+	QuietHours         QuietHours               `json:"quiet_hours" doc:"When non-urgent notifications are held back"`
+	Preferences        []NotificationPreference `json:"preferences" doc:"Per-topic choice for every channel"`
+}
+
+type GetNotificationPreferencesInput struct {
+	ID uuid.UUID `path:"id" format:"uuid" doc:"ID of the guardian"`
+}
+
+type GetNotificationPreferencesOutput struct {
+	Body NotificationPreferencesBody `json:"body"`
+}
+
+type UpdateNotificationPreferencesInput struct {
+	ID   uuid.UUID `path:"id" format:"uuid" doc:"ID of the guardian"`
+	Body struct {
+		Preferences []NotificationPreference `json:"preferences" minItems:"1" doc:"Cells of the preference matrix to change"`
+	}
+}
+
+type UpdateNotificationPreferencesOutput struct {
+	Body NotificationPreferencesBody `json:"body"`
+}
+
+type UpdateQuietHoursInput struct {
+	ID   uuid.UUID  `path:"id" format:"uuid" doc:"ID of the guardian"`
+	Body QuietHours `json:"body"`
+}
+
+type UpdateQuietHoursOutput struct {
+	Body NotificationPreferencesBody `json:"body"`
+}
+
+type UnsubscribeInput struct {
+	Token string `query:"token" required:"true" doc:"Signed unsubscribe token from the email link"`
+}
+
+type UnsubscribeOutput struct {
+	Body struct {
+		Message string            `json:"message" doc:"Confirmation message"`
+		Topic   NotificationTopic `json:"topic" doc:"Topic that was turned off for email"`
+	} `json:"body"`
+}
@@ -20,6 +20,7 @@ type Organization struct {
 	Links                  []OrgLink         `json:"links" db:"links"`
 	PfpS3Key               *string           `json:"pfp_s3_key,omitempty" db:"pfp_s3_key"`
 	PresignedURL           *string           `json:"presigned_url,omitempty"`
+	PfpRenditions          []ImageRendition  `json:"pfp_renditions,omitempty"`
 	LocationID             *uuid.UUID        `json:"location_id,omitempty" db:"location_id"`
 	Latitude               *float64          `json:"latitude,omitempty"`
 	Longitude              *float64          `json:"longitude,omitempty"`
@@ -43,7 +44,7 @@ type UpdateOrganizationFormData struct {
 	About        string        `form:"about"`
 	Active       bool          `form:"active"`
 	LocationID   uuid.UUID     `form:"location_id"`
-	ProfileImage huma.FormFile `form:"profile_image" contentType:"image/png,image/jpeg"`
+	ProfileImage huma.FormFile `form:"profile_image" contentType:"image/png,image/jpeg,image/webp"`
 	Links        string        `form:"links"`
 }
 
@@ -53,7 +54,7 @@ type CreateOrganizationFormData struct {
 	Active       bool          `form:"active"`
 	LocationID   uuid.UUID     `form:"location_id"`
 	Links        string        `form:"links"`
-	ProfileImage huma.FormFile `form:"profile_image" contentType:"image/png,image/jpeg"`
+	ProfileImage huma.FormFile `form:"profile_image" contentType:"image/png,image/jpeg,image/webp"`
 }
 
 type UpdateOrganizationRouteInput struct {
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Outbox topics. The topic decides which sink the relay publishes a message to.
+const (
+	// OutboxTopicNotification carries a NotificationMessage to the SQS delivery queue
+	OutboxTopicNotification = "notification.send"
+	// OutboxTopicRegistrationCreated sends the confirmation and schedules the reminders
+	// for a new registration
+	OutboxTopicRegistrationCreated = "registration.created"
+	// OutboxTopicRegistrationCancelled removes the unsent reminders of a cancelled registration
+	OutboxTopicRegistrationCancelled = "registration.cancelled"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxStatusPending   OutboxStatus = "pending"
+	OutboxStatusPublished OutboxStatus = "published"
+	OutboxStatusFailed    OutboxStatus = "failed"
+)
+
+// OutboxMessage is a side effect recorded alongside a write, waiting for the relay to
+// publish it
+type OutboxMessage struct {
+	ID          uuid.UUID       `json:"id" db:"id"`
+	Topic       string          `json:"topic" db:"topic"`
+	Payload     json.RawMessage `json:"payload" db:"payload"`
+	DedupID     string          `json:"dedup_id" db:"dedup_id"`
+	Status      OutboxStatus    `json:"status" db:"status"`
+	Attempts    int             `json:"attempts" db:"attempts"`
+	MaxAttempts int             `json:"max_attempts" db:"max_attempts"`
+	AvailableAt time.Time       `json:"available_at" db:"available_at"`
+	LockedUntil *time.Time      `json:"locked_until,omitempty" db:"locked_until"`
+	LastError   *string         `json:"last_error,omitempty" db:"last_error"`
+	PublishedAt *time.Time      `json:"published_at,omitempty" db:"published_at"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+}
+
+// IsFinalAttempt reports whether a failure of the current attempt fails the message for good
+func (m OutboxMessage) IsFinalAttempt() bool {
+	return m.Attempts >= m.MaxAttempts
+}
+
+// EnqueueOutboxMessageData is the internal storage input for recording an outbox message
+type EnqueueOutboxMessageData struct {
+	Topic   string
+	Payload json.RawMessage
+	DedupID string
+}
+
+// RegistrationOutboxPayload is the payload of the registration topics
+type RegistrationOutboxPayload struct {
+	RegistrationID uuid.UUID `json:"registration_id"`
+}
@@ -108,6 +108,8 @@ type CreatePaymentIntentInput struct {
 		PlatformFeePercentage int       `json:"platform_fee_percentage" doc:"Platform fee as a percentage of the total amount (e.g. 10 for 10%)"`
 		GuardianStripeID      string
 		OrgStripeID           string
+		// IdempotencyKey makes retried creates return the original intent instead of charging twice
+		IdempotencyKey string `json:"-"`
 	}
 }
 
@@ -138,6 +140,7 @@ type CancelPaymentIntentOutput struct {
 
 type CapturePaymentIntentInput struct {
 	PaymentIntentID string `json:"payment_intent_id" doc:"Stripe payment intent ID to capture"`
+	IdempotencyKey  string `json:"-"`
 }
 
 type CapturePaymentIntentOutput struct {
@@ -162,6 +165,12 @@ type CreatePaymentData struct {
 	PlatformFeeAmount     int
 	Currency              string
 	PaymentIntentStatus   string
+	// CreditAmount is the wallet credit applied on top of the card charge in TotalAmount.
+	// StripePaymentIntentID is empty when credit covered the whole price.
+	CreditAmount int
+	// StripeTransferID is the transfer of the organization's share when credit covered
+	// the whole price, since there is no charge to transfer it from.
+	StripeTransferID string
 }
 
 type CreatePaymentForRegistrationInput struct {
@@ -221,6 +230,62 @@ type RefundPaymentOutput struct {
 	}
 }
 
+// CreateTransferInput pays an organization's connected account from the platform's
+// balance, e.g. its share of a booking paid with wallet credit.
+type CreateTransferInput struct {
+	Amount               int64
+	Currency             string
+	DestinationAccountID string
+	Description          string
+	Metadata             map[string]string
+	IdempotencyKey       string `json:"-"`
+}
+
+type CreateTransferOutput struct {
+	Body struct {
+		TransferID string `json:"transfer_id"`
+		Amount     int64  `json:"amount"`
+		Currency   string `json:"currency"`
+	}
+}
+
+// ReverseTransferInput takes back what was transferred to the organization's connected
+// account: TransferID when it is known, otherwise the transfer a destination charge
+// made for PaymentIntentID, leaving the whole charge with the platform.
+type ReverseTransferInput struct {
+	PaymentIntentID string
+	TransferID      string
+	IdempotencyKey  string `json:"-"`
+}
+
+type ReverseTransferOutput struct {
+	Body struct {
+		ReversalID string `json:"reversal_id"`
+		TransferID string `json:"transfer_id"`
+		Amount     int64  `json:"amount"`
+		Currency   string `json:"currency"`
+	}
+}
+
+// ChargeCustomerInput is a platform-only charge (no connected account), e.g. gift card purchases.
+type ChargeCustomerInput struct {
+	CustomerID      string
+	PaymentMethodID string
+	Amount          int64
+	Currency        string
+	Description     string
+	Metadata        map[string]string
+}
+
+type ChargeCustomerOutput struct {
+	Body struct {
+		PaymentIntentID string `json:"payment_intent_id"`
+		Status          string `json:"status"`
+		Amount          int64  `json:"amount"`
+		Currency        string `json:"currency"`
+	}
+}
+
 type AttachPaymentMethodInput struct {
 	GuardianID uuid.UUID `path:"guardian_id" doc:"Guardian ID"`
 	Body       struct {
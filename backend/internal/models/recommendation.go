@@ -19,7 +19,7 @@ type GetRecommendationsByChildIDInput struct {
 }
 
 type GetRecommendationsByChildIDOutput struct {
-	Body []Event `json:"body" doc:"List of recommended events for the child"`
+	Body []Recommendation `json:"body" doc:"List of recommended events for the child, best first"`
 }
 
 type RecommendationFilters struct {
@@ -30,4 +30,82 @@ type RecommendationFilters struct {
 	RadiusKm  float64         `query:"radius_km"`
 	MinDate   time.Time       `query:"min_date"`
 	MaxDate   time.Time       `query:"max_date"`
+	// NewSince keeps only events created since then or with an upcoming occurrence added
+	// since then; zero keeps every event
+	NewSince time.Time
+}
+
+type RecommendationInteractionKind string
+
+const (
+	RecommendationInteractionRegistration RecommendationInteractionKind = "registration"
+	RecommendationInteractionSaved        RecommendationInteractionKind = "saved"
+	RecommendationInteractionReview       RecommendationInteractionKind = "review"
+)
+
+// RecommendationInteraction is one thing a family did with an event, which the
+// collaborative-filtering job learns from. Saves belong to the whole family, so they are
+// listed once for each of the guardian's children.
+type RecommendationInteraction struct {
+	GuardianID uuid.UUID                     `db:"guardian_id"`
+	ChildID    uuid.UUID                     `db:"child_id"`
+	EventID    uuid.UUID                     `db:"event_id"`
+	Kind       RecommendationInteractionKind `db:"kind"`
+	// only set for reviews, from 1 to 5
+	Rating    *int      `db:"rating"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// ChildRecommendation is a collaborative-filtering score for an event the child hasn't
+// interacted with yet, scaled so the child's best match scores 1
+type ChildRecommendation struct {
+	ChildID uuid.UUID
+	EventID uuid.UUID
+	Score   float64
+}
+
+// RecommendationScoreComponents are the parts a recommendation's score adds up from
+type RecommendationScoreComponents struct {
+	Interest      float64 `json:"interest" doc:"One point for each of the event's categories the child is interested in"`
+	Collaborative float64 `json:"collaborative" doc:"How strongly families like the child's booked the event, from 0 to 2"`
+	Popularity    float64 `json:"popularity" doc:"How many families registered for the event recently, from 0 to 1"`
+	Diversity     float64 `json:"diversity" doc:"Penalty, 0 or less, for sharing an organization, categories or a day with better recommendations"`
+}
+
+// RecommendationCandidate is an upcoming event the child could be recommended, with the
+// signals it is ranked and explained by
+type RecommendationCandidate struct {
+	Event
+	// Components has every part of the score except the diversity penalty
+	Components       RecommendationScoreComponents
+	MatchedInterests []string
+	// RecentRegistrations counts registrations for the event by any family lately
+	RecentRegistrations int
+	// DistanceKm is only set when the recommendations are for a location
+	DistanceKm    *float64
+	NextStartTime time.Time
+}
+
+type RecommendationReason string
+
+const (
+	RecommendationReasonMatchesInterest RecommendationReason = "matches_interest"
+	RecommendationReasonSimilarFamilies RecommendationReason = "similar_families"
+	RecommendationReasonPopularNearYou  RecommendationReason = "popular_near_you"
+	RecommendationReasonPopular         RecommendationReason = "popular"
+	RecommendationReasonUpcoming        RecommendationReason = "upcoming"
+)
+
+// RecommendationExplanation is one reason an event was recommended
+type RecommendationExplanation struct {
+	Reason RecommendationReason `json:"reason" enum:"matches_interest,similar_families,popular_near_you,popular,upcoming" doc:"Why the event was recommended"`
+	Text   string               `json:"text" doc:"The reason in the requested language, e.g. 'Matches interest: robotics'"`
+}
+
+// Recommendation is a recommended event with its score and why it was recommended
+type Recommendation struct {
+	Event
+	Score           float64                       `json:"score" doc:"Sum of the score components; recommendations are ordered by it"`
+	ScoreComponents RecommendationScoreComponents `json:"score_components" doc:"What the score adds up from"`
+	Explanations    []RecommendationExplanation   `json:"explanations" doc:"Reasons the event was recommended; there is always at least one"`
 }
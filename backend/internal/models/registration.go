@@ -16,6 +16,7 @@ type Registration struct {
 	StripeCustomerID      string             `json:"stripe_customer_id" db:"stripe_customer_id" doc:"Stripe customer ID"`
 	OrgStripeAccountID    string             `json:"org_stripe_account_id" db:"org_stripe_account_id" doc:"Organization's Stripe account ID"`
 	StripePaymentMethodID string             `json:"stripe_payment_method_id" db:"stripe_payment_method_id" doc:"Stripe payment method ID"`
+	StripeTransferID      string             `json:"stripe_transfer_id,omitempty" db:"stripe_transfer_id" doc:"Stripe transfer paying the organization for a registration paid with wallet credit"`
 	TotalAmount           int                `json:"total_amount" db:"total_amount" doc:"Total amount in cents"`
 	ProviderAmount        int                `json:"provider_amount" db:"provider_amount" doc:"Amount provider receives in cents"`
 	PlatformFeeAmount     int                `json:"platform_fee_amount" db:"platform_fee_amount" doc:"Platform fee amount in cents"`
@@ -88,6 +89,7 @@ type UpdateRegistrationOutput struct {
 type CancelRegistrationInput struct {
 	AcceptLanguage      string              `header:"Accept-Language" default:"en-US" enum:"en-US,th-TH"`
 	ID                  uuid.UUID           `path:"id"`
+	RefundTo            RefundDestination   `query:"refund_to" default:"card" enum:"card,credit" doc:"Where an eligible refund is sent: back to the card or to the guardian's wallet"`
 	Status              *RegistrationStatus `json:"status,omitempty"`
 	PaymentIntentStatus *string             `json:"payment_intent_status,omitempty"`
 }
@@ -16,6 +16,10 @@ type Review struct {
 	Rating         int        `json:"rating" db:"rating" doc:"Rating left with the review, can be 1-5 inclusive"`
 	CreatedAt      time.Time  `json:"created_at" db:"created_at" doc:"Timestamp when registration was created"`
 	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at" doc:"Timestamp when registration was last updated"`
+
+	PhotoS3Key      *string          `json:"photo_s3_key,omitempty" db:"photo_s3_key" doc:"S3 key of the photo attached through a direct upload"`
+	PhotoURL        *string          `json:"photo_url,omitempty" db:"-" doc:"Presigned URL of the photo"`
+	PhotoRenditions []ImageRendition `json:"photo_renditions,omitempty" db:"-" doc:"Resized copies of the photo"`
 }
 
 type CreateReviewDBBody struct {
@@ -43,6 +47,8 @@ type GetReviewInput struct {
 	Categories     []string   `json:"categories" db:"categories" doc:"Review categories for this review, can be one of fun, engaging, interesting or informative."`
 	CreatedAt      time.Time  `json:"created_at" db:"created_at" doc:"Timestamp when registration was created"`
 	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at" doc:"Timestamp when registration was last updated"`
+
+	PhotoS3Key *string `json:"photo_s3_key" db:"photo_s3_key"`
 }
 
 type CreateReviewInput struct {
@@ -1,12 +1,244 @@
 package models
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SearchSort string
+
+const (
+	SearchSortRelevance SearchSort = "relevance"
+	SearchSortDistance  SearchSort = "distance"
+	SearchSortDate      SearchSort = "date"
+	SearchSortPrice     SearchSort = "price"
+)
+
 type SearchEventsInput struct {
-	Query          string `query:"q" doc:"Search query string" minLength:"1"`
-	Page           int    `query:"page" minimum:"1" default:"1" doc:"Page number (starts at 1)"`
-	Limit          int    `query:"limit" minimum:"1" maximum:"100" default:"10" doc:"Number of results per page"`
-	AcceptLanguage string `header:"Accept-Language" default:"en-US" enum:"en-US,th-TH"`
+	Query          string          `query:"q" doc:"Search query string; leave empty to browse with filters only"`
+	Page           int             `query:"page" minimum:"1" default:"1" doc:"Page number (starts at 1)"`
+	Limit          int             `query:"limit" minimum:"1" maximum:"100" default:"10" doc:"Number of results per page"`
+	AcceptLanguage string          `header:"Accept-Language" default:"en-US" enum:"en-US,th-TH"`
+	Latitude       OptionalFloat64 `query:"lat" doc:"The user's latitude, used by radius_km and the distance sort"`
+	Longitude      OptionalFloat64 `query:"lng" doc:"The user's longitude, used by radius_km and the distance sort"`
+	RadiusKm       float64         `query:"radius_km" doc:"Only return events whose organization is within this many km"`
+	MinPrice       int             `query:"min_price" doc:"Minimum occurrence price in cents"`
+	MaxPrice       int             `query:"max_price" doc:"Maximum occurrence price in cents (exclusive)"`
+	MinDuration    int             `query:"min_duration" doc:"Minimum occurrence duration in minutes"`
+	MaxDuration    int             `query:"max_duration" doc:"Maximum occurrence duration in minutes"`
+	MinAge         int             `query:"min_age"`
+	MaxAge         int             `query:"max_age"`
+	Category       string          `query:"category" doc:"Comma-separated list of category values"`
+	SoldOut        bool            `query:"soldout"`
+	MinDate        time.Time       `query:"min_date"`
+	MaxDate        time.Time       `query:"max_date"`
+	Sort           SearchSort      `query:"sort" default:"relevance" enum:"relevance,distance,date,price" doc:"distance needs lat and lng; date and price use each event's earliest matching upcoming occurrence"`
+}
+
+type FacetBucket struct {
+	Key   string `json:"key" doc:"Category, district or band name"`
+	Count int    `json:"count" doc:"Number of matching events in the bucket"`
+}
+
+type SearchFacets struct {
+	Category  []FacetBucket `json:"category"`
+	AgeBand   []FacetBucket `json:"age_band" doc:"Events whose age range overlaps each band"`
+	PriceBand []FacetBucket `json:"price_band" doc:"Events with an occurrence priced within each band"`
+	District  []FacetBucket `json:"district" doc:"District of the event's organization"`
+}
+
+type SearchEventsResult struct {
+	SearchID *uuid.UUID `json:"search_id,omitempty" doc:"Send with the result the user opens to POST /api/v1/search/{id}/click"`
+	Results  []Event    `json:"results"`
+	Total    int        `json:"total" doc:"Number of events matching the query and filters"`
+	// when search falls back to Postgres, Total only counts this page and Facets is empty
+	Facets SearchFacets `json:"facets" doc:"Counts over every matching event, not only this page"`
+	// only set by OpenSearch, when the query matched nothing
+	DidYouMean *string `json:"did_you_mean,omitempty" doc:"A spelling correction of the query, when it matched no events"`
 }
 
 type SearchEventsOutput struct {
-	Body []Event
+	Body SearchEventsResult
+}
+
+type SuggestInput struct {
+	Query          string `query:"q" required:"true" minLength:"1" maxLength:"100" doc:"What the user has typed so far"`
+	Limit          int    `query:"limit" minimum:"1" maximum:"10" default:"5" doc:"Maximum number of suggestions in each group"`
+	AcceptLanguage string `header:"Accept-Language" default:"en-US" enum:"en-US,th-TH"`
+}
+
+type EventSuggestion struct {
+	ID    uuid.UUID `json:"id"`
+	Title string    `json:"title"`
+}
+
+type OrganizationSuggestion struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+type SearchSuggestions struct {
+	Events        []EventSuggestion        `json:"events" doc:"Events whose title starts with or closely matches the query"`
+	Organizations []OrganizationSuggestion `json:"organizations" doc:"Organizations whose name matches the query, most events first"`
+	Categories    []string                 `json:"categories" doc:"Category values with a word starting with the query"`
+}
+
+type SuggestOutput struct {
+	Body SearchSuggestions
+}
+
+type SearchType string
+
+const (
+	SearchTypeOccurrences   SearchType = "occurrences"
+	SearchTypeEvents        SearchType = "events"
+	SearchTypeOrganizations SearchType = "organizations"
+)
+
+type UnifiedSearchInput struct {
+	Query          string `query:"q" required:"true" minLength:"1" maxLength:"200" doc:"Search query string"`
+	Types          string `query:"types" default:"occurrences,events,organizations" doc:"Comma-separated list of result types to search: occurrences, events, organizations"`
+	Limit          int    `query:"limit" minimum:"1" maximum:"20" default:"5" doc:"Maximum number of results of each type"`
+	AcceptLanguage string `header:"Accept-Language" default:"en-US" enum:"en-US,th-TH"`
+}
+
+// OccurrenceSearchResult is an upcoming occurrence with what a parent needs to choose it:
+// its event, when it is, what it costs and how many seats are left
+type OccurrenceSearchResult struct {
+	ID                    uuid.UUID        `json:"id"`
+	EventID               uuid.UUID        `json:"event_id"`
+	OrganizationID        uuid.UUID        `json:"organization_id"`
+	OrganizationName      string           `json:"organization_name,omitempty"`
+	Title                 string           `json:"title"`
+	Description           string           `json:"description"`
+	Category              []string         `json:"category"`
+	HeaderImageS3Key      *string          `json:"header_image_s3_key,omitempty"`
+	PresignedURL          *string          `json:"presigned_url,omitempty"`
+	HeaderImageRenditions []ImageRendition `json:"header_image_renditions,omitempty"`
+	AgeRangeMin           *int             `json:"age_range_min,omitempty"`
+	AgeRangeMax           *int             `json:"age_range_max,omitempty"`
+	District              string           `json:"district,omitempty"`
+	StartTime             time.Time        `json:"start_time"`
+	EndTime               time.Time        `json:"end_time"`
+	Price                 int              `json:"price" doc:"Price in cents"`
+	Currency              string           `json:"currency"`
+	Language              string           `json:"language"`
+	SeatsLeft             int              `json:"seats_left"`
+	SoldOut               bool             `json:"sold_out"`
+}
+
+type OrganizationSearchResult struct {
+	ID            uuid.UUID        `json:"id"`
+	Name          string           `json:"name"`
+	About         *string          `json:"about,omitempty"`
+	District      string           `json:"district,omitempty"`
+	PfpS3Key      *string          `json:"pfp_s3_key,omitempty"`
+	PresignedURL  *string          `json:"presigned_url,omitempty"`
+	PfpRenditions []ImageRendition `json:"pfp_renditions,omitempty"`
+}
+
+// UnifiedSearchResult groups the matches of each type; types that weren't requested are
+// empty
+type UnifiedSearchResult struct {
+	SearchID           *uuid.UUID                 `json:"search_id,omitempty" doc:"Send with the result the user opens to POST /api/v1/search/{id}/click"`
+	Occurrences        []OccurrenceSearchResult   `json:"occurrences" doc:"Upcoming scheduled occurrences, best match first"`
+	OccurrencesTotal   int                        `json:"occurrences_total"`
+	Events             []Event                    `json:"events"`
+	EventsTotal        int                        `json:"events_total"`
+	Organizations      []OrganizationSearchResult `json:"organizations"`
+	OrganizationsTotal int                        `json:"organizations_total"`
+}
+
+type UnifiedSearchOutput struct {
+	Body UnifiedSearchResult
+}
+
+// EventSearchDocument is an event as it is indexed in OpenSearch, with the location of its
+// organization and its scheduled occurrences denormalised onto it
+type EventSearchDocument struct {
+	ID               string                     `json:"id"`
+	OrganizationID   string                     `json:"organization_id"`
+	OrganizationName string                     `json:"organization_name"`
+	TitleEN          string                     `json:"title_en"`
+	TitleTH          *string                    `json:"title_th"`
+	DescriptionEN    string                     `json:"description_en"`
+	DescriptionTH    *string                    `json:"description_th"`
+	Category         []string                   `json:"category"`
+	HeaderImageS3Key *string                    `json:"header_image_s3_key"`
+	AgeRangeMin      *int                       `json:"age_range_min"`
+	AgeRangeMax      *int                       `json:"age_range_max"`
+	Location         *SearchGeoPoint            `json:"location,omitempty"`
+	District         string                     `json:"district,omitempty"`
+	Occurrences      []OccurrenceSearchDocument `json:"occurrences"`
+	// UpdatedAt is the latest change to the event, its organization, its location or its
+	// occurrences; incremental sync picks up from the newest one in the index
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SearchGeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// OccurrenceSearchDocument is one scheduled occurrence of an indexed event. Cancelled
+// occurrences are not indexed.
+type OccurrenceSearchDocument struct {
+	ID              string    `json:"id"`
+	StartTime       time.Time `json:"start_time"`
+	EndTime         time.Time `json:"end_time"`
+	Price           int       `json:"price"`
+	DurationMinutes int       `json:"duration_minutes"`
+	SoldOut         bool      `json:"sold_out"`
+}
+
+// EventOccurrenceSearchDocument is a scheduled occurrence as it is indexed in OpenSearch,
+// with its event and the location of its organization denormalised onto it
+type EventOccurrenceSearchDocument struct {
+	ID               string          `json:"id"`
+	EventID          string          `json:"event_id"`
+	OrganizationID   string          `json:"organization_id"`
+	OrganizationName string          `json:"organization_name"`
+	TitleEN          string          `json:"title_en"`
+	TitleTH          *string         `json:"title_th"`
+	DescriptionEN    string          `json:"description_en"`
+	DescriptionTH    *string         `json:"description_th"`
+	Category         []string        `json:"category"`
+	HeaderImageS3Key *string         `json:"header_image_s3_key"`
+	AgeRangeMin      *int            `json:"age_range_min"`
+	AgeRangeMax      *int            `json:"age_range_max"`
+	Location         *SearchGeoPoint `json:"location,omitempty"`
+	District         string          `json:"district,omitempty"`
+	StartTime        time.Time       `json:"start_time"`
+	EndTime          time.Time       `json:"end_time"`
+	DurationMinutes  int             `json:"duration_minutes"`
+	Price            int             `json:"price"`
+	Currency         string          `json:"currency"`
+	Language         string          `json:"language"`
+	MaxAttendees     int             `json:"max_attendees"`
+	SeatsLeft        int             `json:"seats_left"`
+	SoldOut          bool            `json:"sold_out"`
+	// UpdatedAt is the latest change to the occurrence, its event, its organization or its
+	// location
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OrganizationSearchDocument is an active organization as it is indexed in OpenSearch
+type OrganizationSearchDocument struct {
+	ID       string          `json:"id"`
+	Name     string          `json:"name"`
+	AboutEN  *string         `json:"about_en"`
+	AboutTH  *string         `json:"about_th"`
+	PfpS3Key *string         `json:"pfp_s3_key"`
+	Location *SearchGeoPoint `json:"location,omitempty"`
+	District string          `json:"district,omitempty"`
+	// UpdatedAt is the latest change to the organization or its location
+	UpdatedAt time.Time `json:"updated_at"`
 }
+
+// DocumentID is the id each document is indexed under
+func (d EventSearchDocument) DocumentID() string { return d.ID }
+
+func (d EventOccurrenceSearchDocument) DocumentID() string { return d.ID }
+
+func (d OrganizationSearchDocument) DocumentID() string { return d.ID }
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SearchLogEndpoint string
+
+const (
+	SearchLogEndpointEvents SearchLogEndpoint = "events"
+	SearchLogEndpointAll    SearchLogEndpoint = "all"
+)
+
+type SearchResultType string
+
+const (
+	SearchResultTypeEvent        SearchResultType = "event"
+	SearchResultTypeOccurrence   SearchResultType = "occurrence"
+	SearchResultTypeOrganization SearchResultType = "organization"
+)
+
+// SearchLog is one anonymous search request and the first result opened from it
+type SearchLog struct {
+	ID                uuid.UUID         `json:"id" db:"id" doc:"Search ID, returned with the results as search_id"`
+	Endpoint          SearchLogEndpoint `json:"endpoint" db:"endpoint" doc:"Which search endpoint was called" enum:"events,all"`
+	Query             string            `json:"query" db:"query" doc:"The query as typed"`
+	NormalizedQuery   string            `json:"normalized_query" db:"normalized_query" doc:"The query lowercased with whitespace collapsed"`
+	Language          string            `json:"language" db:"language" doc:"Accept-Language of the request"`
+	Filters           map[string]any    `json:"filters" db:"filters" doc:"Filters applied to the search"`
+	ResultCount       int               `json:"result_count" db:"result_count" doc:"Number of matching results"`
+	ClickedResultID   *uuid.UUID        `json:"clicked_result_id,omitempty" db:"clicked_result_id" doc:"ID of the result opened from the results"`
+	ClickedResultType *SearchResultType `json:"clicked_result_type,omitempty" db:"clicked_result_type" doc:"Type of the result opened" enum:"event,occurrence,organization"`
+	ClickedPosition   *int              `json:"clicked_position,omitempty" db:"clicked_position" doc:"Zero-based position of the result opened"`
+	ClickedAt         *time.Time        `json:"clicked_at,omitempty" db:"clicked_at" doc:"Timestamp when the result was opened"`
+	CreatedAt         time.Time         `json:"created_at" db:"created_at" doc:"Timestamp of the search"`
+}
+
+// CreateSearchLogData is the internal storage input for logging a search
+type CreateSearchLogData struct {
+	Endpoint    SearchLogEndpoint
+	Query       string
+	Language    string
+	Filters     map[string]any
+	ResultCount int
+}
+
+// NormalizeSearchQuery lowercases the query and collapses its whitespace, so the same
+// query typed differently is counted together
+func NormalizeSearchQuery(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
+
+type RecordSearchClickInput struct {
+	ID   uuid.UUID `path:"id" format:"uuid" doc:"The search_id returned with the results"`
+	Body struct {
+		ResultID   uuid.UUID        `json:"result_id" doc:"ID of the result opened"`
+		ResultType SearchResultType `json:"result_type" enum:"event,occurrence,organization" doc:"Type of the result opened"`
+		Position   int              `json:"position" minimum:"0" doc:"Zero-based position of the result in the list shown"`
+	}
+}
+
+type RecordSearchClickOutput struct {
+	Body SearchLog `json:"body"`
+}
+
+// RecordSearchClickData is the internal storage input for recording the result opened
+type RecordSearchClickData struct {
+	ID         uuid.UUID
+	ResultID   uuid.UUID
+	ResultType SearchResultType
+	Position   int
+}
+
+type GetSearchAnalyticsInput struct {
+	Since time.Time `query:"since" doc:"Start of the reporting window; defaults to 30 days ago"`
+	Until time.Time `query:"until" doc:"End of the reporting window; defaults to now"`
+	Limit int       `query:"limit" minimum:"1" maximum:"100" default:"20" doc:"Number of queries in each list"`
+}
+
+// SearchQueryStats counts the searches for one normalized query
+type SearchQueryStats struct {
+	Query              string    `json:"query" doc:"Normalized query"`
+	Searches           int       `json:"searches" doc:"Number of searches"`
+	ZeroResultSearches int       `json:"zero_result_searches" doc:"Number of searches that found nothing"`
+	Clicks             int       `json:"clicks" doc:"Number of searches followed by opening a result"`
+	ClickThroughRate   float64   `json:"click_through_rate" doc:"clicks / searches"`
+	LastSearchedAt     time.Time `json:"last_searched_at"`
+}
+
+type SearchAnalytics struct {
+	Since              time.Time          `json:"since"`
+	Until              time.Time          `json:"until"`
+	Searches           int                `json:"searches" doc:"Searches with a text query in the window"`
+	ZeroResultSearches int                `json:"zero_result_searches"`
+	Clicks             int                `json:"clicks"`
+	ClickThroughRate   float64            `json:"click_through_rate"`
+	TopQueries         []SearchQueryStats `json:"top_queries" doc:"Most searched queries"`
+	ZeroResultQueries  []SearchQueryStats `json:"zero_result_queries" doc:"Queries that most often found nothing, the activities to recruit organizations for"`
+}
+
+type GetSearchAnalyticsOutput struct {
+	Body SearchAnalytics `json:"body"`
+}
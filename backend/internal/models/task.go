@@ -0,0 +1,67 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TaskStatus string
+
+const (
+	TaskStatusPending   TaskStatus = "pending"
+	TaskStatusRunning   TaskStatus = "running"
+	TaskStatusSucceeded TaskStatus = "succeeded"
+	TaskStatusDead      TaskStatus = "dead"
+)
+
+// Task is one unit of background work in the durable task queue
+type Task struct {
+	ID          uuid.UUID       `json:"id" db:"id" doc:"Unique task identifier"`
+	TaskType    string          `json:"task_type" db:"task_type" doc:"Kind of work, e.g. capture_payment"`
+	Payload     json.RawMessage `json:"payload" db:"payload" doc:"Task-specific input"`
+	DedupKey    *string         `json:"dedup_key,omitempty" db:"dedup_key" doc:"Key that prevents the same work being queued twice"`
+	Status      TaskStatus      `json:"status" db:"status" doc:"Queue state of the task" enum:"pending,running,succeeded,dead"`
+	Attempts    int             `json:"attempts" db:"attempts" doc:"Number of times the task has been claimed"`
+	MaxAttempts int             `json:"max_attempts" db:"max_attempts" doc:"Attempts allowed before the task is dead-lettered"`
+	RunAfter    time.Time       `json:"run_after" db:"run_after" doc:"Earliest time the task may run"`
+	LockedUntil *time.Time      `json:"locked_until,omitempty" db:"locked_until" doc:"When a running task's claim expires"`
+	LastError   *string         `json:"last_error,omitempty" db:"last_error" doc:"Error from the most recent failed attempt"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty" db:"completed_at" doc:"Timestamp when the task succeeded"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at" doc:"Timestamp when the task was enqueued"`
+	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at" doc:"Timestamp when the task was last updated"`
+}
+
+// IsFinalAttempt reports whether a failure of the current attempt dead-letters the task
+func (t Task) IsFinalAttempt() bool {
+	return t.Attempts >= t.MaxAttempts
+}
+
+// EnqueueTaskData is the internal storage input for adding a task to the queue
+type EnqueueTaskData struct {
+	TaskType    string
+	Payload     json.RawMessage
+	DedupKey    *string
+	MaxAttempts int
+	RunAfter    *time.Time
+}
+
+type GetAllTasksInput struct {
+	Status   string `query:"status" required:"false" enum:"pending,running,succeeded,dead" doc:"Only return tasks in this state"`
+	TaskType string `query:"task_type" required:"false" doc:"Only return tasks of this type"`
+	Page     int    `query:"page" minimum:"1" default:"1" doc:"Page number (starts at 1)"`
+	PageSize int    `query:"page_size" minimum:"1" maximum:"100" default:"10" doc:"Number of items per page"`
+}
+
+type GetAllTasksOutput struct {
+	Body []Task `json:"body"`
+}
+
+type RetryTaskInput struct {
+	ID uuid.UUID `path:"id" format:"uuid" doc:"ID of the dead task to retry"`
+}
+
+type RetryTaskOutput struct {
+	Body Task `json:"body"`
+}
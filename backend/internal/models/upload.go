@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UploadTarget is what an uploaded image is attached to when its session is finalized
+type UploadTarget string
+
+const (
+	UploadTargetEventHeader         UploadTarget = "event_header"
+	UploadTargetOrganizationPicture UploadTarget = "organization_picture"
+	UploadTargetGuardianAvatar      UploadTarget = "guardian_avatar"
+	UploadTargetReviewPhoto         UploadTarget = "review_photo"
+)
+
+type UploadSessionStatus string
+
+const (
+	UploadSessionStatusPending   UploadSessionStatus = "pending"
+	UploadSessionStatusFinalized UploadSessionStatus = "finalized"
+	UploadSessionStatusExpired   UploadSessionStatus = "expired"
+)
+
+type UploadSession struct {
+	ID            uuid.UUID           `json:"id" db:"id"`
+	Target        UploadTarget        `json:"target" db:"target"`
+	TargetID      uuid.UUID           `json:"target_id" db:"target_id"`
+	StagingKey    string              `json:"-" db:"staging_key"`
+	ContentType   string              `json:"content_type" db:"content_type"`
+	ContentLength int64               `json:"content_length" db:"content_length"`
+	Status        UploadSessionStatus `json:"status" db:"status"`
+	ExpiresAt     time.Time           `json:"expires_at" db:"expires_at"`
+	FinalizedKey  *string             `json:"finalized_key,omitempty" db:"finalized_key"`
+	FinalizedAt   *time.Time          `json:"finalized_at,omitempty" db:"finalized_at"`
+	CleanedUpAt   *time.Time          `json:"-" db:"cleaned_up_at"`
+	CreatedAt     time.Time           `json:"created_at" db:"created_at"`
+	// ReplacedKey is the key the finalized upload replaced on its target, if it had one
+	ReplacedKey *string `json:"-" db:"-"`
+}
+
+type CreateUploadSessionData struct {
+	Target        UploadTarget
+	TargetID      uuid.UUID
+	StagingKey    string
+	ContentType   string
+	ContentLength int64
+	ExpiresAt     time.Time
+}
+
+type CreateUploadSessionInput struct {
+	Body struct {
+		Target        UploadTarget `json:"target" enum:"event_header,organization_picture,guardian_avatar,review_photo" doc:"What the image is for"`
+		TargetID      uuid.UUID    `json:"target_id" doc:"ID of the event, organization, guardian or review the image is attached to"`
+		ContentType   string       `json:"content_type" enum:"image/jpeg,image/png,image/webp" doc:"Content type of the file; the upload must send the same Content-Type header"`
+		ContentLength int64        `json:"content_length" minimum:"1" doc:"Size of the file in bytes; the upload must send exactly this many bytes"`
+	}
+}
+
+type CreateUploadSessionOutput struct {
+	Body struct {
+		Session   UploadSession     `json:"session"`
+		UploadURL string            `json:"upload_url" doc:"Presigned S3 URL to PUT the file to"`
+		Headers   map[string]string `json:"headers" doc:"Headers the PUT request must send; they are part of the signature"`
+	}
+}
+
+type FinalizeUploadSessionInput struct {
+	ID uuid.UUID `path:"id"`
+}
+
+type FinalizeUploadSessionOutput struct {
+	Body struct {
+		Session    UploadSession    `json:"session"`
+		URL        *string          `json:"url" doc:"Presigned URL of the processed image"`
+		Renditions []ImageRendition `json:"renditions" doc:"Resized copies of the processed image"`
+	}
+}
@@ -0,0 +1,180 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WalletAccountType identifies which side of the ledger an account belongs to
+type WalletAccountType string
+
+const (
+	WalletAccountTypeGuardian WalletAccountType = "guardian"
+	WalletAccountTypeGoodwill WalletAccountType = "goodwill"
+	WalletAccountTypeRefund   WalletAccountType = "refund"
+	WalletAccountTypeGiftCard WalletAccountType = "gift_card"
+	WalletAccountTypePayment  WalletAccountType = "payment"
+	WalletAccountTypeStripe   WalletAccountType = "stripe"
+)
+
+// WalletTransactionType represents why money moved between two wallet accounts
+type WalletTransactionType string
+
+const (
+	WalletTransactionTypeGoodwillCredit     WalletTransactionType = "goodwill_credit"
+	WalletTransactionTypeRefundCredit       WalletTransactionType = "refund_credit"
+	WalletTransactionTypeGiftCardPurchase   WalletTransactionType = "gift_card_purchase"
+	WalletTransactionTypeGiftCardRedemption WalletTransactionType = "gift_card_redemption"
+	WalletTransactionTypePaymentApplied     WalletTransactionType = "payment_applied"
+	WalletTransactionTypePaymentReversal    WalletTransactionType = "payment_reversal"
+)
+
+type RefundDestination string
+
+const (
+	RefundDestinationCard   RefundDestination = "card"
+	RefundDestinationCredit RefundDestination = "credit"
+)
+
+// WalletEntry is one side of a wallet transaction. Debits are negative, credits positive.
+type WalletEntry struct {
+	ID            uuid.UUID         `json:"id" db:"id" doc:"Unique entry identifier"`
+	TransactionID uuid.UUID         `json:"transaction_id" db:"transaction_id" doc:"Transaction this entry belongs to"`
+	AccountID     uuid.UUID         `json:"account_id" db:"account_id" doc:"Wallet account the entry was posted to"`
+	AccountType   WalletAccountType `json:"account_type" db:"account_type" doc:"Type of the wallet account" enum:"guardian,goodwill,refund,gift_card,payment,stripe"`
+	GuardianID    *uuid.UUID        `json:"guardian_id,omitempty" db:"guardian_id" doc:"Owning guardian for guardian accounts"`
+	Amount        int               `json:"amount" db:"amount" doc:"Signed amount in cents (negative for debits)"`
+	CreatedAt     time.Time         `json:"created_at" db:"created_at" doc:"Timestamp when the entry was posted"`
+}
+
+// WalletTransaction is a balanced movement of credit between two wallet accounts
+type WalletTransaction struct {
+	ID              uuid.UUID             `json:"id" db:"id" doc:"Unique transaction identifier"`
+	TransactionType WalletTransactionType `json:"transaction_type" db:"transaction_type" doc:"Reason for the transaction" enum:"goodwill_credit,refund_credit,gift_card_purchase,gift_card_redemption,payment_applied,payment_reversal"`
+	Amount          int                   `json:"amount" db:"amount" doc:"Amount moved in cents"`
+	Currency        string                `json:"currency" db:"currency" doc:"Currency code (e.g., thb, usd)"`
+	Description     *string               `json:"description,omitempty" db:"description" doc:"Free-form description of the transaction"`
+	RegistrationID  *uuid.UUID            `json:"registration_id,omitempty" db:"registration_id" doc:"Related registration, if any"`
+	GiftCardID      *uuid.UUID            `json:"gift_card_id,omitempty" db:"gift_card_id" doc:"Related gift card, if any"`
+	CreatedAt       time.Time             `json:"created_at" db:"created_at" doc:"Timestamp when the transaction was posted"`
+	Entries         []WalletEntry         `json:"entries" doc:"Ledger entries of the transaction"`
+}
+
+// GuardianWalletTransaction is a wallet transaction seen from the guardian's account
+type GuardianWalletTransaction struct {
+	TransactionID   uuid.UUID             `json:"transaction_id" db:"transaction_id" doc:"Unique transaction identifier"`
+	TransactionType WalletTransactionType `json:"transaction_type" db:"transaction_type" doc:"Reason for the transaction" enum:"goodwill_credit,refund_credit,gift_card_purchase,gift_card_redemption,payment_applied,payment_reversal"`
+	Amount          int                   `json:"amount" db:"amount" doc:"Signed amount in cents (positive adds credit, negative spends it)"`
+	Currency        string                `json:"currency" db:"currency" doc:"Currency code (e.g., thb, usd)"`
+	Description     *string               `json:"description,omitempty" db:"description" doc:"Free-form description of the transaction"`
+	RegistrationID  *uuid.UUID            `json:"registration_id,omitempty" db:"registration_id" doc:"Related registration, if any"`
+	CreatedAt       time.Time             `json:"created_at" db:"created_at" doc:"Timestamp when the transaction was posted"`
+}
+
+// WalletAccountRef identifies an account without knowing its ID.
+// GuardianID is required for guardian accounts and must be nil for system accounts.
+type WalletAccountRef struct {
+	Type       WalletAccountType
+	GuardianID *uuid.UUID
+}
+
+// CreateWalletTransactionData is the internal storage input for posting a transaction.
+// Amount is debited from From and credited to To.
+type CreateWalletTransactionData struct {
+	TransactionType WalletTransactionType
+	Amount          int
+	Currency        string
+	From            WalletAccountRef
+	To              WalletAccountRef
+	Description     *string
+	RegistrationID  *uuid.UUID
+	GiftCardID      *uuid.UUID
+}
+
+type GiftCard struct {
+	ID                    uuid.UUID  `json:"id" db:"id" doc:"Unique gift card identifier"`
+	Code                  string     `json:"code" db:"code" doc:"Redeemable gift card code"`
+	Amount                int        `json:"amount" db:"amount" doc:"Gift card value in cents"`
+	Currency              string     `json:"currency" db:"currency" doc:"Currency code (e.g., thb, usd)"`
+	PurchaserGuardianID   *uuid.UUID `json:"purchaser_guardian_id,omitempty" db:"purchaser_guardian_id" doc:"Guardian who bought the gift card"`
+	RecipientEmail        *string    `json:"recipient_email,omitempty" db:"recipient_email" doc:"Email of the intended recipient"`
+	StripePaymentIntentID *string    `json:"stripe_payment_intent_id,omitempty" db:"stripe_payment_intent_id" doc:"Stripe payment intent that paid for the gift card"`
+	RedeemedByGuardianID  *uuid.UUID `json:"redeemed_by_guardian_id,omitempty" db:"redeemed_by_guardian_id" doc:"Guardian who redeemed the gift card"`
+	RedeemedAt            *time.Time `json:"redeemed_at,omitempty" db:"redeemed_at" doc:"Timestamp when the gift card was redeemed"`
+	CreatedAt             time.Time  `json:"created_at" db:"created_at" doc:"Timestamp when the gift card was created"`
+	UpdatedAt             time.Time  `json:"updated_at" db:"updated_at" doc:"Timestamp when the gift card was last updated"`
+}
+
+// CreateGiftCardData is the internal storage input for issuing a paid gift card
+type CreateGiftCardData struct {
+	Code                  string
+	Amount                int
+	Currency              string
+	PurchaserGuardianID   *uuid.UUID
+	RecipientEmail        *string
+	StripePaymentIntentID *string
+}
+
+type GetWalletByGuardianIDInput struct {
+	GuardianID uuid.UUID `path:"guardian_id" format:"uuid" doc:"Guardian ID"`
+	Currency   string    `query:"currency" default:"thb" pattern:"^[a-z]{3}$" doc:"Currency of the wallet"`
+	Page       int       `query:"page" minimum:"1" default:"1" doc:"Page number (starts at 1)"`
+	PageSize   int       `query:"page_size" minimum:"1" maximum:"100" default:"10" doc:"Number of items per page"`
+}
+
+type GetWalletByGuardianIDOutput struct {
+	Body struct {
+		GuardianID   uuid.UUID                   `json:"guardian_id" doc:"Guardian ID"`
+		Balance      int                         `json:"balance" doc:"Available credit in cents"`
+		Currency     string                      `json:"currency" doc:"Currency code"`
+		Transactions []GuardianWalletTransaction `json:"transactions" doc:"Transaction history, newest first"`
+	} `json:"body"`
+}
+
+type IssueGoodwillCreditInput struct {
+	GuardianID uuid.UUID `path:"guardian_id" format:"uuid" doc:"Guardian to credit"`
+	Body       struct {
+		Amount      int    `json:"amount" minimum:"1" doc:"Credit amount in cents"`
+		Currency    string `json:"currency,omitempty" required:"false" default:"thb" pattern:"^[a-z]{3}$" doc:"Currency code (e.g., thb, usd)"`
+		Description string `json:"description,omitempty" required:"false" doc:"Reason for the goodwill credit"`
+	} `json:"body"`
+}
+
+type IssueGoodwillCreditOutput struct {
+	Body WalletTransaction `json:"body"`
+}
+
+type GetAllWalletTransactionsInput struct {
+	Page     int `query:"page" minimum:"1" default:"1" doc:"Page number (starts at 1)"`
+	PageSize int `query:"page_size" minimum:"1" maximum:"100" default:"10" doc:"Number of items per page"`
+}
+
+type GetAllWalletTransactionsOutput struct {
+	Body []WalletTransaction `json:"body"`
+}
+
+type PurchaseGiftCardInput struct {
+	GuardianID uuid.UUID `path:"guardian_id" format:"uuid" doc:"Guardian purchasing the gift card"`
+	Body       struct {
+		Amount          int     `json:"amount" minimum:"1" doc:"Gift card value in cents"`
+		Currency        string  `json:"currency,omitempty" required:"false" default:"thb" pattern:"^[a-z]{3}$" doc:"Currency code (e.g., thb, usd)"`
+		PaymentMethodID string  `json:"payment_method_id" doc:"Stripe payment method to charge"`
+		RecipientEmail  *string `json:"recipient_email,omitempty" required:"false" doc:"Email of the intended recipient"`
+	} `json:"body"`
+}
+
+type PurchaseGiftCardOutput struct {
+	Body GiftCard `json:"body"`
+}
+
+type RedeemGiftCardInput struct {
+	GuardianID uuid.UUID `path:"guardian_id" format:"uuid" doc:"Guardian redeeming the gift card"`
+	Body       struct {
+		Code string `json:"code" minLength:"1" doc:"Gift card code"`
+	} `json:"body"`
+}
+
+type RedeemGiftCardOutput struct {
+	Body WalletTransaction `json:"body"`
+}
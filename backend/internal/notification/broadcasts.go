@@ -0,0 +1,128 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"skillspark/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type organizationBroadcastMetadata struct {
+	Type            string       `json:"type"`
+	Template        TemplateName `json:"template"`
+	TemplateVersion int          `json:"template_version"`
+	BroadcastID     uuid.UUID    `json:"broadcast_id"`
+	OrganizationID  uuid.UUID    `json:"organization_id"`
+}
+
+// broadcastInboxLinks is where a broadcast's inbox item opens, by what it was sent to
+var broadcastInboxLinks = map[models.BroadcastTargetType]models.InboxLinkType{
+	models.BroadcastTargetEventOccurrence: models.InboxLinkEventOccurrence,
+	models.BroadcastTargetEvent:           models.InboxLinkEvent,
+	models.BroadcastTargetOrganization:    models.InboxLinkOrganization,
+}
+
+// SendBroadcast fans a broadcast out to every guardian currently registered for its target,
+// in each guardian's language, and returns how many guardians it went to. Every recipient
+// gets an inbox item; email and push are queued through the scheduled notification sender,
+// which applies the guardian's organization update preferences. A failure for one guardian
+// does not stop the others.
+func (s *Service) SendBroadcast(ctx context.Context, broadcast *models.Broadcast) (int, error) {
+	recipients, err := s.repo.Broadcast.GetBroadcastRecipients(ctx, broadcast)
+	if err != nil {
+		return 0, err
+	}
+
+	organization, err := s.repo.Organization.GetOrganizationByID(ctx, broadcast.OrganizationID, LanguageEnglish.AcceptLanguage())
+	if err != nil {
+		return 0, err
+	}
+	organizationNames := map[Language]string{LanguageEnglish: organization.Name}
+
+	now := time.Now()
+	sent := 0
+	var errs []error
+	for i := range recipients {
+		guardian := &recipients[i]
+		lang := LanguageFromPreference(guardian.LanguagePreference)
+
+		name, ok := organizationNames[lang]
+		if !ok {
+			name = s.localizedOrganizationName(ctx, broadcast.OrganizationID, lang, organization.Name)
+			organizationNames[lang] = name
+		}
+
+		if err := s.sendBroadcastToGuardian(ctx, broadcast, guardian, lang, name, now); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send broadcast to guardian %s: %w", guardian.ID, err))
+			continue
+		}
+		sent++
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+func (s *Service) sendBroadcastToGuardian(ctx context.Context, broadcast *models.Broadcast, guardian *models.Guardian, lang Language, organizationName string, now time.Time) error {
+	subject, message := broadcast.Message(string(lang))
+
+	rendered, err := RenderTemplate(TemplateOrganizationBroadcast, lang, OrganizationBroadcastData{
+		GuardianName:     guardian.Name,
+		OrganizationName: organizationName,
+		Subject:          subject,
+		Message:          message,
+	}, s.unsubscribeURL(guardian.ID, templateTopics[TemplateOrganizationBroadcast]))
+	if err != nil {
+		return err
+	}
+
+	metadata, err := json.Marshal(organizationBroadcastMetadata{
+		Type:            string(TemplateOrganizationBroadcast),
+		Template:        rendered.Name,
+		TemplateVersion: rendered.Version,
+		BroadcastID:     broadcast.ID,
+		OrganizationID:  broadcast.OrganizationID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode broadcast metadata: %w", err)
+	}
+
+	if err := s.addToInbox(ctx, guardian.ID, rendered, metadata, broadcastInboxLinks[broadcast.TargetType], broadcast.TargetID, nil, nil); err != nil {
+		return err
+	}
+
+	for _, input := range rendered.GuardianInputs(guardian, metadata) {
+		if _, err := s.ScheduleNotification(ctx, &models.CreateScheduledNotificationInput{
+			NotificationType:   input.NotificationType,
+			RecipientEmail:     input.RecipientEmail,
+			RecipientPushToken: input.RecipientPushToken,
+			Subject:            input.Subject,
+			Body:               input.Body,
+			HTMLBody:           input.HTMLBody,
+			Metadata:           input.Metadata,
+			ScheduledFor:       now,
+			GuardianID:         &guardian.ID,
+			BroadcastID:        &broadcast.ID,
+			Topic:              input.Topic,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// localizedOrganizationName returns the organization's name in lang, falling back to the
+// English name when it can't be looked up
+func (s *Service) localizedOrganizationName(ctx context.Context, organizationID uuid.UUID, lang Language, fallback string) string {
+	organization, err := s.repo.Organization.GetOrganizationByID(ctx, organizationID, lang.AcceptLanguage())
+	if err != nil || organization == nil || organization.Name == "" {
+		slog.Warn("Falling back to untranslated organization name", "organization_id", organizationID, "language", lang, "error", err)
+		return fallback
+	}
+	return organization.Name
+}
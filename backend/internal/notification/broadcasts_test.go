@@ -0,0 +1,146 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/models"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendBroadcast(t *testing.T) {
+	pushToken := "ExponentPushToken[abc]"
+	subjectTH := "ย้ายห้องเรียน"
+	bodyTH := "สัปดาห์นี้เรียนที่ห้อง 204"
+	organizationID := uuid.New()
+	occurrenceID := uuid.New()
+
+	broadcast := &models.Broadcast{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		TargetType:     models.BroadcastTargetEventOccurrence,
+		TargetID:       occurrenceID,
+		SubjectEN:      "Room change",
+		BodyEN:         "This week's class is in room 204.",
+		SubjectTH:      &subjectTH,
+		BodyTH:         &bodyTH,
+		ScheduledFor:   time.Now(),
+		Status:         models.BroadcastStatusSending,
+	}
+	english := models.Guardian{ID: uuid.New(), Name: "Alex", Email: "alex@example.com", LanguagePreference: "en", ExpoPushToken: &pushToken}
+	thai := models.Guardian{ID: uuid.New(), Name: "สมชาย", Email: "somchai@example.com", LanguagePreference: "th"}
+
+	mockBroadcastRepo := new(repomocks.MockBroadcastRepository)
+	mockOrgRepo := new(repomocks.MockOrganizationRepository)
+	mockNotifRepo := new(repomocks.MockNotificationRepository)
+	mockInboxRepo := new(repomocks.MockInboxRepository)
+	service := NewService(&storage.Repository{
+		Broadcast:    mockBroadcastRepo,
+		Organization: mockOrgRepo,
+		Notification: mockNotifRepo,
+		Inbox:        mockInboxRepo,
+	}, NewUnsubscribeSigner("https://api.example.com", "secret"), "")
+
+	mockBroadcastRepo.On("GetBroadcastRecipients", mock.Anything, broadcast).Return([]models.Guardian{english, thai}, nil)
+	mockOrgRepo.On("GetOrganizationByID", mock.Anything, organizationID, "en-US").Return(&models.Organization{Name: "Bangkok Robotics"}, nil).Once()
+	mockOrgRepo.On("GetOrganizationByID", mock.Anything, organizationID, "th-TH").Return(&models.Organization{Name: "หุ่นยนต์กรุงเทพ"}, nil).Once()
+
+	var scheduled []*models.CreateScheduledNotificationInput
+	mockNotifRepo.On("CreateScheduledNotification", mock.Anything, mock.AnythingOfType("*models.CreateScheduledNotificationInput")).
+		Run(func(args mock.Arguments) {
+			scheduled = append(scheduled, args.Get(1).(*models.CreateScheduledNotificationInput))
+		}).
+		Return(&models.Notification{}, nil)
+	var inboxItems []*models.CreateInboxItemData
+	mockInboxRepo.On("CreateInboxItem", mock.Anything, mock.AnythingOfType("*models.CreateInboxItemData")).
+		Run(func(args mock.Arguments) {
+			inboxItems = append(inboxItems, args.Get(1).(*models.CreateInboxItemData))
+		}).
+		Return(&models.InboxItem{}, nil)
+
+	sent, err := service.SendBroadcast(context.Background(), broadcast)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	// email and push for the English guardian, email only for the Thai one
+	require.Len(t, scheduled, 3)
+	for _, input := range scheduled {
+		require.NotNil(t, input.BroadcastID)
+		assert.Equal(t, broadcast.ID, *input.BroadcastID)
+		assert.Equal(t, models.NotificationTopicOrganizationUpdates, input.Topic)
+		assert.WithinDuration(t, time.Now(), input.ScheduledFor, time.Minute)
+	}
+	assert.Equal(t, "Bangkok Robotics: Room change", *scheduled[0].Subject)
+	assert.Equal(t, "หุ่นยนต์กรุงเทพ: ย้ายห้องเรียน", *scheduled[2].Subject)
+	assert.Contains(t, scheduled[2].Body, bodyTH)
+
+	require.Len(t, inboxItems, 2)
+	for _, item := range inboxItems {
+		assert.Equal(t, models.InboxLinkEventOccurrence, *item.LinkType)
+		assert.Equal(t, occurrenceID, *item.LinkID)
+		assert.Nil(t, item.VisibleAt)
+	}
+	mockOrgRepo.AssertExpectations(t)
+}
+
+func TestSendBroadcast_ContinuesAfterFailure(t *testing.T) {
+	broadcast := &models.Broadcast{
+		ID:             uuid.New(),
+		OrganizationID: uuid.New(),
+		TargetType:     models.BroadcastTargetOrganization,
+		SubjectEN:      "Closed on Monday",
+		BodyEN:         "We are closed for the holiday.",
+	}
+	broadcast.TargetID = broadcast.OrganizationID
+	failing := models.Guardian{ID: uuid.New(), Name: "Alex", Email: "alex@example.com"}
+	working := models.Guardian{ID: uuid.New(), Name: "Sam", Email: "sam@example.com"}
+
+	mockBroadcastRepo := new(repomocks.MockBroadcastRepository)
+	mockOrgRepo := new(repomocks.MockOrganizationRepository)
+	mockNotifRepo := new(repomocks.MockNotificationRepository)
+	mockInboxRepo := new(repomocks.MockInboxRepository)
+	service := NewService(&storage.Repository{
+		Broadcast:    mockBroadcastRepo,
+		Organization: mockOrgRepo,
+		Notification: mockNotifRepo,
+		Inbox:        mockInboxRepo,
+	}, nil, "")
+
+	mockBroadcastRepo.On("GetBroadcastRecipients", mock.Anything, broadcast).Return([]models.Guardian{failing, working}, nil)
+	mockOrgRepo.On("GetOrganizationByID", mock.Anything, broadcast.OrganizationID, "en-US").Return(&models.Organization{Name: "Bangkok Robotics"}, nil)
+	mockInboxRepo.On("CreateInboxItem", mock.Anything, mock.MatchedBy(func(input *models.CreateInboxItemData) bool {
+		return input.GuardianID == failing.ID
+	})).Return(nil, errors.New("db down"))
+	mockInboxRepo.On("CreateInboxItem", mock.Anything, mock.MatchedBy(func(input *models.CreateInboxItemData) bool {
+		return input.GuardianID == working.ID && *input.LinkType == models.InboxLinkOrganization
+	})).Return(&models.InboxItem{}, nil)
+	mockNotifRepo.On("CreateScheduledNotification", mock.Anything, mock.MatchedBy(func(input *models.CreateScheduledNotificationInput) bool {
+		return *input.GuardianID == working.ID
+	})).Return(&models.Notification{}, nil).Once()
+
+	sent, err := service.SendBroadcast(context.Background(), broadcast)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, sent)
+	mockNotifRepo.AssertExpectations(t)
+}
+
+func TestSendBroadcast_RecipientLookupFails(t *testing.T) {
+	mockBroadcastRepo := new(repomocks.MockBroadcastRepository)
+	service := NewService(&storage.Repository{Broadcast: mockBroadcastRepo}, nil, "")
+	broadcast := &models.Broadcast{ID: uuid.New()}
+
+	mockBroadcastRepo.On("GetBroadcastRecipients", mock.Anything, broadcast).Return(nil, errors.New("db down"))
+
+	sent, err := service.SendBroadcast(context.Background(), broadcast)
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, sent)
+}
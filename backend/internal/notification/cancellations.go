@@ -0,0 +1,101 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"skillspark/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type eventCancelledMetadata struct {
+	Type              string       `json:"type"`
+	Template          TemplateName `json:"template"`
+	TemplateVersion   int          `json:"template_version"`
+	RegistrationID    uuid.UUID    `json:"registration_id"`
+	EventOccurrenceID uuid.UUID    `json:"event_occurrence_id"`
+}
+
+// SendEventCancellationNotices tells the guardian behind each registration, in their language,
+// that its occurrence was cancelled. The notices are urgent, so they go out during quiet hours.
+// registrations are the occurrence's registrations as they were before it was cancelled; ones
+// that were already cancelled are skipped. A failure for one registration does not stop the others.
+func (s *Service) SendEventCancellationNotices(ctx context.Context, registrations []models.Registration) error {
+	guardians := make(map[uuid.UUID]*models.Guardian)
+	var errs []error
+	for _, registration := range registrations {
+		if registration.Status != models.RegistrationStatusRegistered {
+			continue
+		}
+
+		guardian, ok := guardians[registration.GuardianID]
+		if !ok {
+			var err error
+			guardian, err = s.repo.Guardian.GetGuardianByID(ctx, registration.GuardianID)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to get guardian %s: %w", registration.GuardianID, err))
+				continue
+			}
+			guardians[registration.GuardianID] = guardian
+		}
+
+		if err := s.sendEventCancellationNotice(ctx, &registration, guardian); err != nil {
+			errs = append(errs, fmt.Errorf("failed to notify cancellation for registration %s: %w", registration.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *Service) sendEventCancellationNotice(ctx context.Context, registration *models.Registration, guardian *models.Guardian) error {
+	lang := LanguageFromPreference(guardian.LanguagePreference)
+
+	rendered, err := RenderTemplate(TemplateEventCancelled, lang, EventCancelledData{
+		GuardianName: guardian.Name,
+		EventName:    s.localizedEventName(ctx, registration, lang),
+		StartTime:    registration.OccurrenceStartTime,
+	}, s.unsubscribeURL(guardian.ID, templateTopics[TemplateEventCancelled]))
+	if err != nil {
+		return err
+	}
+
+	metadata, err := json.Marshal(eventCancelledMetadata{
+		Type:              string(TemplateEventCancelled),
+		Template:          rendered.Name,
+		TemplateVersion:   rendered.Version,
+		RegistrationID:    registration.ID,
+		EventOccurrenceID: registration.EventOccurrenceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode cancellation metadata: %w", err)
+	}
+
+	if err := s.addToInbox(ctx, guardian.ID, rendered, metadata, models.InboxLinkRegistration, registration.ID, nil, nil); err != nil {
+		return err
+	}
+
+	// not tied to the registration, so clearing its pending reminders leaves these alone
+	now := time.Now()
+	for _, input := range rendered.GuardianInputs(guardian, metadata) {
+		if _, err := s.ScheduleNotification(ctx, &models.CreateScheduledNotificationInput{
+			NotificationType:   input.NotificationType,
+			RecipientEmail:     input.RecipientEmail,
+			RecipientPushToken: input.RecipientPushToken,
+			Subject:            input.Subject,
+			Body:               input.Body,
+			HTMLBody:           input.HTMLBody,
+			Metadata:           input.Metadata,
+			ScheduledFor:       now,
+			GuardianID:         &guardian.ID,
+			Topic:              input.Topic,
+			Urgent:             true,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
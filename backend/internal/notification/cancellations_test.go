@@ -0,0 +1,67 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/models"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendEventCancellationNotices(t *testing.T) {
+	mockNotifRepo := new(repomocks.MockNotificationRepository)
+	mockGuardianRepo := new(repomocks.MockGuardianRepository)
+	mockInboxRepo := new(repomocks.MockInboxRepository)
+	service := NewService(&storage.Repository{
+		Notification: mockNotifRepo,
+		Guardian:     mockGuardianRepo,
+		Inbox:        mockInboxRepo,
+	}, nil, "")
+
+	pushToken := "ExponentPushToken[abc]"
+	guardian := &models.Guardian{ID: uuid.New(), Name: "Alex", Email: "parent@example.com", LanguagePreference: "en", ExpoPushToken: &pushToken}
+	missing := uuid.New()
+	occurrenceID := uuid.New()
+	start := time.Now().Add(3 * time.Hour)
+
+	registrations := []models.Registration{
+		{ID: uuid.New(), GuardianID: guardian.ID, EventOccurrenceID: occurrenceID, EventName: "Robotics Club", Status: models.RegistrationStatusRegistered, OccurrenceStartTime: start},
+		{ID: uuid.New(), GuardianID: uuid.New(), EventOccurrenceID: occurrenceID, EventName: "Robotics Club", Status: models.RegistrationStatusCancelled, OccurrenceStartTime: start},
+		{ID: uuid.New(), GuardianID: missing, EventOccurrenceID: occurrenceID, EventName: "Robotics Club", Status: models.RegistrationStatusRegistered, OccurrenceStartTime: start},
+	}
+
+	mockGuardianRepo.On("GetGuardianByID", mock.Anything, guardian.ID).Return(guardian, nil).Once()
+	mockGuardianRepo.On("GetGuardianByID", mock.Anything, missing).Return(nil, errors.New("not found")).Once()
+	mockInboxRepo.On("CreateInboxItem", mock.Anything, mock.AnythingOfType("*models.CreateInboxItemData")).Return(&models.InboxItem{}, nil).Once()
+
+	var scheduled []*models.CreateScheduledNotificationInput
+	mockNotifRepo.On("CreateScheduledNotification", mock.Anything, mock.AnythingOfType("*models.CreateScheduledNotificationInput")).
+		Run(func(args mock.Arguments) {
+			scheduled = append(scheduled, args.Get(1).(*models.CreateScheduledNotificationInput))
+		}).
+		Return(&models.Notification{}, nil)
+
+	err := service.SendEventCancellationNotices(context.Background(), registrations)
+
+	// the missing guardian is reported, but the others are still notified
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), missing.String())
+
+	require.Len(t, scheduled, 2)
+	for _, input := range scheduled {
+		assert.True(t, input.Urgent)
+		assert.Nil(t, input.RegistrationID)
+		assert.Equal(t, models.NotificationTopicRegistrations, input.Topic)
+		assert.WithinDuration(t, time.Now(), input.ScheduledFor, time.Minute)
+	}
+	assert.Equal(t, "Cancelled: Robotics Club on "+start.In(bangkok).Format("January 2, 2006"), *scheduled[0].Subject)
+	mockGuardianRepo.AssertExpectations(t)
+	mockInboxRepo.AssertExpectations(t)
+}
@@ -0,0 +1,62 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"skillspark/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type registrationConfirmedMetadata struct {
+	Type              string       `json:"type"`
+	Template          TemplateName `json:"template"`
+	TemplateVersion   int          `json:"template_version"`
+	RegistrationID    uuid.UUID    `json:"registration_id"`
+	EventOccurrenceID uuid.UUID    `json:"event_occurrence_id"`
+}
+
+// SendRegistrationConfirmation tells the guardian, in their language, that the registration
+// went through. It goes out right away on the channels the guardian accepts registration
+// notifications on; the inbox always gets a copy.
+func (s *Service) SendRegistrationConfirmation(ctx context.Context, registration *models.Registration, guardian *models.Guardian) error {
+	lang := LanguageFromPreference(guardian.LanguagePreference)
+
+	rendered, err := RenderTemplate(TemplateRegistrationConfirmed, lang, RegistrationConfirmedData{
+		GuardianName: guardian.Name,
+		EventName:    s.localizedEventName(ctx, registration, lang),
+		StartTime:    registration.OccurrenceStartTime,
+	}, s.unsubscribeURL(guardian.ID, templateTopics[TemplateRegistrationConfirmed]))
+	if err != nil {
+		return err
+	}
+
+	metadata, err := json.Marshal(registrationConfirmedMetadata{
+		Type:              string(TemplateRegistrationConfirmed),
+		Template:          rendered.Name,
+		TemplateVersion:   rendered.Version,
+		RegistrationID:    registration.ID,
+		EventOccurrenceID: registration.EventOccurrenceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode confirmation metadata: %w", err)
+	}
+
+	// one confirmation per channel, and in the inbox, however often the registration's side
+	// effects are retried
+	var errs []error
+	dedupKey := fmt.Sprintf("registration_confirmed:%s", registration.ID)
+	if err := s.addToInbox(ctx, guardian.ID, rendered, metadata, models.InboxLinkRegistration, registration.ID, nil, &dedupKey); err != nil {
+		errs = append(errs, err)
+	}
+	for _, input := range rendered.GuardianInputs(guardian, metadata) {
+		input.DedupID = fmt.Sprintf("registration_confirmed:%s:%s", registration.ID, input.NotificationType)
+		if err := s.SendNotification(ctx, input); err != nil && !errors.Is(err, ErrChannelDisabled) {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
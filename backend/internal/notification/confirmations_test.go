@@ -0,0 +1,152 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"html"
+	"skillspark/internal/models"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingOutbox keeps the notification messages queued in the outbox, dropping repeated
+// dedup IDs like the real table does
+type recordingOutbox struct {
+	storage.OutboxRepository
+	messages []models.NotificationMessage
+	dedupIDs []string
+}
+
+func (o *recordingOutbox) EnqueueOutboxMessage(ctx context.Context, input *models.EnqueueOutboxMessageData) (*models.OutboxMessage, error) {
+	if slices.Contains(o.dedupIDs, input.DedupID) {
+		return nil, nil
+	}
+	var message models.NotificationMessage
+	if err := json.Unmarshal(input.Payload, &message); err != nil {
+		return nil, err
+	}
+	o.dedupIDs = append(o.dedupIDs, input.DedupID)
+	o.messages = append(o.messages, message)
+	return &models.OutboxMessage{Topic: input.Topic, Payload: input.Payload, DedupID: input.DedupID}, nil
+}
+
+func TestSendRegistrationConfirmation(t *testing.T) {
+	pushToken := "ExponentPushToken[abc]"
+
+	tests := []struct {
+		name      string
+		prefs     models.GuardianNotificationPreferences
+		wantTypes []models.NotificationType
+	}{
+		{
+			name:      "email and push enabled",
+			prefs:     models.GuardianNotificationPreferences{EmailNotifications: true, PushNotifications: true},
+			wantTypes: []models.NotificationType{models.NotificationTypeEmail, models.NotificationTypePush},
+		},
+		{
+			name:      "push disabled",
+			prefs:     models.GuardianNotificationPreferences{EmailNotifications: true},
+			wantTypes: []models.NotificationType{models.NotificationTypeEmail},
+		},
+		{
+			name: "registrations turned off for email",
+			prefs: models.GuardianNotificationPreferences{
+				EmailNotifications: true,
+				PushNotifications:  true,
+				Topics: map[models.NotificationTopic]map[models.NotificationType]bool{
+					models.NotificationTopicRegistrations: {models.NotificationTypeEmail: false},
+				},
+			},
+			wantTypes: []models.NotificationType{models.NotificationTypePush},
+		},
+		{
+			name:  "all channels disabled",
+			prefs: models.GuardianNotificationPreferences{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outbox := &recordingOutbox{}
+			mockInboxRepo := new(repomocks.MockInboxRepository)
+			mockGuardianRepo := new(repomocks.MockGuardianRepository)
+			signer := NewUnsubscribeSigner("https://api.example.com", "secret")
+			service := NewService(&storage.Repository{Inbox: mockInboxRepo, Guardian: mockGuardianRepo, Outbox: outbox}, signer, "")
+
+			guardian := &models.Guardian{ID: uuid.New(), Name: "Alex", Email: "parent@example.com", ExpoPushToken: &pushToken}
+			mockGuardianRepo.On("GetGuardianNotificationPreferences", mock.Anything, []uuid.UUID{guardian.ID}).
+				Return(map[uuid.UUID]models.GuardianNotificationPreferences{guardian.ID: tt.prefs}, nil)
+
+			registration := &models.Registration{
+				ID:                  uuid.New(),
+				EventOccurrenceID:   uuid.New(),
+				EventName:           "Robotics Club",
+				OccurrenceStartTime: time.Now().Add(48 * time.Hour),
+			}
+
+			// the inbox gets a copy even when every channel is off
+			mockInboxRepo.On("CreateInboxItem", mock.Anything, mock.MatchedBy(func(input *models.CreateInboxItemData) bool {
+				return input.GuardianID == guardian.ID &&
+					*input.LinkType == models.InboxLinkRegistration &&
+					*input.LinkID == registration.ID &&
+					*input.DeepLink == "skillspark://registrations/"+registration.ID.String() &&
+					input.Kind == string(TemplateRegistrationConfirmed) &&
+					input.VisibleAt == nil
+			})).Return(&models.InboxItem{}, nil).Once()
+
+			err := service.SendRegistrationConfirmation(context.Background(), registration, guardian)
+
+			require.NoError(t, err)
+			require.Len(t, outbox.messages, len(tt.wantTypes))
+			for i, message := range outbox.messages {
+				assert.Equal(t, tt.wantTypes[i], message.NotificationType)
+				if message.NotificationType == models.NotificationTypeEmail {
+					assert.Equal(t, "Registration Confirmed: Robotics Club", *message.Subject)
+					require.NotNil(t, message.HTMLBody)
+					assert.Contains(t, *message.HTMLBody, "Robotics Club")
+					require.NotNil(t, message.UnsubscribeURL)
+					assert.Equal(t, signer.URL(guardian.ID, models.NotificationTopicRegistrations), *message.UnsubscribeURL)
+					assert.Contains(t, html.UnescapeString(*message.HTMLBody), *message.UnsubscribeURL)
+				} else {
+					assert.Nil(t, message.UnsubscribeURL)
+				}
+			}
+			mockInboxRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSendRegistrationConfirmation_RetryQueuesOnce(t *testing.T) {
+	pushToken := "ExponentPushToken[abc]"
+	outbox := &recordingOutbox{}
+	mockInboxRepo := new(repomocks.MockInboxRepository)
+	mockGuardianRepo := new(repomocks.MockGuardianRepository)
+	service := NewService(&storage.Repository{Inbox: mockInboxRepo, Guardian: mockGuardianRepo, Outbox: outbox}, nil, "")
+
+	guardian := &models.Guardian{ID: uuid.New(), Name: "Alex", Email: "parent@example.com", ExpoPushToken: &pushToken}
+	mockGuardianRepo.On("GetGuardianNotificationPreferences", mock.Anything, []uuid.UUID{guardian.ID}).
+		Return(map[uuid.UUID]models.GuardianNotificationPreferences{guardian.ID: {EmailNotifications: true, PushNotifications: true}}, nil)
+	registration := &models.Registration{ID: uuid.New(), EventName: "Robotics Club", OccurrenceStartTime: time.Now().Add(48 * time.Hour)}
+	mockInboxRepo.On("CreateInboxItem", mock.Anything, mock.MatchedBy(func(input *models.CreateInboxItemData) bool {
+		return input.DedupKey != nil && *input.DedupKey == "registration_confirmed:"+registration.ID.String()
+	})).Return(&models.InboxItem{}, nil).Once()
+	// the item is already there, so the retry adds nothing
+	mockInboxRepo.On("CreateInboxItem", mock.Anything, mock.Anything).Return(nil, nil).Once()
+
+	// the relay runs the registration's side effects again after a failed attempt
+	require.NoError(t, service.SendRegistrationConfirmation(context.Background(), registration, guardian))
+	require.NoError(t, service.SendRegistrationConfirmation(context.Background(), registration, guardian))
+
+	require.Len(t, outbox.messages, 2)
+	assert.Equal(t, "registration_confirmed:"+registration.ID.String()+":email", outbox.messages[0].DedupID)
+	assert.Equal(t, "registration_confirmed:"+registration.ID.String()+":push", outbox.messages[1].DedupID)
+	mockInboxRepo.AssertExpectations(t)
+}
@@ -0,0 +1,223 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/recommend"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// digestRadiusKm is how far from a child's school listed activities can be
+	digestRadiusKm = 25
+	// digestLookahead is how soon a listed activity must have an occurrence
+	digestLookahead = 14 * 24 * time.Hour
+	// digestCandidates is how many recommendations are looked at for each child
+	digestCandidates = 10
+	// digestActivitiesPerChild is the most activities listed for one child
+	digestActivitiesPerChild = 3
+	// digestRepeatWindow keeps an event out of a family's digests for a while after it was listed
+	digestRepeatWindow = 28 * 24 * time.Hour
+	// digestNewWindow is how recently an event, or one of its occurrences, must have been
+	// added to be listed, a week so each digest covers what was added since the last
+	digestNewWindow = 7 * 24 * time.Hour
+)
+
+type weeklyDigestMetadata struct {
+	Type            string       `json:"type"`
+	Template        TemplateName `json:"template"`
+	TemplateVersion int          `json:"template_version"`
+	DigestID        uuid.UUID    `json:"digest_id"`
+}
+
+// SendWeeklyDigest sends the guardian's digest for the week starting weekStart, listing
+// activities added in the last week, or with an occurrence added, that come up soon near
+// each child's school and match the child's interests and age, in the order
+// recommendations are ranked. Children without a good match are left out, and nothing is
+// sent when none of them has one. Like every notification, the digest also goes to the
+// guardian's inbox. It reports whether a digest was sent; a guardian who already got this
+// week's digest is not sent another.
+func (s *Service) SendWeeklyDigest(ctx context.Context, guardian *models.Guardian, weekStart time.Time) (bool, error) {
+	children, err := s.repo.Digest.GetDigestChildren(ctx, guardian.ID)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	recent, err := s.repo.Digest.GetRecentlyDigestedEventIDs(ctx, guardian.ID, now.Add(-digestRepeatWindow))
+	if err != nil {
+		return false, err
+	}
+	listed := make(map[uuid.UUID]bool, len(recent))
+	for _, id := range recent {
+		listed[id] = true
+	}
+
+	lang := LanguageFromPreference(guardian.LanguagePreference)
+	var items []models.CreateDigestItemData
+	var sections []DigestChildSection
+	for _, child := range children {
+		events, err := s.digestCandidates(ctx, child, lang, now)
+		if err != nil {
+			return false, err
+		}
+
+		section := DigestChildSection{ChildName: child.Name}
+		for _, event := range events {
+			if len(section.Activities) == digestActivitiesPerChild {
+				break
+			}
+			// a sibling may already have it, or an earlier digest listed it
+			if listed[event.ID] || interestMatches(event.Category, child.Interests) == 0 {
+				continue
+			}
+			listed[event.ID] = true
+			items = append(items, models.CreateDigestItemData{ChildID: child.ID, EventID: event.ID})
+			section.Activities = append(section.Activities, DigestActivity{Title: event.Title})
+		}
+		if len(section.Activities) > 0 {
+			sections = append(sections, section)
+		}
+	}
+
+	if len(items) == 0 {
+		return false, nil
+	}
+
+	// IDs are chosen up front so the links can point at the items before they are stored
+	digestID := uuid.New()
+	next := 0
+	for i := range sections {
+		for j := range sections[i].Activities {
+			items[next].ID = uuid.New()
+			sections[i].Activities[j].URL = s.digestItemURL(items[next].ID)
+			next++
+		}
+	}
+
+	rendered, err := RenderTemplate(TemplateWeeklyDigest, lang, WeeklyDigestData{
+		GuardianName:  guardian.Name,
+		WeekStart:     weekStart,
+		Children:      sections,
+		ActivityCount: len(items),
+	}, s.unsubscribeURL(guardian.ID, templateTopics[TemplateWeeklyDigest]))
+	if err != nil {
+		return false, err
+	}
+
+	metadata, err := json.Marshal(weeklyDigestMetadata{
+		Type:            string(TemplateWeeklyDigest),
+		Template:        rendered.Name,
+		TemplateVersion: rendered.Version,
+		DigestID:        digestID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to encode digest metadata: %w", err)
+	}
+
+	var notifications []models.CreateScheduledNotificationInput
+	for _, input := range rendered.GuardianInputs(guardian, metadata) {
+		if err := validateNotificationInput(input.NotificationType, input.RecipientEmail, input.RecipientPushToken); err != nil {
+			return false, err
+		}
+		notifications = append(notifications, models.CreateScheduledNotificationInput{
+			NotificationType:   input.NotificationType,
+			RecipientEmail:     input.RecipientEmail,
+			RecipientPushToken: input.RecipientPushToken,
+			Subject:            input.Subject,
+			Body:               input.Body,
+			HTMLBody:           input.HTMLBody,
+			Metadata:           input.Metadata,
+			ScheduledFor:       now,
+			GuardianID:         &guardian.ID,
+			Topic:              input.Topic,
+		})
+	}
+
+	// the notifications and inbox item are stored with the digest, so a digest that failed
+	// to schedule them isn't recorded and the next run sends it
+	if _, err := s.repo.Digest.CreateDigest(ctx, &models.CreateDigestData{
+		ID:            digestID,
+		GuardianID:    guardian.ID,
+		WeekStart:     weekStart,
+		Items:         items,
+		Notifications: notifications,
+		InboxItem: &models.CreateInboxItemData{
+			GuardianID: guardian.ID,
+			Kind:       string(rendered.Name),
+			Title:      rendered.PushTitle,
+			Body:       rendered.PushBody,
+			Metadata:   metadata,
+		},
+	}); err != nil {
+		var httpErr *errs.HTTPError
+		if errors.As(err, &httpErr) && httpErr.Code == http.StatusConflict {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// digestCandidates returns the child's best recommendations that were added in the last
+// week or had an occurrence added, with an occurrence coming up soon, near their school when it has a location, ranked the way the app ranks them
+func (s *Service) digestCandidates(ctx context.Context, child models.DigestChild, lang Language, now time.Time) ([]models.Event, error) {
+	filters := models.RecommendationFilters{
+		MinDate:  now,
+		MaxDate:  now.Add(digestLookahead),
+		NewSince: now.Add(-digestNewWindow),
+	}
+	if child.Latitude != nil && child.Longitude != nil {
+		filters.Latitude = models.OptionalFloat64{Value: *child.Latitude, Set: true}
+		filters.Longitude = models.OptionalFloat64{Value: *child.Longitude, Set: true}
+		filters.RadiusKm = digestRadiusKm
+	}
+
+	candidates, err := s.repo.Recommendation.GetRecommendationCandidates(ctx, child.ID, child.Interests, child.BirthYear, lang.AcceptLanguage(), digestCandidates, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := recommend.Rank(candidates, lang.AcceptLanguage())
+	events := make([]models.Event, len(ranked))
+	for i, recommendation := range ranked {
+		events[i] = recommendation.Event
+	}
+	return events, nil
+}
+
+// digestItemURL is the click-tracked link for a digest item, which redirects to the event
+func (s *Service) digestItemURL(itemID uuid.UUID) string {
+	return fmt.Sprintf("%s/api/v1/digests/items/%s/open", s.publicAPIURL, itemID)
+}
+
+// interestMatches counts the event's categories the child is interested in, the interest part
+// of the score recommendations are ranked by
+func interestMatches(categories []string, interests []string) int {
+	matches := 0
+	for _, category := range categories {
+		for _, interest := range interests {
+			if category == interest {
+				matches++
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// DigestWeekStart is the Monday of t's week in Bangkok, as a date at midnight UTC so it is
+// stored as that day whatever the database timezone
+func DigestWeekStart(t time.Time) time.Time {
+	local := t.In(bangkok)
+	offset := (int(local.Weekday()) + 6) % 7
+	return time.Date(local.Year(), local.Month(), local.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
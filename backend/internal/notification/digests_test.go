@@ -0,0 +1,200 @@
+package notification
+
+import (
+	"context"
+	"html"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var digestWeekStart = time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+
+// digestCandidate is an event from its own organization, scored by how many of the
+// categories match
+func digestCandidate(title string, matches int, categories ...string) models.RecommendationCandidate {
+	return models.RecommendationCandidate{
+		Event:      models.Event{ID: uuid.New(), OrganizationID: uuid.New(), Title: title, Category: categories},
+		Components: models.RecommendationScoreComponents{Interest: float64(matches)},
+	}
+}
+
+func TestSendWeeklyDigest(t *testing.T) {
+	pushToken := "ExponentPushToken[abc]"
+	lat, lng := 13.7563, 100.5018
+	guardian := &models.Guardian{ID: uuid.New(), Name: "Alex", Email: "alex@example.com", LanguagePreference: "en", ExpoPushToken: &pushToken}
+	robots := models.DigestChild{ID: uuid.New(), Name: "Mali", BirthYear: 2017, Interests: []string{"science", "technology"}, Latitude: &lat, Longitude: &lng}
+	painter := models.DigestChild{ID: uuid.New(), Name: "Niran", BirthYear: 2019, Interests: []string{"art"}}
+	bored := models.DigestChild{ID: uuid.New(), Name: "Ploy", BirthYear: 2015, Interests: []string{"music"}}
+
+	robotics := digestCandidate("Robotics Club", 2, "science", "technology")
+	coding := digestCandidate("Coding for Kids", 1, "technology")
+	alreadySent := digestCandidate("Chemistry Lab", 1, "science")
+	painting := digestCandidate("Watercolor Painting", 1, "art")
+	// the recommendation query still returns events that match none of the interests
+	swimming := digestCandidate("Swimming", 0, "sports")
+
+	mockDigestRepo := new(repomocks.MockDigestRepository)
+	mockRecRepo := new(repomocks.MockRecommendationRepository)
+	mockNotifRepo := new(repomocks.MockNotificationRepository)
+	service := NewService(&storage.Repository{
+		Digest:         mockDigestRepo,
+		Recommendation: mockRecRepo,
+		Notification:   mockNotifRepo,
+	}, NewUnsubscribeSigner("https://api.example.com", "secret"), "https://api.example.com/")
+
+	mockDigestRepo.On("GetDigestChildren", mock.Anything, guardian.ID).Return([]models.DigestChild{robots, painter, bored}, nil)
+	mockDigestRepo.On("GetRecentlyDigestedEventIDs", mock.Anything, guardian.ID, mock.AnythingOfType("time.Time")).Return([]uuid.UUID{alreadySent.ID}, nil)
+	// near the school for the child whose school has a location, anywhere for the other
+	mockRecRepo.On("GetRecommendationCandidates", mock.Anything, robots.ID, robots.Interests, robots.BirthYear, "en-US", digestCandidates,
+		mock.MatchedBy(func(f models.RecommendationFilters) bool {
+			return f.Latitude.Set && f.Latitude.Value == lat && f.RadiusKm == digestRadiusKm && f.MaxDate.After(f.MinDate) &&
+				f.NewSince.Equal(f.MinDate.Add(-digestNewWindow))
+		})).Return([]models.RecommendationCandidate{robotics, alreadySent, coding, swimming}, nil)
+	mockRecRepo.On("GetRecommendationCandidates", mock.Anything, painter.ID, painter.Interests, painter.BirthYear, "en-US", mock.Anything,
+		mock.MatchedBy(func(f models.RecommendationFilters) bool { return !f.Latitude.Set })).
+		Return([]models.RecommendationCandidate{painting, robotics}, nil)
+	mockRecRepo.On("GetRecommendationCandidates", mock.Anything, bored.ID, bored.Interests, bored.BirthYear, "en-US", mock.Anything, mock.Anything).
+		Return([]models.RecommendationCandidate{swimming}, nil)
+
+	var created *models.CreateDigestData
+	mockDigestRepo.On("CreateDigest", mock.Anything, mock.AnythingOfType("*models.CreateDigestData")).
+		Run(func(args mock.Arguments) { created = args.Get(1).(*models.CreateDigestData) }).
+		Return(&models.Digest{}, nil)
+
+	sent, err := service.SendWeeklyDigest(context.Background(), guardian, digestWeekStart)
+
+	require.NoError(t, err)
+	assert.True(t, sent)
+
+	require.NotNil(t, created)
+	assert.NotEqual(t, uuid.Nil, created.ID)
+	assert.Equal(t, digestWeekStart, created.WeekStart)
+	require.Len(t, created.Items, 3)
+	for _, item := range created.Items {
+		assert.NotEqual(t, uuid.Nil, item.ID)
+	}
+	assert.Equal(t, []models.CreateDigestItemData{
+		{ID: created.Items[0].ID, ChildID: robots.ID, EventID: robotics.ID},
+		{ID: created.Items[1].ID, ChildID: robots.ID, EventID: coding.ID},
+		{ID: created.Items[2].ID, ChildID: painter.ID, EventID: painting.ID},
+	}, created.Items)
+
+	// the notifications are stored with the digest and link to its items
+	scheduled := created.Notifications
+	require.Len(t, scheduled, 2)
+	email := scheduled[0]
+	assert.Equal(t, models.NotificationTypeEmail, email.NotificationType)
+	assert.Equal(t, models.NotificationTopicWeeklyDigest, email.Topic)
+	assert.Equal(t, &guardian.ID, email.GuardianID)
+	assert.Contains(t, email.Body, "For Mali")
+	assert.Contains(t, email.Body, "Robotics Club: https://api.example.com/api/v1/digests/items/"+created.Items[0].ID.String()+"/open")
+	assert.Contains(t, string(email.Metadata), created.ID.String())
+	assert.NotContains(t, email.Body, "Ploy")
+	assert.NotContains(t, email.Body, "Chemistry Lab")
+	assert.Equal(t, 3, strings.Count(html.UnescapeString(*email.HTMLBody), "/open\""))
+	assert.Equal(t, "3 new activities picked for your family", scheduled[1].Body)
+
+	// and so is the inbox item
+	require.NotNil(t, created.InboxItem)
+	assert.Equal(t, guardian.ID, created.InboxItem.GuardianID)
+	assert.Equal(t, string(TemplateWeeklyDigest), created.InboxItem.Kind)
+	assert.Equal(t, "3 new activities picked for your family", created.InboxItem.Body)
+	assert.Contains(t, string(created.InboxItem.Metadata), created.ID.String())
+	mockRecRepo.AssertExpectations(t)
+	mockNotifRepo.AssertNotCalled(t, "CreateScheduledNotification", mock.Anything, mock.Anything)
+}
+
+func TestSendWeeklyDigest_CreateFails(t *testing.T) {
+	guardian := &models.Guardian{ID: uuid.New(), Email: "alex@example.com"}
+	child := models.DigestChild{ID: uuid.New(), Name: "Niran", BirthYear: 2019, Interests: []string{"art"}}
+
+	mockDigestRepo := new(repomocks.MockDigestRepository)
+	mockRecRepo := new(repomocks.MockRecommendationRepository)
+	service := NewService(&storage.Repository{Digest: mockDigestRepo, Recommendation: mockRecRepo}, nil, "")
+
+	mockDigestRepo.On("GetDigestChildren", mock.Anything, guardian.ID).Return([]models.DigestChild{child}, nil)
+	mockDigestRepo.On("GetRecentlyDigestedEventIDs", mock.Anything, guardian.ID, mock.Anything).Return([]uuid.UUID{}, nil)
+	mockRecRepo.On("GetRecommendationCandidates", mock.Anything, child.ID, child.Interests, child.BirthYear, "en-US", mock.Anything, mock.Anything).
+		Return([]models.RecommendationCandidate{digestCandidate("Watercolor Painting", 1, "art")}, nil)
+	failed := errs.InternalServerError("Failed to create scheduled notification: ", "connection reset")
+	mockDigestRepo.On("CreateDigest", mock.Anything, mock.Anything).Return(nil, &failed).Once()
+	mockDigestRepo.On("CreateDigest", mock.Anything, mock.Anything).Return(&models.Digest{}, nil).Once()
+
+	sent, err := service.SendWeeklyDigest(context.Background(), guardian, digestWeekStart)
+
+	require.Error(t, err)
+	assert.False(t, sent)
+
+	// nothing was recorded, so the rerun sends the digest
+	sent, err = service.SendWeeklyDigest(context.Background(), guardian, digestWeekStart)
+
+	require.NoError(t, err)
+	assert.True(t, sent)
+	mockDigestRepo.AssertExpectations(t)
+}
+
+func TestSendWeeklyDigest_NoGoodMatches(t *testing.T) {
+	guardian := &models.Guardian{ID: uuid.New(), Email: "alex@example.com"}
+	child := models.DigestChild{ID: uuid.New(), Name: "Ploy", BirthYear: 2015, Interests: []string{"music"}}
+
+	mockDigestRepo := new(repomocks.MockDigestRepository)
+	mockRecRepo := new(repomocks.MockRecommendationRepository)
+	service := NewService(&storage.Repository{Digest: mockDigestRepo, Recommendation: mockRecRepo}, nil, "")
+
+	mockDigestRepo.On("GetDigestChildren", mock.Anything, guardian.ID).Return([]models.DigestChild{child}, nil)
+	mockDigestRepo.On("GetRecentlyDigestedEventIDs", mock.Anything, guardian.ID, mock.Anything).Return([]uuid.UUID{}, nil)
+	mockRecRepo.On("GetRecommendationCandidates", mock.Anything, child.ID, child.Interests, child.BirthYear, "en-US", mock.Anything, mock.Anything).
+		Return([]models.RecommendationCandidate{digestCandidate("Swimming", 0, "sports")}, nil)
+
+	sent, err := service.SendWeeklyDigest(context.Background(), guardian, digestWeekStart)
+
+	require.NoError(t, err)
+	assert.False(t, sent)
+	mockDigestRepo.AssertNotCalled(t, "CreateDigest", mock.Anything, mock.Anything)
+}
+
+func TestSendWeeklyDigest_AlreadySentThisWeek(t *testing.T) {
+	guardian := &models.Guardian{ID: uuid.New(), Email: "alex@example.com"}
+	child := models.DigestChild{ID: uuid.New(), Name: "Niran", BirthYear: 2019, Interests: []string{"art"}}
+
+	mockDigestRepo := new(repomocks.MockDigestRepository)
+	mockRecRepo := new(repomocks.MockRecommendationRepository)
+	mockNotifRepo := new(repomocks.MockNotificationRepository)
+	service := NewService(&storage.Repository{Digest: mockDigestRepo, Recommendation: mockRecRepo, Notification: mockNotifRepo}, nil, "")
+
+	mockDigestRepo.On("GetDigestChildren", mock.Anything, guardian.ID).Return([]models.DigestChild{child}, nil)
+	mockDigestRepo.On("GetRecentlyDigestedEventIDs", mock.Anything, guardian.ID, mock.Anything).Return([]uuid.UUID{}, nil)
+	mockRecRepo.On("GetRecommendationCandidates", mock.Anything, child.ID, child.Interests, child.BirthYear, "en-US", mock.Anything, mock.Anything).
+		Return([]models.RecommendationCandidate{digestCandidate("Watercolor Painting", 1, "art")}, nil)
+	conflict := errs.Conflict("Digest", "week_start", "2026-03-02")
+	mockDigestRepo.On("CreateDigest", mock.Anything, mock.Anything).Return(nil, &conflict)
+
+	sent, err := service.SendWeeklyDigest(context.Background(), guardian, digestWeekStart)
+
+	require.NoError(t, err)
+	assert.False(t, sent)
+	mockNotifRepo.AssertNotCalled(t, "CreateScheduledNotification", mock.Anything, mock.Anything)
+}
+
+func TestInterestMatches(t *testing.T) {
+	assert.Equal(t, 2, interestMatches([]string{"science", "technology", "art"}, []string{"technology", "science"}))
+	assert.Equal(t, 0, interestMatches([]string{"sports"}, []string{"art"}))
+	assert.Equal(t, 0, interestMatches(nil, []string{"art"}))
+}
+
+func TestDigestWeekStart(t *testing.T) {
+	// Sunday night UTC is already Monday in Bangkok
+	assert.Equal(t, time.Date(2026, time.March, 9, 0, 0, 0, 0, time.UTC), DigestWeekStart(time.Date(2026, time.March, 8, 20, 0, 0, 0, time.UTC)))
+	assert.Equal(t, digestWeekStart, DigestWeekStart(time.Date(2026, time.March, 8, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, digestWeekStart, DigestWeekStart(time.Date(2026, time.March, 2, 2, 0, 0, 0, bangkok)))
+}
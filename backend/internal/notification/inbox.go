@@ -0,0 +1,50 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"skillspark/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// deepLinkScheme is the mobile app's URL scheme, see frontend/apps/mobile/app.json
+const deepLinkScheme = "skillspark://"
+
+var deepLinkPaths = map[models.InboxLinkType]string{
+	models.InboxLinkRegistration:    "registrations",
+	models.InboxLinkEvent:           "event",
+	models.InboxLinkEventOccurrence: "event-occurrences",
+	models.InboxLinkReview:          "reviews",
+	models.InboxLinkOrganization:    "org",
+}
+
+// DeepLink is the app URL that opens the linked record
+func DeepLink(linkType models.InboxLinkType, id uuid.UUID) string {
+	return fmt.Sprintf("%s%s/%s", deepLinkScheme, deepLinkPaths[linkType], id)
+}
+
+// addToInbox keeps a copy of the rendered notification in the guardian's in-app inbox,
+// linked to the record it is about. visibleAt holds a scheduled notification back until
+// it is due; nil shows it straight away. A dedupKey keeps a notification that is sent again
+// to one item. The inbox is kept whatever channels the guardian has turned off, so nothing
+// they were sent is lost.
+func (s *Service) addToInbox(ctx context.Context, guardianID uuid.UUID, rendered *RenderedTemplate, metadata []byte, linkType models.InboxLinkType, linkID uuid.UUID, visibleAt *time.Time, dedupKey *string) error {
+	link := DeepLink(linkType, linkID)
+	if _, err := s.repo.Inbox.CreateInboxItem(ctx, &models.CreateInboxItemData{
+		GuardianID: guardianID,
+		Kind:       string(rendered.Name),
+		Title:      rendered.PushTitle,
+		Body:       rendered.PushBody,
+		LinkType:   &linkType,
+		LinkID:     &linkID,
+		DeepLink:   &link,
+		Metadata:   metadata,
+		VisibleAt:  visibleAt,
+		DedupKey:   dedupKey,
+	}); err != nil {
+		return fmt.Errorf("failed to add notification to inbox: %w", err)
+	}
+	return nil
+}
@@ -3,9 +3,21 @@ package notification
 import (
 	"context"
 	"skillspark/internal/models"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type NotificationServiceInterface interface {
 	SendNotification(ctx context.Context, input *models.SendNotificationInput) error
 	ScheduleNotification(ctx context.Context, input *models.CreateScheduledNotificationInput) (*models.Notification, error)
+	SendRegistrationConfirmation(ctx context.Context, registration *models.Registration, guardian *models.Guardian) error
+	ScheduleEventReminders(ctx context.Context, registration *models.Registration, guardian *models.Guardian) error
+	CancelEventReminders(ctx context.Context, registrationID uuid.UUID) error
+	CancelEventOccurrenceReminders(ctx context.Context, eventOccurrenceID uuid.UUID) error
+	RescheduleEventReminders(ctx context.Context, eventOccurrenceID uuid.UUID) error
+	SendEventCancellationNotices(ctx context.Context, registrations []models.Registration) error
+	Unsubscribe(ctx context.Context, token string) (models.NotificationTopic, error)
+	SendBroadcast(ctx context.Context, broadcast *models.Broadcast) (int, error)
+	SendWeeklyDigest(ctx context.Context, guardian *models.Guardian, weekStart time.Time) (bool, error)
 }
@@ -0,0 +1,88 @@
+package notification
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Language is a language notifications are rendered in
+type Language string
+
+const (
+	LanguageEnglish Language = "en"
+	LanguageThai    Language = "th"
+)
+
+// LanguageFromPreference maps a guardian's LanguagePreference ("th", "th-TH", "en", ...) to a
+// supported notification language, defaulting to English
+func LanguageFromPreference(preference string) Language {
+	if strings.HasPrefix(strings.ToLower(preference), "th") {
+		return LanguageThai
+	}
+	return LanguageEnglish
+}
+
+// AcceptLanguage is the Accept-Language value repositories use for localized content
+func (l Language) AcceptLanguage() string {
+	if l == LanguageThai {
+		return "th-TH"
+	}
+	return "en-US"
+}
+
+// bangkok is the timezone all notification times are shown in. Thailand has no daylight
+// saving, so a fixed offset is a safe fallback when tzdata is missing.
+var bangkok = loadBangkok()
+
+func loadBangkok() *time.Location {
+	loc, err := time.LoadLocation("Asia/Bangkok")
+	if err != nil {
+		return time.FixedZone("ICT", 7*60*60)
+	}
+	return loc
+}
+
+var thaiMonths = [...]string{
+	"มกราคม", "กุมภาพันธ์", "มีนาคม", "เมษายน", "พฤษภาคม", "มิถุนายน",
+	"กรกฎาคม", "สิงหาคม", "กันยายน", "ตุลาคม", "พฤศจิกายน", "ธันวาคม",
+}
+
+var thaiWeekdays = [...]string{
+	"อาทิตย์", "จันทร์", "อังคาร", "พุธ", "พฤหัสบดี", "ศุกร์", "เสาร์",
+}
+
+// buddhistEraOffset converts a Gregorian year to the Buddhist-era year used in Thai dates
+const buddhistEraOffset = 543
+
+// formatDateTime renders t in Bangkok time, e.g. "Friday, March 6, 2026 at 3:30 PM (GMT+7)"
+// or "วันศุกร์ที่ 6 มีนาคม 2569 เวลา 15:30 น."
+func formatDateTime(lang Language, t time.Time) string {
+	local := t.In(bangkok)
+	if lang == LanguageThai {
+		return fmt.Sprintf("วัน%sที่ %s เวลา %s น.", thaiWeekdays[local.Weekday()], formatThaiDate(local), local.Format("15:04"))
+	}
+	return local.Format("Monday, January 2, 2006 at 3:04 PM") + " (GMT+7)"
+}
+
+// formatDate renders the calendar date of t in Bangkok time
+func formatDate(lang Language, t time.Time) string {
+	local := t.In(bangkok)
+	if lang == LanguageThai {
+		return formatThaiDate(local)
+	}
+	return local.Format("January 2, 2006")
+}
+
+// formatTime renders the clock time of t in Bangkok time
+func formatTime(lang Language, t time.Time) string {
+	local := t.In(bangkok)
+	if lang == LanguageThai {
+		return local.Format("15:04") + " น."
+	}
+	return local.Format("3:04 PM")
+}
+
+func formatThaiDate(local time.Time) string {
+	return fmt.Sprintf("%d %s %d", local.Day(), thaiMonths[local.Month()-1], local.Year()+buddhistEraOffset)
+}
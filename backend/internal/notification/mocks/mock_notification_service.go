@@ -3,7 +3,9 @@ package notificationmocks
 import (
 	"context"
 	"skillspark/internal/models"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -23,3 +25,48 @@ func (m *MockNotificationService) ScheduleNotification(ctx context.Context, inpu
 	}
 	return args.Get(0).(*models.Notification), args.Error(1)
 }
+
+func (m *MockNotificationService) SendRegistrationConfirmation(ctx context.Context, registration *models.Registration, guardian *models.Guardian) error {
+	args := m.Called(ctx, registration, guardian)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) ScheduleEventReminders(ctx context.Context, registration *models.Registration, guardian *models.Guardian) error {
+	args := m.Called(ctx, registration, guardian)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) CancelEventReminders(ctx context.Context, registrationID uuid.UUID) error {
+	args := m.Called(ctx, registrationID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) CancelEventOccurrenceReminders(ctx context.Context, eventOccurrenceID uuid.UUID) error {
+	args := m.Called(ctx, eventOccurrenceID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) SendEventCancellationNotices(ctx context.Context, registrations []models.Registration) error {
+	args := m.Called(ctx, registrations)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) RescheduleEventReminders(ctx context.Context, eventOccurrenceID uuid.UUID) error {
+	args := m.Called(ctx, eventOccurrenceID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) Unsubscribe(ctx context.Context, token string) (models.NotificationTopic, error) {
+	args := m.Called(ctx, token)
+	return args.Get(0).(models.NotificationTopic), args.Error(1)
+}
+
+func (m *MockNotificationService) SendBroadcast(ctx context.Context, broadcast *models.Broadcast) (int, error) {
+	args := m.Called(ctx, broadcast)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockNotificationService) SendWeeklyDigest(ctx context.Context, guardian *models.Guardian, weekStart time.Time) (bool, error) {
+	args := m.Called(ctx, guardian, weekStart)
+	return args.Bool(0), args.Error(1)
+}
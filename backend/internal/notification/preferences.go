@@ -0,0 +1,55 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// guardianPreferences returns the guardian's notification preferences, or nil when the
+// guardian no longer exists
+func (s *Service) guardianPreferences(ctx context.Context, guardianID uuid.UUID) (*models.GuardianNotificationPreferences, error) {
+	prefs, err := s.repo.Guardian.GetGuardianNotificationPreferences(ctx, []uuid.UUID{guardianID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guardian notification preferences: %w", err)
+	}
+	guardianPrefs, ok := prefs[guardianID]
+	if !ok {
+		return nil, nil
+	}
+	return &guardianPrefs, nil
+}
+
+// unsubscribeURL is the one-click unsubscribe link for the guardian's emails on topic, or ""
+// when links can't be signed or the notification has no topic to unsubscribe from
+func (s *Service) unsubscribeURL(guardianID uuid.UUID, topic models.NotificationTopic) string {
+	if s.unsubscribe == nil || topic == "" {
+		return ""
+	}
+	return s.unsubscribe.URL(guardianID, topic)
+}
+
+// Unsubscribe turns off email for the topic named in a signed unsubscribe token
+func (s *Service) Unsubscribe(ctx context.Context, token string) (models.NotificationTopic, error) {
+	if s.unsubscribe == nil {
+		errr := errs.BadRequest("Unsubscribe links are not enabled")
+		return "", &errr
+	}
+
+	guardianID, topic, err := s.unsubscribe.Verify(token)
+	if err != nil {
+		errr := errs.BadRequest("Invalid unsubscribe link")
+		return "", &errr
+	}
+
+	if err := s.repo.Guardian.UpdateGuardianNotificationPreferences(ctx, guardianID, []models.NotificationPreference{
+		{Topic: topic, Channel: models.NotificationTypeEmail, Enabled: false},
+	}); err != nil {
+		return "", err
+	}
+
+	return topic, nil
+}
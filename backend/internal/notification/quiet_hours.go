@@ -0,0 +1,71 @@
+package notification
+
+import (
+	"fmt"
+	"log/slog"
+	"skillspark/internal/models"
+	"time"
+	// guardians' timezones are looked up by name, and servers may not have a zoneinfo database
+	_ "time/tzdata"
+)
+
+// QuietHoursError is returned by SendNotification for a scheduled notification that falls in
+// the guardian's quiet hours. Nothing is sent; the notification should be sent at Until.
+type QuietHoursError struct {
+	Until time.Time
+}
+
+func (e *QuietHoursError) Error() string {
+	return fmt.Sprintf("guardian has quiet hours until %s", e.Until.Format(time.RFC3339))
+}
+
+// quietHoursEnd reports whether t falls in the guardian's quiet hours and, if it does, when
+// they end. An unknown timezone is treated as Bangkok, where most guardians are.
+func quietHoursEnd(q models.QuietHours, t time.Time) (time.Time, bool) {
+	if !q.Enabled {
+		return time.Time{}, false
+	}
+
+	start, startErr := parseClock(q.Start)
+	end, endErr := parseClock(q.End)
+	if startErr != nil || endErr != nil || start == end {
+		return time.Time{}, false
+	}
+
+	loc, err := time.LoadLocation(q.Timezone)
+	if err != nil {
+		slog.Warn("Unknown guardian timezone, using Bangkok", "timezone", q.Timezone)
+		loc = bangkok
+	}
+
+	local := t.In(loc)
+	now := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute + time.Duration(local.Second())*time.Second
+
+	var endsTomorrow bool
+	switch {
+	case start < end:
+		if now < start || now >= end {
+			return time.Time{}, false
+		}
+	case now >= start:
+		// the window runs over midnight and started this evening
+		endsTomorrow = true
+	case now >= end:
+		return time.Time{}, false
+	}
+
+	day := local.Day()
+	if endsTomorrow {
+		day++
+	}
+	return time.Date(local.Year(), local.Month(), day, int(end/time.Hour), int(end%time.Hour/time.Minute), 0, 0, loc), true
+}
+
+// parseClock turns an "HH:MM" time of day into the time since midnight
+func parseClock(clock string) (time.Duration, error) {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
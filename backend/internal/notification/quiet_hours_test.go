@@ -0,0 +1,165 @@
+package notification
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuietHoursEnd(t *testing.T) {
+	overnight := models.QuietHours{Timezone: "Asia/Bangkok", Enabled: true, Start: "21:00", End: "07:00"}
+	afternoon := models.QuietHours{Timezone: "Asia/Bangkok", Enabled: true, Start: "13:00", End: "15:30"}
+
+	tests := []struct {
+		name      string
+		quiet     models.QuietHours
+		at        time.Time
+		wantQuiet bool
+		wantUntil time.Time
+	}{
+		{
+			name:      "late evening waits for the next morning",
+			quiet:     overnight,
+			at:        time.Date(2026, time.March, 6, 23, 30, 0, 0, bangkok),
+			wantQuiet: true,
+			wantUntil: time.Date(2026, time.March, 7, 7, 0, 0, 0, bangkok),
+		},
+		{
+			name:      "3am waits until the same morning",
+			quiet:     overnight,
+			at:        time.Date(2026, time.March, 7, 3, 0, 0, 0, bangkok),
+			wantQuiet: true,
+			wantUntil: time.Date(2026, time.March, 7, 7, 0, 0, 0, bangkok),
+		},
+		{
+			name:  "daytime is not quiet",
+			quiet: overnight,
+			at:    time.Date(2026, time.March, 7, 12, 0, 0, 0, bangkok),
+		},
+		{
+			name:  "the end of the window is no longer quiet",
+			quiet: overnight,
+			at:    time.Date(2026, time.March, 7, 7, 0, 0, 0, bangkok),
+		},
+		{
+			name:      "a window within one day",
+			quiet:     afternoon,
+			at:        time.Date(2026, time.March, 7, 14, 0, 0, 0, bangkok),
+			wantQuiet: true,
+			wantUntil: time.Date(2026, time.March, 7, 15, 30, 0, 0, bangkok),
+		},
+		{
+			name:  "before a window within one day",
+			quiet: afternoon,
+			at:    time.Date(2026, time.March, 7, 12, 59, 0, 0, bangkok),
+		},
+		{
+			name:      "the guardian's own timezone is used",
+			quiet:     models.QuietHours{Timezone: "Europe/London", Enabled: true, Start: "21:00", End: "07:00"},
+			at:        time.Date(2026, time.March, 7, 3, 0, 0, 0, bangkok), // 20:00 the day before in London
+			wantQuiet: false,
+		},
+		{
+			name:      "unknown timezones fall back to Bangkok",
+			quiet:     models.QuietHours{Timezone: "Mars/Olympus", Enabled: true, Start: "21:00", End: "07:00"},
+			at:        time.Date(2026, time.March, 7, 3, 0, 0, 0, time.UTC), // 10:00 in Bangkok
+			wantQuiet: false,
+		},
+		{
+			name:  "disabled",
+			quiet: models.QuietHours{Timezone: "Asia/Bangkok", Enabled: false, Start: "21:00", End: "07:00"},
+			at:    time.Date(2026, time.March, 7, 3, 0, 0, 0, bangkok),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			until, quiet := quietHoursEnd(tt.quiet, tt.at)
+			assert.Equal(t, tt.wantQuiet, quiet)
+			if tt.wantQuiet {
+				assert.True(t, tt.wantUntil.Equal(until), "until %s, want %s", until, tt.wantUntil)
+			}
+		})
+	}
+}
+
+func TestSendNotification_QuietHours(t *testing.T) {
+	guardianID := uuid.New()
+	notificationID := uuid.New()
+	email := "parent@example.com"
+
+	// a window around now, so the test doesn't depend on the time it runs
+	now := time.Now().UTC()
+	quiet := models.QuietHours{
+		Timezone: "UTC",
+		Enabled:  true,
+		Start:    now.Add(-time.Hour).Format("15:04"),
+		End:      now.Add(time.Hour).Format("15:04"),
+	}
+
+	newService := func(outbox *recordingOutbox) *Service {
+		mockGuardianRepo := new(repomocks.MockGuardianRepository)
+		mockGuardianRepo.On("GetGuardianNotificationPreferences", mock.Anything, []uuid.UUID{guardianID}).
+			Return(map[uuid.UUID]models.GuardianNotificationPreferences{
+				guardianID: {EmailNotifications: true, QuietHours: quiet},
+			}, nil)
+		return NewService(&storage.Repository{Guardian: mockGuardianRepo, Outbox: outbox}, nil, "")
+	}
+
+	t.Run("scheduled notification waits for the end of quiet hours", func(t *testing.T) {
+		outbox := &recordingOutbox{}
+		err := newService(outbox).SendNotification(context.Background(), &models.SendNotificationInput{
+			NotificationType: models.NotificationTypeEmail,
+			RecipientEmail:   &email,
+			Body:             "Your class is tomorrow",
+			GuardianID:       &guardianID,
+			Topic:            models.NotificationTopicEventReminders,
+			NotificationID:   &notificationID,
+		})
+
+		var quietErr *QuietHoursError
+		require.ErrorAs(t, err, &quietErr)
+		assert.WithinDuration(t, now.Add(time.Hour), quietErr.Until, time.Minute)
+		assert.Empty(t, outbox.messages)
+	})
+
+	t.Run("urgent notification is sent", func(t *testing.T) {
+		outbox := &recordingOutbox{}
+		err := newService(outbox).SendNotification(context.Background(), &models.SendNotificationInput{
+			NotificationType: models.NotificationTypeEmail,
+			RecipientEmail:   &email,
+			Body:             "Today's class is cancelled",
+			GuardianID:       &guardianID,
+			Topic:            models.NotificationTopicRegistrations,
+			NotificationID:   &notificationID,
+			Urgent:           true,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, outbox.messages, 1)
+		// retries of the send task queue the same message
+		assert.Equal(t, "notification:"+notificationID.String(), outbox.messages[0].DedupID)
+	})
+
+	t.Run("immediate notification the guardian asked for is sent", func(t *testing.T) {
+		outbox := &recordingOutbox{}
+		err := newService(outbox).SendNotification(context.Background(), &models.SendNotificationInput{
+			NotificationType: models.NotificationTypeEmail,
+			RecipientEmail:   &email,
+			Body:             "You're registered",
+			GuardianID:       &guardianID,
+			Topic:            models.NotificationTopicRegistrations,
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, outbox.messages, 1)
+	})
+}
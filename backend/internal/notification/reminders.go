@@ -0,0 +1,164 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"skillspark/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const eventReminderMetadataType = "event_reminder"
+
+// eventReminderOffsets are how long before an occurrence starts each of its reminders goes out
+var eventReminderOffsets = []time.Duration{24 * time.Hour, 2 * time.Hour}
+
+type eventReminderMetadata struct {
+	Type              string       `json:"type"`
+	Template          TemplateName `json:"template"`
+	TemplateVersion   int          `json:"template_version"`
+	RegistrationID    uuid.UUID    `json:"registration_id"`
+	EventOccurrenceID uuid.UUID    `json:"event_occurrence_id"`
+}
+
+// ScheduleEventReminders queues reminders ahead of the registration's occurrence on every
+// channel the guardian can be reached on, in the guardian's language. Reminders whose time
+// has already passed are skipped. Channel preferences are checked again when each reminder
+// is sent, so turning a topic or channel off after registering still takes effect.
+func (s *Service) ScheduleEventReminders(ctx context.Context, registration *models.Registration, guardian *models.Guardian) error {
+	if registration.Status != models.RegistrationStatusRegistered {
+		return nil
+	}
+
+	lang := LanguageFromPreference(guardian.LanguagePreference)
+	eventName := s.localizedEventName(ctx, registration, lang)
+
+	now := time.Now()
+	for _, offset := range eventReminderOffsets {
+		scheduledFor := registration.OccurrenceStartTime.Add(-offset)
+		if !scheduledFor.After(now) {
+			continue
+		}
+
+		rendered, err := RenderTemplate(TemplateEventReminder, lang, EventReminderData{
+			GuardianName: guardian.Name,
+			EventName:    eventName,
+			StartTime:    registration.OccurrenceStartTime,
+			HoursBefore:  int(offset.Hours()),
+		}, s.unsubscribeURL(guardian.ID, templateTopics[TemplateEventReminder]))
+		if err != nil {
+			return err
+		}
+
+		metadata, err := json.Marshal(eventReminderMetadata{
+			Type:              eventReminderMetadataType,
+			Template:          rendered.Name,
+			TemplateVersion:   rendered.Version,
+			RegistrationID:    registration.ID,
+			EventOccurrenceID: registration.EventOccurrenceID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to encode reminder metadata: %w", err)
+		}
+
+		if err := s.addToInbox(ctx, guardian.ID, rendered, metadata, models.InboxLinkRegistration, registration.ID, &scheduledFor, nil); err != nil {
+			return err
+		}
+
+		for _, input := range rendered.GuardianInputs(guardian, metadata) {
+			if _, err := s.ScheduleNotification(ctx, &models.CreateScheduledNotificationInput{
+				NotificationType:   input.NotificationType,
+				RecipientEmail:     input.RecipientEmail,
+				RecipientPushToken: input.RecipientPushToken,
+				Subject:            input.Subject,
+				Body:               input.Body,
+				HTMLBody:           input.HTMLBody,
+				Metadata:           input.Metadata,
+				ScheduledFor:       scheduledFor,
+				GuardianID:         &guardian.ID,
+				RegistrationID:     &registration.ID,
+				Topic:              input.Topic,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// CancelEventReminders removes the registration's reminders that have not been sent yet,
+// along with their inbox items
+func (s *Service) CancelEventReminders(ctx context.Context, registrationID uuid.UUID) error {
+	if err := s.repo.Notification.DeletePendingNotificationsByRegistrationID(ctx, registrationID); err != nil {
+		return err
+	}
+	return s.repo.Inbox.DeleteUpcomingInboxItemsByRegistrationID(ctx, registrationID)
+}
+
+// CancelEventOccurrenceReminders removes unsent reminders for every registration of the occurrence
+func (s *Service) CancelEventOccurrenceReminders(ctx context.Context, eventOccurrenceID uuid.UUID) error {
+	if err := s.repo.Notification.DeletePendingNotificationsByEventOccurrenceID(ctx, eventOccurrenceID); err != nil {
+		return err
+	}
+	return s.repo.Inbox.DeleteUpcomingInboxItemsByEventOccurrenceID(ctx, eventOccurrenceID)
+}
+
+// RescheduleEventReminders replaces the occurrence's unsent reminders with ones timed from its
+// current start time. A failure for one registration does not stop the others.
+func (s *Service) RescheduleEventReminders(ctx context.Context, eventOccurrenceID uuid.UUID) error {
+	if err := s.CancelEventOccurrenceReminders(ctx, eventOccurrenceID); err != nil {
+		return err
+	}
+
+	registrations, err := s.repo.Registration.GetRegistrationsByEventOccurrenceID(ctx, &models.GetRegistrationsByEventOccurrenceIDInput{
+		AcceptLanguage:    "en-US",
+		EventOccurrenceID: eventOccurrenceID,
+	})
+	if err != nil {
+		return err
+	}
+
+	guardians := make(map[uuid.UUID]*models.Guardian)
+	var errs []error
+	for _, registration := range registrations.Body.Registrations {
+		if registration.Status != models.RegistrationStatusRegistered {
+			continue
+		}
+
+		guardian, ok := guardians[registration.GuardianID]
+		if !ok {
+			guardian, err = s.repo.Guardian.GetGuardianByID(ctx, registration.GuardianID)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to get guardian %s: %w", registration.GuardianID, err))
+				continue
+			}
+			guardians[registration.GuardianID] = guardian
+		}
+
+		if err := s.ScheduleEventReminders(ctx, &registration, guardian); err != nil {
+			errs = append(errs, fmt.Errorf("failed to schedule reminders for registration %s: %w", registration.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// localizedEventName returns the event title in lang, falling back to the name already on
+// the registration when it can't be looked up
+func (s *Service) localizedEventName(ctx context.Context, registration *models.Registration, lang Language) string {
+	if lang == LanguageEnglish {
+		return registration.EventName
+	}
+
+	occurrence, err := s.repo.EventOccurrence.GetEventOccurrenceByID(ctx, registration.EventOccurrenceID, lang.AcceptLanguage())
+	if err != nil || occurrence == nil || occurrence.Event.Title == "" {
+		slog.Warn("Falling back to untranslated event name", "event_occurrence_id", registration.EventOccurrenceID, "language", lang, "error", err)
+		return registration.EventName
+	}
+	return occurrence.Event.Title
+}
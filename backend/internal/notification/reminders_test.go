@@ -0,0 +1,238 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"skillspark/internal/models"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleEventReminders(t *testing.T) {
+	pushToken := "ExponentPushToken[abc]"
+	guardian := &models.Guardian{ID: uuid.New(), Email: "parent@example.com", ExpoPushToken: &pushToken}
+	emailOnly := &models.Guardian{ID: uuid.New(), Email: "parent@example.com"}
+
+	tests := []struct {
+		name          string
+		guardian      *models.Guardian
+		startsIn      time.Duration
+		status        models.RegistrationStatus
+		expectedCount int
+		expectedInbox int
+	}{
+		{name: "both reminders on both channels", guardian: guardian, startsIn: 72 * time.Hour, status: models.RegistrationStatusRegistered, expectedCount: 4, expectedInbox: 2},
+		{name: "email only without push token", guardian: emailOnly, startsIn: 72 * time.Hour, status: models.RegistrationStatusRegistered, expectedCount: 2, expectedInbox: 2},
+		{name: "day-before reminder already passed", guardian: guardian, startsIn: 10 * time.Hour, status: models.RegistrationStatusRegistered, expectedCount: 2, expectedInbox: 1},
+		{name: "both reminders passed", guardian: guardian, startsIn: time.Hour, status: models.RegistrationStatusRegistered},
+		{name: "cancelled registration", guardian: guardian, startsIn: 72 * time.Hour, status: models.RegistrationStatusCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNotifRepo := new(repomocks.MockNotificationRepository)
+			mockInboxRepo := new(repomocks.MockInboxRepository)
+			service := NewService(&storage.Repository{Notification: mockNotifRepo, Inbox: mockInboxRepo}, nil, "")
+
+			registration := &models.Registration{
+				ID:                  uuid.New(),
+				EventOccurrenceID:   uuid.New(),
+				GuardianID:          tt.guardian.ID,
+				Status:              tt.status,
+				EventName:           "Robotics Club",
+				OccurrenceStartTime: time.Now().Add(tt.startsIn),
+			}
+
+			var scheduled []*models.CreateScheduledNotificationInput
+			mockNotifRepo.On("CreateScheduledNotification", mock.Anything, mock.AnythingOfType("*models.CreateScheduledNotificationInput")).
+				Run(func(args mock.Arguments) {
+					scheduled = append(scheduled, args.Get(1).(*models.CreateScheduledNotificationInput))
+				}).
+				Return(&models.Notification{}, nil).Maybe()
+			var inboxItems []*models.CreateInboxItemData
+			mockInboxRepo.On("CreateInboxItem", mock.Anything, mock.AnythingOfType("*models.CreateInboxItemData")).
+				Run(func(args mock.Arguments) {
+					inboxItems = append(inboxItems, args.Get(1).(*models.CreateInboxItemData))
+				}).
+				Return(&models.InboxItem{}, nil).Maybe()
+
+			err := service.ScheduleEventReminders(context.Background(), registration, tt.guardian)
+
+			require.NoError(t, err)
+			require.Len(t, scheduled, tt.expectedCount)
+			// one inbox item per reminder, however many channels it goes out on
+			require.Len(t, inboxItems, tt.expectedInbox)
+			for _, item := range inboxItems {
+				require.NotNil(t, item.VisibleAt)
+				assert.True(t, item.VisibleAt.After(time.Now()))
+				assert.Equal(t, registration.ID, *item.LinkID)
+				assert.Contains(t, item.Title, "Robotics Club")
+			}
+			for _, input := range scheduled {
+				assert.Equal(t, registration.ID, *input.RegistrationID)
+				assert.Equal(t, tt.guardian.ID, *input.GuardianID)
+				assert.True(t, input.ScheduledFor.After(time.Now()))
+				assert.True(t, input.ScheduledFor.Before(registration.OccurrenceStartTime))
+				assert.Contains(t, *input.Subject, "Robotics Club")
+				if input.NotificationType == models.NotificationTypeEmail {
+					require.NotNil(t, input.HTMLBody)
+					assert.Contains(t, *input.HTMLBody, "Robotics Club")
+				}
+
+				var metadata eventReminderMetadata
+				require.NoError(t, json.Unmarshal(input.Metadata, &metadata))
+				assert.Equal(t, eventReminderMetadataType, metadata.Type)
+				assert.Equal(t, registration.EventOccurrenceID, metadata.EventOccurrenceID)
+				assert.Equal(t, TemplateEventReminder, metadata.Template)
+				assert.Equal(t, 1, metadata.TemplateVersion)
+			}
+		})
+	}
+}
+
+func TestScheduleEventReminders_ThaiGuardian(t *testing.T) {
+	mockNotifRepo := new(repomocks.MockNotificationRepository)
+	mockEORepo := new(repomocks.MockEventOccurrenceRepository)
+	mockInboxRepo := new(repomocks.MockInboxRepository)
+	service := NewService(&storage.Repository{Notification: mockNotifRepo, EventOccurrence: mockEORepo, Inbox: mockInboxRepo}, nil, "")
+
+	guardian := &models.Guardian{ID: uuid.New(), Name: "สมชาย", Email: "parent@example.com", LanguagePreference: "th"}
+	registration := &models.Registration{
+		ID:                  uuid.New(),
+		EventOccurrenceID:   uuid.New(),
+		GuardianID:          guardian.ID,
+		Status:              models.RegistrationStatusRegistered,
+		EventName:           "Robotics Club",
+		OccurrenceStartTime: time.Now().Add(72 * time.Hour),
+	}
+
+	occurrence := &models.EventOccurrence{}
+	occurrence.Event.Title = "ชมรมหุ่นยนต์"
+	mockEORepo.On("GetEventOccurrenceByID", mock.Anything, registration.EventOccurrenceID, "th-TH").Return(occurrence, nil).Once()
+	mockInboxRepo.On("CreateInboxItem", mock.Anything, mock.MatchedBy(func(input *models.CreateInboxItemData) bool {
+		return strings.Contains(input.Title, "ชมรมหุ่นยนต์")
+	})).Return(&models.InboxItem{}, nil).Twice()
+
+	var scheduled []*models.CreateScheduledNotificationInput
+	mockNotifRepo.On("CreateScheduledNotification", mock.Anything, mock.AnythingOfType("*models.CreateScheduledNotificationInput")).
+		Run(func(args mock.Arguments) {
+			scheduled = append(scheduled, args.Get(1).(*models.CreateScheduledNotificationInput))
+		}).
+		Return(&models.Notification{}, nil)
+
+	err := service.ScheduleEventReminders(context.Background(), registration, guardian)
+
+	require.NoError(t, err)
+	require.Len(t, scheduled, 2)
+	for _, input := range scheduled {
+		assert.Contains(t, *input.Subject, "ชมรมหุ่นยนต์")
+		assert.Contains(t, input.Body, "สมชาย")
+		assert.NotContains(t, input.Body, "Robotics Club")
+	}
+	mockEORepo.AssertExpectations(t)
+	mockInboxRepo.AssertExpectations(t)
+}
+
+func TestCancelEventReminders(t *testing.T) {
+	mockNotifRepo := new(repomocks.MockNotificationRepository)
+	mockInboxRepo := new(repomocks.MockInboxRepository)
+	service := NewService(&storage.Repository{Notification: mockNotifRepo, Inbox: mockInboxRepo}, nil, "")
+
+	registrationID := uuid.New()
+	mockNotifRepo.On("DeletePendingNotificationsByRegistrationID", mock.Anything, registrationID).Return(nil).Once()
+	mockInboxRepo.On("DeleteUpcomingInboxItemsByRegistrationID", mock.Anything, registrationID).Return(nil).Once()
+
+	require.NoError(t, service.CancelEventReminders(context.Background(), registrationID))
+	mockNotifRepo.AssertExpectations(t)
+	mockInboxRepo.AssertExpectations(t)
+}
+
+func TestRescheduleEventReminders(t *testing.T) {
+	mockNotifRepo := new(repomocks.MockNotificationRepository)
+	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	mockGuardianRepo := new(repomocks.MockGuardianRepository)
+	mockInboxRepo := new(repomocks.MockInboxRepository)
+	service := NewService(&storage.Repository{
+		Notification: mockNotifRepo,
+		Registration: mockRegRepo,
+		Guardian:     mockGuardianRepo,
+		Inbox:        mockInboxRepo,
+	}, nil, "")
+
+	occurrenceID := uuid.New()
+	guardian := &models.Guardian{ID: uuid.New(), Email: "parent@example.com"}
+	start := time.Now().Add(48 * time.Hour)
+
+	out := &models.GetRegistrationsByEventOccurrenceIDOutput{}
+	out.Body.Registrations = []models.Registration{
+		{ID: uuid.New(), GuardianID: guardian.ID, EventOccurrenceID: occurrenceID, Status: models.RegistrationStatusRegistered, OccurrenceStartTime: start},
+		{ID: uuid.New(), GuardianID: guardian.ID, EventOccurrenceID: occurrenceID, Status: models.RegistrationStatusRegistered, OccurrenceStartTime: start},
+		{ID: uuid.New(), GuardianID: uuid.New(), EventOccurrenceID: occurrenceID, Status: models.RegistrationStatusCancelled, OccurrenceStartTime: start},
+	}
+
+	mockNotifRepo.On("DeletePendingNotificationsByEventOccurrenceID", mock.Anything, occurrenceID).Return(nil).Once()
+	mockInboxRepo.On("DeleteUpcomingInboxItemsByEventOccurrenceID", mock.Anything, occurrenceID).Return(nil).Once()
+	mockInboxRepo.On("CreateInboxItem", mock.Anything, mock.AnythingOfType("*models.CreateInboxItemData")).Return(&models.InboxItem{}, nil)
+	mockRegRepo.On("GetRegistrationsByEventOccurrenceID", mock.Anything, mock.MatchedBy(func(input *models.GetRegistrationsByEventOccurrenceIDInput) bool {
+		return input.EventOccurrenceID == occurrenceID
+	})).Return(out, nil)
+	mockGuardianRepo.On("GetGuardianByID", mock.Anything, guardian.ID).Return(guardian, nil).Once()
+	mockNotifRepo.On("CreateScheduledNotification", mock.Anything, mock.AnythingOfType("*models.CreateScheduledNotificationInput")).
+		Return(&models.Notification{}, nil)
+
+	err := service.RescheduleEventReminders(context.Background(), occurrenceID)
+
+	require.NoError(t, err)
+	mockNotifRepo.AssertExpectations(t)
+	mockGuardianRepo.AssertExpectations(t)
+	mockInboxRepo.AssertExpectations(t)
+	mockInboxRepo.AssertNumberOfCalls(t, "CreateInboxItem", 4)
+	// two active registrations, two reminders each, email only
+	mockNotifRepo.AssertNumberOfCalls(t, "CreateScheduledNotification", 4)
+}
+
+func TestRescheduleEventReminders_ContinuesAfterGuardianError(t *testing.T) {
+	mockNotifRepo := new(repomocks.MockNotificationRepository)
+	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	mockGuardianRepo := new(repomocks.MockGuardianRepository)
+	mockInboxRepo := new(repomocks.MockInboxRepository)
+	service := NewService(&storage.Repository{
+		Notification: mockNotifRepo,
+		Registration: mockRegRepo,
+		Guardian:     mockGuardianRepo,
+		Inbox:        mockInboxRepo,
+	}, nil, "")
+
+	occurrenceID := uuid.New()
+	missingGuardianID := uuid.New()
+	guardian := &models.Guardian{ID: uuid.New(), Email: "parent@example.com"}
+	start := time.Now().Add(48 * time.Hour)
+
+	out := &models.GetRegistrationsByEventOccurrenceIDOutput{}
+	out.Body.Registrations = []models.Registration{
+		{ID: uuid.New(), GuardianID: missingGuardianID, Status: models.RegistrationStatusRegistered, OccurrenceStartTime: start},
+		{ID: uuid.New(), GuardianID: guardian.ID, Status: models.RegistrationStatusRegistered, OccurrenceStartTime: start},
+	}
+
+	mockNotifRepo.On("DeletePendingNotificationsByEventOccurrenceID", mock.Anything, occurrenceID).Return(nil)
+	mockInboxRepo.On("DeleteUpcomingInboxItemsByEventOccurrenceID", mock.Anything, occurrenceID).Return(nil)
+	mockInboxRepo.On("CreateInboxItem", mock.Anything, mock.AnythingOfType("*models.CreateInboxItemData")).Return(&models.InboxItem{}, nil)
+	mockRegRepo.On("GetRegistrationsByEventOccurrenceID", mock.Anything, mock.Anything).Return(out, nil)
+	mockGuardianRepo.On("GetGuardianByID", mock.Anything, missingGuardianID).Return(nil, assert.AnError)
+	mockGuardianRepo.On("GetGuardianByID", mock.Anything, guardian.ID).Return(guardian, nil)
+	mockNotifRepo.On("CreateScheduledNotification", mock.Anything, mock.AnythingOfType("*models.CreateScheduledNotificationInput")).
+		Return(&models.Notification{}, nil)
+
+	err := service.RescheduleEventReminders(context.Background(), occurrenceID)
+
+	require.Error(t, err)
+	mockNotifRepo.AssertNumberOfCalls(t, "CreateScheduledNotification", 2)
+}
@@ -2,44 +2,105 @@ package notification
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"skillspark/internal/models"
-	"skillspark/internal/sqs_client"
 	"skillspark/internal/storage"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 )
 
+// ErrChannelDisabled is returned by SendNotification when the guardian has turned off the
+// notification's topic on its channel. Nothing is sent.
+var ErrChannelDisabled = errors.New("guardian has this notification channel disabled")
+
 type Service struct {
-	repo      *storage.Repository
-	sqsClient sqs_client.SQSInterface
+	repo        *storage.Repository
+	unsubscribe *UnsubscribeSigner
+	// publicAPIURL is the base of tracked links in notifications, such as digest clicks
+	publicAPIURL string
 }
 
-func NewService(repo *storage.Repository, sqsClient sqs_client.SQSInterface) *Service {
+// NewService creates the notification service. unsubscribe may be nil, in which case emails
+// go out without an unsubscribe link.
+func NewService(repo *storage.Repository, unsubscribe *UnsubscribeSigner, publicAPIURL string) *Service {
 	return &Service{
-		repo:      repo,
-		sqsClient: sqsClient,
+		repo:         repo,
+		unsubscribe:  unsubscribe,
+		publicAPIURL: strings.TrimRight(publicAPIURL, "/"),
 	}
 }
 
-// SendNotification sends an immediate notification to SQS
+// SendNotification sends an immediate notification. It is written to the outbox, and the
+// worker's relay publishes it to the SQS delivery queue. When the input names a guardian,
+// their preferences are checked first and ErrChannelDisabled is returned if they opted out.
+// A scheduled notification that is not urgent is not sent during the guardian's quiet hours;
+// a *QuietHoursError says when they end.
 func (s *Service) SendNotification(ctx context.Context, input *models.SendNotificationInput) error {
 	// Validate input
 	if err := validateNotificationInput(input.NotificationType, input.RecipientEmail, input.RecipientPushToken); err != nil {
 		return err
 	}
 
-	// Create notification message for SQS
+	var unsubscribeURL *string
+	if input.GuardianID != nil {
+		prefs, err := s.guardianPreferences(ctx, *input.GuardianID)
+		if err != nil {
+			return err
+		}
+		// a guardian that no longer exists has nobody to notify
+		if prefs == nil || !prefs.Allows(input.Topic, input.NotificationType) {
+			return ErrChannelDisabled
+		}
+		if input.NotificationID != nil && !input.Urgent {
+			if until, quiet := quietHoursEnd(prefs.QuietHours, time.Now()); quiet {
+				return &QuietHoursError{Until: until}
+			}
+		}
+		if input.NotificationType == models.NotificationTypeEmail {
+			if url := s.unsubscribeURL(*input.GuardianID, input.Topic); url != "" {
+				unsubscribeURL = &url
+			}
+		}
+	}
+
+	dedupID := input.DedupID
+	if dedupID == "" {
+		if input.NotificationID != nil {
+			dedupID = "notification:" + input.NotificationID.String()
+		} else {
+			dedupID = uuid.NewString()
+		}
+	}
+
 	message := models.NotificationMessage{
+		NotificationID:     input.NotificationID,
+		GuardianID:         input.GuardianID,
 		NotificationType:   input.NotificationType,
 		RecipientEmail:     input.RecipientEmail,
 		RecipientPushToken: input.RecipientPushToken,
 		Subject:            input.Subject,
 		Body:               input.Body,
+		HTMLBody:           input.HTMLBody,
 		Metadata:           input.Metadata,
+		UnsubscribeURL:     unsubscribeURL,
+		DedupID:            dedupID,
+	}
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification message: %w", err)
 	}
 
-	// Send to SQS
-	if err := s.sqsClient.SendMessage(ctx, message); err != nil {
-		return fmt.Errorf("failed to send notification to SQS: %w", err)
+	// a message already queued under the same dedup ID is not queued again
+	if _, err := s.repo.Outbox.EnqueueOutboxMessage(ctx, &models.EnqueueOutboxMessageData{
+		Topic:   models.OutboxTopicNotification,
+		Payload: payload,
+		DedupID: dedupID,
+	}); err != nil {
+		return fmt.Errorf("failed to queue notification: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,316 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"skillspark/internal/models"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// TemplateName identifies a kind of notification. Each kind has numbered versions under
+// templates/<name>/v<N>/, with one file per language defining the "subject", "text",
+// "content" (the HTML email body), "push_title" and "push_body" blocks.
+type TemplateName string
+
+const (
+	TemplateRegistrationConfirmed TemplateName = "registration_confirmed"
+	TemplateEventReminder         TemplateName = "event_reminder"
+	TemplateOrganizationBroadcast TemplateName = "organization_broadcast"
+	TemplateWeeklyDigest          TemplateName = "weekly_digest"
+	TemplateEventCancelled        TemplateName = "event_cancelled"
+)
+
+// templateTopics is the preference topic each kind of notification is filed under
+var templateTopics = map[TemplateName]models.NotificationTopic{
+	TemplateRegistrationConfirmed: models.NotificationTopicRegistrations,
+	TemplateEventReminder:         models.NotificationTopicEventReminders,
+	TemplateOrganizationBroadcast: models.NotificationTopicOrganizationUpdates,
+	TemplateWeeklyDigest:          models.NotificationTopicWeeklyDigest,
+	TemplateEventCancelled:        models.NotificationTopicRegistrations,
+}
+
+// RegistrationConfirmedData is the data for TemplateRegistrationConfirmed
+type RegistrationConfirmedData struct {
+	GuardianName string
+	EventName    string
+	StartTime    time.Time
+}
+
+// EventReminderData is the data for TemplateEventReminder
+type EventReminderData struct {
+	GuardianName string
+	EventName    string
+	StartTime    time.Time
+	HoursBefore  int
+}
+
+// EventCancelledData is the data for TemplateEventCancelled
+type EventCancelledData struct {
+	GuardianName string
+	EventName    string
+	StartTime    time.Time
+}
+
+// OrganizationBroadcastData is the data for TemplateOrganizationBroadcast. Subject and
+// Message are the manager's own words, in the guardian's language where they gave one.
+type OrganizationBroadcastData struct {
+	GuardianName     string
+	OrganizationName string
+	Subject          string
+	Message          string
+}
+
+// WeeklyDigestData is the data for TemplateWeeklyDigest
+type WeeklyDigestData struct {
+	GuardianName  string
+	WeekStart     time.Time
+	Children      []DigestChildSection
+	ActivityCount int
+}
+
+// DigestChildSection lists the activities picked for one child
+type DigestChildSection struct {
+	ChildName  string
+	Activities []DigestActivity
+}
+
+// DigestActivity is one listed event; URL is its click-tracked link
+type DigestActivity struct {
+	Title string
+	URL   string
+}
+
+// RenderedTemplate is a notification rendered for one language, ready for either channel
+type RenderedTemplate struct {
+	Name      TemplateName
+	Version   int
+	Language  Language
+	Topic     models.NotificationTopic
+	Subject   string
+	TextBody  string
+	HTMLBody  string
+	PushTitle string
+	PushBody  string
+}
+
+//go:embed templates
+var templateFiles embed.FS
+
+// emailLayoutData is what layout.html.tmpl is executed with; the template's own data is
+// passed on to its "subject" and "content" blocks
+type emailLayoutData struct {
+	Content        any
+	UnsubscribeURL string
+}
+
+var unsubscribeLabels = map[Language]string{
+	LanguageEnglish: "Unsubscribe",
+	LanguageThai:    "ยกเลิกการรับอีเมล",
+}
+
+var unsubscribeFooters = map[Language]string{
+	LanguageEnglish: "To stop receiving these emails, unsubscribe here: %s",
+	LanguageThai:    "หากไม่ต้องการรับอีเมลประเภทนี้อีก ยกเลิกได้ที่: %s",
+}
+
+type templateKey struct {
+	name     TemplateName
+	version  int
+	language Language
+}
+
+type templateVariant struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+type templateRegistry struct {
+	variants map[templateKey]templateVariant
+	latest   map[TemplateName]int
+}
+
+var templates = mustLoadTemplates()
+
+// RenderTemplate renders the latest version of the named template. Languages without a
+// variant fall back to English. A non-empty unsubscribeURL is linked from the email footer.
+func RenderTemplate(name TemplateName, lang Language, data any, unsubscribeURL string) (*RenderedTemplate, error) {
+	version, ok := templates.latest[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown notification template %q", name)
+	}
+	return RenderTemplateVersion(name, version, lang, data, unsubscribeURL)
+}
+
+// RenderTemplateVersion renders a specific version of the named template
+func RenderTemplateVersion(name TemplateName, version int, lang Language, data any, unsubscribeURL string) (*RenderedTemplate, error) {
+	variant, ok := templates.variants[templateKey{name, version, lang}]
+	if !ok {
+		lang = LanguageEnglish
+		variant, ok = templates.variants[templateKey{name, version, lang}]
+		if !ok {
+			return nil, fmt.Errorf("unknown notification template %s v%d", name, version)
+		}
+	}
+
+	rendered := &RenderedTemplate{Name: name, Version: version, Language: lang, Topic: templateTopics[name]}
+	for block, dest := range map[string]*string{
+		"subject":    &rendered.Subject,
+		"text":       &rendered.TextBody,
+		"push_title": &rendered.PushTitle,
+		"push_body":  &rendered.PushBody,
+	} {
+		var buf bytes.Buffer
+		if err := variant.text.ExecuteTemplate(&buf, block, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s v%d %s: %w", name, version, block, err)
+		}
+		*dest = strings.TrimSpace(buf.String())
+	}
+
+	if unsubscribeURL != "" {
+		rendered.TextBody += "\n\n" + fmt.Sprintf(unsubscribeFooters[lang], unsubscribeURL)
+	}
+
+	var buf bytes.Buffer
+	if err := variant.html.ExecuteTemplate(&buf, "html", emailLayoutData{Content: data, UnsubscribeURL: unsubscribeURL}); err != nil {
+		return nil, fmt.Errorf("failed to render %s v%d html: %w", name, version, err)
+	}
+	rendered.HTMLBody = buf.String()
+
+	return rendered, nil
+}
+
+// EmailInput addresses the rendered email to recipient
+func (r *RenderedTemplate) EmailInput(recipient string, metadata []byte) *models.SendNotificationInput {
+	return &models.SendNotificationInput{
+		NotificationType: models.NotificationTypeEmail,
+		RecipientEmail:   &recipient,
+		Subject:          &r.Subject,
+		Body:             r.TextBody,
+		HTMLBody:         &r.HTMLBody,
+		Metadata:         metadata,
+	}
+}
+
+// PushInput addresses the rendered push notification to a device token
+func (r *RenderedTemplate) PushInput(pushToken string, metadata []byte) *models.SendNotificationInput {
+	return &models.SendNotificationInput{
+		NotificationType:   models.NotificationTypePush,
+		RecipientPushToken: &pushToken,
+		Subject:            &r.PushTitle,
+		Body:               r.PushBody,
+		Metadata:           metadata,
+	}
+}
+
+// GuardianInputs addresses the rendered notification to every channel the guardian has an
+// address for. The inputs carry the guardian and topic, so SendNotification checks the
+// guardian's preferences for each channel.
+func (r *RenderedTemplate) GuardianInputs(guardian *models.Guardian, metadata []byte) []*models.SendNotificationInput {
+	var inputs []*models.SendNotificationInput
+	if guardian.Email != "" {
+		inputs = append(inputs, r.EmailInput(guardian.Email, metadata))
+	}
+	if guardian.ExpoPushToken != nil && *guardian.ExpoPushToken != "" {
+		inputs = append(inputs, r.PushInput(*guardian.ExpoPushToken, metadata))
+	}
+	for _, input := range inputs {
+		input.GuardianID = &guardian.ID
+		input.Topic = r.Topic
+	}
+	return inputs
+}
+
+func mustLoadTemplates() *templateRegistry {
+	registry, err := loadTemplates(templateFiles)
+	if err != nil {
+		panic(err)
+	}
+	return registry
+}
+
+func loadTemplates(files fs.FS) (*templateRegistry, error) {
+	layout, err := fs.ReadFile(files, "templates/layout.html.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notification layout: %w", err)
+	}
+
+	paths, err := fs.Glob(files, "templates/*/v*/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	registry := &templateRegistry{
+		variants: make(map[templateKey]templateVariant),
+		latest:   make(map[TemplateName]int),
+	}
+	for _, p := range paths {
+		parts := strings.Split(p, "/")
+		name := TemplateName(parts[1])
+		version, err := strconv.Atoi(strings.TrimPrefix(parts[2], "v"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid notification template version in %s", p)
+		}
+		lang := Language(strings.TrimSuffix(path.Base(p), ".tmpl"))
+
+		source, err := fs.ReadFile(files, p)
+		if err != nil {
+			return nil, err
+		}
+
+		funcs := templateFuncs(lang)
+		text, err := texttemplate.New(p).Funcs(texttemplate.FuncMap(funcs)).Parse(string(source))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", p, err)
+		}
+		html, err := htmltemplate.New(p).Funcs(htmltemplate.FuncMap(funcs)).Parse(string(layout))
+		if err == nil {
+			_, err = html.Parse(string(source))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", p, err)
+		}
+
+		registry.variants[templateKey{name, version, lang}] = templateVariant{text: text, html: html}
+		registry.latest[name] = max(registry.latest[name], version)
+	}
+
+	for name, version := range registry.latest {
+		if _, ok := registry.variants[templateKey{name, version, LanguageEnglish}]; !ok {
+			return nil, fmt.Errorf("notification template %s v%d has no English variant", name, version)
+		}
+	}
+
+	return registry, nil
+}
+
+func templateFuncs(lang Language) map[string]any {
+	return map[string]any{
+		"lang": func() string { return string(lang) },
+		"unsubscribeLabel": func() string {
+			if label, ok := unsubscribeLabels[lang]; ok {
+				return label
+			}
+			return unsubscribeLabels[LanguageEnglish]
+		},
+		"datetime": func(t time.Time) string { return formatDateTime(lang, t) },
+		"date":     func(t time.Time) string { return formatDate(lang, t) },
+		"clock":    func(t time.Time) string { return formatTime(lang, t) },
+		// paragraphs splits free text written by a person on blank lines
+		"paragraphs": func(text string) []string {
+			var paragraphs []string
+			for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+				if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+					paragraphs = append(paragraphs, paragraph)
+				}
+			}
+			return paragraphs
+		},
+	}
+}
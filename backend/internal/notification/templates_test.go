@@ -0,0 +1,200 @@
+package notification
+
+import (
+	"html"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 2026-03-06 08:30 UTC is 15:30 on a Friday in Bangkok
+var templateTestTime = time.Date(2026, time.March, 6, 8, 30, 0, 0, time.UTC)
+
+func TestRenderTemplate(t *testing.T) {
+	tests := []struct {
+		name         string
+		template     TemplateName
+		lang         Language
+		data         any
+		wantSubject  string
+		wantText     []string
+		wantPush     string
+		wantHTMLLang string
+	}{
+		{
+			name:         "registration confirmed in English",
+			template:     TemplateRegistrationConfirmed,
+			lang:         LanguageEnglish,
+			data:         RegistrationConfirmedData{GuardianName: "Alex", EventName: "Robotics Club", StartTime: templateTestTime},
+			wantSubject:  "Registration Confirmed: Robotics Club",
+			wantText:     []string{"Hi Alex", "Friday, March 6, 2026 at 3:30 PM (GMT+7)"},
+			wantHTMLLang: `lang="en"`,
+		},
+		{
+			name:         "registration confirmed in Thai",
+			template:     TemplateRegistrationConfirmed,
+			lang:         LanguageThai,
+			data:         RegistrationConfirmedData{GuardianName: "สมชาย", EventName: "ชมรมหุ่นยนต์", StartTime: templateTestTime},
+			wantSubject:  "ยืนยันการลงทะเบียน: ชมรมหุ่นยนต์",
+			wantText:     []string{"สวัสดีคุณสมชาย", "วันศุกร์ที่ 6 มีนาคม 2569 เวลา 15:30 น."},
+			wantHTMLLang: `lang="th"`,
+		},
+		{
+			name:         "day-before reminder in English",
+			template:     TemplateEventReminder,
+			lang:         LanguageEnglish,
+			data:         EventReminderData{GuardianName: "Alex", EventName: "Robotics Club", StartTime: templateTestTime, HoursBefore: 24},
+			wantSubject:  "Reminder: Robotics Club is tomorrow",
+			wantText:     []string{"starts tomorrow"},
+			wantPush:     "Starts at 3:30 PM on March 6, 2026",
+			wantHTMLLang: `lang="en"`,
+		},
+		{
+			name:         "two-hour reminder in Thai",
+			template:     TemplateEventReminder,
+			lang:         LanguageThai,
+			data:         EventReminderData{GuardianName: "สมชาย", EventName: "ชมรมหุ่นยนต์", StartTime: templateTestTime, HoursBefore: 2},
+			wantText:     []string{"2569"},
+			wantPush:     "15:30 น.",
+			wantHTMLLang: `lang="th"`,
+		},
+		{
+			name:         "occurrence cancelled in English",
+			template:     TemplateEventCancelled,
+			lang:         LanguageEnglish,
+			data:         EventCancelledData{GuardianName: "Alex", EventName: "Robotics Club", StartTime: templateTestTime},
+			wantSubject:  "Cancelled: Robotics Club on March 6, 2026",
+			wantText:     []string{"has been cancelled by the organizer", "Friday, March 6, 2026 at 3:30 PM (GMT+7)"},
+			wantPush:     "won't take place",
+			wantHTMLLang: `lang="en"`,
+		},
+		{
+			name:         "organization broadcast in Thai",
+			template:     TemplateOrganizationBroadcast,
+			lang:         LanguageThai,
+			data:         OrganizationBroadcastData{GuardianName: "สมชาย", OrganizationName: "Bangkok Robotics", Subject: "ย้ายห้องเรียน", Message: "สัปดาห์นี้เรียนที่ห้อง 204"},
+			wantSubject:  "Bangkok Robotics: ย้ายห้องเรียน",
+			wantText:     []string{"สวัสดีคุณสมชาย", "สัปดาห์นี้เรียนที่ห้อง 204"},
+			wantPush:     "สัปดาห์นี้เรียนที่ห้อง 204",
+			wantHTMLLang: `lang="th"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := RenderTemplate(tt.template, tt.lang, tt.data, "")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.template, rendered.Name)
+			assert.Equal(t, 1, rendered.Version)
+			assert.Equal(t, tt.lang, rendered.Language)
+			if tt.wantSubject != "" {
+				assert.Equal(t, tt.wantSubject, rendered.Subject)
+			}
+			for _, want := range tt.wantText {
+				assert.Contains(t, rendered.TextBody, want)
+				assert.Contains(t, html.UnescapeString(rendered.HTMLBody), want)
+			}
+			if tt.wantPush != "" {
+				assert.Contains(t, rendered.PushBody, tt.wantPush)
+			}
+			assert.NotEmpty(t, rendered.PushTitle)
+			assert.Contains(t, rendered.HTMLBody, tt.wantHTMLLang)
+			assert.NotContains(t, rendered.TextBody, "<p>")
+		})
+	}
+}
+
+func TestRenderTemplate_EscapesHTML(t *testing.T) {
+	rendered, err := RenderTemplate(TemplateRegistrationConfirmed, LanguageEnglish, RegistrationConfirmedData{
+		GuardianName: "Alex",
+		EventName:    "<script>alert(1)</script>",
+		StartTime:    templateTestTime,
+	}, "")
+
+	require.NoError(t, err)
+	assert.NotContains(t, rendered.HTMLBody, "<script>")
+	assert.Contains(t, rendered.TextBody, "<script>alert(1)</script>")
+}
+
+func TestRenderTemplate_BroadcastParagraphs(t *testing.T) {
+	rendered, err := RenderTemplate(TemplateOrganizationBroadcast, LanguageEnglish, OrganizationBroadcastData{
+		GuardianName:     "Alex",
+		OrganizationName: "Bangkok Robotics",
+		Subject:          "Bring swimsuits",
+		Message:          "Saturday's class is at the pool.\r\n\r\nPlease bring a swimsuit & towel.",
+	}, "")
+
+	require.NoError(t, err)
+	assert.Contains(t, rendered.HTMLBody, "<p>Saturday&#39;s class is at the pool.</p>")
+	assert.Contains(t, rendered.HTMLBody, "<p>Please bring a swimsuit &amp; towel.</p>")
+	assert.Contains(t, rendered.TextBody, "Please bring a swimsuit & towel.")
+}
+
+func TestRenderTemplate_UnknownTemplate(t *testing.T) {
+	_, err := RenderTemplate("not_a_template", LanguageEnglish, nil, "")
+	assert.Error(t, err)
+
+	_, err = RenderTemplateVersion(TemplateEventReminder, 99, LanguageEnglish, nil, "")
+	assert.Error(t, err)
+}
+
+func TestRenderTemplate_FallsBackToEnglish(t *testing.T) {
+	rendered, err := RenderTemplate(TemplateRegistrationConfirmed, Language("ja"), RegistrationConfirmedData{
+		GuardianName: "Alex",
+		EventName:    "Robotics Club",
+		StartTime:    templateTestTime,
+	}, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, LanguageEnglish, rendered.Language)
+	assert.Equal(t, "Registration Confirmed: Robotics Club", rendered.Subject)
+}
+
+func TestLoadTemplates_UsesLatestVersion(t *testing.T) {
+	block := func(subject string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(`{{define "subject"}}` + subject + `{{end}}{{define "text"}}t{{end}}{{define "content"}}c{{end}}{{define "push_title"}}p{{end}}{{define "push_body"}}b{{end}}`)}
+	}
+	files := fstest.MapFS{
+		"templates/layout.html.tmpl":    &fstest.MapFile{Data: []byte(`{{define "html"}}{{template "content" .}}{{end}}`)},
+		"templates/greeting/v1/en.tmpl": block("old"),
+		"templates/greeting/v2/en.tmpl": block("new"),
+		"templates/greeting/v2/th.tmpl": block("ใหม่"),
+	}
+
+	registry, err := loadTemplates(files)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, registry.latest["greeting"])
+	assert.Len(t, registry.variants, 3)
+}
+
+func TestLoadTemplates_RequiresEnglish(t *testing.T) {
+	files := fstest.MapFS{
+		"templates/layout.html.tmpl":    &fstest.MapFile{Data: []byte(`{{define "html"}}{{end}}`)},
+		"templates/greeting/v1/th.tmpl": &fstest.MapFile{Data: []byte(`{{define "subject"}}x{{end}}`)},
+	}
+
+	_, err := loadTemplates(files)
+
+	assert.Error(t, err)
+}
+
+func TestLanguageFromPreference(t *testing.T) {
+	assert.Equal(t, LanguageThai, LanguageFromPreference("th"))
+	assert.Equal(t, LanguageThai, LanguageFromPreference("th-TH"))
+	assert.Equal(t, LanguageEnglish, LanguageFromPreference("en"))
+	assert.Equal(t, LanguageEnglish, LanguageFromPreference("es"))
+	assert.Equal(t, LanguageEnglish, LanguageFromPreference(""))
+}
+
+func TestFormatDateTime_BangkokAcrossMidnight(t *testing.T) {
+	// 20:00 UTC on Dec 31 is already New Year's Day in Bangkok
+	late := time.Date(2025, time.December, 31, 20, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "Thursday, January 1, 2026 at 3:00 AM (GMT+7)", formatDateTime(LanguageEnglish, late))
+	assert.Equal(t, "วันพฤหัสบดีที่ 1 มกราคม 2569 เวลา 03:00 น.", formatDateTime(LanguageThai, late))
+}
@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"skillspark/internal/config"
+	"skillspark/internal/models"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidUnsubscribeToken is returned for tokens that are malformed or were not signed by us
+var ErrInvalidUnsubscribeToken = errors.New("invalid unsubscribe token")
+
+// UnsubscribeSigner builds and checks the one-click unsubscribe links put in every email. A
+// token names a guardian and topic and is signed, so the link works without signing in but
+// can't be forged for someone else.
+type UnsubscribeSigner struct {
+	baseURL string
+	secret  []byte
+}
+
+func NewUnsubscribeSigner(publicAPIURL string, secret string) *UnsubscribeSigner {
+	return &UnsubscribeSigner{
+		baseURL: strings.TrimRight(publicAPIURL, "/") + "/api/v1/notifications/unsubscribe",
+		secret:  []byte(secret),
+	}
+}
+
+// UnsubscribeSignerFromConfig returns nil, leaving emails without unsubscribe links, when no
+// signing secret is configured
+func UnsubscribeSignerFromConfig(cfg config.Notification) *UnsubscribeSigner {
+	if cfg.UnsubscribeSecret == "" {
+		slog.Warn("NOTIFICATION_UNSUBSCRIBE_SECRET is not set; emails will not include unsubscribe links")
+		return nil
+	}
+	return NewUnsubscribeSigner(cfg.PublicAPIURL, cfg.UnsubscribeSecret)
+}
+
+// URL is the unsubscribe link for the guardian's emails on topic
+func (u *UnsubscribeSigner) URL(guardianID uuid.UUID, topic models.NotificationTopic) string {
+	return u.baseURL + "?token=" + url.QueryEscape(u.Token(guardianID, topic))
+}
+
+// Token signs the guardian and topic as "<guardian id>.<topic>.<signature>"
+func (u *UnsubscribeSigner) Token(guardianID uuid.UUID, topic models.NotificationTopic) string {
+	payload := guardianID.String() + "." + string(topic)
+	return payload + "." + u.sign(payload)
+}
+
+// Verify returns the guardian and topic a token was issued for
+func (u *UnsubscribeSigner) Verify(token string) (uuid.UUID, models.NotificationTopic, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return uuid.Nil, "", ErrInvalidUnsubscribeToken
+	}
+
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(u.sign(payload))) {
+		return uuid.Nil, "", ErrInvalidUnsubscribeToken
+	}
+
+	guardianID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf("%w: %v", ErrInvalidUnsubscribeToken, err)
+	}
+	return guardianID, models.NotificationTopic(parts[1]), nil
+}
+
+func (u *UnsubscribeSigner) sign(payload string) string {
+	mac := hmac.New(sha256.New, u.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,154 @@
+package notification
+
+import (
+	"context"
+	"net/url"
+	"skillspark/internal/models"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnsubscribeSigner_RoundTrip(t *testing.T) {
+	signer := NewUnsubscribeSigner("https://api.example.com/", "secret")
+	guardianID := uuid.New()
+
+	link, err := url.Parse(signer.URL(guardianID, models.NotificationTopicEventReminders))
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/notifications/unsubscribe", link.Path)
+
+	gotID, gotTopic, err := signer.Verify(link.Query().Get("token"))
+
+	require.NoError(t, err)
+	assert.Equal(t, guardianID, gotID)
+	assert.Equal(t, models.NotificationTopicEventReminders, gotTopic)
+}
+
+func TestUnsubscribeSigner_RejectsTamperedTokens(t *testing.T) {
+	signer := NewUnsubscribeSigner("https://api.example.com", "secret")
+	guardianID := uuid.New()
+	token := signer.Token(guardianID, models.NotificationTopicMarketing)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "empty", token: ""},
+		{name: "other topic", token: strings.Replace(token, string(models.NotificationTopicMarketing), string(models.NotificationTopicPayments), 1)},
+		{name: "other guardian", token: strings.Replace(token, guardianID.String(), uuid.NewString(), 1)},
+		{name: "other secret", token: NewUnsubscribeSigner("https://api.example.com", "other").Token(guardianID, models.NotificationTopicMarketing)},
+		{name: "missing signature", token: guardianID.String() + "." + string(models.NotificationTopicMarketing)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := signer.Verify(tt.token)
+			assert.ErrorIs(t, err, ErrInvalidUnsubscribeToken)
+		})
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	signer := NewUnsubscribeSigner("https://api.example.com", "secret")
+	guardianID := uuid.New()
+
+	t.Run("turns off email for the topic", func(t *testing.T) {
+		mockGuardianRepo := new(repomocks.MockGuardianRepository)
+		service := NewService(&storage.Repository{Guardian: mockGuardianRepo}, signer, "")
+		mockGuardianRepo.On("UpdateGuardianNotificationPreferences", mock.Anything, guardianID, []models.NotificationPreference{
+			{Topic: models.NotificationTopicEventReminders, Channel: models.NotificationTypeEmail, Enabled: false},
+		}).Return(nil).Once()
+
+		topic, err := service.Unsubscribe(context.Background(), signer.Token(guardianID, models.NotificationTopicEventReminders))
+
+		require.NoError(t, err)
+		assert.Equal(t, models.NotificationTopicEventReminders, topic)
+		mockGuardianRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		mockGuardianRepo := new(repomocks.MockGuardianRepository)
+		service := NewService(&storage.Repository{Guardian: mockGuardianRepo}, signer, "")
+
+		_, err := service.Unsubscribe(context.Background(), "not-a-token")
+
+		assert.Error(t, err)
+		mockGuardianRepo.AssertNotCalled(t, "UpdateGuardianNotificationPreferences", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("links disabled", func(t *testing.T) {
+		service := NewService(&storage.Repository{}, nil, "")
+
+		_, err := service.Unsubscribe(context.Background(), signer.Token(guardianID, models.NotificationTopicEventReminders))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSendNotification_RespectsPreferences(t *testing.T) {
+	guardianID := uuid.New()
+	email := "parent@example.com"
+	input := &models.SendNotificationInput{
+		NotificationType: models.NotificationTypeEmail,
+		RecipientEmail:   &email,
+		Body:             "hello",
+		GuardianID:       &guardianID,
+		Topic:            models.NotificationTopicMarketing,
+	}
+
+	t.Run("default off topic is not sent", func(t *testing.T) {
+		outbox := &recordingOutbox{}
+		mockGuardianRepo := new(repomocks.MockGuardianRepository)
+		service := NewService(&storage.Repository{Guardian: mockGuardianRepo, Outbox: outbox}, nil, "")
+		mockGuardianRepo.On("GetGuardianNotificationPreferences", mock.Anything, []uuid.UUID{guardianID}).
+			Return(map[uuid.UUID]models.GuardianNotificationPreferences{
+				guardianID: {EmailNotifications: true, PushNotifications: true},
+			}, nil)
+
+		err := service.SendNotification(context.Background(), input)
+
+		assert.ErrorIs(t, err, ErrChannelDisabled)
+		assert.Empty(t, outbox.messages)
+	})
+
+	t.Run("opted in topic is sent", func(t *testing.T) {
+		outbox := &recordingOutbox{}
+		mockGuardianRepo := new(repomocks.MockGuardianRepository)
+		service := NewService(&storage.Repository{Guardian: mockGuardianRepo, Outbox: outbox}, nil, "")
+		mockGuardianRepo.On("GetGuardianNotificationPreferences", mock.Anything, []uuid.UUID{guardianID}).
+			Return(map[uuid.UUID]models.GuardianNotificationPreferences{
+				guardianID: {
+					EmailNotifications: true,
+					Topics: map[models.NotificationTopic]map[models.NotificationType]bool{
+						models.NotificationTopicMarketing: {models.NotificationTypeEmail: true},
+					},
+				},
+			}, nil)
+
+		err := service.SendNotification(context.Background(), input)
+
+		require.NoError(t, err)
+		require.Len(t, outbox.messages, 1)
+		// no signer configured, so no unsubscribe link
+		assert.Nil(t, outbox.messages[0].UnsubscribeURL)
+	})
+
+	t.Run("deleted guardian is not sent", func(t *testing.T) {
+		outbox := &recordingOutbox{}
+		mockGuardianRepo := new(repomocks.MockGuardianRepository)
+		service := NewService(&storage.Repository{Guardian: mockGuardianRepo, Outbox: outbox}, nil, "")
+		mockGuardianRepo.On("GetGuardianNotificationPreferences", mock.Anything, []uuid.UUID{guardianID}).
+			Return(map[uuid.UUID]models.GuardianNotificationPreferences{}, nil)
+
+		err := service.SendNotification(context.Background(), input)
+
+		assert.ErrorIs(t, err, ErrChannelDisabled)
+		assert.Empty(t, outbox.messages)
+	})
+}
@@ -8,13 +8,10 @@ import (
 	"skillspark/internal/config"
 	"skillspark/internal/models"
 
-	"github.com/google/uuid"
 	"github.com/opensearch-project/opensearch-go/v4"
 	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
 )
 
-const index = "events"
-
 type Client struct {
 	api *opensearchapi.Client
 }
@@ -36,86 +33,53 @@ func NewClient(cfg config.OpenSearch) (*Client, error) {
 	return &Client{api: client}, nil
 }
 
-func (c *Client) FuzzySearch(ctx context.Context, query string, acceptLanguage string, from, size int) ([]models.Event, error) {
-	titleField := "title_en"
-	descField := "description_en"
-	if acceptLanguage == "th-TH" {
-		titleField = "title_th"
-		descField = "description_th"
-	}
-
-	body := map[string]any{
-		"from": from,
-		"size": size,
-		"query": map[string]any{
-			"bool": map[string]any{
-				"should": []any{
-					map[string]any{
-						"multi_match": map[string]any{
-							"query":     query,
-							"fields":    []string{titleField + "^2", descField},
-							"fuzziness": "AUTO",
-						},
-					},
-					map[string]any{
-						"term": map[string]any{
-							"category": query,
-						},
-					},
-				},
-				"minimum_should_match": 1,
-			},
-		},
-	}
+// SearchResult is a page of matching events, the number of events that match, and the facet
+// counts over all of them. DidYouMean is a corrected query when a text query matched nothing.
+type SearchResult struct {
+	Events     []models.Event
+	Total      int
+	Facets     models.SearchFacets
+	DidYouMean *string
+}
 
-	bodyBytes, err := json.Marshal(body)
+// Search runs a fuzzy full-text query over the title and description in the requested
+// language, narrowed by the filters and sorted as requested. An empty query browses every
+// event that passes the filters.
+func (c *Client) Search(ctx context.Context, params SearchParams) (*SearchResult, error) {
+	bodyBytes, err := json.Marshal(buildSearchBody(params))
 	if err != nil {
 		return nil, fmt.Errorf("opensearch: failed to marshal query: %w", err)
 	}
 
 	resp, err := c.api.Search(ctx, &opensearchapi.SearchReq{
-		Indices: []string{index},
+		Indices: []string{Events.Alias},
 		Body:    bytes.NewReader(bodyBytes),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("opensearch: search failed: %w", err)
 	}
 
-	type osEvent struct {
-		ID               string   `json:"id"`
-		TitleEN          string   `json:"title_en"`
-		TitleTH          string   `json:"title_th"`
-		DescriptionEN    string   `json:"description_en"`
-		DescriptionTH    string   `json:"description_th"`
-		Category         []string `json:"category"`
-		HeaderImageS3Key *string  `json:"header_image_s3_key"`
-		AgeRangeMin      *int     `json:"age_range_min"`
-		AgeRangeMax      *int     `json:"age_range_max"`
-	}
-
-	events := make([]models.Event, 0)
+	events := make([]models.Event, 0, len(resp.Hits.Hits))
 	for _, hit := range resp.Hits.Hits {
-		var src osEvent
+		var src models.EventSearchDocument
 		if err := json.Unmarshal(hit.Source, &src); err != nil {
 			return nil, fmt.Errorf("opensearch: failed to unmarshal hit: %w", err)
 		}
-		id, err := uuid.Parse(src.ID)
-		if err != nil {
+		event, ok := toEvent(src, params.AcceptLanguage)
+		if !ok {
 			continue
 		}
-		title, description := src.TitleEN, src.DescriptionEN
-		if acceptLanguage == "th-TH" && src.TitleTH != "" {
-			title, description = src.TitleTH, src.DescriptionTH
-		}
-		events = append(events, models.Event{
-			ID:               id,
-			Title:            title,
-			Description:      description,
-			Category:         src.Category,
-			HeaderImageS3Key: src.HeaderImageS3Key,
-			AgeRangeMin:      src.AgeRangeMin,
-			AgeRangeMax:      src.AgeRangeMax,
-		})
+		events = append(events, event)
+	}
+
+	facets, err := parseFacets(resp.Aggregations)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SearchResult{Events: events, Total: resp.Hits.Total.Value, Facets: facets}
+	if result.Total == 0 {
+		result.DidYouMean = correctQuery(params.Query, resp.Suggest[didYouMeanSuggester])
 	}
-	return events, nil
+	return result, nil
 }
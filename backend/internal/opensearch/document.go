@@ -0,0 +1,81 @@
+package opensearch
+
+import (
+	_ "embed"
+	"skillspark/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	//go:embed mappings/events.json
+	eventsMapping []byte
+	//go:embed mappings/occurrences.json
+	occurrencesMapping []byte
+	//go:embed mappings/organizations.json
+	organizationsMapping []byte
+)
+
+// Index is a kind of document the API searches. Alias is the name the API and the sync edge
+// function read and write through; it points at a versioned index built by cmd/reindex.
+type Index struct {
+	Alias   string
+	Mapping []byte
+}
+
+var (
+	// Events are indexed with their occurrences nested, so a filter on price, date and
+	// duration has to be met by a single occurrence, as it is in Postgres
+	Events = Index{Alias: "events", Mapping: eventsMapping}
+	// Occurrences are the scheduled occurrences, each with its event denormalised onto it
+	Occurrences = Index{Alias: "occurrences", Mapping: occurrencesMapping}
+	// Organizations are the active organizations
+	Organizations = Index{Alias: "organizations", Mapping: organizationsMapping}
+)
+
+// Indices lists every index, in the order cmd/reindex builds them
+var Indices = []Index{Events, Occurrences, Organizations}
+
+// IndexByAlias returns the index with the given alias
+func IndexByAlias(alias string) (Index, bool) {
+	for _, ix := range Indices {
+		if ix.Alias == alias {
+			return ix, true
+		}
+	}
+	return Index{}, false
+}
+
+// Document is anything written to an index
+type Document interface {
+	DocumentID() string
+}
+
+// toEvent returns the event in the requested language, falling back to English when it
+// has no Thai title. Documents with an invalid id are skipped.
+func toEvent(d models.EventSearchDocument, acceptLanguage string) (models.Event, bool) {
+	id, err := uuid.Parse(d.ID)
+	if err != nil {
+		return models.Event{}, false
+	}
+	organizationID, _ := uuid.Parse(d.OrganizationID)
+
+	title, description := d.TitleEN, d.DescriptionEN
+	if acceptLanguage == "th-TH" && d.TitleTH != nil && *d.TitleTH != "" {
+		title = *d.TitleTH
+		if d.DescriptionTH != nil {
+			description = *d.DescriptionTH
+		}
+	}
+
+	return models.Event{
+		ID:               id,
+		OrganizationID:   organizationID,
+		Title:            title,
+		Description:      description,
+		Category:         d.Category,
+		HeaderImageS3Key: d.HeaderImageS3Key,
+		AgeRangeMin:      d.AgeRangeMin,
+		AgeRangeMax:      d.AgeRangeMax,
+	}, true
+}
@@ -0,0 +1,75 @@
+package opensearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"skillspark/internal/models"
+)
+
+type termsAggregation struct {
+	Buckets []struct {
+		Key      string `json:"key"`
+		DocCount int    `json:"doc_count"`
+	} `json:"buckets"`
+}
+
+type searchAggregations struct {
+	Category termsAggregation `json:"category"`
+	District termsAggregation `json:"district"`
+	AgeBand  struct {
+		Buckets map[string]struct {
+			DocCount int `json:"doc_count"`
+		} `json:"buckets"`
+	} `json:"age_band"`
+	PriceBand struct {
+		Matching struct {
+			Bands struct {
+				Buckets []struct {
+					Key    string `json:"key"`
+					Events struct {
+						DocCount int `json:"doc_count"`
+					} `json:"events"`
+				} `json:"buckets"`
+			} `json:"bands"`
+		} `json:"matching"`
+	} `json:"price_band"`
+}
+
+// parseFacets reads the aggregations built by buildAggregations. Bands are returned in
+// their defined order, including empty ones, so clients can render a stable list.
+func parseFacets(raw json.RawMessage) (models.SearchFacets, error) {
+	facets := models.SearchFacets{
+		Category:  []models.FacetBucket{},
+		AgeBand:   []models.FacetBucket{},
+		PriceBand: []models.FacetBucket{},
+		District:  []models.FacetBucket{},
+	}
+	if len(raw) == 0 {
+		return facets, nil
+	}
+
+	var aggs searchAggregations
+	if err := json.Unmarshal(raw, &aggs); err != nil {
+		return facets, fmt.Errorf("opensearch: failed to unmarshal aggregations: %w", err)
+	}
+
+	for _, b := range aggs.Category.Buckets {
+		facets.Category = append(facets.Category, models.FacetBucket{Key: b.Key, Count: b.DocCount})
+	}
+	for _, b := range aggs.District.Buckets {
+		facets.District = append(facets.District, models.FacetBucket{Key: b.Key, Count: b.DocCount})
+	}
+	for _, b := range ageBands {
+		facets.AgeBand = append(facets.AgeBand, models.FacetBucket{Key: b.Key, Count: aggs.AgeBand.Buckets[b.Key].DocCount})
+	}
+
+	prices := map[string]int{}
+	for _, b := range aggs.PriceBand.Matching.Bands.Buckets {
+		prices[b.Key] = b.Events.DocCount
+	}
+	for _, b := range priceBands {
+		facets.PriceBand = append(facets.PriceBand, models.FacetBucket{Key: b.Key, Count: prices[b.Key]})
+	}
+
+	return facets, nil
+}
@@ -0,0 +1,50 @@
+package opensearch
+
+import (
+	"encoding/json"
+	"skillspark/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFacets(t *testing.T) {
+	raw := json.RawMessage(`{
+		"category": {"buckets": [{"key": "science", "doc_count": 4}, {"key": "music", "doc_count": 2}]},
+		"district": {"buckets": [{"key": "Pathum Wan", "doc_count": 3}]},
+		"age_band": {"buckets": {"0-3": {"doc_count": 0}, "4-6": {"doc_count": 2}, "7-9": {"doc_count": 5}, "10-12": {"doc_count": 1}, "13+": {"doc_count": 0}}},
+		"price_band": {"doc_count": 12, "matching": {"doc_count": 9, "bands": {"buckets": [
+			{"key": "free", "doc_count": 3, "events": {"doc_count": 1}},
+			{"key": "500-1000", "doc_count": 6, "events": {"doc_count": 2}}
+		]}}}
+	}`)
+
+	facets, err := parseFacets(raw)
+	require.NoError(t, err)
+
+	assert.Equal(t, []models.FacetBucket{{Key: "science", Count: 4}, {Key: "music", Count: 2}}, facets.Category)
+	assert.Equal(t, []models.FacetBucket{{Key: "Pathum Wan", Count: 3}}, facets.District)
+	assert.Equal(t, []models.FacetBucket{
+		{Key: "0-3", Count: 0},
+		{Key: "4-6", Count: 2},
+		{Key: "7-9", Count: 5},
+		{Key: "10-12", Count: 1},
+		{Key: "13+", Count: 0},
+	}, facets.AgeBand)
+	// bands count events, and missing bands are returned empty
+	assert.Equal(t, []models.FacetBucket{
+		{Key: "free", Count: 1},
+		{Key: "under_500", Count: 0},
+		{Key: "500-1000", Count: 2},
+		{Key: "1000-2000", Count: 0},
+		{Key: "2000+", Count: 0},
+	}, facets.PriceBand)
+}
+
+func TestParseFacets_NoAggregations(t *testing.T) {
+	facets, err := parseFacets(nil)
+	require.NoError(t, err)
+	assert.NotNil(t, facets.Category)
+	assert.Empty(t, facets.Category)
+}
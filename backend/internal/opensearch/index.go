@@ -0,0 +1,354 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+const (
+	versionLayout = "20060102150405"
+	// idPageSize is how many document ids are read per request when listing the index
+	idPageSize = 1000
+)
+
+// ErrNoIndex is returned when an alias doesn't point at an index yet, which means a full
+// reindex has never run
+var ErrNoIndex = errors.New("opensearch: alias does not point at an index")
+
+// VersionedName returns the name of a new index for a rebuild started at now. Names sort
+// in the order they were built.
+func (ix Index) VersionedName(now time.Time) string {
+	return ix.Alias + "_" + now.UTC().Format(versionLayout)
+}
+
+func (ix Index) isVersioned(name string) bool {
+	version, ok := strings.CutPrefix(name, ix.Alias+"_")
+	if !ok {
+		return false
+	}
+	_, err := time.Parse(versionLayout, version)
+	return err == nil
+}
+
+// CreateIndex creates an empty index with the mapping of ix
+func (c *Client) CreateIndex(ctx context.Context, ix Index, name string) error {
+	_, err := c.api.Indices.Create(ctx, opensearchapi.IndicesCreateReq{
+		Index: name,
+		Body:  bytes.NewReader(ix.Mapping),
+	})
+	if err != nil {
+		return fmt.Errorf("opensearch: failed to create index %s: %w", name, err)
+	}
+	return nil
+}
+
+// RefreshIndex makes everything written to the index searchable
+func (c *Client) RefreshIndex(ctx context.Context, name string) error {
+	_, err := c.api.Indices.Refresh(ctx, &opensearchapi.IndicesRefreshReq{Indices: []string{name}})
+	if err != nil {
+		return fmt.Errorf("opensearch: failed to refresh index %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteIndices deletes whole indices
+func (c *Client) DeleteIndices(ctx context.Context, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	_, err := c.api.Indices.Delete(ctx, opensearchapi.IndicesDeleteReq{Indices: names})
+	if err != nil {
+		return fmt.Errorf("opensearch: failed to delete indices %v: %w", names, err)
+	}
+	return nil
+}
+
+// BulkIndex writes the documents to index, replacing any with the same id
+func (c *Client) BulkIndex(ctx context.Context, index string, documents []Document) error {
+	if len(documents) == 0 {
+		return nil
+	}
+	body, err := bulkIndexBody(index, documents)
+	if err != nil {
+		return err
+	}
+	return c.bulk(ctx, body)
+}
+
+// BulkDelete removes documents from index. Ids that aren't indexed are ignored.
+func (c *Client) BulkDelete(ctx context.Context, index string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return c.bulk(ctx, bulkDeleteBody(index, ids))
+}
+
+func (c *Client) bulk(ctx context.Context, body []byte) error {
+	resp, err := c.api.Bulk(ctx, opensearchapi.BulkReq{Body: bytes.NewReader(body)})
+	if err != nil {
+		return fmt.Errorf("opensearch: bulk request failed: %w", err)
+	}
+	if !resp.Errors {
+		return nil
+	}
+
+	var failed []string
+	for _, item := range resp.Items {
+		for action, result := range item {
+			if result.Error != nil {
+				failed = append(failed, fmt.Sprintf("%s %s: %s", action, result.ID, result.Error.Reason))
+			}
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("opensearch: %d bulk items failed, first: %s", len(failed), failed[0])
+}
+
+// bulkIndexBody builds the newline delimited body of a bulk request
+func bulkIndexBody(index string, documents []Document) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, document := range documents {
+		action, err := json.Marshal(map[string]any{
+			"index": map[string]any{"_index": index, "_id": document.DocumentID()},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("opensearch: failed to marshal bulk action: %w", err)
+		}
+		source, err := json.Marshal(document)
+		if err != nil {
+			return nil, fmt.Errorf("opensearch: failed to marshal document %s: %w", document.DocumentID(), err)
+		}
+		buf.Write(action)
+		buf.WriteByte('\n')
+		buf.Write(source)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+func bulkDeleteBody(index string, ids []string) []byte {
+	var buf bytes.Buffer
+	for _, id := range ids {
+		action, _ := json.Marshal(map[string]any{
+			"delete": map[string]any{"_index": index, "_id": id},
+		})
+		buf.Write(action)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// indices returns every index whose name starts with the alias of ix, with the aliases on
+// each
+func (c *Client) indices(ctx context.Context, ix Index) (map[string][]string, error) {
+	resp, err := c.api.Indices.Get(ctx, opensearchapi.IndicesGetReq{Indices: []string{ix.Alias + "*"}})
+	if err != nil {
+		return nil, fmt.Errorf("opensearch: failed to list indices: %w", err)
+	}
+
+	indices := make(map[string][]string, len(resp.Indices))
+	for name, index := range resp.Indices {
+		aliases := []string{}
+		for alias := range index.Aliases {
+			aliases = append(aliases, alias)
+		}
+		indices[name] = aliases
+	}
+	return indices, nil
+}
+
+// SwapAlias points the alias at index in one atomic request, so searches never see a
+// missing or half built index. An index left over from before the alias existed, which is
+// named like the alias, is removed in the same request. Returns the indices the alias
+// pointed at before.
+func (c *Client) SwapAlias(ctx context.Context, ix Index, index string) ([]string, error) {
+	indices, err := c.indices(ctx, ix)
+	if err != nil {
+		return nil, err
+	}
+
+	actions, previous := aliasActions(ix.Alias, index, indices)
+	body, err := json.Marshal(map[string]any{"actions": actions})
+	if err != nil {
+		return nil, fmt.Errorf("opensearch: failed to marshal alias actions: %w", err)
+	}
+
+	if _, err := c.api.Aliases(ctx, opensearchapi.AliasesReq{Body: bytes.NewReader(body)}); err != nil {
+		return nil, fmt.Errorf("opensearch: failed to point %s at %s: %w", ix.Alias, index, err)
+	}
+	return previous, nil
+}
+
+func aliasActions(alias string, index string, indices map[string][]string) ([]any, []string) {
+	var actions []any
+	var previous []string
+
+	names := make([]string, 0, len(indices))
+	for name := range indices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if name == alias {
+			actions = append(actions, map[string]any{"remove_index": map[string]any{"index": name}})
+			previous = append(previous, name)
+			continue
+		}
+		if name == index {
+			continue
+		}
+		for _, other := range indices[name] {
+			if other == alias {
+				actions = append(actions, map[string]any{"remove": map[string]any{"index": name, "alias": alias}})
+				previous = append(previous, name)
+			}
+		}
+	}
+
+	actions = append(actions, map[string]any{"add": map[string]any{"index": index, "alias": alias}})
+	return actions, previous
+}
+
+// StaleIndices returns the versioned indices that can be deleted: all but the newest keep
+// indices the alias doesn't point at, which are kept so a rebuild can be rolled back
+func (c *Client) StaleIndices(ctx context.Context, ix Index, keep int) ([]string, error) {
+	indices, err := c.indices(ctx, ix)
+	if err != nil {
+		return nil, err
+	}
+	return staleIndices(ix, indices, keep), nil
+}
+
+func staleIndices(ix Index, indices map[string][]string, keep int) []string {
+	var unused []string
+	for name, aliases := range indices {
+		if !ix.isVersioned(name) {
+			continue
+		}
+		live := false
+		for _, alias := range aliases {
+			live = live || alias == ix.Alias
+		}
+		if !live {
+			unused = append(unused, name)
+		}
+	}
+
+	// newest first
+	sort.Sort(sort.Reverse(sort.StringSlice(unused)))
+	if len(unused) <= keep {
+		return nil
+	}
+	return unused[max(keep, 0):]
+}
+
+// LatestUpdate returns the newest updated_at in the index behind the alias of ix, or nil
+// when it is empty
+func (c *Client) LatestUpdate(ctx context.Context, ix Index) (*time.Time, error) {
+	if err := c.requireIndex(ctx, ix); err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"size": 0,
+		"aggs": map[string]any{
+			"latest": map[string]any{"max": map[string]any{"field": "updated_at"}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("opensearch: failed to marshal query: %w", err)
+	}
+
+	resp, err := c.api.Search(ctx, &opensearchapi.SearchReq{
+		Indices: []string{ix.Alias},
+		Body:    bytes.NewReader(body),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("opensearch: failed to read latest update: %w", err)
+	}
+	return parseLatestUpdate(resp.Aggregations)
+}
+
+func parseLatestUpdate(raw json.RawMessage) (*time.Time, error) {
+	var aggs struct {
+		Latest struct {
+			// milliseconds since the epoch, null when there are no documents
+			Value *float64 `json:"value"`
+		} `json:"latest"`
+	}
+	if err := json.Unmarshal(raw, &aggs); err != nil {
+		return nil, fmt.Errorf("opensearch: failed to unmarshal aggregations: %w", err)
+	}
+	if aggs.Latest.Value == nil {
+		return nil, nil
+	}
+	latest := time.UnixMilli(int64(*aggs.Latest.Value)).UTC()
+	return &latest, nil
+}
+
+// IndexedIDs returns the id of every document in the index behind the alias of ix
+func (c *Client) IndexedIDs(ctx context.Context, ix Index) ([]string, error) {
+	if err := c.requireIndex(ctx, ix); err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	var after []any
+	for {
+		query := map[string]any{
+			"size":    idPageSize,
+			"_source": false,
+			"query":   map[string]any{"match_all": map[string]any{}},
+			"sort":    []any{map[string]any{"id": "asc"}},
+		}
+		if after != nil {
+			query["search_after"] = after
+		}
+		body, err := json.Marshal(query)
+		if err != nil {
+			return nil, fmt.Errorf("opensearch: failed to marshal query: %w", err)
+		}
+
+		resp, err := c.api.Search(ctx, &opensearchapi.SearchReq{
+			Indices: []string{ix.Alias},
+			Body:    bytes.NewReader(body),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("opensearch: failed to list document ids: %w", err)
+		}
+
+		for _, hit := range resp.Hits.Hits {
+			ids = append(ids, hit.ID)
+		}
+		if len(resp.Hits.Hits) < idPageSize {
+			return ids, nil
+		}
+		after = resp.Hits.Hits[len(resp.Hits.Hits)-1].Sort
+	}
+}
+
+func (c *Client) requireIndex(ctx context.Context, ix Index) error {
+	indices, err := c.indices(ctx, ix)
+	if err != nil {
+		return err
+	}
+	for _, aliases := range indices {
+		for _, alias := range aliases {
+			if alias == ix.Alias {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrNoIndex, ix.Alias)
+}
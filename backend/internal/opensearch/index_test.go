@@ -0,0 +1,118 @@
+package opensearch
+
+import (
+	"encoding/json"
+	"skillspark/internal/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionedIndexName(t *testing.T) {
+	bangkok := time.FixedZone("ICT", 7*60*60)
+	name := Events.VersionedName(time.Date(2026, time.May, 18, 9, 30, 15, 0, bangkok))
+
+	assert.Equal(t, "events_20260518023015", name)
+	assert.True(t, Events.isVersioned(name))
+	assert.False(t, Events.isVersioned("events"))
+	assert.False(t, Events.isVersioned("events_backup"))
+	assert.False(t, Events.isVersioned("eventsx_20260518023015"))
+	assert.False(t, Occurrences.isVersioned(name))
+	assert.Equal(t, "occurrences_20260518023015", Occurrences.VersionedName(time.Date(2026, time.May, 18, 9, 30, 15, 0, bangkok)))
+}
+
+func TestBulkIndexBody(t *testing.T) {
+	title := "เวิร์คช็อป"
+	body, err := bulkIndexBody("events_1", []Document{
+		models.EventSearchDocument{ID: "a", TitleEN: "Robotics", TitleTH: &title, Category: []string{"science"}},
+		models.EventSearchDocument{ID: "b", TitleEN: "Chemistry", Occurrences: []models.OccurrenceSearchDocument{{ID: "o", Price: 1000}}},
+	})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+	require.Len(t, lines, 4)
+	assert.JSONEq(t, `{"index": {"_index": "events_1", "_id": "a"}}`, lines[0])
+	assert.JSONEq(t, `{"index": {"_index": "events_1", "_id": "b"}}`, lines[2])
+
+	var document models.EventSearchDocument
+	require.NoError(t, json.Unmarshal([]byte(lines[3]), &document))
+	assert.Equal(t, "Chemistry", document.TitleEN)
+	assert.Equal(t, 1000, document.Occurrences[0].Price)
+	assert.True(t, strings.HasSuffix(string(body), "\n"), "bulk bodies must end with a newline")
+}
+
+func TestBulkDeleteBody(t *testing.T) {
+	body := bulkDeleteBody("events", []string{"a", "b"})
+
+	assert.Equal(t, `{"delete":{"_id":"a","_index":"events"}}`+"\n"+`{"delete":{"_id":"b","_index":"events"}}`+"\n", string(body))
+}
+
+func TestAliasActions(t *testing.T) {
+	tests := []struct {
+		name         string
+		indices      map[string][]string
+		wantActions  string
+		wantPrevious []string
+	}{
+		{
+			name:        "first build",
+			indices:     map[string][]string{"events_2": {}},
+			wantActions: `[{"add": {"index": "events_2", "alias": "events"}}]`,
+		},
+		{
+			name:         "replaces the index written before the alias existed",
+			indices:      map[string][]string{"events": {}, "events_2": {}},
+			wantActions:  `[{"remove_index": {"index": "events"}}, {"add": {"index": "events_2", "alias": "events"}}]`,
+			wantPrevious: []string{"events"},
+		},
+		{
+			name:         "moves the alias",
+			indices:      map[string][]string{"events_1": {"events"}, "events_0": {}, "events_2": {}},
+			wantActions:  `[{"remove": {"index": "events_1", "alias": "events"}}, {"add": {"index": "events_2", "alias": "events"}}]`,
+			wantPrevious: []string{"events_1"},
+		},
+		{
+			name:        "leaves other aliases alone",
+			indices:     map[string][]string{"events_1": {"events_old"}, "events_2": {}},
+			wantActions: `[{"add": {"index": "events_2", "alias": "events"}}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actions, previous := aliasActions("events", "events_2", tt.indices)
+			assert.JSONEq(t, tt.wantActions, toJSON(t, actions))
+			assert.Equal(t, tt.wantPrevious, previous)
+		})
+	}
+}
+
+func TestStaleIndices(t *testing.T) {
+	indices := map[string][]string{
+		"events":                     {},
+		"events_20260101000000":      {},
+		"events_20260201000000":      {},
+		"events_20260301000000":      {},
+		"events_20260401000000":      {"events"},
+		"events_manual_copy":         {},
+		"occurrences_20260101000000": {},
+	}
+
+	assert.Equal(t, []string{"events_20260201000000", "events_20260101000000"}, staleIndices(Events, indices, 1))
+	assert.Equal(t, []string{"events_20260301000000", "events_20260201000000", "events_20260101000000"}, staleIndices(Events, indices, 0))
+	assert.Nil(t, staleIndices(Events, indices, 3))
+}
+
+func TestParseLatestUpdate(t *testing.T) {
+	latest, err := parseLatestUpdate(json.RawMessage(`{"latest": {"value": 1779095415000, "value_as_string": "2026-05-18T09:10:15.000Z"}}`))
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.True(t, time.Date(2026, time.May, 18, 9, 10, 15, 0, time.UTC).Equal(*latest))
+
+	latest, err = parseLatestUpdate(json.RawMessage(`{"latest": {"value": null}}`))
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+}
@@ -0,0 +1,330 @@
+package opensearch
+
+import (
+	"fmt"
+	"skillspark/internal/models"
+	"strings"
+	"time"
+)
+
+const (
+	categoryFacetSize = 20
+	districtFacetSize = 50
+)
+
+// SearchParams is a search request. Filters have the same meaning as they do for
+// GetAllEventOccurrences. Latitude and Longitude without a RadiusKm only position the
+// distance sort.
+type SearchParams struct {
+	Query          string
+	AcceptLanguage string
+	Filters        models.GetAllEventOccurrencesFilter
+	Sort           models.SearchSort
+	From           int
+	Size           int
+}
+
+type band struct {
+	Key string
+	// From is inclusive and To is exclusive; nil means unbounded
+	From *int
+	To   *int
+}
+
+func bound(n int) *int { return &n }
+
+// ageBands are matched against an event's age range, so an event for ages 5-8 is counted
+// in both 4-6 and 7-9
+var ageBands = []band{
+	{Key: "0-3", To: bound(4)},
+	{Key: "4-6", From: bound(4), To: bound(7)},
+	{Key: "7-9", From: bound(7), To: bound(10)},
+	{Key: "10-12", From: bound(10), To: bound(13)},
+	{Key: "13+", From: bound(13)},
+}
+
+// priceBands are in cents
+var priceBands = []band{
+	{Key: "free", To: bound(1)},
+	{Key: "under_500", From: bound(1), To: bound(50000)},
+	{Key: "500-1000", From: bound(50000), To: bound(100000)},
+	{Key: "1000-2000", From: bound(100000), To: bound(200000)},
+	{Key: "2000+", From: bound(200000)},
+}
+
+// textFields returns the title and description fields for the language
+func textFields(acceptLanguage string) (string, string) {
+	if acceptLanguage == "th-TH" {
+		return "title_th", "description_th"
+	}
+	return "title_en", "description_en"
+}
+
+// textQuery matches the query fuzzily against the title and description in the language,
+// or exactly against a category, in the events and occurrences indices
+func textQuery(query string, acceptLanguage string, fields ...string) map[string]any {
+	titleField, descField := textFields(acceptLanguage)
+	return map[string]any{
+		"bool": map[string]any{
+			"should": []any{
+				map[string]any{
+					"multi_match": map[string]any{
+						"query":     query,
+						"fields":    append([]string{titleField + "^2", descField}, fields...),
+						"fuzziness": "AUTO",
+					},
+				},
+				map[string]any{
+					"term": map[string]any{
+						"category": query,
+					},
+				},
+			},
+			"minimum_should_match": 1,
+		},
+	}
+}
+
+// buildSearchBody builds the body of a search request: the text query, the filters, the
+// sort, the facet aggregations and, for a text query, the spelling suggester
+func buildSearchBody(params SearchParams) map[string]any {
+	occurrenceFilters := buildOccurrenceFilters(params.Filters)
+
+	boolQuery := map[string]any{
+		"filter": buildFilters(params.Filters, occurrenceFilters),
+	}
+	if params.Query != "" {
+		boolQuery["must"] = []any{textQuery(params.Query, params.AcceptLanguage)}
+	}
+
+	body := map[string]any{
+		"from":             params.From,
+		"size":             params.Size,
+		"track_total_hits": true,
+		"query":            map[string]any{"bool": boolQuery},
+		"sort":             buildSort(params, occurrenceFilters),
+		"aggs":             buildAggregations(occurrenceFilters),
+	}
+	if params.Query != "" {
+		body["suggest"] = didYouMeanSuggestion(params.Query, params.AcceptLanguage)
+	}
+	return body
+}
+
+// buildFilters returns the event level filters. The occurrence filters are wrapped in one
+// nested query so a single occurrence has to match all of them.
+func buildFilters(filters models.GetAllEventOccurrencesFilter, occurrenceFilters []any) []any {
+	clauses := []any{}
+
+	if filters.Latitude != nil && filters.Longitude != nil && filters.RadiusKm != nil {
+		clauses = append(clauses, map[string]any{
+			"geo_distance": map[string]any{
+				"distance": fmt.Sprintf("%gkm", *filters.RadiusKm),
+				"location": map[string]any{"lat": *filters.Latitude, "lon": *filters.Longitude},
+			},
+		})
+	}
+
+	// events without an age range are suitable for every age
+	if filters.MinAge != nil {
+		clauses = append(clauses, rangeOrMissing("age_range_max", "gte", *filters.MinAge))
+	}
+	if filters.MaxAge != nil {
+		clauses = append(clauses, rangeOrMissing("age_range_min", "lte", *filters.MaxAge))
+	}
+
+	if filters.Category != nil {
+		if categories := splitCategories(*filters.Category); len(categories) > 0 {
+			clauses = append(clauses, map[string]any{
+				"terms": map[string]any{"category": categories},
+			})
+		}
+	}
+
+	if len(occurrenceFilters) > 0 {
+		clauses = append(clauses, map[string]any{
+			"nested": map[string]any{
+				"path":  "occurrences",
+				"query": map[string]any{"bool": map[string]any{"filter": occurrenceFilters}},
+			},
+		})
+	}
+
+	return clauses
+}
+
+func buildOccurrenceFilters(filters models.GetAllEventOccurrencesFilter) []any {
+	clauses := []any{}
+
+	duration := map[string]any{}
+	if filters.MinDurationMinutes != nil {
+		duration["gte"] = *filters.MinDurationMinutes
+	}
+	if filters.MaxDurationMinutes != nil {
+		duration["lte"] = *filters.MaxDurationMinutes
+	}
+	if len(duration) > 0 {
+		clauses = append(clauses, rangeQuery("occurrences.duration_minutes", duration))
+	}
+
+	price := map[string]any{}
+	if filters.MinPrice != nil {
+		price["gte"] = *filters.MinPrice
+	}
+	if filters.MaxPrice != nil {
+		price["lt"] = *filters.MaxPrice
+	}
+	if len(price) > 0 {
+		clauses = append(clauses, rangeQuery("occurrences.price", price))
+	}
+
+	if filters.MinDate != nil {
+		clauses = append(clauses, rangeQuery("occurrences.start_time", map[string]any{"gte": filters.MinDate.Format(time.RFC3339)}))
+	}
+	if filters.MaxDate != nil {
+		clauses = append(clauses, rangeQuery("occurrences.end_time", map[string]any{"lte": filters.MaxDate.Format(time.RFC3339)}))
+	}
+
+	if filters.SoldOut != nil {
+		clauses = append(clauses, map[string]any{
+			"term": map[string]any{"occurrences.sold_out": *filters.SoldOut},
+		})
+	}
+
+	return clauses
+}
+
+// buildSort orders by the requested sort, then by score and id so pages are stable. The
+// date and price sorts use each event's earliest matching occurrence that hasn't started,
+// and put events without one last.
+func buildSort(params SearchParams, occurrenceFilters []any) []any {
+	tiebreak := []any{"_score", map[string]any{"id": "asc"}}
+
+	switch params.Sort {
+	case models.SearchSortDistance:
+		if params.Filters.Latitude == nil || params.Filters.Longitude == nil {
+			break
+		}
+		return append([]any{map[string]any{
+			"_geo_distance": map[string]any{
+				"location":        map[string]any{"lat": *params.Filters.Latitude, "lon": *params.Filters.Longitude},
+				"order":           "asc",
+				"unit":            "km",
+				"ignore_unmapped": true,
+			},
+		}}, tiebreak...)
+	case models.SearchSortDate, models.SearchSortPrice:
+		field := "occurrences.start_time"
+		if params.Sort == models.SearchSortPrice {
+			field = "occurrences.price"
+		}
+		upcoming := append([]any{}, occurrenceFilters...)
+		if params.Filters.MinDate == nil {
+			upcoming = append(upcoming, rangeQuery("occurrences.start_time", map[string]any{"gte": "now"}))
+		}
+		return append([]any{map[string]any{
+			field: map[string]any{
+				"order":   "asc",
+				"mode":    "min",
+				"missing": "_last",
+				"nested": map[string]any{
+					"path":   "occurrences",
+					"filter": map[string]any{"bool": map[string]any{"filter": upcoming}},
+				},
+			},
+		}}, tiebreak...)
+	}
+
+	return tiebreak
+}
+
+// buildAggregations returns the facets. They count every event matching the query and
+// filters; the price bands only look at the occurrences that matched.
+func buildAggregations(occurrenceFilters []any) map[string]any {
+	ages := map[string]any{}
+	for _, b := range ageBands {
+		var clauses []any
+		if b.To != nil {
+			clauses = append(clauses, rangeOrMissing("age_range_min", "lt", *b.To))
+		}
+		if b.From != nil {
+			clauses = append(clauses, rangeOrMissing("age_range_max", "gte", *b.From))
+		}
+		ages[b.Key] = map[string]any{"bool": map[string]any{"filter": clauses}}
+	}
+
+	prices := make([]any, 0, len(priceBands))
+	for _, b := range priceBands {
+		r := map[string]any{"key": b.Key}
+		if b.From != nil {
+			r["from"] = *b.From
+		}
+		if b.To != nil {
+			r["to"] = *b.To
+		}
+		prices = append(prices, r)
+	}
+
+	matching := map[string]any{"match_all": map[string]any{}}
+	if len(occurrenceFilters) > 0 {
+		matching = map[string]any{"bool": map[string]any{"filter": occurrenceFilters}}
+	}
+
+	return map[string]any{
+		"category": map[string]any{
+			"terms": map[string]any{"field": "category", "size": categoryFacetSize},
+		},
+		"district": map[string]any{
+			"terms": map[string]any{"field": "district", "size": districtFacetSize},
+		},
+		"age_band": map[string]any{
+			"filters": map[string]any{"filters": ages},
+		},
+		"price_band": map[string]any{
+			"nested": map[string]any{"path": "occurrences"},
+			"aggs": map[string]any{
+				"matching": map[string]any{
+					"filter": matching,
+					"aggs": map[string]any{
+						"bands": map[string]any{
+							"range": map[string]any{"field": "occurrences.price", "ranges": prices},
+							// counts events rather than occurrences
+							"aggs": map[string]any{
+								"events": map[string]any{"reverse_nested": map[string]any{}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func rangeQuery(field string, bounds map[string]any) map[string]any {
+	return map[string]any{"range": map[string]any{field: bounds}}
+}
+
+// rangeOrMissing matches documents where field is within the bound or isn't set
+func rangeOrMissing(field string, op string, value int) map[string]any {
+	return map[string]any{
+		"bool": map[string]any{
+			"should": []any{
+				rangeQuery(field, map[string]any{op: value}),
+				map[string]any{"bool": map[string]any{
+					"must_not": map[string]any{"exists": map[string]any{"field": field}},
+				}},
+			},
+			"minimum_should_match": 1,
+		},
+	}
+}
+
+func splitCategories(category string) []string {
+	var categories []string
+	for _, c := range strings.Split(category, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			categories = append(categories, c)
+		}
+	}
+	return categories
+}
@@ -0,0 +1,208 @@
+package opensearch
+
+import (
+	"encoding/json"
+	"skillspark/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// toJSON round-trips a query fragment so it can be compared with JSONEq
+func toJSON(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func boolQuery(t *testing.T, body map[string]any) map[string]any {
+	t.Helper()
+	query, ok := body["query"].(map[string]any)
+	require.True(t, ok)
+	boolQuery, ok := query["bool"].(map[string]any)
+	require.True(t, ok)
+	return boolQuery
+}
+
+func TestBuildSearchBody_TextQuery(t *testing.T) {
+	body := buildSearchBody(SearchParams{Query: "robotics", AcceptLanguage: "th-TH", From: 20, Size: 10})
+
+	assert.Equal(t, 20, body["from"])
+	assert.Equal(t, 10, body["size"])
+
+	must := boolQuery(t, body)["must"]
+	assert.JSONEq(t, `[{"bool": {
+		"should": [
+			{"multi_match": {"query": "robotics", "fields": ["title_th^2", "description_th"], "fuzziness": "AUTO"}},
+			{"term": {"category": "robotics"}}
+		],
+		"minimum_should_match": 1
+	}}]`, toJSON(t, must))
+	assert.JSONEq(t, `[]`, toJSON(t, boolQuery(t, body)["filter"]))
+	assert.JSONEq(t, `{
+		"text": "robotics",
+		"did_you_mean": {"term": {"field": "title_th", "suggest_mode": "missing", "sort": "score"}}
+	}`, toJSON(t, body["suggest"]))
+}
+
+func TestBuildSearchBody_EmptyQueryBrowses(t *testing.T) {
+	body := buildSearchBody(SearchParams{AcceptLanguage: "en-US", Size: 10})
+
+	_, hasMust := boolQuery(t, body)["must"]
+	assert.False(t, hasMust)
+	assert.NotContains(t, body, "suggest")
+}
+
+func TestBuildSearchBody_Filters(t *testing.T) {
+	lat, lng, radius := 13.75, 100.5, 7.5
+	minAge, maxAge := 6, 10
+	minPrice, maxPrice := 1000, 50000
+	minDuration, maxDuration := 30, 120
+	category := "science, technology,"
+	soldOut := false
+	minDate := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+	maxDate := time.Date(2026, time.June, 30, 0, 0, 0, 0, time.UTC)
+
+	body := buildSearchBody(SearchParams{
+		AcceptLanguage: "en-US",
+		Size:           10,
+		Filters: models.GetAllEventOccurrencesFilter{
+			Latitude:           &lat,
+			Longitude:          &lng,
+			RadiusKm:           &radius,
+			MinAge:             &minAge,
+			MaxAge:             &maxAge,
+			MinPrice:           &minPrice,
+			MaxPrice:           &maxPrice,
+			MinDurationMinutes: &minDuration,
+			MaxDurationMinutes: &maxDuration,
+			Category:           &category,
+			SoldOut:            &soldOut,
+			MinDate:            &minDate,
+			MaxDate:            &maxDate,
+		},
+	})
+
+	assert.JSONEq(t, `[
+		{"geo_distance": {"distance": "7.5km", "location": {"lat": 13.75, "lon": 100.5}}},
+		{"bool": {"should": [
+			{"range": {"age_range_max": {"gte": 6}}},
+			{"bool": {"must_not": {"exists": {"field": "age_range_max"}}}}
+		], "minimum_should_match": 1}},
+		{"bool": {"should": [
+			{"range": {"age_range_min": {"lte": 10}}},
+			{"bool": {"must_not": {"exists": {"field": "age_range_min"}}}}
+		], "minimum_should_match": 1}},
+		{"terms": {"category": ["science", "technology"]}},
+		{"nested": {"path": "occurrences", "query": {"bool": {"filter": [
+			{"range": {"occurrences.duration_minutes": {"gte": 30, "lte": 120}}},
+			{"range": {"occurrences.price": {"gte": 1000, "lt": 50000}}},
+			{"range": {"occurrences.start_time": {"gte": "2026-06-01T00:00:00Z"}}},
+			{"range": {"occurrences.end_time": {"lte": "2026-06-30T00:00:00Z"}}},
+			{"term": {"occurrences.sold_out": false}}
+		]}}}}
+	]`, toJSON(t, boolQuery(t, body)["filter"]))
+}
+
+func TestBuildSearchBody_LocationWithoutRadiusDoesNotFilter(t *testing.T) {
+	lat, lng := 13.75, 100.5
+	body := buildSearchBody(SearchParams{Filters: models.GetAllEventOccurrencesFilter{Latitude: &lat, Longitude: &lng}})
+
+	assert.JSONEq(t, `[]`, toJSON(t, boolQuery(t, body)["filter"]))
+}
+
+func TestBuildSearchBody_Sort(t *testing.T) {
+	lat, lng := 13.75, 100.5
+	minPrice := 1000
+	minDate := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+	tiebreak := `"_score", {"id": "asc"}`
+
+	tests := []struct {
+		name   string
+		params SearchParams
+		want   string
+	}{
+		{
+			name:   "relevance",
+			params: SearchParams{Sort: models.SearchSortRelevance},
+			want:   `[` + tiebreak + `]`,
+		},
+		{
+			name: "distance",
+			params: SearchParams{
+				Sort:    models.SearchSortDistance,
+				Filters: models.GetAllEventOccurrencesFilter{Latitude: &lat, Longitude: &lng},
+			},
+			want: `[{"_geo_distance": {"location": {"lat": 13.75, "lon": 100.5}, "order": "asc", "unit": "km", "ignore_unmapped": true}}, ` + tiebreak + `]`,
+		},
+		{
+			name:   "distance without a location falls back to relevance",
+			params: SearchParams{Sort: models.SearchSortDistance},
+			want:   `[` + tiebreak + `]`,
+		},
+		{
+			name:   "date uses the next upcoming occurrence",
+			params: SearchParams{Sort: models.SearchSortDate},
+			want: `[{"occurrences.start_time": {"order": "asc", "mode": "min", "missing": "_last", "nested": {
+				"path": "occurrences",
+				"filter": {"bool": {"filter": [{"range": {"occurrences.start_time": {"gte": "now"}}}]}}
+			}}}, ` + tiebreak + `]`,
+		},
+		{
+			name: "price only considers matching occurrences",
+			params: SearchParams{
+				Sort:    models.SearchSortPrice,
+				Filters: models.GetAllEventOccurrencesFilter{MinPrice: &minPrice, MinDate: &minDate},
+			},
+			want: `[{"occurrences.price": {"order": "asc", "mode": "min", "missing": "_last", "nested": {
+				"path": "occurrences",
+				"filter": {"bool": {"filter": [
+					{"range": {"occurrences.price": {"gte": 1000}}},
+					{"range": {"occurrences.start_time": {"gte": "2026-06-01T00:00:00Z"}}}
+				]}}
+			}}}, ` + tiebreak + `]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.JSONEq(t, tt.want, toJSON(t, buildSearchBody(tt.params)["sort"]))
+		})
+	}
+}
+
+func TestBuildSearchBody_Aggregations(t *testing.T) {
+	minPrice := 1000
+	body := buildSearchBody(SearchParams{Filters: models.GetAllEventOccurrencesFilter{MinPrice: &minPrice}})
+
+	aggs, ok := body["aggs"].(map[string]any)
+	require.True(t, ok)
+	assert.ElementsMatch(t, []string{"category", "district", "age_band", "price_band"}, keys(aggs))
+
+	// an event matches an age band when its range overlaps it
+	assert.JSONEq(t, `{"bool": {"filter": [
+		{"bool": {"should": [
+			{"range": {"age_range_min": {"lt": 7}}},
+			{"bool": {"must_not": {"exists": {"field": "age_range_min"}}}}
+		], "minimum_should_match": 1}},
+		{"bool": {"should": [
+			{"range": {"age_range_max": {"gte": 4}}},
+			{"bool": {"must_not": {"exists": {"field": "age_range_max"}}}}
+		], "minimum_should_match": 1}}
+	]}}`, toJSON(t, aggs["age_band"].(map[string]any)["filters"].(map[string]any)["filters"].(map[string]any)["4-6"]))
+
+	// price bands only count the occurrences that matched the filters
+	assert.JSONEq(t, `{"bool": {"filter": [{"range": {"occurrences.price": {"gte": 1000}}}]}}`,
+		toJSON(t, aggs["price_band"].(map[string]any)["aggs"].(map[string]any)["matching"].(map[string]any)["filter"]))
+}
+
+func keys(m map[string]any) []string {
+	var result []string
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
@@ -0,0 +1,210 @@
+package opensearch
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// syncOverlap is subtracted from the newest updated_at in the index when an incremental sync
+// picks where to start, so rows committed late by a slow transaction aren't skipped.
+// Indexing a document twice is harmless.
+const syncOverlap = 5 * time.Minute
+
+// DocumentSource reads the documents of one index from Postgres
+type DocumentSource interface {
+	Documents(ctx context.Context, updatedSince *time.Time, afterID *uuid.UUID, limit int) ([]Document, error)
+	IDs(ctx context.Context) ([]uuid.UUID, error)
+}
+
+// NewSource adapts a pair of repository methods, like EventRepository's
+// GetEventSearchDocuments and GetAllEventIDs, to a DocumentSource
+func NewSource[D Document](
+	documents func(ctx context.Context, updatedSince *time.Time, afterID *uuid.UUID, limit int) ([]D, error),
+	ids func(ctx context.Context) ([]uuid.UUID, error),
+) DocumentSource {
+	return &source[D]{documents: documents, ids: ids}
+}
+
+type source[D Document] struct {
+	documents func(ctx context.Context, updatedSince *time.Time, afterID *uuid.UUID, limit int) ([]D, error)
+	ids       func(ctx context.Context) ([]uuid.UUID, error)
+}
+
+func (s *source[D]) Documents(ctx context.Context, updatedSince *time.Time, afterID *uuid.UUID, limit int) ([]Document, error) {
+	page, err := s.documents(ctx, updatedSince, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	documents := make([]Document, len(page))
+	for i, document := range page {
+		documents[i] = document
+	}
+	return documents, nil
+}
+
+func (s *source[D]) IDs(ctx context.Context) ([]uuid.UUID, error) {
+	return s.ids(ctx)
+}
+
+// Reindexer rebuilds one index from Postgres or brings it up to date
+type Reindexer struct {
+	client    *Client
+	index     Index
+	source    DocumentSource
+	batchSize int
+}
+
+func NewReindexer(client *Client, index Index, source DocumentSource, batchSize int) *Reindexer {
+	return &Reindexer{client: client, index: index, source: source, batchSize: batchSize}
+}
+
+type RebuildResult struct {
+	Index    string
+	Indexed  int
+	Previous []string
+	Deleted  []string
+	CatchUp  *SyncResult
+}
+
+type SyncResult struct {
+	Since   *time.Time
+	Indexed int
+	Removed int
+}
+
+// Rebuild loads every document into a new versioned index and then points the alias at it.
+// Changes the edge function writes to the old index during the build are picked up by an
+// incremental sync from when the build started. Versioned indices beyond the newest keep
+// unused ones are deleted.
+func (r *Reindexer) Rebuild(ctx context.Context, keep int) (*RebuildResult, error) {
+	started := time.Now()
+	index := r.index.VersionedName(started)
+
+	if err := r.client.CreateIndex(ctx, r.index, index); err != nil {
+		return nil, err
+	}
+	slog.Info("Created index", "index", index)
+
+	indexed, err := r.load(ctx, index, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s, the alias still points at the previous index: %w", index, err)
+	}
+	if err := r.client.RefreshIndex(ctx, index); err != nil {
+		return nil, err
+	}
+
+	previous, err := r.client.SwapAlias(ctx, r.index, index)
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("Swapped alias", "alias", r.index.Alias, "index", index, "previous", previous)
+
+	since := started.Add(-syncOverlap)
+	catchUp, err := r.Sync(ctx, &since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to catch up after swapping to %s: %w", index, err)
+	}
+
+	stale, err := r.client.StaleIndices(ctx, r.index, keep)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.client.DeleteIndices(ctx, stale); err != nil {
+		return nil, err
+	}
+
+	return &RebuildResult{
+		Index:    index,
+		Indexed:  indexed,
+		Previous: previous,
+		Deleted:  stale,
+		CatchUp:  catchUp,
+	}, nil
+}
+
+// Sync reindexes the rows that changed after since and removes documents of rows that no
+// longer exist. With a nil since, it starts from the newest document in the index.
+func (r *Reindexer) Sync(ctx context.Context, since *time.Time) (*SyncResult, error) {
+	if since == nil {
+		latest, err := r.client.LatestUpdate(ctx, r.index)
+		if err != nil {
+			return nil, err
+		}
+		if latest != nil {
+			start := latest.Add(-syncOverlap)
+			since = &start
+		}
+	}
+
+	indexed, err := r.load(ctx, r.index.Alias, since)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := r.prune(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SyncResult{Since: since, Indexed: indexed, Removed: removed}, nil
+}
+
+// load writes the documents changed after since, or all of them, to index in batches
+func (r *Reindexer) load(ctx context.Context, index string, since *time.Time) (int, error) {
+	var afterID *uuid.UUID
+	total := 0
+	for {
+		documents, err := r.source.Documents(ctx, since, afterID, r.batchSize)
+		if err != nil {
+			return total, err
+		}
+		if err := r.client.BulkIndex(ctx, index, documents); err != nil {
+			return total, err
+		}
+		total += len(documents)
+
+		if len(documents) < r.batchSize {
+			return total, nil
+		}
+		lastID := documents[len(documents)-1].DocumentID()
+		last, err := uuid.Parse(lastID)
+		if err != nil {
+			return total, fmt.Errorf("invalid document id %q: %w", lastID, err)
+		}
+		afterID = &last
+		slog.Info("Indexed batch", "index", index, "total", total)
+	}
+}
+
+// prune removes documents whose row has been deleted, or no longer belongs in the index,
+// since that leaves no row for updated_at to find
+func (r *Reindexer) prune(ctx context.Context) (int, error) {
+	ids, err := r.source.IDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	existing := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		existing[id.String()] = true
+	}
+
+	indexed, err := r.client.IndexedIDs(ctx, r.index)
+	if err != nil {
+		return 0, err
+	}
+
+	var deleted []string
+	for _, id := range indexed {
+		if !existing[id] {
+			deleted = append(deleted, id)
+		}
+	}
+	if err := r.client.BulkDelete(ctx, r.index.Alias, deleted); err != nil {
+		return 0, err
+	}
+	return len(deleted), nil
+}
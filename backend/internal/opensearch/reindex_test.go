@@ -0,0 +1,361 @@
+package opensearch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"skillspark/internal/config"
+	"skillspark/internal/models"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCluster is just enough of OpenSearch for the reindexer: indices with aliases, bulk
+// writes, alias actions, and the two searches the reindexer runs
+type fakeCluster struct {
+	mu      sync.Mutex
+	indices map[string]*fakeIndex
+}
+
+type fakeIndex struct {
+	aliases   map[string]bool
+	documents map[string]models.EventSearchDocument
+}
+
+func newFakeCluster(t *testing.T) (*fakeCluster, *Client) {
+	t.Helper()
+	cluster := &fakeCluster{indices: map[string]*fakeIndex{}}
+	server := httptest.NewServer(cluster)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(config.OpenSearch{URL: server.URL})
+	require.NoError(t, err)
+	return cluster, client
+}
+
+func (c *fakeCluster) addIndex(name string, aliases ...string) *fakeIndex {
+	index := &fakeIndex{aliases: map[string]bool{}, documents: map[string]models.EventSearchDocument{}}
+	for _, alias := range aliases {
+		index.aliases[alias] = true
+	}
+	c.indices[name] = index
+	return index
+}
+
+// resolve returns the index an alias or index name refers to
+func (c *fakeCluster) resolve(name string) *fakeIndex {
+	if index, ok := c.indices[name]; ok {
+		return index
+	}
+	for _, index := range c.indices {
+		if index.aliases[name] {
+			return index
+		}
+	}
+	return nil
+}
+
+func (c *fakeCluster) aliasTarget() string {
+	for name, index := range c.indices {
+		if index.aliases[Events.Alias] {
+			return name
+		}
+	}
+	return ""
+}
+
+func (c *fakeCluster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	path := strings.Trim(r.URL.Path, "/")
+	body := new(bytes.Buffer)
+	_, _ = body.ReadFrom(r.Body)
+
+	switch {
+	case path == "_bulk":
+		c.bulk(w, body.Bytes())
+	case path == "_aliases":
+		c.aliases(w, body.Bytes())
+	case strings.HasSuffix(path, "/_refresh"):
+		_, _ = w.Write([]byte(`{"_shards": {"total": 1, "successful": 1, "failed": 0}}`))
+	case strings.HasSuffix(path, "/_search"):
+		c.search(w, strings.TrimSuffix(path, "/_search"), body.Bytes())
+	case r.Method == http.MethodPut:
+		c.addIndex(path)
+		_, _ = w.Write([]byte(`{"acknowledged": true, "index": "` + path + `"}`))
+	case r.Method == http.MethodDelete:
+		for _, name := range strings.Split(path, ",") {
+			delete(c.indices, name)
+		}
+		_, _ = w.Write([]byte(`{"acknowledged": true}`))
+	case r.Method == http.MethodGet && strings.HasSuffix(path, "*"):
+		result := map[string]any{}
+		for name, index := range c.indices {
+			if strings.HasPrefix(name, strings.TrimSuffix(path, "*")) {
+				aliases := map[string]any{}
+				for alias := range index.aliases {
+					aliases[alias] = map[string]any{}
+				}
+				result[name] = map[string]any{"aliases": aliases, "mappings": map[string]any{}, "settings": map[string]any{}}
+			}
+		}
+		_ = json.NewEncoder(w).Encode(result)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": {"type": "not_found", "reason": "` + r.Method + ` ` + path + `"}, "status": 404}`))
+	}
+}
+
+func (c *fakeCluster) bulk(w http.ResponseWriter, body []byte) {
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 1<<20), 1<<20)
+	for scanner.Scan() {
+		var action map[string]struct {
+			Index string `json:"_index"`
+			ID    string `json:"_id"`
+		}
+		_ = json.Unmarshal(scanner.Bytes(), &action)
+		if target, ok := action["index"]; ok {
+			scanner.Scan()
+			var document models.EventSearchDocument
+			_ = json.Unmarshal(scanner.Bytes(), &document)
+			c.resolve(target.Index).documents[target.ID] = document
+		}
+		if target, ok := action["delete"]; ok {
+			delete(c.resolve(target.Index).documents, target.ID)
+		}
+	}
+	_, _ = w.Write([]byte(`{"took": 1, "errors": false, "items": []}`))
+}
+
+func (c *fakeCluster) aliases(w http.ResponseWriter, body []byte) {
+	var request struct {
+		Actions []map[string]struct {
+			Index string `json:"index"`
+			Alias string `json:"alias"`
+		} `json:"actions"`
+	}
+	_ = json.Unmarshal(body, &request)
+	for _, action := range request.Actions {
+		for kind, target := range action {
+			switch kind {
+			case "add":
+				c.indices[target.Index].aliases[target.Alias] = true
+			case "remove":
+				delete(c.indices[target.Index].aliases, target.Alias)
+			case "remove_index":
+				delete(c.indices, target.Index)
+			}
+		}
+	}
+	_, _ = w.Write([]byte(`{"acknowledged": true}`))
+}
+
+func (c *fakeCluster) search(w http.ResponseWriter, name string, body []byte) {
+	var request struct {
+		Size        int            `json:"size"`
+		Aggs        map[string]any `json:"aggs"`
+		SearchAfter []string       `json:"search_after"`
+	}
+	_ = json.Unmarshal(body, &request)
+	index := c.resolve(name)
+
+	if request.Aggs != nil {
+		var latest *int64
+		for _, document := range index.documents {
+			ms := document.UpdatedAt.UnixMilli()
+			if latest == nil || ms > *latest {
+				latest = &ms
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"hits":         map[string]any{"total": map[string]any{"value": len(index.documents)}, "hits": []any{}},
+			"aggregations": map[string]any{"latest": map[string]any{"value": latest}},
+		})
+		return
+	}
+
+	var ids []string
+	for id := range index.documents {
+		if len(request.SearchAfter) == 0 || id > request.SearchAfter[0] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	if len(ids) > request.Size {
+		ids = ids[:request.Size]
+	}
+	hits := []any{}
+	for _, id := range ids {
+		hits = append(hits, map[string]any{"_index": name, "_id": id, "sort": []string{id}})
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"hits": map[string]any{"total": map[string]any{"value": len(hits)}, "hits": hits},
+	})
+}
+
+// fakeSource serves documents from memory the way the event repository pages them
+type fakeSource struct {
+	events []models.EventSearchDocument
+}
+
+func (s *fakeSource) documents(_ context.Context, updatedSince *time.Time, afterID *uuid.UUID, limit int) ([]models.EventSearchDocument, error) {
+	sorted := append([]models.EventSearchDocument{}, s.events...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	var page []models.EventSearchDocument
+	for _, document := range sorted {
+		if updatedSince != nil && !document.UpdatedAt.After(*updatedSince) {
+			continue
+		}
+		if afterID != nil && document.ID <= afterID.String() {
+			continue
+		}
+		if len(page) == limit {
+			break
+		}
+		page = append(page, document)
+	}
+	return page, nil
+}
+
+func (s *fakeSource) ids(_ context.Context) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, document := range s.events {
+		ids = append(ids, uuid.MustParse(document.ID))
+	}
+	return ids, nil
+}
+
+func (s *fakeSource) reindexer(client *Client, batchSize int) *Reindexer {
+	return NewReindexer(client, Events, NewSource(s.documents, s.ids), batchSize)
+}
+
+func newDocument(title string, updatedAt time.Time) models.EventSearchDocument {
+	return models.EventSearchDocument{ID: uuid.NewString(), TitleEN: title, UpdatedAt: updatedAt}
+}
+
+func TestReindexer_Rebuild(t *testing.T) {
+	cluster, client := newFakeCluster(t)
+
+	// the index the edge function wrote to before the alias existed, and old builds
+	legacy := cluster.addIndex(Events.Alias)
+	legacy.documents["stale"] = models.EventSearchDocument{ID: "stale"}
+	cluster.addIndex("events_20260101000000")
+	cluster.addIndex("events_20260201000000")
+
+	now := time.Now()
+	source := &fakeSource{}
+	for i := 0; i < 7; i++ {
+		source.events = append(source.events, newDocument("event", now.Add(-24*time.Hour)))
+	}
+
+	result, err := source.reindexer(client, 3).Rebuild(context.Background(), 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, 7, result.Indexed)
+	assert.Equal(t, []string{Events.Alias}, result.Previous)
+	assert.Equal(t, []string{"events_20260101000000"}, result.Deleted)
+	assert.Equal(t, result.Index, cluster.aliasTarget())
+	assert.Len(t, cluster.indices[result.Index].documents, 7)
+	assert.Contains(t, cluster.indices, "events_20260201000000", "the newest unused build is kept for rollback")
+	require.NotNil(t, result.CatchUp)
+	assert.Equal(t, 0, result.CatchUp.Removed)
+}
+
+func TestReindexer_RebuildMovesAlias(t *testing.T) {
+	cluster, client := newFakeCluster(t)
+	cluster.addIndex("events_20260101000000", Events.Alias)
+
+	source := &fakeSource{events: []models.EventSearchDocument{newDocument("event", time.Now())}}
+
+	result, err := source.reindexer(client, 100).Rebuild(context.Background(), 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"events_20260101000000"}, result.Previous)
+	assert.Equal(t, result.Index, cluster.aliasTarget())
+	assert.Empty(t, result.Deleted)
+	assert.False(t, cluster.indices["events_20260101000000"].aliases[Events.Alias])
+}
+
+func TestReindexer_Sync(t *testing.T) {
+	cluster, client := newFakeCluster(t)
+	index := cluster.addIndex("events_20260101000000", Events.Alias)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	unchanged := newDocument("unchanged", now.Add(-72*time.Hour))
+	changed := newDocument("changed", now.Add(-48*time.Hour))
+	deleted := newDocument("deleted", now.Add(-48*time.Hour))
+	for _, document := range []models.EventSearchDocument{unchanged, changed, deleted} {
+		index.documents[document.ID] = document
+	}
+
+	// the changed event was edited after the index was last written
+	changed.TitleEN = "changed again"
+	changed.UpdatedAt = now
+	added := newDocument("added", now)
+	source := &fakeSource{events: []models.EventSearchDocument{unchanged, changed, added}}
+
+	result, err := source.reindexer(client, 100).Sync(context.Background(), nil)
+	require.NoError(t, err)
+
+	require.NotNil(t, result.Since)
+	assert.True(t, now.Add(-48*time.Hour-syncOverlap).Equal(*result.Since))
+	assert.Equal(t, 2, result.Indexed)
+	assert.Equal(t, 1, result.Removed)
+
+	assert.Len(t, index.documents, 3)
+	assert.Equal(t, "changed again", index.documents[changed.ID].TitleEN)
+	assert.Contains(t, index.documents, added.ID)
+	assert.NotContains(t, index.documents, deleted.ID)
+}
+
+func TestReindexer_SyncWithoutIndex(t *testing.T) {
+	_, client := newFakeCluster(t)
+
+	_, err := (&fakeSource{}).reindexer(client, 100).Sync(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrNoIndex)
+}
+
+func TestReindexer_RebuildLeavesOtherIndices(t *testing.T) {
+	cluster, client := newFakeCluster(t)
+	cluster.addIndex("events_20260101000000", Events.Alias)
+
+	occurrences := []models.EventOccurrenceSearchDocument{
+		{ID: uuid.NewString(), TitleEN: "robotics", UpdatedAt: time.Now()},
+		{ID: uuid.NewString(), TitleEN: "swimming", UpdatedAt: time.Now()},
+	}
+	source := NewSource(
+		func(_ context.Context, _ *time.Time, afterID *uuid.UUID, _ int) ([]models.EventOccurrenceSearchDocument, error) {
+			if afterID != nil {
+				return nil, nil
+			}
+			return occurrences, nil
+		},
+		func(_ context.Context) ([]uuid.UUID, error) {
+			return []uuid.UUID{uuid.MustParse(occurrences[0].ID), uuid.MustParse(occurrences[1].ID)}, nil
+		},
+	)
+
+	result, err := NewReindexer(client, Occurrences, source, 100).Rebuild(context.Background(), 0)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(result.Index, "occurrences_"))
+	assert.Equal(t, 2, result.Indexed)
+	assert.Empty(t, result.Previous)
+	assert.Empty(t, result.Deleted)
+	assert.True(t, cluster.indices[result.Index].aliases[Occurrences.Alias])
+	assert.True(t, cluster.indices["events_20260101000000"].aliases[Events.Alias])
+}
@@ -0,0 +1,260 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"skillspark/internal/models"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/google/uuid"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// categoryBuckets is more than there are category values, so every indexed category is
+// considered when matching the query
+const categoryBuckets = 200
+
+// didYouMeanSuggester names the term suggester added to text searches
+const didYouMeanSuggester = "did_you_mean"
+
+// Suggest returns events, organizations and categories matching a partially typed query,
+// at most size of each, in one request. Titles in both languages are matched, with the
+// requested one ranked higher.
+func (c *Client) Suggest(ctx context.Context, query string, acceptLanguage string, size int) (*models.SearchSuggestions, error) {
+	bodyBytes, err := json.Marshal(buildSuggestBody(query, acceptLanguage, size))
+	if err != nil {
+		return nil, fmt.Errorf("opensearch: failed to marshal query: %w", err)
+	}
+
+	resp, err := c.api.Search(ctx, &opensearchapi.SearchReq{
+		Indices: []string{Events.Alias},
+		Body:    bytes.NewReader(bodyBytes),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("opensearch: suggest failed: %w", err)
+	}
+
+	suggestions, err := parseSuggestions(resp.Hits.Hits, resp.Aggregations, query, acceptLanguage, size)
+	if err != nil {
+		return nil, err
+	}
+	return &suggestions, nil
+}
+
+// autocompleteFields returns the title fields matched by Suggest, the requested language
+// first
+func autocompleteFields(acceptLanguage string) []string {
+	if acceptLanguage == "th-TH" {
+		return []string{"title_th.autocomplete^2", "title_en.autocomplete"}
+	}
+	return []string{"title_en.autocomplete^2", "title_th.autocomplete"}
+}
+
+// autocompleteQuery matches the query against edge n-gram fields, so every word has to be
+// the start of a word in the field. Small typos are tolerated after the first letter.
+func autocompleteQuery(query string, fields ...string) map[string]any {
+	return map[string]any{
+		"multi_match": map[string]any{
+			"query":         query,
+			"fields":        fields,
+			"operator":      "and",
+			"fuzziness":     "AUTO",
+			"prefix_length": 1,
+		},
+	}
+}
+
+// buildSuggestBody builds the body of a suggest request. The hits are the event
+// suggestions. Organizations and categories are global aggregations, so they are found
+// even when no event title matches.
+func buildSuggestBody(query string, acceptLanguage string, size int) map[string]any {
+	return map[string]any{
+		"size":             size,
+		"track_total_hits": false,
+		"_source":          []string{"id", "title_en", "title_th"},
+		"query":            autocompleteQuery(query, autocompleteFields(acceptLanguage)...),
+		"aggs": map[string]any{
+			"organizations": map[string]any{
+				"global": map[string]any{},
+				"aggs": map[string]any{
+					"matching": map[string]any{
+						"filter": autocompleteQuery(query, "organization_name.autocomplete"),
+						"aggs": map[string]any{
+							"ids": map[string]any{
+								"terms": map[string]any{"field": "organization_id", "size": size},
+								"aggs": map[string]any{
+									"name": map[string]any{
+										"top_hits": map[string]any{"size": 1, "_source": []string{"organization_name"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			// category values are English enum values, matched in Go by matchCategories
+			"categories": map[string]any{
+				"global": map[string]any{},
+				"aggs": map[string]any{
+					"values": map[string]any{
+						"terms": map[string]any{"field": "category", "size": categoryBuckets},
+					},
+				},
+			},
+		},
+	}
+}
+
+type suggestAggregations struct {
+	Organizations struct {
+		Matching struct {
+			IDs struct {
+				Buckets []struct {
+					Key  string `json:"key"`
+					Name struct {
+						Hits struct {
+							Hits []struct {
+								Source struct {
+									OrganizationName string `json:"organization_name"`
+								} `json:"_source"`
+							} `json:"hits"`
+						} `json:"hits"`
+					} `json:"name"`
+				} `json:"buckets"`
+			} `json:"ids"`
+		} `json:"matching"`
+	} `json:"organizations"`
+	Categories struct {
+		Values termsAggregation `json:"values"`
+	} `json:"categories"`
+}
+
+// parseSuggestions reads the response to buildSuggestBody. Every group is an empty slice
+// rather than nil when nothing matched.
+func parseSuggestions(hits []opensearchapi.SearchHit, raw json.RawMessage, query string, acceptLanguage string, size int) (models.SearchSuggestions, error) {
+	suggestions := models.SearchSuggestions{
+		Events:        []models.EventSuggestion{},
+		Organizations: []models.OrganizationSuggestion{},
+		Categories:    []string{},
+	}
+
+	for _, hit := range hits {
+		var src models.EventSearchDocument
+		if err := json.Unmarshal(hit.Source, &src); err != nil {
+			return suggestions, fmt.Errorf("opensearch: failed to unmarshal hit: %w", err)
+		}
+		event, ok := toEvent(src, acceptLanguage)
+		if !ok {
+			continue
+		}
+		suggestions.Events = append(suggestions.Events, models.EventSuggestion{ID: event.ID, Title: event.Title})
+	}
+
+	if len(raw) == 0 {
+		return suggestions, nil
+	}
+	var aggs suggestAggregations
+	if err := json.Unmarshal(raw, &aggs); err != nil {
+		return suggestions, fmt.Errorf("opensearch: failed to unmarshal aggregations: %w", err)
+	}
+
+	for _, b := range aggs.Organizations.Matching.IDs.Buckets {
+		id, err := uuid.Parse(b.Key)
+		if err != nil || len(b.Name.Hits.Hits) == 0 {
+			continue
+		}
+		suggestions.Organizations = append(suggestions.Organizations, models.OrganizationSuggestion{
+			ID:   id,
+			Name: b.Name.Hits.Hits[0].Source.OrganizationName,
+		})
+	}
+
+	categories := make([]string, 0, len(aggs.Categories.Values.Buckets))
+	for _, b := range aggs.Categories.Values.Buckets {
+		categories = append(categories, b.Key)
+	}
+	suggestions.Categories = matchCategories(categories, query, size)
+
+	return suggestions, nil
+}
+
+// matchCategories returns up to size of the categories, in their given order, that have a
+// word starting with the query, so "sci" finds both "science" and "data science"
+func matchCategories(categories []string, query string, size int) []string {
+	query = strings.ToLower(strings.TrimSpace(query))
+	matched := []string{}
+	if query == "" {
+		return matched
+	}
+
+	for _, category := range categories {
+		if len(matched) == size {
+			break
+		}
+		lower := strings.ToLower(category)
+		if strings.HasPrefix(lower, query) {
+			matched = append(matched, category)
+			continue
+		}
+		for _, word := range strings.Fields(lower) {
+			if strings.HasPrefix(word, query) {
+				matched = append(matched, category)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// didYouMeanSuggestion builds the term suggester run alongside a text search. Only words
+// that aren't in any title get a suggestion.
+func didYouMeanSuggestion(query string, acceptLanguage string) map[string]any {
+	field := "title_en.words"
+	if acceptLanguage == "th-TH" {
+		field = "title_th"
+	}
+	return map[string]any{
+		"text": query,
+		didYouMeanSuggester: map[string]any{
+			"term": map[string]any{
+				"field":        field,
+				"suggest_mode": "missing",
+				"sort":         "score",
+			},
+		},
+	}
+}
+
+// correctQuery rebuilds the query with each misspelt word replaced by its best suggestion.
+// Returns nil when no word has one.
+func correctQuery(query string, entries []opensearchapi.Suggest) *string {
+	sorted := append([]opensearchapi.Suggest{}, entries...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Offset < sorted[j].Offset })
+
+	// offsets count UTF-16 code units, as Java strings do
+	text := utf16.Encode([]rune(query))
+	var corrected []uint16
+	last := 0
+	changed := false
+	for _, entry := range sorted {
+		end := entry.Offset + entry.Length
+		if len(entry.Options) == 0 || entry.Offset < last || end > len(text) {
+			continue
+		}
+		corrected = append(corrected, text[last:entry.Offset]...)
+		corrected = append(corrected, utf16.Encode([]rune(entry.Options[0].Text))...)
+		last = end
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	corrected = append(corrected, text[last:]...)
+
+	suggestion := string(utf16.Decode(corrected))
+	return &suggestion
+}
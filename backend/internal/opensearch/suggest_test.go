@@ -0,0 +1,171 @@
+package opensearch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSuggestBody(t *testing.T) {
+	body := buildSuggestBody("robo", "th-TH", 5)
+
+	assert.Equal(t, 5, body["size"])
+	assert.JSONEq(t, `{"multi_match": {
+		"query": "robo",
+		"fields": ["title_th.autocomplete^2", "title_en.autocomplete"],
+		"operator": "and",
+		"fuzziness": "AUTO",
+		"prefix_length": 1
+	}}`, toJSON(t, body["query"]))
+
+	aggs, ok := body["aggs"].(map[string]any)
+	require.True(t, ok)
+	assert.JSONEq(t, `{
+		"global": {},
+		"aggs": {"matching": {
+			"filter": {"multi_match": {
+				"query": "robo",
+				"fields": ["organization_name.autocomplete"],
+				"operator": "and",
+				"fuzziness": "AUTO",
+				"prefix_length": 1
+			}},
+			"aggs": {"ids": {
+				"terms": {"field": "organization_id", "size": 5},
+				"aggs": {"name": {"top_hits": {"size": 1, "_source": ["organization_name"]}}}
+			}}
+		}}
+	}`, toJSON(t, aggs["organizations"]))
+	assert.Contains(t, aggs, "categories")
+}
+
+func TestParseSuggestions(t *testing.T) {
+	eventID := uuid.New()
+	organizationID := uuid.New()
+
+	hits := []opensearchapi.SearchHit{
+		{Source: json.RawMessage(`{"id": "` + eventID.String() + `", "title_en": "Robotics Club", "title_th": "ชมรมหุ่นยนต์"}`)},
+		{Source: json.RawMessage(`{"id": "not-a-uuid", "title_en": "Broken"}`)},
+	}
+	aggs := json.RawMessage(`{
+		"organizations": {"doc_count": 12, "matching": {"doc_count": 3, "ids": {"buckets": [
+			{"key": "` + organizationID.String() + `", "doc_count": 3, "name": {"hits": {"hits": [
+				{"_source": {"organization_name": "Robo Academy"}}
+			]}}}
+		]}}},
+		"categories": {"doc_count": 12, "values": {"buckets": [
+			{"key": "robotics", "doc_count": 4},
+			{"key": "coding", "doc_count": 3}
+		]}}
+	}`)
+
+	suggestions, err := parseSuggestions(hits, aggs, "ro", "th-TH", 5)
+	require.NoError(t, err)
+
+	require.Len(t, suggestions.Events, 1)
+	assert.Equal(t, eventID, suggestions.Events[0].ID)
+	assert.Equal(t, "ชมรมหุ่นยนต์", suggestions.Events[0].Title)
+
+	require.Len(t, suggestions.Organizations, 1)
+	assert.Equal(t, organizationID, suggestions.Organizations[0].ID)
+	assert.Equal(t, "Robo Academy", suggestions.Organizations[0].Name)
+
+	assert.Equal(t, []string{"robotics"}, suggestions.Categories)
+}
+
+func TestParseSuggestions_NoMatches(t *testing.T) {
+	suggestions, err := parseSuggestions(nil, nil, "zzz", "en-US", 5)
+	require.NoError(t, err)
+
+	assert.NotNil(t, suggestions.Events)
+	assert.Empty(t, suggestions.Events)
+	assert.NotNil(t, suggestions.Organizations)
+	assert.Empty(t, suggestions.Organizations)
+	assert.NotNil(t, suggestions.Categories)
+	assert.Empty(t, suggestions.Categories)
+}
+
+func TestMatchCategories(t *testing.T) {
+	categories := []string{"science", "data science", "social studies", "soccer", "earth science"}
+
+	tests := []struct {
+		name     string
+		query    string
+		size     int
+		expected []string
+	}{
+		{name: "any word prefix", query: "sci", size: 5, expected: []string{"science", "data science", "earth science"}},
+		{name: "case and space insensitive", query: " SO ", size: 5, expected: []string{"social studies", "soccer"}},
+		{name: "phrase prefix", query: "data sc", size: 5, expected: []string{"data science"}},
+		{name: "limited to size", query: "s", size: 2, expected: []string{"science", "data science"}},
+		{name: "no match", query: "xyz", size: 5, expected: []string{}},
+		{name: "empty query", query: "", size: 5, expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, matchCategories(categories, tt.query, tt.size))
+		})
+	}
+}
+
+func TestCorrectQuery(t *testing.T) {
+	option := func(text string) []opensearchapi.SuggestOptions {
+		return []opensearchapi.SuggestOptions{{Text: text}}
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		entries  []opensearchapi.Suggest
+		expected *string
+	}{
+		{
+			name:  "replaces misspelt words",
+			query: "Robotcs Wrkshop for kids",
+			entries: []opensearchapi.Suggest{
+				{Text: "wrkshop", Offset: 8, Length: 7, Options: option("workshop")},
+				{Text: "robotcs", Offset: 0, Length: 7, Options: option("robotics")},
+				{Text: "kids", Offset: 20, Length: 4},
+			},
+			expected: strPtr("robotics workshop for kids"),
+		},
+		{
+			name:  "thai",
+			query: "ว่ายนำ้ เด็ก",
+			entries: []opensearchapi.Suggest{
+				{Text: "ว่ายนำ้", Offset: 0, Length: 7, Options: option("ว่ายน้ำ")},
+			},
+			expected: strPtr("ว่ายน้ำ เด็ก"),
+		},
+		{
+			name:     "no options",
+			query:    "robotics",
+			entries:  []opensearchapi.Suggest{{Text: "robotics", Offset: 0, Length: 8}},
+			expected: nil,
+		},
+		{
+			name:     "no entries",
+			query:    "robotics",
+			expected: nil,
+		},
+		{
+			name:     "offset past the end is ignored",
+			query:    "robot",
+			entries:  []opensearchapi.Suggest{{Text: "robot", Offset: 3, Length: 7, Options: option("robots")}},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, correctQuery(tt.query, tt.entries))
+		})
+	}
+}
+
+func strPtr(s string) *string { return &s }
@@ -0,0 +1,245 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"skillspark/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// UnifiedSearchParams is a query run against the occurrences, events and organizations
+// indices at once. Only occurrences starting at or after Now are returned.
+type UnifiedSearchParams struct {
+	Query          string
+	AcceptLanguage string
+	Types          []models.SearchType
+	Size           int
+	Now            time.Time
+}
+
+// SearchAll runs the query against the index of each requested type in one multi search
+// request and returns at most Size results of each type, best match first
+func (c *Client) SearchAll(ctx context.Context, params UnifiedSearchParams) (*models.UnifiedSearchResult, error) {
+	body, err := buildUnifiedSearchBody(params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.api.MSearch(ctx, opensearchapi.MSearchReq{Body: bytes.NewReader(body)})
+	if err != nil {
+		return nil, fmt.Errorf("opensearch: unified search failed: %w", err)
+	}
+	if len(resp.Responses) != len(params.Types) {
+		return nil, fmt.Errorf("opensearch: unified search returned %d responses for %d searches", len(resp.Responses), len(params.Types))
+	}
+
+	result := emptyUnifiedSearchResult()
+	for i, searchType := range params.Types {
+		r := resp.Responses[i]
+		if r.Status >= 300 {
+			return nil, fmt.Errorf("opensearch: %s search failed with status %d", searchType, r.Status)
+		}
+		if err := addHits(&result, searchType, r.Hits.Hits, r.Hits.Total.Value, params.AcceptLanguage); err != nil {
+			return nil, err
+		}
+	}
+	return &result, nil
+}
+
+func emptyUnifiedSearchResult() models.UnifiedSearchResult {
+	return models.UnifiedSearchResult{
+		Occurrences:   []models.OccurrenceSearchResult{},
+		Events:        []models.Event{},
+		Organizations: []models.OrganizationSearchResult{},
+	}
+}
+
+// buildUnifiedSearchBody builds the newline delimited body of a multi search request, one
+// search per type in the order of params.Types
+func buildUnifiedSearchBody(params UnifiedSearchParams) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, searchType := range params.Types {
+		var alias string
+		var query map[string]any
+		switch searchType {
+		case models.SearchTypeOccurrences:
+			alias, query = Occurrences.Alias, occurrencesQuery(params)
+		case models.SearchTypeEvents:
+			alias, query = Events.Alias, eventsQuery(params)
+		case models.SearchTypeOrganizations:
+			alias, query = Organizations.Alias, organizationsQuery(params)
+		default:
+			return nil, fmt.Errorf("opensearch: unknown search type %q", searchType)
+		}
+
+		header, err := json.Marshal(map[string]any{"index": alias})
+		if err != nil {
+			return nil, fmt.Errorf("opensearch: failed to marshal search header: %w", err)
+		}
+		search, err := json.Marshal(query)
+		if err != nil {
+			return nil, fmt.Errorf("opensearch: failed to marshal query: %w", err)
+		}
+		buf.Write(header)
+		buf.WriteByte('\n')
+		buf.Write(search)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// occurrencesQuery matches upcoming occurrences by their event's text or their
+// organization's name. Equally good matches are ordered soonest first.
+func occurrencesQuery(params UnifiedSearchParams) map[string]any {
+	return map[string]any{
+		"size":             params.Size,
+		"track_total_hits": true,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"must": []any{textQuery(params.Query, params.AcceptLanguage, "organization_name")},
+				"filter": []any{
+					rangeQuery("start_time", map[string]any{"gte": params.Now.UTC().Format(time.RFC3339)}),
+				},
+			},
+		},
+		"sort": []any{
+			"_score",
+			map[string]any{"start_time": "asc"},
+		},
+	}
+}
+
+func eventsQuery(params UnifiedSearchParams) map[string]any {
+	return map[string]any{
+		"size":             params.Size,
+		"track_total_hits": true,
+		"query":            textQuery(params.Query, params.AcceptLanguage),
+	}
+}
+
+func organizationsQuery(params UnifiedSearchParams) map[string]any {
+	aboutField := "about_en"
+	if params.AcceptLanguage == "th-TH" {
+		aboutField = "about_th"
+	}
+	return map[string]any{
+		"size":             params.Size,
+		"track_total_hits": true,
+		"query": map[string]any{
+			"multi_match": map[string]any{
+				"query":     params.Query,
+				"fields":    []string{"name^3", aboutField},
+				"fuzziness": "AUTO",
+			},
+		},
+	}
+}
+
+// addHits converts the hits of one search to results of its type
+func addHits(result *models.UnifiedSearchResult, searchType models.SearchType, hits []opensearchapi.SearchHit, total int, acceptLanguage string) error {
+	for _, hit := range hits {
+		switch searchType {
+		case models.SearchTypeOccurrences:
+			var src models.EventOccurrenceSearchDocument
+			if err := json.Unmarshal(hit.Source, &src); err != nil {
+				return fmt.Errorf("opensearch: failed to unmarshal hit: %w", err)
+			}
+			if occurrence, ok := toOccurrence(src, acceptLanguage); ok {
+				result.Occurrences = append(result.Occurrences, occurrence)
+			}
+		case models.SearchTypeEvents:
+			var src models.EventSearchDocument
+			if err := json.Unmarshal(hit.Source, &src); err != nil {
+				return fmt.Errorf("opensearch: failed to unmarshal hit: %w", err)
+			}
+			if event, ok := toEvent(src, acceptLanguage); ok {
+				result.Events = append(result.Events, event)
+			}
+		case models.SearchTypeOrganizations:
+			var src models.OrganizationSearchDocument
+			if err := json.Unmarshal(hit.Source, &src); err != nil {
+				return fmt.Errorf("opensearch: failed to unmarshal hit: %w", err)
+			}
+			if organization, ok := toOrganization(src, acceptLanguage); ok {
+				result.Organizations = append(result.Organizations, organization)
+			}
+		}
+	}
+
+	switch searchType {
+	case models.SearchTypeOccurrences:
+		result.OccurrencesTotal = total
+	case models.SearchTypeEvents:
+		result.EventsTotal = total
+	case models.SearchTypeOrganizations:
+		result.OrganizationsTotal = total
+	}
+	return nil
+}
+
+// toOccurrence returns the occurrence in the requested language, falling back to English
+// when its event has no Thai title. Documents with an invalid id are skipped.
+func toOccurrence(d models.EventOccurrenceSearchDocument, acceptLanguage string) (models.OccurrenceSearchResult, bool) {
+	id, err := uuid.Parse(d.ID)
+	if err != nil {
+		return models.OccurrenceSearchResult{}, false
+	}
+	eventID, _ := uuid.Parse(d.EventID)
+	organizationID, _ := uuid.Parse(d.OrganizationID)
+
+	title, description := d.TitleEN, d.DescriptionEN
+	if acceptLanguage == "th-TH" && d.TitleTH != nil && *d.TitleTH != "" {
+		title = *d.TitleTH
+		if d.DescriptionTH != nil {
+			description = *d.DescriptionTH
+		}
+	}
+
+	return models.OccurrenceSearchResult{
+		ID:               id,
+		EventID:          eventID,
+		OrganizationID:   organizationID,
+		OrganizationName: d.OrganizationName,
+		Title:            title,
+		Description:      description,
+		Category:         d.Category,
+		HeaderImageS3Key: d.HeaderImageS3Key,
+		AgeRangeMin:      d.AgeRangeMin,
+		AgeRangeMax:      d.AgeRangeMax,
+		District:         d.District,
+		StartTime:        d.StartTime,
+		EndTime:          d.EndTime,
+		Price:            d.Price,
+		Currency:         d.Currency,
+		Language:         d.Language,
+		SeatsLeft:        d.SeatsLeft,
+		SoldOut:          d.SoldOut,
+	}, true
+}
+
+// toOrganization returns the organization with its about text in the requested language,
+// falling back to English
+func toOrganization(d models.OrganizationSearchDocument, acceptLanguage string) (models.OrganizationSearchResult, bool) {
+	id, err := uuid.Parse(d.ID)
+	if err != nil {
+		return models.OrganizationSearchResult{}, false
+	}
+
+	about := d.AboutEN
+	if acceptLanguage == "th-TH" && d.AboutTH != nil && *d.AboutTH != "" {
+		about = d.AboutTH
+	}
+
+	return models.OrganizationSearchResult{
+		ID:       id,
+		Name:     d.Name,
+		About:    about,
+		District: d.District,
+		PfpS3Key: d.PfpS3Key,
+	}, true
+}
@@ -0,0 +1,152 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"skillspark/internal/config"
+	"skillspark/internal/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildUnifiedSearchBody(t *testing.T) {
+	now := time.Date(2026, time.May, 18, 9, 0, 0, 0, time.UTC)
+	body, err := buildUnifiedSearchBody(UnifiedSearchParams{
+		Query:          "robotics",
+		AcceptLanguage: "th-TH",
+		Types:          []models.SearchType{models.SearchTypeOccurrences, models.SearchTypeOrganizations},
+		Size:           5,
+		Now:            now,
+	})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+	require.Len(t, lines, 4)
+	assert.JSONEq(t, `{"index": "occurrences"}`, lines[0])
+	assert.JSONEq(t, `{"index": "organizations"}`, lines[2])
+
+	var occurrences map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &occurrences))
+	assert.EqualValues(t, 5, occurrences["size"])
+	assert.JSONEq(t, `["_score", {"start_time": "asc"}]`, toJSON(t, occurrences["sort"]))
+	assert.JSONEq(t, `{"bool": {
+		"must": [{"bool": {
+			"should": [
+				{"multi_match": {"query": "robotics", "fields": ["title_th^2", "description_th", "organization_name"], "fuzziness": "AUTO"}},
+				{"term": {"category": "robotics"}}
+			],
+			"minimum_should_match": 1
+		}}],
+		"filter": [{"range": {"start_time": {"gte": "2026-05-18T09:00:00Z"}}}]
+	}}`, toJSON(t, occurrences["query"]))
+
+	var organizations map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[3]), &organizations))
+	assert.JSONEq(t, `{"multi_match": {"query": "robotics", "fields": ["name^3", "about_th"], "fuzziness": "AUTO"}}`, toJSON(t, organizations["query"]))
+}
+
+func TestBuildUnifiedSearchBody_UnknownType(t *testing.T) {
+	_, err := buildUnifiedSearchBody(UnifiedSearchParams{Query: "robotics", Types: []models.SearchType{"schools"}})
+	assert.Error(t, err)
+}
+
+func TestToOccurrence(t *testing.T) {
+	titleTH := "หุ่นยนต์"
+	document := models.EventOccurrenceSearchDocument{
+		ID:               uuid.NewString(),
+		EventID:          uuid.NewString(),
+		OrganizationID:   uuid.NewString(),
+		OrganizationName: "Robo Academy",
+		TitleEN:          "Robotics",
+		TitleTH:          &titleTH,
+		DescriptionEN:    "Build robots",
+		Price:            150000,
+		Currency:         "thb",
+		SeatsLeft:        0,
+		SoldOut:          true,
+	}
+
+	occurrence, ok := toOccurrence(document, "th-TH")
+	require.True(t, ok)
+	assert.Equal(t, "หุ่นยนต์", occurrence.Title)
+	assert.Equal(t, document.EventID, occurrence.EventID.String())
+	assert.Equal(t, "Robo Academy", occurrence.OrganizationName)
+	assert.Equal(t, 150000, occurrence.Price)
+	assert.True(t, occurrence.SoldOut)
+
+	document.ID = "not-a-uuid"
+	_, ok = toOccurrence(document, "en-US")
+	assert.False(t, ok)
+}
+
+func TestSearchAll(t *testing.T) {
+	occurrenceID, eventID, organizationID := uuid.New(), uuid.New(), uuid.New()
+	aboutTH := "สอนหุ่นยนต์"
+
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requested = r.URL.Path + "\n" + string(body)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"took": 1, "responses": []any{
+			map[string]any{"status": 200, "hits": map[string]any{"total": map[string]any{"value": 7}, "hits": []any{
+				map[string]any{"_source": models.EventOccurrenceSearchDocument{ID: occurrenceID.String(), EventID: eventID.String(), TitleEN: "Robotics", SeatsLeft: 3}},
+			}}},
+			map[string]any{"status": 200, "hits": map[string]any{"total": map[string]any{"value": 1}, "hits": []any{
+				map[string]any{"_source": models.EventSearchDocument{ID: eventID.String(), TitleEN: "Robotics"}},
+			}}},
+			map[string]any{"status": 200, "hits": map[string]any{"total": map[string]any{"value": 1}, "hits": []any{
+				map[string]any{"_source": models.OrganizationSearchDocument{ID: organizationID.String(), Name: "Robo Academy", AboutTH: &aboutTH}},
+			}}},
+		}})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(config.OpenSearch{URL: server.URL})
+	require.NoError(t, err)
+
+	result, err := client.SearchAll(context.Background(), UnifiedSearchParams{
+		Query:          "robotics",
+		AcceptLanguage: "th-TH",
+		Types:          []models.SearchType{models.SearchTypeOccurrences, models.SearchTypeEvents, models.SearchTypeOrganizations},
+		Size:           5,
+		Now:            time.Now(),
+	})
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(requested, "/_msearch\n"))
+	require.Len(t, result.Occurrences, 1)
+	assert.Equal(t, occurrenceID, result.Occurrences[0].ID)
+	assert.Equal(t, 3, result.Occurrences[0].SeatsLeft)
+	assert.Equal(t, 7, result.OccurrencesTotal)
+	require.Len(t, result.Events, 1)
+	assert.Equal(t, eventID, result.Events[0].ID)
+	require.Len(t, result.Organizations, 1)
+	assert.Equal(t, "สอนหุ่นยนต์", *result.Organizations[0].About)
+}
+
+func TestSearchAll_FailedSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took": 1, "responses": [{"status": 404, "error": {"type": "index_not_found_exception"}}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(config.OpenSearch{URL: server.URL})
+	require.NoError(t, err)
+
+	_, err = client.SearchAll(context.Background(), UnifiedSearchParams{
+		Query: "robotics",
+		Types: []models.SearchType{models.SearchTypeOccurrences},
+		Size:  5,
+	})
+	assert.ErrorContains(t, err, "occurrences search failed")
+}
@@ -0,0 +1,113 @@
+package recommend
+
+import (
+	"skillspark/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Evaluation is how well recommendations predicted the events children went on to book
+type Evaluation struct {
+	// Children is the number of children whose latest booking was held out
+	Children int
+	// HitRate is the share of children whose held out event was in their top K
+	HitRate float64
+	// MeanReciprocalRank averages 1/rank of the held out event, counting 0 when it wasn't
+	// in the top K
+	MeanReciprocalRank float64
+}
+
+// ranker returns the top k events for a child's profile, trained on train
+type ranker func(train []models.RecommendationInteraction, profile map[uuid.UUID]float64, k int) []Scored
+
+// Evaluate measures the collaborative-filtering model offline. For every child who booked
+// at least two events, the latest one is held out; the model is trained on everything else
+// and scored on whether it recommends the held out event in the child's top k. The model
+// is retrained for each child, so this is meant for offline runs, not the job.
+func Evaluate(interactions []models.RecommendationInteraction, k int) Evaluation {
+	return evaluate(interactions, k, func(train []models.RecommendationInteraction, profile map[uuid.UUID]float64, k int) []Scored {
+		return Train(train).Recommend(profile, k)
+	})
+}
+
+// EvaluatePopularity measures recommending the events the most families booked, the
+// baseline the model has to beat
+func EvaluatePopularity(interactions []models.RecommendationInteraction, k int) Evaluation {
+	return evaluate(interactions, k, func(train []models.RecommendationInteraction, profile map[uuid.UUID]float64, k int) []Scored {
+		popularity := map[uuid.UUID]float64{}
+		for _, events := range profiles(train, byGuardian) {
+			for event, weight := range events {
+				if weight > 0 {
+					popularity[event]++
+				}
+			}
+		}
+
+		scores := make(map[uuid.UUID]float64, len(popularity))
+		for event, families := range popularity {
+			if _, seen := profile[event]; !seen {
+				scores[event] = families
+			}
+		}
+		return top(scores, k)
+	})
+}
+
+func evaluate(interactions []models.RecommendationInteraction, k int, rank ranker) Evaluation {
+	heldOut := latestBookings(interactions)
+	if len(heldOut) == 0 {
+		return Evaluation{}
+	}
+
+	var hits, reciprocalRanks float64
+	for childID, booking := range heldOut {
+		// everything the family did with the held out event goes, so a save or a
+		// sibling's booking of it can't give the answer away
+		var train []models.RecommendationInteraction
+		for _, interaction := range interactions {
+			if interaction.GuardianID != booking.GuardianID || interaction.EventID != booking.EventID {
+				train = append(train, interaction)
+			}
+		}
+
+		for i, scored := range rank(train, profiles(train, byChild)[childID], k) {
+			if scored.EventID == booking.EventID {
+				hits++
+				reciprocalRanks += 1 / float64(i+1)
+				break
+			}
+		}
+	}
+
+	return Evaluation{
+		Children:           len(heldOut),
+		HitRate:            hits / float64(len(heldOut)),
+		MeanReciprocalRank: reciprocalRanks / float64(len(heldOut)),
+	}
+}
+
+// latestBookings returns the latest registration of each child who registered for at
+// least two different events
+func latestBookings(interactions []models.RecommendationInteraction) map[uuid.UUID]models.RecommendationInteraction {
+	latest := map[uuid.UUID]models.RecommendationInteraction{}
+	booked := map[uuid.UUID]map[uuid.UUID]bool{}
+	for _, interaction := range interactions {
+		if interaction.Kind != models.RecommendationInteractionRegistration {
+			continue
+		}
+		if booked[interaction.ChildID] == nil {
+			booked[interaction.ChildID] = map[uuid.UUID]bool{}
+		}
+		booked[interaction.ChildID][interaction.EventID] = true
+		if current, ok := latest[interaction.ChildID]; !ok || interaction.CreatedAt.After(current.CreatedAt) {
+			latest[interaction.ChildID] = interaction
+		}
+	}
+
+	for childID, events := range booked {
+		if len(events) < 2 {
+			delete(latest, childID)
+		}
+	}
+	return latest
+}
@@ -0,0 +1,57 @@
+package recommend
+
+import (
+	"skillspark/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// bookings builds families who book robotics then coding, or painting then ballet. Many
+// of them also booked swimming first, which makes it the most popular event, so a
+// popularity baseline predicts swimming for the families who haven't booked it.
+func bookings() []models.RecommendationInteraction {
+	var interactions []models.RecommendationInteraction
+	for i := range 20 {
+		f := newFamily()
+		if i%2 == 0 {
+			interactions = append(interactions, f.registers(swimming, 1))
+		}
+		if i < 10 {
+			interactions = append(interactions, f.registers(robotics, 2), f.registers(coding, 3), f.reviews(coding, 5))
+		} else {
+			interactions = append(interactions, f.registers(painting, 2), f.saves(ballet), f.registers(ballet, 3))
+		}
+	}
+	return interactions
+}
+
+func TestEvaluate(t *testing.T) {
+	interactions := bookings()
+
+	model := Evaluate(interactions, 1)
+	baseline := EvaluatePopularity(interactions, 1)
+
+	assert.Equal(t, 20, model.Children)
+	assert.Equal(t, 1.0, model.HitRate, "the held out event is what families like theirs booked next")
+	assert.Equal(t, 1.0, model.MeanReciprocalRank)
+	assert.Greater(t, model.HitRate, baseline.HitRate)
+}
+
+func TestEvaluate_TopK(t *testing.T) {
+	interactions := bookings()
+
+	model := Evaluate(interactions, 3)
+	baseline := EvaluatePopularity(interactions, 3)
+
+	assert.Equal(t, 1.0, model.HitRate)
+	assert.GreaterOrEqual(t, model.MeanReciprocalRank, baseline.MeanReciprocalRank)
+}
+
+func TestEvaluate_NothingToHoldOut(t *testing.T) {
+	f := newFamily()
+	interactions := []models.RecommendationInteraction{f.registers(robotics, 1), f.saves(coding)}
+
+	assert.Equal(t, Evaluation{}, Evaluate(interactions, 5))
+	assert.Equal(t, Evaluation{}, EvaluatePopularity(interactions, 5))
+}
@@ -0,0 +1,110 @@
+package recommend
+
+import (
+	"skillspark/internal/models"
+)
+
+// popularRegistrations is how many recent registrations make an event popular
+const popularRegistrations = 3
+
+var explanationTexts = map[models.RecommendationReason][2]string{
+	// English, Thai
+	models.RecommendationReasonMatchesInterest: {"Matches interest: ", "ตรงกับความสนใจ: "},
+	models.RecommendationReasonSimilarFamilies: {"Booked by families like yours", "ครอบครัวที่คล้ายกับคุณจองกิจกรรมนี้"},
+	models.RecommendationReasonPopularNearYou:  {"Popular near you", "ยอดนิยมใกล้คุณ"},
+	models.RecommendationReasonPopular:         {"Popular with families", "ได้รับความนิยมในหมู่ครอบครัว"},
+	models.RecommendationReasonUpcoming:        {"Coming up soon", "เริ่มเร็วๆ นี้"},
+}
+
+// interestLabelsTH are the Thai names of the event categories, as the app shows them; in
+// English the category itself is the label
+var interestLabelsTH = map[string]string{
+	"science":            "วิทยาศาสตร์",
+	"math":               "คณิตศาสตร์",
+	"music":              "ดนตรี",
+	"art":                "ศิลปะ",
+	"sports":             "กีฬา",
+	"technology":         "เทคโนโลยี",
+	"language":           "ภาษา",
+	"other":              "อื่นๆ",
+	"physics":            "ฟิสิกส์",
+	"chemistry":          "เคมี",
+	"biology":            "ชีววิทยา",
+	"astronomy":          "ดาราศาสตร์",
+	"earth science":      "วิทยาศาสตร์โลก",
+	"data science":       "วิทยาการข้อมูล",
+	"robotics":           "หุ่นยนต์",
+	"engineering":        "วิศวกรรมศาสตร์",
+	"statistics":         "สถิติ",
+	"coding":             "การเขียนโปรแกรม",
+	"painting":           "การวาดภาพระบายสี",
+	"drawing":            "การวาดภาพ",
+	"sculpture":          "ประติมากรรม",
+	"photography":        "การถ่ายภาพ",
+	"filmmaking":         "การทำหนัง",
+	"graphic design":     "กราฟิกดีไซน์",
+	"fashion":            "แฟชั่น",
+	"crafts":             "งานฝีมือ",
+	"creative writing":   "การเขียนสร้างสรรค์",
+	"instrumental music": "ดนตรีบรรเลง",
+	"vocal music":        "ดนตรีร้อง",
+	"theater":            "ละครเวที",
+	"acting":             "การแสดง",
+	"dance":              "การเต้น",
+	"improv":             "การแสดงด้นสด",
+	"soccer":             "ฟุตบอล",
+	"basketball":         "บาสเกตบอล",
+	"tennis":             "เทนนิส",
+	"swimming":           "การว่ายน้ำ",
+	"martial arts":       "ศิลปะการต่อสู้",
+	"yoga":               "โยคะ",
+	"fitness":            "ฟิตเนส",
+	"running":            "การวิ่ง",
+	"hiking":             "การเดินป่า",
+	"cycling":            "การปั่นจักรยาน",
+	"language learning":  "การเรียนภาษา",
+	"linguistics":        "ภาษาศาสตร์",
+	"history":            "ประวัติศาสตร์",
+}
+
+// explain lists why a candidate was recommended: each interest it matches, then whether
+// families like the child's booked it, then whether it is popular. A candidate with none
+// of those is only there because it is coming up.
+func explain(c models.RecommendationCandidate, acceptLanguage string) []models.RecommendationExplanation {
+	var explanations []models.RecommendationExplanation
+	for _, interest := range c.MatchedInterests {
+		explanations = append(explanations, explanation(models.RecommendationReasonMatchesInterest, acceptLanguage, interestLabel(interest, acceptLanguage)))
+	}
+	if c.Components.Collaborative > 0 {
+		explanations = append(explanations, explanation(models.RecommendationReasonSimilarFamilies, acceptLanguage, ""))
+	}
+	if c.RecentRegistrations >= popularRegistrations {
+		if c.DistanceKm != nil {
+			explanations = append(explanations, explanation(models.RecommendationReasonPopularNearYou, acceptLanguage, ""))
+		} else {
+			explanations = append(explanations, explanation(models.RecommendationReasonPopular, acceptLanguage, ""))
+		}
+	}
+	if len(explanations) == 0 {
+		explanations = append(explanations, explanation(models.RecommendationReasonUpcoming, acceptLanguage, ""))
+	}
+	return explanations
+}
+
+func explanation(reason models.RecommendationReason, acceptLanguage string, detail string) models.RecommendationExplanation {
+	texts := explanationTexts[reason]
+	text := texts[0]
+	if acceptLanguage == "th-TH" {
+		text = texts[1]
+	}
+	return models.RecommendationExplanation{Reason: reason, Text: text + detail}
+}
+
+// interestLabel is the name of an interest in the language; categories without a Thai
+// name are shown as they are
+func interestLabel(interest string, acceptLanguage string) string {
+	if label, ok := interestLabelsTH[interest]; ok && acceptLanguage == "th-TH" {
+		return label
+	}
+	return interest
+}
@@ -0,0 +1,95 @@
+package recommend
+
+import (
+	"skillspark/internal/models"
+	"time"
+)
+
+const (
+	// sameOrganizationPenalty is taken off a candidate for each better recommendation from
+	// the same organization
+	sameOrganizationPenalty = 1.0
+	// sharedCategoryPenalty is taken off a candidate for each better recommendation with all
+	// of its categories, and proportionally less for some of them
+	sharedCategoryPenalty = 0.5
+	// sameDayPenalty is taken off a candidate for each better recommendation whose next
+	// occurrence is on the same day
+	sameDayPenalty = 0.25
+)
+
+// bangkok is the timezone days are compared in. Thailand has no daylight saving, so a fixed
+// offset is exact.
+var bangkok = time.FixedZone("ICT", 7*60*60)
+
+// Rank orders candidates by score, taking a diversity penalty off each one for every better
+// recommendation it shares an organization, categories or a day with, so the top isn't five
+// sessions of one organization's robotics class. Candidates should come best first, which
+// is how ties are broken. Explanations are in Thai for acceptLanguage "th-TH".
+func Rank(candidates []models.RecommendationCandidate, acceptLanguage string) []models.Recommendation {
+	penalties := make([]float64, len(candidates))
+	ranked := make([]bool, len(candidates))
+	recommendations := make([]models.Recommendation, 0, len(candidates))
+
+	for range candidates {
+		best := -1
+		for i := range candidates {
+			if ranked[i] {
+				continue
+			}
+			if best == -1 || baseScore(candidates[i])-penalties[i] > baseScore(candidates[best])-penalties[best] {
+				best = i
+			}
+		}
+
+		ranked[best] = true
+		chosen := candidates[best]
+		components := chosen.Components
+		components.Diversity = -penalties[best]
+		recommendations = append(recommendations, models.Recommendation{
+			Event:           chosen.Event,
+			Score:           baseScore(chosen) - penalties[best],
+			ScoreComponents: components,
+			Explanations:    explain(chosen, acceptLanguage),
+		})
+
+		for i := range candidates {
+			if !ranked[i] {
+				penalties[i] += similarity(candidates[i], chosen)
+			}
+		}
+	}
+	return recommendations
+}
+
+func baseScore(c models.RecommendationCandidate) float64 {
+	return c.Components.Interest + c.Components.Collaborative + c.Components.Popularity
+}
+
+// similarity is the penalty for ranking c below better, by what the two share
+func similarity(c, better models.RecommendationCandidate) float64 {
+	penalty := 0.0
+	if c.OrganizationID == better.OrganizationID {
+		penalty += sameOrganizationPenalty
+	}
+	if len(c.Category) > 0 {
+		shared := 0
+		for _, category := range c.Category {
+			for _, other := range better.Category {
+				if category == other {
+					shared++
+					break
+				}
+			}
+		}
+		penalty += sharedCategoryPenalty * float64(shared) / float64(len(c.Category))
+	}
+	if sameDay(c.NextStartTime, better.NextStartTime) {
+		penalty += sameDayPenalty
+	}
+	return penalty
+}
+
+func sameDay(a, b time.Time) bool {
+	a, b = a.In(bangkok), b.In(bangkok)
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
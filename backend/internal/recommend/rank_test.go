@@ -0,0 +1,112 @@
+package recommend
+
+import (
+	"skillspark/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	robotsInc  = uuid.MustParse("00000000-0000-0000-0000-0000000000a1")
+	artStudio  = uuid.MustParse("00000000-0000-0000-0000-0000000000a2")
+	codeSchool = uuid.MustParse("00000000-0000-0000-0000-0000000000a3")
+)
+
+// candidate is an event matching one interest per category, with its next occurrence at
+// 10am Bangkok time on the given day of March 2026
+func candidate(id uuid.UUID, organization uuid.UUID, day int, categories ...string) models.RecommendationCandidate {
+	return models.RecommendationCandidate{
+		Event:            models.Event{ID: id, OrganizationID: organization, Category: categories},
+		Components:       models.RecommendationScoreComponents{Interest: float64(len(categories))},
+		MatchedInterests: categories,
+		NextStartTime:    time.Date(2026, time.March, day, 3, 0, 0, 0, time.UTC),
+	}
+}
+
+func rankedIDs(recommendations []models.Recommendation) []uuid.UUID {
+	ids := make([]uuid.UUID, len(recommendations))
+	for i, r := range recommendations {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+func TestRank_SpreadsAcrossOrganizations(t *testing.T) {
+	ranked := Rank([]models.RecommendationCandidate{
+		candidate(robotics, robotsInc, 1, "robotics"),
+		candidate(coding, robotsInc, 2, "coding"),
+		candidate(swimming, robotsInc, 3, "swimming"),
+		candidate(painting, artStudio, 4, "art"),
+	}, "en-US")
+
+	assert.Equal(t, []uuid.UUID{robotics, painting, coding, swimming}, rankedIDs(ranked))
+	assert.Equal(t, -1.0, ranked[2].ScoreComponents.Diversity)
+	assert.Equal(t, 0.0, ranked[2].Score)
+	assert.Equal(t, -2.0, ranked[3].ScoreComponents.Diversity)
+}
+
+func TestRank_SpreadsAcrossCategoriesAndDays(t *testing.T) {
+	ranked := Rank([]models.RecommendationCandidate{
+		candidate(robotics, robotsInc, 1, "robotics"),
+		// same category as robotics, elsewhere and another day
+		candidate(coding, codeSchool, 2, "robotics"),
+		// another category on the same day as robotics
+		candidate(painting, artStudio, 1, "art"),
+		candidate(ballet, uuid.New(), 3, "dance"),
+	}, "en-US")
+
+	assert.Equal(t, []uuid.UUID{robotics, ballet, painting, coding}, rankedIDs(ranked))
+	assert.Equal(t, -0.25, ranked[2].ScoreComponents.Diversity)
+	assert.Equal(t, -0.5, ranked[3].ScoreComponents.Diversity)
+}
+
+func TestRank_KeepsCandidateOrderOnTies(t *testing.T) {
+	ranked := Rank([]models.RecommendationCandidate{
+		candidate(coding, codeSchool, 1, "coding"),
+		candidate(robotics, robotsInc, 2, "robotics"),
+	}, "en-US")
+
+	assert.Equal(t, []uuid.UUID{coding, robotics}, rankedIDs(ranked))
+	assert.Empty(t, Rank(nil, "en-US"))
+}
+
+func TestRank_Explanations(t *testing.T) {
+	near := 2.5
+	interesting := candidate(robotics, robotsInc, 1, "robotics")
+	interesting.Components.Collaborative = 1.2
+	interesting.RecentRegistrations = popularRegistrations
+	interesting.DistanceKm = &near
+
+	popular := candidate(painting, artStudio, 2)
+	popular.RecentRegistrations = popularRegistrations
+
+	justUpcoming := candidate(swimming, codeSchool, 3)
+
+	ranked := Rank([]models.RecommendationCandidate{interesting, popular, justUpcoming}, "en-US")
+	require.Len(t, ranked, 3)
+	assert.Equal(t, []models.RecommendationExplanation{
+		{Reason: models.RecommendationReasonMatchesInterest, Text: "Matches interest: robotics"},
+		{Reason: models.RecommendationReasonSimilarFamilies, Text: "Booked by families like yours"},
+		{Reason: models.RecommendationReasonPopularNearYou, Text: "Popular near you"},
+	}, ranked[0].Explanations)
+	assert.Equal(t, []models.RecommendationExplanation{
+		{Reason: models.RecommendationReasonPopular, Text: "Popular with families"},
+	}, ranked[1].Explanations)
+	assert.Equal(t, []models.RecommendationExplanation{
+		{Reason: models.RecommendationReasonUpcoming, Text: "Coming up soon"},
+	}, ranked[2].Explanations)
+
+	thai := Rank([]models.RecommendationCandidate{interesting}, "th-TH")
+	assert.Equal(t, "ตรงกับความสนใจ: หุ่นยนต์", thai[0].Explanations[0].Text)
+	assert.Equal(t, "ยอดนิยมใกล้คุณ", thai[0].Explanations[2].Text)
+}
+
+func TestSameDay_InBangkok(t *testing.T) {
+	// 11pm UTC is already the next morning in Bangkok
+	assert.True(t, sameDay(time.Date(2026, time.March, 1, 23, 0, 0, 0, time.UTC), time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)))
+	assert.False(t, sameDay(time.Date(2026, time.March, 1, 16, 0, 0, 0, time.UTC), time.Date(2026, time.March, 1, 18, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,212 @@
+// Package recommend learns which events go together from what families register for, save
+// and review, so children can be recommended what families like theirs booked: families
+// whose kids took robotics also booked coding. It also ranks the events a child could be
+// recommended so they are spread across organizations, categories and days, and explains
+// each recommendation.
+package recommend
+
+import (
+	"cmp"
+	"math"
+	"skillspark/internal/models"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+const (
+	registrationWeight = 3.0
+	savedWeight        = 1.0
+	// shrinkage damps the similarity of events only a few families share, which would
+	// otherwise look as similar as events many families share
+	shrinkage = 2.0
+)
+
+// Scored is an event and how strongly it is recommended
+type Scored struct {
+	EventID uuid.UUID
+	Score   float64
+}
+
+// Weight is how strongly an interaction says the family liked the event. A review adds to
+// the registration it belongs to: 5 stars adds 2 and 1 star takes 2 away.
+func Weight(interaction models.RecommendationInteraction) float64 {
+	switch interaction.Kind {
+	case models.RecommendationInteractionRegistration:
+		return registrationWeight
+	case models.RecommendationInteractionSaved:
+		return savedWeight
+	case models.RecommendationInteractionReview:
+		if interaction.Rating == nil {
+			return 0
+		}
+		return float64(*interaction.Rating - 3)
+	}
+	return 0
+}
+
+// profiles sums the weights of each owner's interactions per event. Repeats of the same
+// kind of interaction with the same event, such as two children booking it or a save
+// listed for each child, count once.
+func profiles(interactions []models.RecommendationInteraction, owner func(models.RecommendationInteraction) uuid.UUID) map[uuid.UUID]map[uuid.UUID]float64 {
+	type key struct {
+		owner uuid.UUID
+		event uuid.UUID
+		kind  models.RecommendationInteractionKind
+	}
+	strongest := map[key]float64{}
+	for _, interaction := range interactions {
+		k := key{owner: owner(interaction), event: interaction.EventID, kind: interaction.Kind}
+		weight := Weight(interaction)
+		if current, ok := strongest[k]; !ok || weight > current {
+			strongest[k] = weight
+		}
+	}
+
+	result := map[uuid.UUID]map[uuid.UUID]float64{}
+	for k, weight := range strongest {
+		if result[k.owner] == nil {
+			result[k.owner] = map[uuid.UUID]float64{}
+		}
+		result[k.owner][k.event] += weight
+	}
+	return result
+}
+
+func byGuardian(interaction models.RecommendationInteraction) uuid.UUID {
+	return interaction.GuardianID
+}
+
+func byChild(interaction models.RecommendationInteraction) uuid.UUID {
+	return interaction.ChildID
+}
+
+// Model holds how similar each pair of events is
+type Model struct {
+	similar map[uuid.UUID]map[uuid.UUID]float64
+}
+
+// Train computes the similarity of every pair of events some family interacted with both
+// of: the cosine of the events' weights across families, shrunk towards 0 when only a few
+// families share them
+func Train(interactions []models.RecommendationInteraction) *Model {
+	type cooccurrence struct {
+		dot      float64
+		families int
+	}
+
+	norms := map[uuid.UUID]float64{}
+	cooccurrences := map[uuid.UUID]map[uuid.UUID]*cooccurrence{}
+	for _, events := range profiles(interactions, byGuardian) {
+		for a, weightA := range events {
+			norms[a] += weightA * weightA
+			for b, weightB := range events {
+				if a == b {
+					continue
+				}
+				if cooccurrences[a] == nil {
+					cooccurrences[a] = map[uuid.UUID]*cooccurrence{}
+				}
+				c := cooccurrences[a][b]
+				if c == nil {
+					c = &cooccurrence{}
+					cooccurrences[a][b] = c
+				}
+				c.dot += weightA * weightB
+				c.families++
+			}
+		}
+	}
+
+	model := &Model{similar: map[uuid.UUID]map[uuid.UUID]float64{}}
+	for a, row := range cooccurrences {
+		for b, c := range row {
+			if c.dot == 0 || norms[a] == 0 || norms[b] == 0 {
+				continue
+			}
+			cosine := c.dot / (math.Sqrt(norms[a]) * math.Sqrt(norms[b]))
+			if model.similar[a] == nil {
+				model.similar[a] = map[uuid.UUID]float64{}
+			}
+			model.similar[a][b] = cosine * float64(c.families) / (float64(c.families) + shrinkage)
+		}
+	}
+	return model
+}
+
+// Similarity is how similar two events are, from -1 to 1, and 0 when no family
+// interacted with both
+func (m *Model) Similarity(a, b uuid.UUID) float64 {
+	return m.similar[a][b]
+}
+
+// Recommend scores the events similar to the ones in profile, weighted by how much the
+// profile liked each, and returns the best n, best first and scaled so the best scores 1.
+// Events already in the profile are left out, as are events mostly similar to ones the
+// profile disliked.
+func (m *Model) Recommend(profile map[uuid.UUID]float64, n int) []Scored {
+	scores := map[uuid.UUID]float64{}
+	for event, weight := range profile {
+		for similar, similarity := range m.similar[event] {
+			if _, seen := profile[similar]; seen {
+				continue
+			}
+			scores[similar] += weight * similarity
+		}
+	}
+	return top(scores, n)
+}
+
+// top returns the n highest positive scores, best first and scaled so the best scores 1.
+// Ties are broken by event id so runs over the same data give the same result.
+func top(scores map[uuid.UUID]float64, n int) []Scored {
+	var ranked []Scored
+	for event, score := range scores {
+		if score > 0 {
+			ranked = append(ranked, Scored{EventID: event, Score: score})
+		}
+	}
+	slices.SortFunc(ranked, func(a, b Scored) int {
+		if a.Score != b.Score {
+			return cmp.Compare(b.Score, a.Score)
+		}
+		return cmp.Compare(a.EventID.String(), b.EventID.String())
+	})
+	if len(ranked) > n {
+		ranked = ranked[:n]
+	}
+	if len(ranked) > 0 {
+		best := ranked[0].Score
+		for i := range ranked {
+			ranked[i].Score /= best
+		}
+	}
+	return ranked
+}
+
+// ForChildren trains a model on every interaction and returns up to n recommendations for
+// each child with any interactions
+func ForChildren(interactions []models.RecommendationInteraction, n int) []models.ChildRecommendation {
+	model := Train(interactions)
+	children := profiles(interactions, byChild)
+
+	childIDs := make([]uuid.UUID, 0, len(children))
+	for childID := range children {
+		childIDs = append(childIDs, childID)
+	}
+	slices.SortFunc(childIDs, func(a, b uuid.UUID) int {
+		return cmp.Compare(a.String(), b.String())
+	})
+
+	var recommendations []models.ChildRecommendation
+	for _, childID := range childIDs {
+		for _, scored := range model.Recommend(children[childID], n) {
+			recommendations = append(recommendations, models.ChildRecommendation{
+				ChildID: childID,
+				EventID: scored.EventID,
+				Score:   scored.Score,
+			})
+		}
+	}
+	return recommendations
+}
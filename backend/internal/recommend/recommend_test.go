@@ -0,0 +1,155 @@
+package recommend
+
+import (
+	"skillspark/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	robotics = uuid.MustParse("e0000000-0000-0000-0000-000000000001")
+	coding   = uuid.MustParse("e0000000-0000-0000-0000-000000000002")
+	painting = uuid.MustParse("e0000000-0000-0000-0000-000000000003")
+	ballet   = uuid.MustParse("e0000000-0000-0000-0000-000000000004")
+	swimming = uuid.MustParse("e0000000-0000-0000-0000-000000000005")
+)
+
+// family is a guardian with one child
+type family struct {
+	guardian uuid.UUID
+	child    uuid.UUID
+}
+
+func newFamily() family {
+	return family{guardian: uuid.New(), child: uuid.New()}
+}
+
+func (f family) registers(event uuid.UUID, day int) models.RecommendationInteraction {
+	return models.RecommendationInteraction{
+		GuardianID: f.guardian,
+		ChildID:    f.child,
+		EventID:    event,
+		Kind:       models.RecommendationInteractionRegistration,
+		CreatedAt:  time.Date(2026, time.January, day, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (f family) saves(event uuid.UUID) models.RecommendationInteraction {
+	return models.RecommendationInteraction{GuardianID: f.guardian, ChildID: f.child, EventID: event, Kind: models.RecommendationInteractionSaved}
+}
+
+func (f family) reviews(event uuid.UUID, rating int) models.RecommendationInteraction {
+	return models.RecommendationInteraction{GuardianID: f.guardian, ChildID: f.child, EventID: event, Kind: models.RecommendationInteractionReview, Rating: &rating}
+}
+
+func TestWeight(t *testing.T) {
+	f := newFamily()
+	assert.Equal(t, 3.0, Weight(f.registers(robotics, 1)))
+	assert.Equal(t, 1.0, Weight(f.saves(robotics)))
+	assert.Equal(t, 2.0, Weight(f.reviews(robotics, 5)))
+	assert.Equal(t, 0.0, Weight(f.reviews(robotics, 3)))
+	assert.Equal(t, -2.0, Weight(f.reviews(robotics, 1)))
+}
+
+func TestProfiles_CountsRepeatsOnce(t *testing.T) {
+	f := newFamily()
+	sibling := family{guardian: f.guardian, child: uuid.New()}
+
+	families := profiles([]models.RecommendationInteraction{
+		f.registers(robotics, 1),
+		f.registers(robotics, 8),
+		sibling.registers(robotics, 1),
+		f.saves(coding),
+		sibling.saves(coding),
+		f.reviews(robotics, 5),
+	}, byGuardian)
+
+	assert.Equal(t, map[uuid.UUID]float64{robotics: 5, coding: 1}, families[f.guardian])
+}
+
+func TestTrain(t *testing.T) {
+	var interactions []models.RecommendationInteraction
+	for range 4 {
+		f := newFamily()
+		interactions = append(interactions, f.registers(robotics, 1), f.registers(coding, 2))
+	}
+	for range 4 {
+		f := newFamily()
+		interactions = append(interactions, f.registers(painting, 1), f.registers(ballet, 2))
+	}
+	f := newFamily()
+	interactions = append(interactions, f.registers(robotics, 1), f.registers(ballet, 2))
+
+	model := Train(interactions)
+
+	assert.Greater(t, model.Similarity(robotics, coding), model.Similarity(robotics, ballet))
+	assert.Equal(t, model.Similarity(robotics, coding), model.Similarity(coding, robotics))
+	assert.Zero(t, model.Similarity(robotics, painting), "no family did both")
+}
+
+func TestTrain_FewSharedFamiliesAreShrunk(t *testing.T) {
+	one := newFamily()
+	model := Train([]models.RecommendationInteraction{one.registers(robotics, 1), one.registers(coding, 2)})
+
+	// the cosine is 1, but one family isn't much evidence
+	assert.InDelta(t, 1.0/3.0, model.Similarity(robotics, coding), 1e-9)
+}
+
+func TestRecommend(t *testing.T) {
+	var interactions []models.RecommendationInteraction
+	for i := range 3 {
+		f := newFamily()
+		interactions = append(interactions, f.registers(robotics, 1), f.registers(coding, 2))
+		if i == 0 {
+			interactions = append(interactions, f.saves(swimming))
+		}
+	}
+	for range 3 {
+		f := newFamily()
+		interactions = append(interactions, f.registers(painting, 1), f.registers(ballet, 2))
+	}
+	model := Train(interactions)
+
+	recommended := model.Recommend(map[uuid.UUID]float64{robotics: 3}, 5)
+	require.Len(t, recommended, 2)
+	assert.Equal(t, coding, recommended[0].EventID)
+	assert.Equal(t, 1.0, recommended[0].Score)
+	assert.Equal(t, swimming, recommended[1].EventID)
+	assert.Less(t, recommended[1].Score, 1.0)
+
+	// events already in the profile aren't recommended again
+	recommended = model.Recommend(map[uuid.UUID]float64{robotics: 3, coding: 3}, 5)
+	require.Len(t, recommended, 1)
+	assert.Equal(t, swimming, recommended[0].EventID)
+
+	// a disliked event pushes similar ones out
+	assert.Empty(t, model.Recommend(map[uuid.UUID]float64{painting: -2}, 5))
+
+	assert.Len(t, model.Recommend(map[uuid.UUID]float64{robotics: 3}, 1), 1)
+}
+
+func TestForChildren(t *testing.T) {
+	var interactions []models.RecommendationInteraction
+	for range 3 {
+		f := newFamily()
+		interactions = append(interactions, f.registers(robotics, 1), f.registers(coding, 2))
+	}
+	newcomer := newFamily()
+	interactions = append(interactions, newcomer.saves(robotics))
+
+	recommendations := ForChildren(interactions, 10)
+
+	var forNewcomer []models.ChildRecommendation
+	for _, recommendation := range recommendations {
+		if recommendation.ChildID == newcomer.child {
+			forNewcomer = append(forNewcomer, recommendation)
+		}
+	}
+	require.Len(t, forNewcomer, 1)
+	assert.Equal(t, coding, forNewcomer[0].EventID)
+	assert.Equal(t, 1.0, forNewcomer[0].Score)
+}
@@ -0,0 +1,36 @@
+package s3_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// GeneratePresignedUploadURL presigns a PUT of exactly contentLength bytes with the given
+// content type. Both are signed headers, so S3 rejects an upload that sends anything else.
+func (c *Client) GeneratePresignedUploadURL(ctx context.Context, key string, contentType string,
+	contentLength int64, expiry time.Duration) (string, error) {
+	if key == "" {
+		return "", errors.New("key cannot be empty")
+	}
+
+	req := &s3.PutObjectInput{
+		Bucket:        aws.String(c.Bucket),
+		Key:           aws.String(key),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(contentLength),
+	}
+
+	presigned, err := c.presigner().PresignPutObject(ctx, req, func(opts *s3.PresignOptions) {
+		opts.Expires = expiry
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to presign upload URL for key %q: %w", key, err)
+	}
+
+	return presigned.URL, nil
+}
@@ -10,25 +10,66 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
+// GeneratePresignedURL returns a URL to read key that is valid for at least a few minutes
+// and at most expiry. Public assets get their stable public URL. Other URLs are cached
+// until shortly before they expire, so repeated requests for the same object get the
+// same URL.
 func (c *Client) GeneratePresignedURL(ctx context.Context, key string,
 	expiry time.Duration) (string, error) {
-	psClient := s3.NewPresignClient(c.S3)
-
 	if key == "" {
 		return "", errors.New("key cannot be empty")
 	}
 
+	if url, ok := c.publicURL(key); ok {
+		return url, nil
+	}
+
+	if c.urls != nil {
+		if url, ok := c.urls.get(key, expiry); ok {
+			return url, nil
+		}
+	}
+
 	req := &s3.GetObjectInput{
 		Bucket: aws.String(c.Bucket),
 		Key:    aws.String(key),
 	}
 
-	presigned, err := psClient.PresignGetObject(ctx, req, func(opts *s3.PresignOptions) {
+	signedAt := time.Now()
+	presigned, err := c.presigner().PresignGetObject(ctx, req, func(opts *s3.PresignOptions) {
 		opts.Expires = expiry
 	})
 	if err != nil {
 		return "", fmt.Errorf("failed to presign URL for key %q: %w", key, err)
 	}
 
+	if c.urls != nil {
+		c.urls.put(key, expiry, presigned.URL, signedAt)
+	}
+
 	return presigned.URL, nil
 }
+
+// GeneratePresignedURLs returns a URL for every distinct key, for pages that show many
+// images. Keys repeated on the page are only looked up once.
+func (c *Client) GeneratePresignedURLs(ctx context.Context, keys []string, expiry time.Duration) (map[string]string, error) {
+	urls := make(map[string]string, len(keys))
+	for _, key := range keys {
+		if _, ok := urls[key]; ok {
+			continue
+		}
+		url, err := c.GeneratePresignedURL(ctx, key, expiry)
+		if err != nil {
+			return nil, err
+		}
+		urls[key] = url
+	}
+	return urls, nil
+}
+
+func (c *Client) presigner() *s3.PresignClient {
+	if c.presignClient == nil {
+		return s3.NewPresignClient(c.S3)
+	}
+	return c.presignClient
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"skillspark/internal/s3_client"
 	"time"
 
 	"github.com/stretchr/testify/mock"
@@ -11,8 +12,8 @@ type S3ClientMock struct {
 	mock.Mock
 }
 
-func (m *S3ClientMock) UploadImage(ctx context.Context, key *string, image_data []byte) (*string, error) {
-	args := m.Called(ctx, key, image_data)
+func (m *S3ClientMock) UploadImage(ctx context.Context, key *string, image_data []byte, contentType string) (*string, error) {
+	args := m.Called(ctx, key, image_data, contentType)
 	if args.Get(0) == nil {
 		if args.Get(1) == nil {
 			return nil, nil
@@ -26,3 +27,37 @@ func (m *S3ClientMock) GeneratePresignedURL(ctx context.Context, key string, exp
 	args := m.Called(ctx, key, expiry)
 	return args.String(0), args.Error(1)
 }
+
+func (m *S3ClientMock) GeneratePresignedURLs(ctx context.Context, keys []string, expiry time.Duration) (map[string]string, error) {
+	args := m.Called(ctx, keys, expiry)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *S3ClientMock) GeneratePresignedUploadURL(ctx context.Context, key string, contentType string, contentLength int64, expiry time.Duration) (string, error) {
+	args := m.Called(ctx, key, contentType, contentLength, expiry)
+	return args.String(0), args.Error(1)
+}
+
+func (m *S3ClientMock) HeadObject(ctx context.Context, key string) (*s3_client.ObjectInfo, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*s3_client.ObjectInfo), args.Error(1)
+}
+
+func (m *S3ClientMock) DownloadObject(ctx context.Context, key string, maxBytes int64) ([]byte, error) {
+	args := m.Called(ctx, key, maxBytes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *S3ClientMock) DeleteObject(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
@@ -0,0 +1,79 @@
+package s3_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// ErrObjectNotFound is returned when the key has no object, such as an upload session the
+// client never uploaded to
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectInfo is what S3 reports about a stored object
+type ObjectInfo struct {
+	ContentType   string
+	ContentLength int64
+}
+
+func (c *Client) HeadObject(ctx context.Context, key string) (*ObjectInfo, error) {
+	out, err := c.S3.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(c.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to head object %q: %w", key, err)
+	}
+
+	return &ObjectInfo{
+		ContentType:   aws.ToString(out.ContentType),
+		ContentLength: aws.ToInt64(out.ContentLength),
+	}, nil
+}
+
+// DownloadObject reads an object into memory, failing if it is larger than maxBytes
+func (c *Client) DownloadObject(ctx context.Context, key string, maxBytes int64) ([]byte, error) {
+	out, err := c.S3.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to download object %q: %w", key, err)
+	}
+	defer func() { _ = out.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(out.Body, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object %q: %w", key, err)
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("object %q is larger than %d bytes", key, maxBytes)
+	}
+
+	return data, nil
+}
+
+// DeleteObject removes an object. Deleting a key that has no object succeeds.
+func (c *Client) DeleteObject(ctx context.Context, key string) error {
+	_, err := c.S3.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(c.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete object %q: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package s3_client
+
+import (
+	"net/url"
+	"strings"
+)
+
+// publicURL returns the stable URL of a public asset, or false if key isn't public or no
+// public base URL is configured
+func (c *Client) publicURL(key string) (string, bool) {
+	if c.PublicBaseURL == "" {
+		return "", false
+	}
+	for _, prefix := range c.PublicPrefixes {
+		if prefix != "" && strings.HasPrefix(key, prefix) {
+			return strings.TrimSuffix(c.PublicBaseURL, "/") + "/" + escapeKey(key), true
+		}
+	}
+	return "", false
+}
+
+// escapeKey escapes each path segment of key, keeping the slashes
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
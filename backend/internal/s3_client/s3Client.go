@@ -14,6 +14,12 @@ import (
 type Client struct {
 	S3     *s3.Client
 	Bucket string
+
+	PublicBaseURL  string
+	PublicPrefixes []string
+
+	presignClient *s3.PresignClient
+	urls          *urlCache
 }
 
 func NewClient(s3Config s3_config.S3) (*Client, error) {
@@ -51,7 +57,11 @@ func NewClient(s3Config s3_config.S3) (*Client, error) {
 	})
 
 	return &Client{
-		S3:     s3Client,
-		Bucket: bucket,
+		S3:             s3Client,
+		Bucket:         bucket,
+		PublicBaseURL:  s3Config.PublicBaseURL,
+		PublicPrefixes: s3Config.PublicPrefixes,
+		presignClient:  s3.NewPresignClient(s3Client),
+		urls:           newURLCache(),
 	}, nil
 }
@@ -7,5 +7,10 @@ import (
 
 type S3Interface interface {
 	GeneratePresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
-	UploadImage(ctx context.Context, key *string, image_data []byte) (*string, error)
+	GeneratePresignedURLs(ctx context.Context, keys []string, expiry time.Duration) (map[string]string, error)
+	UploadImage(ctx context.Context, key *string, image_data []byte, contentType string) (*string, error)
+	GeneratePresignedUploadURL(ctx context.Context, key string, contentType string, contentLength int64, expiry time.Duration) (string, error)
+	HeadObject(ctx context.Context, key string) (*ObjectInfo, error)
+	DownloadObject(ctx context.Context, key string, maxBytes int64) ([]byte, error)
+	DeleteObject(ctx context.Context, key string) error
 }
@@ -11,10 +11,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
-// UploadImage uploads file content to S3 with the given key.
-// The caller is responsible for closing the reader after this function returns.
-
-func (c *Client) UploadImage(ctx context.Context, key *string, image_data []byte) (*string, error) {
+// UploadImage uploads file content to S3 with the given key and content type, and returns
+// a presigned URL for it. Uploads should go through imageproc first, which validates them
+// and knows the content type.
+func (c *Client) UploadImage(ctx context.Context, key *string, image_data []byte, contentType string) (*string, error) {
 	if key == nil {
 		return nil, errors.New("key cannot be empty")
 	}
@@ -25,7 +25,7 @@ func (c *Client) UploadImage(ctx context.Context, key *string, image_data []byte
 		Bucket:      aws.String(c.Bucket),
 		Key:         aws.String(*key),
 		Body:        data,
-		ContentType: aws.String("image/png"),
+		ContentType: aws.String(contentType),
 	})
 
 	if err != nil {
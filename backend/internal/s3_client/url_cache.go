@@ -0,0 +1,74 @@
+package s3_client
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// presignRefreshMargin is how long before a cached URL expires it stops being handed
+	// out, so a client always gets a URL that is valid for a while
+	presignRefreshMargin = 10 * time.Minute
+	// maxCachedURLs bounds the cache; a full cache drops its expired entries, and if that
+	// isn't enough, everything
+	maxCachedURLs = 50_000
+)
+
+type cacheKey struct {
+	key    string
+	expiry time.Duration
+}
+
+type cachedURL struct {
+	url       string
+	expiresAt time.Time
+}
+
+// urlCache keeps presigned URLs until shortly before they expire. Handing out the same URL
+// for the same object lets clients and CDNs cache the image, and skips signing.
+type urlCache struct {
+	mu      sync.Mutex
+	entries map[cacheKey]cachedURL
+	now     func() time.Time
+}
+
+func newURLCache() *urlCache {
+	return &urlCache{entries: map[cacheKey]cachedURL{}, now: time.Now}
+}
+
+// get returns a cached URL for key that is still valid for longer than the refresh margin.
+// The margin never exceeds half of expiry, so short-lived URLs are cached too.
+func (c *urlCache) get(key string, expiry time.Duration) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[cacheKey{key, expiry}]
+	if !ok || !c.now().Add(refreshMargin(expiry)).Before(entry.expiresAt) {
+		return "", false
+	}
+	return entry.url, true
+}
+
+// put caches a URL that was signed at signedAt
+func (c *urlCache) put(key string, expiry time.Duration, url string, signedAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= maxCachedURLs {
+		now := c.now()
+		for k, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxCachedURLs {
+			c.entries = map[cacheKey]cachedURL{}
+		}
+	}
+
+	c.entries[cacheKey{key, expiry}] = cachedURL{url: url, expiresAt: signedAt.Add(expiry)}
+}
+
+func refreshMargin(expiry time.Duration) time.Duration {
+	return min(presignRefreshMargin, expiry/2)
+}
@@ -0,0 +1,102 @@
+package s3_client
+
+import (
+	"context"
+	"fmt"
+	"skillspark/internal/config"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLCache(t *testing.T) {
+	now := time.Date(2026, time.May, 1, 12, 0, 0, 0, time.UTC)
+	cache := newURLCache()
+	cache.now = func() time.Time { return now }
+
+	cache.put("events/a.jpg", time.Hour, "https://signed/a?1", now)
+
+	url, ok := cache.get("events/a.jpg", time.Hour)
+	require.True(t, ok)
+	assert.Equal(t, "https://signed/a?1", url)
+
+	// a different expiry is a different URL
+	_, ok = cache.get("events/a.jpg", 15*time.Minute)
+	assert.False(t, ok)
+
+	// still handed out until shortly before it expires
+	now = now.Add(49 * time.Minute)
+	_, ok = cache.get("events/a.jpg", time.Hour)
+	assert.True(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok = cache.get("events/a.jpg", time.Hour)
+	assert.False(t, ok)
+}
+
+func TestURLCache_ShortExpiry(t *testing.T) {
+	now := time.Date(2026, time.May, 1, 12, 0, 0, 0, time.UTC)
+	cache := newURLCache()
+	cache.now = func() time.Time { return now }
+
+	cache.put("events/a.jpg", 4*time.Minute, "https://signed/a", now)
+
+	now = now.Add(time.Minute)
+	_, ok := cache.get("events/a.jpg", 4*time.Minute)
+	assert.True(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok = cache.get("events/a.jpg", 4*time.Minute)
+	assert.False(t, ok)
+}
+
+func TestURLCache_EvictsWhenFull(t *testing.T) {
+	now := time.Date(2026, time.May, 1, 12, 0, 0, 0, time.UTC)
+	cache := newURLCache()
+	cache.now = func() time.Time { return now }
+
+	for i := range maxCachedURLs {
+		cache.put(fmt.Sprintf("k%d", i), time.Hour, "u", now.Add(-2*time.Hour))
+	}
+	cache.put("fresh", time.Hour, "https://signed/fresh", now)
+
+	assert.Len(t, cache.entries, 1)
+	url, ok := cache.get("fresh", time.Hour)
+	require.True(t, ok)
+	assert.Equal(t, "https://signed/fresh", url)
+}
+
+func TestGeneratePresignedURL_Public(t *testing.T) {
+	client := &Client{
+		PublicBaseURL:  "https://cdn.skillspark.example/",
+		PublicPrefixes: []string{"events/header-image/", "orgs/header-image/"},
+	}
+
+	url, err := client.GeneratePresignedURL(context.Background(), "events/header-image/abc/original photo.jpg", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.skillspark.example/events/header-image/abc/original%20photo.jpg", url)
+
+	urls, err := client.GeneratePresignedURLs(context.Background(), []string{"orgs/header-image/x/small.webp", "orgs/header-image/x/small.webp"}, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"orgs/header-image/x/small.webp": "https://cdn.skillspark.example/orgs/header-image/x/small.webp"}, urls)
+
+	_, ok := client.publicURL("guardians/profile-picture/abc/original.jpg")
+	assert.False(t, ok)
+}
+
+func TestGeneratePresignedURL_Cached(t *testing.T) {
+	client, err := NewClient(config.S3{Bucket: "skillspark", Region: "us-east-1", AccessKey: "key", SecretKey: "secret"})
+	require.NoError(t, err)
+
+	first, err := client.GeneratePresignedURL(context.Background(), "events/header-image/abc/original.jpg", time.Hour)
+	require.NoError(t, err)
+	assert.Contains(t, first, "X-Amz-Signature")
+
+	// signing again would change X-Amz-Date, and with it the URL
+	time.Sleep(1100 * time.Millisecond)
+	second, err := client.GeneratePresignedURL(context.Background(), "events/header-image/abc/original.jpg", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+}
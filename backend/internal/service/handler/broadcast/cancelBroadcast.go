@@ -0,0 +1,15 @@
+package broadcast
+
+import (
+	"context"
+	"skillspark/internal/models"
+)
+
+func (h *Handler) CancelBroadcast(ctx context.Context, input *models.CancelBroadcastInput) (*models.CancelBroadcastOutput, error) {
+	broadcast, err := h.BroadcastRepository.CancelBroadcast(ctx, input.OrganizationID, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CancelBroadcastOutput{Body: *broadcast}, nil
+}
@@ -0,0 +1,112 @@
+package broadcast
+
+import (
+	"context"
+	"fmt"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// broadcastDailyLimit is how many broadcasts an organization can send in any 24 hours
+	broadcastDailyLimit = 5
+	// broadcastMaxLead is how far ahead a broadcast can be scheduled
+	broadcastMaxLead = 30 * 24 * time.Hour
+	// broadcastClockSkew allows scheduled_for to be slightly in the past, e.g. "now" from a slow client
+	broadcastClockSkew = time.Minute
+)
+
+func (h *Handler) CreateBroadcast(ctx context.Context, input *models.CreateBroadcastInput) (*models.CreateBroadcastOutput, error) {
+	body := input.Body
+
+	if (body.SubjectTH == nil) != (body.BodyTH == nil) {
+		return nil, errs.BadRequest("subject_th and body_th must be given together")
+	}
+
+	targetID := input.OrganizationID
+	if body.TargetType != models.BroadcastTargetOrganization {
+		if body.TargetID == nil {
+			return nil, errs.BadRequest("target_id is required for " + string(body.TargetType) + " broadcasts")
+		}
+		targetID = *body.TargetID
+	}
+
+	now := time.Now()
+	scheduledFor := now
+	if body.ScheduledFor != nil {
+		if body.ScheduledFor.Before(now.Add(-broadcastClockSkew)) {
+			return nil, errs.BadRequest("scheduled_for must not be in the past")
+		}
+		if body.ScheduledFor.After(now.Add(broadcastMaxLead)) {
+			return nil, errs.BadRequest("scheduled_for must be within 30 days")
+		}
+		scheduledFor = *body.ScheduledFor
+	}
+
+	manager, err := h.ManagerRepository.GetManagerByID(ctx, body.ManagerID)
+	if err != nil {
+		return nil, err
+	}
+	if manager.OrganizationID != input.OrganizationID {
+		return nil, errs.BadRequest("manager does not belong to this organization")
+	}
+
+	if err := h.checkTarget(ctx, input.OrganizationID, body.TargetType, targetID); err != nil {
+		return nil, err
+	}
+
+	recent, err := h.BroadcastRepository.CountRecentBroadcasts(ctx, input.OrganizationID, now.Add(-24*time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	if recent >= broadcastDailyLimit {
+		return nil, errs.TooManyRequests(fmt.Sprintf("organizations can send at most %d broadcasts a day", broadcastDailyLimit))
+	}
+
+	broadcast, err := h.BroadcastRepository.CreateBroadcast(ctx, &models.CreateBroadcastData{
+		OrganizationID: input.OrganizationID,
+		ManagerID:      body.ManagerID,
+		TargetType:     body.TargetType,
+		TargetID:       targetID,
+		SubjectEN:      body.SubjectEN,
+		BodyEN:         body.BodyEN,
+		SubjectTH:      body.SubjectTH,
+		BodyTH:         body.BodyTH,
+		ScheduledFor:   scheduledFor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CreateBroadcastOutput{Body: *broadcast}, nil
+}
+
+// checkTarget fails with a 404 when the target event or occurrence doesn't exist or belongs
+// to another organization, so a manager can't message another organization's families
+func (h *Handler) checkTarget(ctx context.Context, organizationID uuid.UUID, targetType models.BroadcastTargetType, targetID uuid.UUID) error {
+	var owner uuid.UUID
+	switch targetType {
+	case models.BroadcastTargetEvent:
+		event, err := h.EventRepository.GetEventByID(ctx, targetID, "en-US")
+		if err != nil {
+			return err
+		}
+		owner = event.OrganizationID
+	case models.BroadcastTargetEventOccurrence:
+		occurrence, err := h.EventOccurrenceRepository.GetEventOccurrenceByID(ctx, targetID, "en-US")
+		if err != nil {
+			return err
+		}
+		owner = occurrence.Event.OrganizationID
+	default:
+		return nil
+	}
+
+	if owner != organizationID {
+		return errs.NotFound("Broadcast target", "id", targetID)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package broadcast
+
+import (
+	"context"
+	"skillspark/internal/models"
+)
+
+func (h *Handler) GetBroadcast(ctx context.Context, input *models.GetBroadcastInput) (*models.GetBroadcastOutput, error) {
+	broadcast, err := h.BroadcastRepository.GetBroadcastByID(ctx, input.OrganizationID, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	channels, err := h.BroadcastRepository.GetBroadcastStats(ctx, broadcast.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := models.BroadcastStats{Channels: channels}
+	if broadcast.RecipientCount != nil {
+		stats.Recipients = *broadcast.RecipientCount
+	}
+
+	return &models.GetBroadcastOutput{Body: models.BroadcastWithStats{Broadcast: *broadcast, Stats: stats}}, nil
+}
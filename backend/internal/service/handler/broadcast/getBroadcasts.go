@@ -0,0 +1,18 @@
+package broadcast
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/utils"
+)
+
+func (h *Handler) GetBroadcasts(ctx context.Context, input *models.GetBroadcastsInput) (*models.GetBroadcastsOutput, error) {
+	pagination := utils.Pagination{Page: input.Page, Limit: input.PageSize}
+
+	broadcasts, err := h.BroadcastRepository.GetBroadcastsByOrganizationID(ctx, input.OrganizationID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GetBroadcastsOutput{Body: broadcasts}, nil
+}
@@ -0,0 +1,19 @@
+package broadcast
+
+import "skillspark/internal/storage"
+
+type Handler struct {
+	BroadcastRepository       storage.BroadcastRepository
+	ManagerRepository         storage.ManagerRepository
+	EventRepository           storage.EventRepository
+	EventOccurrenceRepository storage.EventOccurrenceRepository
+}
+
+func NewHandler(broadcastRepo storage.BroadcastRepository, managerRepo storage.ManagerRepository, eventRepo storage.EventRepository, eventOccurrenceRepo storage.EventOccurrenceRepository) *Handler {
+	return &Handler{
+		BroadcastRepository:       broadcastRepo,
+		ManagerRepository:         managerRepo,
+		EventRepository:           eventRepo,
+		EventOccurrenceRepository: eventOccurrenceRepo,
+	}
+}
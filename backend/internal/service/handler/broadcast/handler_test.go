@@ -0,0 +1,395 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"skillspark/internal/utils"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_CreateBroadcast(t *testing.T) {
+	orgID := uuid.New()
+	managerID := uuid.New()
+	occurrenceID := uuid.New()
+	subjectTH := "ย้ายห้องเรียน"
+	bodyTH := "สัปดาห์นี้เรียนที่ห้อง 204"
+	tomorrow := time.Now().Add(24 * time.Hour)
+	lastWeek := time.Now().Add(-7 * 24 * time.Hour)
+	nextYear := time.Now().Add(365 * 24 * time.Hour)
+
+	newInput := func(modify func(*models.CreateBroadcastInput)) *models.CreateBroadcastInput {
+		input := &models.CreateBroadcastInput{OrganizationID: orgID}
+		input.Body.ManagerID = managerID
+		input.Body.TargetType = models.BroadcastTargetEventOccurrence
+		input.Body.TargetID = &occurrenceID
+		input.Body.SubjectEN = "Room change"
+		input.Body.BodyEN = "This week's class is in room 204."
+		if modify != nil {
+			modify(input)
+		}
+		return input
+	}
+	managerInOrg := func(m *repomocks.MockManagerRepository) {
+		m.On("GetManagerByID", mock.Anything, managerID).Return(&models.Manager{ID: managerID, OrganizationID: orgID}, nil)
+	}
+	occurrenceOf := func(organizationID uuid.UUID) func(*repomocks.MockEventRepository, *repomocks.MockEventOccurrenceRepository) {
+		return func(_ *repomocks.MockEventRepository, m *repomocks.MockEventOccurrenceRepository) {
+			m.On("GetEventOccurrenceByID", mock.Anything, occurrenceID, "en-US").
+				Return(&models.EventOccurrence{ID: occurrenceID, Event: models.Event{OrganizationID: organizationID}}, nil)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		input         *models.CreateBroadcastInput
+		setupManager  func(*repomocks.MockManagerRepository)
+		setupTarget   func(*repomocks.MockEventRepository, *repomocks.MockEventOccurrenceRepository)
+		setupRepo     func(*repomocks.MockBroadcastRepository)
+		wantStatus    int
+		wantErr       bool
+		wantTargetID  uuid.UUID
+		wantScheduled *time.Time
+	}{
+		{
+			name:         "sends now to an occurrence",
+			input:        newInput(nil),
+			setupManager: managerInOrg,
+			setupTarget:  occurrenceOf(orgID),
+			setupRepo: func(m *repomocks.MockBroadcastRepository) {
+				m.On("CountRecentBroadcasts", mock.Anything, orgID, mock.AnythingOfType("time.Time")).Return(0, nil)
+				m.On("CreateBroadcast", mock.Anything, mock.AnythingOfType("*models.CreateBroadcastData")).
+					Return(&models.Broadcast{ID: uuid.New(), Status: models.BroadcastStatusScheduled}, nil)
+			},
+			wantTargetID: occurrenceID,
+		},
+		{
+			name: "organization target uses the organization ID",
+			input: newInput(func(input *models.CreateBroadcastInput) {
+				input.Body.TargetType = models.BroadcastTargetOrganization
+				input.Body.TargetID = nil
+				input.Body.SubjectTH = &subjectTH
+				input.Body.BodyTH = &bodyTH
+				input.Body.ScheduledFor = &tomorrow
+			}),
+			setupManager: managerInOrg,
+			setupRepo: func(m *repomocks.MockBroadcastRepository) {
+				m.On("CountRecentBroadcasts", mock.Anything, orgID, mock.AnythingOfType("time.Time")).Return(4, nil)
+				m.On("CreateBroadcast", mock.Anything, mock.AnythingOfType("*models.CreateBroadcastData")).
+					Return(&models.Broadcast{ID: uuid.New()}, nil)
+			},
+			wantTargetID:  orgID,
+			wantScheduled: &tomorrow,
+		},
+		{
+			name: "event target without an ID",
+			input: newInput(func(input *models.CreateBroadcastInput) {
+				input.Body.TargetType = models.BroadcastTargetEvent
+				input.Body.TargetID = nil
+			}),
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name: "Thai subject without a Thai body",
+			input: newInput(func(input *models.CreateBroadcastInput) {
+				input.Body.SubjectTH = &subjectTH
+			}),
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name: "scheduled in the past",
+			input: newInput(func(input *models.CreateBroadcastInput) {
+				input.Body.ScheduledFor = &lastWeek
+			}),
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name: "scheduled too far ahead",
+			input: newInput(func(input *models.CreateBroadcastInput) {
+				input.Body.ScheduledFor = &nextYear
+			}),
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name:  "manager from another organization",
+			input: newInput(nil),
+			setupManager: func(m *repomocks.MockManagerRepository) {
+				m.On("GetManagerByID", mock.Anything, managerID).Return(&models.Manager{ID: managerID, OrganizationID: uuid.New()}, nil)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name:  "manager not found",
+			input: newInput(nil),
+			setupManager: func(m *repomocks.MockManagerRepository) {
+				notFound := errs.NotFound("Manager", "id", managerID)
+				m.On("GetManagerByID", mock.Anything, managerID).Return(nil, &notFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantErr:    true,
+		},
+		{
+			name:         "daily limit reached",
+			input:        newInput(nil),
+			setupManager: managerInOrg,
+			setupTarget:  occurrenceOf(orgID),
+			setupRepo: func(m *repomocks.MockBroadcastRepository) {
+				m.On("CountRecentBroadcasts", mock.Anything, orgID, mock.AnythingOfType("time.Time")).Return(broadcastDailyLimit, nil)
+			},
+			wantStatus: http.StatusTooManyRequests,
+			wantErr:    true,
+		},
+		{
+			name:         "occurrence of another organization",
+			input:        newInput(nil),
+			setupManager: managerInOrg,
+			setupTarget:  occurrenceOf(uuid.New()),
+			wantStatus:   http.StatusNotFound,
+			wantErr:      true,
+		},
+		{
+			name: "event of another organization",
+			input: newInput(func(input *models.CreateBroadcastInput) {
+				input.Body.TargetType = models.BroadcastTargetEvent
+			}),
+			setupManager: managerInOrg,
+			setupTarget: func(m *repomocks.MockEventRepository, _ *repomocks.MockEventOccurrenceRepository) {
+				m.On("GetEventByID", mock.Anything, occurrenceID, "en-US").Return(&models.Event{ID: occurrenceID, OrganizationID: uuid.New()}, nil)
+			},
+			wantStatus: http.StatusNotFound,
+			wantErr:    true,
+		},
+		{
+			name:         "target not found",
+			input:        newInput(nil),
+			setupManager: managerInOrg,
+			setupTarget: func(_ *repomocks.MockEventRepository, m *repomocks.MockEventOccurrenceRepository) {
+				notFound := errs.NotFound("EventOccurrence", "id", occurrenceID)
+				m.On("GetEventOccurrenceByID", mock.Anything, occurrenceID, "en-US").Return(nil, &notFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broadcastRepo := new(repomocks.MockBroadcastRepository)
+			managerRepo := new(repomocks.MockManagerRepository)
+			eventRepo := new(repomocks.MockEventRepository)
+			occurrenceRepo := new(repomocks.MockEventOccurrenceRepository)
+			if tt.setupManager != nil {
+				tt.setupManager(managerRepo)
+			}
+			if tt.setupTarget != nil {
+				tt.setupTarget(eventRepo, occurrenceRepo)
+			}
+			if tt.setupRepo != nil {
+				tt.setupRepo(broadcastRepo)
+			}
+
+			h := NewHandler(broadcastRepo, managerRepo, eventRepo, occurrenceRepo)
+			out, err := h.CreateBroadcast(context.Background(), tt.input)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, out)
+				var statusErr huma.StatusError
+				require.True(t, errors.As(err, &statusErr))
+				assert.Equal(t, tt.wantStatus, statusErr.GetStatus())
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, out)
+				data := broadcastRepo.Calls[len(broadcastRepo.Calls)-1].Arguments.Get(1).(*models.CreateBroadcastData)
+				assert.Equal(t, tt.wantTargetID, data.TargetID)
+				assert.Equal(t, managerID, data.ManagerID)
+				if tt.wantScheduled != nil {
+					assert.True(t, tt.wantScheduled.Equal(data.ScheduledFor))
+				} else {
+					assert.WithinDuration(t, time.Now(), data.ScheduledFor, time.Minute)
+				}
+			}
+			managerRepo.AssertExpectations(t)
+			eventRepo.AssertExpectations(t)
+			occurrenceRepo.AssertExpectations(t)
+			broadcastRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetBroadcasts(t *testing.T) {
+	orgID := uuid.New()
+
+	tests := []struct {
+		name      string
+		mockSetup func(*repomocks.MockBroadcastRepository)
+		wantLen   int
+		wantErr   bool
+	}{
+		{
+			name: "returns broadcasts",
+			mockSetup: func(m *repomocks.MockBroadcastRepository) {
+				m.On("GetBroadcastsByOrganizationID", mock.Anything, orgID, utils.Pagination{Page: 2, Limit: 10}).
+					Return([]models.Broadcast{{ID: uuid.New()}, {ID: uuid.New()}}, nil)
+			},
+			wantLen: 2,
+		},
+		{
+			name: "repository error",
+			mockSetup: func(m *repomocks.MockBroadcastRepository) {
+				m.On("GetBroadcastsByOrganizationID", mock.Anything, orgID, mock.Anything).Return(nil, errors.New("db down"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(repomocks.MockBroadcastRepository)
+			tt.mockSetup(repo)
+
+			h := NewHandler(repo, nil, nil, nil)
+			out, err := h.GetBroadcasts(context.Background(), &models.GetBroadcastsInput{OrganizationID: orgID, Page: 2, PageSize: 10})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, out)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, out.Body, tt.wantLen)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetBroadcast(t *testing.T) {
+	orgID := uuid.New()
+	broadcastID := uuid.New()
+	recipients := 12
+
+	tests := []struct {
+		name           string
+		mockSetup      func(*repomocks.MockBroadcastRepository)
+		wantRecipients int
+		wantChannels   int
+		wantErr        bool
+	}{
+		{
+			name: "sent broadcast with stats",
+			mockSetup: func(m *repomocks.MockBroadcastRepository) {
+				m.On("GetBroadcastByID", mock.Anything, orgID, broadcastID).
+					Return(&models.Broadcast{ID: broadcastID, Status: models.BroadcastStatusSent, RecipientCount: &recipients}, nil)
+				m.On("GetBroadcastStats", mock.Anything, broadcastID).Return([]models.BroadcastChannelStats{
+					{Channel: models.NotificationTypeEmail, Delivered: 10, Bounced: 2},
+					{Channel: models.NotificationTypePush, Pending: 3},
+				}, nil)
+			},
+			wantRecipients: 12,
+			wantChannels:   2,
+		},
+		{
+			name: "scheduled broadcast has no recipients yet",
+			mockSetup: func(m *repomocks.MockBroadcastRepository) {
+				m.On("GetBroadcastByID", mock.Anything, orgID, broadcastID).
+					Return(&models.Broadcast{ID: broadcastID, Status: models.BroadcastStatusScheduled}, nil)
+				m.On("GetBroadcastStats", mock.Anything, broadcastID).Return([]models.BroadcastChannelStats{}, nil)
+			},
+		},
+		{
+			name: "not found",
+			mockSetup: func(m *repomocks.MockBroadcastRepository) {
+				notFound := errs.NotFound("Broadcast", "id", broadcastID)
+				m.On("GetBroadcastByID", mock.Anything, orgID, broadcastID).Return(nil, &notFound)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(repomocks.MockBroadcastRepository)
+			tt.mockSetup(repo)
+
+			h := NewHandler(repo, nil, nil, nil)
+			out, err := h.GetBroadcast(context.Background(), &models.GetBroadcastInput{OrganizationID: orgID, ID: broadcastID})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, out)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, broadcastID, out.Body.ID)
+				assert.Equal(t, tt.wantRecipients, out.Body.Stats.Recipients)
+				assert.Len(t, out.Body.Stats.Channels, tt.wantChannels)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_CancelBroadcast(t *testing.T) {
+	orgID := uuid.New()
+	broadcastID := uuid.New()
+
+	tests := []struct {
+		name       string
+		mockSetup  func(*repomocks.MockBroadcastRepository)
+		wantStatus int
+		wantErr    bool
+	}{
+		{
+			name: "cancels a scheduled broadcast",
+			mockSetup: func(m *repomocks.MockBroadcastRepository) {
+				m.On("CancelBroadcast", mock.Anything, orgID, broadcastID).
+					Return(&models.Broadcast{ID: broadcastID, Status: models.BroadcastStatusCancelled}, nil)
+			},
+		},
+		{
+			name: "already sent",
+			mockSetup: func(m *repomocks.MockBroadcastRepository) {
+				conflict := errs.NewHTTPError(http.StatusConflict, errors.New("only scheduled broadcasts can be cancelled"))
+				m.On("CancelBroadcast", mock.Anything, orgID, broadcastID).Return(nil, &conflict)
+			},
+			wantStatus: http.StatusConflict,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(repomocks.MockBroadcastRepository)
+			tt.mockSetup(repo)
+
+			h := NewHandler(repo, nil, nil, nil)
+			out, err := h.CancelBroadcast(context.Background(), &models.CancelBroadcastInput{OrganizationID: orgID, ID: broadcastID})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, out)
+				var httpErr *errs.HTTPError
+				require.True(t, errors.As(err, &httpErr))
+				assert.Equal(t, tt.wantStatus, httpErr.Code)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, models.BroadcastStatusCancelled, out.Body.Status)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package digest
+
+import "skillspark/internal/storage"
+
+type Handler struct {
+	DigestRepository storage.DigestRepository
+}
+
+func NewHandler(digestRepo storage.DigestRepository) *Handler {
+	return &Handler{
+		DigestRepository: digestRepo,
+	}
+}
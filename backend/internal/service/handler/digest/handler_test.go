@@ -0,0 +1,69 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_OpenDigestItem(t *testing.T) {
+	itemID := uuid.New()
+	eventID := uuid.New()
+
+	tests := []struct {
+		name         string
+		mockSetup    func(*repomocks.MockDigestRepository)
+		wantLocation string
+		wantStatus   int
+		wantErr      bool
+	}{
+		{
+			name: "records the click and redirects to the event",
+			mockSetup: func(m *repomocks.MockDigestRepository) {
+				m.On("RecordDigestClick", mock.Anything, itemID).Return(&models.DigestItem{ID: itemID, EventID: eventID, ClickCount: 1}, nil)
+			},
+			wantLocation: "skillspark://event/" + eventID.String(),
+		},
+		{
+			name: "unknown item",
+			mockSetup: func(m *repomocks.MockDigestRepository) {
+				notFound := errs.NotFound("Digest item", "id", itemID)
+				m.On("RecordDigestClick", mock.Anything, itemID).Return(nil, &notFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(repomocks.MockDigestRepository)
+			tt.mockSetup(repo)
+
+			h := NewHandler(repo)
+			out, err := h.OpenDigestItem(context.Background(), &models.OpenDigestItemInput{ID: itemID})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, out)
+				var httpErr *errs.HTTPError
+				require.True(t, errors.As(err, &httpErr))
+				assert.Equal(t, tt.wantStatus, httpErr.Code)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusFound, out.Status)
+				assert.Equal(t, tt.wantLocation, out.Location)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
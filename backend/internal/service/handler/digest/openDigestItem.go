@@ -0,0 +1,20 @@
+package digest
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/models"
+	"skillspark/internal/notification"
+)
+
+func (h *Handler) OpenDigestItem(ctx context.Context, input *models.OpenDigestItemInput) (*models.OpenDigestItemOutput, error) {
+	item, err := h.DigestRepository.RecordDigestClick(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OpenDigestItemOutput{
+		Status:   http.StatusFound,
+		Location: notification.DeepLink(models.InboxLinkEvent, item.EventID),
+	}, nil
+}
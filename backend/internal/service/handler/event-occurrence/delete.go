@@ -2,7 +2,7 @@ package eventoccurrence
 
 import (
 	"context"
-	"errors"
+	"log/slog"
 	"skillspark/internal/models"
 
 	"github.com/google/uuid"
@@ -18,13 +18,21 @@ func (h *Handler) CancelEventOccurrence(ctx context.Context, id uuid.UUID) (stri
 	}
 
 	for _, reg := range registrations.Body.Registrations {
+		// cancelled registrations were already refunded and had their credit restored
+		if reg.Status == models.RegistrationStatusCancelled {
+			continue
+		}
+
 		switch reg.PaymentIntentStatus {
 		case "succeeded":
-			refundInput := &models.RefundPaymentInput{
-				PaymentIntentID: reg.StripePaymentIntentID,
-			}
-			if _, err := h.StripeClient.RefundPayment(ctx, refundInput); err != nil {
-				return "", err
+			// payments fully covered by wallet credit have no payment intent to refund
+			if reg.StripePaymentIntentID != "" {
+				refundInput := &models.RefundPaymentInput{
+					PaymentIntentID: reg.StripePaymentIntentID,
+				}
+				if _, err := h.StripeClient.RefundPayment(ctx, refundInput); err != nil {
+					return "", err
+				}
 			}
 		case "requires_capture":
 			cancelInput := &models.CancelPaymentIntentInput{
@@ -34,7 +42,22 @@ func (h *Handler) CancelEventOccurrence(ctx context.Context, id uuid.UUID) (stri
 				return "", err
 			}
 		default:
-			return "", errors.New("payment intent must be requires_capture or succeeded")
+			// nothing was charged yet, though credit may already be applied to the payment
+		}
+
+		// the applied credit goes back to the wallet, so the organization's share of it,
+		// transferred when the payment was made, is taken back
+		if reg.StripeTransferID != "" {
+			_, err := h.StripeClient.ReverseTransfer(ctx, &models.ReverseTransferInput{
+				TransferID:     reg.StripeTransferID,
+				IdempotencyKey: "transfer_reversal:" + reg.ID.String(),
+			})
+			if err != nil {
+				return "", err
+			}
+		}
+		if _, err := h.WalletRepository.ReverseAppliedCredit(ctx, reg.ID, reg.GuardianID); err != nil {
+			return "", err
 		}
 	}
 
@@ -42,5 +65,14 @@ func (h *Handler) CancelEventOccurrence(ctx context.Context, id uuid.UUID) (stri
 		return "", err
 	}
 
+	if h.NotificationService != nil {
+		if err := h.NotificationService.CancelEventOccurrenceReminders(ctx, id); err != nil {
+			slog.Error("failed to cancel event reminders", "event_occurrence_id", id, "error", err)
+		}
+		if err := h.NotificationService.SendEventCancellationNotices(ctx, registrations.Body.Registrations); err != nil {
+			slog.Error("failed to send cancellation notices", "event_occurrence_id", id, "error", err)
+		}
+	}
+
 	return "Event occurrence successfully cancelled.", nil
 }
@@ -2,6 +2,7 @@ package eventoccurrence
 
 import (
 	"context"
+	"skillspark/internal/imageproc"
 	"skillspark/internal/models"
 	"skillspark/internal/utils"
 	"time"
@@ -33,6 +34,11 @@ func (h *Handler) AssignURLS(ctx context.Context, occurrences []models.EventOccu
 		if err != nil {
 			return err
 		}
+
+		occurrences[idx].Event.HeaderImageRenditions, err = imageproc.PresignRenditions(ctx, h.s3Client, imageproc.EventHeader, *key, time.Hour)
+		if err != nil {
+			return err
+		}
 	}
 
 	occurrences[idx].Event.PresignedURL = &url
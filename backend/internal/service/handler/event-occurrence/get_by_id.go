@@ -3,6 +3,7 @@ package eventoccurrence
 import (
 	"context"
 	"skillspark/internal/errs"
+	"skillspark/internal/imageproc"
 	"skillspark/internal/models"
 	"time"
 
@@ -30,6 +31,11 @@ func (h *Handler) GetEventOccurrenceByID(ctx context.Context, input *models.GetE
 		if errr != nil {
 			return nil, err
 		}
+
+		eventOccurrence.Event.HeaderImageRenditions, errr = imageproc.PresignRenditions(ctx, h.s3Client, imageproc.EventHeader, *key, time.Hour)
+		if errr != nil {
+			return nil, errr
+		}
 	}
 	eventOccurrence.Event.PresignedURL = &url
 
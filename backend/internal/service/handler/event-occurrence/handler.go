@@ -1,6 +1,7 @@
 package eventoccurrence
 
 import (
+	"skillspark/internal/notification"
 	"skillspark/internal/s3_client"
 	"skillspark/internal/storage"
 	"skillspark/internal/stripeClient"
@@ -13,7 +14,9 @@ type Handler struct {
 	LocationRepository        storage.LocationRepository
 	s3Client                  s3_client.S3Interface
 	RegistrationRepository    storage.RegistrationRepository
+	WalletRepository          storage.WalletRepository
 	StripeClient              stripeClient.StripeClientInterface
+	NotificationService       notification.NotificationServiceInterface
 }
 
 func NewHandler(
@@ -23,7 +26,9 @@ func NewHandler(
 	locationRepository storage.LocationRepository,
 	s3client s3_client.S3Interface,
 	registrationRepository storage.RegistrationRepository,
-	stripeClient stripeClient.StripeClientInterface) *Handler {
+	walletRepository storage.WalletRepository,
+	stripeClient stripeClient.StripeClientInterface,
+	notifService notification.NotificationServiceInterface) *Handler {
 	return &Handler{
 		EventOccurrenceRepository: eventOccurrenceRepository,
 		ManagerRepository:         managerRepository,
@@ -31,6 +36,8 @@ func NewHandler(
 		LocationRepository:        locationRepository,
 		s3Client:                  s3client,
 		RegistrationRepository:    registrationRepository,
+		WalletRepository:          walletRepository,
 		StripeClient:              stripeClient,
+		NotificationService:       notifService,
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"skillspark/internal/errs"
 	"skillspark/internal/models"
+	notificationmocks "skillspark/internal/notification/mocks"
 	s3mocks "skillspark/internal/s3_client/mocks"
 	repomocks "skillspark/internal/storage/repo-mocks"
 	stripemocks "skillspark/internal/stripeClient/mocks"
@@ -84,9 +85,10 @@ func newHandler(
 	locationRepo *repomocks.MockLocationRepository,
 	s3 *s3mocks.S3ClientMock,
 	regRepo *repomocks.MockRegistrationRepository,
+	walletRepo *repomocks.MockWalletRepository,
 	sc *stripemocks.MockStripeClient,
 ) *Handler {
-	return NewHandler(eoRepo, managerRepo, eventRepo, locationRepo, s3, regRepo, sc)
+	return NewHandler(eoRepo, managerRepo, eventRepo, locationRepo, s3, regRepo, walletRepo, sc, nil)
 }
 
 func TestHandler_CreateEventOccurrence(t *testing.T) {
@@ -153,7 +155,7 @@ func TestHandler_CreateEventOccurrence(t *testing.T) {
 			mockStripeClient := new(stripemocks.MockStripeClient)
 			tt.mockSetup(mockRepo)
 
-			handler := NewHandler(mockRepo, mockManagerRepo, mockEventRepo, mockLocationRepo, mockS3, mockRegRepo, mockStripeClient)
+			handler := NewHandler(mockRepo, mockManagerRepo, mockEventRepo, mockLocationRepo, mockS3, mockRegRepo, new(repomocks.MockWalletRepository), mockStripeClient, nil)
 			ctx := context.Background()
 
 			mockManagerRepo.On("GetManagerByID", mock.Anything, mock.Anything).Return(&models.Manager{
@@ -281,7 +283,7 @@ func TestHandler_GetEventOccurrenceById(t *testing.T) {
 			mockStripeClient := new(stripemocks.MockStripeClient)
 			tt.mockSetup(mockRepo)
 
-			handler := NewHandler(mockRepo, mockManagerRepo, mockEventRepo, mockLocationRepo, mockS3, mockRegRepo, mockStripeClient)
+			handler := NewHandler(mockRepo, mockManagerRepo, mockEventRepo, mockLocationRepo, mockS3, mockRegRepo, new(repomocks.MockWalletRepository), mockStripeClient, nil)
 			ctx := context.Background()
 
 			input := &models.GetEventOccurrenceByIDInput{ID: uuid.MustParse(tt.id), AcceptLanguage: "en-US"}
@@ -476,7 +478,7 @@ func TestHandler_UpdateEventOccurrence(t *testing.T) {
 			mockStripeClient := new(stripemocks.MockStripeClient)
 			tt.mockSetup(mockRepo)
 
-			handler := NewHandler(mockRepo, mockManagerRepo, mockEventRepo, mockLocationRepo, mockS3, mockRegRepo, mockStripeClient)
+			handler := NewHandler(mockRepo, mockManagerRepo, mockEventRepo, mockLocationRepo, mockS3, mockRegRepo, new(repomocks.MockWalletRepository), mockStripeClient, nil)
 			ctx := context.Background()
 
 			if !tt.wantErr {
@@ -599,19 +601,22 @@ func TestHandler_CancelEventOccurrence(t *testing.T) {
 	tests := []struct {
 		name      string
 		eoID      uuid.UUID
-		mockSetup func(*repomocks.MockEventOccurrenceRepository, *repomocks.MockRegistrationRepository, *stripemocks.MockStripeClient)
+		mockSetup func(*repomocks.MockEventOccurrenceRepository, *repomocks.MockRegistrationRepository, *repomocks.MockWalletRepository, *stripemocks.MockStripeClient)
 		wantErr   bool
 	}{
 		{
 			name: "cancel with requires_capture registrations — cancels payment intents",
 			eoID: eoID,
-			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, regRepo *repomocks.MockRegistrationRepository, sc *stripemocks.MockStripeClient) {
+			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, regRepo *repomocks.MockRegistrationRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
 				regRepo.On("GetRegistrationsByEventOccurrenceID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationsByEventOccurrenceIDInput")).
 					Return(makeRegistrations("requires_capture"), nil)
 
 				sc.On("CancelPaymentIntent", mock.Anything, mock.AnythingOfType("*models.CancelPaymentIntentInput")).
 					Return(cancelledPaymentIntentOutput, nil)
 
+				walletRepo.On("ReverseAppliedCredit", mock.Anything, mock.AnythingOfType("uuid.UUID"), mock.Anything).
+					Return(0, nil)
+
 				eoRepo.On("CancelEventOccurrence", mock.Anything, eoID).
 					Return(nil)
 			},
@@ -620,13 +625,33 @@ func TestHandler_CancelEventOccurrence(t *testing.T) {
 		{
 			name: "cancel with succeeded registrations — issues refunds",
 			eoID: eoID,
-			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, regRepo *repomocks.MockRegistrationRepository, sc *stripemocks.MockStripeClient) {
+			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, regRepo *repomocks.MockRegistrationRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
 				regRepo.On("GetRegistrationsByEventOccurrenceID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationsByEventOccurrenceIDInput")).
 					Return(makeRegistrations("succeeded"), nil)
 
 				sc.On("RefundPayment", mock.Anything, mock.AnythingOfType("*models.RefundPaymentInput")).
 					Return(refundOutput, nil)
 
+				walletRepo.On("ReverseAppliedCredit", mock.Anything, mock.AnythingOfType("uuid.UUID"), mock.Anything).
+					Return(0, nil)
+
+				eoRepo.On("CancelEventOccurrence", mock.Anything, eoID).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "cancel with credit-only registrations — restores wallet credit",
+			eoID: eoID,
+			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, regRepo *repomocks.MockRegistrationRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
+				regs := makeRegistrations("succeeded")
+				regs.Body.Registrations[0].StripePaymentIntentID = ""
+				regRepo.On("GetRegistrationsByEventOccurrenceID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationsByEventOccurrenceIDInput")).
+					Return(regs, nil)
+
+				walletRepo.On("ReverseAppliedCredit", mock.Anything, regs.Body.Registrations[0].ID, mock.Anything).
+					Return(10000, nil)
+
 				eoRepo.On("CancelEventOccurrence", mock.Anything, eoID).
 					Return(nil)
 			},
@@ -635,7 +660,7 @@ func TestHandler_CancelEventOccurrence(t *testing.T) {
 		{
 			name: "cancel with no registrations",
 			eoID: eoID,
-			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, regRepo *repomocks.MockRegistrationRepository, sc *stripemocks.MockStripeClient) {
+			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, regRepo *repomocks.MockRegistrationRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
 				out := &models.GetRegistrationsByEventOccurrenceIDOutput{}
 				out.Body.Registrations = []models.Registration{}
 				regRepo.On("GetRegistrationsByEventOccurrenceID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationsByEventOccurrenceIDInput")).
@@ -649,7 +674,7 @@ func TestHandler_CancelEventOccurrence(t *testing.T) {
 		{
 			name: "get registrations fails",
 			eoID: eoID,
-			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, regRepo *repomocks.MockRegistrationRepository, sc *stripemocks.MockStripeClient) {
+			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, regRepo *repomocks.MockRegistrationRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
 				regRepo.On("GetRegistrationsByEventOccurrenceID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationsByEventOccurrenceIDInput")).
 					Return(nil, &errs.HTTPError{Code: 500, Message: "db error"})
 			},
@@ -658,7 +683,7 @@ func TestHandler_CancelEventOccurrence(t *testing.T) {
 		{
 			name: "refund payment fails — returns error",
 			eoID: eoID,
-			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, regRepo *repomocks.MockRegistrationRepository, sc *stripemocks.MockStripeClient) {
+			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, regRepo *repomocks.MockRegistrationRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
 				regRepo.On("GetRegistrationsByEventOccurrenceID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationsByEventOccurrenceIDInput")).
 					Return(makeRegistrations("succeeded"), nil)
 
@@ -670,7 +695,7 @@ func TestHandler_CancelEventOccurrence(t *testing.T) {
 		{
 			name: "cancel payment intent fails — returns error",
 			eoID: eoID,
-			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, regRepo *repomocks.MockRegistrationRepository, sc *stripemocks.MockStripeClient) {
+			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, regRepo *repomocks.MockRegistrationRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
 				regRepo.On("GetRegistrationsByEventOccurrenceID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationsByEventOccurrenceIDInput")).
 					Return(makeRegistrations("requires_capture"), nil)
 
@@ -680,18 +705,64 @@ func TestHandler_CancelEventOccurrence(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "unknown payment intent status — returns error",
+			name: "cancel with credit-only registrations — reverses the organization's transfer",
 			eoID: eoID,
-			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, regRepo *repomocks.MockRegistrationRepository, sc *stripemocks.MockStripeClient) {
+			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, regRepo *repomocks.MockRegistrationRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
+				regs := makeRegistrations("succeeded")
+				regs.Body.Registrations[0].StripePaymentIntentID = ""
+				regs.Body.Registrations[0].StripeTransferID = "tr_test_123"
 				regRepo.On("GetRegistrationsByEventOccurrenceID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationsByEventOccurrenceIDInput")).
-					Return(makeRegistrations("requires_payment_method"), nil)
+					Return(regs, nil)
+
+				sc.On("ReverseTransfer", mock.Anything, &models.ReverseTransferInput{
+					TransferID:     "tr_test_123",
+					IdempotencyKey: "transfer_reversal:" + regs.Body.Registrations[0].ID.String(),
+				}).Return(&models.ReverseTransferOutput{}, nil)
+
+				walletRepo.On("ReverseAppliedCredit", mock.Anything, regs.Body.Registrations[0].ID, mock.Anything).
+					Return(10000, nil)
+
+				eoRepo.On("CancelEventOccurrence", mock.Anything, eoID).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "transfer reversal fails — returns error before restoring credit",
+			eoID: eoID,
+			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, regRepo *repomocks.MockRegistrationRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
+				regs := makeRegistrations("succeeded")
+				regs.Body.Registrations[0].StripePaymentIntentID = ""
+				regs.Body.Registrations[0].StripeTransferID = "tr_test_123"
+				regRepo.On("GetRegistrationsByEventOccurrenceID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationsByEventOccurrenceIDInput")).
+					Return(regs, nil)
+
+				sc.On("ReverseTransfer", mock.Anything, mock.AnythingOfType("*models.ReverseTransferInput")).
+					Return(nil, &errs.HTTPError{Code: 500, Message: "stripe error"})
 			},
 			wantErr: true,
 		},
+		{
+			name: "cancel with uncharged registrations — restores applied credit",
+			eoID: eoID,
+			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, regRepo *repomocks.MockRegistrationRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
+				regs := makeRegistrations("")
+				regs.Body.Registrations[0].StripePaymentIntentID = ""
+				regRepo.On("GetRegistrationsByEventOccurrenceID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationsByEventOccurrenceIDInput")).
+					Return(regs, nil)
+
+				walletRepo.On("ReverseAppliedCredit", mock.Anything, regs.Body.Registrations[0].ID, mock.Anything).
+					Return(2500, nil)
+
+				eoRepo.On("CancelEventOccurrence", mock.Anything, eoID).
+					Return(nil)
+			},
+			wantErr: false,
+		},
 		{
 			name: "cancel event occurrence repo fails",
 			eoID: eoID,
-			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, regRepo *repomocks.MockRegistrationRepository, sc *stripemocks.MockStripeClient) {
+			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, regRepo *repomocks.MockRegistrationRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
 				out := &models.GetRegistrationsByEventOccurrenceIDOutput{}
 				out.Body.Registrations = []models.Registration{}
 				regRepo.On("GetRegistrationsByEventOccurrenceID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationsByEventOccurrenceIDInput")).
@@ -715,10 +786,11 @@ func TestHandler_CancelEventOccurrence(t *testing.T) {
 			mockLocationRepo := new(repomocks.MockLocationRepository)
 			mockS3 := new(s3mocks.S3ClientMock)
 			mockRegRepo := new(repomocks.MockRegistrationRepository)
+			mockWalletRepo := new(repomocks.MockWalletRepository)
 			mockStripeClient := new(stripemocks.MockStripeClient)
-			tt.mockSetup(mockEORepo, mockRegRepo, mockStripeClient)
+			tt.mockSetup(mockEORepo, mockRegRepo, mockWalletRepo, mockStripeClient)
 
-			handler := newHandler(mockEORepo, mockManagerRepo, mockEventRepo, mockLocationRepo, mockS3, mockRegRepo, mockStripeClient)
+			handler := newHandler(mockEORepo, mockManagerRepo, mockEventRepo, mockLocationRepo, mockS3, mockRegRepo, mockWalletRepo, mockStripeClient)
 			ctx := context.Background()
 
 			msg, err := handler.CancelEventOccurrence(ctx, tt.eoID)
@@ -734,6 +806,7 @@ func TestHandler_CancelEventOccurrence(t *testing.T) {
 			mockEORepo.AssertExpectations(t)
 			mockRegRepo.AssertExpectations(t)
 			mockStripeClient.AssertExpectations(t)
+			mockWalletRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -842,7 +915,7 @@ func TestHandler_GetTrendingEventOccurrences(t *testing.T) {
 			mockStripeClient := new(stripemocks.MockStripeClient)
 			tt.mockSetup(mockRepo, mockS3)
 
-			handler := newHandler(mockRepo, mockManagerRepo, mockEventRepo, mockLocationRepo, mockS3, mockRegRepo, mockStripeClient)
+			handler := newHandler(mockRepo, mockManagerRepo, mockEventRepo, mockLocationRepo, mockS3, mockRegRepo, new(repomocks.MockWalletRepository), mockStripeClient)
 			ctx := context.Background()
 
 			results, err := handler.GetTrendingEventOccurrences(ctx, tt.input)
@@ -864,3 +937,72 @@ func TestHandler_GetTrendingEventOccurrences(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_UpdateEventOccurrence_RescheduleReminders(t *testing.T) {
+	eoID := uuid.New()
+	originalStart := time.Now().Add(72 * time.Hour).Truncate(time.Second)
+	movedStart := originalStart.Add(time.Hour)
+	sameStart := originalStart
+
+	tests := []struct {
+		name             string
+		startTime        *time.Time
+		expectReschedule bool
+	}{
+		{name: "start time moved", startTime: &movedStart, expectReschedule: true},
+		{name: "start time unchanged", startTime: &sameStart},
+		{name: "start time not updated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockEORepo := new(repomocks.MockEventOccurrenceRepository)
+			mockNotifService := new(notificationmocks.MockNotificationService)
+
+			mockEORepo.On("GetEventOccurrenceByID", mock.Anything, eoID, "en-US").
+				Return(&models.EventOccurrence{ID: eoID, StartTime: originalStart, MaxAttendees: 10}, nil)
+			mockEORepo.On("UpdateEventOccurrence", mock.Anything, mock.AnythingOfType("*models.UpdateEventOccurrenceInput")).
+				Return(&models.EventOccurrence{ID: eoID}, nil)
+			if tt.expectReschedule {
+				mockNotifService.On("RescheduleEventReminders", mock.Anything, eoID).Return(nil)
+			}
+
+			handler := NewHandler(mockEORepo, nil, nil, nil, nil, nil, nil, nil, mockNotifService)
+			input := &models.UpdateEventOccurrenceInput{AcceptLanguage: "en-US", ID: eoID}
+			input.Body.StartTime = tt.startTime
+
+			_, err := handler.UpdateEventOccurrence(context.Background(), input)
+
+			assert.NoError(t, err)
+			mockNotifService.AssertExpectations(t)
+			if !tt.expectReschedule {
+				mockNotifService.AssertNotCalled(t, "RescheduleEventReminders", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestHandler_CancelEventOccurrence_CancelsReminders(t *testing.T) {
+	t.Parallel()
+
+	eoID := uuid.New()
+	mockEORepo := new(repomocks.MockEventOccurrenceRepository)
+	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	mockNotifService := new(notificationmocks.MockNotificationService)
+
+	out := &models.GetRegistrationsByEventOccurrenceIDOutput{}
+	out.Body.Registrations = []models.Registration{}
+	mockRegRepo.On("GetRegistrationsByEventOccurrenceID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationsByEventOccurrenceIDInput")).
+		Return(out, nil)
+	mockEORepo.On("CancelEventOccurrence", mock.Anything, eoID).Return(nil)
+	mockNotifService.On("CancelEventOccurrenceReminders", mock.Anything, eoID).Return(nil)
+	mockNotifService.On("SendEventCancellationNotices", mock.Anything, out.Body.Registrations).Return(nil)
+
+	handler := NewHandler(mockEORepo, nil, nil, nil, nil, mockRegRepo, nil, nil, mockNotifService)
+	_, err := handler.CancelEventOccurrence(context.Background(), eoID)
+
+	assert.NoError(t, err)
+	mockNotifService.AssertExpectations(t)
+}
@@ -3,6 +3,7 @@ package eventoccurrence
 import (
 	"cmp"
 	"context"
+	"log/slog"
 	"skillspark/internal/errs"
 	"skillspark/internal/models"
 )
@@ -52,5 +53,13 @@ func (h *Handler) UpdateEventOccurrence(ctx context.Context, input *models.Updat
 	if err != nil {
 		return nil, err
 	}
+
+	// reminders are timed from the start time, so move them with it
+	if h.NotificationService != nil && input.Body.StartTime != nil && !input.Body.StartTime.Equal(ogEventOccurrence.StartTime) {
+		if err := h.NotificationService.RescheduleEventReminders(ctx, input.ID); err != nil {
+			slog.Error("failed to reschedule event reminders", "event_occurrence_id", input.ID, "error", err)
+		}
+	}
+
 	return eventOccurrence, nil
 }
@@ -3,6 +3,7 @@ package event
 import (
 	"context"
 	"skillspark/internal/errs"
+	"skillspark/internal/imageproc"
 	"skillspark/internal/models"
 	"skillspark/internal/s3_client"
 )
@@ -10,7 +11,11 @@ import (
 func (h *Handler) CreateEvent(ctx context.Context, input *models.CreateEventInput, updateBody *models.UpdateEventBody, imageData *[]byte, s3Client s3_client.S3Interface) (*models.Event, error) {
 
 	var key *string
-	var url *string
+
+	image, err := processHeaderImage(imageData)
+	if err != nil {
+		return nil, err
+	}
 
 	misc := ""
 
@@ -33,43 +38,49 @@ func (h *Handler) CreateEvent(ctx context.Context, input *models.CreateEventInpu
 		return nil, e
 	}
 
-	url, key, err = h.CreateEventS3Helper(ctx, s3Client, event, updateInput, imageData)
+	stored, err := h.CreateEventS3Helper(ctx, s3Client, event, updateInput, image)
 	if err != nil {
 		e := errs.InternalServerError("Something went wrong when inserting into S3" + err.Error())
 		return nil, e
 	}
+	if stored != nil {
+		key = &stored.Key
+	}
 	_, err = h.EventRepository.UpdateEvent(ctx, translationsReinsertion, key)
 
 	if err != nil {
 		e := errs.InternalServerError("Invalid Update" + err.Error())
 		return nil, e
 	}
-	event.PresignedURL = url
+	if stored != nil {
+		event.PresignedURL = stored.URL
+		event.HeaderImageRenditions = stored.Renditions
+	}
 	event.HeaderImageS3Key = key
 	return event, nil
 }
 
 // helper for uploading image to s3
 func (h *Handler) CreateEventS3Helper(ctx context.Context, s3Client s3_client.S3Interface, event *models.Event,
-	updateInput *models.UpdateEventInput, imageData *[]byte) (*string, *string, error) {
+	updateInput *models.UpdateEventInput, image *imageproc.Processed) (*imageproc.Stored, error) {
 
-	if imageData != nil {
+	if image != nil {
 
 		key, err := h.generateS3Key(event.ID)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
-		url, errr := s3Client.UploadImage(ctx, key, *imageData)
+		stored, errr := imageproc.Store(ctx, s3Client, *key, image)
 		if errr != nil {
-			return nil, nil, errr
+			return nil, errr
 		}
 
-		return url, key, nil
+		return stored, nil
 
 	}
 
-	return nil, nil, nil
+	return nil, nil
 
 }
 
@@ -2,6 +2,7 @@ package event
 
 import (
 	"context"
+	"skillspark/internal/imageproc"
 	"skillspark/internal/models"
 	"skillspark/internal/utils"
 	"time"
@@ -20,6 +21,11 @@ func (h *Handler) GetAllEvents(ctx context.Context, pagination utils.Pagination,
 				return nil, err
 			}
 			events[idx].PresignedURL = &url
+
+			events[idx].HeaderImageRenditions, err = imageproc.PresignRenditions(ctx, h.s3client, imageproc.EventHeader, *events[idx].HeaderImageS3Key, time.Hour)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -3,6 +3,7 @@ package event
 import (
 	"context"
 	"skillspark/internal/errs"
+	"skillspark/internal/imageproc"
 	"skillspark/internal/models"
 	"skillspark/internal/s3_client"
 	"time"
@@ -27,6 +28,7 @@ func (h *Handler) GetEventOccurrencesByEventID(ctx context.Context, input *model
 	}
 
 	var url *string
+	var renditions []models.ImageRendition
 	key := eventOccurrence[0].Event.HeaderImageS3Key
 	if key != nil {
 		presignedURL, err := s3Client.GeneratePresignedURL(ctx, *key, time.Hour)
@@ -35,10 +37,16 @@ func (h *Handler) GetEventOccurrencesByEventID(ctx context.Context, input *model
 		}
 
 		url = &presignedURL
+
+		renditions, err = imageproc.PresignRenditions(ctx, s3Client, imageproc.EventHeader, *key, time.Hour)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	for idx := range eventOccurrence {
 		eventOccurrence[idx].Event.PresignedURL = url
+		eventOccurrence[idx].Event.HeaderImageRenditions = renditions
 	}
 
 	return eventOccurrence, nil
@@ -1,7 +1,10 @@
 package event
 
 import (
+	"bytes"
 	"context"
+	"image"
+	"image/png"
 	"net/url"
 	"skillspark/internal/errs"
 	"skillspark/internal/models"
@@ -28,14 +31,11 @@ func createMockTranslateClient() *translatemocks.TranslateMock {
 	return new(translatemocks.TranslateMock)
 }
 
-// createDummyImageData
+// createDummyImageData is a valid PNG large enough to pass the header image limits
 func createDummyImageData() *[]byte {
-	data := []byte{
-		0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A,
-		0x00, 0x00, 0x00, 0x0D, 0x49, 0x48, 0x44, 0x52,
-		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01,
-		0x08, 0x02, 0x00, 0x00, 0x00, 0x90, 0x77, 0x53,
-	}
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 320, 240)))
+	data := buf.Bytes()
 	return &data
 }
 
@@ -153,7 +153,7 @@ func TestHandler_CreateEvent(t *testing.T) {
 			},
 			mockS3Setup: func(m *s3mocks.S3ClientMock) {
 				mockURL := "https://test-bucket.s3.amazonaws.com/events/test/header.jpg?X-Amz-Signature=abc123"
-				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything).Return(&mockURL, nil)
+				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&mockURL, nil)
 			},
 			mockTranslateSetup: func(m *translatemocks.TranslateMock) {
 				translatedTitle := "จูเนียร์ โรโบติกส์"
@@ -303,6 +303,10 @@ func TestHandler_UpdateEvent(t *testing.T) {
 			}(),
 			imageData: createDummyImageData(),
 			mockSetup: func(m *repomocks.MockEventRepository) {
+				m.On("GetEventByID", mock.Anything, eventID, "en-US").Return(&models.Event{
+					ID:               eventID,
+					HeaderImageS3Key: &headerKey,
+				}, nil)
 				m.On("UpdateEvent", mock.Anything, mock.AnythingOfType("*models.UpdateEventDBInput"), mock.Anything).Return(&models.Event{
 					ID:               eventID,
 					Title:            "Updated Robotics with Image",
@@ -314,7 +318,9 @@ func TestHandler_UpdateEvent(t *testing.T) {
 			},
 			mockS3Setup: func(m *s3mocks.S3ClientMock) {
 				mockURL := "https://test-bucket.s3.amazonaws.com/events/test/header.jpg?X-Amz-Signature=abc123"
-				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything).Return(&mockURL, nil)
+				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&mockURL, nil)
+				// the replaced header was uploaded before renditions existed
+				m.On("DeleteObject", mock.Anything, headerKey).Return(nil).Once()
 			},
 			mockTranslateSetup: func(m *translatemocks.TranslateMock) {
 				translatedTitle := "หุ่นยนต์ที่อัปเดตพร้อมรูปภาพ"
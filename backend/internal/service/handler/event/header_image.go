@@ -0,0 +1,24 @@
+package event
+
+import (
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/imageproc"
+)
+
+// processHeaderImage validates an uploaded header image before anything is saved. It
+// returns nil when no image was sent.
+func processHeaderImage(imageData *[]byte) (*imageproc.Processed, error) {
+	if imageData == nil || len(*imageData) == 0 {
+		return nil, nil
+	}
+
+	processed, err := imageproc.Process(*imageData, imageproc.EventHeader)
+	if err != nil {
+		if errors.Is(err, imageproc.ErrInvalidImage) {
+			return nil, errs.BadRequest("Invalid header_image: " + err.Error())
+		}
+		return nil, errs.InternalServerError("Failed to process header image: ", err.Error())
+	}
+	return processed, nil
+}
@@ -2,7 +2,9 @@ package event
 
 import (
 	"context"
+	"log"
 	"skillspark/internal/errs"
+	"skillspark/internal/imageproc"
 	"skillspark/internal/models"
 	"skillspark/internal/s3_client"
 )
@@ -10,7 +12,12 @@ import (
 func (h *Handler) UpdateEvent(ctx context.Context, input *models.UpdateEventInput, image_data *[]byte, s3Client s3_client.S3Interface) (*models.Event, error) {
 
 	var key *string
-	var url *string
+	var stored *imageproc.Stored
+
+	image, err := processHeaderImage(image_data)
+	if err != nil {
+		return nil, err
+	}
 
 	translateInput := []*string{input.Body.Title, input.Body.Description}
 
@@ -25,13 +32,20 @@ func (h *Handler) UpdateEvent(ctx context.Context, input *models.UpdateEventInpu
 
 	updateInput := h.UpdateEventTranslateStruct(ctx, input, translatedTitle, translatedDescription)
 
-	if image_data != nil {
-		var err error
-		url, key, err = h.UpdateEventS3Helper(ctx, s3Client, input, image_data)
+	var previousKey *string
+	if image != nil {
+		previous, err := h.EventRepository.GetEventByID(ctx, input.ID, input.AcceptLanguage)
+		if err != nil {
+			return nil, err
+		}
+		previousKey = previous.HeaderImageS3Key
+
+		stored, err = h.UpdateEventS3Helper(ctx, s3Client, input, image)
 		if err != nil {
 			e := errs.InternalServerError("S3 upload failed", err.Error())
 			return nil, e
 		}
+		key = &stored.Key
 	}
 
 	event, err := h.EventRepository.UpdateEvent(ctx, updateInput, key)
@@ -39,20 +53,30 @@ func (h *Handler) UpdateEvent(ctx context.Context, input *models.UpdateEventInpu
 		return nil, err
 	}
 
-	event.PresignedURL = url
+	if stored != nil {
+		event.PresignedURL = stored.URL
+		event.HeaderImageRenditions = stored.Renditions
+
+		// the replaced header is only removed once nothing points at it
+		if previousKey != nil && *previousKey != stored.Key {
+			if err := imageproc.Delete(ctx, s3Client, imageproc.EventHeader, *previousKey); err != nil {
+				log.Printf("Failed to delete replaced header image of event %s: %v", input.ID, err)
+			}
+		}
+	}
 
 	return event, nil
 }
 
-func (h *Handler) UpdateEventS3Helper(ctx context.Context, s3Client s3_client.S3Interface, input *models.UpdateEventInput, image_data *[]byte) (*string, *string, error) {
+func (h *Handler) UpdateEventS3Helper(ctx context.Context, s3Client s3_client.S3Interface, input *models.UpdateEventInput, image *imageproc.Processed) (*imageproc.Stored, error) {
 	key, genErr := h.generateS3Key(input.ID)
 	if genErr != nil {
-		return nil, nil, genErr
+		return nil, genErr
 	}
-	url, errr := s3Client.UploadImage(ctx, key, *image_data)
+	stored, errr := imageproc.Store(ctx, s3Client, *key, image)
 	if errr != nil {
-		return nil, nil, errr
+		return nil, errr
 	}
 
-	return url, key, nil
+	return stored, nil
 }
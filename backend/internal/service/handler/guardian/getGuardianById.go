@@ -2,7 +2,9 @@ package guardian
 
 import (
 	"context"
+	"time"
 
+	"skillspark/internal/imageproc"
 	"skillspark/internal/models"
 )
 
@@ -12,5 +14,18 @@ func (h *Handler) GetGuardianById(ctx context.Context, input *models.GetGuardian
 		return nil, err
 	}
 
+	if key := guardian.ProfilePictureS3Key; key != nil {
+		url, err := h.s3Client.GeneratePresignedURL(ctx, *key, time.Hour)
+		if err != nil {
+			return nil, err
+		}
+		guardian.ProfilePictureURL = &url
+
+		guardian.ProfilePictureRenditions, err = imageproc.PresignRenditions(ctx, h.s3Client, imageproc.GuardianAvatar, *key, time.Hour)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return guardian, nil
 }
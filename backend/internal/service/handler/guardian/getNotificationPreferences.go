@@ -0,0 +1,34 @@
+package guardian
+
+import (
+	"context"
+
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func (h *Handler) GetNotificationPreferences(ctx context.Context, input *models.GetNotificationPreferencesInput) (*models.NotificationPreferencesBody, error) {
+	return h.notificationPreferences(ctx, input.ID)
+}
+
+func (h *Handler) notificationPreferences(ctx context.Context, guardianID uuid.UUID) (*models.NotificationPreferencesBody, error) {
+	prefs, err := h.GuardianRepository.GetGuardianNotificationPreferences(ctx, []uuid.UUID{guardianID})
+	if err != nil {
+		return nil, err
+	}
+
+	guardianPrefs, ok := prefs[guardianID]
+	if !ok {
+		errr := errs.NotFound("Guardian", "id", guardianID.String())
+		return nil, &errr
+	}
+
+	return &models.NotificationPreferencesBody{
+		PushNotifications:  guardianPrefs.PushNotifications,
+		EmailNotifications: guardianPrefs.EmailNotifications,
+		QuietHours:         guardianPrefs.QuietHours,
+		Preferences:        guardianPrefs.Matrix(),
+	}, nil
+}
@@ -2,6 +2,7 @@ package guardian
 
 import (
 	"skillspark/internal/config"
+	"skillspark/internal/s3_client"
 	"skillspark/internal/storage"
 	"skillspark/internal/stripeClient"
 
@@ -11,15 +12,17 @@ import (
 type Handler struct {
 	GuardianRepository storage.GuardianRepository
 	StripeClient       stripeClient.StripeClientInterface
+	s3Client           s3_client.S3Interface
 	db                 *pgxpool.Pool
 	config             config.Supabase
 }
 
-func NewHandler(guardianRepository storage.GuardianRepository, db *pgxpool.Pool, sc stripeClient.StripeClientInterface, config config.Supabase) *Handler {
+func NewHandler(guardianRepository storage.GuardianRepository, db *pgxpool.Pool, sc stripeClient.StripeClientInterface, s3Client s3_client.S3Interface, config config.Supabase) *Handler {
 	return &Handler{
 		GuardianRepository: guardianRepository,
 		db:                 db,
 		config:             config,
 		StripeClient:       sc,
+		s3Client:           s3Client,
 	}
 }
@@ -80,7 +80,7 @@ func TestHandler_GetGuardianById(t *testing.T) {
 
 			testDB := testutil.SetupTestDB(t)
 
-			handler := NewHandler(mockRepo, testDB, mockStripeClient, cfg)
+			handler := NewHandler(mockRepo, testDB, mockStripeClient, nil, cfg)
 			ctx := context.Background()
 
 			input := &models.GetGuardianByIDInput{ID: uuid.MustParse(tt.id)}
@@ -172,7 +172,7 @@ func TestHandler_UpdateGuardian(t *testing.T) {
 
 			testDB := testutil.SetupTestDB(t)
 
-			handler := NewHandler(mockRepo, testDB, mockStripeClient, cfg)
+			handler := NewHandler(mockRepo, testDB, mockStripeClient, nil, cfg)
 			ctx := context.Background()
 
 			guardian, err := handler.UpdateGuardian(ctx, tt.input)
@@ -241,7 +241,7 @@ func TestHandler_GetGuardianByChildId(t *testing.T) {
 
 			testDB := testutil.SetupTestDB(t)
 
-			handler := NewHandler(mockRepo, testDB, mockStripeClient, cfg)
+			handler := NewHandler(mockRepo, testDB, mockStripeClient, nil, cfg)
 			ctx := context.Background()
 
 			input := &models.GetGuardianByChildIDInput{ChildID: uuid.MustParse(tt.childID)}
@@ -324,7 +324,7 @@ func TestHandler_DeleteGuardian(t *testing.T) {
 
 			testDB := testutil.SetupTestDB(t)
 
-			handler := NewHandler(mockRepo, testDB, mockStripeClient, cfg)
+			handler := NewHandler(mockRepo, testDB, mockStripeClient, nil, cfg)
 			ctx := context.Background()
 
 			input := &models.DeleteGuardianInput{ID: uuid.MustParse(tt.id)}
@@ -343,3 +343,157 @@ func TestHandler_DeleteGuardian(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_UpdateNotificationPreferences(t *testing.T) {
+	guardianID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+
+	tests := []struct {
+		name        string
+		preferences []models.NotificationPreference
+		mockSetup   func(*repomocks.MockGuardianRepository)
+		wantErr     bool
+	}{
+		{
+			name: "turns a topic off on one channel",
+			preferences: []models.NotificationPreference{
+				{Topic: models.NotificationTopicEventReminders, Channel: models.NotificationTypeEmail, Enabled: false},
+			},
+			mockSetup: func(m *repomocks.MockGuardianRepository) {
+				m.On("GetGuardianNotificationPreferences", mock.Anything, []uuid.UUID{guardianID}).
+					Return(map[uuid.UUID]models.GuardianNotificationPreferences{
+						guardianID: {EmailNotifications: true, PushNotifications: true},
+					}, nil).Once()
+				m.On("UpdateGuardianNotificationPreferences", mock.Anything, guardianID, mock.Anything).Return(nil).Once()
+				m.On("GetGuardianNotificationPreferences", mock.Anything, []uuid.UUID{guardianID}).
+					Return(map[uuid.UUID]models.GuardianNotificationPreferences{
+						guardianID: {
+							EmailNotifications: true,
+							PushNotifications:  true,
+							Topics: map[models.NotificationTopic]map[models.NotificationType]bool{
+								models.NotificationTopicEventReminders: {models.NotificationTypeEmail: false},
+							},
+						},
+					}, nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown channel",
+			preferences: []models.NotificationPreference{
+				{Topic: models.NotificationTopicEventReminders, Channel: "sms", Enabled: false},
+			},
+			mockSetup: func(m *repomocks.MockGuardianRepository) {},
+			wantErr:   true,
+		},
+		{
+			name: "guardian not found",
+			preferences: []models.NotificationPreference{
+				{Topic: models.NotificationTopicMarketing, Channel: models.NotificationTypeEmail, Enabled: true},
+			},
+			mockSetup: func(m *repomocks.MockGuardianRepository) {
+				m.On("GetGuardianNotificationPreferences", mock.Anything, []uuid.UUID{guardianID}).
+					Return(map[uuid.UUID]models.GuardianNotificationPreferences{}, nil).Once()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(repomocks.MockGuardianRepository)
+			tt.mockSetup(mockRepo)
+
+			handler := NewHandler(mockRepo, nil, new(stripemocks.MockStripeClient), nil, config.Supabase{})
+
+			input := &models.UpdateNotificationPreferencesInput{ID: guardianID}
+			input.Body.Preferences = tt.preferences
+			prefs, err := handler.UpdateNotificationPreferences(context.Background(), input)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, prefs)
+			} else {
+				assert.NoError(t, err)
+				assert.Contains(t, prefs.Preferences, models.NotificationPreference{
+					Topic: models.NotificationTopicEventReminders, Channel: models.NotificationTypeEmail, Enabled: false,
+				})
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_UpdateQuietHours(t *testing.T) {
+	guardianID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+
+	tests := []struct {
+		name       string
+		quietHours models.QuietHours
+		mockSetup  func(*repomocks.MockGuardianRepository)
+		wantErr    bool
+	}{
+		{
+			name:       "saves the window",
+			quietHours: models.QuietHours{Timezone: "Europe/London", Enabled: true, Start: "21:30", End: "07:00"},
+			mockSetup: func(m *repomocks.MockGuardianRepository) {
+				m.On("UpdateGuardianQuietHours", mock.Anything, guardianID, mock.Anything).Return(nil).Once()
+				m.On("GetGuardianNotificationPreferences", mock.Anything, []uuid.UUID{guardianID}).
+					Return(map[uuid.UUID]models.GuardianNotificationPreferences{
+						guardianID: {QuietHours: models.QuietHours{Timezone: "Europe/London", Enabled: true, Start: "21:30", End: "07:00"}},
+					}, nil).Once()
+			},
+		},
+		{
+			name:       "turning quiet hours off keeps the window",
+			quietHours: models.QuietHours{Timezone: "Asia/Bangkok", Enabled: false, Start: "21:00", End: "21:00"},
+			mockSetup: func(m *repomocks.MockGuardianRepository) {
+				m.On("UpdateGuardianQuietHours", mock.Anything, guardianID, mock.Anything).Return(nil).Once()
+				m.On("GetGuardianNotificationPreferences", mock.Anything, []uuid.UUID{guardianID}).
+					Return(map[uuid.UUID]models.GuardianNotificationPreferences{guardianID: {}}, nil).Once()
+			},
+		},
+		{
+			name:       "unknown timezone",
+			quietHours: models.QuietHours{Timezone: "Nowhere/Special", Enabled: true, Start: "21:00", End: "07:00"},
+			mockSetup:  func(m *repomocks.MockGuardianRepository) {},
+			wantErr:    true,
+		},
+		{
+			name:       "guardian not found",
+			quietHours: models.QuietHours{Timezone: "Asia/Bangkok", Enabled: true, Start: "21:00", End: "07:00"},
+			mockSetup: func(m *repomocks.MockGuardianRepository) {
+				notFound := errs.NotFound("Guardian", "id", guardianID)
+				m.On("UpdateGuardianQuietHours", mock.Anything, guardianID, mock.Anything).Return(&notFound).Once()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(repomocks.MockGuardianRepository)
+			tt.mockSetup(mockRepo)
+
+			handler := NewHandler(mockRepo, nil, new(stripemocks.MockStripeClient), nil, config.Supabase{})
+
+			prefs, err := handler.UpdateQuietHours(context.Background(), &models.UpdateQuietHoursInput{ID: guardianID, Body: tt.quietHours})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, prefs)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, prefs)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package guardian
+
+import (
+	"context"
+	"slices"
+
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+)
+
+func (h *Handler) UpdateNotificationPreferences(ctx context.Context, input *models.UpdateNotificationPreferencesInput) (*models.NotificationPreferencesBody, error) {
+	for _, pref := range input.Body.Preferences {
+		if !slices.Contains(models.NotificationTopics, pref.Topic) {
+			errr := errs.BadRequest("Unknown notification topic: " + string(pref.Topic))
+			return nil, &errr
+		}
+		if !slices.Contains(models.NotificationChannels, pref.Channel) {
+			errr := errs.BadRequest("Unknown notification channel: " + string(pref.Channel))
+			return nil, &errr
+		}
+	}
+
+	// look the guardian up first so an unknown id is a 404 rather than a foreign key error
+	if _, err := h.notificationPreferences(ctx, input.ID); err != nil {
+		return nil, err
+	}
+
+	if err := h.GuardianRepository.UpdateGuardianNotificationPreferences(ctx, input.ID, input.Body.Preferences); err != nil {
+		return nil, err
+	}
+
+	return h.notificationPreferences(ctx, input.ID)
+}
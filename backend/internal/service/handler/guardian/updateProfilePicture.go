@@ -0,0 +1,52 @@
+package guardian
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"skillspark/internal/errs"
+	"skillspark/internal/imageproc"
+	"skillspark/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// UpdateProfilePicture validates and resizes an uploaded avatar, stores it with its
+// renditions and points the guardian at it
+func (h *Handler) UpdateProfilePicture(ctx context.Context, id uuid.UUID, imageData []byte) (*models.Guardian, error) {
+	guardian, err := h.GuardianRepository.GetGuardianByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	processed, err := imageproc.Process(imageData, imageproc.GuardianAvatar)
+	if err != nil {
+		if errors.Is(err, imageproc.ErrInvalidImage) {
+			return nil, errs.BadRequest("Invalid profile_picture: " + err.Error())
+		}
+		return nil, errs.InternalServerError("Failed to process profile picture: ", err.Error())
+	}
+
+	stored, err := imageproc.Store(ctx, h.s3Client, "guardians/profile-picture/"+id.String(), processed)
+	if err != nil {
+		return nil, errs.InternalServerError("Failed to upload profile picture: ", err.Error())
+	}
+
+	if err := h.GuardianRepository.UpdateGuardianProfilePicture(ctx, id, stored.Key); err != nil {
+		return nil, err
+	}
+
+	// the replaced picture is only removed once nothing points at it
+	if previous := guardian.ProfilePictureS3Key; previous != nil && *previous != stored.Key {
+		if err := imageproc.Delete(ctx, h.s3Client, imageproc.GuardianAvatar, *previous); err != nil {
+			log.Printf("Failed to delete replaced profile picture of guardian %s: %v", id, err)
+		}
+	}
+
+	guardian.ProfilePictureS3Key = &stored.Key
+	guardian.ProfilePictureURL = stored.URL
+	guardian.ProfilePictureRenditions = stored.Renditions
+
+	return guardian, nil
+}
@@ -0,0 +1,28 @@
+package guardian
+
+import (
+	"context"
+	"time"
+	// timezones are validated by name, and servers may not have a zoneinfo database
+	_ "time/tzdata"
+
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+)
+
+func (h *Handler) UpdateQuietHours(ctx context.Context, input *models.UpdateQuietHoursInput) (*models.NotificationPreferencesBody, error) {
+	if _, err := time.LoadLocation(input.Body.Timezone); err != nil || input.Body.Timezone == "" {
+		errr := errs.BadRequest("Unknown timezone: " + input.Body.Timezone)
+		return nil, &errr
+	}
+	if input.Body.Enabled && input.Body.Start == input.Body.End {
+		errr := errs.BadRequest("Quiet hours must start and end at different times")
+		return nil, &errr
+	}
+
+	if err := h.GuardianRepository.UpdateGuardianQuietHours(ctx, input.ID, &input.Body); err != nil {
+		return nil, err
+	}
+
+	return h.notificationPreferences(ctx, input.ID)
+}
@@ -0,0 +1,18 @@
+package inbox
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/utils"
+)
+
+func (h *Handler) GetInbox(ctx context.Context, input *models.GetInboxInput) (*models.GetInboxOutput, error) {
+	pagination := utils.Pagination{Page: input.Page, Limit: input.PageSize}
+
+	items, err := h.InboxRepository.GetInboxItemsByGuardianID(ctx, input.GuardianID, input.UnreadOnly, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GetInboxOutput{Body: items}, nil
+}
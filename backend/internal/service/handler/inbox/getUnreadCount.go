@@ -0,0 +1,17 @@
+package inbox
+
+import (
+	"context"
+	"skillspark/internal/models"
+)
+
+func (h *Handler) GetUnreadCount(ctx context.Context, input *models.GetInboxUnreadCountInput) (*models.GetInboxUnreadCountOutput, error) {
+	count, err := h.InboxRepository.GetUnreadInboxCount(ctx, input.GuardianID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &models.GetInboxUnreadCountOutput{}
+	out.Body.UnreadCount = count
+	return out, nil
+}
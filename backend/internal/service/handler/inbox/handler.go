@@ -0,0 +1,13 @@
+package inbox
+
+import "skillspark/internal/storage"
+
+type Handler struct {
+	InboxRepository storage.InboxRepository
+}
+
+func NewHandler(inboxRepo storage.InboxRepository) *Handler {
+	return &Handler{
+		InboxRepository: inboxRepo,
+	}
+}
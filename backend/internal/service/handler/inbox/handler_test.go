@@ -0,0 +1,213 @@
+package inbox
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"skillspark/internal/utils"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_GetInbox(t *testing.T) {
+	guardianID := uuid.New()
+
+	tests := []struct {
+		name      string
+		input     *models.GetInboxInput
+		mockSetup func(*repomocks.MockInboxRepository)
+		wantLen   int
+		wantErr   bool
+	}{
+		{
+			name:  "returns items",
+			input: &models.GetInboxInput{GuardianID: guardianID, Page: 1, PageSize: 20},
+			mockSetup: func(m *repomocks.MockInboxRepository) {
+				m.On("GetInboxItemsByGuardianID", mock.Anything, guardianID, false, utils.Pagination{Page: 1, Limit: 20}).
+					Return([]models.InboxItem{{ID: uuid.New()}, {ID: uuid.New()}}, nil)
+			},
+			wantLen: 2,
+		},
+		{
+			name:  "unread only",
+			input: &models.GetInboxInput{GuardianID: guardianID, UnreadOnly: true, Page: 2, PageSize: 5},
+			mockSetup: func(m *repomocks.MockInboxRepository) {
+				m.On("GetInboxItemsByGuardianID", mock.Anything, guardianID, true, utils.Pagination{Page: 2, Limit: 5}).
+					Return([]models.InboxItem{{ID: uuid.New()}}, nil)
+			},
+			wantLen: 1,
+		},
+		{
+			name:  "repository error",
+			input: &models.GetInboxInput{GuardianID: guardianID, Page: 1, PageSize: 20},
+			mockSetup: func(m *repomocks.MockInboxRepository) {
+				m.On("GetInboxItemsByGuardianID", mock.Anything, guardianID, false, mock.Anything).Return(nil, errors.New("db down"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(repomocks.MockInboxRepository)
+			tt.mockSetup(repo)
+
+			h := NewHandler(repo)
+			out, err := h.GetInbox(context.Background(), tt.input)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, out)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, out.Body, tt.wantLen)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetUnreadCount(t *testing.T) {
+	guardianID := uuid.New()
+
+	tests := []struct {
+		name      string
+		mockSetup func(*repomocks.MockInboxRepository)
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name: "returns count",
+			mockSetup: func(m *repomocks.MockInboxRepository) {
+				m.On("GetUnreadInboxCount", mock.Anything, guardianID).Return(3, nil)
+			},
+			wantCount: 3,
+		},
+		{
+			name: "repository error",
+			mockSetup: func(m *repomocks.MockInboxRepository) {
+				m.On("GetUnreadInboxCount", mock.Anything, guardianID).Return(0, errors.New("db down"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(repomocks.MockInboxRepository)
+			tt.mockSetup(repo)
+
+			h := NewHandler(repo)
+			out, err := h.GetUnreadCount(context.Background(), &models.GetInboxUnreadCountInput{GuardianID: guardianID})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, out)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantCount, out.Body.UnreadCount)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_MarkRead(t *testing.T) {
+	guardianID := uuid.New()
+	itemID := uuid.New()
+	readAt := time.Now()
+
+	tests := []struct {
+		name      string
+		mockSetup func(*repomocks.MockInboxRepository)
+		wantErr   bool
+	}{
+		{
+			name: "marks item read",
+			mockSetup: func(m *repomocks.MockInboxRepository) {
+				m.On("MarkInboxItemRead", mock.Anything, guardianID, itemID).
+					Return(&models.InboxItem{ID: itemID, GuardianID: guardianID, ReadAt: &readAt}, nil)
+			},
+		},
+		{
+			name: "not found",
+			mockSetup: func(m *repomocks.MockInboxRepository) {
+				notFound := errs.NotFound("InboxItem", "id", itemID)
+				m.On("MarkInboxItemRead", mock.Anything, guardianID, itemID).Return(nil, &notFound)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(repomocks.MockInboxRepository)
+			tt.mockSetup(repo)
+
+			h := NewHandler(repo)
+			out, err := h.MarkRead(context.Background(), &models.MarkInboxItemReadInput{GuardianID: guardianID, ID: itemID})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, out)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, itemID, out.Body.ID)
+				assert.NotNil(t, out.Body.ReadAt)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_MarkAllRead(t *testing.T) {
+	guardianID := uuid.New()
+
+	tests := []struct {
+		name        string
+		mockSetup   func(*repomocks.MockInboxRepository)
+		wantUpdated int
+		wantErr     bool
+	}{
+		{
+			name: "marks all read",
+			mockSetup: func(m *repomocks.MockInboxRepository) {
+				m.On("MarkAllInboxItemsRead", mock.Anything, guardianID).Return(4, nil)
+			},
+			wantUpdated: 4,
+		},
+		{
+			name: "repository error",
+			mockSetup: func(m *repomocks.MockInboxRepository) {
+				m.On("MarkAllInboxItemsRead", mock.Anything, guardianID).Return(0, errors.New("db down"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(repomocks.MockInboxRepository)
+			tt.mockSetup(repo)
+
+			h := NewHandler(repo)
+			out, err := h.MarkAllRead(context.Background(), &models.MarkAllInboxItemsReadInput{GuardianID: guardianID})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, out)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantUpdated, out.Body.Updated)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
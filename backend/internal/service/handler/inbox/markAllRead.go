@@ -0,0 +1,17 @@
+package inbox
+
+import (
+	"context"
+	"skillspark/internal/models"
+)
+
+func (h *Handler) MarkAllRead(ctx context.Context, input *models.MarkAllInboxItemsReadInput) (*models.MarkAllInboxItemsReadOutput, error) {
+	updated, err := h.InboxRepository.MarkAllInboxItemsRead(ctx, input.GuardianID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &models.MarkAllInboxItemsReadOutput{}
+	out.Body.Updated = updated
+	return out, nil
+}
@@ -0,0 +1,15 @@
+package inbox
+
+import (
+	"context"
+	"skillspark/internal/models"
+)
+
+func (h *Handler) MarkRead(ctx context.Context, input *models.MarkInboxItemReadInput) (*models.MarkInboxItemReadOutput, error) {
+	item, err := h.InboxRepository.MarkInboxItemRead(ctx, input.GuardianID, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MarkInboxItemReadOutput{Body: *item}, nil
+}
@@ -0,0 +1,18 @@
+package job
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/utils"
+)
+
+func (h *Handler) GetAllJobRuns(ctx context.Context, input *models.GetAllJobRunsInput) (*models.GetAllJobRunsOutput, error) {
+	pagination := utils.Pagination{Page: input.Page, Limit: input.PageSize}
+
+	runs, err := h.JobRunRepository.GetAllJobRuns(ctx, input.JobName, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GetAllJobRunsOutput{Body: runs}, nil
+}
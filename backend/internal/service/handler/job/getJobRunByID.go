@@ -0,0 +1,15 @@
+package job
+
+import (
+	"context"
+	"skillspark/internal/models"
+)
+
+func (h *Handler) GetJobRunByID(ctx context.Context, input *models.GetJobRunByIDInput) (*models.GetJobRunByIDOutput, error) {
+	run, err := h.JobRunRepository.GetJobRunByID(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GetJobRunByIDOutput{Body: *run}, nil
+}
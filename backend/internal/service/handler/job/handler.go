@@ -0,0 +1,24 @@
+package job
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage"
+)
+
+// JobRunner starts background jobs on demand; implemented by jobs.JobScheduler
+type JobRunner interface {
+	Trigger(ctx context.Context, name string, dryRun bool) (*models.JobRun, error)
+}
+
+type Handler struct {
+	JobRunRepository storage.JobRunRepository
+	JobRunner        JobRunner
+}
+
+func NewHandler(jobRunRepo storage.JobRunRepository, runner JobRunner) *Handler {
+	return &Handler{
+		JobRunRepository: jobRunRepo,
+		JobRunner:        runner,
+	}
+}
@@ -0,0 +1,186 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"skillspark/internal/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockJobRunner struct {
+	mock.Mock
+}
+
+func (m *mockJobRunner) Trigger(ctx context.Context, name string, dryRun bool) (*models.JobRun, error) {
+	args := m.Called(ctx, name, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.JobRun), args.Error(1)
+}
+
+func TestHandler_GetAllJobRuns(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     *models.GetAllJobRunsInput
+		mockSetup func(*repomocks.MockJobRunRepository)
+		wantLen   int
+		wantErr   bool
+	}{
+		{
+			name:  "returns runs",
+			input: &models.GetAllJobRunsInput{Page: 1, PageSize: 10},
+			mockSetup: func(m *repomocks.MockJobRunRepository) {
+				m.On("GetAllJobRuns", mock.Anything, "", utils.Pagination{Page: 1, Limit: 10}).
+					Return([]models.JobRun{{ID: uuid.New(), JobName: "capture_payments"}, {ID: uuid.New(), JobName: "create_payment_intents"}}, nil)
+			},
+			wantLen: 2,
+		},
+		{
+			name:  "filters by job name",
+			input: &models.GetAllJobRunsInput{JobName: "capture_payments", Page: 2, PageSize: 5},
+			mockSetup: func(m *repomocks.MockJobRunRepository) {
+				m.On("GetAllJobRuns", mock.Anything, "capture_payments", utils.Pagination{Page: 2, Limit: 5}).
+					Return([]models.JobRun{{ID: uuid.New(), JobName: "capture_payments"}}, nil)
+			},
+			wantLen: 1,
+		},
+		{
+			name:  "repository error",
+			input: &models.GetAllJobRunsInput{Page: 1, PageSize: 10},
+			mockSetup: func(m *repomocks.MockJobRunRepository) {
+				m.On("GetAllJobRuns", mock.Anything, "", mock.Anything).Return(nil, errors.New("db down"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(repomocks.MockJobRunRepository)
+			tt.mockSetup(repo)
+
+			h := NewHandler(repo, nil)
+			out, err := h.GetAllJobRuns(context.Background(), tt.input)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, out)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, out.Body, tt.wantLen)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetJobRunByID(t *testing.T) {
+	runID := uuid.New()
+
+	tests := []struct {
+		name      string
+		mockSetup func(*repomocks.MockJobRunRepository)
+		wantErr   bool
+	}{
+		{
+			name: "found",
+			mockSetup: func(m *repomocks.MockJobRunRepository) {
+				m.On("GetJobRunByID", mock.Anything, runID).Return(&models.JobRun{ID: runID, Status: models.JobRunStatusSucceeded}, nil)
+			},
+		},
+		{
+			name: "not found",
+			mockSetup: func(m *repomocks.MockJobRunRepository) {
+				notFound := errs.NotFound("JobRun", "id", runID)
+				m.On("GetJobRunByID", mock.Anything, runID).Return(nil, &notFound)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(repomocks.MockJobRunRepository)
+			tt.mockSetup(repo)
+
+			h := NewHandler(repo, nil)
+			out, err := h.GetJobRunByID(context.Background(), &models.GetJobRunByIDInput{ID: runID})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, out)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, runID, out.Body.ID)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_TriggerJob(t *testing.T) {
+	runID := uuid.New()
+
+	tests := []struct {
+		name      string
+		dryRun    bool
+		mockSetup func(*mockJobRunner)
+		wantErr   bool
+	}{
+		{
+			name: "starts run",
+			mockSetup: func(m *mockJobRunner) {
+				m.On("Trigger", mock.Anything, "capture_payments", false).
+					Return(&models.JobRun{ID: runID, JobName: "capture_payments", Status: models.JobRunStatusRunning}, nil)
+			},
+		},
+		{
+			name:   "starts dry run",
+			dryRun: true,
+			mockSetup: func(m *mockJobRunner) {
+				m.On("Trigger", mock.Anything, "capture_payments", true).
+					Return(&models.JobRun{ID: runID, JobName: "capture_payments", DryRun: true, Status: models.JobRunStatusRunning}, nil)
+			},
+		},
+		{
+			name: "already running",
+			mockSetup: func(m *mockJobRunner) {
+				conflict := errs.Conflict("Job is already running", "name", "capture_payments")
+				m.On("Trigger", mock.Anything, "capture_payments", false).Return(nil, &conflict)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := new(mockJobRunner)
+			tt.mockSetup(runner)
+
+			h := NewHandler(nil, runner)
+			input := &models.TriggerJobInput{JobName: "capture_payments"}
+			input.Body.DryRun = tt.dryRun
+
+			out, err := h.TriggerJob(context.Background(), input)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, out)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, runID, out.Body.ID)
+				assert.Equal(t, tt.dryRun, out.Body.DryRun)
+			}
+			runner.AssertExpectations(t)
+		})
+	}
+}
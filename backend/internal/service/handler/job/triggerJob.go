@@ -0,0 +1,17 @@
+package job
+
+import (
+	"context"
+	"skillspark/internal/models"
+)
+
+// TriggerJob starts the job in the background and returns the run as it was recorded at
+// start; poll GetJobRunByID for the outcome.
+func (h *Handler) TriggerJob(ctx context.Context, input *models.TriggerJobInput) (*models.TriggerJobOutput, error) {
+	run, err := h.JobRunner.Trigger(ctx, input.JobName, input.Body.DryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TriggerJobOutput{Body: *run}, nil
+}
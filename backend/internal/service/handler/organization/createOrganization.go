@@ -3,6 +3,7 @@ package organization
 import (
 	"context"
 	"skillspark/internal/errs"
+	"skillspark/internal/imageproc"
 	"skillspark/internal/models"
 	"skillspark/internal/s3_client"
 )
@@ -15,6 +16,11 @@ func (h *Handler) CreateOrganization(ctx context.Context, input *models.CreateOr
 		return nil, errs.BadRequest("Invalid location_id: location does not exist")
 	}
 
+	image, err := processProfileImage(image_data)
+	if err != nil {
+		return nil, err
+	}
+
 	aboutEN, aboutTH, err := h.translateAbout(ctx, input.Body.About, input.AcceptLanguage)
 	if err != nil {
 		return nil, errs.InternalServerError("Translation failed: ", err.Error())
@@ -23,33 +29,36 @@ func (h *Handler) CreateOrganization(ctx context.Context, input *models.CreateOr
 	dbInput := buildCreateOrgDBInput(input, aboutEN, aboutTH)
 
 	var key *string
-	var url *string
 
 	organization, err := h.OrganizationRepository.CreateOrganization(ctx, dbInput, key)
 	if err != nil {
 		return nil, err
 	}
 
-	if image_data != nil {
-		url, err = h.CreateOrgS3Helper(ctx, s3Client, organization, updateBody, image_data, input.AcceptLanguage)
+	if image != nil {
+		stored, err := h.CreateOrgS3Helper(ctx, s3Client, organization, updateBody, image, input.AcceptLanguage)
 		if err != nil {
 			return nil, err
 		}
+		organization.PresignedURL = stored.URL
+		organization.PfpRenditions = stored.Renditions
 	}
 
-	organization.PresignedURL = url
-
 	return organization, nil
 }
 
 func (h *Handler) CreateOrgS3Helper(ctx context.Context, s3Client s3_client.S3Interface, organization *models.Organization,
-	updateBody *models.UpdateOrganizationBody, image_data *[]byte, acceptLanguage string) (*string, error) {
+	updateBody *models.UpdateOrganizationBody, image *imageproc.Processed, acceptLanguage string) (*imageproc.Stored, error) {
 
-	key, err := h.generateS3Key(organization.ID)
+	base, err := h.generateS3Key(organization.ID)
 	if err != nil {
 		return nil, err
 	}
-	url, errr := s3Client.UploadImage(ctx, key, *image_data)
+	stored, errr := imageproc.Store(ctx, s3Client, *base, image)
+	if errr != nil {
+		return nil, errr
+	}
+	key := &stored.Key
 
 	dbUpdate := &models.UpdateOrganizationDBInput{
 		AcceptLanguage: acceptLanguage,
@@ -63,12 +72,9 @@ func (h *Handler) CreateOrgS3Helper(ctx context.Context, s3Client s3_client.S3In
 	}
 	updateKeyValue, err := h.OrganizationRepository.UpdateOrganization(ctx, dbUpdate, key)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	organization.PfpS3Key = updateKeyValue.PfpS3Key
-	if errr != nil {
-		return nil, errr
-	}
 
-	return url, nil
+	return stored, nil
 }
@@ -2,6 +2,7 @@ package organization
 
 import (
 	"context"
+	"skillspark/internal/imageproc"
 	"skillspark/internal/models"
 	"skillspark/internal/s3_client"
 	"skillspark/internal/utils"
@@ -48,6 +49,11 @@ func GetAllS3Helper(ctx context.Context, s3Client s3_client.S3Interface, idx int
 		}
 		organizations[idx].PresignedURL = &url
 
+		organizations[idx].PfpRenditions, httpErr = imageproc.PresignRenditions(ctx, s3Client, imageproc.OrganizationPicture, *key, time.Hour)
+		if httpErr != nil {
+			return nil, httpErr
+		}
+
 	} else {
 		organizations[idx].PresignedURL = nil
 	}
@@ -3,6 +3,7 @@ package organization
 import (
 	"context"
 	"skillspark/internal/errs"
+	"skillspark/internal/imageproc"
 	"skillspark/internal/models"
 	"skillspark/internal/s3_client"
 	"time"
@@ -54,6 +55,11 @@ func GetOrgS3Helper(ctx context.Context, s3Client s3_client.S3Interface, organiz
 		}
 
 		url = &presignedURL
+
+		organization.PfpRenditions, err = imageproc.PresignRenditions(ctx, s3Client, imageproc.OrganizationPicture, *key, time.Hour)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	organization.PresignedURL = url
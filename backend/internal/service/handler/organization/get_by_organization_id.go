@@ -3,6 +3,7 @@ package organization
 import (
 	"context"
 	"skillspark/internal/errs"
+	"skillspark/internal/imageproc"
 	"skillspark/internal/models"
 	"time"
 
@@ -29,6 +30,11 @@ func (h *Handler) GetEventOccurrencesByOrganizationID(ctx context.Context, input
 				return nil, errr
 			}
 			eventOccurrences[i].Event.PresignedURL = &url
+
+			eventOccurrences[i].Event.HeaderImageRenditions, errr = imageproc.PresignRenditions(ctx, h.s3client, imageproc.EventHeader, *key, time.Hour)
+			if errr != nil {
+				return nil, errr
+			}
 		}
 	}
 
@@ -1,7 +1,10 @@
 package organization
 
 import (
+	"bytes"
 	"context"
+	"image"
+	"image/png"
 	"net/http/httptest"
 	"net/url"
 	"skillspark/internal/errs"
@@ -133,14 +136,16 @@ func TestHandler_GetOrganizationById(t *testing.T) {
 	}
 }
 
+// createDummyImageData is a valid PNG large enough to pass the profile image limits
+func createDummyImageData() *[]byte {
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 320, 240)))
+	data := buf.Bytes()
+	return &data
+}
+
 func TestHandler_CreateOrganization(t *testing.T) {
-	// Dummy image data (PNG header bytes)
-	dummyImageData := []byte{
-		0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A,
-		0x00, 0x00, 0x00, 0x0D, 0x49, 0x48, 0x44, 0x52,
-		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01,
-		0x08, 0x02, 0x00, 0x00, 0x00, 0x90, 0x77, 0x53,
-	}
+	dummyImageData := createDummyImageData()
 	pfpKey := "orgs/a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11/pfp.jpg"
 
 	tests := []struct {
@@ -191,7 +196,7 @@ func TestHandler_CreateOrganization(t *testing.T) {
 				},
 			},
 			updateBody: &models.UpdateOrganizationBody{},
-			imageData:  &dummyImageData,
+			imageData:  dummyImageData,
 			mockSetup: func(orgRepo *repomocks.MockOrganizationRepository, locRepo *repomocks.MockLocationRepository) {
 				orgID := uuid.MustParse("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
 				locRepo.On("GetLocationByID", mock.Anything, testLocationID).Return(&models.Location{
@@ -217,7 +222,10 @@ func TestHandler_CreateOrganization(t *testing.T) {
 			},
 			mockS3Setup: func(m *s3mocks.S3ClientMock) {
 				mockURL := "https://test-bucket.s3.amazonaws.com/orgs/test/pfp.jpg?X-Amz-Signature=abc123"
-				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything).Return(&mockURL, nil)
+				// the original and a JPEG and WebP copy of each rendition
+				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything, "image/png").Return(&mockURL, nil).Once()
+				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything, "image/jpeg").Return(&mockURL, nil).Times(3)
+				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything, "image/webp").Return(&mockURL, nil).Times(3)
 			},
 			wantErr: false,
 			wantURL: true,
@@ -274,6 +282,27 @@ func TestHandler_CreateOrganization(t *testing.T) {
 			wantErr: true,
 			wantURL: false,
 		},
+		{
+			name: "image that is not a picture is rejected before anything is saved",
+			input: &models.CreateOrganizationInput{
+				Body: models.CreateOrganizationBody{
+					Name:       "Tech Corp",
+					LocationID: &testLocationID,
+				},
+			},
+			updateBody: &models.UpdateOrganizationBody{},
+			imageData:  func() *[]byte { b := []byte("<svg onload=alert(1)>"); return &b }(),
+			mockSetup: func(orgRepo *repomocks.MockOrganizationRepository, locRepo *repomocks.MockLocationRepository) {
+				locRepo.On("GetLocationByID", mock.Anything, testLocationID).Return(&models.Location{
+					ID: testLocationID,
+				}, nil)
+			},
+			mockS3Setup: func(m *s3mocks.S3ClientMock) {
+				// No S3 calls expected on error
+			},
+			wantErr: true,
+			wantURL: false,
+		},
 		{
 			name: "database error on create",
 			input: &models.CreateOrganizationInput{
@@ -346,14 +375,7 @@ func TestHandler_UpdateOrganization(t *testing.T) {
 	activeFalse := false
 	pfpKey := "orgs/a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11/pfp.jpg"
 
-	// Dummy image data (PNG header)
-	dummyImageData := []byte{
-		0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A,
-		0x00, 0x00, 0x00, 0x0D, 0x49, 0x48, 0x44, 0x52,
-		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01,
-		0x08, 0x02, 0x00, 0x00, 0x00, 0x90, 0x77, 0x53,
-		0xDE, 0x00, 0x00, 0x00, 0x0C, 0x49, 0x44, 0x41,
-	}
+	dummyImageData := createDummyImageData()
 
 	tests := []struct {
 		name        string
@@ -423,8 +445,10 @@ func TestHandler_UpdateOrganization(t *testing.T) {
 					Name: &newName,
 				},
 			},
-			imageData: &dummyImageData,
+			imageData: dummyImageData,
 			mockSetup: func(orgRepo *repomocks.MockOrganizationRepository, locRepo *repomocks.MockLocationRepository) {
+				// no picture before, so nothing is deleted
+				orgRepo.On("GetOrganizationByID", mock.Anything, existingID, mock.Anything).Return(&models.Organization{ID: existingID}, nil)
 				orgRepo.On("UpdateOrganization", mock.Anything, mock.AnythingOfType("*models.UpdateOrganizationDBInput"), mock.Anything).Return(&models.Organization{
 					ID:         existingID,
 					Name:       "Updated Name",
@@ -437,21 +461,25 @@ func TestHandler_UpdateOrganization(t *testing.T) {
 			},
 			mockS3Setup: func(m *s3mocks.S3ClientMock) {
 				mockURL := "https://test-bucket.s3.amazonaws.com/orgs/test/pfp.jpg?X-Amz-Signature=abc123"
-				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything).Return(&mockURL, nil)
+				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&mockURL, nil)
 			},
 			wantErr: false,
 			wantURL: true,
 		},
 		{
-			name: "successful update with image - existing key reused",
+			name: "successful update with image - previous picture deleted",
 			input: &models.UpdateOrganizationInput{
 				ID: existingID,
 				Body: models.UpdateOrganizationBody{
 					Name: &newName,
 				},
 			},
-			imageData: &dummyImageData,
+			imageData: dummyImageData,
 			mockSetup: func(orgRepo *repomocks.MockOrganizationRepository, locRepo *repomocks.MockLocationRepository) {
+				orgRepo.On("GetOrganizationByID", mock.Anything, existingID, mock.Anything).Return(&models.Organization{
+					ID:       existingID,
+					PfpS3Key: &pfpKey,
+				}, nil)
 				orgRepo.On("UpdateOrganization", mock.Anything, mock.AnythingOfType("*models.UpdateOrganizationDBInput"), mock.Anything).Return(&models.Organization{
 					ID:         existingID,
 					Name:       "Updated Name",
@@ -464,7 +492,9 @@ func TestHandler_UpdateOrganization(t *testing.T) {
 			},
 			mockS3Setup: func(m *s3mocks.S3ClientMock) {
 				mockURL := "https://test-bucket.s3.amazonaws.com/orgs/test/pfp.jpg?X-Amz-Signature=abc123"
-				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything).Return(&mockURL, nil)
+				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&mockURL, nil)
+				// uploaded before renditions existed, so it is the only object to delete
+				m.On("DeleteObject", mock.Anything, pfpKey).Return(nil).Once()
 			},
 			wantErr: false,
 			wantURL: true,
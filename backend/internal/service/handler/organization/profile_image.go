@@ -0,0 +1,24 @@
+package organization
+
+import (
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/imageproc"
+)
+
+// processProfileImage validates an uploaded profile image before anything is saved. It
+// returns nil when no image was sent.
+func processProfileImage(imageData *[]byte) (*imageproc.Processed, error) {
+	if imageData == nil || len(*imageData) == 0 {
+		return nil, nil
+	}
+
+	processed, err := imageproc.Process(*imageData, imageproc.OrganizationPicture)
+	if err != nil {
+		if errors.Is(err, imageproc.ErrInvalidImage) {
+			return nil, errs.BadRequest("Invalid profile_image: " + err.Error())
+		}
+		return nil, errs.InternalServerError("Failed to process profile image: ", err.Error())
+	}
+	return processed, nil
+}
@@ -2,7 +2,9 @@ package organization
 
 import (
 	"context"
+	"log"
 	"skillspark/internal/errs"
+	"skillspark/internal/imageproc"
 	"skillspark/internal/models"
 	"skillspark/internal/s3_client"
 )
@@ -14,6 +16,11 @@ func (h *Handler) UpdateOrganization(ctx context.Context, input *models.UpdateOr
 		}
 	}
 
+	image, err := processProfileImage(image_data)
+	if err != nil {
+		return nil, err
+	}
+
 	aboutEN, aboutTH, err := h.translateAbout(ctx, input.Body.About, input.AcceptLanguage)
 	if err != nil {
 		return nil, errs.InternalServerError("Translation failed: ", err.Error())
@@ -22,13 +29,21 @@ func (h *Handler) UpdateOrganization(ctx context.Context, input *models.UpdateOr
 	dbInput := buildUpdateOrgDBInput(input, aboutEN, aboutTH)
 
 	var key *string
-	var url *string
+	var stored *imageproc.Stored
+
+	var previousKey *string
+	if image != nil {
+		previous, err := h.OrganizationRepository.GetOrganizationByID(ctx, input.ID, input.AcceptLanguage)
+		if err != nil {
+			return nil, err
+		}
+		previousKey = previous.PfpS3Key
 
-	if image_data != nil {
-		url, key, err = h.UpdateOrgS3Helper(ctx, s3Client, input, image_data)
+		stored, err = h.UpdateOrgS3Helper(ctx, s3Client, input, image)
 		if err != nil {
 			return nil, err
 		}
+		key = &stored.Key
 	}
 
 	organization, updateErr := h.OrganizationRepository.UpdateOrganization(ctx, dbInput, key)
@@ -36,20 +51,30 @@ func (h *Handler) UpdateOrganization(ctx context.Context, input *models.UpdateOr
 		return nil, updateErr
 	}
 
-	organization.PresignedURL = url
+	if stored != nil {
+		organization.PresignedURL = stored.URL
+		organization.PfpRenditions = stored.Renditions
+
+		// the replaced picture is only removed once nothing points at it
+		if previousKey != nil && *previousKey != stored.Key {
+			if err := imageproc.Delete(ctx, s3Client, imageproc.OrganizationPicture, *previousKey); err != nil {
+				log.Printf("Failed to delete replaced picture of organization %s: %v", input.ID, err)
+			}
+		}
+	}
 
 	return organization, nil
 }
 
-func (h *Handler) UpdateOrgS3Helper(ctx context.Context, s3Client s3_client.S3Interface, input *models.UpdateOrganizationInput, image_data *[]byte) (*string, *string, error) {
+func (h *Handler) UpdateOrgS3Helper(ctx context.Context, s3Client s3_client.S3Interface, input *models.UpdateOrganizationInput, image *imageproc.Processed) (*imageproc.Stored, error) {
 	key, genErr := h.generateS3Key(input.ID)
 	if genErr != nil {
-		return nil, nil, genErr
+		return nil, genErr
 	}
-	url, errr := s3Client.UploadImage(ctx, key, *image_data)
+	stored, errr := imageproc.Store(ctx, s3Client, *key, image)
 	if errr != nil {
-		return nil, nil, errr
+		return nil, errr
 	}
 
-	return url, key, nil
+	return stored, nil
 }
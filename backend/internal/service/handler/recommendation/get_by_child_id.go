@@ -2,7 +2,9 @@ package recommendation
 
 import (
 	"context"
+	"skillspark/internal/imageproc"
 	"skillspark/internal/models"
+	"skillspark/internal/recommend"
 	"skillspark/internal/s3_client"
 	"skillspark/internal/utils"
 	"time"
@@ -10,38 +12,54 @@ import (
 	"github.com/google/uuid"
 )
 
-func (h *Handler) GetRecommendationsByChildID(ctx context.Context, childID uuid.UUID, acceptLanguage string, pagination utils.Pagination, filters models.RecommendationFilters) ([]models.Event, error) {
+// candidatePool is how many of the best candidates are ranked, so every page is cut from
+// the same ranking; pages past it are empty
+const candidatePool = 200
+
+func (h *Handler) GetRecommendationsByChildID(ctx context.Context, childID uuid.UUID, acceptLanguage string, pagination utils.Pagination, filters models.RecommendationFilters) ([]models.Recommendation, error) {
 	child, err := h.ChildRepository.GetChildByID(ctx, childID)
 	if err != nil {
 		return nil, err
 	}
 
-	output, err := h.RecommendationRepository.GetRecommendationsByChildID(ctx, child.Interests, child.BirthYear, acceptLanguage, pagination, filters)
+	candidates, err := h.RecommendationRepository.GetRecommendationCandidates(ctx, child.ID, child.Interests, child.BirthYear, acceptLanguage, candidatePool, filters)
 	if err != nil {
 		return nil, err
 	}
 
-	for idx := range output {
-		if err := AssignURL(ctx, &output[idx], h.S3Client); err != nil {
-			return nil, err
-		}
+	ranked := recommend.Rank(candidates, acceptLanguage)
+	start := min(pagination.GetOffset(), len(ranked))
+	end := min(start+pagination.Limit, len(ranked))
+	output := ranked[start:end]
+
+	if err := AssignURLs(ctx, output, h.S3Client); err != nil {
+		return nil, err
 	}
 
 	return output, nil
 }
 
-func AssignURL(ctx context.Context, event *models.Event, s3Client s3_client.S3Interface) error {
-
-	key := event.HeaderImageS3Key
-	if key != nil {
-		url, err := s3Client.GeneratePresignedURL(ctx, *key, time.Hour)
-		if err != nil {
-			return err
+// AssignURLs presigns the header images of a page of recommendations in one batch
+func AssignURLs(ctx context.Context, recommendations []models.Recommendation, s3Client s3_client.S3Interface) error {
+	var keys []string
+	for idx := range recommendations {
+		if key := recommendations[idx].HeaderImageS3Key; key != nil {
+			keys = append(keys, *key)
 		}
+	}
 
-		event.PresignedURL = &url
+	images, err := imageproc.PresignImages(ctx, s3Client, imageproc.EventHeader, keys, time.Hour)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	for idx := range recommendations {
+		if key := recommendations[idx].HeaderImageS3Key; key != nil {
+			image := images[*key]
+			recommendations[idx].PresignedURL = &image.URL
+			recommendations[idx].HeaderImageRenditions = image.Renditions
+		}
+	}
 
+	return nil
 }
@@ -23,6 +23,11 @@ func (h *Handler) CancelRegistration(ctx context.Context, input *models.CancelRe
 	}
 
 	var refundStatus string
+	// a cancellation within a day of the start keeps the whole payment; any other gives
+	// back the wallet credit applied to it, plus refundCredit when the card charge is
+	// refunded to the wallet
+	keepPayment := false
+	refundCredit := 0
 	switch registration.Body.PaymentIntentStatus {
 	case "succeeded":
 		eventoccurrence, err := h.EventOccurrenceRepository.GetEventOccurrenceByID(ctx, registration.Body.EventOccurrenceID, "en-US")
@@ -31,15 +36,21 @@ func (h *Handler) CancelRegistration(ctx context.Context, input *models.CancelRe
 		}
 		if eventoccurrence.StartTime.Before(time.Now().AddDate(0, 0, 1)) {
 			refundStatus = "no_refund_needed"
-		} else {
-			refundInput := &models.RefundPaymentInput{
-				PaymentIntentID: registration.Body.StripePaymentIntentID,
+			keepPayment = true
+		} else if registration.Body.StripePaymentIntentID == "" {
+			// paid entirely with credit, which the cancellation restores to the wallet
+			refundStatus = "credited"
+		} else if input.RefundTo == models.RefundDestinationCredit {
+			refundCredit, err = h.refundToWallet(ctx, &registration.Body)
+			if err != nil {
+				return nil, err
 			}
-			refundOutput, err := h.StripeClient.RefundPayment(ctx, refundInput)
+			refundStatus = "credited"
+		} else {
+			refundStatus, err = h.refundToCard(ctx, &registration.Body)
 			if err != nil {
-				return nil, errs.InternalServerError("Failed to refund payment: ", err.Error())
+				return nil, err
 			}
-			refundStatus = refundOutput.Body.Status
 		}
 
 	case "requires_capture":
@@ -53,10 +64,23 @@ func (h *Handler) CancelRegistration(ctx context.Context, input *models.CancelRe
 		}
 		refundStatus = "cancelled"
 	default:
+		// nothing was charged yet, though credit may already be applied to the payment
 		refundStatus = "no_refund_needed"
 	}
 
-	cancelledRegistration, err := h.RegistrationRepository.CancelRegistration(ctx, input)
+	if !keepPayment {
+		if err := h.reverseCreditTransfer(ctx, &registration.Body); err != nil {
+			return nil, err
+		}
+	}
+
+	// unsent reminders are removed by the outbox message recorded with the cancellation
+	var cancelledRegistration *models.CancelRegistrationOutput
+	if keepPayment {
+		cancelledRegistration, err = h.RegistrationRepository.CancelRegistration(ctx, input)
+	} else {
+		cancelledRegistration, err = h.WalletRepository.CancelRegistrationWithCredit(ctx, input, refundCredit)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -66,3 +90,57 @@ func (h *Handler) CancelRegistration(ctx context.Context, input *models.CancelRe
 
 	return cancelledRegistration, nil
 }
+
+// refundToCard refunds the card charge through Stripe. Any wallet credit that was
+// applied to the payment can't go back to the card, so the cancellation restores it
+// to the wallet.
+func (h *Handler) refundToCard(ctx context.Context, registration *models.Registration) (string, error) {
+	refundInput := &models.RefundPaymentInput{
+		PaymentIntentID: registration.StripePaymentIntentID,
+	}
+	refundOutput, err := h.StripeClient.RefundPayment(ctx, refundInput)
+	if err != nil {
+		return "", errs.InternalServerError("Failed to refund payment: ", err.Error())
+	}
+
+	return refundOutput.Body.Status, nil
+}
+
+// refundToWallet keeps the card charge and returns how much of it to credit to the
+// guardian's wallet. The organization's share was transferred when the charge was made,
+// so it is reversed first: the platform then holds the whole charge the credit is
+// paid from, and the organization is paid again when the credit is spent.
+func (h *Handler) refundToWallet(ctx context.Context, registration *models.Registration) (int, error) {
+	if registration.TotalAmount == 0 {
+		return 0, nil
+	}
+
+	_, err := h.StripeClient.ReverseTransfer(ctx, &models.ReverseTransferInput{
+		PaymentIntentID: registration.StripePaymentIntentID,
+		// a retry after the cancellation failed gets the original reversal back
+		IdempotencyKey: "charge_transfer_reversal:" + registration.ID.String(),
+	})
+	if err != nil {
+		return 0, errs.InternalServerError("Failed to reverse transfer: ", err.Error())
+	}
+
+	return registration.TotalAmount, nil
+}
+
+// reverseCreditTransfer takes back the organization's share of the wallet credit applied to
+// a registration, since the cancellation gives the whole credit back to the guardian.
+func (h *Handler) reverseCreditTransfer(ctx context.Context, registration *models.Registration) error {
+	if registration.StripeTransferID == "" {
+		return nil
+	}
+
+	_, err := h.StripeClient.ReverseTransfer(ctx, &models.ReverseTransferInput{
+		TransferID:     registration.StripeTransferID,
+		IdempotencyKey: "transfer_reversal:" + registration.ID.String(),
+	})
+	if err != nil {
+		return errs.InternalServerError("Failed to reverse transfer: ", err.Error())
+	}
+
+	return nil
+}
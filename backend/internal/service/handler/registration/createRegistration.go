@@ -3,8 +3,6 @@ package registration
 import (
 	"context"
 	"errors"
-	"fmt"
-	"log/slog"
 	"skillspark/internal/models"
 	"time"
 )
@@ -48,27 +46,12 @@ func (h *Handler) CreateRegistration(ctx context.Context, input *models.CreateRe
 		Status:            input.Body.Status,
 	}
 
+	// the confirmation and reminders are recorded in the outbox with the registration and
+	// sent by the worker's relay
 	registration, err := h.RegistrationRepository.CreateRegistration(ctx, regData)
 	if err != nil {
 		return nil, err
 	}
 
-	if h.NotificationService != nil && guardian.EmailNotifications {
-		subject := "Registration Confirmed"
-		body := fmt.Sprintf(
-			"Your child has been successfully registered for %s on %s.",
-			registration.Body.EventName,
-			registration.Body.OccurrenceStartTime.Format("January 2, 2006 at 3:04 PM"),
-		)
-		if notifErr := h.NotificationService.SendNotification(ctx, &models.SendNotificationInput{
-			NotificationType: models.NotificationTypeEmail,
-			RecipientEmail:   &guardian.Email,
-			Subject:          &subject,
-			Body:             body,
-		}); notifErr != nil {
-			slog.Error("failed to send registration confirmation notification", "error", notifErr)
-		}
-	}
-
 	return registration, nil
 }
@@ -12,13 +12,15 @@ type Handler struct {
 	GuardianRepository        storage.GuardianRepository
 	ChildRepository           storage.ChildRepository
 	OrganizationRepository    storage.OrganizationRepository
+	WalletRepository          storage.WalletRepository
 	StripeClient              stripeClient.StripeClientInterface
 	NotificationService       notification.NotificationServiceInterface
 }
 
 func NewHandler(registrationRepo storage.RegistrationRepository, childRepo storage.ChildRepository,
 	guardianRepo storage.GuardianRepository, eventOccurrenceRepo storage.EventOccurrenceRepository,
-	organizationRepo storage.OrganizationRepository, sc stripeClient.StripeClientInterface, notifService notification.NotificationServiceInterface) *Handler {
+	organizationRepo storage.OrganizationRepository, walletRepo storage.WalletRepository,
+	sc stripeClient.StripeClientInterface, notifService notification.NotificationServiceInterface) *Handler {
 	return &Handler{
 		RegistrationRepository:    registrationRepo,
 		ChildRepository:           childRepo,
@@ -26,6 +28,7 @@ func NewHandler(registrationRepo storage.RegistrationRepository, childRepo stora
 		EventOccurrenceRepository: eventOccurrenceRepo,
 		NotificationService:       notifService,
 		OrganizationRepository:    organizationRepo,
+		WalletRepository:          walletRepo,
 		StripeClient:              sc,
 	}
 }
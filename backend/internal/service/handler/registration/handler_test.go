@@ -76,10 +76,11 @@ func TestHandler_GetRegistrationByID(t *testing.T) {
 			mockGuardianRepo := new(repomocks.MockGuardianRepository)
 			mockEORepo := new(repomocks.MockEventOccurrenceRepository)
 			mockOrgRepo := new(repomocks.MockOrganizationRepository)
+			mockWalletRepo := new(repomocks.MockWalletRepository)
 			mockStripeClient := new(stripemocks.MockStripeClient)
 			tt.mockSetup(mockRegRepo)
 
-			handler := NewHandler(mockRegRepo, mockChildRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockStripeClient, nil)
+			handler := NewHandler(mockRegRepo, mockChildRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockWalletRepo, mockStripeClient, nil)
 			ctx := context.Background()
 
 			input := &models.GetRegistrationByIDInput{
@@ -162,10 +163,11 @@ func TestHandler_GetRegistrationsByChildID(t *testing.T) {
 			mockGuardianRepo := new(repomocks.MockGuardianRepository)
 			mockEORepo := new(repomocks.MockEventOccurrenceRepository)
 			mockOrgRepo := new(repomocks.MockOrganizationRepository)
+			mockWalletRepo := new(repomocks.MockWalletRepository)
 			mockStripeClient := new(stripemocks.MockStripeClient)
 			tt.mockSetup(mockRegRepo)
 
-			handler := NewHandler(mockRegRepo, mockChildRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockStripeClient, nil)
+			handler := NewHandler(mockRegRepo, mockChildRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockWalletRepo, mockStripeClient, nil)
 			ctx := context.Background()
 
 			input := &models.GetRegistrationsByChildIDInput{AcceptLanguage: "en-US", ChildID: uuid.MustParse(tt.childID)}
@@ -245,10 +247,11 @@ func TestHandler_GetRegistrationsByGuardianID(t *testing.T) {
 			mockGuardianRepo := new(repomocks.MockGuardianRepository)
 			mockEORepo := new(repomocks.MockEventOccurrenceRepository)
 			mockOrgRepo := new(repomocks.MockOrganizationRepository)
+			mockWalletRepo := new(repomocks.MockWalletRepository)
 			mockStripeClient := new(stripemocks.MockStripeClient)
 			tt.mockSetup(mockRegRepo)
 
-			handler := NewHandler(mockRegRepo, mockChildRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockStripeClient, nil)
+			handler := NewHandler(mockRegRepo, mockChildRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockWalletRepo, mockStripeClient, nil)
 			ctx := context.Background()
 
 			input := &models.GetRegistrationsByGuardianIDInput{AcceptLanguage: "en-US", GuardianID: uuid.MustParse(tt.guardianID)}
@@ -329,10 +332,11 @@ func TestHandler_GetRegistrationsByEventOccurrenceID(t *testing.T) {
 			mockGuardianRepo := new(repomocks.MockGuardianRepository)
 			mockEORepo := new(repomocks.MockEventOccurrenceRepository)
 			mockOrgRepo := new(repomocks.MockOrganizationRepository)
+			mockWalletRepo := new(repomocks.MockWalletRepository)
 			mockStripeClient := new(stripemocks.MockStripeClient)
 			tt.mockSetup(mockRegRepo)
 
-			handler := NewHandler(mockRegRepo, mockChildRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockStripeClient, nil)
+			handler := NewHandler(mockRegRepo, mockChildRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockWalletRepo, mockStripeClient, nil)
 			ctx := context.Background()
 
 			input := &models.GetRegistrationsByEventOccurrenceIDInput{AcceptLanguage: "en-US", EventOccurrenceID: uuid.MustParse(tt.eventOccurrenceID)}
@@ -429,9 +433,7 @@ func TestHandler_CreateRegistration(t *testing.T) {
 							UpdatedAt:           time.Now(),
 						},
 					}, nil)
-
-				ns.On("SendNotification", mock.Anything, mock.AnythingOfType("*models.SendNotificationInput")).
-					Return(nil)
+				// the confirmation and reminders go through the outbox the repository writes to
 			},
 			wantErr: false,
 		},
@@ -611,11 +613,12 @@ func TestHandler_CreateRegistration(t *testing.T) {
 			mockGuardianRepo := new(repomocks.MockGuardianRepository)
 			mockEORepo := new(repomocks.MockEventOccurrenceRepository)
 			mockOrgRepo := new(repomocks.MockOrganizationRepository)
+			mockWalletRepo := new(repomocks.MockWalletRepository)
 			mockStripeClient := new(stripemocks.MockStripeClient)
 			mockNotifService := new(notificationmocks.MockNotificationService)
 			tt.mockSetup(mockRegRepo, mockChildRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockStripeClient, mockNotifService)
 
-			handler := NewHandler(mockRegRepo, mockChildRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockStripeClient, mockNotifService)
+			handler := NewHandler(mockRegRepo, mockChildRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockWalletRepo, mockStripeClient, mockNotifService)
 			ctx := context.Background()
 
 			registration, err := handler.CreateRegistration(ctx, tt.input)
@@ -740,10 +743,11 @@ func TestHandler_UpdateRegistration(t *testing.T) {
 			mockGuardianRepo := new(repomocks.MockGuardianRepository)
 			mockEORepo := new(repomocks.MockEventOccurrenceRepository)
 			mockOrgRepo := new(repomocks.MockOrganizationRepository)
+			mockWalletRepo := new(repomocks.MockWalletRepository)
 			mockStripeClient := new(stripemocks.MockStripeClient)
 			tt.mockSetup(mockRegRepo, mockChildRepo)
 
-			handler := NewHandler(mockRegRepo, mockChildRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockStripeClient, nil)
+			handler := NewHandler(mockRegRepo, mockChildRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockWalletRepo, mockStripeClient, nil)
 			ctx := context.Background()
 
 			registration, err := handler.UpdateRegistration(ctx, tt.input)
@@ -813,20 +817,20 @@ func TestHandler_CancelRegistration(t *testing.T) {
 	tests := []struct {
 		name      string
 		input     *models.CancelRegistrationInput
-		mockSetup func(*repomocks.MockRegistrationRepository, *repomocks.MockEventOccurrenceRepository, *stripemocks.MockStripeClient)
+		mockSetup func(*repomocks.MockRegistrationRepository, *repomocks.MockEventOccurrenceRepository, *repomocks.MockWalletRepository, *stripemocks.MockStripeClient)
 		wantErr   bool
 	}{
 		{
 			name:  "cancel requires_capture — cancels payment intent",
 			input: &models.CancelRegistrationInput{AcceptLanguage: "en-US", ID: registrationID},
-			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, sc *stripemocks.MockStripeClient) {
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
 				regRepo.On("GetRegistrationByID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationByIDInput")).
 					Return(validRegistration("requires_capture", time.Now().Add(48*time.Hour)), nil)
 
 				sc.On("CancelPaymentIntent", mock.Anything, mock.AnythingOfType("*models.CancelPaymentIntentInput")).
 					Return(cancelledPaymentIntentOutput, nil)
 
-				regRepo.On("CancelRegistration", mock.Anything, mock.AnythingOfType("*models.CancelRegistrationInput")).
+				walletRepo.On("CancelRegistrationWithCredit", mock.Anything, mock.AnythingOfType("*models.CancelRegistrationInput"), 0).
 					Return(cancelledOutput, nil)
 			},
 			wantErr: false,
@@ -834,7 +838,7 @@ func TestHandler_CancelRegistration(t *testing.T) {
 		{
 			name:  "cancel succeeded — event more than 24hrs away — issues refund",
 			input: &models.CancelRegistrationInput{AcceptLanguage: "en-US", ID: registrationID},
-			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, sc *stripemocks.MockStripeClient) {
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
 				regRepo.On("GetRegistrationByID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationByIDInput")).
 					Return(validRegistration("succeeded", time.Now().Add(48*time.Hour)), nil)
 
@@ -844,7 +848,7 @@ func TestHandler_CancelRegistration(t *testing.T) {
 				sc.On("RefundPayment", mock.Anything, mock.AnythingOfType("*models.RefundPaymentInput")).
 					Return(refundOutput, nil)
 
-				regRepo.On("CancelRegistration", mock.Anything, mock.AnythingOfType("*models.CancelRegistrationInput")).
+				walletRepo.On("CancelRegistrationWithCredit", mock.Anything, mock.AnythingOfType("*models.CancelRegistrationInput"), 0).
 					Return(cancelledOutput, nil)
 			},
 			wantErr: false,
@@ -852,7 +856,7 @@ func TestHandler_CancelRegistration(t *testing.T) {
 		{
 			name:  "cancel succeeded — event within 24hrs — no refund",
 			input: &models.CancelRegistrationInput{AcceptLanguage: "en-US", ID: registrationID},
-			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, sc *stripemocks.MockStripeClient) {
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
 				regRepo.On("GetRegistrationByID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationByIDInput")).
 					Return(validRegistration("succeeded", time.Now().Add(12*time.Hour)), nil)
 
@@ -864,10 +868,166 @@ func TestHandler_CancelRegistration(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:  "cancel succeeded — refund to credit — credits wallet instead of card",
+			input: &models.CancelRegistrationInput{AcceptLanguage: "en-US", ID: registrationID, RefundTo: models.RefundDestinationCredit},
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
+				regRepo.On("GetRegistrationByID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationByIDInput")).
+					Return(validRegistration("succeeded", time.Now().Add(48*time.Hour)), nil)
+
+				eoRepo.On("GetEventOccurrenceByID", mock.Anything, eventOccurrenceID, mock.Anything).
+					Return(validEventOccurrence(time.Now().Add(48*time.Hour)), nil)
+
+				sc.On("ReverseTransfer", mock.Anything, &models.ReverseTransferInput{
+					PaymentIntentID: "pi_test_123",
+					IdempotencyKey:  "charge_transfer_reversal:" + registrationID.String(),
+				}).Return(&models.ReverseTransferOutput{}, nil)
+
+				walletRepo.On("CancelRegistrationWithCredit", mock.Anything, mock.AnythingOfType("*models.CancelRegistrationInput"), 10000).
+					Return(cancelledOutput, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:  "cancel succeeded — paid fully with credit — reverses transfer and restores credit",
+			input: &models.CancelRegistrationInput{AcceptLanguage: "en-US", ID: registrationID},
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
+				reg := validRegistration("succeeded", time.Now().Add(48*time.Hour))
+				reg.Body.StripePaymentIntentID = ""
+				reg.Body.StripeTransferID = "tr_test_123"
+				reg.Body.TotalAmount = 0
+				regRepo.On("GetRegistrationByID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationByIDInput")).
+					Return(reg, nil)
+
+				eoRepo.On("GetEventOccurrenceByID", mock.Anything, eventOccurrenceID, mock.Anything).
+					Return(validEventOccurrence(time.Now().Add(48*time.Hour)), nil)
+
+				sc.On("ReverseTransfer", mock.Anything, mock.MatchedBy(func(input *models.ReverseTransferInput) bool {
+					return input.TransferID == "tr_test_123" && input.PaymentIntentID == ""
+				})).Return(&models.ReverseTransferOutput{}, nil)
+
+				walletRepo.On("CancelRegistrationWithCredit", mock.Anything, mock.AnythingOfType("*models.CancelRegistrationInput"), 0).
+					Return(cancelledOutput, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:  "cancel succeeded — paid fully with credit — transfer reversal fails — not cancelled",
+			input: &models.CancelRegistrationInput{AcceptLanguage: "en-US", ID: registrationID},
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
+				reg := validRegistration("succeeded", time.Now().Add(48*time.Hour))
+				reg.Body.StripePaymentIntentID = ""
+				reg.Body.StripeTransferID = "tr_test_123"
+				reg.Body.TotalAmount = 0
+				regRepo.On("GetRegistrationByID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationByIDInput")).
+					Return(reg, nil)
+
+				eoRepo.On("GetEventOccurrenceByID", mock.Anything, eventOccurrenceID, mock.Anything).
+					Return(validEventOccurrence(time.Now().Add(48*time.Hour)), nil)
+
+				sc.On("ReverseTransfer", mock.Anything, mock.AnythingOfType("*models.ReverseTransferInput")).
+					Return(nil, &errs.HTTPError{Code: 500, Message: "stripe error"})
+			},
+			wantErr: true,
+		},
+		{
+			name:  "cancel succeeded — refund to credit — transfer reversal fails — nothing credited",
+			input: &models.CancelRegistrationInput{AcceptLanguage: "en-US", ID: registrationID, RefundTo: models.RefundDestinationCredit},
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
+				regRepo.On("GetRegistrationByID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationByIDInput")).
+					Return(validRegistration("succeeded", time.Now().Add(48*time.Hour)), nil)
+
+				eoRepo.On("GetEventOccurrenceByID", mock.Anything, eventOccurrenceID, mock.Anything).
+					Return(validEventOccurrence(time.Now().Add(48*time.Hour)), nil)
+
+				sc.On("ReverseTransfer", mock.Anything, mock.AnythingOfType("*models.ReverseTransferInput")).
+					Return(nil, &errs.HTTPError{Code: 500, Message: "stripe error"})
+			},
+			wantErr: true,
+		},
+		{
+			name:  "cancel succeeded — refund to credit — cancelled concurrently — rejected",
+			input: &models.CancelRegistrationInput{AcceptLanguage: "en-US", ID: registrationID, RefundTo: models.RefundDestinationCredit},
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
+				regRepo.On("GetRegistrationByID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationByIDInput")).
+					Return(validRegistration("succeeded", time.Now().Add(48*time.Hour)), nil)
+
+				eoRepo.On("GetEventOccurrenceByID", mock.Anything, eventOccurrenceID, mock.Anything).
+					Return(validEventOccurrence(time.Now().Add(48*time.Hour)), nil)
+
+				sc.On("ReverseTransfer", mock.Anything, mock.AnythingOfType("*models.ReverseTransferInput")).
+					Return(&models.ReverseTransferOutput{}, nil)
+
+				alreadyCancelled := errs.BadRequest("Registration is already cancelled")
+				walletRepo.On("CancelRegistrationWithCredit", mock.Anything, mock.AnythingOfType("*models.CancelRegistrationInput"), 10000).
+					Return(nil, &alreadyCancelled)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "cancel succeeded — paid with credit and card — refunds card and reverses credit transfer",
+			input: &models.CancelRegistrationInput{AcceptLanguage: "en-US", ID: registrationID},
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
+				reg := validRegistration("succeeded", time.Now().Add(48*time.Hour))
+				reg.Body.StripeTransferID = "tr_credit_share"
+				regRepo.On("GetRegistrationByID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationByIDInput")).
+					Return(reg, nil)
+
+				eoRepo.On("GetEventOccurrenceByID", mock.Anything, eventOccurrenceID, mock.Anything).
+					Return(validEventOccurrence(time.Now().Add(48*time.Hour)), nil)
+
+				sc.On("RefundPayment", mock.Anything, mock.AnythingOfType("*models.RefundPaymentInput")).
+					Return(refundOutput, nil)
+
+				sc.On("ReverseTransfer", mock.Anything, &models.ReverseTransferInput{
+					TransferID:     "tr_credit_share",
+					IdempotencyKey: "transfer_reversal:" + registrationID.String(),
+				}).Return(&models.ReverseTransferOutput{}, nil)
+
+				walletRepo.On("CancelRegistrationWithCredit", mock.Anything, mock.AnythingOfType("*models.CancelRegistrationInput"), 0).
+					Return(cancelledOutput, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:  "cancel requires_capture — paid with credit and card — reverses credit transfer",
+			input: &models.CancelRegistrationInput{AcceptLanguage: "en-US", ID: registrationID},
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
+				reg := validRegistration("requires_capture", time.Now().Add(48*time.Hour))
+				reg.Body.StripeTransferID = "tr_credit_share"
+				regRepo.On("GetRegistrationByID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationByIDInput")).
+					Return(reg, nil)
+
+				sc.On("CancelPaymentIntent", mock.Anything, mock.AnythingOfType("*models.CancelPaymentIntentInput")).
+					Return(cancelledPaymentIntentOutput, nil)
+
+				sc.On("ReverseTransfer", mock.Anything, mock.MatchedBy(func(input *models.ReverseTransferInput) bool {
+					return input.TransferID == "tr_credit_share"
+				})).Return(&models.ReverseTransferOutput{}, nil)
+
+				walletRepo.On("CancelRegistrationWithCredit", mock.Anything, mock.AnythingOfType("*models.CancelRegistrationInput"), 0).
+					Return(cancelledOutput, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:  "cancel before payment — gives back credit already applied",
+			input: &models.CancelRegistrationInput{AcceptLanguage: "en-US", ID: registrationID},
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
+				reg := validRegistration("", time.Now().Add(48*time.Hour))
+				reg.Body.StripePaymentIntentID = ""
+				regRepo.On("GetRegistrationByID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationByIDInput")).
+					Return(reg, nil)
+
+				walletRepo.On("CancelRegistrationWithCredit", mock.Anything, mock.AnythingOfType("*models.CancelRegistrationInput"), 0).
+					Return(cancelledOutput, nil)
+			},
+			wantErr: false,
+		},
 		{
 			name:  "registration already cancelled",
 			input: &models.CancelRegistrationInput{AcceptLanguage: "en-US", ID: registrationID},
-			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, sc *stripemocks.MockStripeClient) {
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
 				regRepo.On("GetRegistrationByID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationByIDInput")).
 					Return(&models.GetRegistrationByIDOutput{
 						Body: models.Registration{
@@ -881,7 +1041,7 @@ func TestHandler_CancelRegistration(t *testing.T) {
 		{
 			name:  "registration not found",
 			input: &models.CancelRegistrationInput{AcceptLanguage: "en-US", ID: uuid.New()},
-			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, sc *stripemocks.MockStripeClient) {
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
 				regRepo.On("GetRegistrationByID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationByIDInput")).
 					Return(nil, &errs.HTTPError{
 						Code:    errs.NotFound("Registration", "id", uuid.New().String()).Code,
@@ -893,7 +1053,7 @@ func TestHandler_CancelRegistration(t *testing.T) {
 		{
 			name:  "stripe cancel fails",
 			input: &models.CancelRegistrationInput{AcceptLanguage: "en-US", ID: registrationID},
-			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, sc *stripemocks.MockStripeClient) {
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
 				regRepo.On("GetRegistrationByID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationByIDInput")).
 					Return(validRegistration("requires_capture", time.Now().Add(48*time.Hour)), nil)
 
@@ -905,7 +1065,7 @@ func TestHandler_CancelRegistration(t *testing.T) {
 		{
 			name:  "stripe refund fails",
 			input: &models.CancelRegistrationInput{AcceptLanguage: "en-US", ID: registrationID},
-			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, sc *stripemocks.MockStripeClient) {
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, eoRepo *repomocks.MockEventOccurrenceRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
 				regRepo.On("GetRegistrationByID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationByIDInput")).
 					Return(validRegistration("succeeded", time.Now().Add(48*time.Hour)), nil)
 
@@ -928,10 +1088,11 @@ func TestHandler_CancelRegistration(t *testing.T) {
 			mockGuardianRepo := new(repomocks.MockGuardianRepository)
 			mockEORepo := new(repomocks.MockEventOccurrenceRepository)
 			mockOrgRepo := new(repomocks.MockOrganizationRepository)
+			mockWalletRepo := new(repomocks.MockWalletRepository)
 			mockStripeClient := new(stripemocks.MockStripeClient)
-			tt.mockSetup(mockRegRepo, mockEORepo, mockStripeClient)
+			tt.mockSetup(mockRegRepo, mockEORepo, mockWalletRepo, mockStripeClient)
 
-			handler := NewHandler(mockRegRepo, mockChildRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockStripeClient, nil)
+			handler := NewHandler(mockRegRepo, mockChildRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockWalletRepo, mockStripeClient, nil)
 			ctx := context.Background()
 
 			result, err := handler.CancelRegistration(ctx, tt.input)
@@ -948,6 +1109,106 @@ func TestHandler_CancelRegistration(t *testing.T) {
 			mockRegRepo.AssertExpectations(t)
 			mockEORepo.AssertExpectations(t)
 			mockStripeClient.AssertExpectations(t)
+			mockWalletRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_CancelRegistration_LeavesRemindersToOutbox(t *testing.T) {
+	t.Parallel()
+
+	registrationID := uuid.New()
+	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	mockWalletRepo := new(repomocks.MockWalletRepository)
+	mockNotifService := new(notificationmocks.MockNotificationService)
+
+	mockRegRepo.On("GetRegistrationByID", mock.Anything, mock.AnythingOfType("*models.GetRegistrationByIDInput")).
+		Return(&models.GetRegistrationByIDOutput{Body: models.Registration{ID: registrationID, Status: models.RegistrationStatusRegistered}}, nil)
+	mockWalletRepo.On("CancelRegistrationWithCredit", mock.Anything, mock.AnythingOfType("*models.CancelRegistrationInput"), 0).
+		Return(&models.CancelRegistrationOutput{}, nil)
+
+	handler := NewHandler(mockRegRepo, nil, nil, nil, nil, mockWalletRepo, nil, mockNotifService)
+	result, err := handler.CancelRegistration(context.Background(), &models.CancelRegistrationInput{ID: registrationID})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	mockRegRepo.AssertExpectations(t)
+	mockWalletRepo.AssertExpectations(t)
+	// the cancellation's outbox message removes the reminders once it commits
+	mockNotifService.AssertNotCalled(t, "CancelEventReminders", mock.Anything, mock.Anything)
+}
+
+func TestHandler_UpdateRegistration_RefreshesReminders(t *testing.T) {
+	registrationID := uuid.New()
+	guardianID := uuid.New()
+	newOccurrenceID := uuid.New()
+	cancelled := models.RegistrationStatusCancelled
+	guardian := &models.Guardian{ID: guardianID, Email: "parent@example.com"}
+
+	tests := []struct {
+		name          string
+		input         func() *models.UpdateRegistrationInput
+		updatedStatus models.RegistrationStatus
+		mockSetup     func(*repomocks.MockEventOccurrenceRepository, *repomocks.MockGuardianRepository, *notificationmocks.MockNotificationService)
+	}{
+		{
+			name: "moved to another occurrence reschedules",
+			input: func() *models.UpdateRegistrationInput {
+				i := &models.UpdateRegistrationInput{ID: registrationID}
+				i.Body.EventOccurrenceID = &newOccurrenceID
+				return i
+			},
+			updatedStatus: models.RegistrationStatusRegistered,
+			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, guardianRepo *repomocks.MockGuardianRepository, ns *notificationmocks.MockNotificationService) {
+				eoRepo.On("GetEventOccurrenceByID", mock.Anything, newOccurrenceID, "en-US").Return(&models.EventOccurrence{ID: newOccurrenceID}, nil)
+				ns.On("CancelEventReminders", mock.Anything, registrationID).Return(nil)
+				guardianRepo.On("GetGuardianByID", mock.Anything, guardianID).Return(guardian, nil)
+				ns.On("ScheduleEventReminders", mock.Anything, mock.MatchedBy(func(reg *models.Registration) bool {
+					return reg.ID == registrationID && reg.EventOccurrenceID == newOccurrenceID
+				}), guardian).Return(nil)
+			},
+		},
+		{
+			name: "cancelled through update only removes reminders",
+			input: func() *models.UpdateRegistrationInput {
+				i := &models.UpdateRegistrationInput{ID: registrationID}
+				i.Body.Status = &cancelled
+				return i
+			},
+			updatedStatus: models.RegistrationStatusCancelled,
+			mockSetup: func(eoRepo *repomocks.MockEventOccurrenceRepository, guardianRepo *repomocks.MockGuardianRepository, ns *notificationmocks.MockNotificationService) {
+				ns.On("CancelEventReminders", mock.Anything, registrationID).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRegRepo := new(repomocks.MockRegistrationRepository)
+			mockGuardianRepo := new(repomocks.MockGuardianRepository)
+			mockEORepo := new(repomocks.MockEventOccurrenceRepository)
+			mockNotifService := new(notificationmocks.MockNotificationService)
+			tt.mockSetup(mockEORepo, mockGuardianRepo, mockNotifService)
+
+			mockRegRepo.On("UpdateRegistration", mock.Anything, mock.AnythingOfType("*models.UpdateRegistrationInput")).
+				Return(&models.UpdateRegistrationOutput{Body: models.Registration{
+					ID:                registrationID,
+					GuardianID:        guardianID,
+					EventOccurrenceID: newOccurrenceID,
+					Status:            tt.updatedStatus,
+				}}, nil)
+
+			handler := NewHandler(mockRegRepo, nil, mockGuardianRepo, mockEORepo, nil, nil, nil, mockNotifService)
+			_, err := handler.UpdateRegistration(context.Background(), tt.input())
+
+			assert.NoError(t, err)
+			mockNotifService.AssertExpectations(t)
+			mockGuardianRepo.AssertExpectations(t)
+			if tt.updatedStatus == models.RegistrationStatusCancelled {
+				mockNotifService.AssertNotCalled(t, "ScheduleEventReminders", mock.Anything, mock.Anything, mock.Anything)
+			}
 		})
 	}
 }
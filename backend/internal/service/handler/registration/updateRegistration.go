@@ -2,6 +2,7 @@ package registration
 
 import (
 	"context"
+	"log/slog"
 	"skillspark/internal/errs"
 	"skillspark/internal/models"
 )
@@ -31,5 +32,32 @@ func (h *Handler) UpdateRegistration(ctx context.Context, input *models.UpdateRe
 		return nil, err
 	}
 
+	if h.NotificationService != nil && (input.Body.EventOccurrenceID != nil || input.Body.GuardianID != nil || input.Body.Status != nil) {
+		h.refreshEventReminders(ctx, &updated.Body)
+	}
+
 	return updated, nil
 }
+
+// refreshEventReminders replaces the registration's unsent reminders after a change to
+// its occurrence, guardian or status. Failures are logged rather than failing the update.
+func (h *Handler) refreshEventReminders(ctx context.Context, registration *models.Registration) {
+	if err := h.NotificationService.CancelEventReminders(ctx, registration.ID); err != nil {
+		slog.Error("failed to cancel event reminders", "registration_id", registration.ID, "error", err)
+		return
+	}
+
+	if registration.Status != models.RegistrationStatusRegistered {
+		return
+	}
+
+	guardian, err := h.GuardianRepository.GetGuardianByID(ctx, registration.GuardianID)
+	if err != nil {
+		slog.Error("failed to get guardian for event reminders", "registration_id", registration.ID, "error", err)
+		return
+	}
+
+	if err := h.NotificationService.ScheduleEventReminders(ctx, registration, guardian); err != nil {
+		slog.Error("failed to schedule event reminders", "registration_id", registration.ID, "error", err)
+	}
+}
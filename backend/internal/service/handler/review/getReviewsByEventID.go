@@ -20,5 +20,9 @@ func (h *Handler) GetReviewsByEventID(ctx context.Context, id uuid.UUID, AcceptL
 		return nil, httpErr
 	}
 
+	if err := h.presignPhotos(ctx, reviews); err != nil {
+		return nil, err
+	}
+
 	return reviews, nil
 }
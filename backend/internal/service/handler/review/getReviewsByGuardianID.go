@@ -20,5 +20,9 @@ func (h *Handler) GetReviewsByGuardianID(ctx context.Context, id uuid.UUID, Acce
 		return nil, httpErr
 	}
 
+	if err := h.presignPhotos(ctx, reviews); err != nil {
+		return nil, err
+	}
+
 	return reviews, nil
 }
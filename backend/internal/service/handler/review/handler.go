@@ -1,6 +1,7 @@
 package review
 
 import (
+	"skillspark/internal/s3_client"
 	"skillspark/internal/storage"
 	translations "skillspark/internal/translation"
 )
@@ -12,9 +13,10 @@ type Handler struct {
 	EventRepository        storage.EventRepository
 	OrganizationRepository storage.OrganizationRepository
 	TranslateClient        translations.TranslationInterface
+	s3Client               s3_client.S3Interface
 }
 
-func NewHandler(registrationRepository storage.RegistrationRepository, reviewRepository storage.ReviewRepository, guardianRepository storage.GuardianRepository, eventRepository storage.EventRepository, organizationRepository storage.OrganizationRepository, translateClient translations.TranslationInterface) *Handler {
+func NewHandler(registrationRepository storage.RegistrationRepository, reviewRepository storage.ReviewRepository, guardianRepository storage.GuardianRepository, eventRepository storage.EventRepository, organizationRepository storage.OrganizationRepository, s3Client s3_client.S3Interface, translateClient translations.TranslationInterface) *Handler {
 	return &Handler{
 		RegistrationRepository: registrationRepository,
 		ReviewRepository:       reviewRepository,
@@ -22,5 +24,6 @@ func NewHandler(registrationRepository storage.RegistrationRepository, reviewRep
 		EventRepository:        eventRepository,
 		OrganizationRepository: organizationRepository,
 		TranslateClient:        translateClient,
+		s3Client:               s3Client,
 	}
 }
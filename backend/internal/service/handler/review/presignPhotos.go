@@ -0,0 +1,31 @@
+package review
+
+import (
+	"context"
+	"skillspark/internal/imageproc"
+	"skillspark/internal/models"
+	"time"
+)
+
+// presignPhotos fills in the URLs of photos attached to reviews through a direct upload
+func (h *Handler) presignPhotos(ctx context.Context, reviews []models.Review) error {
+	for idx := range reviews {
+		key := reviews[idx].PhotoS3Key
+		if key == nil {
+			continue
+		}
+
+		presignedURL, err := h.s3Client.GeneratePresignedURL(ctx, *key, time.Hour)
+		if err != nil {
+			return err
+		}
+		reviews[idx].PhotoURL = &presignedURL
+
+		reviews[idx].PhotoRenditions, err = imageproc.PresignRenditions(ctx, h.s3Client, imageproc.ReviewPhoto, *key, time.Hour)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
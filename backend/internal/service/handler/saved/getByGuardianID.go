@@ -3,6 +3,7 @@ package saved
 import (
 	"context"
 	"skillspark/internal/errs"
+	"skillspark/internal/imageproc"
 	"skillspark/internal/models"
 	"skillspark/internal/utils"
 	"time"
@@ -27,17 +28,23 @@ func (h *Handler) GetByGuardianID(
 		return nil, httpErr
 	}
 
+	var keys []string
 	for idx := range saved {
+		if key := saved[idx].Event.HeaderImageS3Key; key != nil {
+			keys = append(keys, *key)
+		}
+	}
 
-		key := saved[idx].Event.HeaderImageS3Key
-		if key != nil {
-
-			presignedURL, err := h.s3Client.GeneratePresignedURL(ctx, *key, time.Hour)
-			if err != nil {
-				return nil, err
-			}
+	images, err := imageproc.PresignImages(ctx, h.s3Client, imageproc.EventHeader, keys, time.Hour)
+	if err != nil {
+		return nil, err
+	}
 
-			saved[idx].Event.PresignedURL = &presignedURL
+	for idx := range saved {
+		if key := saved[idx].Event.HeaderImageS3Key; key != nil {
+			image := images[*key]
+			saved[idx].Event.PresignedURL = &image.URL
+			saved[idx].Event.HeaderImageRenditions = image.Renditions
 		}
 	}
 
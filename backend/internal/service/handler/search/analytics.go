@@ -0,0 +1,97 @@
+package search
+
+import (
+	"context"
+	"log/slog"
+	"skillspark/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// analyticsWindow is how far back the search analytics report looks by default
+const analyticsWindow = 30 * 24 * time.Hour
+
+// logSearch records a search anonymously and returns its id, which the client sends back
+// with the result the user opens. A failure to log is reported but never fails the search.
+func (h *Handler) logSearch(ctx context.Context, data *models.CreateSearchLogData) *uuid.UUID {
+	if h.SearchLogRepo == nil {
+		return nil
+	}
+	log, err := h.SearchLogRepo.CreateSearchLog(ctx, data)
+	if err != nil {
+		slog.Warn("Failed to log search", "endpoint", data.Endpoint, "error", err)
+		return nil
+	}
+	return &log.ID
+}
+
+// eventSearchFilters returns the filters of an events search as they are logged. A location
+// is only recorded as used, never its coordinates.
+func eventSearchFilters(input *models.SearchEventsInput) map[string]any {
+	filters := map[string]any{}
+	if input.Latitude.Set && input.Longitude.Set {
+		filters["location"] = true
+	}
+	if input.RadiusKm != 0 {
+		filters["radius_km"] = input.RadiusKm
+	}
+	if input.MinPrice != 0 {
+		filters["min_price"] = input.MinPrice
+	}
+	if input.MaxPrice != 0 {
+		filters["max_price"] = input.MaxPrice
+	}
+	if input.MinDuration != 0 {
+		filters["min_duration"] = input.MinDuration
+	}
+	if input.MaxDuration != 0 {
+		filters["max_duration"] = input.MaxDuration
+	}
+	if input.MinAge != 0 {
+		filters["min_age"] = input.MinAge
+	}
+	if input.MaxAge != 0 {
+		filters["max_age"] = input.MaxAge
+	}
+	if input.Category != "" {
+		filters["category"] = input.Category
+	}
+	if input.SoldOut {
+		filters["soldout"] = true
+	}
+	if !input.MinDate.IsZero() {
+		filters["min_date"] = input.MinDate
+	}
+	if !input.MaxDate.IsZero() {
+		filters["max_date"] = input.MaxDate
+	}
+	if input.Sort != "" && input.Sort != models.SearchSortRelevance {
+		filters["sort"] = input.Sort
+	}
+	return filters
+}
+
+// RecordSearchClick records the result opened from a search's results
+func (h *Handler) RecordSearchClick(ctx context.Context, input *models.RecordSearchClickInput) (*models.SearchLog, error) {
+	return h.SearchLogRepo.RecordSearchClick(ctx, &models.RecordSearchClickData{
+		ID:         input.ID,
+		ResultID:   input.Body.ResultID,
+		ResultType: input.Body.ResultType,
+		Position:   input.Body.Position,
+	})
+}
+
+// GetSearchAnalytics reports on searches made between since and until, the last 30 days
+// by default
+func (h *Handler) GetSearchAnalytics(ctx context.Context, input *models.GetSearchAnalyticsInput) (*models.SearchAnalytics, error) {
+	until := input.Until
+	if until.IsZero() {
+		until = time.Now()
+	}
+	since := input.Since
+	if since.IsZero() {
+		since = until.Add(-analyticsWindow)
+	}
+	return h.SearchLogRepo.GetSearchAnalytics(ctx, since, until, input.Limit)
+}
@@ -7,15 +7,19 @@ import (
 )
 
 type Handler struct {
-	OpenSearchClient *opensearch.Client
-	S3Client         s3_client.S3Interface
-	EventRepo        storage.EventRepository
+	OpenSearchClient    *opensearch.Client
+	S3Client            s3_client.S3Interface
+	EventRepo           storage.EventRepository
+	EventOccurrenceRepo storage.EventOccurrenceRepository
+	SearchLogRepo       storage.SearchLogRepository
 }
 
-func NewHandler(osClient *opensearch.Client, s3 s3_client.S3Interface, eventRepo storage.EventRepository) *Handler {
+func NewHandler(osClient *opensearch.Client, s3 s3_client.S3Interface, eventRepo storage.EventRepository, eventOccurrenceRepo storage.EventOccurrenceRepository, searchLogRepo storage.SearchLogRepository) *Handler {
 	return &Handler{
-		OpenSearchClient: osClient,
-		S3Client:         s3,
-		EventRepo:        eventRepo,
+		OpenSearchClient:    osClient,
+		S3Client:            s3,
+		EventRepo:           eventRepo,
+		EventOccurrenceRepo: eventOccurrenceRepo,
+		SearchLogRepo:       searchLogRepo,
 	}
 }
@@ -2,29 +2,105 @@ package search
 
 import (
 	"context"
+	"skillspark/internal/imageproc"
 	"skillspark/internal/models"
+	"skillspark/internal/opensearch"
 	"skillspark/internal/utils"
 	"time"
 )
 
-func (h *Handler) SearchEvents(ctx context.Context, query string, acceptLanguage string, pagination utils.Pagination) ([]models.Event, error) {
+// SearchEvents searches the events index and logs the first page of each search for the
+// search analytics report. Without OpenSearch it falls back to Postgres full-text search,
+// which ranks by relevance but only applies the query, category and age filters and
+// returns no facets.
+func (h *Handler) SearchEvents(ctx context.Context, input *models.SearchEventsInput, filters models.GetAllEventOccurrencesFilter) (*models.SearchEventsResult, error) {
+	result, err := h.searchEvents(ctx, input, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	// later pages of the same search aren't new searches
+	if input.Page <= 1 {
+		result.SearchID = h.logSearch(ctx, &models.CreateSearchLogData{
+			Endpoint:    models.SearchLogEndpointEvents,
+			Query:       input.Query,
+			Language:    input.AcceptLanguage,
+			Filters:     eventSearchFilters(input),
+			ResultCount: result.Total,
+		})
+	}
+	return result, nil
+}
+
+func (h *Handler) searchEvents(ctx context.Context, input *models.SearchEventsInput, filters models.GetAllEventOccurrencesFilter) (*models.SearchEventsResult, error) {
+	pagination := utils.Pagination{Page: input.Page, Limit: input.Limit}
+
 	if h.OpenSearchClient == nil {
-		return h.EventRepo.GetAllEvents(ctx, pagination, acceptLanguage, models.GetAllEventsFilter{Search: &query})
+		eventFilters := models.GetAllEventsFilter{
+			Category: filters.Category,
+			MinAge:   filters.MinAge,
+			MaxAge:   filters.MaxAge,
+		}
+		if input.Query != "" {
+			eventFilters.Search = &input.Query
+		}
+		events, total, err := h.EventRepo.SearchEvents(ctx, pagination, input.AcceptLanguage, eventFilters)
+		if err != nil {
+			return nil, err
+		}
+		h.presignEventHeaders(ctx, events)
+		return &models.SearchEventsResult{Results: events, Total: total, Facets: emptyFacets()}, nil
 	}
-	from := (pagination.Page - 1) * pagination.Limit
-	events, err := h.OpenSearchClient.FuzzySearch(ctx, query, acceptLanguage, from, pagination.Limit)
+
+	result, err := h.OpenSearchClient.Search(ctx, opensearch.SearchParams{
+		Query:          input.Query,
+		AcceptLanguage: input.AcceptLanguage,
+		Filters:        filters,
+		Sort:           input.Sort,
+		From:           pagination.GetOffset(),
+		Size:           pagination.Limit,
+	})
 	if err != nil {
 		return nil, err
 	}
+	h.presignEventHeaders(ctx, result.Events)
 
+	return &models.SearchEventsResult{
+		Results:    result.Events,
+		Total:      result.Total,
+		Facets:     result.Facets,
+		DidYouMean: result.DidYouMean,
+	}, nil
+}
+
+// presignEventHeaders signs the header images of a page of results in one batch; results
+// without images are still returned if signing fails
+func (h *Handler) presignEventHeaders(ctx context.Context, events []models.Event) {
+	var keys []string
 	for i := range events {
 		if events[i].HeaderImageS3Key != nil {
-			url, err := h.S3Client.GeneratePresignedURL(ctx, *events[i].HeaderImageS3Key, time.Hour)
-			if err == nil {
-				events[i].PresignedURL = &url
-			}
+			keys = append(keys, *events[i].HeaderImageS3Key)
 		}
 	}
 
-	return events, nil
+	images, err := imageproc.PresignImages(ctx, h.S3Client, imageproc.EventHeader, keys, time.Hour)
+	if err != nil {
+		return
+	}
+	for i := range events {
+		if events[i].HeaderImageS3Key != nil {
+			image := images[*events[i].HeaderImageS3Key]
+			events[i].PresignedURL = &image.URL
+			events[i].HeaderImageRenditions = image.Renditions
+		}
+	}
+}
+
+func emptyFacets() models.SearchFacets {
+	return models.SearchFacets{
+		Category:  []models.FacetBucket{},
+		AgeBand:   []models.FacetBucket{},
+		PriceBand: []models.FacetBucket{},
+		District:  []models.FacetBucket{},
+	}
 }
@@ -0,0 +1,31 @@
+package search
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/utils"
+)
+
+// Suggest returns grouped suggestions for a partially typed query. Without OpenSearch it
+// falls back to Postgres full-text search over events and suggests no organizations or
+// categories.
+func (h *Handler) Suggest(ctx context.Context, input *models.SuggestInput) (*models.SearchSuggestions, error) {
+	if h.OpenSearchClient != nil {
+		return h.OpenSearchClient.Suggest(ctx, input.Query, input.AcceptLanguage, input.Limit)
+	}
+
+	events, _, err := h.EventRepo.SearchEvents(ctx, utils.Pagination{Page: 1, Limit: input.Limit}, input.AcceptLanguage, models.GetAllEventsFilter{Search: &input.Query})
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := &models.SearchSuggestions{
+		Events:        make([]models.EventSuggestion, 0, len(events)),
+		Organizations: []models.OrganizationSuggestion{},
+		Categories:    []string{},
+	}
+	for _, event := range events {
+		suggestions.Events = append(suggestions.Events, models.EventSuggestion{ID: event.ID, Title: event.Title})
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,163 @@
+package search
+
+import (
+	"context"
+	"skillspark/internal/imageproc"
+	"skillspark/internal/models"
+	"skillspark/internal/opensearch"
+	"skillspark/internal/utils"
+	"slices"
+	"time"
+)
+
+// SearchAll searches upcoming occurrences, events and organizations at once, returning
+// only the requested types, and logs the search for the search analytics report. Without
+// OpenSearch it falls back to Postgres for events and occurrences, and finds no
+// organizations.
+func (h *Handler) SearchAll(ctx context.Context, input *models.UnifiedSearchInput, types []models.SearchType) (*models.UnifiedSearchResult, error) {
+	result, err := h.searchAll(ctx, input, types)
+	if err != nil {
+		return nil, err
+	}
+
+	result.SearchID = h.logSearch(ctx, &models.CreateSearchLogData{
+		Endpoint:    models.SearchLogEndpointAll,
+		Query:       input.Query,
+		Language:    input.AcceptLanguage,
+		Filters:     map[string]any{"types": types},
+		ResultCount: result.OccurrencesTotal + result.EventsTotal + result.OrganizationsTotal,
+	})
+	return result, nil
+}
+
+func (h *Handler) searchAll(ctx context.Context, input *models.UnifiedSearchInput, types []models.SearchType) (*models.UnifiedSearchResult, error) {
+	now := time.Now()
+
+	if h.OpenSearchClient == nil {
+		return h.searchAllPostgres(ctx, input, types, now)
+	}
+
+	result, err := h.OpenSearchClient.SearchAll(ctx, opensearch.UnifiedSearchParams{
+		Query:          input.Query,
+		AcceptLanguage: input.AcceptLanguage,
+		Types:          types,
+		Size:           input.Limit,
+		Now:            now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	h.presignUnifiedResults(ctx, result)
+	return result, nil
+}
+
+func (h *Handler) searchAllPostgres(ctx context.Context, input *models.UnifiedSearchInput, types []models.SearchType, now time.Time) (*models.UnifiedSearchResult, error) {
+	pagination := utils.Pagination{Page: 1, Limit: input.Limit}
+	result := &models.UnifiedSearchResult{
+		Occurrences:   []models.OccurrenceSearchResult{},
+		Events:        []models.Event{},
+		Organizations: []models.OrganizationSearchResult{},
+	}
+
+	if slices.Contains(types, models.SearchTypeEvents) {
+		events, total, err := h.EventRepo.SearchEvents(ctx, pagination, input.AcceptLanguage, models.GetAllEventsFilter{Search: &input.Query})
+		if err != nil {
+			return nil, err
+		}
+		result.Events = events
+		result.EventsTotal = total
+	}
+
+	if slices.Contains(types, models.SearchTypeOccurrences) {
+		occurrences, err := h.EventOccurrenceRepo.GetAllEventOccurrences(ctx, pagination, input.AcceptLanguage, models.GetAllEventOccurrencesFilter{
+			Search:  &input.Query,
+			MinDate: &now,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, occurrence := range occurrences {
+			if occurrence.Status == models.EventOccurrenceStatusCancelled {
+				continue
+			}
+			result.Occurrences = append(result.Occurrences, toOccurrenceResult(occurrence))
+		}
+		result.OccurrencesTotal = len(result.Occurrences)
+	}
+
+	return result, nil
+}
+
+func toOccurrenceResult(occurrence models.EventOccurrence) models.OccurrenceSearchResult {
+	seatsLeft := max(occurrence.MaxAttendees-occurrence.CurrEnrolled, 0)
+	return models.OccurrenceSearchResult{
+		ID:                    occurrence.ID,
+		EventID:               occurrence.Event.ID,
+		OrganizationID:        occurrence.Event.OrganizationID,
+		Title:                 occurrence.Event.Title,
+		Description:           occurrence.Event.Description,
+		Category:              occurrence.Event.Category,
+		HeaderImageS3Key:      occurrence.Event.HeaderImageS3Key,
+		PresignedURL:          occurrence.Event.PresignedURL,
+		HeaderImageRenditions: occurrence.Event.HeaderImageRenditions,
+		AgeRangeMin:           occurrence.Event.AgeRangeMin,
+		AgeRangeMax:           occurrence.Event.AgeRangeMax,
+		District:              occurrence.Location.District,
+		StartTime:             occurrence.StartTime,
+		EndTime:               occurrence.EndTime,
+		Price:                 occurrence.Price,
+		Currency:              occurrence.Currency,
+		Language:              occurrence.Language,
+		SeatsLeft:             seatsLeft,
+		SoldOut:               seatsLeft == 0,
+	}
+}
+
+// presignUnifiedResults signs the images of every result, one batch per kind of image.
+// Results are still returned without images if signing fails.
+func (h *Handler) presignUnifiedResults(ctx context.Context, result *models.UnifiedSearchResult) {
+	var headerKeys, pfpKeys []string
+	for _, occurrence := range result.Occurrences {
+		if occurrence.HeaderImageS3Key != nil {
+			headerKeys = append(headerKeys, *occurrence.HeaderImageS3Key)
+		}
+	}
+	for _, event := range result.Events {
+		if event.HeaderImageS3Key != nil {
+			headerKeys = append(headerKeys, *event.HeaderImageS3Key)
+		}
+	}
+	for _, organization := range result.Organizations {
+		if organization.PfpS3Key != nil {
+			pfpKeys = append(pfpKeys, *organization.PfpS3Key)
+		}
+	}
+
+	if headers, err := imageproc.PresignImages(ctx, h.S3Client, imageproc.EventHeader, headerKeys, time.Hour); err == nil {
+		for i := range result.Occurrences {
+			if key := result.Occurrences[i].HeaderImageS3Key; key != nil {
+				image := headers[*key]
+				result.Occurrences[i].PresignedURL = &image.URL
+				result.Occurrences[i].HeaderImageRenditions = image.Renditions
+			}
+		}
+		for i := range result.Events {
+			if key := result.Events[i].HeaderImageS3Key; key != nil {
+				image := headers[*key]
+				result.Events[i].PresignedURL = &image.URL
+				result.Events[i].HeaderImageRenditions = image.Renditions
+			}
+		}
+	}
+
+	if pfps, err := imageproc.PresignImages(ctx, h.S3Client, imageproc.OrganizationPicture, pfpKeys, time.Hour); err == nil {
+		for i := range result.Organizations {
+			if key := result.Organizations[i].PfpS3Key; key != nil {
+				image := pfps[*key]
+				result.Organizations[i].PresignedURL = &image.URL
+				result.Organizations[i].PfpRenditions = image.Renditions
+			}
+		}
+	}
+}
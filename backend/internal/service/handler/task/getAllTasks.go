@@ -0,0 +1,18 @@
+package task
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/utils"
+)
+
+func (h *Handler) GetAllTasks(ctx context.Context, input *models.GetAllTasksInput) (*models.GetAllTasksOutput, error) {
+	pagination := utils.Pagination{Page: input.Page, Limit: input.PageSize}
+
+	tasks, err := h.TaskRepository.GetAllTasks(ctx, input.Status, input.TaskType, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GetAllTasksOutput{Body: tasks}, nil
+}
@@ -0,0 +1,15 @@
+package task
+
+import (
+	"skillspark/internal/storage"
+)
+
+type Handler struct {
+	TaskRepository storage.TaskRepository
+}
+
+func NewHandler(taskRepo storage.TaskRepository) *Handler {
+	return &Handler{
+		TaskRepository: taskRepo,
+	}
+}
@@ -0,0 +1,125 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"skillspark/internal/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_GetAllTasks(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     *models.GetAllTasksInput
+		mockSetup func(*repomocks.MockTaskRepository)
+		wantLen   int
+		wantErr   bool
+	}{
+		{
+			name:  "returns tasks",
+			input: &models.GetAllTasksInput{Page: 1, PageSize: 10},
+			mockSetup: func(m *repomocks.MockTaskRepository) {
+				m.On("GetAllTasks", mock.Anything, "", "", utils.Pagination{Page: 1, Limit: 10}).
+					Return([]models.Task{{ID: uuid.New()}, {ID: uuid.New()}}, nil)
+			},
+			wantLen: 2,
+		},
+		{
+			name:  "filters by status and type",
+			input: &models.GetAllTasksInput{Status: "dead", TaskType: "capture_payment", Page: 1, PageSize: 10},
+			mockSetup: func(m *repomocks.MockTaskRepository) {
+				m.On("GetAllTasks", mock.Anything, "dead", "capture_payment", utils.Pagination{Page: 1, Limit: 10}).
+					Return([]models.Task{{ID: uuid.New(), Status: models.TaskStatusDead}}, nil)
+			},
+			wantLen: 1,
+		},
+		{
+			name:  "repository error",
+			input: &models.GetAllTasksInput{Page: 1, PageSize: 10},
+			mockSetup: func(m *repomocks.MockTaskRepository) {
+				m.On("GetAllTasks", mock.Anything, "", "", mock.Anything).Return(nil, errors.New("db down"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(repomocks.MockTaskRepository)
+			tt.mockSetup(repo)
+
+			h := NewHandler(repo)
+			out, err := h.GetAllTasks(context.Background(), tt.input)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, out)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, out.Body, tt.wantLen)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_RetryTask(t *testing.T) {
+	taskID := uuid.New()
+
+	tests := []struct {
+		name      string
+		mockSetup func(*repomocks.MockTaskRepository)
+		wantErr   bool
+	}{
+		{
+			name: "requeues dead task",
+			mockSetup: func(m *repomocks.MockTaskRepository) {
+				m.On("RequeueDeadTask", mock.Anything, taskID).
+					Return(&models.Task{ID: taskID, Status: models.TaskStatusPending}, nil)
+			},
+		},
+		{
+			name: "task not dead",
+			mockSetup: func(m *repomocks.MockTaskRepository) {
+				badRequest := errs.BadRequest("Only dead tasks can be retried")
+				m.On("RequeueDeadTask", mock.Anything, taskID).Return(nil, &badRequest)
+			},
+			wantErr: true,
+		},
+		{
+			name: "not found",
+			mockSetup: func(m *repomocks.MockTaskRepository) {
+				notFound := errs.NotFound("Task", "id", taskID)
+				m.On("RequeueDeadTask", mock.Anything, taskID).Return(nil, &notFound)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(repomocks.MockTaskRepository)
+			tt.mockSetup(repo)
+
+			h := NewHandler(repo)
+			out, err := h.RetryTask(context.Background(), &models.RetryTaskInput{ID: taskID})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, out)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, models.TaskStatusPending, out.Body.Status)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
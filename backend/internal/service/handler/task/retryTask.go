@@ -0,0 +1,15 @@
+package task
+
+import (
+	"context"
+	"skillspark/internal/models"
+)
+
+func (h *Handler) RetryTask(ctx context.Context, input *models.RetryTaskInput) (*models.RetryTaskOutput, error) {
+	task, err := h.TaskRepository.RequeueDeadTask(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RetryTaskOutput{Body: *task}, nil
+}
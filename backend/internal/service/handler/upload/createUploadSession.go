@@ -0,0 +1,58 @@
+package upload
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	uploadURLExpiry = 15 * time.Minute
+	// a session outlives its URL so an upload that started just before the URL expired
+	// can still be finalized
+	uploadSessionLifetime = time.Hour
+)
+
+// CreateUploadSession records a pending upload and presigns a PUT for it. The file goes
+// to a staging key; nothing is attached to the target until the session is finalized.
+func (h *Handler) CreateUploadSession(ctx context.Context, input *models.CreateUploadSessionInput) (*models.CreateUploadSessionOutput, error) {
+	target, ok := uploadTargets[input.Body.Target]
+	if !ok {
+		return nil, errs.BadRequest("Invalid target: " + string(input.Body.Target))
+	}
+	if input.Body.ContentLength > int64(target.profile.MaxBytes) {
+		return nil, errs.BadRequest(fmt.Sprintf("Invalid content_length: file is larger than %d MB", target.profile.MaxBytes>>20))
+	}
+
+	session, err := h.UploadRepository.CreateUploadSession(ctx, &models.CreateUploadSessionData{
+		Target:        input.Body.Target,
+		TargetID:      input.Body.TargetID,
+		StagingKey:    "uploads/" + uuid.NewString(),
+		ContentType:   input.Body.ContentType,
+		ContentLength: input.Body.ContentLength,
+		ExpiresAt:     time.Now().Add(uploadSessionLifetime),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := h.s3Client.GeneratePresignedUploadURL(ctx, session.StagingKey, session.ContentType, session.ContentLength, uploadURLExpiry)
+	if err != nil {
+		return nil, errs.InternalServerError("Failed to presign upload URL: ", err.Error())
+	}
+
+	output := &models.CreateUploadSessionOutput{}
+	output.Body.Session = *session
+	output.Body.UploadURL = url
+	output.Body.Headers = map[string]string{
+		"Content-Type":   session.ContentType,
+		"Content-Length": fmt.Sprint(session.ContentLength),
+	}
+
+	return output, nil
+}
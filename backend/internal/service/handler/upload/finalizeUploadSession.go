@@ -0,0 +1,95 @@
+package upload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"skillspark/internal/errs"
+	"skillspark/internal/imageproc"
+	"skillspark/internal/models"
+	"skillspark/internal/s3_client"
+)
+
+// FinalizeUploadSession checks the staged file against what the session declared, runs it
+// through the same validation and resizing as a multipart upload, stores the result under
+// the target and attaches it. The image it replaced and the staging object are deleted
+// afterwards; if deleting the staging object fails the cleanup job deletes it later.
+func (h *Handler) FinalizeUploadSession(ctx context.Context, input *models.FinalizeUploadSessionInput) (*models.FinalizeUploadSessionOutput, error) {
+	session, err := h.UploadRepository.GetUploadSessionByID(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	// a pending session past its expiry is only marked expired by the next cleanup run
+	status := session.Status
+	if status == models.UploadSessionStatusPending && !session.ExpiresAt.After(time.Now()) {
+		status = models.UploadSessionStatusExpired
+	}
+	if status != models.UploadSessionStatusPending {
+		return nil, errs.NewHTTPError(http.StatusConflict, fmt.Errorf("Upload session is %s; only pending sessions can be finalized", status))
+	}
+
+	target, ok := uploadTargets[session.Target]
+	if !ok {
+		return nil, errs.InternalServerError("Unknown upload target: ", string(session.Target))
+	}
+
+	info, err := h.s3Client.HeadObject(ctx, session.StagingKey)
+	if err != nil {
+		if errors.Is(err, s3_client.ErrObjectNotFound) {
+			return nil, errs.BadRequest("Nothing has been uploaded for this session")
+		}
+		return nil, errs.InternalServerError("Failed to check upload: ", err.Error())
+	}
+	// the presigned URL already enforces these, but a URL for the same key could have
+	// been presigned elsewhere
+	if info.ContentLength != session.ContentLength || info.ContentType != session.ContentType {
+		return nil, errs.BadRequest("Uploaded file does not match the size or content type of the session")
+	}
+
+	data, err := h.s3Client.DownloadObject(ctx, session.StagingKey, session.ContentLength)
+	if err != nil {
+		return nil, errs.InternalServerError("Failed to download upload: ", err.Error())
+	}
+
+	processed, err := imageproc.Process(data, target.profile)
+	if err != nil {
+		if errors.Is(err, imageproc.ErrInvalidImage) {
+			return nil, errs.BadRequest("Invalid image: " + err.Error())
+		}
+		return nil, errs.InternalServerError("Failed to process image: ", err.Error())
+	}
+
+	stored, err := imageproc.Store(ctx, h.s3Client, target.base(session.TargetID), processed)
+	if err != nil {
+		return nil, errs.InternalServerError("Failed to store image: ", err.Error())
+	}
+
+	finalized, err := h.UploadRepository.FinalizeUploadSession(ctx, session.ID, stored.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	// the replaced image is only removed once nothing points at it
+	if replaced := finalized.ReplacedKey; replaced != nil && *replaced != stored.Key {
+		if err := imageproc.Delete(ctx, h.s3Client, target.profile, *replaced); err != nil {
+			log.Printf("Failed to delete image replaced by upload %s: %v", finalized.ID, err)
+		}
+	}
+
+	if err := h.s3Client.DeleteObject(ctx, finalized.StagingKey); err != nil {
+		log.Printf("Failed to delete staged upload %s: %v", finalized.ID, err)
+	} else if err := h.UploadRepository.MarkUploadSessionCleanedUp(ctx, finalized.ID); err != nil {
+		log.Printf("Failed to mark upload session %s cleaned up: %v", finalized.ID, err)
+	}
+
+	output := &models.FinalizeUploadSessionOutput{}
+	output.Body.Session = *finalized
+	output.Body.URL = stored.URL
+	output.Body.Renditions = stored.Renditions
+
+	return output, nil
+}
@@ -0,0 +1,18 @@
+package upload
+
+import (
+	"skillspark/internal/s3_client"
+	"skillspark/internal/storage"
+)
+
+type Handler struct {
+	UploadRepository storage.UploadRepository
+	s3Client         s3_client.S3Interface
+}
+
+func NewHandler(uploadRepo storage.UploadRepository, s3Client s3_client.S3Interface) *Handler {
+	return &Handler{
+		UploadRepository: uploadRepo,
+		s3Client:         s3Client,
+	}
+}
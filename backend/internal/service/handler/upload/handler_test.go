@@ -0,0 +1,257 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/s3_client"
+	s3mocks "skillspark/internal/s3_client/mocks"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testPNG(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 320, 240))))
+	return buf.Bytes()
+}
+
+func requireStatus(t *testing.T, err error, status int) {
+	t.Helper()
+	var httpErr errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, status, httpErr.Code)
+}
+
+func TestHandler_CreateUploadSession(t *testing.T) {
+	eventID := uuid.New()
+
+	t.Run("presigns a PUT for the staging key", func(t *testing.T) {
+		mockRepo := new(repomocks.MockUploadRepository)
+		mockS3 := new(s3mocks.S3ClientMock)
+		h := NewHandler(mockRepo, mockS3)
+
+		session := &models.UploadSession{
+			ID:            uuid.New(),
+			Target:        models.UploadTargetEventHeader,
+			TargetID:      eventID,
+			StagingKey:    "uploads/abc",
+			ContentType:   "image/png",
+			ContentLength: 2048,
+			Status:        models.UploadSessionStatusPending,
+		}
+		mockRepo.On("CreateUploadSession", mock.Anything, mock.MatchedBy(func(data *models.CreateUploadSessionData) bool {
+			return data.TargetID == eventID &&
+				strings.HasPrefix(data.StagingKey, "uploads/") &&
+				data.ContentLength == 2048 &&
+				data.ExpiresAt.After(time.Now().Add(uploadURLExpiry))
+		})).Return(session, nil)
+		mockS3.On("GeneratePresignedUploadURL", mock.Anything, "uploads/abc", "image/png", int64(2048), uploadURLExpiry).
+			Return("https://bucket.s3.amazonaws.com/uploads/abc?X-Amz-Signature=x", nil)
+
+		input := &models.CreateUploadSessionInput{}
+		input.Body.Target = models.UploadTargetEventHeader
+		input.Body.TargetID = eventID
+		input.Body.ContentType = "image/png"
+		input.Body.ContentLength = 2048
+
+		output, err := h.CreateUploadSession(context.Background(), input)
+		require.NoError(t, err)
+		assert.Equal(t, session.ID, output.Body.Session.ID)
+		assert.Contains(t, output.Body.UploadURL, "X-Amz-Signature")
+		assert.Equal(t, "image/png", output.Body.Headers["Content-Type"])
+		assert.Equal(t, "2048", output.Body.Headers["Content-Length"])
+		mockRepo.AssertExpectations(t)
+		mockS3.AssertExpectations(t)
+	})
+
+	t.Run("a file larger than the target allows is rejected", func(t *testing.T) {
+		mockRepo := new(repomocks.MockUploadRepository)
+		mockS3 := new(s3mocks.S3ClientMock)
+		h := NewHandler(mockRepo, mockS3)
+
+		input := &models.CreateUploadSessionInput{}
+		input.Body.Target = models.UploadTargetGuardianAvatar
+		input.Body.TargetID = uuid.New()
+		input.Body.ContentType = "image/jpeg"
+		input.Body.ContentLength = 50 << 20
+
+		_, err := h.CreateUploadSession(context.Background(), input)
+		requireStatus(t, err, http.StatusBadRequest)
+		mockRepo.AssertNotCalled(t, "CreateUploadSession", mock.Anything, mock.Anything)
+		mockS3.AssertNotCalled(t, "GeneratePresignedUploadURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_FinalizeUploadSession(t *testing.T) {
+	data := testPNG(t)
+
+	newSession := func() *models.UploadSession {
+		return &models.UploadSession{
+			ID:            uuid.New(),
+			Target:        models.UploadTargetReviewPhoto,
+			TargetID:      uuid.New(),
+			StagingKey:    "uploads/" + uuid.NewString(),
+			ContentType:   "image/png",
+			ContentLength: int64(len(data)),
+			Status:        models.UploadSessionStatusPending,
+			ExpiresAt:     time.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("stores the image under its target and attaches it", func(t *testing.T) {
+		mockRepo := new(repomocks.MockUploadRepository)
+		mockS3 := new(s3mocks.S3ClientMock)
+		h := NewHandler(mockRepo, mockS3)
+		session := newSession()
+		prefix := "reviews/photo/" + session.TargetID.String() + "/"
+		url := "https://example.com/presigned"
+
+		mockRepo.On("GetUploadSessionByID", mock.Anything, session.ID).Return(session, nil)
+		mockS3.On("HeadObject", mock.Anything, session.StagingKey).
+			Return(&s3_client.ObjectInfo{ContentType: "image/png", ContentLength: session.ContentLength}, nil)
+		mockS3.On("DownloadObject", mock.Anything, session.StagingKey, session.ContentLength).Return(data, nil)
+		mockS3.On("UploadImage", mock.Anything, mock.MatchedBy(func(key *string) bool {
+			return strings.HasPrefix(*key, prefix)
+		}), mock.Anything, mock.Anything).Return(&url, nil)
+
+		finalized := *session
+		finalized.Status = models.UploadSessionStatusFinalized
+		mockRepo.On("FinalizeUploadSession", mock.Anything, session.ID, mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, prefix) && strings.Contains(key, "/original.")
+		})).Return(&finalized, nil)
+		mockS3.On("DeleteObject", mock.Anything, session.StagingKey).Return(nil)
+		mockRepo.On("MarkUploadSessionCleanedUp", mock.Anything, session.ID).Return(nil)
+
+		output, err := h.FinalizeUploadSession(context.Background(), &models.FinalizeUploadSessionInput{ID: session.ID})
+		require.NoError(t, err)
+		assert.Equal(t, models.UploadSessionStatusFinalized, output.Body.Session.Status)
+		require.NotNil(t, output.Body.URL)
+		assert.Len(t, output.Body.Renditions, 6)
+		mockRepo.AssertExpectations(t)
+		mockS3.AssertExpectations(t)
+	})
+
+	t.Run("deletes the image the upload replaced", func(t *testing.T) {
+		mockRepo := new(repomocks.MockUploadRepository)
+		mockS3 := new(s3mocks.S3ClientMock)
+		h := NewHandler(mockRepo, mockS3)
+		session := newSession()
+		url := "https://example.com/presigned"
+		oldFolder := "reviews/photo/" + session.TargetID.String() + "/" + uuid.NewString() + "/"
+		replaced := oldFolder + "original.jpg"
+
+		mockRepo.On("GetUploadSessionByID", mock.Anything, session.ID).Return(session, nil)
+		mockS3.On("HeadObject", mock.Anything, session.StagingKey).
+			Return(&s3_client.ObjectInfo{ContentType: "image/png", ContentLength: session.ContentLength}, nil)
+		mockS3.On("DownloadObject", mock.Anything, session.StagingKey, session.ContentLength).Return(data, nil)
+		mockS3.On("UploadImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&url, nil)
+
+		finalized := *session
+		finalized.Status = models.UploadSessionStatusFinalized
+		finalized.ReplacedKey = &replaced
+		mockRepo.On("FinalizeUploadSession", mock.Anything, session.ID, mock.Anything).Return(&finalized, nil)
+		// the original and a JPEG and WebP key for each of the three renditions
+		mockS3.On("DeleteObject", mock.Anything, mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, oldFolder)
+		})).Return(nil).Times(7)
+		mockS3.On("DeleteObject", mock.Anything, session.StagingKey).Return(nil)
+		mockRepo.On("MarkUploadSessionCleanedUp", mock.Anything, session.ID).Return(nil)
+
+		_, err := h.FinalizeUploadSession(context.Background(), &models.FinalizeUploadSessionInput{ID: session.ID})
+		require.NoError(t, err)
+		mockS3.AssertCalled(t, "DeleteObject", mock.Anything, replaced)
+		mockRepo.AssertExpectations(t)
+		mockS3.AssertExpectations(t)
+	})
+
+	t.Run("a file that doesn't match the session is rejected", func(t *testing.T) {
+		mockRepo := new(repomocks.MockUploadRepository)
+		mockS3 := new(s3mocks.S3ClientMock)
+		h := NewHandler(mockRepo, mockS3)
+		session := newSession()
+
+		mockRepo.On("GetUploadSessionByID", mock.Anything, session.ID).Return(session, nil)
+		mockS3.On("HeadObject", mock.Anything, session.StagingKey).
+			Return(&s3_client.ObjectInfo{ContentType: "text/html", ContentLength: session.ContentLength}, nil)
+
+		_, err := h.FinalizeUploadSession(context.Background(), &models.FinalizeUploadSessionInput{ID: session.ID})
+		requireStatus(t, err, http.StatusBadRequest)
+		mockS3.AssertNotCalled(t, "DownloadObject", mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "FinalizeUploadSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("nothing uploaded", func(t *testing.T) {
+		mockRepo := new(repomocks.MockUploadRepository)
+		mockS3 := new(s3mocks.S3ClientMock)
+		h := NewHandler(mockRepo, mockS3)
+		session := newSession()
+
+		mockRepo.On("GetUploadSessionByID", mock.Anything, session.ID).Return(session, nil)
+		mockS3.On("HeadObject", mock.Anything, session.StagingKey).Return(nil, s3_client.ErrObjectNotFound)
+
+		_, err := h.FinalizeUploadSession(context.Background(), &models.FinalizeUploadSessionInput{ID: session.ID})
+		requireStatus(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("a file that isn't an image is rejected", func(t *testing.T) {
+		mockRepo := new(repomocks.MockUploadRepository)
+		mockS3 := new(s3mocks.S3ClientMock)
+		h := NewHandler(mockRepo, mockS3)
+		session := newSession()
+		notImage := []byte("<html>not an image</html>")
+		session.ContentLength = int64(len(notImage))
+
+		mockRepo.On("GetUploadSessionByID", mock.Anything, session.ID).Return(session, nil)
+		mockS3.On("HeadObject", mock.Anything, session.StagingKey).
+			Return(&s3_client.ObjectInfo{ContentType: "image/png", ContentLength: session.ContentLength}, nil)
+		mockS3.On("DownloadObject", mock.Anything, session.StagingKey, session.ContentLength).Return(notImage, nil)
+
+		_, err := h.FinalizeUploadSession(context.Background(), &models.FinalizeUploadSessionInput{ID: session.ID})
+		requireStatus(t, err, http.StatusBadRequest)
+		mockS3.AssertNotCalled(t, "UploadImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "FinalizeUploadSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("an expired session can't be finalized", func(t *testing.T) {
+		mockRepo := new(repomocks.MockUploadRepository)
+		mockS3 := new(s3mocks.S3ClientMock)
+		h := NewHandler(mockRepo, mockS3)
+		session := newSession()
+		session.ExpiresAt = time.Now().Add(-time.Minute)
+
+		mockRepo.On("GetUploadSessionByID", mock.Anything, session.ID).Return(session, nil)
+
+		_, err := h.FinalizeUploadSession(context.Background(), &models.FinalizeUploadSessionInput{ID: session.ID})
+		requireStatus(t, err, http.StatusConflict)
+		assert.ErrorContains(t, err, "Upload session is expired")
+		mockS3.AssertNotCalled(t, "HeadObject", mock.Anything, mock.Anything)
+	})
+
+	t.Run("a finalized session can't be finalized again", func(t *testing.T) {
+		mockRepo := new(repomocks.MockUploadRepository)
+		mockS3 := new(s3mocks.S3ClientMock)
+		h := NewHandler(mockRepo, mockS3)
+		session := newSession()
+		session.Status = models.UploadSessionStatusFinalized
+
+		mockRepo.On("GetUploadSessionByID", mock.Anything, session.ID).Return(session, nil)
+
+		_, err := h.FinalizeUploadSession(context.Background(), &models.FinalizeUploadSessionInput{ID: session.ID})
+		requireStatus(t, err, http.StatusConflict)
+		assert.ErrorContains(t, err, "Upload session is finalized")
+		mockS3.AssertNotCalled(t, "HeadObject", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,26 @@
+package upload
+
+import (
+	"skillspark/internal/imageproc"
+	"skillspark/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// uploadTarget is how images for one target are validated and where they are stored. The
+// prefixes match the ones the multipart endpoints use.
+type uploadTarget struct {
+	profile imageproc.Profile
+	prefix  string
+}
+
+var uploadTargets = map[models.UploadTarget]uploadTarget{
+	models.UploadTargetEventHeader:         {profile: imageproc.EventHeader, prefix: "events/header-image/"},
+	models.UploadTargetOrganizationPicture: {profile: imageproc.OrganizationPicture, prefix: "orgs/header-image/"},
+	models.UploadTargetGuardianAvatar:      {profile: imageproc.GuardianAvatar, prefix: "guardians/profile-picture/"},
+	models.UploadTargetReviewPhoto:         {profile: imageproc.ReviewPhoto, prefix: "reviews/photo/"},
+}
+
+func (t uploadTarget) base(id uuid.UUID) string {
+	return t.prefix + id.String()
+}
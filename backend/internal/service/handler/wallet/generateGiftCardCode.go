@@ -0,0 +1,29 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+)
+
+// no 0/O or 1/I/L so codes can be read aloud and typed from a printed card
+const giftCardCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// generateGiftCardCode returns a random code formatted as XXXX-XXXX-XXXX.
+func generateGiftCardCode() (string, error) {
+	var b strings.Builder
+	max := big.NewInt(int64(len(giftCardCodeAlphabet)))
+
+	for i := 0; i < 12; i++ {
+		if i > 0 && i%4 == 0 {
+			b.WriteByte('-')
+		}
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(giftCardCodeAlphabet[n.Int64()])
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,18 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/utils"
+)
+
+func (h *Handler) GetAllWalletTransactions(ctx context.Context, input *models.GetAllWalletTransactionsInput) (*models.GetAllWalletTransactionsOutput, error) {
+	pagination := utils.Pagination{Page: input.Page, Limit: input.PageSize}
+
+	transactions, err := h.WalletRepository.GetAllTransactions(ctx, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GetAllWalletTransactionsOutput{Body: transactions}, nil
+}
@@ -0,0 +1,32 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/utils"
+)
+
+func (h *Handler) GetWalletByGuardianID(ctx context.Context, input *models.GetWalletByGuardianIDInput) (*models.GetWalletByGuardianIDOutput, error) {
+	if _, err := h.GuardianRepository.GetGuardianByID(ctx, input.GuardianID); err != nil {
+		return nil, err
+	}
+
+	balance, err := h.WalletRepository.GetBalance(ctx, input.GuardianID, input.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	pagination := utils.Pagination{Page: input.Page, Limit: input.PageSize}
+	transactions, err := h.WalletRepository.GetTransactionsByGuardianID(ctx, input.GuardianID, input.Currency, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &models.GetWalletByGuardianIDOutput{}
+	output.Body.GuardianID = input.GuardianID
+	output.Body.Balance = balance
+	output.Body.Currency = input.Currency
+	output.Body.Transactions = transactions
+
+	return output, nil
+}
@@ -0,0 +1,24 @@
+package wallet
+
+import (
+	"skillspark/internal/storage"
+	"skillspark/internal/stripeClient"
+)
+
+type Handler struct {
+	WalletRepository   storage.WalletRepository
+	GuardianRepository storage.GuardianRepository
+	StripeClient       stripeClient.StripeClientInterface
+}
+
+func NewHandler(
+	walletRepo storage.WalletRepository,
+	guardianRepo storage.GuardianRepository,
+	sc stripeClient.StripeClientInterface,
+) *Handler {
+	return &Handler{
+		WalletRepository:   walletRepo,
+		GuardianRepository: guardianRepo,
+		StripeClient:       sc,
+	}
+}
@@ -0,0 +1,232 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	stripemocks "skillspark/internal/stripeClient/mocks"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var guardianID = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+
+func TestHandler_GetWalletByGuardianID(t *testing.T) {
+	tests := []struct {
+		name        string
+		mockSetup   func(*repomocks.MockWalletRepository, *repomocks.MockGuardianRepository)
+		wantBalance int
+		wantErr     bool
+	}{
+		{
+			name: "returns balance and history",
+			mockSetup: func(walletRepo *repomocks.MockWalletRepository, guardianRepo *repomocks.MockGuardianRepository) {
+				guardianRepo.On("GetGuardianByID", mock.Anything, guardianID).Return(&models.Guardian{ID: guardianID}, nil)
+				walletRepo.On("GetBalance", mock.Anything, guardianID, "thb").Return(700, nil)
+				walletRepo.On("GetTransactionsByGuardianID", mock.Anything, guardianID, "thb", mock.AnythingOfType("utils.Pagination")).
+					Return([]models.GuardianWalletTransaction{
+						{TransactionType: models.WalletTransactionTypeGoodwillCredit, Amount: 700, Currency: "thb"},
+					}, nil)
+			},
+			wantBalance: 700,
+		},
+		{
+			name: "guardian not found",
+			mockSetup: func(walletRepo *repomocks.MockWalletRepository, guardianRepo *repomocks.MockGuardianRepository) {
+				notFound := errs.NotFound("Guardian", "id", guardianID)
+				guardianRepo.On("GetGuardianByID", mock.Anything, guardianID).Return(nil, &notFound)
+			},
+			wantErr: true,
+		},
+		{
+			name: "balance error",
+			mockSetup: func(walletRepo *repomocks.MockWalletRepository, guardianRepo *repomocks.MockGuardianRepository) {
+				guardianRepo.On("GetGuardianByID", mock.Anything, guardianID).Return(&models.Guardian{ID: guardianID}, nil)
+				walletRepo.On("GetBalance", mock.Anything, guardianID, "thb").Return(0, errors.New("db down"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			walletRepo := new(repomocks.MockWalletRepository)
+			guardianRepo := new(repomocks.MockGuardianRepository)
+			tt.mockSetup(walletRepo, guardianRepo)
+
+			h := NewHandler(walletRepo, guardianRepo, nil)
+			input := &models.GetWalletByGuardianIDInput{GuardianID: guardianID, Currency: "thb", Page: 1, PageSize: 10}
+
+			out, err := h.GetWalletByGuardianID(context.Background(), input)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, out)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantBalance, out.Body.Balance)
+				assert.Len(t, out.Body.Transactions, 1)
+			}
+			walletRepo.AssertExpectations(t)
+			guardianRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_IssueGoodwillCredit(t *testing.T) {
+	walletRepo := new(repomocks.MockWalletRepository)
+	guardianRepo := new(repomocks.MockGuardianRepository)
+
+	guardianRepo.On("GetGuardianByID", mock.Anything, guardianID).Return(&models.Guardian{ID: guardianID}, nil)
+	walletRepo.On("CreateTransaction", mock.Anything, mock.MatchedBy(func(d *models.CreateWalletTransactionData) bool {
+		return d.TransactionType == models.WalletTransactionTypeGoodwillCredit &&
+			d.From.Type == models.WalletAccountTypeGoodwill &&
+			d.To.Type == models.WalletAccountTypeGuardian &&
+			*d.To.GuardianID == guardianID &&
+			d.Amount == 500 &&
+			*d.Description == "late start"
+	})).Return(&models.WalletTransaction{Amount: 500}, nil)
+
+	h := NewHandler(walletRepo, guardianRepo, nil)
+	input := &models.IssueGoodwillCreditInput{GuardianID: guardianID}
+	input.Body.Amount = 500
+	input.Body.Currency = "thb"
+	input.Body.Description = "late start"
+
+	out, err := h.IssueGoodwillCredit(context.Background(), input)
+
+	require.NoError(t, err)
+	assert.Equal(t, 500, out.Body.Amount)
+	walletRepo.AssertExpectations(t)
+}
+
+func TestHandler_GetAllWalletTransactions(t *testing.T) {
+	walletRepo := new(repomocks.MockWalletRepository)
+	walletRepo.On("GetAllTransactions", mock.Anything, mock.AnythingOfType("utils.Pagination")).
+		Return([]models.WalletTransaction{{Amount: 1}, {Amount: 2}}, nil)
+
+	h := NewHandler(walletRepo, nil, nil)
+	out, err := h.GetAllWalletTransactions(context.Background(), &models.GetAllWalletTransactionsInput{Page: 1, PageSize: 10})
+
+	require.NoError(t, err)
+	assert.Len(t, out.Body, 2)
+}
+
+func TestHandler_PurchaseGiftCard(t *testing.T) {
+	customerID := "cus_123"
+
+	tests := []struct {
+		name      string
+		mockSetup func(*repomocks.MockWalletRepository, *repomocks.MockGuardianRepository, *stripemocks.MockStripeClient)
+		wantErr   bool
+	}{
+		{
+			name: "charges and stores the gift card",
+			mockSetup: func(walletRepo *repomocks.MockWalletRepository, guardianRepo *repomocks.MockGuardianRepository, sc *stripemocks.MockStripeClient) {
+				guardianRepo.On("GetGuardianByID", mock.Anything, guardianID).Return(&models.Guardian{ID: guardianID, StripeCustomerID: &customerID}, nil)
+				charge := &models.ChargeCustomerOutput{}
+				charge.Body.PaymentIntentID = "pi_123"
+				charge.Body.Status = "succeeded"
+				sc.On("ChargeCustomer", mock.Anything, mock.MatchedBy(func(in *models.ChargeCustomerInput) bool {
+					return in.CustomerID == customerID && in.Amount == 2000 && in.PaymentMethodID == "pm_card_visa"
+				})).Return(charge, nil)
+				walletRepo.On("CreateGiftCard", mock.Anything, mock.MatchedBy(func(d *models.CreateGiftCardData) bool {
+					return len(d.Code) == 14 && d.Amount == 2000 && *d.StripePaymentIntentID == "pi_123"
+				})).Return(&models.GiftCard{Code: "ABCD-EFGH-JKMN", Amount: 2000}, nil)
+			},
+		},
+		{
+			name: "guardian without stripe customer",
+			mockSetup: func(walletRepo *repomocks.MockWalletRepository, guardianRepo *repomocks.MockGuardianRepository, sc *stripemocks.MockStripeClient) {
+				guardianRepo.On("GetGuardianByID", mock.Anything, guardianID).Return(&models.Guardian{ID: guardianID}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "charge declined",
+			mockSetup: func(walletRepo *repomocks.MockWalletRepository, guardianRepo *repomocks.MockGuardianRepository, sc *stripemocks.MockStripeClient) {
+				guardianRepo.On("GetGuardianByID", mock.Anything, guardianID).Return(&models.Guardian{ID: guardianID, StripeCustomerID: &customerID}, nil)
+				sc.On("ChargeCustomer", mock.Anything, mock.Anything).Return(nil, errors.New("card_declined"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "refunds when the gift card cannot be stored",
+			mockSetup: func(walletRepo *repomocks.MockWalletRepository, guardianRepo *repomocks.MockGuardianRepository, sc *stripemocks.MockStripeClient) {
+				guardianRepo.On("GetGuardianByID", mock.Anything, guardianID).Return(&models.Guardian{ID: guardianID, StripeCustomerID: &customerID}, nil)
+				charge := &models.ChargeCustomerOutput{}
+				charge.Body.PaymentIntentID = "pi_456"
+				charge.Body.Status = "succeeded"
+				sc.On("ChargeCustomer", mock.Anything, mock.Anything).Return(charge, nil)
+				dbErr := errs.InternalServerError("Failed to create gift card")
+				walletRepo.On("CreateGiftCard", mock.Anything, mock.Anything).Return(nil, &dbErr)
+				sc.On("RefundPayment", mock.Anything, &models.RefundPaymentInput{PaymentIntentID: "pi_456"}).
+					Return(&models.RefundPaymentOutput{}, nil)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			walletRepo := new(repomocks.MockWalletRepository)
+			guardianRepo := new(repomocks.MockGuardianRepository)
+			sc := new(stripemocks.MockStripeClient)
+			tt.mockSetup(walletRepo, guardianRepo, sc)
+
+			h := NewHandler(walletRepo, guardianRepo, sc)
+			input := &models.PurchaseGiftCardInput{GuardianID: guardianID}
+			input.Body.Amount = 2000
+			input.Body.Currency = "thb"
+			input.Body.PaymentMethodID = "pm_card_visa"
+
+			out, err := h.PurchaseGiftCard(context.Background(), input)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, out)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, 2000, out.Body.Amount)
+			}
+			walletRepo.AssertExpectations(t)
+			sc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_RedeemGiftCard(t *testing.T) {
+	walletRepo := new(repomocks.MockWalletRepository)
+	guardianRepo := new(repomocks.MockGuardianRepository)
+
+	guardianRepo.On("GetGuardianByID", mock.Anything, guardianID).Return(&models.Guardian{ID: guardianID}, nil)
+	walletRepo.On("RedeemGiftCard", mock.Anything, "ABCD-EFGH-JKMN", guardianID).
+		Return(&models.WalletTransaction{TransactionType: models.WalletTransactionTypeGiftCardRedemption, Amount: 1000}, nil)
+
+	h := NewHandler(walletRepo, guardianRepo, nil)
+	input := &models.RedeemGiftCardInput{GuardianID: guardianID}
+	input.Body.Code = " abcd-efgh-jkmn "
+
+	out, err := h.RedeemGiftCard(context.Background(), input)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1000, out.Body.Amount)
+	walletRepo.AssertExpectations(t)
+}
+
+func TestGenerateGiftCardCode(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		code, err := generateGiftCardCode()
+		require.NoError(t, err)
+		assert.Regexp(t, `^[A-HJKMNP-Z2-9]{4}-[A-HJKMNP-Z2-9]{4}-[A-HJKMNP-Z2-9]{4}$`, code)
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+}
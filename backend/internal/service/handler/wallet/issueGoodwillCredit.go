@@ -0,0 +1,31 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/models"
+)
+
+func (h *Handler) IssueGoodwillCredit(ctx context.Context, input *models.IssueGoodwillCreditInput) (*models.IssueGoodwillCreditOutput, error) {
+	if _, err := h.GuardianRepository.GetGuardianByID(ctx, input.GuardianID); err != nil {
+		return nil, err
+	}
+
+	var description *string
+	if input.Body.Description != "" {
+		description = &input.Body.Description
+	}
+
+	transaction, err := h.WalletRepository.CreateTransaction(ctx, &models.CreateWalletTransactionData{
+		TransactionType: models.WalletTransactionTypeGoodwillCredit,
+		Amount:          input.Body.Amount,
+		Currency:        input.Body.Currency,
+		From:            models.WalletAccountRef{Type: models.WalletAccountTypeGoodwill},
+		To:              models.WalletAccountRef{Type: models.WalletAccountTypeGuardian, GuardianID: &input.GuardianID},
+		Description:     description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.IssueGoodwillCreditOutput{Body: *transaction}, nil
+}
@@ -0,0 +1,63 @@
+package wallet
+
+import (
+	"context"
+	"log/slog"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+)
+
+func (h *Handler) PurchaseGiftCard(ctx context.Context, input *models.PurchaseGiftCardInput) (*models.PurchaseGiftCardOutput, error) {
+	guardian, err := h.GuardianRepository.GetGuardianByID(ctx, input.GuardianID)
+	if err != nil {
+		return nil, err
+	}
+
+	if guardian.StripeCustomerID == nil || *guardian.StripeCustomerID == "" {
+		return nil, errs.BadRequest("Guardian does not have a Stripe customer account")
+	}
+
+	code, err := generateGiftCardCode()
+	if err != nil {
+		return nil, errs.InternalServerError("Failed to generate gift card code: ", err.Error())
+	}
+
+	charge, err := h.StripeClient.ChargeCustomer(ctx, &models.ChargeCustomerInput{
+		CustomerID:      *guardian.StripeCustomerID,
+		PaymentMethodID: input.Body.PaymentMethodID,
+		Amount:          int64(input.Body.Amount),
+		Currency:        input.Body.Currency,
+		Description:     "SkillSpark gift card",
+		Metadata: map[string]string{
+			"guardian_id":    input.GuardianID.String(),
+			"gift_card_code": code,
+		},
+	})
+	if err != nil {
+		return nil, errs.BadRequest("Failed to charge payment method: " + err.Error())
+	}
+
+	if charge.Body.Status != "succeeded" {
+		return nil, errs.BadRequest("Gift card payment did not succeed: " + charge.Body.Status)
+	}
+
+	giftCard, err := h.WalletRepository.CreateGiftCard(ctx, &models.CreateGiftCardData{
+		Code:                  code,
+		Amount:                input.Body.Amount,
+		Currency:              input.Body.Currency,
+		PurchaserGuardianID:   &input.GuardianID,
+		RecipientEmail:        input.Body.RecipientEmail,
+		StripePaymentIntentID: &charge.Body.PaymentIntentID,
+	})
+	if err != nil {
+		// the customer was charged but the card was not stored; refund so they are not out of pocket
+		if _, refundErr := h.StripeClient.RefundPayment(ctx, &models.RefundPaymentInput{
+			PaymentIntentID: charge.Body.PaymentIntentID,
+		}); refundErr != nil {
+			slog.Error("failed to refund gift card charge", "payment_intent_id", charge.Body.PaymentIntentID, "error", refundErr)
+		}
+		return nil, err
+	}
+
+	return &models.PurchaseGiftCardOutput{Body: *giftCard}, nil
+}
@@ -0,0 +1,22 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"strings"
+)
+
+func (h *Handler) RedeemGiftCard(ctx context.Context, input *models.RedeemGiftCardInput) (*models.RedeemGiftCardOutput, error) {
+	if _, err := h.GuardianRepository.GetGuardianByID(ctx, input.GuardianID); err != nil {
+		return nil, err
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(input.Body.Code))
+
+	transaction, err := h.WalletRepository.RedeemGiftCard(ctx, code, input.GuardianID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RedeemGiftCardOutput{Body: *transaction}, nil
+}
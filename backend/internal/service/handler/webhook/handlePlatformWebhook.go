@@ -48,6 +48,12 @@ func (h *Handler) handlePaymentIntentFailed(ctx context.Context, event stripe.Ev
 		return err
 	}
 
+	// Stripe delivers events at least once, and cancelling twice is rejected
+	if registration.Status == models.RegistrationStatusCancelled {
+		log.Printf("Registration %s for failed payment intent %s is already cancelled", registration.ID, pi.ID)
+		return nil
+	}
+
 	cancelledStatus := models.RegistrationStatusCancelled
 	piStatus := string(pi.Status)
 	input := &models.CancelRegistrationInput{
@@ -56,7 +62,20 @@ func (h *Handler) handlePaymentIntentFailed(ctx context.Context, event stripe.Ev
 		PaymentIntentStatus: &piStatus,
 	}
 
-	if _, err := h.repo.Registration.CancelRegistration(ctx, input); err != nil {
+	// credit applied towards the failed charge goes back to the wallet, so the organization's
+	// share of it is taken back
+	if registration.StripeTransferID != "" {
+		_, err := h.stripeClient.ReverseTransfer(ctx, &models.ReverseTransferInput{
+			TransferID:     registration.StripeTransferID,
+			IdempotencyKey: "transfer_reversal:" + registration.ID.String(),
+		})
+		if err != nil {
+			log.Printf("Failed to reverse credit transfer for registration %s: %v", registration.ID, err)
+			return err
+		}
+	}
+
+	if _, err := h.repo.Wallet.CancelRegistrationWithCredit(ctx, input, 0); err != nil {
 		log.Printf("Failed to cancel registration %s: %v", registration.ID, err)
 		return err
 	}
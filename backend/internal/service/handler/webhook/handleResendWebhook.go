@@ -0,0 +1,189 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"skillspark/internal/delivery"
+	"skillspark/internal/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// resendWebhookTolerance is how far a webhook's timestamp may be from now, to stop replays
+const resendWebhookTolerance = 5 * time.Minute
+
+var errInvalidResendSignature = errors.New("invalid resend webhook signature")
+
+type resendEvent struct {
+	Type string `json:"type"`
+	Data struct {
+		EmailID string          `json:"email_id"`
+		To      []string        `json:"to"`
+		Tags    json.RawMessage `json:"tags"`
+		Bounce  *struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"bounce"`
+	} `json:"data"`
+}
+
+// HandleResendWebhook records what happened to emails sent through Resend. Bounces and
+// spam complaints also stop email to the guardian, see delivery.OutcomeRecorder.
+func (h *Handler) HandleResendWebhook(c *fiber.Ctx) error {
+	payload := c.Body()
+
+	err := verifyResendSignature(h.resendWebhookSecret, c.Get("svix-id"), c.Get("svix-timestamp"), c.Get("svix-signature"), payload, time.Now())
+	if err != nil {
+		log.Printf("Resend webhook signature verification failed: %v", err)
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "invalid signature",
+		})
+	}
+
+	var event resendEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		log.Printf("Failed to unmarshal resend webhook: %v", err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid payload",
+		})
+	}
+
+	outcome, ok := resendOutcome(&event)
+	if !ok {
+		log.Printf("Unhandled resend event type: %s", event.Type)
+		return c.SendStatus(fiber.StatusOK)
+	}
+
+	if err := h.recordResendOutcome(c.Context(), &event, outcome); err != nil {
+		log.Printf("Failed to record resend %s for %s: %v", event.Type, event.Data.EmailID, err)
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *Handler) recordResendOutcome(ctx context.Context, event *resendEvent, outcome models.DeliveryOutcome) error {
+	tags := resendTags(event.Data.Tags)
+	outcomeEvent := &models.DeliveryOutcomeEvent{
+		Outcome:        outcome,
+		Channel:        models.NotificationTypeEmail,
+		Provider:       "resend",
+		NotificationID: tagUUID(tags, "notification_id"),
+		GuardianID:     tagUUID(tags, "guardian_id"),
+	}
+	if event.Data.EmailID != "" {
+		outcomeEvent.ProviderMessageID = &event.Data.EmailID
+	}
+	if len(event.Data.To) > 0 {
+		outcomeEvent.Recipient = event.Data.To[0]
+	}
+	if event.Data.Bounce != nil {
+		detail := strings.TrimSpace(event.Data.Bounce.Type + ": " + event.Data.Bounce.Message)
+		outcomeEvent.Detail = &detail
+	}
+
+	return delivery.NewOutcomeRecorder(h.repo.Notification, h.repo.Guardian).Record(ctx, outcomeEvent)
+}
+
+// resendOutcome maps a Resend event type to the outcome it reports. Transient bounces
+// (a full mailbox, a greylisting server) are failures, but not a reason to stop emailing.
+func resendOutcome(event *resendEvent) (models.DeliveryOutcome, bool) {
+	switch event.Type {
+	case "email.delivered":
+		return models.DeliveryOutcomeDelivered, true
+	case "email.bounced":
+		if event.Data.Bounce != nil && strings.EqualFold(event.Data.Bounce.Type, "Transient") {
+			return models.DeliveryOutcomeFailed, true
+		}
+		return models.DeliveryOutcomeBounced, true
+	case "email.complained":
+		return models.DeliveryOutcomeComplained, true
+	default:
+		return "", false
+	}
+}
+
+// resendTags reads the tags sent with the email, which Resend reports either as an object
+// or as a list of name/value pairs
+func resendTags(raw json.RawMessage) map[string]string {
+	tags := map[string]string{}
+	if len(raw) == 0 {
+		return tags
+	}
+	if err := json.Unmarshal(raw, &tags); err == nil {
+		return tags
+	}
+
+	var pairs []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(raw, &pairs); err != nil {
+		return map[string]string{}
+	}
+	for _, pair := range pairs {
+		tags[pair.Name] = pair.Value
+	}
+	return tags
+}
+
+func tagUUID(tags map[string]string, name string) *uuid.UUID {
+	id, err := uuid.Parse(tags[name])
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// verifyResendSignature checks a Resend (Svix) webhook signature: an HMAC-SHA256 of
+// "id.timestamp.body", keyed with the base64 part of the "whsec_" secret. The header may
+// hold several space separated "v1,<signature>" entries while a secret is being rotated.
+func verifyResendSignature(secret, id, timestamp, signatures string, payload []byte, now time.Time) error {
+	if secret == "" || id == "" || timestamp == "" || signatures == "" {
+		return errInvalidResendSignature
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, "whsec_"))
+	if err != nil {
+		return fmt.Errorf("failed to decode webhook secret: %w", err)
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errInvalidResendSignature
+	}
+	sentAt := time.Unix(seconds, 0)
+	if sentAt.Before(now.Add(-resendWebhookTolerance)) || sentAt.After(now.Add(resendWebhookTolerance)) {
+		return fmt.Errorf("%w: timestamp outside tolerance", errInvalidResendSignature)
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id + "." + timestamp + "."))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+
+	for _, entry := range strings.Fields(signatures) {
+		version, signature, ok := strings.Cut(entry, ",")
+		if !ok || version != "v1" {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(signature)
+		if err != nil {
+			continue
+		}
+		if hmac.Equal(decoded, expected) {
+			return nil
+		}
+	}
+	return errInvalidResendSignature
+}
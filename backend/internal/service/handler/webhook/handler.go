@@ -13,13 +13,15 @@ type Handler struct {
 	stripeClient         stripeClient.StripeClientInterface
 	webhookSecret        string
 	connectWebhookSecret string
+	resendWebhookSecret  string
 }
 
-func NewHandler(repo *storage.Repository, webhookSecret string, connectWebhookSecret string, sc stripeClient.StripeClientInterface) *Handler {
+func NewHandler(repo *storage.Repository, webhookSecret string, connectWebhookSecret string, resendWebhookSecret string, sc stripeClient.StripeClientInterface) *Handler {
 	return &Handler{
 		repo:                 repo,
 		webhookSecret:        webhookSecret,
 		connectWebhookSecret: connectWebhookSecret,
+		resendWebhookSecret:  resendWebhookSecret,
 		stripeClient:         sc,
 	}
 }
@@ -2,14 +2,23 @@ package webhook
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"skillspark/internal/errs"
 	"skillspark/internal/models"
 	"skillspark/internal/storage"
 	repomocks "skillspark/internal/storage/repo-mocks"
 	stripemocks "skillspark/internal/stripeClient/mocks"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -70,12 +79,14 @@ func makePaymentMethodEventNoCustomer(pmID string) stripe.Event {
 func newHandler(
 	regRepo *repomocks.MockRegistrationRepository,
 	orgRepo *repomocks.MockOrganizationRepository,
+	walletRepo *repomocks.MockWalletRepository,
 	sc *stripemocks.MockStripeClient,
 ) *Handler {
 	return &Handler{
 		repo: &storage.Repository{
 			Registration: regRepo,
 			Organization: orgRepo,
+			Wallet:       walletRepo,
 		},
 		stripeClient: sc,
 	}
@@ -88,24 +99,48 @@ func TestHandler_HandlePaymentIntentFailed(t *testing.T) {
 	tests := []struct {
 		name      string
 		event     stripe.Event
-		mockSetup func(*repomocks.MockRegistrationRepository)
+		mockSetup func(*repomocks.MockRegistrationRepository, *repomocks.MockWalletRepository, *stripemocks.MockStripeClient)
 		wantErr   bool
 	}{
 		{
 			name:  "successful — cancels registration",
 			event: makePaymentIntentEvent(piID, stripe.PaymentIntentStatusRequiresPaymentMethod),
-			mockSetup: func(regRepo *repomocks.MockRegistrationRepository) {
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
 				regRepo.On("GetRegistrationByPaymentIntentID", mock.Anything, piID, "en-US").
 					Return(&models.Registration{ID: regID}, nil)
-				regRepo.On("CancelRegistration", mock.Anything, mock.AnythingOfType("*models.CancelRegistrationInput")).
+				walletRepo.On("CancelRegistrationWithCredit", mock.Anything, mock.AnythingOfType("*models.CancelRegistrationInput"), 0).
 					Return(&models.CancelRegistrationOutput{}, nil)
 			},
 			wantErr: false,
 		},
+		{
+			name:  "paid partly with credit — reverses credit transfer and cancels",
+			event: makePaymentIntentEvent(piID, stripe.PaymentIntentStatusRequiresPaymentMethod),
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
+				regRepo.On("GetRegistrationByPaymentIntentID", mock.Anything, piID, "en-US").
+					Return(&models.Registration{ID: regID, StripeTransferID: "tr_credit_share"}, nil)
+				sc.On("ReverseTransfer", mock.Anything, &models.ReverseTransferInput{
+					TransferID:     "tr_credit_share",
+					IdempotencyKey: "transfer_reversal:" + regID.String(),
+				}).Return(&models.ReverseTransferOutput{}, nil)
+				walletRepo.On("CancelRegistrationWithCredit", mock.Anything, mock.AnythingOfType("*models.CancelRegistrationInput"), 0).
+					Return(&models.CancelRegistrationOutput{}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:  "already cancelled — redelivered event is ignored",
+			event: makePaymentIntentEvent(piID, stripe.PaymentIntentStatusRequiresPaymentMethod),
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
+				regRepo.On("GetRegistrationByPaymentIntentID", mock.Anything, piID, "en-US").
+					Return(&models.Registration{ID: regID, Status: models.RegistrationStatusCancelled}, nil)
+			},
+			wantErr: false,
+		},
 		{
 			name:  "registration not found — returns error",
 			event: makePaymentIntentEvent(piID, stripe.PaymentIntentStatusRequiresPaymentMethod),
-			mockSetup: func(regRepo *repomocks.MockRegistrationRepository) {
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
 				regRepo.On("GetRegistrationByPaymentIntentID", mock.Anything, piID, "en-US").
 					Return(nil, &errs.HTTPError{Code: 404, Message: "registration not found"})
 			},
@@ -114,10 +149,10 @@ func TestHandler_HandlePaymentIntentFailed(t *testing.T) {
 		{
 			name:  "cancel registration fails — returns error",
 			event: makePaymentIntentEvent(piID, stripe.PaymentIntentStatusRequiresPaymentMethod),
-			mockSetup: func(regRepo *repomocks.MockRegistrationRepository) {
+			mockSetup: func(regRepo *repomocks.MockRegistrationRepository, walletRepo *repomocks.MockWalletRepository, sc *stripemocks.MockStripeClient) {
 				regRepo.On("GetRegistrationByPaymentIntentID", mock.Anything, piID, "en-US").
 					Return(&models.Registration{ID: regID}, nil)
-				regRepo.On("CancelRegistration", mock.Anything, mock.AnythingOfType("*models.CancelRegistrationInput")).
+				walletRepo.On("CancelRegistrationWithCredit", mock.Anything, mock.AnythingOfType("*models.CancelRegistrationInput"), 0).
 					Return(nil, &errs.HTTPError{Code: 500, Message: "db error"})
 			},
 			wantErr: true,
@@ -131,10 +166,11 @@ func TestHandler_HandlePaymentIntentFailed(t *testing.T) {
 
 			mockRegRepo := new(repomocks.MockRegistrationRepository)
 			mockOrgRepo := new(repomocks.MockOrganizationRepository)
+			mockWalletRepo := new(repomocks.MockWalletRepository)
 			mockStripeClient := new(stripemocks.MockStripeClient)
-			tt.mockSetup(mockRegRepo)
+			tt.mockSetup(mockRegRepo, mockWalletRepo, mockStripeClient)
 
-			handler := newHandler(mockRegRepo, mockOrgRepo, mockStripeClient)
+			handler := newHandler(mockRegRepo, mockOrgRepo, mockWalletRepo, mockStripeClient)
 			err := handler.handlePaymentIntentFailed(context.Background(), tt.event)
 
 			if tt.wantErr {
@@ -144,6 +180,8 @@ func TestHandler_HandlePaymentIntentFailed(t *testing.T) {
 			}
 
 			mockRegRepo.AssertExpectations(t)
+			mockWalletRepo.AssertExpectations(t)
+			mockStripeClient.AssertExpectations(t)
 		})
 	}
 }
@@ -214,7 +252,7 @@ func TestHandler_HandleAccountUpdated(t *testing.T) {
 			mockStripeClient := new(stripemocks.MockStripeClient)
 			tt.mockSetup(mockOrgRepo)
 
-			handler := newHandler(mockRegRepo, mockOrgRepo, mockStripeClient)
+			handler := newHandler(mockRegRepo, mockOrgRepo, nil, mockStripeClient)
 			err := handler.handleAccountUpdated(context.Background(), tt.event)
 
 			if tt.wantErr {
@@ -318,7 +356,7 @@ func TestHandler_HandlePaymentMethodAttached(t *testing.T) {
 			mockStripeClient := new(stripemocks.MockStripeClient)
 			tt.mockSetup(mockStripeClient)
 
-			handler := newHandler(mockRegRepo, mockOrgRepo, mockStripeClient)
+			handler := newHandler(mockRegRepo, mockOrgRepo, nil, mockStripeClient)
 			err := handler.handlePaymentMethodAdditionSuccess(context.Background(), tt.event)
 
 			if tt.wantErr {
@@ -331,3 +369,143 @@ func TestHandler_HandlePaymentMethodAttached(t *testing.T) {
 		})
 	}
 }
+
+const testResendSecret = "whsec_dGVzdC1yZXNlbmQtc2VjcmV0"
+
+func signResendWebhook(t *testing.T, id string, sentAt time.Time, body string) string {
+	t.Helper()
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(testResendSecret, "whsec_"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id + "." + strconv.FormatInt(sentAt.Unix(), 10) + "." + body))
+	return "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyResendSignature(t *testing.T) {
+	now := time.Now()
+	body := `{"type":"email.delivered"}`
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	valid := signResendWebhook(t, "msg_1", now, body)
+
+	tests := []struct {
+		name       string
+		secret     string
+		timestamp  string
+		signatures string
+		body       string
+		wantErr    bool
+	}{
+		{name: "valid", secret: testResendSecret, timestamp: timestamp, signatures: valid, body: body},
+		{name: "one of several signatures valid", secret: testResendSecret, timestamp: timestamp, signatures: "v1,aW52YWxpZA== " + valid, body: body},
+		{name: "tampered body", secret: testResendSecret, timestamp: timestamp, signatures: valid, body: `{"type":"email.bounced"}`, wantErr: true},
+		{name: "stale timestamp", secret: testResendSecret, timestamp: strconv.FormatInt(now.Add(-time.Hour).Unix(), 10), signatures: signResendWebhook(t, "msg_1", now.Add(-time.Hour), body), body: body, wantErr: true},
+		{name: "no secret configured", secret: "", timestamp: timestamp, signatures: valid, body: body, wantErr: true},
+		{name: "missing signature", secret: testResendSecret, timestamp: timestamp, signatures: "", body: body, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyResendSignature(tt.secret, "msg_1", tt.timestamp, tt.signatures, []byte(tt.body), now)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHandler_HandleResendWebhook(t *testing.T) {
+	notificationID := uuid.New()
+	guardianID := uuid.New()
+
+	tests := []struct {
+		name       string
+		body       string
+		signed     bool
+		mockSetup  func(*repomocks.MockNotificationRepository, *repomocks.MockGuardianRepository)
+		wantStatus int
+	}{
+		{
+			name:   "delivered is recorded",
+			body:   `{"type":"email.delivered","data":{"email_id":"em_1","to":["parent@example.com"],"tags":{"notification_id":"` + notificationID.String() + `"}}}`,
+			signed: true,
+			mockSetup: func(n *repomocks.MockNotificationRepository, g *repomocks.MockGuardianRepository) {
+				n.On("RecordNotificationOutcome", mock.Anything, mock.MatchedBy(func(event *models.DeliveryOutcomeEvent) bool {
+					return event.Outcome == models.DeliveryOutcomeDelivered && *event.NotificationID == notificationID && *event.ProviderMessageID == "em_1"
+				})).Return(&models.OutcomeNotification{ID: notificationID}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "hard bounce disables email",
+			body:   `{"type":"email.bounced","data":{"email_id":"em_1","to":["gone@example.com"],"tags":[{"name":"guardian_id","value":"` + guardianID.String() + `"}],"bounce":{"type":"Permanent","message":"mailbox does not exist"}}}`,
+			signed: true,
+			mockSetup: func(n *repomocks.MockNotificationRepository, g *repomocks.MockGuardianRepository) {
+				n.On("RecordNotificationOutcome", mock.Anything, mock.MatchedBy(func(event *models.DeliveryOutcomeEvent) bool {
+					return event.Outcome == models.DeliveryOutcomeBounced && *event.GuardianID == guardianID && event.Recipient == "gone@example.com"
+				})).Return(nil, nil).Once()
+				g.On("DisableGuardianNotificationChannel", mock.Anything, guardianID, models.NotificationTypeEmail).Return(nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "transient bounce is only a failure",
+			body:   `{"type":"email.bounced","data":{"email_id":"em_1","to":["full@example.com"],"bounce":{"type":"Transient","message":"mailbox full"}}}`,
+			signed: true,
+			mockSetup: func(n *repomocks.MockNotificationRepository, g *repomocks.MockGuardianRepository) {
+				n.On("RecordNotificationOutcome", mock.Anything, mock.MatchedBy(func(event *models.DeliveryOutcomeEvent) bool {
+					return event.Outcome == models.DeliveryOutcomeFailed
+				})).Return(&models.OutcomeNotification{ID: notificationID, GuardianID: &guardianID}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unhandled event type is acknowledged",
+			body:       `{"type":"email.opened","data":{"email_id":"em_1"}}`,
+			signed:     true,
+			mockSetup:  func(n *repomocks.MockNotificationRepository, g *repomocks.MockGuardianRepository) {},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unsigned request is rejected",
+			body:       `{"type":"email.complained","data":{"email_id":"em_1"}}`,
+			mockSetup:  func(n *repomocks.MockNotificationRepository, g *repomocks.MockGuardianRepository) {},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNotifRepo := new(repomocks.MockNotificationRepository)
+			mockGuardianRepo := new(repomocks.MockGuardianRepository)
+			tt.mockSetup(mockNotifRepo, mockGuardianRepo)
+
+			handler := &Handler{
+				repo:                &storage.Repository{Notification: mockNotifRepo, Guardian: mockGuardianRepo},
+				resendWebhookSecret: testResendSecret,
+			}
+			app := fiber.New()
+			app.Post("/webhooks/resend", handler.HandleResendWebhook)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/resend", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.signed {
+				now := time.Now()
+				req.Header.Set("svix-id", "msg_1")
+				req.Header.Set("svix-timestamp", strconv.FormatInt(now.Unix(), 10))
+				req.Header.Set("svix-signature", signResendWebhook(t, "msg_1", now, tt.body))
+			}
+
+			resp, err := app.Test(req)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			mockNotifRepo.AssertExpectations(t)
+			mockGuardianRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package routes_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"skillspark/internal/models"
+	"skillspark/internal/service/routes"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupBroadcastTestAPI(broadcastRepo *repomocks.MockBroadcastRepository, managerRepo *repomocks.MockManagerRepository, eventRepo *repomocks.MockEventRepository) (*fiber.App, huma.API) {
+	app := fiber.New()
+	api := humafiber.New(app, huma.DefaultConfig("Test Broadcast API", "1.0.0"))
+	repo := &storage.Repository{
+		Broadcast:       broadcastRepo,
+		Manager:         managerRepo,
+		Event:           eventRepo,
+		EventOccurrence: new(repomocks.MockEventOccurrenceRepository),
+	}
+	routes.SetupBroadcastRoutes(api, repo)
+	return app, api
+}
+
+func TestCreateBroadcast_Success(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	managerID := uuid.New()
+	eventID := uuid.New()
+
+	broadcastRepo := new(repomocks.MockBroadcastRepository)
+	managerRepo := new(repomocks.MockManagerRepository)
+	managerRepo.On("GetManagerByID", mock.Anything, managerID).Return(&models.Manager{ID: managerID, OrganizationID: orgID}, nil)
+	eventRepo := new(repomocks.MockEventRepository)
+	eventRepo.On("GetEventByID", mock.Anything, eventID, "en-US").Return(&models.Event{ID: eventID, OrganizationID: orgID}, nil)
+	broadcastRepo.On("CountRecentBroadcasts", mock.Anything, orgID, mock.AnythingOfType("time.Time")).Return(0, nil)
+	broadcastRepo.On("CreateBroadcast", mock.Anything, mock.MatchedBy(func(data *models.CreateBroadcastData) bool {
+		return data.OrganizationID == orgID && data.TargetType == models.BroadcastTargetEvent && data.TargetID == eventID
+	})).Return(&models.Broadcast{ID: uuid.New(), OrganizationID: orgID, TargetType: models.BroadcastTargetEvent, TargetID: eventID, Status: models.BroadcastStatusScheduled}, nil)
+
+	app, _ := setupBroadcastTestAPI(broadcastRepo, managerRepo, eventRepo)
+
+	body, _ := json.Marshal(map[string]any{
+		"manager_id":  managerID,
+		"target_type": "event",
+		"target_id":   eventID,
+		"subject_en":  "Bring swimsuits",
+		"body_en":     "Saturday's class is at the pool.",
+	})
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/organizations/"+orgID.String()+"/broadcasts", bytes.NewReader(body))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var broadcast models.Broadcast
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&broadcast))
+	assert.Equal(t, models.BroadcastStatusScheduled, broadcast.Status)
+	broadcastRepo.AssertExpectations(t)
+}
+
+func TestCreateBroadcast_TargetOfAnotherOrganization(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	managerID := uuid.New()
+	eventID := uuid.New()
+
+	broadcastRepo := new(repomocks.MockBroadcastRepository)
+	managerRepo := new(repomocks.MockManagerRepository)
+	managerRepo.On("GetManagerByID", mock.Anything, managerID).Return(&models.Manager{ID: managerID, OrganizationID: orgID}, nil)
+	eventRepo := new(repomocks.MockEventRepository)
+	eventRepo.On("GetEventByID", mock.Anything, eventID, "en-US").Return(&models.Event{ID: eventID, OrganizationID: uuid.New()}, nil)
+
+	app, _ := setupBroadcastTestAPI(broadcastRepo, managerRepo, eventRepo)
+
+	body, _ := json.Marshal(map[string]any{
+		"manager_id":  managerID,
+		"target_type": "event",
+		"target_id":   eventID,
+		"subject_en":  "Bring swimsuits",
+		"body_en":     "Saturday's class is at the pool.",
+	})
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/organizations/"+orgID.String()+"/broadcasts", bytes.NewReader(body))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	broadcastRepo.AssertNotCalled(t, "CreateBroadcast", mock.Anything, mock.Anything)
+}
+
+func TestCreateBroadcast_RateLimited(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	managerID := uuid.New()
+
+	broadcastRepo := new(repomocks.MockBroadcastRepository)
+	managerRepo := new(repomocks.MockManagerRepository)
+	managerRepo.On("GetManagerByID", mock.Anything, managerID).Return(&models.Manager{ID: managerID, OrganizationID: orgID}, nil)
+	broadcastRepo.On("CountRecentBroadcasts", mock.Anything, orgID, mock.AnythingOfType("time.Time")).Return(5, nil)
+
+	app, _ := setupBroadcastTestAPI(broadcastRepo, managerRepo, new(repomocks.MockEventRepository))
+
+	body, _ := json.Marshal(map[string]any{
+		"manager_id":  managerID,
+		"target_type": "organization",
+		"subject_en":  "Closed on Monday",
+		"body_en":     "We are closed for the holiday.",
+	})
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/organizations/"+orgID.String()+"/broadcasts", bytes.NewReader(body))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	broadcastRepo.AssertNotCalled(t, "CreateBroadcast", mock.Anything, mock.Anything)
+}
+
+func TestCreateBroadcast_InvalidTargetType(t *testing.T) {
+	t.Parallel()
+
+	app, _ := setupBroadcastTestAPI(new(repomocks.MockBroadcastRepository), new(repomocks.MockManagerRepository), new(repomocks.MockEventRepository))
+
+	body, _ := json.Marshal(map[string]any{
+		"manager_id":  uuid.New(),
+		"target_type": "everyone",
+		"subject_en":  "Hello",
+		"body_en":     "Hello",
+	})
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/organizations/"+uuid.New().String()+"/broadcasts", bytes.NewReader(body))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestGetBroadcast_Success(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	broadcastID := uuid.New()
+	recipients := 3
+
+	broadcastRepo := new(repomocks.MockBroadcastRepository)
+	broadcastRepo.On("GetBroadcastByID", mock.Anything, orgID, broadcastID).
+		Return(&models.Broadcast{ID: broadcastID, OrganizationID: orgID, Status: models.BroadcastStatusSent, RecipientCount: &recipients}, nil)
+	broadcastRepo.On("GetBroadcastStats", mock.Anything, broadcastID).
+		Return([]models.BroadcastChannelStats{{Channel: models.NotificationTypeEmail, Delivered: 2, Bounced: 1}}, nil)
+
+	app, _ := setupBroadcastTestAPI(broadcastRepo, new(repomocks.MockManagerRepository), new(repomocks.MockEventRepository))
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/organizations/"+orgID.String()+"/broadcasts/"+broadcastID.String(), nil)
+	assert.NoError(t, err)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var broadcast models.BroadcastWithStats
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&broadcast))
+	assert.Equal(t, 3, broadcast.Stats.Recipients)
+	assert.Len(t, broadcast.Stats.Channels, 1)
+	assert.Equal(t, 1, broadcast.Stats.Channels[0].Bounced)
+	broadcastRepo.AssertExpectations(t)
+}
+
+func TestCancelBroadcast_Success(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	broadcastID := uuid.New()
+
+	broadcastRepo := new(repomocks.MockBroadcastRepository)
+	broadcastRepo.On("CancelBroadcast", mock.Anything, orgID, broadcastID).
+		Return(&models.Broadcast{ID: broadcastID, Status: models.BroadcastStatusCancelled}, nil)
+
+	app, _ := setupBroadcastTestAPI(broadcastRepo, new(repomocks.MockManagerRepository), new(repomocks.MockEventRepository))
+
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/organizations/"+orgID.String()+"/broadcasts/"+broadcastID.String()+"/cancel", nil)
+	assert.NoError(t, err)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	broadcastRepo.AssertExpectations(t)
+}
@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/models"
+	"skillspark/internal/service/handler/broadcast"
+	"skillspark/internal/storage"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+func SetupBroadcastRoutes(api huma.API, repo *storage.Repository) {
+	broadcastHandler := broadcast.NewHandler(repo.Broadcast, repo.Manager, repo.Event, repo.EventOccurrence)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-broadcast",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/organizations/{organization_id}/broadcasts",
+		Summary:     "Send a broadcast",
+		Description: "Sends a message, now or at a scheduled time, to the guardians registered for an occurrence, an event's upcoming occurrences, or any of the organization's upcoming occurrences. Guardians who use the app in Thai get the Thai version when one is given. Organizations can send a limited number of broadcasts a day.",
+		Tags:        []string{"Broadcasts"},
+	}, func(ctx context.Context, input *models.CreateBroadcastInput) (*models.CreateBroadcastOutput, error) {
+		return broadcastHandler.CreateBroadcast(ctx, input)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-broadcasts",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/organizations/{organization_id}/broadcasts",
+		Summary:     "List an organization's broadcasts",
+		Description: "Returns the organization's broadcasts, newest first",
+		Tags:        []string{"Broadcasts"},
+	}, func(ctx context.Context, input *models.GetBroadcastsInput) (*models.GetBroadcastsOutput, error) {
+		return broadcastHandler.GetBroadcasts(ctx, input)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-broadcast",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/organizations/{organization_id}/broadcasts/{id}",
+		Summary:     "Get a broadcast",
+		Description: "Returns a broadcast with how many guardians it went to and its delivery progress on each channel",
+		Tags:        []string{"Broadcasts"},
+	}, func(ctx context.Context, input *models.GetBroadcastInput) (*models.GetBroadcastOutput, error) {
+		return broadcastHandler.GetBroadcast(ctx, input)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "cancel-broadcast",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/organizations/{organization_id}/broadcasts/{id}/cancel",
+		Summary:     "Cancel a scheduled broadcast",
+		Description: "Cancels a broadcast that has not been sent yet",
+		Tags:        []string{"Broadcasts"},
+	}, func(ctx context.Context, input *models.CancelBroadcastInput) (*models.CancelBroadcastOutput, error) {
+		return broadcastHandler.CancelBroadcast(ctx, input)
+	})
+}
@@ -0,0 +1,69 @@
+package routes_test
+
+import (
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/service/routes"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupDigestTestAPI(digestRepo *repomocks.MockDigestRepository) *fiber.App {
+	app := fiber.New()
+	api := humafiber.New(app, huma.DefaultConfig("Test Digest API", "1.0.0"))
+	routes.SetupDigestRoutes(api, &storage.Repository{Digest: digestRepo})
+	return app
+}
+
+func TestOpenDigestItem_Redirects(t *testing.T) {
+	t.Parallel()
+
+	itemID := uuid.New()
+	eventID := uuid.New()
+
+	digestRepo := new(repomocks.MockDigestRepository)
+	digestRepo.On("RecordDigestClick", mock.Anything, itemID).Return(&models.DigestItem{ID: itemID, EventID: eventID, ClickCount: 1}, nil)
+
+	app := setupDigestTestAPI(digestRepo)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/digests/items/"+itemID.String()+"/open", nil)
+	assert.NoError(t, err)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+	assert.Equal(t, "skillspark://event/"+eventID.String(), resp.Header.Get("Location"))
+	digestRepo.AssertExpectations(t)
+}
+
+func TestOpenDigestItem_NotFound(t *testing.T) {
+	t.Parallel()
+
+	itemID := uuid.New()
+
+	digestRepo := new(repomocks.MockDigestRepository)
+	notFound := errs.NotFound("Digest item", "id", itemID)
+	digestRepo.On("RecordDigestClick", mock.Anything, itemID).Return(nil, &notFound)
+
+	app := setupDigestTestAPI(digestRepo)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/digests/items/"+itemID.String()+"/open", nil)
+	assert.NoError(t, err)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/models"
+	"skillspark/internal/service/handler/digest"
+	"skillspark/internal/storage"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// SetupDigestRoutes registers the link target for activities in weekly digests. It is public
+// so the link works from any mail client; item IDs are random and only reveal an event.
+func SetupDigestRoutes(api huma.API, repo *storage.Repository) {
+	digestHandler := digest.NewHandler(repo.Digest)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "open-digest-item",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/digests/items/{id}/open",
+		Summary:     "Open an activity from a weekly digest",
+		Description: "Counts the click and redirects to the event in the app",
+		Tags:        []string{"Notifications"},
+	}, func(ctx context.Context, input *models.OpenDigestItemInput) (*models.OpenDigestItemOutput, error) {
+		return digestHandler.OpenDigestItem(ctx, input)
+	})
+}
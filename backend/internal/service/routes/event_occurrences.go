@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"skillspark/internal/models"
+	"skillspark/internal/notification"
 	"skillspark/internal/s3_client"
 	eventoccurrence "skillspark/internal/service/handler/event-occurrence"
 	"skillspark/internal/storage"
@@ -115,8 +116,8 @@ func mapToDBFilters(input *models.GetAllEventOccurrencesInput) models.GetAllEven
 	return filters
 }
 
-func SetupEventOccurrencesRoutes(api huma.API, repo *storage.Repository, s3Client s3_client.S3Interface, sc stripeClient.StripeClientInterface) {
-	eventOccurrenceHandler := eventoccurrence.NewHandler(repo.EventOccurrence, repo.Manager, repo.Event, repo.Location, s3Client, repo.Registration, sc)
+func SetupEventOccurrencesRoutes(api huma.API, repo *storage.Repository, s3Client s3_client.S3Interface, sc stripeClient.StripeClientInterface, notifService notification.NotificationServiceInterface) {
+	eventOccurrenceHandler := eventoccurrence.NewHandler(repo.EventOccurrence, repo.Manager, repo.Event, repo.Location, s3Client, repo.Registration, repo.Wallet, sc, notifService)
 
 	huma.Register(api, huma.Operation{
 		OperationID: "get-all-event-occurrences",
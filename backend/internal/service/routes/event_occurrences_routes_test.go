@@ -40,7 +40,7 @@ func setupEventOccurrencesTestAPI(
 		Event:           eventRepo,
 		Location:        locationRepo,
 	}
-	routes.SetupEventOccurrencesRoutes(api, repo, s3Client, sc)
+	routes.SetupEventOccurrencesRoutes(api, repo, s3Client, sc, nil)
 	return app, api
 }
 
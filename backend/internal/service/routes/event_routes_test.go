@@ -2,6 +2,8 @@ package routes_test
 
 import (
 	"bytes"
+	"image"
+	"image/png"
 	"io"
 	"mime/multipart"
 	"net/http"
@@ -27,15 +29,11 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
-// dummyImageData for testing purposes
+// dummyImageData is a valid PNG large enough to pass the header image limits
 func dummyImageData() []byte {
-	return []byte{
-		0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A,
-		0x00, 0x00, 0x00, 0x0D, 0x49, 0x48, 0x44, 0x52,
-		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01,
-		0x08, 0x02, 0x00, 0x00, 0x00, 0x90, 0x77, 0x53,
-		0xDE, 0x00, 0x00, 0x00, 0x0C, 0x49, 0x44, 0x41,
-	}
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 320, 240)))
+	return buf.Bytes()
 }
 
 // createEventMultipartForm creates a multipart form with fields and optionally a file
@@ -139,7 +137,7 @@ func TestHumaValidation_CreateEvent(t *testing.T) {
 			},
 			mockS3Setup: func(m *s3mocks.S3ClientMock) {
 				mockURL := "https://test-bucket.s3.amazonaws.com/events/test/header.jpg"
-				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything).Return(&mockURL, nil)
+				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&mockURL, nil)
 			},
 			mockTranslateSetup: func(m *translatemocks.TranslateMock) {
 				translatedTitle := "จูเนียร์ โรโบติกส์"
@@ -246,6 +244,9 @@ func TestHumaValidation_UpdateEvent(t *testing.T) {
 			},
 			includeFile: true,
 			mockSetup: func(m *repomocks.MockEventRepository) {
+				m.On("GetEventByID", mock.Anything, uuid.MustParse(validID), "en-US").Return(&models.Event{
+					ID: uuid.MustParse(validID),
+				}, nil)
 				m.On(
 					"UpdateEvent",
 					mock.Anything,
@@ -258,7 +259,7 @@ func TestHumaValidation_UpdateEvent(t *testing.T) {
 			},
 			mockS3Setup: func(m *s3mocks.S3ClientMock) {
 				mockURL := "https://test-bucket.s3.amazonaws.com/events/test/header.jpg"
-				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything).Return(&mockURL, nil)
+				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&mockURL, nil)
 			},
 			mockTranslateSetup: func(m *translatemocks.TranslateMock) {
 				translatedTitle := "หุ่นยนต์ขั้นสูง"
@@ -280,20 +281,13 @@ func TestHumaValidation_UpdateEvent(t *testing.T) {
 			},
 			includeFile: true,
 			mockSetup: func(m *repomocks.MockEventRepository) {
-				m.On(
-					"UpdateEvent",
-					mock.Anything,
-					mock.AnythingOfType("*models.UpdateEventDBInput"),
-					mock.Anything,
-				).Return(nil, &errs.HTTPError{
+				// found missing before anything is uploaded
+				m.On("GetEventByID", mock.Anything, uuid.MustParse(notFoundID), "en-US").Return(nil, &errs.HTTPError{
 					Code:    http.StatusNotFound,
 					Message: "Event not found",
 				})
 			},
-			mockS3Setup: func(m *s3mocks.S3ClientMock) {
-				mockURL := "https://test-bucket.s3.amazonaws.com/events/test/header.jpg"
-				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything).Return(&mockURL, nil)
-			},
+			mockS3Setup: func(m *s3mocks.S3ClientMock) {},
 			mockTranslateSetup: func(m *translatemocks.TranslateMock) {
 				translatedTitle := "หุ่นยนต์ขั้นสูง"
 				translatedDesc := ""
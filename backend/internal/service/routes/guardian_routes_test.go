@@ -3,12 +3,18 @@ package routes_test
 import (
 	"bytes"
 	"encoding/json"
+	"image"
+	"image/png"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"strings"
 	"testing"
 	"time"
 
 	"skillspark/internal/config"
 	"skillspark/internal/models"
+	s3mocks "skillspark/internal/s3_client/mocks"
 	"skillspark/internal/service/routes"
 	"skillspark/internal/storage"
 	repomocks "skillspark/internal/storage/repo-mocks"
@@ -27,6 +33,15 @@ func setupGuardianTestAPI(
 	managerRepo *repomocks.MockManagerRepository,
 	stripeClient *stripemocks.MockStripeClient,
 ) (*fiber.App, huma.API) {
+	return setupGuardianTestAPIWithS3(guardianRepo, managerRepo, stripeClient, new(s3mocks.S3ClientMock))
+}
+
+func setupGuardianTestAPIWithS3(
+	guardianRepo *repomocks.MockGuardianRepository,
+	managerRepo *repomocks.MockManagerRepository,
+	stripeClient *stripemocks.MockStripeClient,
+	s3Client *s3mocks.S3ClientMock,
+) (*fiber.App, huma.API) {
 
 	app := fiber.New()
 
@@ -45,7 +60,7 @@ func setupGuardianTestAPI(
 		},
 	}
 
-	routes.SetupGuardiansRoutes(api, repo, stripeClient, cfg)
+	routes.SetupGuardiansRoutes(api, repo, stripeClient, s3Client, cfg)
 
 	return app, api
 }
@@ -321,3 +336,317 @@ func TestHumaValidation_GetGuardianByChildID(t *testing.T) {
 		})
 	}
 }
+
+func TestHumaValidation_GetNotificationPreferences(t *testing.T) {
+	t.Parallel()
+
+	guardianID := uuid.MustParse("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
+
+	mockRepo := new(repomocks.MockGuardianRepository)
+	mockRepo.On("GetGuardianNotificationPreferences", mock.Anything, []uuid.UUID{guardianID}).
+		Return(map[uuid.UUID]models.GuardianNotificationPreferences{
+			guardianID: {
+				EmailNotifications: true,
+				PushNotifications:  true,
+				Topics: map[models.NotificationTopic]map[models.NotificationType]bool{
+					models.NotificationTopicMarketing: {models.NotificationTypeEmail: true},
+				},
+			},
+		}, nil)
+
+	app, _ := setupGuardianTestAPI(mockRepo, new(repomocks.MockManagerRepository), new(stripemocks.MockStripeClient))
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/guardians/"+guardianID.String()+"/notification-preferences", nil)
+	assert.NoError(t, err)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body models.NotificationPreferencesBody
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.True(t, body.EmailNotifications)
+	assert.Len(t, body.Preferences, len(models.NotificationTopics)*len(models.NotificationChannels))
+	assert.Contains(t, body.Preferences, models.NotificationPreference{Topic: models.NotificationTopicMarketing, Channel: models.NotificationTypeEmail, Enabled: true})
+	assert.Contains(t, body.Preferences, models.NotificationPreference{Topic: models.NotificationTopicMarketing, Channel: models.NotificationTypePush, Enabled: false})
+	mockRepo.AssertExpectations(t)
+}
+
+func TestHumaValidation_UpdateNotificationPreferences(t *testing.T) {
+	t.Parallel()
+
+	guardianID := uuid.MustParse("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
+	prefs := map[uuid.UUID]models.GuardianNotificationPreferences{
+		guardianID: {EmailNotifications: true, PushNotifications: true},
+	}
+
+	tests := []struct {
+		name       string
+		guardianID uuid.UUID
+		payload    map[string]interface{}
+		mockSetup  func(*repomocks.MockGuardianRepository)
+		statusCode int
+	}{
+		{
+			name:       "valid payload",
+			guardianID: guardianID,
+			payload: map[string]interface{}{
+				"preferences": []map[string]interface{}{
+					{"topic": "event_reminders", "channel": "push", "enabled": false},
+				},
+			},
+			mockSetup: func(m *repomocks.MockGuardianRepository) {
+				m.On("GetGuardianNotificationPreferences", mock.Anything, []uuid.UUID{guardianID}).Return(prefs, nil)
+				m.On("UpdateGuardianNotificationPreferences", mock.Anything, guardianID, []models.NotificationPreference{
+					{Topic: models.NotificationTopicEventReminders, Channel: models.NotificationTypePush, Enabled: false},
+				}).Return(nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "unknown topic",
+			guardianID: guardianID,
+			payload: map[string]interface{}{
+				"preferences": []map[string]interface{}{
+					{"topic": "weather", "channel": "push", "enabled": false},
+				},
+			},
+			mockSetup:  func(*repomocks.MockGuardianRepository) {},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "empty preferences",
+			guardianID: guardianID,
+			payload: map[string]interface{}{
+				"preferences": []map[string]interface{}{},
+			},
+			mockSetup:  func(*repomocks.MockGuardianRepository) {},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "guardian not found",
+			guardianID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+			payload: map[string]interface{}{
+				"preferences": []map[string]interface{}{
+					{"topic": "marketing", "channel": "email", "enabled": true},
+				},
+			},
+			mockSetup: func(m *repomocks.MockGuardianRepository) {
+				m.On("GetGuardianNotificationPreferences", mock.Anything, []uuid.UUID{uuid.MustParse("00000000-0000-0000-0000-000000000000")}).
+					Return(map[uuid.UUID]models.GuardianNotificationPreferences{}, nil)
+			},
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(repomocks.MockGuardianRepository)
+			tt.mockSetup(mockRepo)
+
+			app, _ := setupGuardianTestAPI(mockRepo, new(repomocks.MockManagerRepository), new(stripemocks.MockStripeClient))
+
+			bodyBytes, err := json.Marshal(tt.payload)
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest(
+				http.MethodPatch,
+				"/api/v1/guardians/"+tt.guardianID.String()+"/notification-preferences",
+				bytes.NewBuffer(bodyBytes),
+			)
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tt.statusCode, resp.StatusCode)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHumaValidation_UpdateQuietHours(t *testing.T) {
+	t.Parallel()
+
+	guardianID := uuid.MustParse("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
+	quietHours := models.QuietHours{Timezone: "Asia/Bangkok", Enabled: true, Start: "22:00", End: "06:30"}
+
+	tests := []struct {
+		name       string
+		payload    map[string]interface{}
+		mockSetup  func(*repomocks.MockGuardianRepository)
+		statusCode int
+	}{
+		{
+			name:    "valid payload",
+			payload: map[string]interface{}{"timezone": "Asia/Bangkok", "enabled": true, "start": "22:00", "end": "06:30"},
+			mockSetup: func(m *repomocks.MockGuardianRepository) {
+				m.On("UpdateGuardianQuietHours", mock.Anything, guardianID, &quietHours).Return(nil).Once()
+				m.On("GetGuardianNotificationPreferences", mock.Anything, []uuid.UUID{guardianID}).
+					Return(map[uuid.UUID]models.GuardianNotificationPreferences{
+						guardianID: {EmailNotifications: true, QuietHours: quietHours},
+					}, nil)
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "badly formatted time",
+			payload:    map[string]interface{}{"timezone": "Asia/Bangkok", "enabled": true, "start": "10pm", "end": "06:30"},
+			mockSetup:  func(*repomocks.MockGuardianRepository) {},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "unknown timezone",
+			payload:    map[string]interface{}{"timezone": "Bangkok", "enabled": true, "start": "22:00", "end": "06:30"},
+			mockSetup:  func(*repomocks.MockGuardianRepository) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "empty window",
+			payload:    map[string]interface{}{"timezone": "Asia/Bangkok", "enabled": true, "start": "22:00", "end": "22:00"},
+			mockSetup:  func(*repomocks.MockGuardianRepository) {},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(repomocks.MockGuardianRepository)
+			tt.mockSetup(mockRepo)
+
+			app, _ := setupGuardianTestAPI(mockRepo, new(repomocks.MockManagerRepository), new(stripemocks.MockStripeClient))
+
+			bodyBytes, err := json.Marshal(tt.payload)
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest(
+				http.MethodPut,
+				"/api/v1/guardians/"+guardianID.String()+"/quiet-hours",
+				bytes.NewBuffer(bodyBytes),
+			)
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tt.statusCode, resp.StatusCode)
+			if tt.statusCode == http.StatusOK {
+				var body models.NotificationPreferencesBody
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				assert.Equal(t, quietHours, body.QuietHours)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHumaValidation_UpdateGuardianProfilePicture(t *testing.T) {
+	t.Parallel()
+
+	guardianID := uuid.MustParse("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
+
+	var avatar bytes.Buffer
+	assert.NoError(t, png.Encode(&avatar, image.NewRGBA(image.Rect(0, 0, 400, 300))))
+
+	tests := []struct {
+		name       string
+		file       []byte
+		mockSetup  func(*repomocks.MockGuardianRepository, *s3mocks.S3ClientMock)
+		statusCode int
+	}{
+		{
+			name: "valid image",
+			file: avatar.Bytes(),
+			mockSetup: func(m *repomocks.MockGuardianRepository, s3 *s3mocks.S3ClientMock) {
+				m.On("GetGuardianByID", mock.Anything, guardianID).Return(&models.Guardian{ID: guardianID}, nil).Once()
+				url := "https://test-bucket.s3.amazonaws.com/guardians/avatar.png"
+				// the original and two formats of three renditions
+				s3.On("UploadImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&url, nil).Times(7)
+				m.On("UpdateGuardianProfilePicture", mock.Anything, guardianID, mock.MatchedBy(func(key string) bool {
+					return strings.HasPrefix(key, "guardians/profile-picture/"+guardianID.String()+"/") &&
+						strings.HasSuffix(key, "/original.png")
+				})).Return(nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name: "replaces the previous picture",
+			file: avatar.Bytes(),
+			mockSetup: func(m *repomocks.MockGuardianRepository, s3 *s3mocks.S3ClientMock) {
+				oldFolder := "guardians/profile-picture/" + guardianID.String() + "/old/"
+				previous := oldFolder + "original.jpg"
+				m.On("GetGuardianByID", mock.Anything, guardianID).Return(&models.Guardian{ID: guardianID, ProfilePictureS3Key: &previous}, nil).Once()
+				url := "https://test-bucket.s3.amazonaws.com/guardians/avatar.png"
+				s3.On("UploadImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&url, nil).Times(7)
+				m.On("UpdateGuardianProfilePicture", mock.Anything, guardianID, mock.Anything).Return(nil).Once()
+				// the original and a JPEG and WebP key for each of the three renditions,
+				// deleted once the new key is saved
+				s3.On("DeleteObject", mock.Anything, mock.MatchedBy(func(key string) bool {
+					return strings.HasPrefix(key, oldFolder)
+				})).Return(nil).Times(7)
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name: "not an image",
+			file: []byte("%PDF-1.7 not a picture"),
+			mockSetup: func(m *repomocks.MockGuardianRepository, s3 *s3mocks.S3ClientMock) {
+				m.On("GetGuardianByID", mock.Anything, guardianID).Return(&models.Guardian{ID: guardianID}, nil).Once()
+			},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(repomocks.MockGuardianRepository)
+			mockS3 := new(s3mocks.S3ClientMock)
+			tt.mockSetup(mockRepo, mockS3)
+
+			app, _ := setupGuardianTestAPIWithS3(mockRepo, new(repomocks.MockManagerRepository), new(stripemocks.MockStripeClient), mockS3)
+
+			var body bytes.Buffer
+			writer := multipart.NewWriter(&body)
+			h := make(textproto.MIMEHeader)
+			h.Set("Content-Disposition", `form-data; name="profile_picture"; filename="avatar.png"`)
+			h.Set("Content-Type", "image/png")
+			part, err := writer.CreatePart(h)
+			assert.NoError(t, err)
+			_, _ = part.Write(tt.file)
+			assert.NoError(t, writer.Close())
+
+			req, err := http.NewRequest(http.MethodPut, "/api/v1/guardians/"+guardianID.String()+"/profile-picture", &body)
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tt.statusCode, resp.StatusCode)
+			if tt.statusCode == http.StatusOK {
+				var guardian models.Guardian
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&guardian))
+				assert.NotNil(t, guardian.ProfilePictureURL)
+				assert.Len(t, guardian.ProfilePictureRenditions, 6)
+			}
+			mockRepo.AssertExpectations(t)
+			mockS3.AssertExpectations(t)
+		})
+	}
+}
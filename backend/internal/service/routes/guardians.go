@@ -2,9 +2,12 @@ package routes
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"skillspark/internal/config"
+	"skillspark/internal/errs"
 	"skillspark/internal/models"
+	"skillspark/internal/s3_client"
 	"skillspark/internal/service/handler/guardian"
 	"skillspark/internal/storage"
 	"skillspark/internal/stripeClient"
@@ -12,8 +15,8 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 )
 
-func SetupGuardiansRoutes(api huma.API, repo *storage.Repository, sc stripeClient.StripeClientInterface, config config.Config) {
-	guardianHandler := guardian.NewHandler(repo.Guardian, repo.GetDB(), sc, config.Supabase)
+func SetupGuardiansRoutes(api huma.API, repo *storage.Repository, sc stripeClient.StripeClientInterface, s3Client s3_client.S3Interface, config config.Config) {
+	guardianHandler := guardian.NewHandler(repo.Guardian, repo.GetDB(), sc, s3Client, config.Supabase)
 	huma.Register(api, huma.Operation{
 		OperationID: "get-guardian-by-id",
 		Method:      http.MethodGet,
@@ -85,4 +88,83 @@ func SetupGuardiansRoutes(api huma.API, repo *storage.Repository, sc stripeClien
 			Body: guardian,
 		}, nil
 	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-guardian-profile-picture",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/guardians/{id}/profile-picture",
+		Summary:     "Upload a guardian's profile picture",
+		Description: "Accepts a JPEG, PNG or WebP image, strips its metadata and stores it with resized renditions",
+		Tags:        []string{"Guardians"},
+	}, func(ctx context.Context, input *models.UpdateGuardianProfilePictureInput) (*models.UpdateGuardianProfilePictureOutput, error) {
+		formData := input.RawBody.Data()
+
+		imageData, err := io.ReadAll(formData.ProfilePicture)
+		if err != nil {
+			return nil, errs.BadRequest("Failed to read profile_picture: " + err.Error())
+		}
+
+		guardian, err := guardianHandler.UpdateProfilePicture(ctx, input.ID, imageData)
+		if err != nil {
+			return nil, err
+		}
+
+		return &models.UpdateGuardianProfilePictureOutput{
+			Body: guardian,
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-guardian-notification-preferences",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/guardians/{id}/notification-preferences",
+		Summary:     "Get a guardian's notification preferences",
+		Description: "Returns the guardian's global channel switches and their choice for every topic and channel",
+		Tags:        []string{"Guardians"},
+	}, func(ctx context.Context, input *models.GetNotificationPreferencesInput) (*models.GetNotificationPreferencesOutput, error) {
+		prefs, err := guardianHandler.GetNotificationPreferences(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		return &models.GetNotificationPreferencesOutput{
+			Body: *prefs,
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-guardian-notification-preferences",
+		Method:      http.MethodPatch,
+		Path:        "/api/v1/guardians/{id}/notification-preferences",
+		Summary:     "Update a guardian's notification preferences",
+		Description: "Turns topics on or off per channel. Cells not in the request are left unchanged",
+		Tags:        []string{"Guardians"},
+	}, func(ctx context.Context, input *models.UpdateNotificationPreferencesInput) (*models.UpdateNotificationPreferencesOutput, error) {
+		prefs, err := guardianHandler.UpdateNotificationPreferences(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		return &models.UpdateNotificationPreferencesOutput{
+			Body: *prefs,
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-guardian-quiet-hours",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/guardians/{id}/quiet-hours",
+		Summary:     "Set a guardian's timezone and quiet hours",
+		Description: "Scheduled notifications that fall in quiet hours are held back until they end, unless they are urgent",
+		Tags:        []string{"Guardians"},
+	}, func(ctx context.Context, input *models.UpdateQuietHoursInput) (*models.UpdateQuietHoursOutput, error) {
+		prefs, err := guardianHandler.UpdateQuietHours(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		return &models.UpdateQuietHoursOutput{
+			Body: *prefs,
+		}, nil
+	})
 }
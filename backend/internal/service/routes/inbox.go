@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/models"
+	"skillspark/internal/service/handler/inbox"
+	"skillspark/internal/storage"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+func SetupInboxRoutes(api huma.API, repo *storage.Repository) {
+	inboxHandler := inbox.NewHandler(repo.Inbox)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-inbox",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/inbox/{guardian_id}",
+		Summary:     "Get a guardian's inbox",
+		Description: "Returns the notifications the guardian has received, newest first, with deep links to the related records",
+		Tags:        []string{"Inbox"},
+	}, func(ctx context.Context, input *models.GetInboxInput) (*models.GetInboxOutput, error) {
+		return inboxHandler.GetInbox(ctx, input)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-inbox-unread-count",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/inbox/{guardian_id}/unread-count",
+		Summary:     "Get the unread inbox count",
+		Description: "Returns how many of the guardian's inbox items have not been read",
+		Tags:        []string{"Inbox"},
+	}, func(ctx context.Context, input *models.GetInboxUnreadCountInput) (*models.GetInboxUnreadCountOutput, error) {
+		return inboxHandler.GetUnreadCount(ctx, input)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "mark-inbox-item-read",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/inbox/{guardian_id}/items/{id}/read",
+		Summary:     "Mark an inbox item as read",
+		Description: "Marks one of the guardian's inbox items as read",
+		Tags:        []string{"Inbox"},
+	}, func(ctx context.Context, input *models.MarkInboxItemReadInput) (*models.MarkInboxItemReadOutput, error) {
+		return inboxHandler.MarkRead(ctx, input)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "mark-all-inbox-items-read",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/inbox/{guardian_id}/read",
+		Summary:     "Mark all inbox items as read",
+		Description: "Marks every unread item in the guardian's inbox as read",
+		Tags:        []string{"Inbox"},
+	}, func(ctx context.Context, input *models.MarkAllInboxItemsReadInput) (*models.MarkAllInboxItemsReadOutput, error) {
+		return inboxHandler.MarkAllRead(ctx, input)
+	})
+}
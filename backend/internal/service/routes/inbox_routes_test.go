@@ -0,0 +1,133 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/service/routes"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"skillspark/internal/utils"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupInboxTestAPI(inboxRepo *repomocks.MockInboxRepository) (*fiber.App, huma.API) {
+	app := fiber.New()
+	api := humafiber.New(app, huma.DefaultConfig("Test Inbox API", "1.0.0"))
+	repo := &storage.Repository{
+		Inbox: inboxRepo,
+	}
+	routes.SetupInboxRoutes(api, repo)
+	return app, api
+}
+
+func TestGetInbox_Success(t *testing.T) {
+	t.Parallel()
+
+	guardianID := uuid.New()
+	registrationID := uuid.New()
+	linkType := models.InboxLinkRegistration
+	deepLink := "skillspark://registrations/" + registrationID.String()
+
+	inboxRepo := new(repomocks.MockInboxRepository)
+	inboxRepo.On("GetInboxItemsByGuardianID", mock.Anything, guardianID, true, utils.Pagination{Page: 1, Limit: 20}).
+		Return([]models.InboxItem{{ID: uuid.New(), GuardianID: guardianID, Kind: "event_reminder", LinkType: &linkType, LinkID: &registrationID, DeepLink: &deepLink}}, nil)
+
+	app, _ := setupInboxTestAPI(inboxRepo)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/inbox/"+guardianID.String()+"?unread_only=true", nil)
+	assert.NoError(t, err)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var items []models.InboxItem
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&items))
+	assert.Len(t, items, 1)
+	assert.Equal(t, deepLink, *items[0].DeepLink)
+	inboxRepo.AssertExpectations(t)
+}
+
+func TestGetInboxUnreadCount_Success(t *testing.T) {
+	t.Parallel()
+
+	guardianID := uuid.New()
+	inboxRepo := new(repomocks.MockInboxRepository)
+	inboxRepo.On("GetUnreadInboxCount", mock.Anything, guardianID).Return(2, nil)
+
+	app, _ := setupInboxTestAPI(inboxRepo)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/inbox/"+guardianID.String()+"/unread-count", nil)
+	assert.NoError(t, err)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body struct {
+		UnreadCount int `json:"unread_count"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, 2, body.UnreadCount)
+}
+
+func TestMarkInboxItemRead_NotFound(t *testing.T) {
+	t.Parallel()
+
+	guardianID := uuid.New()
+	itemID := uuid.New()
+	notFound := errs.NotFound("InboxItem", "id", itemID)
+
+	inboxRepo := new(repomocks.MockInboxRepository)
+	inboxRepo.On("MarkInboxItemRead", mock.Anything, guardianID, itemID).Return(nil, &notFound)
+
+	app, _ := setupInboxTestAPI(inboxRepo)
+
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/inbox/"+guardianID.String()+"/items/"+itemID.String()+"/read", nil)
+	assert.NoError(t, err)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	inboxRepo.AssertExpectations(t)
+}
+
+func TestMarkAllInboxItemsRead_Success(t *testing.T) {
+	t.Parallel()
+
+	guardianID := uuid.New()
+	inboxRepo := new(repomocks.MockInboxRepository)
+	inboxRepo.On("MarkAllInboxItemsRead", mock.Anything, guardianID).Return(3, nil)
+
+	app, _ := setupInboxTestAPI(inboxRepo)
+
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/inbox/"+guardianID.String()+"/read", nil)
+	assert.NoError(t, err)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Updated int `json:"updated"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, 3, body.Updated)
+}
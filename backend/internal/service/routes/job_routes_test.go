@@ -0,0 +1,148 @@
+package routes_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"skillspark/internal/auth"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/notification"
+	"skillspark/internal/service/routes"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"skillspark/internal/utils"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupJobTestAPI(
+	jobRunRepo *repomocks.MockJobRunRepository,
+	jobLockRepo *repomocks.MockJobLockRepository,
+) (*fiber.App, huma.API) {
+	app := fiber.New()
+	api := humafiber.New(app, huma.DefaultConfig("Test Jobs API", "1.0.0"))
+	repo := &storage.Repository{
+		JobRun:  jobRunRepo,
+		JobLock: jobLockRepo,
+	}
+	routes.SetupJobRoutes(api, repo, nil, notification.Service{}, nil)
+	return app, api
+}
+
+func TestGetAllJobRuns_Success(t *testing.T) {
+	jobRunRepo := new(repomocks.MockJobRunRepository)
+	jobRunRepo.On("GetAllJobRuns", mock.Anything, "capture_payments", utils.Pagination{Page: 1, Limit: 10}).
+		Return([]models.JobRun{{ID: uuid.New(), JobName: "capture_payments", Status: models.JobRunStatusSucceeded, ItemErrors: []models.JobRunItemError{}}}, nil)
+
+	app, _ := setupJobTestAPI(jobRunRepo, new(repomocks.MockJobLockRepository))
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/admin/jobs/runs?job_name=capture_payments", nil)
+	assert.NoError(t, err)
+	setAuthCookie(t, req, auth.AdminRole)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var runs []models.JobRun
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&runs))
+	assert.Len(t, runs, 1)
+	jobRunRepo.AssertExpectations(t)
+}
+
+func TestGetJobRunByID_NotFound(t *testing.T) {
+	jobRunRepo := new(repomocks.MockJobRunRepository)
+	runID := uuid.New()
+	notFound := errs.NotFound("JobRun", "id", runID)
+	jobRunRepo.On("GetJobRunByID", mock.Anything, runID).Return(nil, &notFound)
+
+	app, _ := setupJobTestAPI(jobRunRepo, new(repomocks.MockJobLockRepository))
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/admin/jobs/runs/"+runID.String(), nil)
+	assert.NoError(t, err)
+	setAuthCookie(t, req, auth.AdminRole)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	jobRunRepo.AssertExpectations(t)
+}
+
+func TestTriggerJob_AlreadyRunning(t *testing.T) {
+	jobRunRepo := new(repomocks.MockJobRunRepository)
+	jobLockRepo := new(repomocks.MockJobLockRepository)
+	jobLockRepo.On("TryAcquireJobLock", mock.Anything, "capture_payments").Return(nil, false, nil)
+
+	app, _ := setupJobTestAPI(jobRunRepo, jobLockRepo)
+
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/admin/jobs/capture_payments/runs", bytes.NewBufferString(`{}`))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	setAuthCookie(t, req, auth.AdminRole)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	jobLockRepo.AssertExpectations(t)
+	jobRunRepo.AssertNotCalled(t, "CreateJobRun", mock.Anything, mock.Anything)
+}
+
+func TestTriggerJob_UnknownJob(t *testing.T) {
+	app, _ := setupJobTestAPI(new(repomocks.MockJobRunRepository), new(repomocks.MockJobLockRepository))
+
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/admin/jobs/not_a_job/runs", bytes.NewBufferString(`{"dry_run": true}`))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	setAuthCookie(t, req, auth.AdminRole)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestTriggerJob_RejectsNonAdmin(t *testing.T) {
+	jobLockRepo := new(repomocks.MockJobLockRepository)
+	app, _ := setupJobTestAPI(new(repomocks.MockJobRunRepository), jobLockRepo)
+
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/admin/jobs/capture_payments/runs", bytes.NewBufferString(`{}`))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	setAuthCookie(t, req, "guardian")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	jobLockRepo.AssertNotCalled(t, "TryAcquireJobLock", mock.Anything, mock.Anything)
+}
+
+func TestGetAllJobRuns_RejectsMissingToken(t *testing.T) {
+	jobRunRepo := new(repomocks.MockJobRunRepository)
+	app, _ := setupJobTestAPI(jobRunRepo, new(repomocks.MockJobLockRepository))
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/admin/jobs/runs", nil)
+	assert.NoError(t, err)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	jobRunRepo.AssertNotCalled(t, "GetAllJobRuns", mock.Anything, mock.Anything, mock.Anything)
+}
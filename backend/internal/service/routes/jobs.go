@@ -0,0 +1,57 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/auth"
+	"skillspark/internal/models"
+	"skillspark/internal/notification"
+	"skillspark/internal/s3_client"
+	"skillspark/internal/service/handler/job"
+	"skillspark/internal/storage"
+	"skillspark/internal/stripeClient"
+	"skillspark/jobs"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+func SetupJobRoutes(api huma.API, repo *storage.Repository, sc stripeClient.StripeClientInterface, notifService notification.Service, s3Client s3_client.S3Interface) {
+	// the scheduler is only used to run jobs on demand here; cron runs in the worker
+	jobHandler := job.NewHandler(repo.JobRun, jobs.NewJobScheduler(repo, sc, &notifService, nil, s3Client))
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-all-job-runs",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/admin/jobs/runs",
+		Summary:     "Get job run history",
+		Description: "Returns background job runs with their item counts and errors, newest first",
+		Tags:        []string{"Jobs", "Admin"},
+		Middlewares: huma.Middlewares{auth.RequireAdmin(api)},
+	}, func(ctx context.Context, input *models.GetAllJobRunsInput) (*models.GetAllJobRunsOutput, error) {
+		return jobHandler.GetAllJobRuns(ctx, input)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-job-run-by-id",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/admin/jobs/runs/{id}",
+		Summary:     "Get a job run",
+		Description: "Returns a single background job run",
+		Tags:        []string{"Jobs", "Admin"},
+		Middlewares: huma.Middlewares{auth.RequireAdmin(api)},
+	}, func(ctx context.Context, input *models.GetJobRunByIDInput) (*models.GetJobRunByIDOutput, error) {
+		return jobHandler.GetJobRunByID(ctx, input)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "trigger-job",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/admin/jobs/{job_name}/runs",
+		Summary:     "Trigger a job",
+		Description: "Starts a run of the job in the background and returns the run record. Dry runs report what the job would do without charging, cancelling or sending anything",
+		Tags:        []string{"Jobs", "Admin"},
+		Middlewares: huma.Middlewares{auth.RequireAdmin(api)},
+	}, func(ctx context.Context, input *models.TriggerJobInput) (*models.TriggerJobOutput, error) {
+		return jobHandler.TriggerJob(ctx, input)
+	})
+}
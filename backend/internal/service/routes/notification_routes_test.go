@@ -0,0 +1,112 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	notificationmocks "skillspark/internal/notification/mocks"
+	"skillspark/internal/service/routes"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupNotificationTestAPI(notifService *notificationmocks.MockNotificationService) (*fiber.App, huma.API) {
+	app := fiber.New()
+	api := humafiber.New(app, huma.DefaultConfig("Test Notifications API", "1.0.0"))
+	routes.SetupNotificationRoutes(api, notifService)
+	return app, api
+}
+
+func TestUnsubscribe(t *testing.T) {
+	t.Parallel()
+
+	badRequest := errs.BadRequest("Invalid unsubscribe link")
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		mockSetup  func(*notificationmocks.MockNotificationService)
+		statusCode int
+	}{
+		{
+			name:   "link from the email",
+			method: http.MethodGet,
+			path:   "/api/v1/notifications/unsubscribe?token=good",
+			mockSetup: func(m *notificationmocks.MockNotificationService) {
+				m.On("Unsubscribe", mock.Anything, "good").Return(models.NotificationTopicEventReminders, nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:   "one-click post from a mail client",
+			method: http.MethodPost,
+			path:   "/api/v1/notifications/unsubscribe?token=good",
+			mockSetup: func(m *notificationmocks.MockNotificationService) {
+				m.On("Unsubscribe", mock.Anything, "good").Return(models.NotificationTopicEventReminders, nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:   "invalid token",
+			method: http.MethodGet,
+			path:   "/api/v1/notifications/unsubscribe?token=bad",
+			mockSetup: func(m *notificationmocks.MockNotificationService) {
+				m.On("Unsubscribe", mock.Anything, "bad").Return(models.NotificationTopic(""), &badRequest).Once()
+			},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "missing token",
+			method:     http.MethodGet,
+			path:       "/api/v1/notifications/unsubscribe",
+			mockSetup:  func(m *notificationmocks.MockNotificationService) {},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			notifService := new(notificationmocks.MockNotificationService)
+			tt.mockSetup(notifService)
+			app, _ := setupNotificationTestAPI(notifService)
+
+			var body *strings.Reader
+			if tt.method == http.MethodPost {
+				body = strings.NewReader("List-Unsubscribe=One-Click")
+			} else {
+				body = strings.NewReader("")
+			}
+			req, err := http.NewRequest(tt.method, tt.path, body)
+			assert.NoError(t, err)
+			if tt.method == http.MethodPost {
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tt.statusCode, resp.StatusCode)
+			if tt.statusCode == http.StatusOK {
+				var out struct {
+					Topic models.NotificationTopic `json:"topic"`
+				}
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+				assert.Equal(t, models.NotificationTopicEventReminders, out.Topic)
+			}
+			notifService.AssertExpectations(t)
+		})
+	}
+}
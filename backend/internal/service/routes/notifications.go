@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/models"
+	"skillspark/internal/notification"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// SetupNotificationRoutes registers the unsubscribe link target. It is public: the signed token
+// in the link identifies the guardian, so unsubscribing works from any mail client.
+func SetupNotificationRoutes(api huma.API, notifService notification.NotificationServiceInterface) {
+	unsubscribe := func(ctx context.Context, input *models.UnsubscribeInput) (*models.UnsubscribeOutput, error) {
+		topic, err := notifService.Unsubscribe(ctx, input.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		output := &models.UnsubscribeOutput{}
+		output.Body.Message = "You will no longer receive these emails"
+		output.Body.Topic = topic
+		return output, nil
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "unsubscribe-notifications",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/notifications/unsubscribe",
+		Summary:     "Unsubscribe from an email topic",
+		Description: "Turns off email for the topic named in a signed unsubscribe link",
+		Tags:        []string{"Notifications"},
+	}, unsubscribe)
+
+	// mail clients send List-Unsubscribe-Post one-click requests here
+	huma.Register(api, huma.Operation{
+		OperationID: "unsubscribe-notifications-one-click",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/notifications/unsubscribe",
+		Summary:     "One-click unsubscribe from an email topic",
+		Description: "RFC 8058 one-click unsubscribe, sent by mail clients from the List-Unsubscribe header",
+		Tags:        []string{"Notifications"},
+	}, unsubscribe)
+}
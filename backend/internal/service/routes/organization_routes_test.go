@@ -2,6 +2,8 @@ package routes
 
 import (
 	"bytes"
+	"image"
+	"image/png"
 	"io"
 	"mime/multipart"
 	"net/http"
@@ -26,15 +28,11 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
-// dummyImageData for testing organization routes
+// dummyImageData is a valid PNG large enough to pass the profile image limits
 func dummyImageData() []byte {
-	return []byte{
-		0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A,
-		0x00, 0x00, 0x00, 0x0D, 0x49, 0x48, 0x44, 0x52,
-		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01,
-		0x08, 0x02, 0x00, 0x00, 0x00, 0x90, 0x77, 0x53,
-		0xDE, 0x00, 0x00, 0x00, 0x0C, 0x49, 0x44, 0x41,
-	}
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 320, 240)))
+	return buf.Bytes()
 }
 
 // createMultipartForm creates a multipart form with fields and optionally a file
@@ -285,7 +283,7 @@ func TestHumaValidation_CreateOrganization(t *testing.T) {
 			mockS3 := createMockS3Client()
 			if tt.statusCode == http.StatusOK {
 				mockURL := "https://test-bucket.s3.amazonaws.com/orgs/test/pfp.jpg"
-				mockS3.On("UploadImage", mock.Anything, mock.Anything, mock.Anything).Return(&mockURL, nil)
+				mockS3.On("UploadImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&mockURL, nil)
 			}
 			app, _ := setupOrganizationTestAPI(mockOrgRepo, mockLocRepo, mockReviewRepo, mockS3)
 
@@ -453,6 +451,7 @@ func TestHumaValidation_UpdateOrganization(t *testing.T) {
 				).Return(&models.Location{
 					ID: testLocationID,
 				}, nil)
+				m.On("GetOrganizationByID", mock.Anything, orgID, "en-US").Return(&models.Organization{ID: orgID}, nil)
 				m.On(
 					"UpdateOrganization",
 					mock.Anything,
@@ -504,7 +503,7 @@ func TestHumaValidation_UpdateOrganization(t *testing.T) {
 			mockS3 := createMockS3Client()
 			if tt.statusCode == http.StatusOK {
 				mockURL := "https://test-bucket.s3.amazonaws.com/orgs/test/pfp.jpg"
-				mockS3.On("UploadImage", mock.Anything, mock.Anything, mock.Anything).Return(&mockURL, nil)
+				mockS3.On("UploadImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&mockURL, nil)
 			}
 			app, _ := setupOrganizationTestAPI(mockOrgRepo, mockLocRepo, mockReviewRepo, mockS3)
 
@@ -21,7 +21,7 @@ func SetupRecommendationRoutes(api huma.API, repo *storage.Repository, s3Client
 		Method:      http.MethodGet,
 		Path:        "/api/v1/recommendations/{child_id}",
 		Summary:     "Get recommendations by child ID",
-		Description: "Returns a list of recommended events for a child",
+		Description: "Returns recommended events for a child, best first, spread across organizations, categories and days. Each comes with its score components and the reasons it was recommended. Events the child is registered for are left out, and only the best 200 are ranked.",
 		Tags:        []string{"Recommendations"},
 	}, func(ctx context.Context, input *models.GetRecommendationsByChildIDInput) (*models.GetRecommendationsByChildIDOutput, error) {
 		pagination := utils.Pagination{Page: input.Page, Limit: input.Limit}
@@ -1,6 +1,7 @@
 package routes_test
 
 import (
+	"encoding/json"
 	"io"
 	"net/http"
 	"testing"
@@ -12,7 +13,6 @@ import (
 	"skillspark/internal/service/routes"
 	"skillspark/internal/storage"
 	repomocks "skillspark/internal/storage/repo-mocks"
-	"skillspark/internal/utils"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
@@ -52,20 +52,24 @@ func TestGetRecommendationsByChildID(t *testing.T) {
 		Interests:  []string{"science", "technology"},
 	}
 
-	events := []models.Event{
+	candidates := []models.RecommendationCandidate{
 		{
-			ID:          uuid.MustParse("60000000-0000-0000-0000-000000000001"),
-			Title:       "Junior Robotics Workshop",
-			Description: "Learn robotics!",
-			AgeRangeMin: &eight,
-			AgeRangeMax: &twelve,
-			Category:    []string{"science", "technology"},
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
+			Event: models.Event{
+				ID:          uuid.MustParse("60000000-0000-0000-0000-000000000001"),
+				Title:       "Junior Robotics Workshop",
+				Description: "Learn robotics!",
+				AgeRangeMin: &eight,
+				AgeRangeMax: &twelve,
+				Category:    []string{"science", "technology"},
+				CreatedAt:   time.Now(),
+				UpdatedAt:   time.Now(),
+			},
+			Components:       models.RecommendationScoreComponents{Interest: 2},
+			MatchedInterests: []string{"science", "technology"},
 		},
 	}
 
-	defaultPagination := utils.Pagination{Page: 1, Limit: 10}
+	candidatePool := 200
 	defaultFilters := models.RecommendationFilters{}
 
 	tests := []struct {
@@ -79,7 +83,7 @@ func TestGetRecommendationsByChildID(t *testing.T) {
 			childID: childID.String(),
 			mockSetup: func(c *repomocks.MockChildRepository, r *repomocks.MockRecommendationRepository, s3 *s3mocks.S3ClientMock) {
 				c.On("GetChildByID", mock.Anything, childID).Return(child, nil)
-				r.On("GetRecommendationsByChildID", mock.Anything, child.Interests, child.BirthYear, "en-US", defaultPagination, defaultFilters).Return(events, nil)
+				r.On("GetRecommendationCandidates", mock.Anything, childID, child.Interests, child.BirthYear, "en-US", candidatePool, defaultFilters).Return(candidates, nil)
 			},
 			statusCode: http.StatusOK,
 		},
@@ -105,7 +109,7 @@ func TestGetRecommendationsByChildID(t *testing.T) {
 			mockSetup: func(c *repomocks.MockChildRepository, r *repomocks.MockRecommendationRepository, s3 *s3mocks.S3ClientMock) {
 				c.On("GetChildByID", mock.Anything, childID).Return(child, nil)
 				repoErr := errs.InternalServerError("db error", "")
-				r.On("GetRecommendationsByChildID", mock.Anything, child.Interests, child.BirthYear, "en-US", defaultPagination, defaultFilters).Return(nil, &repoErr)
+				r.On("GetRecommendationCandidates", mock.Anything, childID, child.Interests, child.BirthYear, "en-US", candidatePool, defaultFilters).Return(nil, &repoErr)
 			},
 			statusCode: http.StatusInternalServerError,
 		},
@@ -142,3 +146,55 @@ func TestGetRecommendationsByChildID(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRecommendationsByChildID_DiversifiedAndExplained(t *testing.T) {
+	t.Parallel()
+
+	childID := uuid.New()
+	child := &models.Child{ID: childID, Name: "Test Child", BirthYear: 2015, Interests: []string{"robotics", "art"}}
+
+	robotsInc := uuid.New()
+	robotics := func(title string) models.RecommendationCandidate {
+		return models.RecommendationCandidate{
+			Event:            models.Event{ID: uuid.New(), OrganizationID: robotsInc, Title: title, Category: []string{"robotics"}},
+			Components:       models.RecommendationScoreComponents{Interest: 1, Popularity: 0.5},
+			MatchedInterests: []string{"robotics"},
+		}
+	}
+	painting := models.RecommendationCandidate{
+		Event:               models.Event{ID: uuid.New(), OrganizationID: uuid.New(), Title: "Watercolor", Category: []string{"art"}},
+		Components:          models.RecommendationScoreComponents{Interest: 1},
+		MatchedInterests:    []string{"art"},
+		RecentRegistrations: 4,
+	}
+
+	mockChild := new(repomocks.MockChildRepository)
+	mockRec := new(repomocks.MockRecommendationRepository)
+	mockChild.On("GetChildByID", mock.Anything, childID).Return(child, nil)
+	mockRec.On("GetRecommendationCandidates", mock.Anything, childID, child.Interests, child.BirthYear, "en-US", 200, models.RecommendationFilters{}).
+		Return([]models.RecommendationCandidate{robotics("Robotics 1"), robotics("Robotics 2"), painting}, nil)
+
+	app, _ := setupRecommendationTestAPI(mockChild, mockRec, new(s3mocks.S3ClientMock))
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/recommendations/"+childID.String()+"?limit=2", nil)
+	assert.NoError(t, err)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body []models.Recommendation
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	if assert.Len(t, body, 2) {
+		// the second robotics class from the same organization drops below the painting class
+		assert.Equal(t, "Robotics 1", body[0].Title)
+		assert.Equal(t, "Watercolor", body[1].Title)
+		assert.InDelta(t, 1.5, body[0].Score, 1e-9)
+		assert.Equal(t, []models.RecommendationExplanation{
+			{Reason: models.RecommendationReasonMatchesInterest, Text: "Matches interest: art"},
+			{Reason: models.RecommendationReasonPopular, Text: "Popular with families"},
+		}, body[1].Explanations)
+		assert.Equal(t, models.RecommendationScoreComponents{Interest: 1, Diversity: -0.25}, body[1].ScoreComponents)
+	}
+	mockRec.AssertExpectations(t)
+}
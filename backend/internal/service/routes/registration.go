@@ -12,8 +12,8 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 )
 
-func SetupRegistrationRoutes(api huma.API, repo *storage.Repository, sc stripeClient.StripeClientInterface, notifService *notification.Service) {
-	registrationHandler := registration.NewHandler(repo.Registration, repo.Child, repo.Guardian, repo.EventOccurrence, repo.Organization, sc, notifService)
+func SetupRegistrationRoutes(api huma.API, repo *storage.Repository, sc stripeClient.StripeClientInterface, notifService notification.NotificationServiceInterface) {
+	registrationHandler := registration.NewHandler(repo.Registration, repo.Child, repo.Guardian, repo.EventOccurrence, repo.Organization, repo.Wallet, sc, notifService)
 
 	huma.Register(api, huma.Operation{
 		OperationID: "create-registration",
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"skillspark/internal/models"
+	"skillspark/internal/s3_client"
 	"skillspark/internal/service/handler/review"
 	"skillspark/internal/storage"
 	translations "skillspark/internal/translation"
@@ -12,9 +13,9 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 )
 
-func SetUpReviewRoutes(api huma.API, repo *storage.Repository, translateClient translations.TranslationInterface) {
+func SetUpReviewRoutes(api huma.API, repo *storage.Repository, s3Client s3_client.S3Interface, translateClient translations.TranslationInterface) {
 
-	reviewHandler := review.NewHandler(repo.Registration, repo.Review, repo.Guardian, repo.Event, repo.Organization, translateClient)
+	reviewHandler := review.NewHandler(repo.Registration, repo.Review, repo.Guardian, repo.Event, repo.Organization, s3Client, translateClient)
 
 	huma.Register(api, huma.Operation{
 		OperationID: "get-review-by-guardian-id",
@@ -38,7 +38,7 @@ func setupReviewTestAPI(
 		Event:        eventRepo,
 	}
 
-	routes.SetUpReviewRoutes(api, repo, translateClient)
+	routes.SetUpReviewRoutes(api, repo, nil, translateClient)
 
 	return app, api
 }
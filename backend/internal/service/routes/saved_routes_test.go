@@ -90,11 +90,11 @@ func TestGetSavedByGuardianID_Success(t *testing.T) {
 	mockS3 := createMockS3Client()
 
 	mockS3.On(
-		"GeneratePresignedURL",
-		mock.Anything,
+		"GeneratePresignedURLs",
 		mock.Anything,
+		[]string{jpg},
 		mock.Anything,
-	).Return("https://mock-url.com/image.jpg", nil)
+	).Return(map[string]string{jpg: "https://mock-url.com/image.jpg"}, nil)
 
 	app, _ := setupSavedTestAPI(mockRepo, mockGuardianRepo, mockS3)
 
@@ -179,11 +179,11 @@ func TestGetSavedByGuardianID_WithPagination(t *testing.T) {
 	mockS3 := createMockS3Client()
 
 	mockS3.On(
-		"GeneratePresignedURL",
-		mock.Anything,
+		"GeneratePresignedURLs",
 		mock.Anything,
+		[]string{jpg},
 		mock.Anything,
-	).Return("https://mock-url.com/image.jpg", nil)
+	).Return(map[string]string{jpg: "https://mock-url.com/image.jpg"}, nil)
 
 	app, _ := setupSavedTestAPI(mockRepo, mockGuardianRepo, mockS3)
 
@@ -3,34 +3,222 @@ package routes
 import (
 	"context"
 	"net/http"
+	"skillspark/internal/auth"
 	"skillspark/internal/models"
 	"skillspark/internal/opensearch"
 	"skillspark/internal/s3_client"
 	searchHandler "skillspark/internal/service/handler/search"
 	"skillspark/internal/storage"
-	"skillspark/internal/utils"
+	"slices"
+	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
 )
 
-func SetupSearchRoutes(api huma.API, osClient *opensearch.Client, s3 s3_client.S3Interface, eventRepo storage.EventRepository) {
-	handler := searchHandler.NewHandler(osClient, s3, eventRepo)
+func validateSearchInput(input *models.SearchEventsInput) error {
+	if input.RadiusKm != 0 && !(input.Latitude.Set && input.Longitude.Set) {
+		return huma.Error400BadRequest("radius_km requires lat and lng")
+	}
+
+	if input.Latitude.Set != input.Longitude.Set {
+		return huma.Error400BadRequest("lat and lng must be provided together")
+	}
+
+	if input.RadiusKm < 0 {
+		return huma.Error400BadRequest("radius_km must be positive")
+	}
+
+	if input.Sort == models.SearchSortDistance && !input.Latitude.Set {
+		return huma.Error400BadRequest("sorting by distance requires lat and lng")
+	}
+
+	if input.MinDuration != 0 && input.MaxDuration != 0 && input.MinDuration > input.MaxDuration {
+		return huma.Error400BadRequest("min_duration cannot be larger than max_duration")
+	}
+
+	if input.MinPrice != 0 && input.MaxPrice != 0 && input.MinPrice > input.MaxPrice {
+		return huma.Error400BadRequest("min_price cannot be larger than max_price")
+	}
+
+	if input.MinAge != 0 && input.MaxAge != 0 && input.MinAge > input.MaxAge {
+		return huma.Error400BadRequest("min age cannot be larger than max age")
+	}
+
+	if !input.MinDate.IsZero() && !input.MaxDate.IsZero() && input.MinDate.After(input.MaxDate) {
+		return huma.Error400BadRequest("min_date cannot be later than max_date")
+	}
+
+	return nil
+}
+
+// mapToSearchFilters maps the search input to the same filters as GetAllEventOccurrences.
+// Unlike there, lat and lng are kept without a radius so they can position the distance
+// sort.
+func mapToSearchFilters(input *models.SearchEventsInput) models.GetAllEventOccurrencesFilter {
+	var filters models.GetAllEventOccurrencesFilter
+
+	if input.Latitude.Set && input.Longitude.Set {
+		filters.Latitude = &input.Latitude.Value
+		filters.Longitude = &input.Longitude.Value
+		if input.RadiusKm != 0 {
+			filters.RadiusKm = &input.RadiusKm
+		}
+	}
+
+	if input.MinDuration != 0 {
+		filters.MinDurationMinutes = &input.MinDuration
+	}
+
+	if input.MaxDuration != 0 {
+		filters.MaxDurationMinutes = &input.MaxDuration
+	}
+
+	if input.MinPrice != 0 {
+		filters.MinPrice = &input.MinPrice
+	}
+
+	if input.MaxPrice != 0 {
+		filters.MaxPrice = &input.MaxPrice
+	}
+
+	if input.MinAge != 0 {
+		filters.MinAge = &input.MinAge
+	}
+
+	if input.MaxAge != 0 {
+		filters.MaxAge = &input.MaxAge
+	}
+
+	if input.Category != "" {
+		filters.Category = &input.Category
+	}
+
+	if input.SoldOut {
+		filters.SoldOut = &input.SoldOut
+	}
+
+	if !input.MinDate.IsZero() {
+		filters.MinDate = &input.MinDate
+	}
+
+	if !input.MaxDate.IsZero() {
+		filters.MaxDate = &input.MaxDate
+	}
+
+	return filters
+}
+
+// parseSearchTypes reads the comma-separated types of a unified search, dropping repeats
+func parseSearchTypes(types string) ([]models.SearchType, error) {
+	var parsed []models.SearchType
+	for _, value := range strings.Split(types, ",") {
+		searchType := models.SearchType(strings.TrimSpace(value))
+		switch searchType {
+		case models.SearchTypeOccurrences, models.SearchTypeEvents, models.SearchTypeOrganizations:
+		default:
+			return nil, huma.Error400BadRequest("types must be a comma-separated list of occurrences, events and organizations")
+		}
+		if !slices.Contains(parsed, searchType) {
+			parsed = append(parsed, searchType)
+		}
+	}
+	return parsed, nil
+}
+
+func SetupSearchRoutes(api huma.API, osClient *opensearch.Client, s3 s3_client.S3Interface, eventRepo storage.EventRepository, eventOccurrenceRepo storage.EventOccurrenceRepository, searchLogRepo storage.SearchLogRepository) {
+	handler := searchHandler.NewHandler(osClient, s3, eventRepo, eventOccurrenceRepo, searchLogRepo)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "search",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/search",
+		Summary:     "Search occurrences, events and organizations",
+		Description: "Returns upcoming event occurrences with their dates, price and seats left, events, and organizations matching the search query, grouped by type. types limits which are searched and limit applies to each type",
+		Tags:        []string{"Search"},
+	}, func(ctx context.Context, input *models.UnifiedSearchInput) (*models.UnifiedSearchOutput, error) {
+		types, err := parseSearchTypes(input.Types)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := handler.SearchAll(ctx, input, types)
+		if err != nil {
+			return nil, err
+		}
+
+		return &models.UnifiedSearchOutput{Body: *result}, nil
+	})
 
 	huma.Register(api, huma.Operation{
 		OperationID: "search-events",
 		Method:      http.MethodGet,
 		Path:        "/api/v1/search/events",
-		Summary:     "Fuzzy search events",
-		Description: "Returns events matching the search query using fuzzy full-text search",
+		Summary:     "Search events",
+		Description: "Returns events matching the search query using fuzzy full-text search, narrowed by the same filters as event occurrences, with facet counts for category, age band, price band and district. When a text query matches nothing, did_you_mean suggests a correction",
 		Tags:        []string{"Search"},
 	}, func(ctx context.Context, input *models.SearchEventsInput) (*models.SearchEventsOutput, error) {
-		pagination := utils.Pagination{Page: input.Page, Limit: input.Limit}
+		if err := validateSearchInput(input); err != nil {
+			return nil, err
+		}
+
+		result, err := handler.SearchEvents(ctx, input, mapToSearchFilters(input))
+		if err != nil {
+			return nil, err
+		}
+
+		return &models.SearchEventsOutput{Body: *result}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "suggest-search",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/search/suggest",
+		Summary:     "Suggest search terms",
+		Description: "Returns events, organizations and categories matching a partially typed query, in English or Thai, for the search bar to show as the user types",
+		Tags:        []string{"Search"},
+	}, func(ctx context.Context, input *models.SuggestInput) (*models.SuggestOutput, error) {
+		suggestions, err := handler.Suggest(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		return &models.SuggestOutput{Body: *suggestions}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "record-search-click",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/search/{id}/click",
+		Summary:     "Record a search result click",
+		Description: "Records which result was opened from a search, using the search_id returned with the results. Only the first result opened from a search is kept",
+		Tags:        []string{"Search"},
+	}, func(ctx context.Context, input *models.RecordSearchClickInput) (*models.RecordSearchClickOutput, error) {
+		log, err := handler.RecordSearchClick(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		return &models.RecordSearchClickOutput{Body: *log}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-search-analytics",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/admin/search/analytics",
+		Summary:     "Get search analytics",
+		Description: "Reports the most common search queries, the queries that found nothing and the click-through rate of each, over the last 30 days unless since and until are given",
+		Tags:        []string{"Search", "Admin"},
+		Middlewares: huma.Middlewares{auth.RequireAdmin(api)},
+	}, func(ctx context.Context, input *models.GetSearchAnalyticsInput) (*models.GetSearchAnalyticsOutput, error) {
+		if !input.Since.IsZero() && !input.Until.IsZero() && input.Since.After(input.Until) {
+			return nil, huma.Error400BadRequest("since cannot be later than until")
+		}
 
-		events, err := handler.SearchEvents(ctx, input.Query, input.AcceptLanguage, pagination)
+		analytics, err := handler.GetSearchAnalytics(ctx, input)
 		if err != nil {
 			return nil, err
 		}
 
-		return &models.SearchEventsOutput{Body: events}, nil
+		return &models.GetSearchAnalyticsOutput{Body: *analytics}, nil
 	})
 }
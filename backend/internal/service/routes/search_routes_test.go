@@ -0,0 +1,529 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"skillspark/internal/auth"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/service/routes"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"skillspark/internal/utils"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSearchTestAPI(eventRepo *repomocks.MockEventRepository, eventOccurrenceRepo *repomocks.MockEventOccurrenceRepository, searchLogRepo *repomocks.MockSearchLogRepository) *fiber.App {
+	app := fiber.New()
+	api := humafiber.New(app, huma.DefaultConfig("Test Search API", "1.0.0"))
+	routes.SetupSearchRoutes(api, nil, nil, eventRepo, eventOccurrenceRepo, searchLogRepo)
+	return app
+}
+
+func TestSearchEvents_PostgresFallback(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(repomocks.MockEventRepository)
+	headerKey := "events/header-image/robotics"
+	event := models.Event{ID: uuid.New(), Title: "Junior Robotics Workshop", HeaderImageS3Key: &headerKey}
+
+	search := "robot"
+	category := "science,technology"
+	minAge := 6
+	mockRepo.On(
+		"SearchEvents",
+		mock.Anything,
+		utils.Pagination{Page: 2, Limit: 5},
+		"en-US",
+		models.GetAllEventsFilter{Search: &search, Category: &category, MinAge: &minAge},
+	).Return([]models.Event{event}, 6, nil)
+
+	mockS3 := createMockS3Client()
+	mockS3.On("GeneratePresignedURLs", mock.Anything, []string{headerKey}, mock.Anything).
+		Return(map[string]string{headerKey: "https://mock-url.com/robotics.jpg"}, nil)
+
+	app := fiber.New()
+	api := humafiber.New(app, huma.DefaultConfig("Test Search API", "1.0.0"))
+	routes.SetupSearchRoutes(api, nil, mockS3, mockRepo, new(repomocks.MockEventOccurrenceRepository), new(repomocks.MockSearchLogRepository))
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/search/events?q=robot&page=2&limit=5&category=science,technology&min_age=6", nil)
+	require.NoError(t, err)
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result models.SearchEventsResult
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.Len(t, result.Results, 1)
+	assert.Equal(t, event.ID, result.Results[0].ID)
+	require.NotNil(t, result.Results[0].PresignedURL)
+	assert.Equal(t, "https://mock-url.com/robotics.jpg", *result.Results[0].PresignedURL)
+	// the total counts every match, not just this page
+	assert.Equal(t, 6, result.Total)
+	assert.Empty(t, result.Facets.Category)
+	assert.Nil(t, result.SearchID, "only the first page of a search is logged")
+
+	mockRepo.AssertExpectations(t)
+	mockS3.AssertExpectations(t)
+}
+
+func TestSearchEvents_InvalidFilters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "radius without a location", query: "radius_km=5"},
+		{name: "latitude without longitude", query: "lat=13.7"},
+		{name: "distance sort without a location", query: "sort=distance"},
+		{name: "min price above max price", query: "min_price=5000&max_price=1000"},
+		{name: "min age above max age", query: "min_age=10&max_age=5"},
+		{name: "min date after max date", query: "min_date=2026-06-01T00:00:00Z&max_date=2026-05-01T00:00:00Z"},
+		{name: "unknown sort", query: "sort=popularity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(repomocks.MockEventRepository)
+			app := setupSearchTestAPI(mockRepo, new(repomocks.MockEventOccurrenceRepository), new(repomocks.MockSearchLogRepository))
+
+			req, err := http.NewRequest(http.MethodGet, "/api/v1/search/events?"+tt.query, nil)
+			require.NoError(t, err)
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Contains(t, []int{http.StatusBadRequest, http.StatusUnprocessableEntity}, resp.StatusCode)
+			mockRepo.AssertNotCalled(t, "SearchEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSuggest_PostgresFallback(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(repomocks.MockEventRepository)
+	event := models.Event{ID: uuid.New(), Title: "ชมรมหุ่นยนต์"}
+
+	search := "หุ่น"
+	mockRepo.On(
+		"SearchEvents",
+		mock.Anything,
+		utils.Pagination{Page: 1, Limit: 3},
+		"th-TH",
+		models.GetAllEventsFilter{Search: &search},
+	).Return([]models.Event{event}, 1, nil)
+
+	app := setupSearchTestAPI(mockRepo, new(repomocks.MockEventOccurrenceRepository), new(repomocks.MockSearchLogRepository))
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/search/suggest?q=%E0%B8%AB%E0%B8%B8%E0%B9%88%E0%B8%99&limit=3", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept-Language", "th-TH")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var suggestions models.SearchSuggestions
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&suggestions))
+	require.Len(t, suggestions.Events, 1)
+	assert.Equal(t, event.ID, suggestions.Events[0].ID)
+	assert.Equal(t, event.Title, suggestions.Events[0].Title)
+	assert.NotNil(t, suggestions.Organizations)
+	assert.NotNil(t, suggestions.Categories)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSuggest_InvalidInput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "missing query", query: ""},
+		{name: "empty query", query: "q="},
+		{name: "limit too large", query: "q=robo&limit=50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(repomocks.MockEventRepository)
+			app := setupSearchTestAPI(mockRepo, new(repomocks.MockEventOccurrenceRepository), new(repomocks.MockSearchLogRepository))
+
+			req, err := http.NewRequest(http.MethodGet, "/api/v1/search/suggest?"+tt.query, nil)
+			require.NoError(t, err)
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+			mockRepo.AssertNotCalled(t, "SearchEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSearchAll_PostgresFallback(t *testing.T) {
+	t.Parallel()
+
+	mockEventRepo := new(repomocks.MockEventRepository)
+	mockOccurrenceRepo := new(repomocks.MockEventOccurrenceRepository)
+
+	event := models.Event{ID: uuid.New(), Title: "Junior Robotics Workshop"}
+	scheduled := models.EventOccurrence{
+		ID:           uuid.New(),
+		Event:        event,
+		Location:     models.Location{District: "Watthana"},
+		StartTime:    time.Now().Add(48 * time.Hour),
+		EndTime:      time.Now().Add(49 * time.Hour),
+		MaxAttendees: 10,
+		CurrEnrolled: 10,
+		Price:        150000,
+		Currency:     "thb",
+		Status:       models.EventOccurrenceStatusScheduled,
+	}
+	cancelled := scheduled
+	cancelled.ID = uuid.New()
+	cancelled.Status = models.EventOccurrenceStatusCancelled
+
+	search := "robot"
+	mockEventRepo.On(
+		"SearchEvents",
+		mock.Anything,
+		utils.Pagination{Page: 1, Limit: 5},
+		"en-US",
+		models.GetAllEventsFilter{Search: &search},
+	).Return([]models.Event{event}, 1, nil)
+	mockOccurrenceRepo.On(
+		"GetAllEventOccurrences",
+		mock.Anything,
+		utils.Pagination{Page: 1, Limit: 5},
+		"en-US",
+		mock.MatchedBy(func(filters models.GetAllEventOccurrencesFilter) bool {
+			return filters.Search != nil && *filters.Search == search && filters.MinDate != nil
+		}),
+	).Return([]models.EventOccurrence{scheduled, cancelled}, nil)
+
+	mockSearchLogRepo := new(repomocks.MockSearchLogRepository)
+	searchLog := &models.SearchLog{ID: uuid.New()}
+	mockSearchLogRepo.On(
+		"CreateSearchLog",
+		mock.Anything,
+		&models.CreateSearchLogData{
+			Endpoint:    models.SearchLogEndpointAll,
+			Query:       "robot",
+			Language:    "en-US",
+			Filters:     map[string]any{"types": []models.SearchType{models.SearchTypeOccurrences, models.SearchTypeEvents, models.SearchTypeOrganizations}},
+			ResultCount: 2,
+		},
+	).Return(searchLog, nil)
+
+	app := setupSearchTestAPI(mockEventRepo, mockOccurrenceRepo, mockSearchLogRepo)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/search?q=robot", nil)
+	require.NoError(t, err)
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result models.UnifiedSearchResult
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.Len(t, result.Occurrences, 1)
+	assert.Equal(t, scheduled.ID, result.Occurrences[0].ID)
+	assert.Equal(t, event.ID, result.Occurrences[0].EventID)
+	assert.Equal(t, "Junior Robotics Workshop", result.Occurrences[0].Title)
+	assert.Equal(t, "Watthana", result.Occurrences[0].District)
+	assert.Equal(t, 150000, result.Occurrences[0].Price)
+	assert.Equal(t, 0, result.Occurrences[0].SeatsLeft)
+	assert.True(t, result.Occurrences[0].SoldOut)
+	require.Len(t, result.Events, 1)
+	assert.Equal(t, event.ID, result.Events[0].ID)
+	assert.NotNil(t, result.Organizations)
+	assert.Empty(t, result.Organizations)
+	require.NotNil(t, result.SearchID)
+	assert.Equal(t, searchLog.ID, *result.SearchID)
+
+	mockEventRepo.AssertExpectations(t)
+	mockOccurrenceRepo.AssertExpectations(t)
+	mockSearchLogRepo.AssertExpectations(t)
+}
+
+func TestSearchAll_Types(t *testing.T) {
+	t.Parallel()
+
+	mockEventRepo := new(repomocks.MockEventRepository)
+	mockOccurrenceRepo := new(repomocks.MockEventOccurrenceRepository)
+	mockEventRepo.On("SearchEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]models.Event{}, 0, nil)
+	mockSearchLogRepo := new(repomocks.MockSearchLogRepository)
+	mockSearchLogRepo.On("CreateSearchLog", mock.Anything, mock.Anything).Return(&models.SearchLog{ID: uuid.New()}, nil)
+
+	app := setupSearchTestAPI(mockEventRepo, mockOccurrenceRepo, mockSearchLogRepo)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/search?q=robot&types=events,%20events", nil)
+	require.NoError(t, err)
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	mockEventRepo.AssertNumberOfCalls(t, "SearchEvents", 1)
+	mockOccurrenceRepo.AssertNotCalled(t, "GetAllEventOccurrences", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSearchAll_InvalidInput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "missing query", query: ""},
+		{name: "unknown type", query: "q=robot&types=events,schools"},
+		{name: "empty type", query: "q=robot&types=events,"},
+		{name: "limit too large", query: "q=robot&limit=50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockEventRepo := new(repomocks.MockEventRepository)
+			mockOccurrenceRepo := new(repomocks.MockEventOccurrenceRepository)
+			app := setupSearchTestAPI(mockEventRepo, mockOccurrenceRepo, new(repomocks.MockSearchLogRepository))
+
+			req, err := http.NewRequest(http.MethodGet, "/api/v1/search?"+tt.query, nil)
+			require.NoError(t, err)
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Contains(t, []int{http.StatusBadRequest, http.StatusUnprocessableEntity}, resp.StatusCode)
+			mockEventRepo.AssertNotCalled(t, "SearchEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			mockOccurrenceRepo.AssertNotCalled(t, "GetAllEventOccurrences", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSearchEvents_LogsFirstPage(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(repomocks.MockEventRepository)
+	event := models.Event{ID: uuid.New(), Title: "Junior Robotics Workshop"}
+	// one result on this page, out of 23 matches
+	mockRepo.On("SearchEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]models.Event{event}, 23, nil)
+
+	mockSearchLogRepo := new(repomocks.MockSearchLogRepository)
+	searchLog := &models.SearchLog{ID: uuid.New()}
+	mockSearchLogRepo.On(
+		"CreateSearchLog",
+		mock.Anything,
+		&models.CreateSearchLogData{
+			Endpoint:    models.SearchLogEndpointEvents,
+			Query:       "robot",
+			Language:    "th-TH",
+			Filters:     map[string]any{"location": true, "radius_km": 5.0, "category": "science"},
+			ResultCount: 23,
+		},
+	).Return(searchLog, nil)
+
+	app := setupSearchTestAPI(mockRepo, new(repomocks.MockEventOccurrenceRepository), mockSearchLogRepo)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/search/events?q=robot&lat=13.7&lng=100.5&radius_km=5&category=science", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept-Language", "th-TH")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result models.SearchEventsResult
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.NotNil(t, result.SearchID)
+	assert.Equal(t, searchLog.ID, *result.SearchID)
+
+	mockSearchLogRepo.AssertExpectations(t)
+}
+
+func TestSearchEvents_LoggingFailure(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(repomocks.MockEventRepository)
+	event := models.Event{ID: uuid.New(), Title: "Junior Robotics Workshop"}
+	mockRepo.On("SearchEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]models.Event{event}, 1, nil)
+
+	mockSearchLogRepo := new(repomocks.MockSearchLogRepository)
+	errr := errs.InternalServerError("Failed to create search log: ", "connection refused")
+	mockSearchLogRepo.On("CreateSearchLog", mock.Anything, mock.Anything).Return(nil, &errr)
+
+	app := setupSearchTestAPI(mockRepo, new(repomocks.MockEventOccurrenceRepository), mockSearchLogRepo)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/search/events?q=robot", nil)
+	require.NoError(t, err)
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result models.SearchEventsResult
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.Len(t, result.Results, 1)
+	assert.Nil(t, result.SearchID)
+}
+
+func TestRecordSearchClick(t *testing.T) {
+	t.Parallel()
+
+	searchID, resultID := uuid.New(), uuid.New()
+	position := 2
+	mockSearchLogRepo := new(repomocks.MockSearchLogRepository)
+	mockSearchLogRepo.On(
+		"RecordSearchClick",
+		mock.Anything,
+		&models.RecordSearchClickData{
+			ID:         searchID,
+			ResultID:   resultID,
+			ResultType: models.SearchResultTypeOccurrence,
+			Position:   position,
+		},
+	).Return(&models.SearchLog{ID: searchID, ClickedResultID: &resultID, ClickedPosition: &position}, nil)
+
+	app := setupSearchTestAPI(new(repomocks.MockEventRepository), new(repomocks.MockEventOccurrenceRepository), mockSearchLogRepo)
+
+	body := `{"result_id": "` + resultID.String() + `", "result_type": "occurrence", "position": 2}`
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/search/"+searchID.String()+"/click", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var log models.SearchLog
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&log))
+	require.NotNil(t, log.ClickedResultID)
+	assert.Equal(t, resultID, *log.ClickedResultID)
+
+	mockSearchLogRepo.AssertExpectations(t)
+}
+
+func TestRecordSearchClick_InvalidResultType(t *testing.T) {
+	t.Parallel()
+
+	mockSearchLogRepo := new(repomocks.MockSearchLogRepository)
+	app := setupSearchTestAPI(new(repomocks.MockEventRepository), new(repomocks.MockEventOccurrenceRepository), mockSearchLogRepo)
+
+	body := `{"result_id": "` + uuid.NewString() + `", "result_type": "school", "position": 0}`
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/search/"+uuid.NewString()+"/click", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	mockSearchLogRepo.AssertNotCalled(t, "RecordSearchClick", mock.Anything, mock.Anything)
+}
+
+func TestGetSearchAnalytics_DefaultWindow(t *testing.T) {
+	mockSearchLogRepo := new(repomocks.MockSearchLogRepository)
+	mockSearchLogRepo.On(
+		"GetSearchAnalytics",
+		mock.Anything,
+		mock.MatchedBy(func(since time.Time) bool {
+			return time.Since(since) > 29*24*time.Hour && time.Since(since) < 31*24*time.Hour
+		}),
+		mock.MatchedBy(func(until time.Time) bool {
+			return time.Since(until) < time.Minute
+		}),
+		20,
+	).Return(&models.SearchAnalytics{
+		Searches:          10,
+		ZeroResultQueries: []models.SearchQueryStats{{Query: "chess", Searches: 3, ZeroResultSearches: 3}},
+	}, nil)
+
+	app := setupSearchTestAPI(new(repomocks.MockEventRepository), new(repomocks.MockEventOccurrenceRepository), mockSearchLogRepo)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/admin/search/analytics", nil)
+	require.NoError(t, err)
+	setAuthCookie(t, req, auth.AdminRole)
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var analytics models.SearchAnalytics
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&analytics))
+	assert.Equal(t, 10, analytics.Searches)
+	require.Len(t, analytics.ZeroResultQueries, 1)
+	assert.Equal(t, "chess", analytics.ZeroResultQueries[0].Query)
+
+	mockSearchLogRepo.AssertExpectations(t)
+}
+
+func TestGetSearchAnalytics_InvalidWindow(t *testing.T) {
+	mockSearchLogRepo := new(repomocks.MockSearchLogRepository)
+	app := setupSearchTestAPI(new(repomocks.MockEventRepository), new(repomocks.MockEventOccurrenceRepository), mockSearchLogRepo)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/admin/search/analytics?since=2026-06-01T00:00:00Z&until=2026-05-01T00:00:00Z", nil)
+	require.NoError(t, err)
+	setAuthCookie(t, req, auth.AdminRole)
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	mockSearchLogRepo.AssertNotCalled(t, "GetSearchAnalytics", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetSearchAnalytics_RejectsNonAdmin(t *testing.T) {
+	mockSearchLogRepo := new(repomocks.MockSearchLogRepository)
+	app := setupSearchTestAPI(new(repomocks.MockEventRepository), new(repomocks.MockEventOccurrenceRepository), mockSearchLogRepo)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/admin/search/analytics", nil)
+	require.NoError(t, err)
+	setAuthCookie(t, req, "guardian")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	mockSearchLogRepo.AssertNotCalled(t, "GetSearchAnalytics", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,145 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"skillspark/internal/auth"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/service/routes"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"skillspark/internal/utils"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupTaskTestAPI(taskRepo *repomocks.MockTaskRepository) (*fiber.App, huma.API) {
+	app := fiber.New()
+	api := humafiber.New(app, huma.DefaultConfig("Test Tasks API", "1.0.0"))
+	repo := &storage.Repository{
+		Task: taskRepo,
+	}
+	routes.SetupTaskRoutes(api, repo)
+	return app, api
+}
+
+func TestGetAllTasks_Success(t *testing.T) {
+	taskRepo := new(repomocks.MockTaskRepository)
+	taskRepo.On("GetAllTasks", mock.Anything, "dead", "", utils.Pagination{Page: 1, Limit: 10}).
+		Return([]models.Task{{ID: uuid.New(), TaskType: "capture_payment", Payload: json.RawMessage(`{}`), Status: models.TaskStatusDead}}, nil)
+
+	app, _ := setupTaskTestAPI(taskRepo)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/admin/tasks?status=dead", nil)
+	assert.NoError(t, err)
+	setAuthCookie(t, req, auth.AdminRole)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var tasks []models.Task
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&tasks))
+	assert.Len(t, tasks, 1)
+	taskRepo.AssertExpectations(t)
+}
+
+func TestGetAllTasks_InvalidStatus(t *testing.T) {
+	taskRepo := new(repomocks.MockTaskRepository)
+	app, _ := setupTaskTestAPI(taskRepo)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/admin/tasks?status=bogus", nil)
+	assert.NoError(t, err)
+	setAuthCookie(t, req, auth.AdminRole)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	taskRepo.AssertNotCalled(t, "GetAllTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRetryTask_Success(t *testing.T) {
+	taskRepo := new(repomocks.MockTaskRepository)
+	taskID := uuid.New()
+	taskRepo.On("RequeueDeadTask", mock.Anything, taskID).
+		Return(&models.Task{ID: taskID, Payload: json.RawMessage(`{}`), Status: models.TaskStatusPending}, nil)
+
+	app, _ := setupTaskTestAPI(taskRepo)
+
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/admin/tasks/"+taskID.String()+"/retry", nil)
+	assert.NoError(t, err)
+	setAuthCookie(t, req, auth.AdminRole)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var task models.Task
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&task))
+	assert.Equal(t, models.TaskStatusPending, task.Status)
+	taskRepo.AssertExpectations(t)
+}
+
+func TestRetryTask_NotDead(t *testing.T) {
+	taskRepo := new(repomocks.MockTaskRepository)
+	taskID := uuid.New()
+	badRequest := errs.BadRequest("Only dead tasks can be retried")
+	taskRepo.On("RequeueDeadTask", mock.Anything, taskID).Return(nil, &badRequest)
+
+	app, _ := setupTaskTestAPI(taskRepo)
+
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/admin/tasks/"+taskID.String()+"/retry", nil)
+	assert.NoError(t, err)
+	setAuthCookie(t, req, auth.AdminRole)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	taskRepo.AssertExpectations(t)
+}
+
+func TestRetryTask_RejectsNonAdmin(t *testing.T) {
+	taskRepo := new(repomocks.MockTaskRepository)
+	app, _ := setupTaskTestAPI(taskRepo)
+
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/admin/tasks/"+uuid.NewString()+"/retry", nil)
+	assert.NoError(t, err)
+	setAuthCookie(t, req, "guardian")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	taskRepo.AssertNotCalled(t, "RequeueDeadTask", mock.Anything, mock.Anything)
+}
+
+func TestGetAllTasks_RejectsMissingToken(t *testing.T) {
+	taskRepo := new(repomocks.MockTaskRepository)
+	app, _ := setupTaskTestAPI(taskRepo)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/admin/tasks", nil)
+	assert.NoError(t, err)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	taskRepo.AssertNotCalled(t, "GetAllTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
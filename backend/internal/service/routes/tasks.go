@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/auth"
+	"skillspark/internal/models"
+	"skillspark/internal/service/handler/task"
+	"skillspark/internal/storage"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+func SetupTaskRoutes(api huma.API, repo *storage.Repository) {
+	taskHandler := task.NewHandler(repo.Task)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-all-tasks",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/admin/tasks",
+		Summary:     "Get background tasks",
+		Description: "Returns tasks from the background task queue with their attempts and last error, newest first",
+		Tags:        []string{"Jobs", "Admin"},
+		Middlewares: huma.Middlewares{auth.RequireAdmin(api)},
+	}, func(ctx context.Context, input *models.GetAllTasksInput) (*models.GetAllTasksOutput, error) {
+		return taskHandler.GetAllTasks(ctx, input)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "retry-task",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/admin/tasks/{id}/retry",
+		Summary:     "Retry a dead task",
+		Description: "Puts a dead-lettered task back on the queue with its attempts reset",
+		Tags:        []string{"Jobs", "Admin"},
+		Middlewares: huma.Middlewares{auth.RequireAdmin(api)},
+	}, func(ctx context.Context, input *models.RetryTaskInput) (*models.RetryTaskOutput, error) {
+		return taskHandler.RetryTask(ctx, input)
+	})
+}
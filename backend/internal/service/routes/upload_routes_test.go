@@ -0,0 +1,120 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	s3mocks "skillspark/internal/s3_client/mocks"
+	"skillspark/internal/service/routes"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupUploadTestAPI(uploadRepo *repomocks.MockUploadRepository, s3Client *s3mocks.S3ClientMock) (*fiber.App, huma.API) {
+	app := fiber.New()
+	api := humafiber.New(app, huma.DefaultConfig("Test Upload API", "1.0.0"))
+	repo := &storage.Repository{
+		Upload: uploadRepo,
+	}
+	routes.SetupUploadRoutes(api, repo, s3Client)
+	return app, api
+}
+
+func TestCreateUploadSession_Success(t *testing.T) {
+	t.Parallel()
+
+	organizationID := uuid.New()
+	session := &models.UploadSession{
+		ID:            uuid.New(),
+		Target:        models.UploadTargetOrganizationPicture,
+		TargetID:      organizationID,
+		StagingKey:    "uploads/abc",
+		ContentType:   "image/webp",
+		ContentLength: 1000,
+		Status:        models.UploadSessionStatusPending,
+	}
+
+	uploadRepo := new(repomocks.MockUploadRepository)
+	uploadRepo.On("CreateUploadSession", mock.Anything, mock.Anything).Return(session, nil)
+	s3Client := new(s3mocks.S3ClientMock)
+	s3Client.On("GeneratePresignedUploadURL", mock.Anything, "uploads/abc", "image/webp", int64(1000), mock.Anything).
+		Return("https://bucket.s3.amazonaws.com/uploads/abc", nil)
+
+	app, _ := setupUploadTestAPI(uploadRepo, s3Client)
+
+	body := `{"target":"organization_picture","target_id":"` + organizationID.String() + `","content_type":"image/webp","content_length":1000}`
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/uploads", strings.NewReader(body))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var out struct {
+		Session   map[string]any    `json:"session"`
+		UploadURL string            `json:"upload_url"`
+		Headers   map[string]string `json:"headers"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	assert.Equal(t, "https://bucket.s3.amazonaws.com/uploads/abc", out.UploadURL)
+	assert.Equal(t, "image/webp", out.Headers["Content-Type"])
+	// the staging key is internal
+	assert.NotContains(t, out.Session, "staging_key")
+	uploadRepo.AssertExpectations(t)
+	s3Client.AssertExpectations(t)
+}
+
+func TestCreateUploadSession_RejectsUnsupportedContentType(t *testing.T) {
+	t.Parallel()
+
+	uploadRepo := new(repomocks.MockUploadRepository)
+	s3Client := new(s3mocks.S3ClientMock)
+	app, _ := setupUploadTestAPI(uploadRepo, s3Client)
+
+	body := `{"target":"event_header","target_id":"` + uuid.NewString() + `","content_type":"image/svg+xml","content_length":1000}`
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/uploads", strings.NewReader(body))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	uploadRepo.AssertNotCalled(t, "CreateUploadSession", mock.Anything, mock.Anything)
+}
+
+func TestFinalizeUploadSession_NotFound(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+	uploadRepo := new(repomocks.MockUploadRepository)
+	notFound := errs.NotFound("Upload session", "id", id)
+	uploadRepo.On("GetUploadSessionByID", mock.Anything, id).Return(nil, &notFound)
+	s3Client := new(s3mocks.S3ClientMock)
+
+	app, _ := setupUploadTestAPI(uploadRepo, s3Client)
+
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/uploads/"+id.String()+"/finalize", nil)
+	assert.NoError(t, err)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	s3Client.AssertNotCalled(t, "HeadObject", mock.Anything, mock.Anything)
+}
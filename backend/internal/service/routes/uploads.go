@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/models"
+	"skillspark/internal/s3_client"
+	"skillspark/internal/service/handler/upload"
+	"skillspark/internal/storage"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+func SetupUploadRoutes(api huma.API, repo *storage.Repository, s3Client s3_client.S3Interface) {
+	uploadHandler := upload.NewHandler(repo.Upload, s3Client)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-upload-session",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/uploads",
+		Summary:     "Start a direct upload",
+		Description: "Returns a presigned URL to PUT an image to S3 for an event, organization, guardian or review. The URL only accepts the declared content type and size, and the upload must be finalized before it is used.",
+		Tags:        []string{"Uploads"},
+	}, func(ctx context.Context, input *models.CreateUploadSessionInput) (*models.CreateUploadSessionOutput, error) {
+		return uploadHandler.CreateUploadSession(ctx, input)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "finalize-upload-session",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/uploads/{id}/finalize",
+		Summary:     "Finalize a direct upload",
+		Description: "Verifies the uploaded image, stores it with its resized renditions and attaches it to the session's target",
+		Tags:        []string{"Uploads"},
+	}, func(ctx context.Context, input *models.FinalizeUploadSessionInput) (*models.FinalizeUploadSessionOutput, error) {
+		return uploadHandler.FinalizeUploadSession(ctx, input)
+	})
+}
@@ -0,0 +1,74 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/auth"
+	"skillspark/internal/models"
+	"skillspark/internal/service/handler/wallet"
+	"skillspark/internal/storage"
+	"skillspark/internal/stripeClient"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+func SetupWalletRoutes(api huma.API, repo *storage.Repository, sc stripeClient.StripeClientInterface) {
+	walletHandler := wallet.NewHandler(repo.Wallet, repo.Guardian, sc)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-wallet-by-guardian-id",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/wallet/{guardian_id}",
+		Summary:     "Get a guardian's wallet",
+		Description: "Returns the guardian's credit balance and transaction history",
+		Tags:        []string{"Wallet"},
+	}, func(ctx context.Context, input *models.GetWalletByGuardianIDInput) (*models.GetWalletByGuardianIDOutput, error) {
+		return walletHandler.GetWalletByGuardianID(ctx, input)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "purchase-gift-card",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/wallet/{guardian_id}/gift-cards",
+		Summary:     "Purchase a gift card",
+		Description: "Charges the guardian's payment method and returns a redeemable gift card code",
+		Tags:        []string{"Wallet"},
+	}, func(ctx context.Context, input *models.PurchaseGiftCardInput) (*models.PurchaseGiftCardOutput, error) {
+		return walletHandler.PurchaseGiftCard(ctx, input)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "redeem-gift-card",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/wallet/{guardian_id}/redeem",
+		Summary:     "Redeem a gift card",
+		Description: "Credits the value of a gift card to the guardian's wallet",
+		Tags:        []string{"Wallet"},
+	}, func(ctx context.Context, input *models.RedeemGiftCardInput) (*models.RedeemGiftCardOutput, error) {
+		return walletHandler.RedeemGiftCard(ctx, input)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-all-wallet-transactions",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/admin/wallet/transactions",
+		Summary:     "Get all wallet transactions",
+		Description: "Returns every wallet transaction with its ledger entries, newest first",
+		Tags:        []string{"Wallet", "Admin"},
+		Middlewares: huma.Middlewares{auth.RequireAdmin(api)},
+	}, func(ctx context.Context, input *models.GetAllWalletTransactionsInput) (*models.GetAllWalletTransactionsOutput, error) {
+		return walletHandler.GetAllWalletTransactions(ctx, input)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "issue-goodwill-credit",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/admin/wallet/{guardian_id}/credits",
+		Summary:     "Issue a goodwill credit",
+		Description: "Adds goodwill credit to a guardian's wallet",
+		Tags:        []string{"Wallet", "Admin"},
+		Middlewares: huma.Middlewares{auth.RequireAdmin(api)},
+	}, func(ctx context.Context, input *models.IssueGoodwillCreditInput) (*models.IssueGoodwillCreditOutput, error) {
+		return walletHandler.IssueGoodwillCredit(ctx, input)
+	})
+}
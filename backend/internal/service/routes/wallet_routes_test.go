@@ -0,0 +1,241 @@
+package routes_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"skillspark/internal/auth"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/service/routes"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	stripemocks "skillspark/internal/stripeClient/mocks"
+	"skillspark/internal/utils"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupWalletTestAPI(
+	walletRepo *repomocks.MockWalletRepository,
+	guardianRepo *repomocks.MockGuardianRepository,
+	sc *stripemocks.MockStripeClient,
+) (*fiber.App, huma.API) {
+	app := fiber.New()
+	api := humafiber.New(app, huma.DefaultConfig("Test Wallet API", "1.0.0"))
+	repo := &storage.Repository{
+		Wallet:   walletRepo,
+		Guardian: guardianRepo,
+	}
+	routes.SetupWalletRoutes(api, repo, sc)
+	return app, api
+}
+
+// setAuthCookie signs a JWT with the given app_metadata role and attaches it to req. The
+// verifier reads its secret from the environment, so callers can't run in parallel.
+func setAuthCookie(t *testing.T, req *http.Request, role string) {
+	t.Helper()
+
+	t.Setenv("SUPABASE_JWT_SECRET", "test-secret")
+	claims := auth.SupabaseClaims{
+		Sub:         uuid.NewString(),
+		Role:        "authenticated",
+		AppMetadata: map[string]interface{}{"role": role},
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test-secret"))
+	assert.NoError(t, err)
+
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: token})
+}
+
+func TestGetWalletByGuardianID_Success(t *testing.T) {
+	t.Parallel()
+
+	walletRepo := new(repomocks.MockWalletRepository)
+	guardianRepo := new(repomocks.MockGuardianRepository)
+	guardianID := uuid.New()
+
+	guardianRepo.On("GetGuardianByID", mock.Anything, guardianID).Return(&models.Guardian{ID: guardianID}, nil)
+	walletRepo.On("GetBalance", mock.Anything, guardianID, "thb").Return(1200, nil)
+	walletRepo.On("GetTransactionsByGuardianID", mock.Anything, guardianID, "thb", utils.Pagination{Page: 1, Limit: 10}).
+		Return([]models.GuardianWalletTransaction{
+			{TransactionID: uuid.New(), TransactionType: models.WalletTransactionTypeRefundCredit, Amount: 1200, Currency: "thb"},
+		}, nil)
+
+	app, _ := setupWalletTestAPI(walletRepo, guardianRepo, nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/wallet/"+guardianID.String(), nil)
+	assert.NoError(t, err)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var decoded struct {
+		Balance      int                                `json:"balance"`
+		Currency     string                             `json:"currency"`
+		Transactions []models.GuardianWalletTransaction `json:"transactions"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+	assert.Equal(t, 1200, decoded.Balance)
+	assert.Equal(t, "thb", decoded.Currency)
+	assert.Len(t, decoded.Transactions, 1)
+
+	walletRepo.AssertExpectations(t)
+}
+
+func TestGetWalletByGuardianID_InvalidUUID(t *testing.T) {
+	t.Parallel()
+
+	app, _ := setupWalletTestAPI(new(repomocks.MockWalletRepository), new(repomocks.MockGuardianRepository), nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/wallet/not-a-uuid", nil)
+	assert.NoError(t, err)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestRedeemGiftCard_AlreadyRedeemed(t *testing.T) {
+	t.Parallel()
+
+	walletRepo := new(repomocks.MockWalletRepository)
+	guardianRepo := new(repomocks.MockGuardianRepository)
+	guardianID := uuid.New()
+
+	guardianRepo.On("GetGuardianByID", mock.Anything, guardianID).Return(&models.Guardian{ID: guardianID}, nil)
+	redeemed := errs.BadRequest("Gift card has already been redeemed")
+	walletRepo.On("RedeemGiftCard", mock.Anything, "ABCD-EFGH-JKMN", guardianID).Return(nil, &redeemed)
+
+	app, _ := setupWalletTestAPI(walletRepo, guardianRepo, nil)
+
+	body, _ := json.Marshal(map[string]string{"code": "abcd-efgh-jkmn"})
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/wallet/"+guardianID.String()+"/redeem", bytes.NewReader(body))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	walletRepo.AssertExpectations(t)
+}
+
+func TestIssueGoodwillCredit_Success(t *testing.T) {
+	walletRepo := new(repomocks.MockWalletRepository)
+	guardianRepo := new(repomocks.MockGuardianRepository)
+	guardianID := uuid.New()
+
+	guardianRepo.On("GetGuardianByID", mock.Anything, guardianID).Return(&models.Guardian{ID: guardianID}, nil)
+	walletRepo.On("CreateTransaction", mock.Anything, mock.AnythingOfType("*models.CreateWalletTransactionData")).
+		Return(&models.WalletTransaction{
+			ID:              uuid.New(),
+			TransactionType: models.WalletTransactionTypeGoodwillCredit,
+			Amount:          300,
+			Currency:        "thb",
+		}, nil)
+
+	app, _ := setupWalletTestAPI(walletRepo, guardianRepo, nil)
+
+	body, _ := json.Marshal(map[string]any{"amount": 300, "description": "sorry about the rain"})
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/admin/wallet/"+guardianID.String()+"/credits", bytes.NewReader(body))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	setAuthCookie(t, req, auth.AdminRole)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var decoded models.WalletTransaction
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+	assert.Equal(t, 300, decoded.Amount)
+
+	walletRepo.AssertExpectations(t)
+}
+
+func TestIssueGoodwillCredit_RejectsNonPositiveAmount(t *testing.T) {
+	app, _ := setupWalletTestAPI(new(repomocks.MockWalletRepository), new(repomocks.MockGuardianRepository), nil)
+
+	body, _ := json.Marshal(map[string]any{"amount": 0, "description": "oops"})
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/admin/wallet/"+uuid.NewString()+"/credits", bytes.NewReader(body))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	setAuthCookie(t, req, auth.AdminRole)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestIssueGoodwillCredit_RejectsNonAdmin(t *testing.T) {
+	walletRepo := new(repomocks.MockWalletRepository)
+	app, _ := setupWalletTestAPI(walletRepo, new(repomocks.MockGuardianRepository), nil)
+
+	body, _ := json.Marshal(map[string]any{"amount": 300, "description": "sorry about the rain"})
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/admin/wallet/"+uuid.NewString()+"/credits", bytes.NewReader(body))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	setAuthCookie(t, req, "guardian")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	walletRepo.AssertNotCalled(t, "CreateTransaction")
+}
+
+func TestIssueGoodwillCredit_RejectsMissingToken(t *testing.T) {
+	t.Parallel()
+
+	app, _ := setupWalletTestAPI(new(repomocks.MockWalletRepository), new(repomocks.MockGuardianRepository), nil)
+
+	body, _ := json.Marshal(map[string]any{"amount": 300, "description": "sorry about the rain"})
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/admin/wallet/"+uuid.NewString()+"/credits", bytes.NewReader(body))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGetAllWalletTransactions_RejectsNonAdmin(t *testing.T) {
+	walletRepo := new(repomocks.MockWalletRepository)
+	app, _ := setupWalletTestAPI(walletRepo, new(repomocks.MockGuardianRepository), nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/admin/wallet/transactions", nil)
+	assert.NoError(t, err)
+	setAuthCookie(t, req, "guardian")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	walletRepo.AssertNotCalled(t, "GetAllTransactions")
+}
@@ -8,9 +8,10 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func SetupWebhookRoutes(app *fiber.App, repo *storage.Repository, webhookSecret string, connectWebhookSecret string, sc stripeClient.StripeClientInterface) {
-	handler := webhook.NewHandler(repo, webhookSecret, connectWebhookSecret, sc)
+func SetupWebhookRoutes(app *fiber.App, repo *storage.Repository, webhookSecret string, connectWebhookSecret string, resendWebhookSecret string, sc stripeClient.StripeClientInterface) {
+	handler := webhook.NewHandler(repo, webhookSecret, connectWebhookSecret, resendWebhookSecret, sc)
 
 	app.Post("/api/v1/webhooks/stripe", handler.HandlePlatformWebhook)
 	app.Post("/api/v1/webhooks/stripe/account", handler.HandleAccountWebhook)
+	app.Post("/api/v1/webhooks/resend", handler.HandleResendWebhook)
 }
@@ -12,12 +12,10 @@ import (
 	"skillspark/internal/opensearch"
 	"skillspark/internal/s3_client"
 	"skillspark/internal/service/routes"
-	"skillspark/internal/sqs_client"
 	"skillspark/internal/storage"
 	"skillspark/internal/storage/postgres"
 	"skillspark/internal/stripeClient"
 	translations "skillspark/internal/translation"
-	"skillspark/jobs"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
@@ -48,18 +46,8 @@ func InitApp(config config.Config) (*App, error) {
 		return nil, err
 	}
 
-	// Initialize SQS client
-	sqsClient, err := sqs_client.NewClient(config.SQS)
-	if err != nil {
-		return nil, err
-	}
-
-	// Initialize notification service and scheduler
-	var notifService *notification.Service
-
-	if config.TestMode {
-		notifService = notification.NewService(repo, sqsClient)
-	}
+	// notifications are queued in the outbox; the worker publishes them to SQS
+	notifService := notification.NewService(repo, notification.UnsubscribeSignerFromConfig(config.Notification), config.Notification.PublicAPIURL)
 
 	c := &http.Client{}
 	translateClient := translations.NewClient(c)
@@ -68,10 +56,6 @@ func InitApp(config config.Config) (*App, error) {
 		return nil, err
 	}
 
-	jobScheduler := jobs.NewJobScheduler(repo, newStripeClient, *notifService)
-	jobScheduler.Start()
-	defer jobScheduler.Stop()
-
 	osClient, err := opensearch.NewClient(config.OpenSearch)
 	if err != nil {
 		return nil, err
@@ -97,6 +81,9 @@ func SetupApp(config config.Config, repo *storage.Repository, s3Client *s3_clien
 		JSONEncoder:  go_json.Marshal,
 		JSONDecoder:  go_json.Unmarshal,
 		ErrorHandler: errs.ErrorHandler,
+		// room for the largest image upload (imageproc.EventHeader) plus the other form
+		// fields; JSON bodies are still capped by Huma
+		BodyLimit: 12 << 20,
 	})
 
 	// Middleware
@@ -134,6 +121,8 @@ func SetupApp(config config.Config, repo *storage.Repository, s3Client *s3_clien
 	routes.SetupAuthRoutes(humaAPI, repo, config)
 	routes.SetupManagerRoutes(humaAPI, repo, config)
 	routes.SetupUserRoutes(humaAPI, repo)
+	routes.SetupNotificationRoutes(humaAPI, &notifService)
+	routes.SetupDigestRoutes(humaAPI, repo)
 
 	// Apply auth middleware — only affects routes registered after this point
 	if !config.TestMode {
@@ -156,6 +145,7 @@ func SetupApp(config config.Config, repo *storage.Repository, s3Client *s3_clien
 	routes.SetupWebhookRoutes(app, repo,
 		os.Getenv("STRIPE_WEBHOOK_SECRET"),
 		os.Getenv("STRIPE_ACCOUNT_WEBHOOK_SECRET"),
+		os.Getenv("RESEND_WEBHOOK_SECRET"),
 		newStripeClient,
 	)
 
@@ -177,15 +167,21 @@ func setupProtectedHumaRoutes(api huma.API, repo *storage.Repository, config con
 	routes.SetupEventRoutes(api, repo, s3Client, translateClient)
 	routes.SetupManagerRoutes(api, repo, config)
 	routes.SetupRegistrationRoutes(api, repo, sc, &notifService)
-	routes.SetupGuardiansRoutes(api, repo, sc, config)
+	routes.SetupGuardiansRoutes(api, repo, sc, s3Client, config)
 	routes.SetupChildRoutes(api, repo)
-	routes.SetupEventOccurrencesRoutes(api, repo, s3Client, sc)
-	routes.SetUpReviewRoutes(api, repo, translateClient)
+	routes.SetupEventOccurrencesRoutes(api, repo, s3Client, sc, &notifService)
+	routes.SetUpReviewRoutes(api, repo, s3Client, translateClient)
 	routes.SetupPaymentRoutes(api, repo, sc)
 	routes.SetUpSavedRoutes(api, repo, s3Client)
 	routes.SetupGeocodingRoutes(api, geocodingService)
 	routes.SetupEmergencyContactRoutes(api, repo)
 	routes.SetupRecommendationRoutes(api, repo, s3Client)
-	routes.SetupSearchRoutes(api, osClient, s3Client, repo.Event)
+	routes.SetupSearchRoutes(api, osClient, s3Client, repo.Event, repo.EventOccurrence, repo.SearchLog)
+	routes.SetupWalletRoutes(api, repo, sc)
+	routes.SetupInboxRoutes(api, repo)
+	routes.SetupBroadcastRoutes(api, repo)
+	routes.SetupJobRoutes(api, repo, sc, notifService, s3Client)
+	routes.SetupTaskRoutes(api, repo)
+	routes.SetupUploadRoutes(api, repo, s3Client)
 	return nil
 }
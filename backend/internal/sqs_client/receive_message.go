@@ -0,0 +1,65 @@
+package sqs_client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// ReceivedMessage is a message taken off the queue. It stays invisible to other consumers
+// until the queue's visibility timeout passes, and comes back unless it is deleted first.
+type ReceivedMessage struct {
+	ID            string
+	Body          string
+	ReceiptHandle string
+	// ReceiveCount is how many times the message has been handed out, this time included
+	ReceiveCount int
+	// DedupID is the dedup_id attribute the message was sent with, empty if none. Copies of
+	// one message share it, while ID differs between copies.
+	DedupID string
+}
+
+// ReceiveMessages long-polls the queue for up to maxMessages messages, waiting at most wait
+// for the first one to arrive
+func (c *Client) ReceiveMessages(ctx context.Context, maxMessages int, wait time.Duration) ([]ReceivedMessage, error) {
+	out, err := c.SQS.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:                    aws.String(c.QueueURL),
+		MaxNumberOfMessages:         int32(maxMessages),
+		WaitTimeSeconds:             int32(wait.Seconds()),
+		MessageSystemAttributeNames: []types.MessageSystemAttributeName{types.MessageSystemAttributeNameApproximateReceiveCount},
+		MessageAttributeNames:       []string{dedupIDAttribute},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive messages from SQS: %w", err)
+	}
+
+	messages := make([]ReceivedMessage, 0, len(out.Messages))
+	for _, m := range out.Messages {
+		count, _ := strconv.Atoi(m.Attributes[string(types.MessageSystemAttributeNameApproximateReceiveCount)])
+		messages = append(messages, ReceivedMessage{
+			ID:            aws.ToString(m.MessageId),
+			Body:          aws.ToString(m.Body),
+			ReceiptHandle: aws.ToString(m.ReceiptHandle),
+			ReceiveCount:  count,
+			DedupID:       aws.ToString(m.MessageAttributes[dedupIDAttribute].StringValue),
+		})
+	}
+	return messages, nil
+}
+
+// DeleteMessage removes a handled message from the queue
+func (c *Client) DeleteMessage(ctx context.Context, receiptHandle string) error {
+	_, err := c.SQS.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(c.QueueURL),
+		ReceiptHandle: aws.String(receiptHandle),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete message from SQS: %w", err)
+	}
+	return nil
+}
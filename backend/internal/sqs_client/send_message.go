@@ -4,13 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 )
 
-// SendMessage sends a notification message to the SQS queue
-func (c *Client) SendMessage(ctx context.Context, messageBody interface{}) error {
+// dedupIDAttribute carries the dedup ID on every message, so consumers of a standard queue
+// can drop the duplicates at-least-once publishing produces
+const dedupIDAttribute = "dedup_id"
+
+// SendMessage sends a notification message to the SQS queue. dedupID is the same for every
+// copy of one message; a FIFO queue drops copies sent within its five-minute deduplication
+// window, and any queue passes it on as the dedup_id message attribute. Empty means none.
+func (c *Client) SendMessage(ctx context.Context, messageBody interface{}, dedupID string) error {
 	// Serialize message body to JSON
 	bodyBytes, err := json.Marshal(messageBody)
 	if err != nil {
@@ -19,10 +27,22 @@ func (c *Client) SendMessage(ctx context.Context, messageBody interface{}) error
 
 	body := string(bodyBytes)
 
-	_, err = c.SQS.SendMessage(ctx, &sqs.SendMessageInput{
+	input := &sqs.SendMessageInput{
 		QueueUrl:    aws.String(c.QueueURL),
 		MessageBody: aws.String(body),
-	})
+	}
+	if dedupID != "" {
+		input.MessageAttributes = map[string]types.MessageAttributeValue{
+			dedupIDAttribute: {DataType: aws.String("String"), StringValue: aws.String(dedupID)},
+		}
+		if strings.HasSuffix(c.QueueURL, ".fifo") {
+			input.MessageDeduplicationId = aws.String(dedupID)
+			// messages are independent, so each is its own group and none waits on another
+			input.MessageGroupId = aws.String(dedupID)
+		}
+	}
+
+	_, err = c.SQS.SendMessage(ctx, input)
 
 	if err != nil {
 		return fmt.Errorf("failed to send message to SQS: %w", err)
@@ -2,8 +2,15 @@ package sqs_client
 
 import (
 	"context"
+	"time"
 )
 
 type SQSInterface interface {
-	SendMessage(ctx context.Context, messageBody interface{}) error
+	SendMessage(ctx context.Context, messageBody interface{}, dedupID string) error
+}
+
+// SQSConsumerInterface is the receiving side of the queue, used by the delivery worker
+type SQSConsumerInterface interface {
+	ReceiveMessages(ctx context.Context, maxMessages int, wait time.Duration) ([]ReceivedMessage, error)
+	DeleteMessage(ctx context.Context, receiptHandle string) error
 }
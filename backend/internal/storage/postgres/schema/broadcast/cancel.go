@@ -0,0 +1,47 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// CancelBroadcast stops a scheduled broadcast from being sent. Broadcasts that have
+// started sending can't be cancelled.
+func (r *BroadcastRepository) CancelBroadcast(ctx context.Context, organizationID uuid.UUID, id uuid.UUID) (*models.Broadcast, error) {
+	query, err := schema.ReadSQLBaseScript("cancel.sql", SqlBroadcastFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, id, organizationID)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to cancel broadcast: ", err.Error())
+		return nil, &errr
+	}
+
+	broadcast, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.Broadcast])
+	if err == nil {
+		return &broadcast, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		errr := errs.InternalServerError("Failed to cancel broadcast: ", err.Error())
+		return nil, &errr
+	}
+
+	// tell a missing broadcast apart from one that is already out
+	existing, err := r.GetBroadcastByID(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	errr := errs.NewHTTPError(http.StatusConflict, fmt.Errorf("broadcast with id='%s' is %s and can no longer be cancelled", id, existing.Status))
+	return nil, &errr
+}
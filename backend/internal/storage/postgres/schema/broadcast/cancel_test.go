@@ -0,0 +1,39 @@
+package broadcast
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	eventoccurrence "skillspark/internal/storage/postgres/schema/event-occurrence"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelBroadcast(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewBroadcastRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	occurrence := eventoccurrence.CreateTestEventOccurrence(t, ctx, testDB)
+	orgID := occurrence.Event.OrganizationID
+	broadcast := CreateTestBroadcast(t, ctx, testDB, orgID, models.BroadcastTargetEventOccurrence, occurrence.ID, time.Now().Add(time.Hour))
+
+	cancelled, err := repo.CancelBroadcast(ctx, orgID, broadcast.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.BroadcastStatusCancelled, cancelled.Status)
+
+	_, err = repo.CancelBroadcast(ctx, orgID, broadcast.ID)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusConflict, err.(*errs.HTTPError).Code)
+
+	_, err = repo.CancelBroadcast(ctx, orgID, uuid.New())
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, err.(*errs.HTTPError).Code)
+}
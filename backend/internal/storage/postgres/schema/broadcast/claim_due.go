@@ -0,0 +1,34 @@
+package broadcast
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ClaimDueBroadcasts moves up to limit broadcasts whose time has come from scheduled to
+// sending and returns them
+func (r *BroadcastRepository) ClaimDueBroadcasts(ctx context.Context, limit int) ([]models.Broadcast, error) {
+	query, err := schema.ReadSQLBaseScript("claim_due.sql", SqlBroadcastFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to claim due broadcasts: ", err.Error())
+		return nil, &errr
+	}
+
+	broadcasts, err := pgx.CollectRows(rows, pgx.RowToStructByName[models.Broadcast])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to scan broadcasts: ", err.Error())
+		return nil, &errr
+	}
+
+	return broadcasts, nil
+}
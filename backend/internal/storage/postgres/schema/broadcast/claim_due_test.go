@@ -0,0 +1,61 @@
+package broadcast
+
+import (
+	"context"
+	"skillspark/internal/models"
+	eventoccurrence "skillspark/internal/storage/postgres/schema/event-occurrence"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimDueBroadcasts(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewBroadcastRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	occurrence := eventoccurrence.CreateTestEventOccurrence(t, ctx, testDB)
+	orgID := occurrence.Event.OrganizationID
+	due := CreateTestBroadcast(t, ctx, testDB, orgID, models.BroadcastTargetEventOccurrence, occurrence.ID, time.Now().Add(-time.Minute))
+	later := CreateTestBroadcast(t, ctx, testDB, orgID, models.BroadcastTargetEventOccurrence, occurrence.ID, time.Now().Add(time.Hour))
+
+	pending, err := repo.GetDueBroadcasts(ctx, 100)
+	require.NoError(t, err)
+	pendingIDs := make(map[uuid.UUID]bool)
+	for _, broadcast := range pending {
+		pendingIDs[broadcast.ID] = true
+	}
+	assert.True(t, pendingIDs[due.ID])
+	assert.False(t, pendingIDs[later.ID])
+
+	claimed, err := repo.ClaimDueBroadcasts(ctx, 100)
+	require.NoError(t, err)
+
+	ids := make(map[uuid.UUID]models.Broadcast)
+	for _, broadcast := range claimed {
+		ids[broadcast.ID] = broadcast
+	}
+	require.Contains(t, ids, due.ID)
+	assert.NotContains(t, ids, later.ID)
+	assert.Equal(t, models.BroadcastStatusSending, ids[due.ID].Status)
+
+	// already claimed
+	claimed, err = repo.ClaimDueBroadcasts(ctx, 100)
+	require.NoError(t, err)
+	for _, broadcast := range claimed {
+		assert.NotEqual(t, due.ID, broadcast.ID)
+	}
+
+	require.NoError(t, repo.FinishBroadcast(ctx, due.ID, 3))
+	sent, err := repo.GetBroadcastByID(ctx, orgID, due.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.BroadcastStatusSent, sent.Status)
+	require.NotNil(t, sent.RecipientCount)
+	assert.Equal(t, 3, *sent.RecipientCount)
+	assert.NotNil(t, sent.SentAt)
+}
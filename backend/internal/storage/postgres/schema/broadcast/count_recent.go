@@ -0,0 +1,28 @@
+package broadcast
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CountRecentBroadcasts counts the organization's broadcasts created since the given time,
+// leaving out cancelled ones
+func (r *BroadcastRepository) CountRecentBroadcasts(ctx context.Context, organizationID uuid.UUID, since time.Time) (int, error) {
+	query, err := schema.ReadSQLBaseScript("count_recent.sql", SqlBroadcastFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return 0, &errr
+	}
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, organizationID, since).Scan(&count); err != nil {
+		errr := errs.InternalServerError("Failed to count broadcasts: ", err.Error())
+		return 0, &errr
+	}
+
+	return count, nil
+}
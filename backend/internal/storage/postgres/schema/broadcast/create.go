@@ -0,0 +1,49 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateBroadcast stores a new scheduled broadcast. It fails with a 404 when the target
+// occurrence or event doesn't exist or belongs to another organization.
+func (r *BroadcastRepository) CreateBroadcast(ctx context.Context, input *models.CreateBroadcastData) (*models.Broadcast, error) {
+	query, err := schema.ReadSQLBaseScript("create.sql", SqlBroadcastFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query,
+		input.OrganizationID,
+		input.ManagerID,
+		input.TargetType,
+		input.TargetID,
+		input.SubjectEN,
+		input.BodyEN,
+		input.SubjectTH,
+		input.BodyTH,
+		input.ScheduledFor,
+	)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to create broadcast: ", err.Error())
+		return nil, &errr
+	}
+
+	broadcast, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.Broadcast])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			errr := errs.NotFound("Broadcast target", "id", input.TargetID)
+			return nil, &errr
+		}
+		errr := errs.InternalServerError("Failed to create broadcast: ", err.Error())
+		return nil, &errr
+	}
+
+	return &broadcast, nil
+}
@@ -0,0 +1,65 @@
+package broadcast
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	eventoccurrence "skillspark/internal/storage/postgres/schema/event-occurrence"
+	"skillspark/internal/storage/postgres/schema/organization"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBroadcast(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	t.Parallel()
+
+	occurrence := eventoccurrence.CreateTestEventOccurrence(t, ctx, testDB)
+	scheduledFor := time.Now().Add(time.Hour)
+
+	broadcast := CreateTestBroadcast(t, ctx, testDB, occurrence.Event.OrganizationID, models.BroadcastTargetEventOccurrence, occurrence.ID, scheduledFor)
+
+	assert.Equal(t, occurrence.Event.OrganizationID, broadcast.OrganizationID)
+	assert.Equal(t, models.BroadcastTargetEventOccurrence, broadcast.TargetType)
+	assert.Equal(t, occurrence.ID, broadcast.TargetID)
+	assert.Equal(t, "Room change", broadcast.SubjectEN)
+	require.NotNil(t, broadcast.SubjectTH)
+	assert.Equal(t, models.BroadcastStatusScheduled, broadcast.Status)
+	assert.WithinDuration(t, scheduledFor, broadcast.ScheduledFor, time.Second)
+	assert.Nil(t, broadcast.RecipientCount)
+}
+
+func TestCreateBroadcast_TargetOfAnotherOrganization(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewBroadcastRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	occurrence := eventoccurrence.CreateTestEventOccurrence(t, ctx, testDB)
+	other := organization.CreateTestOrganization(t, ctx, testDB)
+
+	for _, input := range []*models.CreateBroadcastData{
+		{OrganizationID: other.ID, TargetType: models.BroadcastTargetEventOccurrence, TargetID: occurrence.ID},
+		{OrganizationID: other.ID, TargetType: models.BroadcastTargetEvent, TargetID: occurrence.Event.ID},
+		{OrganizationID: other.ID, TargetType: models.BroadcastTargetOrganization, TargetID: occurrence.Event.OrganizationID},
+	} {
+		input.ManagerID = testManagerID
+		input.SubjectEN = "Room change"
+		input.BodyEN = "Room 204"
+		input.ScheduledFor = time.Now()
+
+		broadcast, err := repo.CreateBroadcast(ctx, input)
+
+		require.Error(t, err)
+		assert.Nil(t, broadcast)
+		httpErr, ok := err.(*errs.HTTPError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, httpErr.Code)
+	}
+}
@@ -0,0 +1,30 @@
+package broadcast
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+)
+
+// FinishBroadcast marks a broadcast sent to recipientCount guardians
+func (r *BroadcastRepository) FinishBroadcast(ctx context.Context, id uuid.UUID, recipientCount int) error {
+	query, err := schema.ReadSQLBaseScript("finish.sql", SqlBroadcastFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return &errr
+	}
+
+	tag, err := r.db.Exec(ctx, query, id, recipientCount)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to finish broadcast: ", err.Error())
+		return &errr
+	}
+	if tag.RowsAffected() == 0 {
+		errr := errs.NotFound("Broadcast", "id", id)
+		return &errr
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetBroadcastByID returns one of the organization's broadcasts
+func (r *BroadcastRepository) GetBroadcastByID(ctx context.Context, organizationID uuid.UUID, id uuid.UUID) (*models.Broadcast, error) {
+	query, err := schema.ReadSQLBaseScript("get_by_id.sql", SqlBroadcastFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, id, organizationID)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to fetch broadcast: ", err.Error())
+		return nil, &errr
+	}
+
+	broadcast, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.Broadcast])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			errr := errs.NotFound("Broadcast", "id", id)
+			return nil, &errr
+		}
+		errr := errs.InternalServerError("Failed to fetch broadcast: ", err.Error())
+		return nil, &errr
+	}
+
+	return &broadcast, nil
+}
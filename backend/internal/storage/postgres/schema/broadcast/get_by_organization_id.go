@@ -0,0 +1,35 @@
+package broadcast
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+	"skillspark/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetBroadcastsByOrganizationID returns the organization's broadcasts, newest first
+func (r *BroadcastRepository) GetBroadcastsByOrganizationID(ctx context.Context, organizationID uuid.UUID, pagination utils.Pagination) ([]models.Broadcast, error) {
+	query, err := schema.ReadSQLBaseScript("get_by_organization_id.sql", SqlBroadcastFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, organizationID, pagination.Limit, pagination.GetOffset())
+	if err != nil {
+		errr := errs.InternalServerError("Failed to fetch broadcasts: ", err.Error())
+		return nil, &errr
+	}
+
+	broadcasts, err := pgx.CollectRows(rows, pgx.RowToStructByName[models.Broadcast])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to scan broadcasts: ", err.Error())
+		return nil, &errr
+	}
+
+	return broadcasts, nil
+}
@@ -0,0 +1,62 @@
+package broadcast
+
+import (
+	"context"
+	"skillspark/internal/models"
+	eventoccurrence "skillspark/internal/storage/postgres/schema/event-occurrence"
+	"skillspark/internal/storage/postgres/testutil"
+	"skillspark/internal/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBroadcastsByOrganizationID(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewBroadcastRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	occurrence := eventoccurrence.CreateTestEventOccurrence(t, ctx, testDB)
+	orgID := occurrence.Event.OrganizationID
+	first := CreateTestBroadcast(t, ctx, testDB, orgID, models.BroadcastTargetEventOccurrence, occurrence.ID, time.Now())
+	second := CreateTestBroadcast(t, ctx, testDB, orgID, models.BroadcastTargetEvent, occurrence.Event.ID, time.Now())
+
+	broadcasts, err := repo.GetBroadcastsByOrganizationID(ctx, orgID, utils.Pagination{Page: 1, Limit: 10})
+
+	require.NoError(t, err)
+	require.Len(t, broadcasts, 2)
+	assert.Equal(t, second.ID, broadcasts[0].ID)
+	assert.Equal(t, first.ID, broadcasts[1].ID)
+
+	got, err := repo.GetBroadcastByID(ctx, orgID, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, got.ID)
+
+	_, err = repo.GetBroadcastByID(ctx, occurrence.Event.ID, first.ID)
+	assert.Error(t, err)
+}
+
+func TestCountRecentBroadcasts(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewBroadcastRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	occurrence := eventoccurrence.CreateTestEventOccurrence(t, ctx, testDB)
+	orgID := occurrence.Event.OrganizationID
+	CreateTestBroadcast(t, ctx, testDB, orgID, models.BroadcastTargetEventOccurrence, occurrence.ID, time.Now())
+	cancelled := CreateTestBroadcast(t, ctx, testDB, orgID, models.BroadcastTargetEventOccurrence, occurrence.ID, time.Now().Add(time.Hour))
+	_, err := repo.CancelBroadcast(ctx, orgID, cancelled.ID)
+	require.NoError(t, err)
+
+	count, err := repo.CountRecentBroadcasts(ctx, orgID, time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	count, err = repo.CountRecentBroadcasts(ctx, orgID, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,34 @@
+package broadcast
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetDueBroadcasts returns up to limit scheduled broadcasts whose time has come, without
+// claiming them
+func (r *BroadcastRepository) GetDueBroadcasts(ctx context.Context, limit int) ([]models.Broadcast, error) {
+	query, err := schema.ReadSQLBaseScript("get_due.sql", SqlBroadcastFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to get due broadcasts: ", err.Error())
+		return nil, &errr
+	}
+
+	broadcasts, err := pgx.CollectRows(rows, pgx.RowToStructByName[models.Broadcast])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to scan broadcasts: ", err.Error())
+		return nil, &errr
+	}
+
+	return broadcasts, nil
+}
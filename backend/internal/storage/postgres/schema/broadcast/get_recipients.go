@@ -0,0 +1,35 @@
+package broadcast
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetBroadcastRecipients returns each guardian the broadcast goes to once, however many
+// of their children are registered. Registrations for other organizations' occurrences are
+// never included, even if the target is one of them.
+func (r *BroadcastRepository) GetBroadcastRecipients(ctx context.Context, broadcast *models.Broadcast) ([]models.Guardian, error) {
+	query, err := schema.ReadSQLBaseScript("get_recipients.sql", SqlBroadcastFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, broadcast.TargetType, broadcast.TargetID, broadcast.OrganizationID)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to fetch broadcast recipients: ", err.Error())
+		return nil, &errr
+	}
+
+	guardians, err := pgx.CollectRows(rows, pgx.RowToStructByName[models.Guardian])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to scan broadcast recipients: ", err.Error())
+		return nil, &errr
+	}
+
+	return guardians, nil
+}
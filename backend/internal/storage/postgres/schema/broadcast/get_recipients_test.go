@@ -0,0 +1,62 @@
+package broadcast
+
+import (
+	"context"
+	"skillspark/internal/models"
+	eventoccurrence "skillspark/internal/storage/postgres/schema/event-occurrence"
+	"skillspark/internal/storage/postgres/schema/organization"
+	"skillspark/internal/storage/postgres/schema/registration"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBroadcastRecipients(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewBroadcastRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	occurrence, err := eventoccurrence.NewEventOccurrenceRepository(testDB).GetEventOccurrenceByID(ctx, reg.EventOccurrenceID, "en-US")
+	require.NoError(t, err)
+
+	guardians, err := repo.GetBroadcastRecipients(ctx, &models.Broadcast{
+		OrganizationID: occurrence.Event.OrganizationID,
+		TargetType:     models.BroadcastTargetEventOccurrence,
+		TargetID:       occurrence.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, guardians, 1)
+	assert.Equal(t, reg.GuardianID, guardians[0].ID)
+	assert.NotEmpty(t, guardians[0].Email)
+
+	// the test occurrence has already finished, so it is left out of wider broadcasts
+	guardians, err = repo.GetBroadcastRecipients(ctx, &models.Broadcast{
+		OrganizationID: occurrence.Event.OrganizationID,
+		TargetType:     models.BroadcastTargetEvent,
+		TargetID:       occurrence.Event.ID,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, guardians)
+}
+
+func TestGetBroadcastRecipients_TargetOfAnotherOrganization(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewBroadcastRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	other := organization.CreateTestOrganization(t, ctx, testDB)
+
+	guardians, err := repo.GetBroadcastRecipients(ctx, &models.Broadcast{
+		OrganizationID: other.ID,
+		TargetType:     models.BroadcastTargetEventOccurrence,
+		TargetID:       reg.EventOccurrenceID,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, guardians)
+}
@@ -0,0 +1,35 @@
+package broadcast
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetBroadcastStats counts the broadcast's notifications per channel by how far their
+// delivery has got
+func (r *BroadcastRepository) GetBroadcastStats(ctx context.Context, id uuid.UUID) ([]models.BroadcastChannelStats, error) {
+	query, err := schema.ReadSQLBaseScript("get_stats.sql", SqlBroadcastFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to fetch broadcast stats: ", err.Error())
+		return nil, &errr
+	}
+
+	stats, err := pgx.CollectRows(rows, pgx.RowToStructByName[models.BroadcastChannelStats])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to scan broadcast stats: ", err.Error())
+		return nil, &errr
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,56 @@
+package broadcast
+
+import (
+	"context"
+	"skillspark/internal/models"
+	eventoccurrence "skillspark/internal/storage/postgres/schema/event-occurrence"
+	"skillspark/internal/storage/postgres/schema/notification"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBroadcastStats(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewBroadcastRepository(testDB)
+	notificationRepo := notification.NewNotificationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	occurrence := eventoccurrence.CreateTestEventOccurrence(t, ctx, testDB)
+	broadcast := CreateTestBroadcast(t, ctx, testDB, occurrence.Event.OrganizationID, models.BroadcastTargetEventOccurrence, occurrence.ID, time.Now())
+
+	email := "parent@example.com"
+	token := "ExponentPushToken[abc]"
+	var created []*models.Notification
+	for _, input := range []*models.CreateScheduledNotificationInput{
+		{NotificationType: models.NotificationTypeEmail, RecipientEmail: &email},
+		{NotificationType: models.NotificationTypeEmail, RecipientEmail: &email},
+		{NotificationType: models.NotificationTypePush, RecipientPushToken: &token},
+	} {
+		input.Body = "Room 204"
+		input.ScheduledFor = time.Now()
+		input.BroadcastID = &broadcast.ID
+		n, err := notificationRepo.CreateScheduledNotification(ctx, input)
+		require.NoError(t, err)
+		created = append(created, n)
+	}
+
+	require.NoError(t, notificationRepo.RecordNotificationDelivery(ctx, created[0].ID, &models.NotificationDeliveryResult{
+		Status:    models.NotificationStatusDelivered,
+		Transport: "smtp",
+	}))
+	// a guardian who turned push off is counted as skipped, not sent
+	_, err := notificationRepo.UpdateNotificationStatus(ctx, created[2].ID, models.NotificationStatusSkipped)
+	require.NoError(t, err)
+
+	stats, err := repo.GetBroadcastStats(ctx, broadcast.ID)
+
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, models.BroadcastChannelStats{Channel: models.NotificationTypeEmail, Pending: 1, Delivered: 1}, stats[0])
+	assert.Equal(t, models.BroadcastChannelStats{Channel: models.NotificationTypePush, Skipped: 1}, stats[1])
+}
@@ -0,0 +1,11 @@
+package broadcast
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type BroadcastRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewBroadcastRepository(db *pgxpool.Pool) *BroadcastRepository {
+	return &BroadcastRepository{db: db}
+}
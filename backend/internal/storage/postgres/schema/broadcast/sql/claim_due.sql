@@ -0,0 +1,13 @@
+-- SKIP LOCKED lets several workers claim due broadcasts without sending one twice
+UPDATE broadcast
+SET status = 'sending'
+WHERE id IN (
+    SELECT id
+    FROM broadcast
+    WHERE status = 'scheduled' AND scheduled_for <= NOW()
+    ORDER BY scheduled_for
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, organization_id, manager_id, target_type, target_id, subject_en, body_en, subject_th, body_th,
+    scheduled_for, status, recipient_count, sent_at, created_at, updated_at;
@@ -0,0 +1,5 @@
+SELECT COUNT(*)
+FROM broadcast
+WHERE organization_id = $1
+  AND created_at >= $2
+  AND status <> 'cancelled';
@@ -0,0 +1,17 @@
+-- the target must belong to the organization; no row comes back otherwise
+INSERT INTO broadcast (organization_id, manager_id, target_type, target_id, subject_en, body_en, subject_th, body_th, scheduled_for)
+SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9
+WHERE CASE $3::broadcast_target_type
+    WHEN 'organization' THEN $4 = $1
+    WHEN 'event' THEN EXISTS (
+        SELECT 1 FROM event e WHERE e.id = $4 AND e.organization_id = $1
+    )
+    WHEN 'event_occurrence' THEN EXISTS (
+        SELECT 1
+        FROM event_occurrence eo
+        JOIN event e ON e.id = eo.event_id
+        WHERE eo.id = $4 AND e.organization_id = $1
+    )
+END
+RETURNING id, organization_id, manager_id, target_type, target_id, subject_en, body_en, subject_th, body_th,
+    scheduled_for, status, recipient_count, sent_at, created_at, updated_at;
@@ -0,0 +1,3 @@
+UPDATE broadcast
+SET status = 'sent', recipient_count = $2, sent_at = NOW()
+WHERE id = $1;
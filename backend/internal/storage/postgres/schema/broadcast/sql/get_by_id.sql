@@ -0,0 +1,4 @@
+SELECT id, organization_id, manager_id, target_type, target_id, subject_en, body_en, subject_th, body_th,
+    scheduled_for, status, recipient_count, sent_at, created_at, updated_at
+FROM broadcast
+WHERE id = $1 AND organization_id = $2;
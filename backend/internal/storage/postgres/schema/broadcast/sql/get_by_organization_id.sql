@@ -0,0 +1,6 @@
+SELECT id, organization_id, manager_id, target_type, target_id, subject_en, body_en, subject_th, body_th,
+    scheduled_for, status, recipient_count, sent_at, created_at, updated_at
+FROM broadcast
+WHERE organization_id = $1
+ORDER BY created_at DESC, id
+LIMIT $2 OFFSET $3;
@@ -0,0 +1,6 @@
+SELECT id, organization_id, manager_id, target_type, target_id, subject_en, body_en, subject_th, body_th,
+    scheduled_for, status, recipient_count, sent_at, created_at, updated_at
+FROM broadcast
+WHERE status = 'scheduled' AND scheduled_for <= NOW()
+ORDER BY scheduled_for
+LIMIT $1;
@@ -0,0 +1,19 @@
+-- guardians with a child still registered for the target; event and organization
+-- broadcasts only reach occurrences that haven't finished. Only the broadcasting
+-- organization's occurrences count, whatever the target.
+SELECT DISTINCT ON (g.id)
+    g.id, g.user_id, u.name, u.email, u.username, u.profile_picture_s3_key, u.language_preference, u.auth_id,
+    g.stripe_customer_id, g.expo_push_token, g.push_notifications, g.email_notifications, g.created_at, g.updated_at
+FROM registration r
+JOIN event_occurrence eo ON eo.id = r.event_occurrence_id
+JOIN event e ON e.id = eo.event_id
+JOIN guardian g ON g.id = r.guardian_id
+JOIN "user" u ON u.id = g.user_id
+WHERE r.status = 'registered'
+  AND eo.status <> 'cancelled'
+  AND CASE $1::broadcast_target_type
+    WHEN 'event_occurrence' THEN eo.id = $2 AND e.organization_id = $3
+    WHEN 'event' THEN e.id = $2 AND e.organization_id = $3 AND eo.end_time > NOW()
+    WHEN 'organization' THEN e.organization_id = $2 AND e.organization_id = $3 AND eo.end_time > NOW()
+  END
+ORDER BY g.id;
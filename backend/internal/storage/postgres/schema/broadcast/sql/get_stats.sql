@@ -0,0 +1,17 @@
+-- bounced covers both rejected email addresses and unregistered push tokens
+SELECT
+    notification_type AS channel,
+    COUNT(*) FILTER (WHERE status = 'pending') AS pending,
+    COUNT(*) FILTER (WHERE status = 'sent') AS sent,
+    COUNT(*) FILTER (WHERE status = 'skipped') AS skipped,
+    COUNT(*) FILTER (WHERE status = 'delivered') AS delivered,
+    COUNT(*) FILTER (
+        WHERE status = 'failed'
+          AND delivery_outcome IS DISTINCT FROM 'bounced'
+          AND delivery_outcome IS DISTINCT FROM 'invalid_token'
+    ) AS failed,
+    COUNT(*) FILTER (WHERE delivery_outcome IN ('bounced', 'invalid_token')) AS bounced
+FROM scheduled_notification
+WHERE broadcast_id = $1
+GROUP BY notification_type
+ORDER BY notification_type;
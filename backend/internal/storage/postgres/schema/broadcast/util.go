@@ -0,0 +1,51 @@
+package broadcast
+
+import (
+	"context"
+	"embed"
+	"skillspark/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed sql/*.sql
+var SqlBroadcastFiles embed.FS
+
+// testManagerID is the manager seeded for test event occurrences
+var testManagerID = uuid.MustParse("50000000-0000-0000-0000-000000000001")
+
+func CreateTestBroadcast(
+	t *testing.T,
+	ctx context.Context,
+	db *pgxpool.Pool,
+	organizationID uuid.UUID,
+	targetType models.BroadcastTargetType,
+	targetID uuid.UUID,
+	scheduledFor time.Time,
+) *models.Broadcast {
+	t.Helper()
+
+	repo := NewBroadcastRepository(db)
+
+	subjectTH := "ย้ายห้องเรียน"
+	bodyTH := "สัปดาห์นี้เรียนที่ห้อง 204"
+	broadcast, err := repo.CreateBroadcast(ctx, &models.CreateBroadcastData{
+		OrganizationID: organizationID,
+		ManagerID:      testManagerID,
+		TargetType:     targetType,
+		TargetID:       targetID,
+		SubjectEN:      "Room change",
+		BodyEN:         "This week's class is in room 204",
+		SubjectTH:      &subjectTH,
+		BodyTH:         &bodyTH,
+		ScheduledFor:   scheduledFor,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, broadcast)
+
+	return broadcast
+}
@@ -0,0 +1,119 @@
+package digest
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+	"skillspark/internal/storage/postgres/schema/inbox"
+	"skillspark/internal/storage/postgres/schema/notification"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateDigest records a guardian's digest for a week along with its items, and schedules
+// its notifications and adds its inbox item in the same transaction so a digest is never
+// recorded without them.
+// A guardian gets one digest a week; a second one for the same week is a conflict.
+func (r *DigestRepository) CreateDigest(ctx context.Context, input *models.CreateDigestData) (*models.Digest, error) {
+	if len(input.Items) == 0 {
+		errr := errs.BadRequest("A digest needs at least one item")
+		return nil, &errr
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to begin transaction: ", err.Error())
+		return nil, &errr
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	digest, err := createDigest(ctx, tx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range input.Notifications {
+		if _, err = notification.CreateScheduledNotificationTx(ctx, tx, &input.Notifications[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.InboxItem != nil {
+		if _, err = inbox.CreateInboxItemTx(ctx, tx, input.InboxItem); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		errr := errs.InternalServerError("Failed to commit transaction: ", err.Error())
+		return nil, &errr
+	}
+
+	return digest, nil
+}
+
+func createDigest(ctx context.Context, tx pgx.Tx, input *models.CreateDigestData) (*models.Digest, error) {
+	query, err := schema.ReadSQLBaseScript("create.sql", SqlDigestFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	itemIDs := make([]uuid.UUID, len(input.Items))
+	childIDs := make([]uuid.UUID, len(input.Items))
+	eventIDs := make([]uuid.UUID, len(input.Items))
+	for i, item := range input.Items {
+		itemIDs[i] = item.ID
+		childIDs[i] = item.ChildID
+		eventIDs[i] = item.EventID
+	}
+
+	rows, err := tx.Query(ctx, query, input.ID, input.GuardianID, input.WeekStart, itemIDs, childIDs, eventIDs)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to create digest: ", err.Error())
+		return nil, &errr
+	}
+	defer rows.Close()
+
+	var digest models.Digest
+	for rows.Next() {
+		var item models.DigestItem
+		if err := rows.Scan(
+			&digest.ID,
+			&digest.GuardianID,
+			&digest.WeekStart,
+			&digest.CreatedAt,
+			&item.ID,
+			&item.DigestID,
+			&item.ChildID,
+			&item.EventID,
+			&item.Position,
+			&item.ClickCount,
+			&item.FirstClickedAt,
+			&item.LastClickedAt,
+			&item.CreatedAt,
+		); err != nil {
+			errr := errs.InternalServerError("Failed to scan digest: ", err.Error())
+			return nil, &errr
+		}
+		digest.Items = append(digest.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		errr := errs.InternalServerError("Failed to create digest: ", err.Error())
+		return nil, &errr
+	}
+
+	if len(digest.Items) == 0 {
+		errr := errs.Conflict("Digest", "week_start", input.WeekStart.Format(time.DateOnly))
+		return nil, &errr
+	}
+
+	return &digest, nil
+}
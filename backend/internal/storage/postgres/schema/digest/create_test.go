@@ -0,0 +1,155 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/child"
+	eventoccurrence "skillspark/internal/storage/postgres/schema/event-occurrence"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDigest(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewDigestRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	weekStart := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	digest := CreateTestDigest(t, ctx, testDB, weekStart)
+
+	assert.NotEqual(t, uuid.Nil, digest.ID)
+	assert.Equal(t, weekStart, digest.WeekStart.UTC())
+	require.Len(t, digest.Items, 1)
+	assert.Equal(t, 1, digest.Items[0].Position)
+	assert.Equal(t, 0, digest.Items[0].ClickCount)
+
+	ids, err := repo.GetRecentlyDigestedEventIDs(ctx, digest.GuardianID, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{digest.Items[0].EventID}, ids)
+
+	// one digest per guardian per week
+	_, err = repo.CreateDigest(ctx, &models.CreateDigestData{
+		ID:         uuid.New(),
+		GuardianID: digest.GuardianID,
+		WeekStart:  weekStart,
+		Items:      []models.CreateDigestItemData{{ID: uuid.New(), ChildID: *digest.Items[0].ChildID, EventID: digest.Items[0].EventID}},
+	})
+	require.Error(t, err)
+	var httpErr *errs.HTTPError
+	require.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusConflict, httpErr.Code)
+}
+
+func TestCreateDigest_SchedulesNotifications(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewDigestRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	c := child.CreateTestChild(t, ctx, testDB)
+	occurrence := eventoccurrence.CreateTestEventOccurrence(t, ctx, testDB)
+	weekStart := time.Date(2026, time.March, 16, 0, 0, 0, 0, time.UTC)
+	email := "guardian@example.com"
+	subject := "Your weekly activities"
+	input := &models.CreateDigestData{
+		ID:         uuid.New(),
+		GuardianID: c.GuardianID,
+		WeekStart:  weekStart,
+		Items:      []models.CreateDigestItemData{{ID: uuid.New(), ChildID: c.ID, EventID: occurrence.Event.ID}},
+		Notifications: []models.CreateScheduledNotificationInput{{
+			NotificationType: models.NotificationTypeEmail,
+			RecipientEmail:   &email,
+			Subject:          &subject,
+			Body:             "New activities this week",
+			ScheduledFor:     time.Now(),
+			GuardianID:       &c.GuardianID,
+		}},
+	}
+
+	// a notification that can't be scheduled leaves no digest behind
+	missingGuardian := uuid.New()
+	input.Notifications[0].GuardianID = &missingGuardian
+	_, err := repo.CreateDigest(ctx, input)
+	require.Error(t, err)
+
+	var count int
+	require.NoError(t, testDB.QueryRow(ctx, `SELECT count(*) FROM notification_digest WHERE guardian_id = $1`, c.GuardianID).Scan(&count))
+	assert.Equal(t, 0, count)
+
+	// so the rerun records the digest and schedules its notification together
+	input.Notifications[0].GuardianID = &c.GuardianID
+	digest, err := repo.CreateDigest(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, input.ID, digest.ID)
+	require.Len(t, digest.Items, 1)
+	assert.Equal(t, input.Items[0].ID, digest.Items[0].ID)
+
+	require.NoError(t, testDB.QueryRow(ctx, `SELECT count(*) FROM scheduled_notification WHERE guardian_id = $1`, c.GuardianID).Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func TestCreateDigest_AddsInboxItem(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewDigestRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	c := child.CreateTestChild(t, ctx, testDB)
+	occurrence := eventoccurrence.CreateTestEventOccurrence(t, ctx, testDB)
+	input := &models.CreateDigestData{
+		ID:         uuid.New(),
+		GuardianID: c.GuardianID,
+		WeekStart:  time.Date(2026, time.March, 23, 0, 0, 0, 0, time.UTC),
+		Items:      []models.CreateDigestItemData{{ID: uuid.New(), ChildID: c.ID, EventID: occurrence.Event.ID}},
+		InboxItem: &models.CreateInboxItemData{
+			GuardianID: c.GuardianID,
+			Kind:       "weekly_digest",
+			Title:      "Your weekly activities",
+			Body:       "1 new activity picked for your family",
+		},
+	}
+
+	_, err := repo.CreateDigest(ctx, input)
+	require.NoError(t, err)
+
+	var count int
+	require.NoError(t, testDB.QueryRow(ctx, `SELECT count(*) FROM notification_inbox_item WHERE guardian_id = $1 AND kind = 'weekly_digest'`, c.GuardianID).Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func TestRecordDigestClick(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewDigestRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	digest := CreateTestDigest(t, ctx, testDB, time.Date(2026, time.March, 9, 0, 0, 0, 0, time.UTC))
+	itemID := digest.Items[0].ID
+
+	item, err := repo.RecordDigestClick(ctx, itemID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, item.ClickCount)
+	require.NotNil(t, item.FirstClickedAt)
+	firstClick := *item.FirstClickedAt
+
+	item, err = repo.RecordDigestClick(ctx, itemID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, item.ClickCount)
+	assert.True(t, firstClick.Equal(*item.FirstClickedAt))
+	assert.Equal(t, digest.Items[0].EventID, item.EventID)
+
+	_, err = repo.RecordDigestClick(ctx, uuid.New())
+	require.Error(t, err)
+	var httpErr *errs.HTTPError
+	require.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusNotFound, httpErr.Code)
+}
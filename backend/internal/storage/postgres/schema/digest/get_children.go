@@ -0,0 +1,34 @@
+package digest
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetDigestChildren returns the guardian's children with their school's coordinates
+func (r *DigestRepository) GetDigestChildren(ctx context.Context, guardianID uuid.UUID) ([]models.DigestChild, error) {
+	query, err := schema.ReadSQLBaseScript("get_children.sql", SqlDigestFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, guardianID)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to fetch digest children: ", err.Error())
+		return nil, &errr
+	}
+
+	children, err := pgx.CollectRows(rows, pgx.RowToStructByName[models.DigestChild])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to scan digest children: ", err.Error())
+		return nil, &errr
+	}
+
+	return children, nil
+}
@@ -0,0 +1,32 @@
+package digest
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/schema/child"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDigestChildren(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewDigestRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	c := child.CreateTestChild(t, ctx, testDB)
+
+	children, err := repo.GetDigestChildren(ctx, c.GuardianID)
+	require.NoError(t, err)
+	require.Len(t, children, 1)
+
+	got := children[0]
+	assert.Equal(t, c.ID, got.ID)
+	assert.Equal(t, c.BirthYear, got.BirthYear)
+	assert.ElementsMatch(t, c.Interests, got.Interests)
+	// the test school has a location
+	assert.NotNil(t, got.Latitude)
+	assert.NotNil(t, got.Longitude)
+}
@@ -0,0 +1,35 @@
+package digest
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetDigestGuardians returns up to limit guardians who opted in to the weekly digest, with
+// IDs after afterID. Pass uuid.Nil for the first page.
+func (r *DigestRepository) GetDigestGuardians(ctx context.Context, afterID uuid.UUID, limit int) ([]models.Guardian, error) {
+	query, err := schema.ReadSQLBaseScript("get_guardians.sql", SqlDigestFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, afterID, limit)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to fetch digest guardians: ", err.Error())
+		return nil, &errr
+	}
+
+	guardians, err := pgx.CollectRows(rows, pgx.RowToStructByName[models.Guardian])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to scan digest guardians: ", err.Error())
+		return nil, &errr
+	}
+
+	return guardians, nil
+}
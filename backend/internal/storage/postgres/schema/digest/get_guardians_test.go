@@ -0,0 +1,57 @@
+package digest
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/guardian"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDigestGuardians(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewDigestRepository(testDB)
+	guardianRepo := guardian.NewGuardianRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	optedIn := guardian.CreateTestGuardian(t, ctx, testDB)
+	optedOut := guardian.CreateTestGuardian(t, ctx, testDB)
+	// push only, but test guardians have no push token to send to
+	unreachable := guardian.CreateTestGuardian(t, ctx, testDB)
+	untouched := guardian.CreateTestGuardian(t, ctx, testDB)
+
+	require.NoError(t, guardianRepo.UpdateGuardianNotificationPreferences(ctx, optedIn.ID, []models.NotificationPreference{
+		{Topic: models.NotificationTopicWeeklyDigest, Channel: models.NotificationTypeEmail, Enabled: true},
+	}))
+	require.NoError(t, guardianRepo.UpdateGuardianNotificationPreferences(ctx, optedOut.ID, []models.NotificationPreference{
+		{Topic: models.NotificationTopicWeeklyDigest, Channel: models.NotificationTypeEmail, Enabled: false},
+	}))
+	require.NoError(t, guardianRepo.UpdateGuardianNotificationPreferences(ctx, unreachable.ID, []models.NotificationPreference{
+		{Topic: models.NotificationTopicWeeklyDigest, Channel: models.NotificationTypePush, Enabled: true},
+	}))
+
+	ids := make(map[uuid.UUID]bool)
+	after := uuid.Nil
+	for {
+		page, err := repo.GetDigestGuardians(ctx, after, 2)
+		require.NoError(t, err)
+		if len(page) == 0 {
+			break
+		}
+		for _, g := range page {
+			assert.False(t, ids[g.ID], "guardian returned on two pages")
+			ids[g.ID] = true
+		}
+		after = page[len(page)-1].ID
+	}
+
+	assert.True(t, ids[optedIn.ID])
+	assert.False(t, ids[optedOut.ID])
+	assert.False(t, ids[unreachable.ID])
+	assert.False(t, ids[untouched.ID])
+}
@@ -0,0 +1,34 @@
+package digest
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetRecentlyDigestedEventIDs returns the events listed in the guardian's digests since since
+func (r *DigestRepository) GetRecentlyDigestedEventIDs(ctx context.Context, guardianID uuid.UUID, since time.Time) ([]uuid.UUID, error) {
+	query, err := schema.ReadSQLBaseScript("get_recent_event_ids.sql", SqlDigestFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, guardianID, since)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to fetch digested events: ", err.Error())
+		return nil, &errr
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to scan digested events: ", err.Error())
+		return nil, &errr
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,39 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// RecordDigestClick counts a click on a digest item's link and returns the item
+func (r *DigestRepository) RecordDigestClick(ctx context.Context, id uuid.UUID) (*models.DigestItem, error) {
+	query, err := schema.ReadSQLBaseScript("record_click.sql", SqlDigestFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to record digest click: ", err.Error())
+		return nil, &errr
+	}
+
+	item, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.DigestItem])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			errr := errs.NotFound("Digest item", "id", id)
+			return nil, &errr
+		}
+		errr := errs.InternalServerError("Failed to record digest click: ", err.Error())
+		return nil, &errr
+	}
+
+	return &item, nil
+}
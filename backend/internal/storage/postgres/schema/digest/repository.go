@@ -0,0 +1,11 @@
+package digest
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type DigestRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewDigestRepository(db *pgxpool.Pool) *DigestRepository {
+	return &DigestRepository{db: db}
+}
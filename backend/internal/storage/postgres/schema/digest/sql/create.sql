@@ -0,0 +1,17 @@
+-- nothing is inserted when the guardian already has a digest for the week
+WITH digest AS (
+    INSERT INTO notification_digest (id, guardian_id, week_start)
+    VALUES ($1, $2, $3)
+    ON CONFLICT (guardian_id, week_start) DO NOTHING
+    RETURNING id, guardian_id, week_start, created_at
+), items AS (
+    INSERT INTO notification_digest_item (id, digest_id, child_id, event_id, position)
+    SELECT item.id, digest.id, item.child_id, item.event_id, item.position::int
+    FROM digest, unnest($4::uuid[], $5::uuid[], $6::uuid[]) WITH ORDINALITY AS item(id, child_id, event_id, position)
+    RETURNING id, digest_id, child_id, event_id, position, click_count, first_clicked_at, last_clicked_at, created_at
+)
+SELECT d.id, d.guardian_id, d.week_start, d.created_at,
+    i.id, i.digest_id, i.child_id, i.event_id, i.position, i.click_count, i.first_clicked_at, i.last_clicked_at, i.created_at
+FROM items i
+JOIN digest d ON d.id = i.digest_id
+ORDER BY i.position;
@@ -0,0 +1,6 @@
+SELECT c.id, c.name, c.birth_year, COALESCE(c.interests::text[], '{}') AS interests, l.latitude, l.longitude
+FROM child c
+LEFT JOIN school s ON s.id = c.school_id
+LEFT JOIN location l ON l.id = s.location_id
+WHERE c.guardian_id = $1
+ORDER BY c.created_at, c.id;
@@ -0,0 +1,20 @@
+-- guardians who turned the digest on for a channel they can still be reached on, in id
+-- order so the job can page through them
+SELECT g.id, g.user_id, u.name, u.email, u.username, u.profile_picture_s3_key, u.language_preference, u.auth_id,
+    g.stripe_customer_id, g.expo_push_token, g.push_notifications, g.email_notifications, g.created_at, g.updated_at
+FROM guardian g
+JOIN "user" u ON u.id = g.user_id
+WHERE g.id > $1
+  AND EXISTS (
+    SELECT 1
+    FROM notification_preference p
+    WHERE p.guardian_id = g.id
+      AND p.topic = 'weekly_digest'
+      AND p.enabled
+      AND (
+        (p.channel = 'email' AND g.email_notifications)
+        OR (p.channel = 'push' AND g.push_notifications AND g.expo_push_token IS NOT NULL)
+      )
+  )
+ORDER BY g.id
+LIMIT $2;
@@ -0,0 +1,4 @@
+SELECT DISTINCT i.event_id
+FROM notification_digest_item i
+JOIN notification_digest d ON d.id = i.digest_id
+WHERE d.guardian_id = $1 AND d.created_at >= $2;
@@ -0,0 +1,6 @@
+UPDATE notification_digest_item
+SET click_count = click_count + 1,
+    first_clicked_at = COALESCE(first_clicked_at, NOW()),
+    last_clicked_at = NOW()
+WHERE id = $1
+RETURNING id, digest_id, child_id, event_id, position, click_count, first_clicked_at, last_clicked_at, created_at;
@@ -0,0 +1,44 @@
+package digest
+
+import (
+	"context"
+	"embed"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/child"
+	eventoccurrence "skillspark/internal/storage/postgres/schema/event-occurrence"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed sql/*.sql
+var SqlDigestFiles embed.FS
+
+// CreateTestDigest creates a digest for a new child's guardian listing a new event
+func CreateTestDigest(
+	t *testing.T,
+	ctx context.Context,
+	db *pgxpool.Pool,
+	weekStart time.Time,
+) *models.Digest {
+	t.Helper()
+
+	repo := NewDigestRepository(db)
+
+	c := child.CreateTestChild(t, ctx, db)
+	occurrence := eventoccurrence.CreateTestEventOccurrence(t, ctx, db)
+
+	digest, err := repo.CreateDigest(ctx, &models.CreateDigestData{
+		ID:         uuid.New(),
+		GuardianID: c.GuardianID,
+		WeekStart:  weekStart,
+		Items:      []models.CreateDigestItemData{{ID: uuid.New(), ChildID: c.ID, EventID: occurrence.Event.ID}},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, digest)
+
+	return digest
+}
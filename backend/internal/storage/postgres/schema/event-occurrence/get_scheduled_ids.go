@@ -0,0 +1,34 @@
+package eventoccurrence
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetScheduledEventOccurrenceIDs returns the id of every scheduled occurrence, for finding
+// search documents of occurrences that have been cancelled or deleted
+func (r *EventOccurrenceRepository) GetScheduledEventOccurrenceIDs(ctx context.Context) ([]uuid.UUID, error) {
+	query, err := schema.ReadSQLBaseScript("get_scheduled_ids.sql", SqlEventOccurrenceFiles)
+	if err != nil {
+		err := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &err
+	}
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		err := errs.InternalServerError("Failed to fetch event occurrence ids: ", err.Error())
+		return nil, &err
+	}
+	defer rows.Close()
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		err := errs.InternalServerError("Failed to scan event occurrence ids: ", err.Error())
+		return nil, &err
+	}
+	return ids, nil
+}
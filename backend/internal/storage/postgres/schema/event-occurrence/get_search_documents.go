@@ -0,0 +1,88 @@
+package eventoccurrence
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetEventOccurrenceSearchDocuments returns a page of scheduled occurrences as they are
+// indexed for search, ordered by id. Pass the last id of a page as afterID to get the next
+// one. With updatedSince, only occurrences where the occurrence, its event, its
+// organization or its location changed after it are returned.
+func (r *EventOccurrenceRepository) GetEventOccurrenceSearchDocuments(ctx context.Context, updatedSince *time.Time, afterID *uuid.UUID, limit int) ([]models.EventOccurrenceSearchDocument, error) {
+	query, err := schema.ReadSQLBaseScript("get_search_documents.sql", SqlEventOccurrenceFiles)
+	if err != nil {
+		err := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &err
+	}
+
+	rows, err := r.db.Query(ctx, query, updatedSince, afterID, limit)
+	if err != nil {
+		err := errs.InternalServerError("Failed to fetch event occurrence search documents: ", err.Error())
+		return nil, &err
+	}
+	defer rows.Close()
+
+	documents, err := pgx.CollectRows(rows, scanSearchDocument)
+	if err != nil {
+		err := errs.InternalServerError("Failed to scan event occurrence search documents: ", err.Error())
+		return nil, &err
+	}
+	return documents, nil
+}
+
+func scanSearchDocument(row pgx.CollectableRow) (models.EventOccurrenceSearchDocument, error) {
+	var document models.EventOccurrenceSearchDocument
+	var id, eventID, organizationID uuid.UUID
+	var latitude, longitude *float64
+	var district *string
+
+	err := row.Scan(
+		&id,
+		&eventID,
+		&organizationID,
+		&document.OrganizationName,
+		&document.TitleEN,
+		&document.TitleTH,
+		&document.DescriptionEN,
+		&document.DescriptionTH,
+		&document.Category,
+		&document.HeaderImageS3Key,
+		&document.AgeRangeMin,
+		&document.AgeRangeMax,
+		&latitude,
+		&longitude,
+		&district,
+		&document.StartTime,
+		&document.EndTime,
+		&document.DurationMinutes,
+		&document.Price,
+		&document.Currency,
+		&document.Language,
+		&document.MaxAttendees,
+		&document.SeatsLeft,
+		&document.UpdatedAt,
+	)
+	if err != nil {
+		return document, err
+	}
+
+	document.ID = id.String()
+	document.EventID = eventID.String()
+	document.OrganizationID = organizationID.String()
+	document.SoldOut = document.SeatsLeft == 0
+	if latitude != nil && longitude != nil {
+		document.Location = &models.SearchGeoPoint{Lat: *latitude, Lon: *longitude}
+	}
+	if district != nil {
+		document.District = *district
+	}
+
+	return document, nil
+}
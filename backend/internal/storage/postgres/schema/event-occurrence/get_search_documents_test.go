@@ -0,0 +1,114 @@
+package eventoccurrence
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findDocument(documents []models.EventOccurrenceSearchDocument, id string) *models.EventOccurrenceSearchDocument {
+	for i := range documents {
+		if documents[i].ID == id {
+			return &documents[i]
+		}
+	}
+	return nil
+}
+
+func TestEventOccurrenceRepository_GetEventOccurrenceSearchDocuments(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewEventOccurrenceRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	occurrence := CreateTestEventOccurrence(t, ctx, testDB)
+
+	documents, err := repo.GetEventOccurrenceSearchDocuments(ctx, nil, nil, 10000)
+	require.NoError(t, err)
+
+	document := findDocument(documents, occurrence.ID.String())
+	require.NotNil(t, document)
+	assert.Equal(t, occurrence.Event.ID.String(), document.EventID)
+	assert.Equal(t, occurrence.Event.OrganizationID.String(), document.OrganizationID)
+	assert.NotEmpty(t, document.OrganizationName)
+	assert.Equal(t, "Junior Robotics Workshop", document.TitleEN)
+	assert.Equal(t, 60, document.DurationMinutes)
+	assert.Equal(t, 10, document.MaxAttendees)
+	assert.Equal(t, 10, document.SeatsLeft)
+	assert.False(t, document.SoldOut)
+	assert.Equal(t, "en", document.Language)
+	assert.NotNil(t, document.Location)
+
+	for i := 1; i < len(documents); i++ {
+		assert.Less(t, documents[i-1].ID, documents[i].ID)
+	}
+}
+
+func TestEventOccurrenceRepository_GetEventOccurrenceSearchDocuments_SkipsCancelled(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewEventOccurrenceRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	occurrence := CreateTestEventOccurrence(t, ctx, testDB)
+	require.NoError(t, repo.CancelEventOccurrence(ctx, occurrence.ID))
+
+	documents, err := repo.GetEventOccurrenceSearchDocuments(ctx, nil, nil, 10000)
+	require.NoError(t, err)
+	assert.Nil(t, findDocument(documents, occurrence.ID.String()))
+
+	ids, err := repo.GetScheduledEventOccurrenceIDs(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, ids, occurrence.ID)
+}
+
+func TestEventOccurrenceRepository_GetEventOccurrenceSearchDocuments_PagesAndUpdatedSince(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewEventOccurrenceRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	occurrence := CreateTestEventOccurrence(t, ctx, testDB)
+
+	all, err := repo.GetEventOccurrenceSearchDocuments(ctx, nil, nil, 10000)
+	require.NoError(t, err)
+	require.Greater(t, len(all), 2)
+
+	first, err := repo.GetEventOccurrenceSearchDocuments(ctx, nil, nil, 2)
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+
+	afterID := uuid.MustParse(first[1].ID)
+	next, err := repo.GetEventOccurrenceSearchDocuments(ctx, nil, &afterID, 2)
+	require.NoError(t, err)
+	require.NotEmpty(t, next)
+	assert.Equal(t, all[2].ID, next[0].ID)
+
+	before := occurrence.UpdatedAt.Add(-time.Second)
+	documents, err := repo.GetEventOccurrenceSearchDocuments(ctx, &before, nil, 10000)
+	require.NoError(t, err)
+	assert.NotNil(t, findDocument(documents, occurrence.ID.String()))
+
+	later := time.Now().Add(time.Hour)
+	documents, err = repo.GetEventOccurrenceSearchDocuments(ctx, &later, nil, 10000)
+	require.NoError(t, err)
+	assert.Empty(t, documents)
+}
@@ -0,0 +1,38 @@
+SELECT
+    eo.id,
+    e.id,
+    e.organization_id,
+    o.name,
+    e.title_en,
+    e.title_th,
+    e.description_en,
+    e.description_th,
+    e.category::text[],
+    e.header_image_s3_key,
+    e.age_range_min,
+    e.age_range_max,
+
+    l.latitude,
+    l.longitude,
+    l.district,
+
+    eo.start_time,
+    eo.end_time,
+    (EXTRACT(EPOCH FROM (eo.end_time - eo.start_time)) / 60)::int,
+    eo.price,
+    eo.currency,
+    eo.language,
+    eo.max_attendees,
+    GREATEST(eo.max_attendees - eo.curr_enrolled, 0),
+    GREATEST(eo.updated_at, e.updated_at, o.updated_at, l.updated_at)
+FROM event_occurrence eo
+JOIN event e ON e.id = eo.event_id
+JOIN organization o ON o.id = e.organization_id
+LEFT JOIN location l ON l.id = o.location_id
+
+WHERE eo.status = 'scheduled'
+AND ($1::timestamptz IS NULL OR GREATEST(eo.updated_at, e.updated_at, o.updated_at, l.updated_at) > $1)
+AND ($2::uuid IS NULL OR eo.id > $2)
+
+ORDER BY eo.id
+LIMIT $3;
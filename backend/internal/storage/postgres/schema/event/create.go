@@ -14,7 +14,7 @@ func (r *EventRepository) CreateEvent(ctx context.Context, event *models.CreateE
 		return nil, &err
 	}
 
-	row := r.db.QueryRow(ctx, query, event.Body.Title_EN, event.Body.Title_TH, event.Body.Description_EN, event.Body.Description_TH, event.Body.OrganizationID, event.Body.AgeRangeMin, event.Body.AgeRangeMax, event.Body.Category, HeaderImageS3Key)
+	row := r.db.QueryRow(ctx, query, event.Body.Title_EN, event.Body.Title_TH, event.Body.Description_EN, event.Body.Description_TH, event.Body.OrganizationID, event.Body.AgeRangeMin, event.Body.AgeRangeMax, event.Body.Category, HeaderImageS3Key, thaiWords(event.Body.Title_TH), thaiWords(event.Body.Description_TH))
 
 	var createdEvent models.Event
 	var titleEN, titleTH, descEN, descTH string
@@ -41,12 +41,13 @@ func (r *EventRepository) GetAllEvents(ctx context.Context, pagination utils.Pag
 	return events, nil
 }
 
-func scanEvent(row pgx.CollectableRow, language string) (models.Event, error) {
+// scanEvent scans an event row; extra receives any columns selected after the event's
+func scanEvent(row pgx.CollectableRow, language string, extra ...any) (models.Event, error) {
 	var event models.Event
 	var titleEN, descriptionEN string
 	var titleTH, descriptionTH *string
 
-	err := row.Scan(
+	dest := []any{
 		&event.ID,
 		&titleEN,
 		&titleTH,
@@ -59,7 +60,8 @@ func scanEvent(row pgx.CollectableRow, language string) (models.Event, error) {
 		&event.HeaderImageS3Key,
 		&event.CreatedAt,
 		&event.UpdatedAt,
-	)
+	}
+	err := row.Scan(append(dest, extra...)...)
 
 	switch language {
 	case "th-TH":
@@ -0,0 +1,34 @@
+package event
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetAllEventIDs returns the id of every event, for finding search documents of events
+// that have been deleted
+func (r *EventRepository) GetAllEventIDs(ctx context.Context) ([]uuid.UUID, error) {
+	query, err := schema.ReadSQLBaseScript("get_all_ids.sql", SqlEventFiles)
+	if err != nil {
+		err := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &err
+	}
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		err := errs.InternalServerError("Failed to fetch event ids: ", err.Error())
+		return nil, &err
+	}
+	defer rows.Close()
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		err := errs.InternalServerError("Failed to scan event ids: ", err.Error())
+		return nil, &err
+	}
+	return ids, nil
+}
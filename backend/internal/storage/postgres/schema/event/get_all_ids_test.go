@@ -0,0 +1,33 @@
+package event
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventRepository_GetAllEventIDs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewEventRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	event := CreateTestEvent(t, ctx, testDB)
+
+	ids, err := repo.GetAllEventIDs(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, ids, event.ID)
+
+	require.NoError(t, repo.DeleteEvent(ctx, event.ID))
+
+	ids, err = repo.GetAllEventIDs(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, ids, event.ID)
+}
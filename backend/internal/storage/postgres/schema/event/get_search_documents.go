@@ -0,0 +1,83 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetEventSearchDocuments returns a page of events as they are indexed for search, ordered
+// by id. Pass the last id of a page as afterID to get the next one. With updatedSince, only
+// events where the event, its organization, its location or one of its occurrences changed
+// after it are returned.
+func (r *EventRepository) GetEventSearchDocuments(ctx context.Context, updatedSince *time.Time, afterID *uuid.UUID, limit int) ([]models.EventSearchDocument, error) {
+	query, err := schema.ReadSQLBaseScript("get_search_documents.sql", SqlEventFiles)
+	if err != nil {
+		err := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &err
+	}
+
+	rows, err := r.db.Query(ctx, query, updatedSince, afterID, limit)
+	if err != nil {
+		err := errs.InternalServerError("Failed to fetch event search documents: ", err.Error())
+		return nil, &err
+	}
+	defer rows.Close()
+
+	documents, err := pgx.CollectRows(rows, scanSearchDocument)
+	if err != nil {
+		err := errs.InternalServerError("Failed to scan event search documents: ", err.Error())
+		return nil, &err
+	}
+	return documents, nil
+}
+
+func scanSearchDocument(row pgx.CollectableRow) (models.EventSearchDocument, error) {
+	var document models.EventSearchDocument
+	var id, organizationID uuid.UUID
+	var latitude, longitude *float64
+	var district *string
+	var occurrences []byte
+
+	err := row.Scan(
+		&id,
+		&organizationID,
+		&document.OrganizationName,
+		&document.TitleEN,
+		&document.TitleTH,
+		&document.DescriptionEN,
+		&document.DescriptionTH,
+		&document.Category,
+		&document.HeaderImageS3Key,
+		&document.AgeRangeMin,
+		&document.AgeRangeMax,
+		&latitude,
+		&longitude,
+		&district,
+		&occurrences,
+		&document.UpdatedAt,
+	)
+	if err != nil {
+		return document, err
+	}
+
+	document.ID = id.String()
+	document.OrganizationID = organizationID.String()
+	if latitude != nil && longitude != nil {
+		document.Location = &models.SearchGeoPoint{Lat: *latitude, Lon: *longitude}
+	}
+	if district != nil {
+		document.District = *district
+	}
+	if err := json.Unmarshal(occurrences, &document.Occurrences); err != nil {
+		return document, err
+	}
+
+	return document, nil
+}
@@ -0,0 +1,109 @@
+package event
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findDocument(documents []models.EventSearchDocument, id string) *models.EventSearchDocument {
+	for i := range documents {
+		if documents[i].ID == id {
+			return &documents[i]
+		}
+	}
+	return nil
+}
+
+func TestEventRepository_GetEventSearchDocuments(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewEventRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	event := CreateTestEvent(t, ctx, testDB)
+
+	documents, err := repo.GetEventSearchDocuments(ctx, nil, nil, 10000)
+	require.NoError(t, err)
+
+	document := findDocument(documents, event.ID.String())
+	require.NotNil(t, document)
+	assert.Equal(t, "Junior Robotics Workshop", document.TitleEN)
+	assert.Equal(t, event.OrganizationID.String(), document.OrganizationID)
+	assert.NotEmpty(t, document.OrganizationName)
+	assert.Equal(t, []string{"science", "technology"}, document.Category)
+	assert.Equal(t, 8, *document.AgeRangeMin)
+	assert.Equal(t, 12, *document.AgeRangeMax)
+	assert.NotNil(t, document.Location)
+	assert.Empty(t, document.Occurrences)
+
+	// seeded events have occurrences
+	seeded := findDocument(documents, "60000000-0000-0000-0000-000000000001")
+	require.NotNil(t, seeded)
+	require.NotEmpty(t, seeded.Occurrences)
+	for _, occurrence := range seeded.Occurrences {
+		assert.Equal(t, int(occurrence.EndTime.Sub(occurrence.StartTime).Minutes()), occurrence.DurationMinutes)
+	}
+
+	for i := 1; i < len(documents); i++ {
+		assert.Less(t, documents[i-1].ID, documents[i].ID)
+	}
+}
+
+func TestEventRepository_GetEventSearchDocuments_Pages(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewEventRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	all, err := repo.GetEventSearchDocuments(ctx, nil, nil, 10000)
+	require.NoError(t, err)
+	require.Greater(t, len(all), 2)
+
+	first, err := repo.GetEventSearchDocuments(ctx, nil, nil, 2)
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+
+	afterID := uuid.MustParse(first[1].ID)
+	next, err := repo.GetEventSearchDocuments(ctx, nil, &afterID, 2)
+	require.NoError(t, err)
+	require.NotEmpty(t, next)
+	assert.Equal(t, all[2].ID, next[0].ID)
+}
+
+func TestEventRepository_GetEventSearchDocuments_UpdatedSince(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewEventRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	event := CreateTestEvent(t, ctx, testDB)
+
+	before := event.UpdatedAt.Add(-time.Second)
+	documents, err := repo.GetEventSearchDocuments(ctx, &before, nil, 10000)
+	require.NoError(t, err)
+	assert.NotNil(t, findDocument(documents, event.ID.String()))
+
+	later := time.Now().Add(time.Hour)
+	documents, err = repo.GetEventSearchDocuments(ctx, &later, nil, 10000)
+	require.NoError(t, err)
+	assert.Empty(t, documents)
+}
@@ -0,0 +1,87 @@
+package event
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+	"skillspark/internal/thai"
+	"skillspark/internal/utils"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SearchEvents is GetAllEvents ranked by relevance to filters.Search. The query is matched
+// against the English text with English stemming and against the segmented Thai words, and
+// titles rank above descriptions. Events that only contain the query as a substring are
+// returned after the ranked ones. The total counts every match, not just the page; a page
+// past the last match has a total of 0.
+func (r *EventRepository) SearchEvents(ctx context.Context, pagination utils.Pagination, AcceptLanguage string, filters models.GetAllEventsFilter) ([]models.Event, int, error) {
+	query, err := schema.ReadSQLBaseScript("search.sql", SqlEventFiles)
+	if err != nil {
+		err := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, 0, &err
+	}
+
+	thaiQuery, pattern := "", ""
+	if filters.Search != nil {
+		thaiQuery = wordsQuery(thai.Segment(*filters.Search))
+		pattern = "%" + likeEscape.Replace(*filters.Search) + "%"
+	}
+
+	rows, err := r.db.Query(ctx, query,
+		pagination.Limit,
+		pagination.GetOffset(),
+		filters.Search,
+		thaiQuery,
+		filters.Category,
+		filters.MinAge,
+		filters.MaxAge,
+		pattern,
+	)
+	if err != nil {
+		err := errs.InternalServerError("Failed to search events: ", err.Error())
+		return nil, 0, &err
+	}
+	defer rows.Close()
+
+	total := 0
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Event, error) {
+		return scanEvent(row, AcceptLanguage, &total)
+	})
+	if err != nil {
+		err := errs.InternalServerError("Failed to scan searched events: ", err.Error())
+		return nil, 0, &err
+	}
+	return events, total, nil
+}
+
+var tsqueryQuote = strings.NewReplacer(`\`, `\\`, `'`, `''`)
+
+// likeEscape escapes LIKE's wildcards, so a query containing % or _ matches them literally
+var likeEscape = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// wordsQuery builds a tsquery matching documents that contain every word. The words are
+// quoted as they are, since the Thai words in search_vector aren't normalised by Postgres.
+func wordsQuery(words []string) string {
+	quoted := make([]string, 0, len(words))
+	for _, word := range words {
+		quoted = append(quoted, "'"+tsqueryQuote.Replace(word)+"'")
+	}
+	return strings.Join(quoted, " & ")
+}
+
+// thaiWords segments Thai text for search_vector, with the words of its compounds so a
+// search for part of one matches. Text without words gets an empty array rather than NULL,
+// so cmd/segment doesn't pick it up again.
+func thaiWords(text *string) []string {
+	if text == nil {
+		return nil
+	}
+	words := thai.SegmentForIndex(*text)
+	if words == nil {
+		words = []string{}
+	}
+	return words
+}
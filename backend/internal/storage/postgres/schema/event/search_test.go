@@ -0,0 +1,156 @@
+package event
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"skillspark/internal/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createThaiEvent(t *testing.T, ctx context.Context, repo *EventRepository, titleEN string, titleTH string, descriptionTH string) *models.Event {
+	t.Helper()
+
+	input := &models.CreateEventDBInput{}
+	input.Body.Title_EN = titleEN
+	input.Body.Description_EN = titleEN + " for curious kids"
+	input.Body.Title_TH = &titleTH
+	input.Body.Description_TH = &descriptionTH
+	input.Body.OrganizationID = uuid.MustParse("40000000-0000-0000-0000-000000000001")
+	input.Body.Category = []string{"science"}
+	input.AcceptLanguage = "th-TH"
+
+	event, err := repo.CreateEvent(ctx, input, nil)
+	require.Nil(t, err)
+	return event
+}
+
+func TestEventRepository_SearchEvents_Thai(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewEventRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	swimming := createThaiEvent(t, ctx, repo, "Zebrafin Swim Lessons", "บทเรียนว่ายน้ำซีบราฟิน", "การสอนว่ายน้ำสำหรับเด็ก")
+
+	search := "ว่ายน้ำเด็ก"
+	events, _, err := repo.SearchEvents(ctx, utils.Pagination{Page: 1, Limit: 100}, "th-TH", models.GetAllEventsFilter{Search: &search})
+	require.Nil(t, err)
+
+	var ids []uuid.UUID
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+	// the words aren't next to each other in the description, so only segmentation finds it
+	assert.Contains(t, ids, swimming.ID)
+}
+
+func TestEventRepository_SearchEvents_RanksTitlesFirst(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewEventRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	inDescription := createThaiEvent(t, ctx, repo, "Quillwort Nature Walk", "เดินป่าควิลเวิร์ต", "สำรวจธรรมชาติและหุ่นยนต์ควิลเวิร์ต")
+	inTitle := createThaiEvent(t, ctx, repo, "Quillwort Robotics", "หุ่นยนต์ควิลเวิร์ต", "สร้างและเขียนโปรแกรม")
+
+	search := "หุ่นยนต์ควิลเวิร์ต"
+	events, _, err := repo.SearchEvents(ctx, utils.Pagination{Page: 1, Limit: 100}, "th-TH", models.GetAllEventsFilter{Search: &search})
+	require.Nil(t, err)
+
+	position := map[uuid.UUID]int{}
+	for i, event := range events {
+		position[event.ID] = i
+	}
+	require.Contains(t, position, inTitle.ID)
+	require.Contains(t, position, inDescription.ID)
+	assert.Less(t, position[inTitle.ID], position[inDescription.ID])
+	assert.Equal(t, "หุ่นยนต์ควิลเวิร์ต", events[position[inTitle.ID]].Title)
+}
+
+func TestEventRepository_SearchEvents_EnglishStemming(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewEventRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	event := createThaiEvent(t, ctx, repo, "Marblewood Painting Classes", "คลาสวาดภาพมาร์เบิลวูด", "วาดภาพและระบายสี")
+
+	search := "marblewood painted class"
+	events, _, err := repo.SearchEvents(ctx, utils.Pagination{Page: 1, Limit: 100}, "en-US", models.GetAllEventsFilter{Search: &search})
+	require.Nil(t, err)
+
+	require.NotEmpty(t, events)
+	assert.Equal(t, event.ID, events[0].ID)
+}
+
+func TestEventRepository_SearchEvents_LiteralWildcards(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewEventRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	discounted := createThaiEvent(t, ctx, repo, "Pebbleshore 50% Off Pottery", "ปั้นดินเผาเพบเบิลชอร์", "ลดราคาครึ่งหนึ่ง")
+	other := createThaiEvent(t, ctx, repo, "Pebbleshore 500 Piece Puzzles", "จิ๊กซอว์เพบเบิลชอร์", "ต่อจิ๊กซอว์")
+
+	search := "shore 50%"
+	events, total, err := repo.SearchEvents(ctx, utils.Pagination{Page: 1, Limit: 100}, "en-US", models.GetAllEventsFilter{Search: &search})
+	require.Nil(t, err)
+
+	var ids []uuid.UUID
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+	// % matches itself, not any run of characters
+	assert.Contains(t, ids, discounted.ID)
+	assert.NotContains(t, ids, other.ID)
+	assert.Equal(t, len(events), total)
+}
+
+func TestEventRepository_SearchEvents_Total(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewEventRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	for _, title := range []string{"Fernhollow Chess Club", "Fernhollow Chess Openings", "Fernhollow Chess Endgames"} {
+		createThaiEvent(t, ctx, repo, title, "หมากรุกเฟิร์นฮอลโลว์", "เล่นหมากรุก")
+	}
+
+	search := "fernhollow chess"
+	events, total, err := repo.SearchEvents(ctx, utils.Pagination{Page: 1, Limit: 2}, "en-US", models.GetAllEventsFilter{Search: &search})
+	require.Nil(t, err)
+
+	assert.Len(t, events, 2)
+	assert.Equal(t, 3, total)
+}
+
+func TestWordsQuery(t *testing.T) {
+	assert.Equal(t, `'ว่ายน้ำ' & 'เด็ก'`, wordsQuery([]string{"ว่ายน้ำ", "เด็ก"}))
+	assert.Equal(t, `'it''s' & 'a\\b'`, wordsQuery([]string{"it's", `a\b`}))
+	assert.Equal(t, "", wordsQuery(nil))
+}
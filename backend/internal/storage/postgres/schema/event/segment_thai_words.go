@@ -0,0 +1,65 @@
+package event
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// SegmentThaiWords stores the segmented Thai words of events that have Thai text but no
+// words yet, or of every event with all, e.g. after the dictionary changes. Returns how
+// many events were updated.
+func (r *EventRepository) SegmentThaiWords(ctx context.Context, all bool, batchSize int) (int, error) {
+	pageQuery, err := schema.ReadSQLBaseScript("segment_page.sql", SqlEventFiles)
+	if err != nil {
+		err := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return 0, &err
+	}
+	updateQuery, err := schema.ReadSQLBaseScript("update_thai_words.sql", SqlEventFiles)
+	if err != nil {
+		err := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return 0, &err
+	}
+
+	type thaiText struct {
+		ID            uuid.UUID
+		TitleTH       *string
+		DescriptionTH *string
+	}
+
+	var afterID *uuid.UUID
+	total := 0
+	for {
+		rows, err := r.db.Query(ctx, pageQuery, all, afterID, batchSize)
+		if err != nil {
+			err := errs.InternalServerError("Failed to fetch events to segment: ", err.Error())
+			return total, &err
+		}
+		page, err := pgx.CollectRows(rows, pgx.RowToStructByPos[thaiText])
+		if err != nil {
+			err := errs.InternalServerError("Failed to scan events to segment: ", err.Error())
+			return total, &err
+		}
+		if len(page) == 0 {
+			return total, nil
+		}
+
+		batch := &pgx.Batch{}
+		for _, event := range page {
+			batch.Queue(updateQuery, event.ID, thaiWords(event.TitleTH), thaiWords(event.DescriptionTH))
+		}
+		if err := r.db.SendBatch(ctx, batch).Close(); err != nil {
+			err := errs.InternalServerError("Failed to store segmented words: ", err.Error())
+			return total, &err
+		}
+		total += len(page)
+
+		if len(page) < batchSize {
+			return total, nil
+		}
+		afterID = &page[len(page)-1].ID
+	}
+}
@@ -0,0 +1,43 @@
+package event
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventRepository_SegmentThaiWords(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewEventRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	// seeded events are inserted without words
+	seeded := uuid.MustParse("60000000-0000-0000-0000-000000000007")
+
+	updated, err := repo.SegmentThaiWords(ctx, false, 4)
+	require.Nil(t, err)
+	assert.Greater(t, updated, 0)
+
+	var titleWords []string
+	err = testDB.QueryRow(ctx, "SELECT title_th_words FROM event WHERE id = $1", seeded).Scan(&titleWords)
+	require.NoError(t, err)
+	assert.NotEmpty(t, titleWords)
+
+	// nothing is left to segment
+	updated, err = repo.SegmentThaiWords(ctx, false, 4)
+	require.Nil(t, err)
+	assert.Equal(t, 0, updated)
+
+	all, err := repo.SegmentThaiWords(ctx, true, 4)
+	require.Nil(t, err)
+	assert.GreaterOrEqual(t, all, 15)
+}
@@ -1,3 +1,3 @@
-Insert into event(title_en, title_th, description_en, description_th, organization_id, age_range_min, age_range_max, category, header_image_s3_key)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+Insert into event(title_en, title_th, description_en, description_th, organization_id, age_range_min, age_range_max, category, header_image_s3_key, title_th_words, description_th_words)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 RETURNING id, title_en, title_th, description_en, description_th, organization_id, age_range_min, age_range_max, category, header_image_s3_key, created_at, updated_at
@@ -0,0 +1,42 @@
+SELECT
+    e.id,
+    e.organization_id,
+    o.name,
+    e.title_en,
+    e.title_th,
+    e.description_en,
+    e.description_th,
+    e.category::text[],
+    e.header_image_s3_key,
+    e.age_range_min,
+    e.age_range_max,
+
+    l.latitude,
+    l.longitude,
+    l.district,
+
+    COALESCE(occ.occurrences, '[]'::json),
+    GREATEST(e.updated_at, o.updated_at, l.updated_at, occ.updated_at)
+FROM event e
+JOIN organization o ON o.id = e.organization_id
+LEFT JOIN location l ON l.id = o.location_id
+LEFT JOIN LATERAL (
+    SELECT
+        json_agg(json_build_object(
+            'id', eo.id,
+            'start_time', eo.start_time,
+            'end_time', eo.end_time,
+            'price', eo.price,
+            'duration_minutes', (EXTRACT(EPOCH FROM (eo.end_time - eo.start_time)) / 60)::int,
+            'sold_out', eo.curr_enrolled >= eo.max_attendees
+        ) ORDER BY eo.start_time) FILTER (WHERE eo.status = 'scheduled') AS occurrences,
+        MAX(eo.updated_at) AS updated_at
+    FROM event_occurrence eo
+    WHERE eo.event_id = e.id
+) occ ON true
+
+WHERE ($1::timestamptz IS NULL OR GREATEST(e.updated_at, o.updated_at, l.updated_at, occ.updated_at) > $1)
+AND ($2::uuid IS NULL OR e.id > $2)
+
+ORDER BY e.id
+LIMIT $3;
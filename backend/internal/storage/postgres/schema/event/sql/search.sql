@@ -0,0 +1,28 @@
+WITH search AS (
+    SELECT websearch_to_tsquery('english', $3) || $4::text::tsquery AS query
+)
+SELECT
+    e.id,
+    e.title_en,
+    e.title_th,
+    e.description_en,
+    e.description_th,
+    e.organization_id,
+    e.age_range_min,
+    e.age_range_max,
+    e.category,
+    e.header_image_s3_key,
+    e.created_at,
+    e.updated_at,
+    COUNT(*) OVER () AS total
+FROM event e, search s
+WHERE 1=1
+-- substring matches catch partly typed words and events whose Thai words haven't been
+-- segmented yet; they rank below full-text matches. $8 is the query with its LIKE
+-- wildcards escaped, and the trigram indexes keep these from scanning every event.
+AND ($3::text IS NULL OR e.search_vector @@ s.query OR e.title_en ILIKE $8 OR e.description_en ILIKE $8 OR e.title_th ILIKE $8 OR e.description_th ILIKE $8)
+AND ($5::text IS NULL OR $5 = '' OR e.category::text[] && string_to_array($5, ','))
+AND ($6::int IS NULL OR e.age_range_max IS NULL OR e.age_range_max >= $6)
+AND ($7::int IS NULL OR e.age_range_min IS NULL OR e.age_range_min <= $7)
+ORDER BY ts_rank(e.search_vector, s.query) DESC, e.created_at DESC
+LIMIT $1 OFFSET $2;
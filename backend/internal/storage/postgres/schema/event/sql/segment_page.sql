@@ -0,0 +1,8 @@
+SELECT id, title_th, description_th
+FROM event
+WHERE ($1::boolean
+    OR (title_th IS NOT NULL AND title_th_words IS NULL)
+    OR (description_th IS NOT NULL AND description_th_words IS NULL))
+AND ($2::uuid IS NULL OR id > $2)
+ORDER BY id
+LIMIT $3;
@@ -1,4 +1,4 @@
 UPDATE event
-SET title_en = $2, title_th = $3, description_en = $4, description_th = $5, organization_id = $6, age_range_min = $7, age_range_max = $8, category = $9, header_image_s3_key = $10, updated_at = NOW()
+SET title_en = $2, title_th = $3, description_en = $4, description_th = $5, organization_id = $6, age_range_min = $7, age_range_max = $8, category = $9, header_image_s3_key = $10, title_th_words = $11, description_th_words = $12, updated_at = NOW()
 WHERE id = $1
 RETURNING id, title_en, title_th, description_en, description_th, organization_id, age_range_min, age_range_max, category, header_image_s3_key, created_at, updated_at
\ No newline at end of file
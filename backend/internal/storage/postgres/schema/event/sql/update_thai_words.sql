@@ -0,0 +1,3 @@
+UPDATE event
+SET title_th_words = $2, description_th_words = $3
+WHERE id = $1;
@@ -14,7 +14,7 @@ func (r *EventRepository) UpdateEvent(ctx context.Context, input *models.UpdateE
 		return nil, &err
 	}
 
-	row := r.db.QueryRow(ctx, query, input.ID, input.Body.Title_EN, input.Body.Title_TH, input.Body.Description_EN, input.Body.Description_TH, input.Body.OrganizationID, input.Body.AgeRangeMin, input.Body.AgeRangeMax, input.Body.Category, HeaderImageS3Key)
+	row := r.db.QueryRow(ctx, query, input.ID, input.Body.Title_EN, input.Body.Title_TH, input.Body.Description_EN, input.Body.Description_TH, input.Body.OrganizationID, input.Body.AgeRangeMin, input.Body.AgeRangeMax, input.Body.Category, HeaderImageS3Key, thaiWords(input.Body.Title_TH), thaiWords(input.Body.Description_TH))
 
 	var event models.Event
 	var titleEN, descEN string
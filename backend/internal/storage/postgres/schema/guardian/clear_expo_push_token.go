@@ -0,0 +1,26 @@
+package guardian
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+)
+
+// ClearExpoPushToken removes token from every guardian registered with it and returns how
+// many were. Matching on the token rather than the guardian leaves alone guardians who
+// have since registered a new device.
+func (r *GuardianRepository) ClearExpoPushToken(ctx context.Context, token string) (int64, error) {
+	query, err := schema.ReadSQLBaseScript("clear_expo_push_token.sql", SqlGuardianFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return 0, &errr
+	}
+
+	tag, err := r.db.Exec(ctx, query, token)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to clear expo push token: ", err.Error())
+		return 0, &errr
+	}
+
+	return tag.RowsAffected(), nil
+}
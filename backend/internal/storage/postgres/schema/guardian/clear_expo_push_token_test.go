@@ -0,0 +1,45 @@
+package guardian
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClearExpoPushToken(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewGuardianRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	stale := CreateTestGuardian(t, ctx, testDB)
+	current := CreateTestGuardian(t, ctx, testDB)
+	staleToken := "ExponentPushToken[" + uuid.NewString() + "]"
+	currentToken := "ExponentPushToken[" + uuid.NewString() + "]"
+	_, err := testDB.Exec(ctx, `UPDATE guardian SET expo_push_token = $2 WHERE id = $1`, stale.ID, staleToken)
+	require.NoError(t, err)
+	_, err = testDB.Exec(ctx, `UPDATE guardian SET expo_push_token = $2 WHERE id = $1`, current.ID, currentToken)
+	require.NoError(t, err)
+
+	cleared, err := repo.ClearExpoPushToken(ctx, staleToken)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), cleared)
+
+	fetched, err := repo.GetGuardianByID(ctx, stale.ID)
+	require.NoError(t, err)
+	assert.Nil(t, fetched.ExpoPushToken)
+
+	fetched, err = repo.GetGuardianByID(ctx, current.ID)
+	require.NoError(t, err)
+	require.NotNil(t, fetched.ExpoPushToken)
+	assert.Equal(t, currentToken, *fetched.ExpoPushToken)
+}
@@ -0,0 +1,32 @@
+package guardian
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+)
+
+// DisableGuardianNotificationChannel turns off the guardian's global switch for channel,
+// leaving their per-topic choices as they were
+func (r *GuardianRepository) DisableGuardianNotificationChannel(ctx context.Context, guardianID uuid.UUID, channel models.NotificationType) error {
+	query, err := schema.ReadSQLBaseScript("disable_notification_channel.sql", SqlGuardianFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return &errr
+	}
+
+	tag, err := r.db.Exec(ctx, query, guardianID, channel)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to disable notification channel: ", err.Error())
+		return &errr
+	}
+	if tag.RowsAffected() == 0 {
+		errr := errs.NotFound("Guardian", "id", guardianID)
+		return &errr
+	}
+
+	return nil
+}
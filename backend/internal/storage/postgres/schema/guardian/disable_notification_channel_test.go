@@ -0,0 +1,37 @@
+package guardian
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisableGuardianNotificationChannel(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewGuardianRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	testGuardian := CreateTestGuardian(t, ctx, testDB)
+	_, err := testDB.Exec(ctx, `UPDATE guardian SET email_notifications = TRUE, push_notifications = TRUE WHERE id = $1`, testGuardian.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.DisableGuardianNotificationChannel(ctx, testGuardian.ID, models.NotificationTypeEmail))
+
+	prefs, err := repo.GetGuardianNotificationPreferences(ctx, []uuid.UUID{testGuardian.ID})
+	require.NoError(t, err)
+	assert.False(t, prefs[testGuardian.ID].EmailNotifications)
+	assert.True(t, prefs[testGuardian.ID].PushNotifications)
+
+	err = repo.DisableGuardianNotificationChannel(ctx, uuid.New(), models.NotificationTypeEmail)
+	assert.Error(t, err)
+}
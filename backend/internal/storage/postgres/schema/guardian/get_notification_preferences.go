@@ -11,6 +11,8 @@ import (
 	"github.com/jackc/pgx/v5"
 )
 
+// GetGuardianNotificationPreferences returns each guardian's global switches together with the
+// topic choices they have made. Guardians that don't exist are left out of the map.
 func (r *GuardianRepository) GetGuardianNotificationPreferences(
 	ctx context.Context,
 	ids []uuid.UUID,
@@ -47,8 +49,44 @@ func (r *GuardianRepository) GetGuardianNotificationPreferences(
 
 	result := make(map[uuid.UUID]models.GuardianNotificationPreferences, len(collected))
 	for _, r := range collected {
+		r.Topics = make(map[models.NotificationTopic]map[models.NotificationType]bool)
 		result[r.ID] = r.GuardianNotificationPreferences
 	}
 
+	topicQuery, err := schema.ReadSQLBaseScript("get_topic_preferences.sql", SqlGuardianFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	topicRows, err := r.db.Query(ctx, topicQuery, uuidStrings)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to query guardian topic preferences: ", err.Error())
+		return nil, &errr
+	}
+	defer topicRows.Close()
+
+	type topicRow struct {
+		GuardianID uuid.UUID `db:"guardian_id"`
+		models.NotificationPreference
+	}
+
+	topics, err := pgx.CollectRows(topicRows, pgx.RowToStructByName[topicRow])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to collect guardian topic preferences: ", err.Error())
+		return nil, &errr
+	}
+
+	for _, t := range topics {
+		prefs, ok := result[t.GuardianID]
+		if !ok {
+			continue
+		}
+		if prefs.Topics[t.Topic] == nil {
+			prefs.Topics[t.Topic] = make(map[models.NotificationType]bool)
+		}
+		prefs.Topics[t.Topic][t.Channel] = t.Enabled
+	}
+
 	return result, nil
 }
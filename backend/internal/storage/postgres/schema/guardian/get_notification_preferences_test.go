@@ -0,0 +1,42 @@
+package guardian
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGuardianNotificationPreferences(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewGuardianRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	guardian := CreateTestGuardian(t, ctx, testDB)
+	untouched := CreateTestGuardian(t, ctx, testDB)
+
+	require.NoError(t, repo.UpdateGuardianNotificationPreferences(ctx, guardian.ID, []models.NotificationPreference{
+		{Topic: models.NotificationTopicEventReminders, Channel: models.NotificationTypeEmail, Enabled: false},
+		{Topic: models.NotificationTopicMarketing, Channel: models.NotificationTypePush, Enabled: true},
+	}))
+	// a later change to the same cell replaces the earlier one
+	require.NoError(t, repo.UpdateGuardianNotificationPreferences(ctx, guardian.ID, []models.NotificationPreference{
+		{Topic: models.NotificationTopicMarketing, Channel: models.NotificationTypePush, Enabled: false},
+	}))
+
+	prefs, err := repo.GetGuardianNotificationPreferences(ctx, []uuid.UUID{guardian.ID, untouched.ID, uuid.New()})
+	require.NoError(t, err)
+	require.Len(t, prefs, 2)
+
+	got := prefs[guardian.ID]
+	assert.Equal(t, map[models.NotificationTopic]map[models.NotificationType]bool{
+		models.NotificationTopicEventReminders: {models.NotificationTypeEmail: false},
+		models.NotificationTopicMarketing:      {models.NotificationTypePush: false},
+	}, got.Topics)
+	assert.Empty(t, prefs[untouched.ID].Topics)
+}
@@ -0,0 +1,5 @@
+UPDATE guardian
+SET
+    expo_push_token = NULL,
+    updated_at = NOW()
+WHERE expo_push_token = $1;
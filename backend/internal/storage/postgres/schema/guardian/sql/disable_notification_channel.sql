@@ -0,0 +1,6 @@
+UPDATE guardian
+SET
+    email_notifications = CASE WHEN $2 = 'email' THEN FALSE ELSE email_notifications END,
+    push_notifications = CASE WHEN $2 = 'push' THEN FALSE ELSE push_notifications END,
+    updated_at = NOW()
+WHERE id = $1;
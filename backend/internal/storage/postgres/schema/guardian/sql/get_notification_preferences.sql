@@ -1,3 +1,10 @@
-SELECT id, push_notifications, email_notifications
+SELECT
+    id,
+    push_notifications,
+    email_notifications,
+    timezone,
+    quiet_hours_enabled,
+    to_char(quiet_hours_start, 'HH24:MI') AS quiet_hours_start,
+    to_char(quiet_hours_end, 'HH24:MI') AS quiet_hours_end
 FROM guardian
 WHERE id = ANY($1::uuid[]);
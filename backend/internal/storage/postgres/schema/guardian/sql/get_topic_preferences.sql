@@ -0,0 +1,3 @@
+SELECT guardian_id, topic, channel, enabled
+FROM notification_preference
+WHERE guardian_id = ANY($1::uuid[]);
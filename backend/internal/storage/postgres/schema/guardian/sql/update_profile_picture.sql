@@ -0,0 +1,5 @@
+UPDATE "user" u
+SET profile_picture_s3_key = $2,
+    updated_at = NOW()
+FROM guardian g
+WHERE g.id = $1 AND u.id = g.user_id;
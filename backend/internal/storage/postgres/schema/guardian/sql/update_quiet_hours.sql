@@ -0,0 +1,7 @@
+UPDATE guardian
+SET timezone = $2,
+    quiet_hours_enabled = $3,
+    quiet_hours_start = $4::time,
+    quiet_hours_end = $5::time,
+    updated_at = NOW()
+WHERE id = $1;
@@ -0,0 +1,5 @@
+INSERT INTO notification_preference (guardian_id, topic, channel, enabled)
+SELECT $1, p.topic, p.channel::notification_type, p.enabled
+FROM unnest($2::text[], $3::text[], $4::boolean[]) AS p(topic, channel, enabled)
+ON CONFLICT (guardian_id, topic, channel)
+DO UPDATE SET enabled = EXCLUDED.enabled, updated_at = NOW();
@@ -0,0 +1,37 @@
+package guardian
+
+import (
+	"context"
+
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+)
+
+// UpdateGuardianNotificationPreferences saves the given cells of the guardian's topic × channel
+// matrix, leaving the others as they were
+func (r *GuardianRepository) UpdateGuardianNotificationPreferences(ctx context.Context, guardianID uuid.UUID, preferences []models.NotificationPreference) error {
+	query, err := schema.ReadSQLBaseScript("upsert_topic_preferences.sql", SqlGuardianFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return &errr
+	}
+
+	topics := make([]string, len(preferences))
+	channels := make([]string, len(preferences))
+	enabled := make([]bool, len(preferences))
+	for i, p := range preferences {
+		topics[i] = string(p.Topic)
+		channels[i] = string(p.Channel)
+		enabled[i] = p.Enabled
+	}
+
+	if _, err := r.db.Exec(ctx, query, guardianID, topics, channels, enabled); err != nil {
+		errr := errs.InternalServerError("Failed to update notification preferences: ", err.Error())
+		return &errr
+	}
+
+	return nil
+}
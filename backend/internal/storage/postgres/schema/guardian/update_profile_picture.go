@@ -0,0 +1,30 @@
+package guardian
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+)
+
+// UpdateGuardianProfilePicture points the guardian's user at a newly uploaded profile picture
+func (r *GuardianRepository) UpdateGuardianProfilePicture(ctx context.Context, guardianID uuid.UUID, key string) error {
+	query, err := schema.ReadSQLBaseScript("update_profile_picture.sql", SqlGuardianFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return &errr
+	}
+
+	tag, err := r.db.Exec(ctx, query, guardianID, key)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to update profile picture: ", err.Error())
+		return &errr
+	}
+	if tag.RowsAffected() == 0 {
+		errr := errs.NotFound("Guardian", "id", guardianID)
+		return &errr
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package guardian
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateGuardianProfilePicture(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewGuardianRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	guardian := CreateTestGuardian(t, ctx, testDB)
+
+	key := "guardians/avatar/" + guardian.ID.String() + "/v1/original.jpg"
+	require.NoError(t, repo.UpdateGuardianProfilePicture(ctx, guardian.ID, key))
+
+	fetched, err := repo.GetGuardianByID(ctx, guardian.ID)
+	require.NoError(t, err)
+	require.NotNil(t, fetched.ProfilePictureS3Key)
+	assert.Equal(t, key, *fetched.ProfilePictureS3Key)
+}
+
+func TestUpdateGuardianProfilePicture_NotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewGuardianRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	err := repo.UpdateGuardianProfilePicture(ctx, uuid.New(), "guardians/avatar/missing/original.jpg")
+	require.Error(t, err)
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusNotFound, httpErr.Code)
+}
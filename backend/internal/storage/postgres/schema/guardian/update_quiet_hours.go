@@ -0,0 +1,31 @@
+package guardian
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+)
+
+// UpdateGuardianQuietHours saves the guardian's timezone and quiet hours window
+func (r *GuardianRepository) UpdateGuardianQuietHours(ctx context.Context, guardianID uuid.UUID, quietHours *models.QuietHours) error {
+	query, err := schema.ReadSQLBaseScript("update_quiet_hours.sql", SqlGuardianFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return &errr
+	}
+
+	tag, err := r.db.Exec(ctx, query, guardianID, quietHours.Timezone, quietHours.Enabled, quietHours.Start, quietHours.End)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to update quiet hours: ", err.Error())
+		return &errr
+	}
+	if tag.RowsAffected() == 0 {
+		errr := errs.NotFound("Guardian", "id", guardianID)
+		return &errr
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package guardian
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateGuardianQuietHours(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewGuardianRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	guardian := CreateTestGuardian(t, ctx, testDB)
+
+	prefs, err := repo.GetGuardianNotificationPreferences(ctx, []uuid.UUID{guardian.ID})
+	require.NoError(t, err)
+	assert.Equal(t, models.QuietHours{Timezone: "Asia/Bangkok", Enabled: true, Start: "21:00", End: "07:00"}, prefs[guardian.ID].QuietHours)
+
+	updated := models.QuietHours{Timezone: "Europe/London", Enabled: true, Start: "22:30", End: "06:15"}
+	require.NoError(t, repo.UpdateGuardianQuietHours(ctx, guardian.ID, &updated))
+
+	prefs, err = repo.GetGuardianNotificationPreferences(ctx, []uuid.UUID{guardian.ID})
+	require.NoError(t, err)
+	assert.Equal(t, updated, prefs[guardian.ID].QuietHours)
+}
+
+func TestUpdateGuardianQuietHours_NotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewGuardianRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	err := repo.UpdateGuardianQuietHours(ctx, uuid.New(), &models.QuietHours{Timezone: "Asia/Bangkok", Start: "21:00", End: "07:00"})
+	require.Error(t, err)
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusNotFound, httpErr.Code)
+}
@@ -0,0 +1,63 @@
+package inbox
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateInboxItem adds an item to a guardian's inbox. When an item with the same dedup key
+// already exists nothing is added and it returns nil without an error.
+func (r *InboxRepository) CreateInboxItem(ctx context.Context, input *models.CreateInboxItemData) (*models.InboxItem, error) {
+	return createInboxItem(ctx, r.db, input)
+}
+
+// CreateInboxItemTx adds the item inside tx, so callers can store it together with the
+// records it is about.
+func CreateInboxItemTx(ctx context.Context, tx pgx.Tx, input *models.CreateInboxItemData) (*models.InboxItem, error) {
+	return createInboxItem(ctx, tx, input)
+}
+
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func createInboxItem(ctx context.Context, db querier, input *models.CreateInboxItemData) (*models.InboxItem, error) {
+	query, err := schema.ReadSQLBaseScript("create.sql", SqlInboxFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := db.Query(ctx, query,
+		input.GuardianID,
+		input.Kind,
+		input.Title,
+		input.Body,
+		input.LinkType,
+		input.LinkID,
+		input.DeepLink,
+		input.Metadata,
+		input.VisibleAt,
+		input.DedupKey,
+	)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to create inbox item: ", err.Error())
+		return nil, &errr
+	}
+
+	item, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.InboxItem])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		errr := errs.InternalServerError("Failed to create inbox item: ", err.Error())
+		return nil, &errr
+	}
+
+	return &item, nil
+}
@@ -0,0 +1,74 @@
+package inbox
+
+import (
+	"context"
+	"encoding/json"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/registration"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateInboxItem(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewInboxRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	linkType := models.InboxLinkRegistration
+	deepLink := "skillspark://registrations/" + reg.ID.String()
+
+	item, err := repo.CreateInboxItem(ctx, &models.CreateInboxItemData{
+		GuardianID: reg.GuardianID,
+		Kind:       "registration_confirmed",
+		Title:      "Registration confirmed",
+		Body:       "You're registered for Robotics Club",
+		LinkType:   &linkType,
+		LinkID:     &reg.ID,
+		DeepLink:   &deepLink,
+		Metadata:   json.RawMessage(`{"type":"registration_confirmed"}`),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, reg.GuardianID, item.GuardianID)
+	assert.Equal(t, models.InboxLinkRegistration, *item.LinkType)
+	assert.Equal(t, reg.ID, *item.LinkID)
+	assert.Equal(t, deepLink, *item.DeepLink)
+	assert.Nil(t, item.ReadAt)
+	assert.WithinDuration(t, time.Now(), item.VisibleAt, time.Minute)
+}
+
+func TestCreateInboxItem_DedupKey(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewInboxRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	dedupKey := "registration_confirmed:" + reg.ID.String()
+	input := &models.CreateInboxItemData{
+		GuardianID: reg.GuardianID,
+		Kind:       "registration_confirmed",
+		Title:      "Registration confirmed",
+		Body:       "You're registered for Robotics Club",
+		DedupKey:   &dedupKey,
+	}
+
+	item, err := repo.CreateInboxItem(ctx, input)
+	require.NoError(t, err)
+	require.NotNil(t, item)
+
+	// a retry with the same key adds nothing
+	item, err = repo.CreateInboxItem(ctx, input)
+	require.NoError(t, err)
+	assert.Nil(t, item)
+
+	var count int
+	require.NoError(t, testDB.QueryRow(ctx, `SELECT count(*) FROM notification_inbox_item WHERE guardian_id = $1 AND kind = 'registration_confirmed'`, reg.GuardianID).Scan(&count))
+	assert.Equal(t, 1, count)
+}
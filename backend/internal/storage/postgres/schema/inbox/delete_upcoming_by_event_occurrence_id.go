@@ -0,0 +1,25 @@
+package inbox
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+)
+
+// DeleteUpcomingInboxItemsByEventOccurrenceID removes undelivered inbox items for every registration of the occurrence
+func (r *InboxRepository) DeleteUpcomingInboxItemsByEventOccurrenceID(ctx context.Context, eventOccurrenceID uuid.UUID) error {
+	query, err := schema.ReadSQLBaseScript("delete_upcoming_by_event_occurrence_id.sql", SqlInboxFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return &errr
+	}
+
+	if _, err := r.db.Exec(ctx, query, eventOccurrenceID); err != nil {
+		errr := errs.InternalServerError("Failed to delete upcoming inbox items: ", err.Error())
+		return &errr
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package inbox
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+)
+
+// DeleteUpcomingInboxItemsByRegistrationID removes the registration's inbox items that have not been delivered yet
+func (r *InboxRepository) DeleteUpcomingInboxItemsByRegistrationID(ctx context.Context, registrationID uuid.UUID) error {
+	query, err := schema.ReadSQLBaseScript("delete_upcoming_by_registration_id.sql", SqlInboxFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return &errr
+	}
+
+	if _, err := r.db.Exec(ctx, query, registrationID); err != nil {
+		errr := errs.InternalServerError("Failed to delete upcoming inbox items: ", err.Error())
+		return &errr
+	}
+
+	return nil
+}
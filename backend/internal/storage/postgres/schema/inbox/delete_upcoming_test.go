@@ -0,0 +1,62 @@
+package inbox
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/schema/registration"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func countInboxItems(t *testing.T, ctx context.Context, repo *InboxRepository, ids ...uuid.UUID) int {
+	t.Helper()
+
+	var count int
+	err := repo.db.QueryRow(ctx, `SELECT COUNT(*) FROM notification_inbox_item WHERE id = ANY($1)`, ids).Scan(&count)
+	require.NoError(t, err)
+	return count
+}
+
+func TestDeleteUpcomingInboxItemsByRegistrationID(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewInboxRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	other := registration.CreateTestRegistration(t, ctx, testDB)
+	upcoming := time.Now().Add(time.Hour)
+
+	pending := CreateTestInboxItem(t, ctx, testDB, reg.GuardianID, &reg.ID, &upcoming)
+	delivered := CreateTestInboxItem(t, ctx, testDB, reg.GuardianID, &reg.ID, nil)
+	otherPending := CreateTestInboxItem(t, ctx, testDB, other.GuardianID, &other.ID, &upcoming)
+
+	require.NoError(t, repo.DeleteUpcomingInboxItemsByRegistrationID(ctx, reg.ID))
+
+	assert.Equal(t, 0, countInboxItems(t, ctx, repo, pending.ID))
+	assert.Equal(t, 2, countInboxItems(t, ctx, repo, delivered.ID, otherPending.ID))
+}
+
+func TestDeleteUpcomingInboxItemsByEventOccurrenceID(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewInboxRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	other := registration.CreateTestRegistration(t, ctx, testDB)
+	upcoming := time.Now().Add(time.Hour)
+
+	pending := CreateTestInboxItem(t, ctx, testDB, reg.GuardianID, &reg.ID, &upcoming)
+	delivered := CreateTestInboxItem(t, ctx, testDB, reg.GuardianID, &reg.ID, nil)
+	otherPending := CreateTestInboxItem(t, ctx, testDB, other.GuardianID, &other.ID, &upcoming)
+
+	require.NoError(t, repo.DeleteUpcomingInboxItemsByEventOccurrenceID(ctx, reg.EventOccurrenceID))
+
+	assert.Equal(t, 0, countInboxItems(t, ctx, repo, pending.ID))
+	assert.Equal(t, 2, countInboxItems(t, ctx, repo, delivered.ID, otherPending.ID))
+}
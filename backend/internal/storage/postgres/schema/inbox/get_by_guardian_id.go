@@ -0,0 +1,35 @@
+package inbox
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+	"skillspark/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetInboxItemsByGuardianID returns the guardian's delivered inbox items, newest first
+func (r *InboxRepository) GetInboxItemsByGuardianID(ctx context.Context, guardianID uuid.UUID, unreadOnly bool, pagination utils.Pagination) ([]models.InboxItem, error) {
+	query, err := schema.ReadSQLBaseScript("get_by_guardian_id.sql", SqlInboxFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, guardianID, unreadOnly, pagination.Limit, pagination.GetOffset())
+	if err != nil {
+		errr := errs.InternalServerError("Failed to fetch inbox items: ", err.Error())
+		return nil, &errr
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[models.InboxItem])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to scan inbox items: ", err.Error())
+		return nil, &errr
+	}
+
+	return items, nil
+}
@@ -0,0 +1,44 @@
+package inbox
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/schema/guardian"
+	"skillspark/internal/storage/postgres/testutil"
+	"skillspark/internal/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetInboxItemsByGuardianID(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewInboxRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	other := guardian.CreateTestGuardian(t, ctx, testDB)
+
+	older := time.Now().Add(-time.Hour)
+	upcoming := time.Now().Add(time.Hour)
+	first := CreateTestInboxItem(t, ctx, testDB, g.ID, nil, &older)
+	second := CreateTestInboxItem(t, ctx, testDB, g.ID, nil, nil)
+	CreateTestInboxItem(t, ctx, testDB, g.ID, nil, &upcoming)
+	CreateTestInboxItem(t, ctx, testDB, other.ID, nil, nil)
+
+	_, err := repo.MarkInboxItemRead(ctx, g.ID, second.ID)
+	require.NoError(t, err)
+
+	items, err := repo.GetInboxItemsByGuardianID(ctx, g.ID, false, utils.Pagination{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, second.ID, items[0].ID)
+	assert.Equal(t, first.ID, items[1].ID)
+
+	unread, err := repo.GetInboxItemsByGuardianID(ctx, g.ID, true, utils.Pagination{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, unread, 1)
+	assert.Equal(t, first.ID, unread[0].ID)
+}
@@ -0,0 +1,25 @@
+package inbox
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+)
+
+func (r *InboxRepository) GetUnreadInboxCount(ctx context.Context, guardianID uuid.UUID) (int, error) {
+	query, err := schema.ReadSQLBaseScript("get_unread_count.sql", SqlInboxFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return 0, &errr
+	}
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, guardianID).Scan(&count); err != nil {
+		errr := errs.InternalServerError("Failed to count unread inbox items: ", err.Error())
+		return 0, &errr
+	}
+
+	return count, nil
+}
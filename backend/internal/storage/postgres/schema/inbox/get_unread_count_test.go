@@ -0,0 +1,32 @@
+package inbox
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/schema/guardian"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUnreadInboxCount(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewInboxRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	upcoming := time.Now().Add(time.Hour)
+	CreateTestInboxItem(t, ctx, testDB, g.ID, nil, nil)
+	read := CreateTestInboxItem(t, ctx, testDB, g.ID, nil, nil)
+	CreateTestInboxItem(t, ctx, testDB, g.ID, nil, &upcoming)
+
+	_, err := repo.MarkInboxItemRead(ctx, g.ID, read.ID)
+	require.NoError(t, err)
+
+	count, err := repo.GetUnreadInboxCount(ctx, g.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
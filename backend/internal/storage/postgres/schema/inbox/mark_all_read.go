@@ -0,0 +1,26 @@
+package inbox
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+)
+
+// MarkAllInboxItemsRead marks every delivered, unread item as read and returns how many changed
+func (r *InboxRepository) MarkAllInboxItemsRead(ctx context.Context, guardianID uuid.UUID) (int, error) {
+	query, err := schema.ReadSQLBaseScript("mark_all_read.sql", SqlInboxFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return 0, &errr
+	}
+
+	tag, err := r.db.Exec(ctx, query, guardianID)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to mark inbox items read: ", err.Error())
+		return 0, &errr
+	}
+
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,35 @@
+package inbox
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/schema/guardian"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkAllInboxItemsRead(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewInboxRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	other := guardian.CreateTestGuardian(t, ctx, testDB)
+	upcoming := time.Now().Add(time.Hour)
+	CreateTestInboxItem(t, ctx, testDB, g.ID, nil, nil)
+	CreateTestInboxItem(t, ctx, testDB, g.ID, nil, nil)
+	CreateTestInboxItem(t, ctx, testDB, g.ID, nil, &upcoming)
+	CreateTestInboxItem(t, ctx, testDB, other.ID, nil, nil)
+
+	updated, err := repo.MarkAllInboxItemsRead(ctx, g.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated)
+
+	count, err := repo.GetUnreadInboxCount(ctx, other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,40 @@
+package inbox
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// MarkInboxItemRead marks one of the guardian's items as read. Items that belong to another
+// guardian, or have not been delivered yet, are reported as not found.
+func (r *InboxRepository) MarkInboxItemRead(ctx context.Context, guardianID uuid.UUID, id uuid.UUID) (*models.InboxItem, error) {
+	query, err := schema.ReadSQLBaseScript("mark_read.sql", SqlInboxFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, id, guardianID)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to mark inbox item read: ", err.Error())
+		return nil, &errr
+	}
+
+	item, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.InboxItem])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			errr := errs.NotFound("InboxItem", "id", id)
+			return nil, &errr
+		}
+		errr := errs.InternalServerError("Failed to mark inbox item read: ", err.Error())
+		return nil, &errr
+	}
+
+	return &item, nil
+}
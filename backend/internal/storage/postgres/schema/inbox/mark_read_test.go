@@ -0,0 +1,50 @@
+package inbox
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/schema/guardian"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkInboxItemRead(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewInboxRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	item := CreateTestInboxItem(t, ctx, testDB, g.ID, nil, nil)
+
+	read, err := repo.MarkInboxItemRead(ctx, g.ID, item.ID)
+	require.NoError(t, err)
+	require.NotNil(t, read.ReadAt)
+
+	// marking again keeps the original read time
+	again, err := repo.MarkInboxItemRead(ctx, g.ID, item.ID)
+	require.NoError(t, err)
+	assert.True(t, read.ReadAt.Equal(*again.ReadAt))
+}
+
+func TestMarkInboxItemRead_NotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewInboxRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	other := guardian.CreateTestGuardian(t, ctx, testDB)
+	upcoming := time.Now().Add(time.Hour)
+	othersItem := CreateTestInboxItem(t, ctx, testDB, other.ID, nil, nil)
+	undelivered := CreateTestInboxItem(t, ctx, testDB, g.ID, nil, &upcoming)
+
+	_, err := repo.MarkInboxItemRead(ctx, g.ID, othersItem.ID)
+	assert.Error(t, err)
+
+	_, err = repo.MarkInboxItemRead(ctx, g.ID, undelivered.ID)
+	assert.Error(t, err)
+}
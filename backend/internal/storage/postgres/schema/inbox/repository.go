@@ -0,0 +1,11 @@
+package inbox
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type InboxRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewInboxRepository(db *pgxpool.Pool) *InboxRepository {
+	return &InboxRepository{db: db}
+}
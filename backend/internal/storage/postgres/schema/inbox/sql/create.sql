@@ -0,0 +1,4 @@
+INSERT INTO notification_inbox_item (guardian_id, kind, title, body, link_type, link_id, deep_link, metadata, visible_at, dedup_key)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE($9, NOW()), $10)
+ON CONFLICT (dedup_key) DO NOTHING
+RETURNING id, guardian_id, kind, title, body, link_type, link_id, deep_link, metadata, visible_at, read_at, created_at;
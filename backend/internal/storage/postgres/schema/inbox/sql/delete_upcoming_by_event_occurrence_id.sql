@@ -0,0 +1,6 @@
+DELETE FROM notification_inbox_item i
+USING registration r
+WHERE i.link_type = 'registration'
+  AND i.link_id = r.id
+  AND r.event_occurrence_id = $1
+  AND i.visible_at > NOW();
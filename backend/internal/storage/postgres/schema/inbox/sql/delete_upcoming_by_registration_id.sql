@@ -0,0 +1,4 @@
+DELETE FROM notification_inbox_item
+WHERE link_type = 'registration'
+  AND link_id = $1
+  AND visible_at > NOW();
@@ -0,0 +1,7 @@
+SELECT id, guardian_id, kind, title, body, link_type, link_id, deep_link, metadata, visible_at, read_at, created_at
+FROM notification_inbox_item
+WHERE guardian_id = $1
+  AND visible_at <= NOW()
+  AND (NOT $2 OR read_at IS NULL)
+ORDER BY visible_at DESC, id
+LIMIT $3 OFFSET $4;
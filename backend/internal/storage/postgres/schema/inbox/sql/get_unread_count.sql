@@ -0,0 +1,5 @@
+SELECT COUNT(*)
+FROM notification_inbox_item
+WHERE guardian_id = $1
+  AND visible_at <= NOW()
+  AND read_at IS NULL;
@@ -0,0 +1,5 @@
+UPDATE notification_inbox_item
+SET read_at = NOW()
+WHERE guardian_id = $1
+  AND visible_at <= NOW()
+  AND read_at IS NULL;
@@ -0,0 +1,6 @@
+UPDATE notification_inbox_item
+SET read_at = COALESCE(read_at, NOW())
+WHERE id = $1
+  AND guardian_id = $2
+  AND visible_at <= NOW()
+RETURNING id, guardian_id, kind, title, body, link_type, link_id, deep_link, metadata, visible_at, read_at, created_at;
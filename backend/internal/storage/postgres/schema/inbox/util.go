@@ -0,0 +1,48 @@
+package inbox
+
+import (
+	"context"
+	"embed"
+	"skillspark/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed sql/*.sql
+var SqlInboxFiles embed.FS
+
+func CreateTestInboxItem(
+	t *testing.T,
+	ctx context.Context,
+	db *pgxpool.Pool,
+	guardianID uuid.UUID,
+	registrationID *uuid.UUID,
+	visibleAt *time.Time,
+) *models.InboxItem {
+	t.Helper()
+
+	repo := NewInboxRepository(db)
+
+	input := &models.CreateInboxItemData{
+		GuardianID: guardianID,
+		Kind:       "event_reminder",
+		Title:      "Robotics Club is tomorrow",
+		Body:       "Starts at 3:30 PM",
+		VisibleAt:  visibleAt,
+	}
+	if registrationID != nil {
+		linkType := models.InboxLinkRegistration
+		input.LinkType = &linkType
+		input.LinkID = registrationID
+	}
+
+	item, err := repo.CreateInboxItem(ctx, input)
+	require.NoError(t, err)
+	require.NotNil(t, item)
+
+	return item
+}
@@ -0,0 +1,17 @@
+package inbox
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/schema/guardian"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+)
+
+func Test_CreateTestInboxItem(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	CreateTestInboxItem(t, ctx, testDB, g.ID, nil, nil)
+}
@@ -0,0 +1,11 @@
+package joblock
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type JobLockRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewJobLockRepository(db *pgxpool.Pool) *JobLockRepository {
+	return &JobLockRepository{db: db}
+}
@@ -0,0 +1 @@
+SELECT pg_advisory_unlock(hashtext('job:' || $1));
@@ -0,0 +1,57 @@
+package joblock
+
+import (
+	"context"
+	"log"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+)
+
+// TryAcquireJobLock takes a session-level Postgres advisory lock for the named job
+// without blocking. Advisory locks belong to a connection, so a pooled connection is
+// held until release is called; if the process dies Postgres drops the lock with the
+// connection. When another instance already holds the lock, acquired is false and
+// release is nil.
+func (r *JobLockRepository) TryAcquireJobLock(ctx context.Context, name string) (func(), bool, error) {
+	acquireQuery, err := schema.ReadSQLBaseScript("try_acquire.sql", SqlJobLockFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, false, &errr
+	}
+
+	releaseQuery, err := schema.ReadSQLBaseScript("release.sql", SqlJobLockFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, false, &errr
+	}
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to acquire database connection: ", err.Error())
+		return nil, false, &errr
+	}
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, acquireQuery, name).Scan(&acquired); err != nil {
+		conn.Release()
+		errr := errs.InternalServerError("Failed to acquire job lock: ", err.Error())
+		return nil, false, &errr
+	}
+
+	if !acquired {
+		conn.Release()
+		return nil, false, nil
+	}
+
+	release := func() {
+		// use a fresh context so the lock is still released when the job's context was cancelled
+		if _, err := conn.Exec(context.Background(), releaseQuery, name); err != nil {
+			log.Printf("Failed to release job lock %s: %v", name, err)
+			// the lock is tied to the session, so drop the connection rather than return it locked
+			_ = conn.Conn().Close(context.Background())
+		}
+		conn.Release()
+	}
+
+	return release, true, nil
+}
@@ -0,0 +1,41 @@
+package joblock
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTryAcquireJobLock(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewJobLockRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	release, acquired, err := repo.TryAcquireJobLock(ctx, "capture_payments")
+	require.NoError(t, err)
+	require.True(t, acquired)
+	require.NotNil(t, release)
+
+	// a second holder, as another API instance would be, is turned away
+	second, acquired, err := repo.TryAcquireJobLock(ctx, "capture_payments")
+	require.NoError(t, err)
+	assert.False(t, acquired)
+	assert.Nil(t, second)
+
+	// other jobs are not blocked by the held lock
+	other, acquired, err := repo.TryAcquireJobLock(ctx, "create_payment_intents")
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	other()
+
+	release()
+
+	again, acquired, err := repo.TryAcquireJobLock(ctx, "capture_payments")
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	again()
+}
@@ -0,0 +1,6 @@
+package joblock
+
+import "embed"
+
+//go:embed sql/*.sql
+var SqlJobLockFiles embed.FS
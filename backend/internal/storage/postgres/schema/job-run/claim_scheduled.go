@@ -0,0 +1,40 @@
+package jobrun
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ClaimScheduledJobRun records the start of the named job's run for the cron tick
+// scheduledFor. Only the first claim of a tick succeeds; later ones report false, so a
+// tick runs once however many workers see it.
+func (r *JobRunRepository) ClaimScheduledJobRun(ctx context.Context, jobName string, scheduledFor time.Time) (*models.JobRun, bool, error) {
+	query, err := schema.ReadSQLBaseScript("claim_scheduled.sql", SqlJobRunFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, false, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, jobName, scheduledFor)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to claim job run: ", err.Error())
+		return nil, false, &errr
+	}
+
+	run, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.JobRun])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, nil
+		}
+		errr := errs.InternalServerError("Failed to claim job run: ", err.Error())
+		return nil, false, &errr
+	}
+
+	return &run, true, nil
+}
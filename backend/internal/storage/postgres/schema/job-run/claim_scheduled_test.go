@@ -0,0 +1,45 @@
+package jobrun
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimScheduledJobRun(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewJobRunRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	tick := time.Date(2026, time.March, 6, 9, 0, 0, 0, time.UTC)
+
+	run, claimed, err := repo.ClaimScheduledJobRun(ctx, "capture_payments", tick)
+	require.NoError(t, err)
+	require.True(t, claimed)
+	assert.Equal(t, "capture_payments", run.JobName)
+	assert.Equal(t, models.JobRunTriggerSchedule, run.Trigger)
+	assert.Equal(t, models.JobRunStatusRunning, run.Status)
+	require.NotNil(t, run.ScheduledFor)
+	assert.True(t, tick.Equal(*run.ScheduledFor))
+
+	// another worker seeing the same tick doesn't get to run it
+	again, claimed, err := repo.ClaimScheduledJobRun(ctx, "capture_payments", tick)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+	assert.Nil(t, again)
+
+	// the next tick and other jobs are claimed separately
+	_, claimed, err = repo.ClaimScheduledJobRun(ctx, "capture_payments", tick.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	_, claimed, err = repo.ClaimScheduledJobRun(ctx, "create_payment_intents", tick)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+}
@@ -0,0 +1,32 @@
+package jobrun
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *JobRunRepository) CreateJobRun(ctx context.Context, input *models.CreateJobRunData) (*models.JobRun, error) {
+	query, err := schema.ReadSQLBaseScript("create.sql", SqlJobRunFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, input.JobName, input.Trigger, input.DryRun)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to create job run: ", err.Error())
+		return nil, &errr
+	}
+
+	run, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.JobRun])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to create job run: ", err.Error())
+		return nil, &errr
+	}
+
+	return &run, nil
+}
@@ -0,0 +1,36 @@
+package jobrun
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateJobRun(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewJobRunRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	run, err := repo.CreateJobRun(ctx, &models.CreateJobRunData{
+		JobName: "create_payment_intents",
+		Trigger: models.JobRunTriggerManual,
+		DryRun:  true,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, run)
+
+	assert.Equal(t, "create_payment_intents", run.JobName)
+	assert.Equal(t, models.JobRunTriggerManual, run.Trigger)
+	assert.True(t, run.DryRun)
+	assert.Equal(t, models.JobRunStatusRunning, run.Status)
+	assert.Zero(t, run.ItemsProcessed)
+	assert.Empty(t, run.ItemErrors)
+	assert.Nil(t, run.Error)
+	assert.Nil(t, run.FinishedAt)
+	assert.False(t, run.StartedAt.IsZero())
+}
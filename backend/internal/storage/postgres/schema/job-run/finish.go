@@ -0,0 +1,56 @@
+package jobrun
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *JobRunRepository) FinishJobRun(ctx context.Context, input *models.FinishJobRunData) (*models.JobRun, error) {
+	query, err := schema.ReadSQLBaseScript("finish.sql", SqlJobRunFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	itemErrors := input.ItemErrors
+	if itemErrors == nil {
+		itemErrors = []models.JobRunItemError{}
+	}
+	itemErrorsJSON, err := json.Marshal(itemErrors)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to encode job run item errors: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query,
+		input.ID,
+		input.Status,
+		input.ItemsProcessed,
+		input.ItemsSucceeded,
+		input.ItemsFailed,
+		string(itemErrorsJSON),
+		input.Error,
+	)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to finish job run: ", err.Error())
+		return nil, &errr
+	}
+
+	run, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.JobRun])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			errr := errs.NotFound("JobRun", "id", input.ID)
+			return nil, &errr
+		}
+		errr := errs.InternalServerError("Failed to finish job run: ", err.Error())
+		return nil, &errr
+	}
+
+	return &run, nil
+}
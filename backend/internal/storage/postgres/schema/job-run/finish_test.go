@@ -0,0 +1,83 @@
+package jobrun
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinishJobRun(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewJobRunRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	run := CreateTestJobRun(t, ctx, testDB, "capture_payments")
+	itemID := uuid.New()
+
+	finished, err := repo.FinishJobRun(ctx, &models.FinishJobRunData{
+		ID:             run.ID,
+		Status:         models.JobRunStatusPartiallyFailed,
+		ItemsProcessed: 3,
+		ItemsSucceeded: 2,
+		ItemsFailed:    1,
+		ItemErrors:     []models.JobRunItemError{{ItemID: itemID, Error: "card declined"}},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, finished)
+
+	assert.Equal(t, models.JobRunStatusPartiallyFailed, finished.Status)
+	assert.Equal(t, 3, finished.ItemsProcessed)
+	assert.Equal(t, 2, finished.ItemsSucceeded)
+	assert.Equal(t, 1, finished.ItemsFailed)
+	require.Len(t, finished.ItemErrors, 1)
+	assert.Equal(t, itemID, finished.ItemErrors[0].ItemID)
+	assert.Equal(t, "card declined", finished.ItemErrors[0].Error)
+	require.NotNil(t, finished.FinishedAt)
+}
+
+func TestFinishJobRun_RunError(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewJobRunRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	run := CreateTestJobRun(t, ctx, testDB, "send_scheduled_notifications")
+	runErr := "failed to get pending notifications"
+
+	finished, err := repo.FinishJobRun(ctx, &models.FinishJobRunData{
+		ID:     run.ID,
+		Status: models.JobRunStatusFailed,
+		Error:  &runErr,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.JobRunStatusFailed, finished.Status)
+	require.NotNil(t, finished.Error)
+	assert.Equal(t, runErr, *finished.Error)
+	assert.Empty(t, finished.ItemErrors)
+}
+
+func TestFinishJobRun_NotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewJobRunRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	finished, err := repo.FinishJobRun(ctx, &models.FinishJobRunData{
+		ID:     uuid.New(),
+		Status: models.JobRunStatusSucceeded,
+	})
+	require.Error(t, err)
+	assert.Nil(t, finished)
+
+	httpErr, ok := err.(*errs.HTTPError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, httpErr.Code)
+}
@@ -0,0 +1,34 @@
+package jobrun
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+	"skillspark/internal/utils"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetAllJobRuns returns runs newest first. An empty jobName returns runs of every job.
+func (r *JobRunRepository) GetAllJobRuns(ctx context.Context, jobName string, pagination utils.Pagination) ([]models.JobRun, error) {
+	query, err := schema.ReadSQLBaseScript("get_all.sql", SqlJobRunFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, jobName, pagination.Limit, pagination.GetOffset())
+	if err != nil {
+		errr := errs.InternalServerError("Failed to fetch job runs: ", err.Error())
+		return nil, &errr
+	}
+
+	runs, err := pgx.CollectRows(rows, pgx.RowToStructByName[models.JobRun])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to scan job runs: ", err.Error())
+		return nil, &errr
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,49 @@
+package jobrun
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/testutil"
+	"skillspark/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllJobRuns(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewJobRunRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	first := CreateTestJobRun(t, ctx, testDB, "capture_payments")
+	second := CreateTestJobRun(t, ctx, testDB, "create_payment_intents")
+
+	runs, err := repo.GetAllJobRuns(ctx, "", utils.Pagination{Page: 1, Limit: 100})
+	require.NoError(t, err)
+
+	ids := map[string]bool{}
+	for _, r := range runs {
+		ids[r.ID.String()] = true
+	}
+	assert.True(t, ids[first.ID.String()])
+	assert.True(t, ids[second.ID.String()])
+}
+
+func TestGetAllJobRuns_FilterByJobName(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewJobRunRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	CreateTestJobRun(t, ctx, testDB, "capture_payments")
+	run := CreateTestJobRun(t, ctx, testDB, "send_scheduled_notifications")
+
+	runs, err := repo.GetAllJobRuns(ctx, "send_scheduled_notifications", utils.Pagination{Page: 1, Limit: 100})
+	require.NoError(t, err)
+	require.NotEmpty(t, runs)
+	for _, r := range runs {
+		assert.Equal(t, "send_scheduled_notifications", r.JobName)
+	}
+	assert.Equal(t, run.ID, runs[0].ID)
+}
@@ -0,0 +1,38 @@
+package jobrun
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *JobRunRepository) GetJobRunByID(ctx context.Context, id uuid.UUID) (*models.JobRun, error) {
+	query, err := schema.ReadSQLBaseScript("get_by_id.sql", SqlJobRunFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to fetch job run: ", err.Error())
+		return nil, &errr
+	}
+
+	run, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.JobRun])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			errr := errs.NotFound("JobRun", "id", id)
+			return nil, &errr
+		}
+		errr := errs.InternalServerError("Failed to fetch job run: ", err.Error())
+		return nil, &errr
+	}
+
+	return &run, nil
+}
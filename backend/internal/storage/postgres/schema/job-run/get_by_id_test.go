@@ -0,0 +1,42 @@
+package jobrun
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetJobRunByID(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewJobRunRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	run := CreateTestJobRun(t, ctx, testDB, "capture_payments")
+
+	fetched, err := repo.GetJobRunByID(ctx, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, run.ID, fetched.ID)
+	assert.Equal(t, "capture_payments", fetched.JobName)
+}
+
+func TestGetJobRunByID_NotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewJobRunRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	fetched, err := repo.GetJobRunByID(ctx, uuid.New())
+	require.Error(t, err)
+	assert.Nil(t, fetched)
+
+	httpErr, ok := err.(*errs.HTTPError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, httpErr.Code)
+}
@@ -0,0 +1,11 @@
+package jobrun
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type JobRunRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewJobRunRepository(db *pgxpool.Pool) *JobRunRepository {
+	return &JobRunRepository{db: db}
+}
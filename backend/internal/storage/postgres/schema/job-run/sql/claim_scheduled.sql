@@ -0,0 +1,4 @@
+INSERT INTO job_run (job_name, trigger, scheduled_for)
+VALUES ($1, 'schedule', $2)
+ON CONFLICT (job_name, scheduled_for) WHERE scheduled_for IS NOT NULL DO NOTHING
+RETURNING id, job_name, trigger, dry_run, status, items_processed, items_succeeded, items_failed, item_errors, error, scheduled_for, started_at, finished_at;
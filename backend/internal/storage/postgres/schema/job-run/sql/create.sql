@@ -0,0 +1,3 @@
+INSERT INTO job_run (job_name, trigger, dry_run)
+VALUES ($1, $2, $3)
+RETURNING id, job_name, trigger, dry_run, status, items_processed, items_succeeded, items_failed, item_errors, error, scheduled_for, started_at, finished_at;
@@ -0,0 +1,10 @@
+UPDATE job_run
+SET status = $2,
+    items_processed = $3,
+    items_succeeded = $4,
+    items_failed = $5,
+    item_errors = $6::jsonb,
+    error = $7,
+    finished_at = NOW()
+WHERE id = $1
+RETURNING id, job_name, trigger, dry_run, status, items_processed, items_succeeded, items_failed, item_errors, error, scheduled_for, started_at, finished_at;
@@ -0,0 +1,5 @@
+SELECT id, job_name, trigger, dry_run, status, items_processed, items_succeeded, items_failed, item_errors, error, scheduled_for, started_at, finished_at
+FROM job_run
+WHERE $1 = '' OR job_name = $1
+ORDER BY started_at DESC, id
+LIMIT $2 OFFSET $3;
@@ -0,0 +1,3 @@
+SELECT id, job_name, trigger, dry_run, status, items_processed, items_succeeded, items_failed, item_errors, error, scheduled_for, started_at, finished_at
+FROM job_run
+WHERE id = $1;
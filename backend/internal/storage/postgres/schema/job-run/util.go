@@ -0,0 +1,34 @@
+package jobrun
+
+import (
+	"context"
+	"embed"
+	"skillspark/internal/models"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed sql/*.sql
+var SqlJobRunFiles embed.FS
+
+func CreateTestJobRun(
+	t *testing.T,
+	ctx context.Context,
+	db *pgxpool.Pool,
+	jobName string,
+) *models.JobRun {
+	t.Helper()
+
+	repo := NewJobRunRepository(db)
+
+	run, err := repo.CreateJobRun(ctx, &models.CreateJobRunData{
+		JobName: jobName,
+		Trigger: models.JobRunTriggerSchedule,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, run)
+
+	return run
+}
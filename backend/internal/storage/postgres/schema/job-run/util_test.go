@@ -0,0 +1,14 @@
+package jobrun
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+)
+
+func Test_CreateTestJobRun(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	t.Parallel()
+	CreateTestJobRun(t, ctx, testDB, "capture_payments")
+}
@@ -0,0 +1,32 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ClaimNotificationDelivery records that the queue message messageID delivers the message
+// identified by dedupID, and reports whether messageID holds the claim. Other copies of the
+// message are refused, while the claiming copy can be retried.
+func (r *NotificationRepository) ClaimNotificationDelivery(ctx context.Context, dedupID string, messageID string) (bool, error) {
+	query, err := schema.ReadSQLBaseScript("claim_delivery.sql", SqlNotificationFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return false, &errr
+	}
+
+	var holder string
+	if err := r.db.QueryRow(ctx, query, dedupID, messageID).Scan(&holder); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		errr := errs.InternalServerError("Failed to claim notification delivery: ", err.Error())
+		return false, &errr
+	}
+
+	return holder == messageID, nil
+}
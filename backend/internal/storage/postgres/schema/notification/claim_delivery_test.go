@@ -0,0 +1,34 @@
+package notification
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimNotificationDelivery(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewNotificationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	dedupID := "registration_confirmed:" + uuid.NewString() + ":email"
+
+	claimed, err := repo.ClaimNotificationDelivery(ctx, dedupID, "message-1")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	// the same copy received again after a failed attempt keeps its claim
+	claimed, err = repo.ClaimNotificationDelivery(ctx, dedupID, "message-1")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	// a second copy of the message is refused
+	claimed, err = repo.ClaimNotificationDelivery(ctx, dedupID, "message-2")
+	require.NoError(t, err)
+	assert.False(t, claimed)
+}
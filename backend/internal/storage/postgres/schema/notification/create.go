@@ -5,25 +5,46 @@ import (
 	"skillspark/internal/errs"
 	"skillspark/internal/models"
 	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/jackc/pgx/v5"
 )
 
 func (r *NotificationRepository) CreateScheduledNotification(ctx context.Context, input *models.CreateScheduledNotificationInput) (*models.Notification, error) {
+	return createScheduledNotification(ctx, r.db, input)
+}
+
+// CreateScheduledNotificationTx schedules a notification inside tx, so callers can schedule
+// it together with the records it is about.
+func CreateScheduledNotificationTx(ctx context.Context, tx pgx.Tx, input *models.CreateScheduledNotificationInput) (*models.Notification, error) {
+	return createScheduledNotification(ctx, tx, input)
+}
+
+type queryRower interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func createScheduledNotification(ctx context.Context, db queryRower, input *models.CreateScheduledNotificationInput) (*models.Notification, error) {
 	query, err := schema.ReadSQLBaseScript("create.sql", SqlNotificationFiles)
 	if err != nil {
 		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
 		return nil, &errr
 	}
 
-	row := r.db.QueryRow(ctx, query,
+	row := db.QueryRow(ctx, query,
 		input.NotificationType,
 		input.RecipientEmail,
 		input.RecipientPushToken,
 		input.Subject,
 		input.Body,
+		input.HTMLBody,
 		input.Metadata,
 		input.ScheduledFor,
 		models.NotificationStatusPending,
 		input.GuardianID,
+		input.RegistrationID,
+		input.Topic,
+		input.BroadcastID,
+		input.Urgent,
 	)
 
 	var notification models.Notification
@@ -35,11 +56,15 @@ func (r *NotificationRepository) CreateScheduledNotification(ctx context.Context
 		&notification.RecipientPushToken,
 		&notification.Subject,
 		&notification.Body,
+		&notification.HTMLBody,
 		&notification.Metadata,
 		&notification.ScheduledFor,
 		&notification.SentAt,
 		&notification.Status,
 		&notification.GuardianID,
+		&notification.RegistrationID,
+		&notification.Topic,
+		&notification.Urgent,
 		&notification.CreatedAt,
 		&notification.UpdatedAt,
 	)
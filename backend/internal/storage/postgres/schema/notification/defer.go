@@ -0,0 +1,27 @@
+package notification
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeferNotification moves a pending notification's send time to until. Notifications that
+// are no longer pending are left alone.
+func (r *NotificationRepository) DeferNotification(ctx context.Context, id uuid.UUID, until time.Time) error {
+	query, err := schema.ReadSQLBaseScript("defer.sql", SqlNotificationFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return &errr
+	}
+
+	if _, err := r.db.Exec(ctx, query, id, until); err != nil {
+		errr := errs.InternalServerError("Failed to defer notification: ", err.Error())
+		return &errr
+	}
+
+	return nil
+}
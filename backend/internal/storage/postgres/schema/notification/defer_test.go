@@ -0,0 +1,57 @@
+package notification
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/guardian"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeferNotification(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewNotificationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	email := "quiet@example.com"
+	due, err := repo.CreateScheduledNotification(ctx, &models.CreateScheduledNotificationInput{
+		NotificationType: models.NotificationTypeEmail,
+		RecipientEmail:   &email,
+		Body:             "Your class starts soon",
+		ScheduledFor:     time.Now().Add(-time.Minute),
+		GuardianID:       &g.ID,
+		Urgent:           true,
+	})
+	require.NoError(t, err)
+	assert.True(t, due.Urgent)
+
+	pending, err := repo.GetPendingNotifications(ctx)
+	require.NoError(t, err)
+	assert.True(t, containsNotification(pending, due))
+
+	until := time.Now().Add(8 * time.Hour).Truncate(time.Second)
+	require.NoError(t, repo.DeferNotification(ctx, due.ID, until))
+
+	pending, err = repo.GetPendingNotifications(ctx)
+	require.NoError(t, err)
+	assert.False(t, containsNotification(pending, due))
+
+	var scheduledFor time.Time
+	require.NoError(t, testDB.QueryRow(ctx, `SELECT scheduled_for FROM scheduled_notification WHERE id = $1`, due.ID).Scan(&scheduledFor))
+	assert.True(t, until.Equal(scheduledFor))
+}
+
+func containsNotification(notifications []models.Notification, want *models.Notification) bool {
+	for _, n := range notifications {
+		if n.ID == want.ID {
+			return true
+		}
+	}
+	return false
+}
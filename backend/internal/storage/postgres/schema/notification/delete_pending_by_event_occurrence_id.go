@@ -0,0 +1,25 @@
+package notification
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+)
+
+// DeletePendingNotificationsByEventOccurrenceID removes unsent notifications for every registration of the occurrence
+func (r *NotificationRepository) DeletePendingNotificationsByEventOccurrenceID(ctx context.Context, eventOccurrenceID uuid.UUID) error {
+	query, err := schema.ReadSQLBaseScript("delete_pending_by_event_occurrence_id.sql", SqlNotificationFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return &errr
+	}
+
+	if _, err := r.db.Exec(ctx, query, eventOccurrenceID); err != nil {
+		errr := errs.InternalServerError("Failed to delete pending notifications: ", err.Error())
+		return &errr
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package notification
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/schema/registration"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeletePendingNotificationsByEventOccurrenceID(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewNotificationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	other := registration.CreateTestRegistration(t, ctx, testDB)
+
+	pending := CreateTestNotification(t, ctx, testDB, reg.GuardianID, &reg.ID)
+	otherPending := CreateTestNotification(t, ctx, testDB, other.GuardianID, &other.ID)
+
+	require.NoError(t, repo.DeletePendingNotificationsByEventOccurrenceID(ctx, reg.EventOccurrenceID))
+
+	assert.Equal(t, 0, countNotifications(t, ctx, repo, pending.ID))
+	assert.Equal(t, 1, countNotifications(t, ctx, repo, otherPending.ID))
+}
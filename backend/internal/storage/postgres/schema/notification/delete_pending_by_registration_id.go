@@ -0,0 +1,25 @@
+package notification
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+)
+
+// DeletePendingNotificationsByRegistrationID removes the registration's notifications that have not been sent yet
+func (r *NotificationRepository) DeletePendingNotificationsByRegistrationID(ctx context.Context, registrationID uuid.UUID) error {
+	query, err := schema.ReadSQLBaseScript("delete_pending_by_registration_id.sql", SqlNotificationFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return &errr
+	}
+
+	if _, err := r.db.Exec(ctx, query, registrationID); err != nil {
+		errr := errs.InternalServerError("Failed to delete pending notifications: ", err.Error())
+		return &errr
+	}
+
+	return nil
+}
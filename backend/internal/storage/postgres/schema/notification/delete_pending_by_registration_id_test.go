@@ -0,0 +1,44 @@
+package notification
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/registration"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func countNotifications(t *testing.T, ctx context.Context, repo *NotificationRepository, ids ...uuid.UUID) int {
+	t.Helper()
+
+	var count int
+	err := repo.db.QueryRow(ctx, `SELECT COUNT(*) FROM scheduled_notification WHERE id = ANY($1)`, ids).Scan(&count)
+	require.NoError(t, err)
+	return count
+}
+
+func TestDeletePendingNotificationsByRegistrationID(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewNotificationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	other := registration.CreateTestRegistration(t, ctx, testDB)
+
+	pending := CreateTestNotification(t, ctx, testDB, reg.GuardianID, &reg.ID)
+	sent := CreateTestNotification(t, ctx, testDB, reg.GuardianID, &reg.ID)
+	_, err := repo.UpdateNotificationStatus(ctx, sent.ID, models.NotificationStatusSent)
+	require.NoError(t, err)
+	otherPending := CreateTestNotification(t, ctx, testDB, other.GuardianID, &other.ID)
+	unlinked := CreateTestNotification(t, ctx, testDB, reg.GuardianID, nil)
+
+	require.NoError(t, repo.DeletePendingNotificationsByRegistrationID(ctx, reg.ID))
+
+	assert.Equal(t, 0, countNotifications(t, ctx, repo, pending.ID))
+	assert.Equal(t, 3, countNotifications(t, ctx, repo, sent.ID, otherPending.ID, unlinked.ID))
+}
@@ -0,0 +1,48 @@
+package notification
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+)
+
+// GetPushNotificationsAwaitingReceipt returns push notifications Expo accepted whose receipt
+// should be ready but hasn't been checked. Only the id, token, guardian, topic and ticket id
+// are filled in.
+func (r *NotificationRepository) GetPushNotificationsAwaitingReceipt(ctx context.Context, limit int) ([]models.Notification, error) {
+	query, err := schema.ReadSQLBaseScript("get_awaiting_receipt.sql", SqlNotificationFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to get push notifications awaiting receipt: ", err.Error())
+		return nil, &errr
+	}
+	defer rows.Close()
+
+	notifications := []models.Notification{}
+	for rows.Next() {
+		notification := models.Notification{NotificationType: models.NotificationTypePush, Status: models.NotificationStatusDelivered}
+		if err := rows.Scan(
+			&notification.ID,
+			&notification.RecipientPushToken,
+			&notification.GuardianID,
+			&notification.Topic,
+			&notification.ProviderMessageID,
+		); err != nil {
+			errr := errs.InternalServerError("Failed to scan notification: ", err.Error())
+			return nil, &errr
+		}
+		notifications = append(notifications, notification)
+	}
+	if err := rows.Err(); err != nil {
+		errr := errs.InternalServerError("Failed to get push notifications awaiting receipt: ", err.Error())
+		return nil, &errr
+	}
+
+	return notifications, nil
+}
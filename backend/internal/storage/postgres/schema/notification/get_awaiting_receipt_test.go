@@ -0,0 +1,66 @@
+package notification
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/registration"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPushNotificationsAwaitingReceipt(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewNotificationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+
+	deliver := func(ticket string, ago string) uuid.UUID {
+		notification := CreateTestNotification(t, ctx, testDB, reg.GuardianID, &reg.ID)
+		_, err := testDB.Exec(ctx, `UPDATE scheduled_notification SET notification_type = 'push', recipient_push_token = 'ExponentPushToken[x]' WHERE id = $1`, notification.ID)
+		require.NoError(t, err)
+		require.NoError(t, repo.RecordNotificationDelivery(ctx, notification.ID, &models.NotificationDeliveryResult{
+			Status:            models.NotificationStatusDelivered,
+			Transport:         "expo",
+			ProviderMessageID: &ticket,
+		}))
+		_, err = testDB.Exec(ctx, `UPDATE scheduled_notification SET delivered_at = NOW() - $2::interval WHERE id = $1`, notification.ID, ago)
+		require.NoError(t, err)
+		return notification.ID
+	}
+
+	ready := deliver("ticket-ready", "30 minutes")
+	tooNew := deliver("ticket-too-new", "1 minute")
+	expired := deliver("ticket-expired", "2 days")
+	checked := deliver("ticket-checked", "30 minutes")
+	_, err := repo.RecordNotificationOutcome(ctx, &models.DeliveryOutcomeEvent{
+		Outcome:        models.DeliveryOutcomeDelivered,
+		Channel:        models.NotificationTypePush,
+		Provider:       "expo",
+		NotificationID: &checked,
+		Recipient:      "ExponentPushToken[x]",
+	})
+	require.NoError(t, err)
+
+	notifications, err := repo.GetPushNotificationsAwaitingReceipt(ctx, 100)
+
+	require.NoError(t, err)
+	var ids []uuid.UUID
+	for _, n := range notifications {
+		ids = append(ids, n.ID)
+	}
+	assert.Contains(t, ids, ready)
+	assert.NotContains(t, ids, tooNew)
+	assert.NotContains(t, ids, expired)
+	assert.NotContains(t, ids, checked)
+	for _, n := range notifications {
+		if n.ID == ready {
+			assert.Equal(t, "ticket-ready", *n.ProviderMessageID)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *NotificationRepository) GetNotificationByID(ctx context.Context, id uuid.UUID) (*models.Notification, error) {
+	query, err := schema.ReadSQLBaseScript("get_by_id.sql", SqlNotificationFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to query notification: ", err.Error())
+		return nil, &errr
+	}
+
+	notification, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[models.Notification])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			errr := errs.NotFound("Notification", "id", id)
+			return nil, &errr
+		}
+		errr := errs.InternalServerError("Failed to scan notification: ", err.Error())
+		return nil, &errr
+	}
+
+	return &notification, nil
+}
@@ -0,0 +1,47 @@
+package notification
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/guardian"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNotificationByID(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewNotificationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	created := CreateTestNotification(t, ctx, testDB, g.ID, nil)
+
+	notification, err := repo.GetNotificationByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, notification.ID)
+	assert.Equal(t, models.NotificationStatusPending, notification.Status)
+	assert.True(t, created.ScheduledFor.Equal(notification.ScheduledFor))
+
+	_, err = repo.UpdateNotificationStatus(ctx, created.ID, models.NotificationStatusSkipped)
+	require.NoError(t, err)
+
+	notification, err = repo.GetNotificationByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.NotificationStatusSkipped, notification.Status)
+}
+
+func TestGetNotificationByID_NotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewNotificationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	notification, err := repo.GetNotificationByID(ctx, uuid.New())
+	require.Error(t, err)
+	assert.Nil(t, notification)
+}
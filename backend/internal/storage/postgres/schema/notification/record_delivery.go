@@ -0,0 +1,31 @@
+package notification
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+)
+
+// RecordNotificationDelivery stores what the delivery worker did with the notification
+func (r *NotificationRepository) RecordNotificationDelivery(ctx context.Context, id uuid.UUID, result *models.NotificationDeliveryResult) error {
+	query, err := schema.ReadSQLBaseScript("record_delivery.sql", SqlNotificationFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return &errr
+	}
+
+	tag, err := r.db.Exec(ctx, query, id, result.Status, result.Transport, result.ProviderMessageID, result.Error)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to record notification delivery: ", err.Error())
+		return &errr
+	}
+	if tag.RowsAffected() == 0 {
+		errr := errs.NotFound("Notification", "id", id)
+		return &errr
+	}
+
+	return nil
+}
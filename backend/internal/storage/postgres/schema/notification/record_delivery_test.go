@@ -0,0 +1,60 @@
+package notification
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/registration"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordNotificationDelivery(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewNotificationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	notification := CreateTestNotification(t, ctx, testDB, reg.GuardianID, &reg.ID)
+
+	messageID := "<abc@skillspark>"
+	require.NoError(t, repo.RecordNotificationDelivery(ctx, notification.ID, &models.NotificationDeliveryResult{
+		Status:            models.NotificationStatusDelivered,
+		Transport:         "smtp",
+		ProviderMessageID: &messageID,
+	}))
+
+	// the job marking the notification sent afterwards doesn't undo the delivery
+	updated, err := repo.UpdateNotificationStatus(ctx, notification.ID, models.NotificationStatusSent)
+	require.NoError(t, err)
+	assert.Equal(t, models.NotificationStatusDelivered, updated.Status)
+	require.NotNil(t, updated.SentAt)
+
+	var transport, providerID string
+	var deliveredAt *time.Time
+	err = testDB.QueryRow(ctx, `SELECT delivery_transport, provider_message_id, delivered_at FROM scheduled_notification WHERE id = $1`, notification.ID).
+		Scan(&transport, &providerID, &deliveredAt)
+	require.NoError(t, err)
+	assert.Equal(t, "smtp", transport)
+	assert.Equal(t, messageID, providerID)
+	assert.NotNil(t, deliveredAt)
+}
+
+func TestRecordNotificationDelivery_NotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewNotificationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	err := repo.RecordNotificationDelivery(ctx, uuid.New(), &models.NotificationDeliveryResult{
+		Status:    models.NotificationStatusFailed,
+		Transport: "expo",
+	})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,43 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// RecordNotificationOutcome logs a delivery outcome and stores it on the scheduled
+// notification it belongs to. It returns that notification, or nil when the outcome is for
+// a notification that was never scheduled (or has since been deleted).
+func (r *NotificationRepository) RecordNotificationOutcome(ctx context.Context, event *models.DeliveryOutcomeEvent) (*models.OutcomeNotification, error) {
+	query, err := schema.ReadSQLBaseScript("record_outcome.sql", SqlNotificationFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	var matched models.OutcomeNotification
+	err = r.db.QueryRow(ctx, query,
+		event.Outcome,
+		event.Channel,
+		event.Provider,
+		event.ProviderMessageID,
+		event.NotificationID,
+		event.GuardianID,
+		event.Recipient,
+		event.Detail,
+	).Scan(&matched.ID, &matched.GuardianID, &matched.Topic)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		errr := errs.InternalServerError("Failed to record notification outcome: ", err.Error())
+		return nil, &errr
+	}
+
+	return &matched, nil
+}
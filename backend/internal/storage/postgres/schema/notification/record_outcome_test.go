@@ -0,0 +1,78 @@
+package notification
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/registration"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordNotificationOutcome(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewNotificationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	notification := CreateTestNotification(t, ctx, testDB, reg.GuardianID, &reg.ID)
+
+	messageID := "<bounce-me@skillspark>"
+	require.NoError(t, repo.RecordNotificationDelivery(ctx, notification.ID, &models.NotificationDeliveryResult{
+		Status:            models.NotificationStatusDelivered,
+		Transport:         "smtp",
+		ProviderMessageID: &messageID,
+	}))
+
+	detail := "mailbox does not exist"
+	matched, err := repo.RecordNotificationOutcome(ctx, &models.DeliveryOutcomeEvent{
+		Outcome:           models.DeliveryOutcomeBounced,
+		Channel:           models.NotificationTypeEmail,
+		Provider:          "smtp",
+		ProviderMessageID: &messageID,
+		Recipient:         "reminder@example.com",
+		Detail:            &detail,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, matched)
+	assert.Equal(t, notification.ID, matched.ID)
+	assert.Equal(t, reg.GuardianID, *matched.GuardianID)
+
+	var status, outcome string
+	var logged int
+	require.NoError(t, testDB.QueryRow(ctx, `SELECT status, delivery_outcome FROM scheduled_notification WHERE id = $1`, notification.ID).Scan(&status, &outcome))
+	require.NoError(t, testDB.QueryRow(ctx, `SELECT COUNT(*) FROM notification_delivery_event WHERE notification_id = $1 AND outcome = 'bounced'`, notification.ID).Scan(&logged))
+	assert.Equal(t, string(models.NotificationStatusFailed), status)
+	assert.Equal(t, string(models.DeliveryOutcomeBounced), outcome)
+	assert.Equal(t, 1, logged)
+}
+
+func TestRecordNotificationOutcome_UnknownNotificationIsStillLogged(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewNotificationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	emailID := "resend-email-id"
+
+	matched, err := repo.RecordNotificationOutcome(ctx, &models.DeliveryOutcomeEvent{
+		Outcome:           models.DeliveryOutcomeComplained,
+		Channel:           models.NotificationTypeEmail,
+		Provider:          "resend",
+		ProviderMessageID: &emailID,
+		GuardianID:        &reg.GuardianID,
+		Recipient:         "parent@example.com",
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, matched)
+
+	var logged int
+	require.NoError(t, testDB.QueryRow(ctx, `SELECT COUNT(*) FROM notification_delivery_event WHERE guardian_id = $1 AND notification_id IS NULL`, reg.GuardianID).Scan(&logged))
+	assert.Equal(t, 1, logged)
+}
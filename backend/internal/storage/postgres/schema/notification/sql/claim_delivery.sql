@@ -0,0 +1,12 @@
+-- returns the message holding the claim: this one if the insert won, otherwise the
+-- existing holder. No row means a concurrent claim committed after this statement began.
+WITH claimed AS (
+    INSERT INTO notification_delivery_claim (dedup_id, message_id)
+    VALUES ($1, $2)
+    ON CONFLICT (dedup_id) DO NOTHING
+    RETURNING message_id
+)
+SELECT message_id FROM claimed
+UNION ALL
+SELECT message_id FROM notification_delivery_claim WHERE dedup_id = $1
+LIMIT 1;
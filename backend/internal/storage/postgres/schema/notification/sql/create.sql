@@ -4,12 +4,17 @@ INSERT INTO scheduled_notification (
     recipient_push_token,
     subject,
     body,
+    html_body,
     metadata,
     scheduled_for,
     status,
-    guardian_id
+    guardian_id,
+    registration_id,
+    topic,
+    broadcast_id,
+    urgent
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, ''), $13, $14)
 RETURNING
     id,
     notification_type,
@@ -17,10 +22,14 @@ RETURNING
     recipient_push_token,
     subject,
     body,
+    html_body,
     metadata,
     scheduled_for,
     sent_at,
     status,
     guardian_id,
+    registration_id,
+    topic,
+    urgent,
     created_at,
     updated_at;
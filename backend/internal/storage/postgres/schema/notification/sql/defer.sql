@@ -0,0 +1,5 @@
+UPDATE scheduled_notification
+SET scheduled_for = $2,
+    updated_at = NOW()
+WHERE id = $1
+  AND status = 'pending';
@@ -0,0 +1,5 @@
+DELETE FROM scheduled_notification sn
+USING registration r
+WHERE sn.registration_id = r.id
+  AND r.event_occurrence_id = $1
+  AND sn.status = 'pending';
@@ -0,0 +1,3 @@
+DELETE FROM scheduled_notification
+WHERE registration_id = $1
+  AND status = 'pending';
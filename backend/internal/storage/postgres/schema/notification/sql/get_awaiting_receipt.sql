@@ -0,0 +1,17 @@
+-- Expo keeps push receipts for a day and they are usually ready within 15 minutes
+SELECT
+    id,
+    recipient_push_token,
+    guardian_id,
+    topic,
+    provider_message_id
+FROM scheduled_notification
+WHERE notification_type = 'push'
+  AND status = 'delivered'
+  AND delivery_outcome IS NULL
+  AND delivery_transport = 'expo'
+  AND provider_message_id IS NOT NULL
+  AND delivered_at < NOW() - INTERVAL '15 minutes'
+  AND delivered_at > NOW() - INTERVAL '24 hours'
+ORDER BY delivered_at
+LIMIT $1;
@@ -0,0 +1,21 @@
+SELECT
+    id,
+    notification_type,
+    recipient_email,
+    recipient_push_token,
+    subject,
+    body,
+    html_body,
+    metadata,
+    scheduled_for,
+    sent_at,
+    status,
+    guardian_id,
+    registration_id,
+    topic,
+    urgent,
+    provider_message_id,
+    created_at,
+    updated_at
+FROM scheduled_notification
+WHERE id = $1;
@@ -5,11 +5,16 @@ SELECT
     recipient_push_token,
     subject,
     body,
+    html_body,
     metadata,
     scheduled_for,
     sent_at,
     status,
     guardian_id,
+    registration_id,
+    topic,
+    urgent,
+    provider_message_id,
     created_at,
     updated_at
 FROM scheduled_notification
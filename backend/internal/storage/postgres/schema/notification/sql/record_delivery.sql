@@ -0,0 +1,10 @@
+UPDATE scheduled_notification
+SET
+    status = $2,
+    sent_at = COALESCE(sent_at, NOW()),
+    delivered_at = CASE WHEN $2 = 'delivered' THEN NOW() ELSE delivered_at END,
+    delivery_transport = $3,
+    provider_message_id = $4,
+    delivery_error = $5,
+    updated_at = NOW()
+WHERE id = $1;
@@ -0,0 +1,27 @@
+WITH matched AS (
+    UPDATE scheduled_notification
+    SET
+        delivery_outcome = $1,
+        outcome_at = NOW(),
+        status = CASE
+            WHEN $1 = 'delivered' THEN 'delivered'::notification_status
+            WHEN $1 IN ('bounced', 'invalid_token', 'failed') THEN 'failed'::notification_status
+            ELSE status
+        END,
+        delivery_error = COALESCE($8, delivery_error),
+        updated_at = NOW()
+    WHERE ($5::uuid IS NOT NULL AND id = $5)
+       OR ($5::uuid IS NULL AND $4::text IS NOT NULL AND provider_message_id = $4)
+    RETURNING id, guardian_id, topic
+),
+logged AS (
+    INSERT INTO notification_delivery_event (
+        notification_id, guardian_id, channel, outcome, provider, provider_message_id, recipient, detail
+    )
+    VALUES (
+        (SELECT id FROM matched LIMIT 1),
+        COALESCE($6::uuid, (SELECT guardian_id FROM matched LIMIT 1)),
+        $2, $1, $3, $4, $7, $8
+    )
+)
+SELECT id, guardian_id, topic FROM matched;
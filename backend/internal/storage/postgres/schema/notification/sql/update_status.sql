@@ -1,6 +1,8 @@
 UPDATE scheduled_notification
 SET 
-    status = $2,
+    -- the delivery worker can finish before the job marks the notification sent;
+    -- don't let that late 'sent' overwrite the delivery result
+    status = CASE WHEN $2 = 'sent' AND status IN ('delivered', 'failed') THEN status ELSE $2 END,
     sent_at = CASE WHEN $2 = 'sent' THEN NOW() ELSE sent_at END,
     updated_at = NOW()
 WHERE id = $1
@@ -1,8 +1,42 @@
 package notification
 
 import (
+	"context"
 	"embed"
+	"skillspark/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
 )
 
 //go:embed sql/*.sql
 var SqlNotificationFiles embed.FS
+
+func CreateTestNotification(
+	t *testing.T,
+	ctx context.Context,
+	db *pgxpool.Pool,
+	guardianID uuid.UUID,
+	registrationID *uuid.UUID,
+) *models.Notification {
+	t.Helper()
+
+	repo := NewNotificationRepository(db)
+
+	email := "reminder@example.com"
+	notification, err := repo.CreateScheduledNotification(ctx, &models.CreateScheduledNotificationInput{
+		NotificationType: models.NotificationTypeEmail,
+		RecipientEmail:   &email,
+		Body:             "Your class starts soon",
+		ScheduledFor:     time.Now().Add(time.Hour),
+		GuardianID:       &guardianID,
+		RegistrationID:   registrationID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, notification)
+
+	return notification
+}
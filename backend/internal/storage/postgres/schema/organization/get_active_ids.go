@@ -0,0 +1,34 @@
+package organization
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetActiveOrganizationIDs returns the id of every active organization, for finding search
+// documents of organizations that have been deactivated or deleted
+func (r *OrganizationRepository) GetActiveOrganizationIDs(ctx context.Context) ([]uuid.UUID, error) {
+	query, err := schema.ReadSQLBaseScript("get_active_ids.sql", SqlOrganizationFiles)
+	if err != nil {
+		err := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &err
+	}
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		err := errs.InternalServerError("Failed to fetch organization ids: ", err.Error())
+		return nil, &err
+	}
+	defer rows.Close()
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		err := errs.InternalServerError("Failed to scan organization ids: ", err.Error())
+		return nil, &err
+	}
+	return ids, nil
+}
@@ -0,0 +1,70 @@
+package organization
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetOrganizationSearchDocuments returns a page of active organizations as they are
+// indexed for search, ordered by id. Pass the last id of a page as afterID to get the next
+// one. With updatedSince, only organizations where the organization or its location
+// changed after it are returned.
+func (r *OrganizationRepository) GetOrganizationSearchDocuments(ctx context.Context, updatedSince *time.Time, afterID *uuid.UUID, limit int) ([]models.OrganizationSearchDocument, error) {
+	query, err := schema.ReadSQLBaseScript("get_search_documents.sql", SqlOrganizationFiles)
+	if err != nil {
+		err := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &err
+	}
+
+	rows, err := r.db.Query(ctx, query, updatedSince, afterID, limit)
+	if err != nil {
+		err := errs.InternalServerError("Failed to fetch organization search documents: ", err.Error())
+		return nil, &err
+	}
+	defer rows.Close()
+
+	documents, err := pgx.CollectRows(rows, scanSearchDocument)
+	if err != nil {
+		err := errs.InternalServerError("Failed to scan organization search documents: ", err.Error())
+		return nil, &err
+	}
+	return documents, nil
+}
+
+func scanSearchDocument(row pgx.CollectableRow) (models.OrganizationSearchDocument, error) {
+	var document models.OrganizationSearchDocument
+	var id uuid.UUID
+	var latitude, longitude *float64
+	var district *string
+
+	err := row.Scan(
+		&id,
+		&document.Name,
+		&document.AboutEN,
+		&document.AboutTH,
+		&document.PfpS3Key,
+		&latitude,
+		&longitude,
+		&district,
+		&document.UpdatedAt,
+	)
+	if err != nil {
+		return document, err
+	}
+
+	document.ID = id.String()
+	if latitude != nil && longitude != nil {
+		document.Location = &models.SearchGeoPoint{Lat: *latitude, Lon: *longitude}
+	}
+	if district != nil {
+		document.District = *district
+	}
+
+	return document, nil
+}
@@ -0,0 +1,75 @@
+package organization
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findDocument(documents []models.OrganizationSearchDocument, id string) *models.OrganizationSearchDocument {
+	for i := range documents {
+		if documents[i].ID == id {
+			return &documents[i]
+		}
+	}
+	return nil
+}
+
+func TestOrganizationRepository_GetOrganizationSearchDocuments(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewOrganizationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	organization := CreateTestOrganization(t, ctx, testDB)
+
+	documents, err := repo.GetOrganizationSearchDocuments(ctx, nil, nil, 10000)
+	require.NoError(t, err)
+
+	document := findDocument(documents, organization.ID.String())
+	require.NotNil(t, document)
+	assert.Equal(t, "Test Corp", document.Name)
+	assert.NotNil(t, document.Location)
+
+	for i := 1; i < len(documents); i++ {
+		assert.Less(t, documents[i-1].ID, documents[i].ID)
+	}
+
+	later := time.Now().Add(time.Hour)
+	documents, err = repo.GetOrganizationSearchDocuments(ctx, &later, nil, 10000)
+	require.NoError(t, err)
+	assert.Empty(t, documents)
+}
+
+func TestOrganizationRepository_GetOrganizationSearchDocuments_SkipsInactive(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewOrganizationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	organization := CreateTestOrganization(t, ctx, testDB)
+	_, err := testDB.Exec(ctx, "UPDATE organization SET active = false WHERE id = $1", organization.ID)
+	require.NoError(t, err)
+
+	documents, err := repo.GetOrganizationSearchDocuments(ctx, nil, nil, 10000)
+	require.NoError(t, err)
+	assert.Nil(t, findDocument(documents, organization.ID.String()))
+
+	ids, err := repo.GetActiveOrganizationIDs(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, ids, organization.ID)
+	assert.NotEmpty(t, ids)
+}
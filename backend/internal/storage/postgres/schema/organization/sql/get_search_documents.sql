@@ -0,0 +1,21 @@
+SELECT
+    o.id,
+    o.name,
+    o.about_en,
+    o.about_th,
+    o.pfp_s3_key,
+
+    l.latitude,
+    l.longitude,
+    l.district,
+
+    GREATEST(o.updated_at, l.updated_at)
+FROM organization o
+LEFT JOIN location l ON l.id = o.location_id
+
+WHERE o.active
+AND ($1::timestamptz IS NULL OR GREATEST(o.updated_at, l.updated_at) > $1)
+AND ($2::uuid IS NULL OR o.id > $2)
+
+ORDER BY o.id
+LIMIT $3;
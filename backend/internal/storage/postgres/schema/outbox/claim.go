@@ -0,0 +1,64 @@
+package outbox
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ClaimOutboxMessages takes up to limit due messages for publishing, oldest first, and
+// increments their attempt count. A claim lasts visibilityTimeout; a message whose claim
+// expired is assumed lost and can be claimed again, or is failed if it has used all of
+// its attempts. Concurrent relays never receive the same message.
+func (r *OutboxRepository) ClaimOutboxMessages(ctx context.Context, limit int, visibilityTimeout time.Duration) ([]models.OutboxMessage, error) {
+	expireQuery, err := schema.ReadSQLBaseScript("fail_expired.sql", SqlOutboxFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	claimQuery, err := schema.ReadSQLBaseScript("claim.sql", SqlOutboxFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to begin transaction: ", err.Error())
+		return nil, &errr
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if _, err = tx.Exec(ctx, expireQuery); err != nil {
+		errr := errs.InternalServerError("Failed to fail expired outbox messages: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := tx.Query(ctx, claimQuery, limit, visibilityTimeout.Seconds())
+	if err != nil {
+		errr := errs.InternalServerError("Failed to claim outbox messages: ", err.Error())
+		return nil, &errr
+	}
+
+	messages, err := pgx.CollectRows(rows, pgx.RowToStructByName[models.OutboxMessage])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to scan claimed outbox messages: ", err.Error())
+		return nil, &errr
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		errr := errs.InternalServerError("Failed to commit transaction: ", err.Error())
+		return nil, &errr
+	}
+
+	return messages, nil
+}
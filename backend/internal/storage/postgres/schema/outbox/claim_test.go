@@ -0,0 +1,80 @@
+package outbox
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimOutboxMessages(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewOutboxRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	first := CreateTestOutboxMessage(t, ctx, testDB, models.OutboxTopicNotification)
+	second := CreateTestOutboxMessage(t, ctx, testDB, models.OutboxTopicRegistrationCreated)
+
+	claimed, err := repo.ClaimOutboxMessages(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+	assert.ElementsMatch(t, []uuid.UUID{first.ID, second.ID}, []uuid.UUID{claimed[0].ID, claimed[1].ID})
+	assert.Equal(t, 1, claimed[0].Attempts)
+	require.NotNil(t, claimed[0].LockedUntil)
+
+	// a claimed message is invisible to other relays until its claim expires
+	again, err := repo.ClaimOutboxMessages(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+}
+
+func TestClaimOutboxMessages_ReclaimsExpired(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewOutboxRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	message := CreateTestOutboxMessage(t, ctx, testDB, models.OutboxTopicNotification)
+
+	claimed, err := repo.ClaimOutboxMessages(ctx, 10, -time.Second)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	reclaimed, err := repo.ClaimOutboxMessages(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, reclaimed, 1)
+	assert.Equal(t, message.ID, reclaimed[0].ID)
+	assert.Equal(t, 2, reclaimed[0].Attempts)
+
+	// the first relay's late result is rejected
+	require.Error(t, repo.MarkOutboxMessagePublished(ctx, message.ID, 1))
+}
+
+func TestClaimOutboxMessages_FailsExpiredFinalAttempt(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewOutboxRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	message := CreateTestOutboxMessage(t, ctx, testDB, models.OutboxTopicNotification)
+	_, err := testDB.Exec(ctx, `UPDATE outbox_message SET max_attempts = 1 WHERE id = $1`, message.ID)
+	require.NoError(t, err)
+
+	claimed, err := repo.ClaimOutboxMessages(ctx, 10, -time.Second)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	reclaimed, err := repo.ClaimOutboxMessages(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, reclaimed)
+
+	failed := GetTestOutboxMessage(t, ctx, testDB, message.ID)
+	assert.Equal(t, models.OutboxStatusFailed, failed.Status)
+	require.NotNil(t, failed.LastError)
+}
@@ -0,0 +1,47 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// with the relay backing off from one minute to an hour, ten attempts span about four hours
+const defaultMaxAttempts = 10
+
+// EnqueueOutboxMessage records a message for the relay to publish. When a message with the
+// same dedup ID was recorded before, in any state, nothing is recorded and it returns nil
+// without an error.
+func (r *OutboxRepository) EnqueueOutboxMessage(ctx context.Context, input *models.EnqueueOutboxMessageData) (*models.OutboxMessage, error) {
+	query, err := schema.ReadSQLBaseScript("enqueue.sql", SqlOutboxFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	payload := "{}"
+	if len(input.Payload) > 0 {
+		payload = string(input.Payload)
+	}
+
+	rows, err := r.db.Query(ctx, query, input.Topic, payload, input.DedupID, defaultMaxAttempts)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to enqueue outbox message: ", err.Error())
+		return nil, &errr
+	}
+
+	message, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.OutboxMessage])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		errr := errs.InternalServerError("Failed to enqueue outbox message: ", err.Error())
+		return nil, &errr
+	}
+
+	return &message, nil
+}
@@ -0,0 +1,52 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnqueueOutboxMessage(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewOutboxRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	message, err := repo.EnqueueOutboxMessage(ctx, &models.EnqueueOutboxMessageData{
+		Topic:   models.OutboxTopicNotification,
+		Payload: json.RawMessage(`{"body": "hello"}`),
+		DedupID: "registration_confirmed:abc:email",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, message)
+
+	assert.Equal(t, models.OutboxTopicNotification, message.Topic)
+	assert.JSONEq(t, `{"body": "hello"}`, string(message.Payload))
+	assert.Equal(t, "registration_confirmed:abc:email", message.DedupID)
+	assert.Equal(t, models.OutboxStatusPending, message.Status)
+	assert.Equal(t, 0, message.Attempts)
+	assert.Equal(t, defaultMaxAttempts, message.MaxAttempts)
+	assert.WithinDuration(t, time.Now(), message.AvailableAt, time.Minute)
+}
+
+func TestEnqueueOutboxMessage_Deduplicates(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewOutboxRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	data := &models.EnqueueOutboxMessageData{Topic: models.OutboxTopicRegistrationCreated, DedupID: "registration.created:1"}
+	first, err := repo.EnqueueOutboxMessage(ctx, data)
+	require.NoError(t, err)
+	require.NotNil(t, first)
+
+	second, err := repo.EnqueueOutboxMessage(ctx, data)
+	require.NoError(t, err)
+	assert.Nil(t, second)
+}
@@ -0,0 +1,12 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// FailOutboxMessage stops publishing a message that will not succeed by retrying
+func (r *OutboxRepository) FailOutboxMessage(ctx context.Context, id uuid.UUID, attempt int, lastError string) error {
+	return r.updateClaimedMessage(ctx, "fail.sql", id, attempt, lastError)
+}
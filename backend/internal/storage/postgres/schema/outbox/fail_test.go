@@ -0,0 +1,30 @@
+package outbox
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailOutboxMessage(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewOutboxRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	message := CreateTestOutboxMessage(t, ctx, testDB, models.OutboxTopicRegistrationCreated)
+	_, err := repo.ClaimOutboxMessages(ctx, 1, time.Minute)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.FailOutboxMessage(ctx, message.ID, 1, "registration not found"))
+
+	failed := GetTestOutboxMessage(t, ctx, testDB, message.ID)
+	assert.Equal(t, models.OutboxStatusFailed, failed.Status)
+	require.NotNil(t, failed.LastError)
+	assert.Equal(t, "registration not found", *failed.LastError)
+}
@@ -0,0 +1,11 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+func (r *OutboxRepository) MarkOutboxMessagePublished(ctx context.Context, id uuid.UUID, attempt int) error {
+	return r.updateClaimedMessage(ctx, "mark_published.sql", id, attempt)
+}
@@ -0,0 +1,53 @@
+package outbox
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkOutboxMessagePublished(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewOutboxRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	message := CreateTestOutboxMessage(t, ctx, testDB, models.OutboxTopicNotification)
+	claimed, err := repo.ClaimOutboxMessages(ctx, 1, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	require.NoError(t, repo.MarkOutboxMessagePublished(ctx, message.ID, claimed[0].Attempts))
+
+	published := GetTestOutboxMessage(t, ctx, testDB, message.ID)
+	assert.Equal(t, models.OutboxStatusPublished, published.Status)
+	assert.NotNil(t, published.PublishedAt)
+	assert.Nil(t, published.LockedUntil)
+
+	again, err := repo.ClaimOutboxMessages(ctx, 1, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+}
+
+func TestMarkOutboxMessagePublished_NotClaimed(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewOutboxRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	message := CreateTestOutboxMessage(t, ctx, testDB, models.OutboxTopicNotification)
+
+	err := repo.MarkOutboxMessagePublished(ctx, message.ID, 0)
+	require.Error(t, err)
+
+	httpErr, ok := err.(*errs.HTTPError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusConflict, httpErr.Code)
+}
@@ -0,0 +1,11 @@
+package outbox
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type OutboxRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOutboxRepository(db *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
@@ -0,0 +1,14 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RetryOutboxMessageLater releases a message that failed to publish, to be claimed again
+// after availableAt
+func (r *OutboxRepository) RetryOutboxMessageLater(ctx context.Context, id uuid.UUID, attempt int, availableAt time.Time, lastError string) error {
+	return r.updateClaimedMessage(ctx, "retry_later.sql", id, attempt, availableAt, lastError)
+}
@@ -0,0 +1,39 @@
+package outbox
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryOutboxMessageLater(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewOutboxRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	message := CreateTestOutboxMessage(t, ctx, testDB, models.OutboxTopicNotification)
+	_, err := repo.ClaimOutboxMessages(ctx, 1, time.Minute)
+	require.NoError(t, err)
+
+	availableAt := time.Now().Add(30 * time.Second)
+	require.NoError(t, repo.RetryOutboxMessageLater(ctx, message.ID, 1, availableAt, "queue unavailable"))
+
+	// not due yet, so not claimable
+	claimed, err := repo.ClaimOutboxMessages(ctx, 1, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	retried := GetTestOutboxMessage(t, ctx, testDB, message.ID)
+	assert.Equal(t, models.OutboxStatusPending, retried.Status)
+	assert.Equal(t, 1, retried.Attempts)
+	assert.Nil(t, retried.LockedUntil)
+	require.NotNil(t, retried.LastError)
+	assert.Equal(t, "queue unavailable", *retried.LastError)
+	assert.WithinDuration(t, availableAt, retried.AvailableAt, time.Second)
+}
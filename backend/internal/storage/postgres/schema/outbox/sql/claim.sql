@@ -0,0 +1,15 @@
+UPDATE outbox_message
+SET attempts = attempts + 1,
+    locked_until = NOW() + make_interval(secs => $2)
+WHERE id IN (
+    SELECT id
+    FROM outbox_message
+    WHERE status = 'pending'
+      AND available_at <= NOW()
+      AND (locked_until IS NULL OR locked_until < NOW())
+      AND attempts < max_attempts
+    ORDER BY available_at, created_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, topic, payload, dedup_id, status, attempts, max_attempts, available_at, locked_until, last_error, published_at, created_at;
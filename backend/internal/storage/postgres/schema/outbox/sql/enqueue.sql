@@ -0,0 +1,4 @@
+INSERT INTO outbox_message (topic, payload, dedup_id, max_attempts)
+VALUES ($1, $2::jsonb, $3, $4)
+ON CONFLICT (dedup_id) DO NOTHING
+RETURNING id, topic, payload, dedup_id, status, attempts, max_attempts, available_at, locked_until, last_error, published_at, created_at;
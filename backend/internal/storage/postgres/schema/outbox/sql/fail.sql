@@ -0,0 +1,8 @@
+UPDATE outbox_message
+SET status = 'failed',
+    locked_until = NULL,
+    last_error = $3
+WHERE id = $1
+  AND status = 'pending'
+  AND attempts = $2
+  AND locked_until IS NOT NULL;
@@ -0,0 +1,7 @@
+UPDATE outbox_message
+SET status = 'failed',
+    locked_until = NULL,
+    last_error = 'claim expired on the final attempt; the relay likely crashed or timed out'
+WHERE status = 'pending'
+  AND locked_until < NOW()
+  AND attempts >= max_attempts;
@@ -0,0 +1,3 @@
+SELECT id, topic, payload, dedup_id, status, attempts, max_attempts, available_at, locked_until, last_error, published_at, created_at
+FROM outbox_message
+WHERE id = $1;
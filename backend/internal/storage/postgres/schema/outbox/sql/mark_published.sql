@@ -0,0 +1,8 @@
+UPDATE outbox_message
+SET status = 'published',
+    locked_until = NULL,
+    published_at = NOW()
+WHERE id = $1
+  AND status = 'pending'
+  AND attempts = $2
+  AND locked_until IS NOT NULL;
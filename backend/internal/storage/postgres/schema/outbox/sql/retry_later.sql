@@ -0,0 +1,8 @@
+UPDATE outbox_message
+SET locked_until = NULL,
+    available_at = $3,
+    last_error = $4
+WHERE id = $1
+  AND status = 'pending'
+  AND attempts = $2
+  AND locked_until IS NOT NULL;
@@ -0,0 +1,55 @@
+package outbox
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed sql/*.sql
+var SqlOutboxFiles embed.FS
+
+func CreateTestOutboxMessage(
+	t *testing.T,
+	ctx context.Context,
+	db *pgxpool.Pool,
+	topic string,
+) *models.OutboxMessage {
+	t.Helper()
+
+	repo := NewOutboxRepository(db)
+
+	message, err := repo.EnqueueOutboxMessage(ctx, &models.EnqueueOutboxMessageData{
+		Topic:   topic,
+		Payload: json.RawMessage(`{"test": true}`),
+		DedupID: "test:" + uuid.NewString(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, message)
+
+	return message
+}
+
+// GetTestOutboxMessage reads a message back, for checking what the relay or a write left
+func GetTestOutboxMessage(t *testing.T, ctx context.Context, db *pgxpool.Pool, id uuid.UUID) *models.OutboxMessage {
+	t.Helper()
+
+	query, err := schema.ReadSQLBaseScript("get_by_id.sql", SqlOutboxFiles)
+	require.NoError(t, err)
+
+	rows, err := db.Query(ctx, query, id)
+	require.NoError(t, err)
+
+	message, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.OutboxMessage])
+	require.NoError(t, err)
+
+	return &message
+}
@@ -0,0 +1,117 @@
+package recommendation
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// collaborativeWeight is how many matching interests a collaborative-filtering score of 1
+	// counts as, so an event families like the child's booked can outrank one that only
+	// shares a category with the child's interests
+	collaborativeWeight = 2.0
+	// popularityWeight is how many matching interests a very popular event counts as, at most
+	popularityWeight = 1.0
+	// popularityHalfway is how many recent registrations earn half of popularityWeight
+	popularityHalfway = 5.0
+	// popularityWindow is how far back registrations count towards popularity
+	popularityWindow = 30 * 24 * time.Hour
+)
+
+// GetRecommendationCandidates returns up to limit upcoming events the child could be
+// recommended, leaving out events the child is registered for. Each is scored by how many
+// of its categories match the child's interests, blended with the child's
+// collaborative-filtering score and how popular the event is lately, best first.
+func (r *RecommendationRepository) GetRecommendationCandidates(ctx context.Context, childID uuid.UUID, childInterests []string, childBirthYear int, acceptLanguage string, limit int, filters models.RecommendationFilters) ([]models.RecommendationCandidate, error) {
+	query, err := schema.ReadSQLBaseScript("get_candidates.sql", SqlRecommendationFiles)
+	if err != nil {
+		e := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &e
+	}
+
+	var lat, lng *float64
+	if filters.Latitude.Set {
+		lat = &filters.Latitude.Value
+	}
+	if filters.Longitude.Set {
+		lng = &filters.Longitude.Value
+	}
+
+	var minDate, maxDate *time.Time
+	if !filters.MinDate.IsZero() {
+		minDate = &filters.MinDate
+	}
+	if !filters.MaxDate.IsZero() {
+		maxDate = &filters.MaxDate
+	}
+	var newSince *time.Time
+	if !filters.NewSince.IsZero() {
+		newSince = &filters.NewSince
+	}
+
+	popularSince := time.Now().Add(-popularityWindow)
+	rows, err := r.db.Query(ctx, query, childInterests, childBirthYear, limit, minDate, maxDate, lat, lng, filters.RadiusKm, childID, collaborativeWeight, popularSince, popularityWeight, popularityHalfway, newSince)
+	if err != nil {
+		e := errs.InternalServerError("Failed to fetch recommendations: ", err.Error())
+		return nil, &e
+	}
+	defer rows.Close()
+
+	candidates, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.RecommendationCandidate, error) {
+		return scanCandidate(row, acceptLanguage)
+	})
+	if err != nil {
+		e := errs.InternalServerError("Failed to scan recommendations: ", err.Error())
+		return nil, &e
+	}
+
+	return candidates, nil
+}
+
+func scanCandidate(row pgx.CollectableRow, language string) (models.RecommendationCandidate, error) {
+	var c models.RecommendationCandidate
+	var titleEN, descriptionEN string
+	var titleTH, descriptionTH *string
+
+	err := row.Scan(
+		&c.ID,
+		&titleEN,
+		&titleTH,
+		&descriptionEN,
+		&descriptionTH,
+		&c.OrganizationID,
+		&c.AgeRangeMin,
+		&c.AgeRangeMax,
+		&c.Category,
+		&c.HeaderImageS3Key,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+		&c.MatchedInterests,
+		&c.Components.Collaborative,
+		&c.RecentRegistrations,
+		&c.Components.Popularity,
+		&c.DistanceKm,
+		&c.NextStartTime,
+	)
+	c.Components.Interest = float64(len(c.MatchedInterests))
+
+	c.Title = titleEN
+	c.Description = descriptionEN
+
+	if language == "th-TH" {
+		if titleTH != nil {
+			c.Title = *titleTH
+		}
+		if descriptionTH != nil {
+			c.Description = *descriptionTH
+		}
+	}
+
+	return c, err
+}
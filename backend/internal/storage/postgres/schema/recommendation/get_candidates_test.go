@@ -0,0 +1,90 @@
+package recommendation
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/child"
+	"skillspark/internal/storage/postgres/schema/event"
+	eventoccurrence "skillspark/internal/storage/postgres/schema/event-occurrence"
+	"skillspark/internal/storage/postgres/schema/registration"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findCandidate(candidates []models.RecommendationCandidate, eventID uuid.UUID) *models.RecommendationCandidate {
+	for i := range candidates {
+		if candidates[i].ID == eventID {
+			return &candidates[i]
+		}
+	}
+	return nil
+}
+
+func TestRecommendationRepository_GetRecommendationCandidates(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewRecommendationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	// the test event is for 8 to 12 year olds
+	birthYear := time.Now().Year() - 10
+	e := event.CreateTestEvent(t, ctx, testDB)
+
+	mid := uuid.MustParse("50000000-0000-0000-0000-000000000001")
+	start := time.Now().Add(7 * 24 * time.Hour).Truncate(time.Second)
+	occurrenceInput := &models.CreateEventOccurrenceInput{}
+	occurrenceInput.Body.ManagerId = &mid
+	occurrenceInput.Body.EventId = e.ID
+	occurrenceInput.Body.StartTime = start
+	occurrenceInput.Body.EndTime = start.Add(time.Hour)
+	occurrenceInput.Body.MaxAttendees = 10
+	occurrenceInput.Body.Language = "en"
+	occurrence, err := eventoccurrence.NewEventOccurrenceRepository(testDB).CreateEventOccurrence(ctx, occurrenceInput)
+	require.NoError(t, err)
+
+	attendee := child.CreateTestChild(t, ctx, testDB)
+	_, err = registration.NewRegistrationRepository(testDB).CreateRegistration(ctx, &models.CreateRegistrationData{
+		AcceptLanguage:    "en-US",
+		ChildID:           attendee.ID,
+		GuardianID:        attendee.GuardianID,
+		EventOccurrenceID: occurrence.ID,
+		Status:            models.RegistrationStatusRegistered,
+	})
+	require.NoError(t, err)
+
+	other := child.CreateTestChild(t, ctx, testDB)
+	candidates, err := repo.GetRecommendationCandidates(ctx, other.ID, []string{"science"}, birthYear, "en-US", 200, models.RecommendationFilters{})
+	require.NoError(t, err)
+
+	candidate := findCandidate(candidates, e.ID)
+	require.NotNil(t, candidate)
+	assert.Equal(t, []string{"science"}, candidate.MatchedInterests)
+	assert.Equal(t, 1.0, candidate.Components.Interest)
+	assert.Equal(t, 1, candidate.RecentRegistrations)
+	assert.Greater(t, candidate.Components.Popularity, 0.0)
+	assert.Nil(t, candidate.DistanceKm)
+	assert.True(t, start.Equal(candidate.NextStartTime))
+
+	// the event and its occurrence were just added, so they count as new
+	candidates, err = repo.GetRecommendationCandidates(ctx, other.ID, []string{"science"}, birthYear, "en-US", 200, models.RecommendationFilters{NewSince: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+	assert.NotNil(t, findCandidate(candidates, e.ID))
+
+	candidates, err = repo.GetRecommendationCandidates(ctx, other.ID, []string{"science"}, birthYear, "en-US", 200, models.RecommendationFilters{NewSince: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Nil(t, findCandidate(candidates, e.ID))
+
+	// nothing the child is already registered for
+	candidates, err = repo.GetRecommendationCandidates(ctx, attendee.ID, []string{"science"}, birthYear, "en-US", 200, models.RecommendationFilters{})
+	require.NoError(t, err)
+	assert.Nil(t, findCandidate(candidates, e.ID))
+}
@@ -0,0 +1,34 @@
+package recommendation
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetRecommendationInteractions returns every registration, save and review, for the
+// recommendation job to learn from
+func (r *RecommendationRepository) GetRecommendationInteractions(ctx context.Context) ([]models.RecommendationInteraction, error) {
+	query, err := schema.ReadSQLBaseScript("get_interactions.sql", SqlRecommendationFiles)
+	if err != nil {
+		e := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &e
+	}
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		e := errs.InternalServerError("Failed to fetch recommendation interactions: ", err.Error())
+		return nil, &e
+	}
+
+	interactions, err := pgx.CollectRows(rows, pgx.RowToStructByName[models.RecommendationInteraction])
+	if err != nil {
+		e := errs.InternalServerError("Failed to scan recommendation interactions: ", err.Error())
+		return nil, &e
+	}
+
+	return interactions, nil
+}
@@ -0,0 +1,111 @@
+package recommendation
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/child"
+	"skillspark/internal/storage/postgres/schema/event"
+	eventoccurrence "skillspark/internal/storage/postgres/schema/event-occurrence"
+	"skillspark/internal/storage/postgres/schema/registration"
+	"skillspark/internal/storage/postgres/schema/review"
+	"skillspark/internal/storage/postgres/schema/saved"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findInteraction(interactions []models.RecommendationInteraction, childID uuid.UUID, kind models.RecommendationInteractionKind) *models.RecommendationInteraction {
+	for i := range interactions {
+		if interactions[i].ChildID == childID && interactions[i].Kind == kind {
+			return &interactions[i]
+		}
+	}
+	return nil
+}
+
+func TestRecommendationRepository_GetRecommendationInteractions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewRecommendationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	occurrence, err := eventoccurrence.NewEventOccurrenceRepository(testDB).GetEventOccurrenceByID(ctx, reg.EventOccurrenceID, "en-US")
+	require.NoError(t, err)
+
+	// a save counts for each of the guardian's children
+	c := child.CreateTestChild(t, ctx, testDB)
+	e := event.CreateTestEvent(t, ctx, testDB)
+	savedInput := &models.CreateSavedInput{}
+	savedInput.Body.EventID = e.ID
+	savedInput.Body.GuardianID = c.GuardianID
+	_, err = saved.NewSavedRepository(testDB).CreateSaved(ctx, savedInput)
+	require.NoError(t, err)
+
+	interactions, err := repo.GetRecommendationInteractions(ctx)
+	require.NoError(t, err)
+
+	registered := findInteraction(interactions, reg.ChildID, models.RecommendationInteractionRegistration)
+	require.NotNil(t, registered)
+	assert.Equal(t, reg.GuardianID, registered.GuardianID)
+	assert.Equal(t, occurrence.Event.ID, registered.EventID)
+	assert.Nil(t, registered.Rating)
+
+	savedEvent := findInteraction(interactions, c.ID, models.RecommendationInteractionSaved)
+	require.NotNil(t, savedEvent)
+	assert.Equal(t, c.GuardianID, savedEvent.GuardianID)
+	assert.Equal(t, e.ID, savedEvent.EventID)
+}
+
+func TestRecommendationRepository_GetRecommendationInteractions_Review(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewRecommendationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	r := review.CreateTestReview(t, ctx, testDB)
+	reg, err := registration.NewRegistrationRepository(testDB).GetRegistrationByID(ctx, &models.GetRegistrationByIDInput{
+		AcceptLanguage: "en-US",
+		ID:             r.RegistrationID,
+	}, nil)
+	require.NoError(t, err)
+
+	interactions, err := repo.GetRecommendationInteractions(ctx)
+	require.NoError(t, err)
+
+	reviewed := findInteraction(interactions, reg.Body.ChildID, models.RecommendationInteractionReview)
+	require.NotNil(t, reviewed)
+	require.NotNil(t, reviewed.Rating)
+	assert.Equal(t, r.Rating, *reviewed.Rating)
+}
+
+func TestRecommendationRepository_GetRecommendationInteractions_SkipsCancelled(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewRecommendationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	_, err := testDB.Exec(ctx, `UPDATE registration SET status = 'cancelled' WHERE id = $1`, reg.ID)
+	require.NoError(t, err)
+
+	interactions, err := repo.GetRecommendationInteractions(ctx)
+	require.NoError(t, err)
+
+	assert.Nil(t, findInteraction(interactions, reg.ChildID, models.RecommendationInteractionRegistration))
+}
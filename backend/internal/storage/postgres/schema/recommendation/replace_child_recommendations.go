@@ -0,0 +1,64 @@
+package recommendation
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+)
+
+// ReplaceChildRecommendations replaces every stored collaborative-filtering score with
+// recommendations in one transaction, so children the job no longer has scores for lose
+// their old ones and readers never see a half-written set
+func (r *RecommendationRepository) ReplaceChildRecommendations(ctx context.Context, recommendations []models.ChildRecommendation) error {
+	deleteQuery, err := schema.ReadSQLBaseScript("delete_child_recommendations.sql", SqlRecommendationFiles)
+	if err != nil {
+		e := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return &e
+	}
+
+	createQuery, err := schema.ReadSQLBaseScript("create_child_recommendations.sql", SqlRecommendationFiles)
+	if err != nil {
+		e := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return &e
+	}
+
+	childIDs := make([]uuid.UUID, len(recommendations))
+	eventIDs := make([]uuid.UUID, len(recommendations))
+	scores := make([]float64, len(recommendations))
+	for i, recommendation := range recommendations {
+		childIDs[i] = recommendation.ChildID
+		eventIDs[i] = recommendation.EventID
+		scores[i] = recommendation.Score
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		e := errs.InternalServerError("Failed to begin transaction: ", err.Error())
+		return &e
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if _, err = tx.Exec(ctx, deleteQuery); err != nil {
+		e := errs.InternalServerError("Failed to delete recommendations: ", err.Error())
+		return &e
+	}
+
+	if _, err = tx.Exec(ctx, createQuery, childIDs, eventIDs, scores); err != nil {
+		e := errs.InternalServerError("Failed to create recommendations: ", err.Error())
+		return &e
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		e := errs.InternalServerError("Failed to commit transaction: ", err.Error())
+		return &e
+	}
+
+	return nil
+}
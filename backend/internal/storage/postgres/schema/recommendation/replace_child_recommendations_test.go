@@ -0,0 +1,102 @@
+package recommendation
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/child"
+	"skillspark/internal/storage/postgres/schema/event"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func storedScores(t *testing.T, ctx context.Context, db *pgxpool.Pool, childID uuid.UUID) map[uuid.UUID]float64 {
+	t.Helper()
+
+	rows, err := db.Query(ctx, `SELECT event_id, score FROM child_recommendation WHERE child_id = $1`, childID)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	scores := map[uuid.UUID]float64{}
+	for rows.Next() {
+		var eventID uuid.UUID
+		var score float64
+		require.NoError(t, rows.Scan(&eventID, &score))
+		scores[eventID] = score
+	}
+	require.NoError(t, rows.Err())
+	return scores
+}
+
+func TestRecommendationRepository_ReplaceChildRecommendations(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewRecommendationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	c := child.CreateTestChild(t, ctx, testDB)
+	first := event.CreateTestEvent(t, ctx, testDB)
+	second := event.CreateTestEvent(t, ctx, testDB)
+
+	err := repo.ReplaceChildRecommendations(ctx, []models.ChildRecommendation{
+		{ChildID: c.ID, EventID: first.ID, Score: 1},
+		{ChildID: c.ID, EventID: second.ID, Score: 0.5},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]float64{first.ID: 1, second.ID: 0.5}, storedScores(t, ctx, testDB, c.ID))
+
+	// the next run's scores replace the old ones
+	err = repo.ReplaceChildRecommendations(ctx, []models.ChildRecommendation{
+		{ChildID: c.ID, EventID: second.ID, Score: 1},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]float64{second.ID: 1}, storedScores(t, ctx, testDB, c.ID))
+}
+
+func TestRecommendationRepository_ReplaceChildRecommendations_SkipsDeleted(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewRecommendationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	c := child.CreateTestChild(t, ctx, testDB)
+	e := event.CreateTestEvent(t, ctx, testDB)
+
+	err := repo.ReplaceChildRecommendations(ctx, []models.ChildRecommendation{
+		{ChildID: c.ID, EventID: e.ID, Score: 1},
+		{ChildID: c.ID, EventID: uuid.New(), Score: 0.5},
+		{ChildID: uuid.New(), EventID: e.ID, Score: 1},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]float64{e.ID: 1}, storedScores(t, ctx, testDB, c.ID))
+}
+
+func TestRecommendationRepository_ReplaceChildRecommendations_Empty(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewRecommendationRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	c := child.CreateTestChild(t, ctx, testDB)
+	e := event.CreateTestEvent(t, ctx, testDB)
+	require.NoError(t, repo.ReplaceChildRecommendations(ctx, []models.ChildRecommendation{{ChildID: c.ID, EventID: e.ID, Score: 1}}))
+
+	require.NoError(t, repo.ReplaceChildRecommendations(ctx, nil))
+	assert.Empty(t, storedScores(t, ctx, testDB, c.ID))
+}
@@ -0,0 +1,6 @@
+INSERT INTO child_recommendation (child_id, event_id, score)
+SELECT t.child_id, t.event_id, t.score
+FROM unnest($1::uuid[], $2::uuid[], $3::float8[]) AS t(child_id, event_id, score)
+-- skip children and events deleted while the scores were computed
+WHERE EXISTS (SELECT 1 FROM child c WHERE c.id = t.child_id)
+  AND EXISTS (SELECT 1 FROM event e WHERE e.id = t.event_id);
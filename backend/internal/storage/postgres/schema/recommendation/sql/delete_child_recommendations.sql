@@ -0,0 +1 @@
+DELETE FROM child_recommendation;
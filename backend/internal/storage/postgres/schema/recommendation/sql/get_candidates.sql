@@ -0,0 +1,99 @@
+WITH candidates AS (
+    SELECT
+        e.id,
+        e.title_en,
+        e.title_th,
+        e.description_en,
+        e.description_th,
+        e.organization_id,
+        e.age_range_min,
+        e.age_range_max,
+        e.category,
+        e.header_image_s3_key,
+        e.created_at,
+        e.updated_at,
+        interests.matched AS matched_interests,
+        -- the collaborative score, from 0 to 1, weighted by $10
+        $10::float8 * COALESCE(cr.score, 0) AS collaborative,
+        recent.registrations AS recent_registrations,
+        -- recent registrations, weighted by $12 and halfway there at $13 of them
+        $12::float8 * recent.registrations / (recent.registrations + $13::float8) AS popularity,
+        CASE
+            WHEN $6::float IS NULL OR $7::float IS NULL THEN NULL
+            ELSE earth_distance(
+                ll_to_earth(l.latitude, l.longitude),
+                ll_to_earth($6, $7)
+            )/1000
+        END AS distance_km,
+        upcoming.start_time AS next_start_time
+    FROM event e
+    JOIN organization o ON o.id = e.organization_id
+    JOIN location l ON l.id = o.location_id
+    LEFT JOIN child_recommendation cr ON cr.child_id = $9 AND cr.event_id = e.id
+    -- the event's next scheduled occurrence in the requested dates
+    JOIN LATERAL (
+        SELECT MIN(eo.start_time) AS start_time
+        FROM event_occurrence eo
+        WHERE eo.event_id = e.id
+          AND eo.status = 'scheduled'
+          AND eo.start_time > NOW()
+          AND ($4::timestamptz IS NULL OR eo.start_time >= $4)
+          AND ($5::timestamptz IS NULL OR eo.start_time <= $5)
+    ) upcoming ON upcoming.start_time IS NOT NULL
+    CROSS JOIN LATERAL (
+        SELECT ARRAY(
+            SELECT cat
+            FROM unnest(e.category::text[]) AS cat
+            WHERE cat = ANY($1::text[])
+        ) AS matched
+    ) interests
+    CROSS JOIN LATERAL (
+        SELECT COUNT(*)::int AS registrations
+        FROM registration r
+        JOIN event_occurrence eo ON eo.id = r.event_occurrence_id
+        WHERE eo.event_id = e.id
+          AND r.status = 'registered'
+          AND r.created_at >= $11
+    ) recent
+    -- nothing the child is already registered for, on any of its occurrences
+    WHERE NOT EXISTS (
+        SELECT 1
+        FROM registration r
+        JOIN event_occurrence eo ON eo.id = r.event_occurrence_id
+        WHERE eo.event_id = e.id
+          AND r.child_id = $9
+          AND r.status = 'registered'
+    )
+    AND (
+        $2::int IS NULL OR e.age_range_min IS NULL OR (EXTRACT(YEAR FROM NOW()) - $2) >= e.age_range_min
+    )
+    AND (
+        $2::int IS NULL OR e.age_range_max IS NULL OR (EXTRACT(YEAR FROM NOW()) - $2) <= e.age_range_max
+    )
+    AND (
+        $6::float IS NULL
+        OR $7::float IS NULL
+        OR $8::float IS NULL
+        OR earth_distance(
+            ll_to_earth(l.latitude, l.longitude),
+            ll_to_earth($6, $7)
+        )/1000 <= $8
+    )
+    -- only new events, or events with a new occurrence coming up, when $14 is given
+    AND (
+        $14::timestamptz IS NULL
+        OR e.created_at >= $14
+        OR EXISTS (
+            SELECT 1
+            FROM event_occurrence eo
+            WHERE eo.event_id = e.id
+              AND eo.created_at >= $14
+              AND eo.status = 'scheduled'
+              AND eo.start_time > NOW()
+        )
+    )
+)
+SELECT *
+FROM candidates
+ORDER BY cardinality(matched_interests) + collaborative + popularity DESC, created_at DESC, id DESC
+LIMIT $3;
@@ -0,0 +1,37 @@
+-- registrations that weren't cancelled, saves listed once for each of the guardian's
+-- children, and the ratings of reviews of registrations
+SELECT
+    r.guardian_id,
+    r.child_id,
+    eo.event_id,
+    'registration' AS kind,
+    NULL::int AS rating,
+    r.created_at
+FROM registration r
+JOIN event_occurrence eo ON eo.id = r.event_occurrence_id
+WHERE r.status = 'registered'
+
+UNION ALL
+
+SELECT
+    s.guardian_id,
+    c.id AS child_id,
+    s.event_id,
+    'saved' AS kind,
+    NULL::int AS rating,
+    s.created_at
+FROM saved s
+JOIN child c ON c.guardian_id = s.guardian_id
+
+UNION ALL
+
+SELECT
+    r.guardian_id,
+    r.child_id,
+    eo.event_id,
+    'review' AS kind,
+    rv.rating,
+    rv.created_at
+FROM review rv
+JOIN registration r ON r.id = rv.registration_id
+JOIN event_occurrence eo ON eo.id = r.event_occurrence_id;
@@ -11,10 +11,44 @@ import (
 )
 
 func (r *RegistrationRepository) CancelRegistration(ctx context.Context, input *models.CancelRegistrationInput) (*models.CancelRegistrationOutput, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to begin transaction: ", err.Error())
+		return nil, &errr
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	output, err := CancelRegistrationTx(ctx, tx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		errr := errs.InternalServerError("Failed to commit transaction: ", err.Error())
+		return nil, &errr
+	}
+
+	return output, nil
+}
+
+// CancelRegistrationTx cancels a registration inside tx, so callers can record what the
+// cancellation pays back in the same transaction. The registration is locked first and a
+// registration that is already cancelled is rejected, so only one cancellation succeeds.
+func CancelRegistrationTx(ctx context.Context, tx pgx.Tx, input *models.CancelRegistrationInput) (*models.CancelRegistrationOutput, error) {
 
 	var titleEN string
 	var titleTH *string
 
+	lockQuery, err := schema.ReadSQLBaseScript("lock_registration.sql", SqlRegistrationFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read lock query: ", err.Error())
+		return nil, &errr
+	}
+
 	cancelQuery, err := schema.ReadSQLBaseScript("cancel_registration.sql", SqlRegistrationFiles)
 	if err != nil {
 		errr := errs.InternalServerError("Failed to read cancel query: ", err.Error())
@@ -27,16 +61,19 @@ func (r *RegistrationRepository) CancelRegistration(ctx context.Context, input *
 		return nil, &errr
 	}
 
-	tx, err := r.db.Begin(ctx)
-	if err != nil {
-		errr := errs.InternalServerError("Failed to begin transaction: ", err.Error())
+	var status models.RegistrationStatus
+	if err := tx.QueryRow(ctx, lockQuery, input.ID).Scan(&status); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			errr := errs.NotFound("Registration", "id", input.ID)
+			return nil, &errr
+		}
+		errr := errs.InternalServerError("Failed to lock registration: ", err.Error())
+		return nil, &errr
+	}
+	if status == models.RegistrationStatusCancelled {
+		errr := errs.BadRequest("Registration is already cancelled")
 		return nil, &errr
 	}
-	defer func() {
-		if err != nil {
-			_ = tx.Rollback(ctx)
-		}
-	}()
 
 	row := tx.QueryRow(ctx, cancelQuery,
 		input.ID,
@@ -70,23 +107,17 @@ func (r *RegistrationRepository) CancelRegistration(ctx context.Context, input *
 		&output.Body.Registration.OccurrenceStartTime,
 	)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			errr := errs.NotFound("Registration", "id", input.ID)
-			return nil, &errr
-		}
 		errr := errs.InternalServerError("Failed to cancel registration: ", err.Error())
 		return nil, &errr
 	}
 
-	_, err = tx.Exec(ctx, decrementQuery, output.Body.Registration.EventOccurrenceID)
-	if err != nil {
+	if _, err := tx.Exec(ctx, decrementQuery, output.Body.Registration.EventOccurrenceID); err != nil {
 		errr := errs.InternalServerError("Failed to decrement enrolled: ", err.Error())
 		return nil, &errr
 	}
 
-	if err = tx.Commit(ctx); err != nil {
-		errr := errs.InternalServerError("Failed to commit transaction: ", err.Error())
-		return nil, &errr
+	if err := enqueueOutboxMessage(ctx, tx, models.OutboxTopicRegistrationCancelled, output.Body.Registration.ID); err != nil {
+		return nil, err
 	}
 
 	switch input.AcceptLanguage {
@@ -52,6 +52,13 @@ func TestCancelRegistration(t *testing.T) {
 	row = testDB.QueryRow(ctx, "SELECT curr_enrolled FROM event_occurrence WHERE id = $1", created.EventOccurrenceID)
 	require.NoError(t, row.Scan(&enrolledAfter))
 	assert.Equal(t, enrolledBefore-1, enrolledAfter)
+
+	// the reminders are removed by the relay
+	var outboxCount int
+	row = testDB.QueryRow(ctx, "SELECT COUNT(*) FROM outbox_message WHERE dedup_id = $1",
+		models.OutboxTopicRegistrationCancelled+":"+created.ID.String())
+	require.NoError(t, row.Scan(&outboxCount))
+	assert.Equal(t, 1, outboxCount)
 }
 
 func TestCancelRegistration_AlreadyCancelled(t *testing.T) {
@@ -69,12 +76,19 @@ func TestCancelRegistration_AlreadyCancelled(t *testing.T) {
 	_, err := repo.CancelRegistration(ctx, input)
 	require.NoError(t, err)
 
-	// second cancel should still succeed at the repo level
+	var enrolledBefore int
+	row := testDB.QueryRow(ctx, "SELECT curr_enrolled FROM event_occurrence WHERE id = $1", created.EventOccurrenceID)
+	require.NoError(t, row.Scan(&enrolledBefore))
+
+	// only the first cancel goes through, so it is only paid back once
 	cancelled, err := repo.CancelRegistration(ctx, input)
-	require.NoError(t, err)
-	require.NotNil(t, cancelled)
-	assert.Equal(t, models.RegistrationStatusCancelled, cancelled.Body.Registration.Status)
-	assert.NotNil(t, cancelled.Body.Registration.CancelledAt)
+	require.Error(t, err)
+	assert.Nil(t, cancelled)
+
+	var enrolledAfter int
+	row = testDB.QueryRow(ctx, "SELECT curr_enrolled FROM event_occurrence WHERE id = $1", created.EventOccurrenceID)
+	require.NoError(t, row.Scan(&enrolledAfter))
+	assert.Equal(t, enrolledBefore, enrolledAfter)
 }
 
 func TestCancelRegistration_NotFound(t *testing.T) {
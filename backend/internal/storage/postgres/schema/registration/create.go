@@ -27,7 +27,7 @@ func (r *RegistrationRepository) CreateRegistration(ctx context.Context, input *
 		return nil, &errr
 	}
 
-	row := r.db.QueryRow(ctx, query,
+	row := tx.QueryRow(ctx, query,
 		input.ChildID,
 		input.GuardianID,
 		input.EventOccurrenceID,
@@ -86,6 +86,13 @@ func (r *RegistrationRepository) CreateRegistration(ctx context.Context, input *
 		return nil, &errr
 	}
 
+	if err := enqueueOutboxMessage(ctx, tx, models.OutboxTopicRegistrationCreated, createdRegistration.Body.ID); err != nil {
+		if err := tx.Rollback(ctx); err != nil {
+			slog.Error("Failed to rollback transaction: " + err.Error())
+		}
+		return nil, err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		slog.Error("Failed to commit transaction: " + err.Error())
 		if err := tx.Rollback(ctx); err != nil {
@@ -25,6 +25,8 @@ func (r *RegistrationRepository) CreatePayment(ctx context.Context, input *model
 		input.PlatformFeeAmount,
 		input.Currency,
 		input.PaymentIntentStatus,
+		input.CreditAmount,
+		input.StripeTransferID,
 	)
 	if err != nil {
 		errr := errs.InternalServerError("Failed to create payment record: ", err.Error())
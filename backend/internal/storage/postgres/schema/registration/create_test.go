@@ -45,6 +45,22 @@ func TestCreateRegistration(t *testing.T) {
 	assert.Nil(t, created.PaidAt)
 }
 
+func TestCreateRegistration_RecordsOutboxMessage(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	t.Parallel()
+
+	created := CreateTestRegistration(t, ctx, testDB)
+
+	var topic string
+	var registrationID uuid.UUID
+	row := testDB.QueryRow(ctx, "SELECT topic, (payload->>'registration_id')::uuid FROM outbox_message WHERE dedup_id = $1",
+		models.OutboxTopicRegistrationCreated+":"+created.ID.String())
+	require.NoError(t, row.Scan(&topic, &registrationID))
+	assert.Equal(t, models.OutboxTopicRegistrationCreated, topic)
+	assert.Equal(t, created.ID, registrationID)
+}
+
 func TestCreateRegistration_VerifyEventNameJoin(t *testing.T) {
 	testDB := testutil.SetupTestDB(t)
 	ctx := context.Background()
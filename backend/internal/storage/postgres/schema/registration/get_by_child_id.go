@@ -60,6 +60,7 @@ func scanRegistrationWithLang(lang string) pgx.RowToFunc[models.Registration] {
 			&registration.PlatformFeeAmount,
 			&registration.PaidAt,
 			&registration.StripePaymentMethodID,
+			&registration.StripeTransferID,
 			&titleEN,
 			&titleTH,
 			&registration.OccurrenceStartTime,
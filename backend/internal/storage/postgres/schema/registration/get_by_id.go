@@ -49,6 +49,7 @@ func (r *RegistrationRepository) GetRegistrationByID(ctx context.Context, input
 		&registration.Body.PlatformFeeAmount,
 		&registration.Body.PaidAt,
 		&registration.Body.StripePaymentMethodID,
+		&registration.Body.StripeTransferID,
 		&titleEN,
 		&titleTH,
 		&registration.Body.OccurrenceStartTime,
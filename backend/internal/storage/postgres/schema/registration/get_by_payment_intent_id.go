@@ -33,6 +33,7 @@ func (r *RegistrationRepository) GetRegistrationByPaymentIntentID(ctx context.Co
 		&registration.StripeCustomerID,
 		&registration.OrgStripeAccountID,
 		&registration.StripePaymentMethodID,
+		&registration.StripeTransferID,
 		&registration.TotalAmount,
 		&registration.ProviderAmount,
 		&registration.PlatformFeeAmount,
@@ -0,0 +1,35 @@
+package registration
+
+import (
+	"context"
+	"encoding/json"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// enqueueOutboxMessage records the side effects of a registration change in the change's
+// own transaction, so they are published if and only if it commits
+func enqueueOutboxMessage(ctx context.Context, tx pgx.Tx, topic string, registrationID uuid.UUID) error {
+	query, err := schema.ReadSQLBaseScript("enqueue_outbox_message.sql", SqlRegistrationFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return &errr
+	}
+
+	payload, err := json.Marshal(models.RegistrationOutboxPayload{RegistrationID: registrationID})
+	if err != nil {
+		errr := errs.InternalServerError("Failed to encode outbox payload: ", err.Error())
+		return &errr
+	}
+
+	if _, err := tx.Exec(ctx, query, topic, string(payload), topic+":"+registrationID.String()); err != nil {
+		errr := errs.InternalServerError("Failed to enqueue outbox message: ", err.Error())
+		return &errr
+	}
+
+	return nil
+}
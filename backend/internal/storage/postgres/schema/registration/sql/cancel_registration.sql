@@ -5,6 +5,7 @@ WITH cancelled_reg AS (
         cancelled_at = NOW(),
         updated_at   = NOW()
     WHERE id = $1
+      AND status = 'registered'
     RETURNING id, child_id, guardian_id, event_occurrence_id, status, cancelled_at, created_at, updated_at
 ),
 updated_payment AS (
@@ -8,6 +8,8 @@ INSERT INTO payment (
     provider_amount,
     platform_fee_amount,
     currency,
-    payment_intent_status
+    payment_intent_status,
+    credit_amount,
+    stripe_transfer_id
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, ''));
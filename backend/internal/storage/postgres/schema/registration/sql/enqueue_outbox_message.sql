@@ -0,0 +1,3 @@
+INSERT INTO outbox_message (topic, payload, dedup_id)
+VALUES ($1, $2::jsonb, $3)
+ON CONFLICT (dedup_id) DO NOTHING;
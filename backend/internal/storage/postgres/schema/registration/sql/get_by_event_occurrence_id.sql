@@ -17,6 +17,7 @@ SELECT
     COALESCE(p.platform_fee_amount, 0) AS platform_fee_amount,
     p.paid_at,
     COALESCE(p.stripe_payment_method_id, '') AS stripe_payment_method_id,
+    COALESCE(p.stripe_transfer_id, '') AS stripe_transfer_id,
     e.title_en,
     e.title_th,
     eo.start_time AS occurrence_start_time
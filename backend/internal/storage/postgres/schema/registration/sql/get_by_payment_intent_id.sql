@@ -8,6 +8,7 @@ SELECT
     p.stripe_customer_id,
     p.org_stripe_account_id,
     p.stripe_payment_method_id,
+    COALESCE(p.stripe_transfer_id, '') AS stripe_transfer_id,
     p.total_amount,
     p.provider_amount,
     p.platform_fee_amount,
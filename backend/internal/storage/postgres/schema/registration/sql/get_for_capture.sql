@@ -17,6 +17,7 @@ SELECT
     p.platform_fee_amount,
     p.paid_at,
     p.stripe_payment_method_id,
+    COALESCE(p.stripe_transfer_id, '') AS stripe_transfer_id,
     e.title_en,
     e.title_th,
     eo.start_time AS occurrence_start_time
@@ -0,0 +1,4 @@
+SELECT status
+FROM registration
+WHERE id = $1
+FOR UPDATE;
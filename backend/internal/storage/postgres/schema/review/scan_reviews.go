@@ -11,7 +11,7 @@ func (r *ReviewRepository) ScanReviews(row pgx.CollectableRow) (models.Review, e
 	var output models.Review
 	var desc *string
 
-	err := row.Scan(&review.ID, &review.RegistrationID, &review.GuardianID, &review.EventID, &review.Rating, &review.Description_EN, &review.Description_TH, &review.Categories, &review.CreatedAt, &review.UpdatedAt)
+	err := row.Scan(&review.ID, &review.RegistrationID, &review.GuardianID, &review.EventID, &review.Rating, &review.Description_EN, &review.Description_TH, &review.Categories, &review.PhotoS3Key, &review.CreatedAt, &review.UpdatedAt)
 
 	if language == "th" {
 		desc = review.Description_TH
@@ -29,6 +29,7 @@ func (r *ReviewRepository) ScanReviews(row pgx.CollectableRow) (models.Review, e
 		Categories:     review.Categories,
 		CreatedAt:      review.CreatedAt,
 		UpdatedAt:      review.UpdatedAt,
+		PhotoS3Key:     review.PhotoS3Key,
 	}
 
 	return output, err
@@ -1,4 +1,4 @@
-SELECT r.id, r.registration_id, r.guardian_id, eo.event_id, r.rating, r.description_en, r.description_th, r.categories, r.created_at, r.updated_at
+SELECT r.id, r.registration_id, r.guardian_id, eo.event_id, r.rating, r.description_en, r.description_th, r.categories, r.photo_s3_key, r.created_at, r.updated_at
 FROM review r
 JOIN registration reg
 ON r.registration_id= reg.id
@@ -0,0 +1,45 @@
+package searchlog
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateSearchLog records a search with its normalized query
+func (r *SearchLogRepository) CreateSearchLog(ctx context.Context, input *models.CreateSearchLogData) (*models.SearchLog, error) {
+	query, err := schema.ReadSQLBaseScript("create.sql", SqlSearchLogFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	filters := input.Filters
+	if filters == nil {
+		filters = map[string]any{}
+	}
+
+	rows, err := r.db.Query(ctx, query,
+		input.Endpoint,
+		input.Query,
+		models.NormalizeSearchQuery(input.Query),
+		input.Language,
+		filters,
+		input.ResultCount,
+	)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to create search log: ", err.Error())
+		return nil, &errr
+	}
+
+	log, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.SearchLog])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to create search log: ", err.Error())
+		return nil, &errr
+	}
+
+	return &log, nil
+}
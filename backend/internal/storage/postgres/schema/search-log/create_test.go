@@ -0,0 +1,43 @@
+package searchlog
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchLogRepository_CreateSearchLog(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewSearchLogRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	log, err := repo.CreateSearchLog(ctx, &models.CreateSearchLogData{
+		Endpoint:    models.SearchLogEndpointAll,
+		Query:       "  Robotics   CLUB ",
+		Language:    "th-TH",
+		Filters:     map[string]any{"category": "science", "location": true},
+		ResultCount: 4,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, log)
+
+	assert.Equal(t, models.SearchLogEndpointAll, log.Endpoint)
+	assert.Equal(t, "  Robotics   CLUB ", log.Query)
+	assert.Equal(t, "robotics club", log.NormalizedQuery)
+	assert.Equal(t, "th-TH", log.Language)
+	assert.Equal(t, "science", log.Filters["category"])
+	assert.Equal(t, true, log.Filters["location"])
+	assert.Equal(t, 4, log.ResultCount)
+	assert.Nil(t, log.ClickedResultID)
+	assert.Nil(t, log.ClickedAt)
+	assert.False(t, log.CreatedAt.IsZero())
+}
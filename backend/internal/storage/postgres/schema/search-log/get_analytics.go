@@ -0,0 +1,75 @@
+package searchlog
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetSearchAnalytics reports the searches with a text query made in [since, until): the
+// totals, the most searched queries and the queries that most often found nothing, at most
+// limit of each
+func (r *SearchLogRepository) GetSearchAnalytics(ctx context.Context, since time.Time, until time.Time, limit int) (*models.SearchAnalytics, error) {
+	analytics := &models.SearchAnalytics{Since: since, Until: until}
+
+	totalsQuery, err := schema.ReadSQLBaseScript("get_totals.sql", SqlSearchLogFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+	err = r.db.QueryRow(ctx, totalsQuery, since, until).Scan(&analytics.Searches, &analytics.ZeroResultSearches, &analytics.Clicks)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to fetch search totals: ", err.Error())
+		return nil, &errr
+	}
+	analytics.ClickThroughRate = clickThroughRate(analytics.Clicks, analytics.Searches)
+
+	analytics.TopQueries, err = r.queryStats(ctx, "get_top_queries.sql", since, until, limit)
+	if err != nil {
+		return nil, err
+	}
+	analytics.ZeroResultQueries, err = r.queryStats(ctx, "get_zero_result_queries.sql", since, until, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return analytics, nil
+}
+
+func (r *SearchLogRepository) queryStats(ctx context.Context, file string, since time.Time, until time.Time, limit int) ([]models.SearchQueryStats, error) {
+	query, err := schema.ReadSQLBaseScript(file, SqlSearchLogFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, since, until, limit)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to fetch search query stats: ", err.Error())
+		return nil, &errr
+	}
+
+	stats, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.SearchQueryStats, error) {
+		var s models.SearchQueryStats
+		err := row.Scan(&s.Query, &s.Searches, &s.ZeroResultSearches, &s.Clicks, &s.LastSearchedAt)
+		s.ClickThroughRate = clickThroughRate(s.Clicks, s.Searches)
+		return s, err
+	})
+	if err != nil {
+		errr := errs.InternalServerError("Failed to scan search query stats: ", err.Error())
+		return nil, &errr
+	}
+
+	return stats, nil
+}
+
+func clickThroughRate(clicks int, searches int) float64 {
+	if searches == 0 {
+		return 0
+	}
+	return float64(clicks) / float64(searches)
+}
@@ -0,0 +1,84 @@
+package searchlog
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findStats(stats []models.SearchQueryStats, query string) *models.SearchQueryStats {
+	for i := range stats {
+		if stats[i].Query == query {
+			return &stats[i]
+		}
+	}
+	return nil
+}
+
+func TestSearchLogRepository_GetSearchAnalytics(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewSearchLogRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	// queries unique to this test, so parallel tests don't change the counts
+	popular := "robotics " + uuid.NewString()[:8]
+	missing := "fencing " + uuid.NewString()[:8]
+
+	clicked := CreateTestSearchLog(t, ctx, testDB, popular, 5)
+	CreateTestSearchLog(t, ctx, testDB, popular+"  ", 5)
+	CreateTestSearchLog(t, ctx, testDB, popular, 0)
+	CreateTestSearchLog(t, ctx, testDB, missing, 0)
+	CreateTestSearchLog(t, ctx, testDB, "", 12)
+
+	_, err := repo.RecordSearchClick(ctx, &models.RecordSearchClickData{
+		ID:         clicked.ID,
+		ResultID:   uuid.New(),
+		ResultType: models.SearchResultTypeEvent,
+	})
+	require.NoError(t, err)
+
+	since := time.Now().Add(-time.Hour)
+	until := time.Now().Add(time.Hour)
+	analytics, err := repo.GetSearchAnalytics(ctx, since, until, 100)
+	require.NoError(t, err)
+
+	assert.GreaterOrEqual(t, analytics.Searches, 4)
+	assert.GreaterOrEqual(t, analytics.ZeroResultSearches, 2)
+	assert.GreaterOrEqual(t, analytics.Clicks, 1)
+
+	top := findStats(analytics.TopQueries, popular)
+	require.NotNil(t, top, "trailing whitespace is normalized into the same query")
+	assert.Equal(t, 3, top.Searches)
+	assert.Equal(t, 1, top.ZeroResultSearches)
+	assert.Equal(t, 1, top.Clicks)
+	assert.InDelta(t, 1.0/3.0, top.ClickThroughRate, 0.0001)
+	assert.Nil(t, findStats(analytics.TopQueries, ""), "browsing without a query isn't a query")
+
+	zero := findStats(analytics.ZeroResultQueries, missing)
+	require.NotNil(t, zero)
+	assert.Equal(t, 1, zero.Searches)
+	assert.Equal(t, 1, zero.ZeroResultSearches)
+	assert.Zero(t, zero.ClickThroughRate)
+	assert.NotNil(t, findStats(analytics.ZeroResultQueries, popular))
+
+	for i := 1; i < len(analytics.TopQueries); i++ {
+		assert.GreaterOrEqual(t, analytics.TopQueries[i-1].Searches, analytics.TopQueries[i].Searches)
+	}
+
+	// a window before the searches reports nothing
+	analytics, err = repo.GetSearchAnalytics(ctx, since.Add(-24*time.Hour), since, 100)
+	require.NoError(t, err)
+	assert.Nil(t, findStats(analytics.TopQueries, popular))
+	assert.NotNil(t, analytics.TopQueries)
+}
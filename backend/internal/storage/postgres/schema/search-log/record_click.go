@@ -0,0 +1,38 @@
+package searchlog
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// RecordSearchClick records the result opened from a search. Only the first click is kept.
+func (r *SearchLogRepository) RecordSearchClick(ctx context.Context, input *models.RecordSearchClickData) (*models.SearchLog, error) {
+	query, err := schema.ReadSQLBaseScript("record_click.sql", SqlSearchLogFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, input.ID, input.ResultID, input.ResultType, input.Position)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to record search click: ", err.Error())
+		return nil, &errr
+	}
+
+	log, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.SearchLog])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			errr := errs.NotFound("SearchLog", "id", input.ID)
+			return nil, &errr
+		}
+		errr := errs.InternalServerError("Failed to record search click: ", err.Error())
+		return nil, &errr
+	}
+
+	return &log, nil
+}
@@ -0,0 +1,75 @@
+package searchlog
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchLogRepository_RecordSearchClick(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewSearchLogRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	search := CreateTestSearchLog(t, ctx, testDB, "robotics", 3)
+
+	first := uuid.New()
+	log, err := repo.RecordSearchClick(ctx, &models.RecordSearchClickData{
+		ID:         search.ID,
+		ResultID:   first,
+		ResultType: models.SearchResultTypeOccurrence,
+		Position:   2,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, log.ClickedResultID)
+	assert.Equal(t, first, *log.ClickedResultID)
+	assert.Equal(t, models.SearchResultTypeOccurrence, *log.ClickedResultType)
+	assert.Equal(t, 2, *log.ClickedPosition)
+	require.NotNil(t, log.ClickedAt)
+
+	// the result clicked next is the first one; later clicks don't replace it
+	log, err = repo.RecordSearchClick(ctx, &models.RecordSearchClickData{
+		ID:         search.ID,
+		ResultID:   uuid.New(),
+		ResultType: models.SearchResultTypeEvent,
+		Position:   0,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, first, *log.ClickedResultID)
+	assert.Equal(t, 2, *log.ClickedPosition)
+}
+
+func TestSearchLogRepository_RecordSearchClick_NotFound(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	testDB := testutil.SetupTestDB(t)
+	repo := NewSearchLogRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	log, err := repo.RecordSearchClick(ctx, &models.RecordSearchClickData{
+		ID:         uuid.New(),
+		ResultID:   uuid.New(),
+		ResultType: models.SearchResultTypeEvent,
+	})
+	assert.Nil(t, log)
+	require.Error(t, err)
+
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusNotFound, httpErr.Code)
+}
@@ -0,0 +1,11 @@
+package searchlog
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type SearchLogRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSearchLogRepository(db *pgxpool.Pool) *SearchLogRepository {
+	return &SearchLogRepository{db: db}
+}
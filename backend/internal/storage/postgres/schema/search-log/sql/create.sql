@@ -0,0 +1,3 @@
+INSERT INTO search_log (endpoint, query, normalized_query, language, filters, result_count)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, endpoint, query, normalized_query, language, filters, result_count, clicked_result_id, clicked_result_type, clicked_position, clicked_at, created_at;
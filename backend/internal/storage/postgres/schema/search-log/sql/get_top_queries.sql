@@ -0,0 +1,12 @@
+SELECT
+    normalized_query,
+    COUNT(*) AS searches,
+    COUNT(*) FILTER (WHERE result_count = 0) AS zero_result_searches,
+    COUNT(clicked_at) AS clicks,
+    MAX(created_at) AS last_searched_at
+FROM search_log
+WHERE created_at >= $1 AND created_at < $2
+AND normalized_query <> ''
+GROUP BY normalized_query
+ORDER BY searches DESC, normalized_query
+LIMIT $3;
@@ -0,0 +1,7 @@
+SELECT
+    COUNT(*),
+    COUNT(*) FILTER (WHERE result_count = 0),
+    COUNT(clicked_at)
+FROM search_log
+WHERE created_at >= $1 AND created_at < $2
+AND normalized_query <> '';
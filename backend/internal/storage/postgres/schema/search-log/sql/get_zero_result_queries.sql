@@ -0,0 +1,13 @@
+SELECT
+    normalized_query,
+    COUNT(*) AS searches,
+    COUNT(*) FILTER (WHERE result_count = 0) AS zero_result_searches,
+    COUNT(clicked_at) AS clicks,
+    MAX(created_at) AS last_searched_at
+FROM search_log
+WHERE created_at >= $1 AND created_at < $2
+AND normalized_query <> ''
+GROUP BY normalized_query
+HAVING COUNT(*) FILTER (WHERE result_count = 0) > 0
+ORDER BY zero_result_searches DESC, last_searched_at DESC
+LIMIT $3;
@@ -0,0 +1,8 @@
+-- only the first result opened is kept; later clicks return the search unchanged
+UPDATE search_log
+SET clicked_result_id   = COALESCE(clicked_result_id, $2),
+    clicked_result_type = COALESCE(clicked_result_type, $3),
+    clicked_position    = COALESCE(clicked_position, $4),
+    clicked_at          = COALESCE(clicked_at, NOW())
+WHERE id = $1
+RETURNING id, endpoint, query, normalized_query, language, filters, result_count, clicked_result_id, clicked_result_type, clicked_position, clicked_at, created_at;
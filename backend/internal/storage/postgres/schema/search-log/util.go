@@ -0,0 +1,38 @@
+package searchlog
+
+import (
+	"context"
+	"embed"
+	"skillspark/internal/models"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed sql/*.sql
+var SqlSearchLogFiles embed.FS
+
+func CreateTestSearchLog(
+	t *testing.T,
+	ctx context.Context,
+	db *pgxpool.Pool,
+	query string,
+	resultCount int,
+) *models.SearchLog {
+	t.Helper()
+
+	repo := NewSearchLogRepository(db)
+
+	log, err := repo.CreateSearchLog(ctx, &models.CreateSearchLogData{
+		Endpoint:    models.SearchLogEndpointEvents,
+		Query:       query,
+		Language:    "en-US",
+		Filters:     map[string]any{},
+		ResultCount: resultCount,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, log)
+
+	return log
+}
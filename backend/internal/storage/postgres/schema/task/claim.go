@@ -0,0 +1,64 @@
+package task
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ClaimTasks marks up to limit due tasks as running and returns them, incrementing their
+// attempt count. A claim lasts visibilityTimeout; a running task whose claim has expired
+// is treated as abandoned and can be claimed again, or dead-lettered if it has used all
+// of its attempts. Concurrent workers never receive the same task.
+func (r *TaskRepository) ClaimTasks(ctx context.Context, limit int, visibilityTimeout time.Duration) ([]models.Task, error) {
+	expireQuery, err := schema.ReadSQLBaseScript("dead_letter_expired.sql", SqlTaskFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	claimQuery, err := schema.ReadSQLBaseScript("claim.sql", SqlTaskFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to begin transaction: ", err.Error())
+		return nil, &errr
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if _, err = tx.Exec(ctx, expireQuery); err != nil {
+		errr := errs.InternalServerError("Failed to dead-letter expired tasks: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := tx.Query(ctx, claimQuery, limit, visibilityTimeout.Seconds())
+	if err != nil {
+		errr := errs.InternalServerError("Failed to claim tasks: ", err.Error())
+		return nil, &errr
+	}
+
+	tasks, err := pgx.CollectRows(rows, pgx.RowToStructByName[models.Task])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to scan claimed tasks: ", err.Error())
+		return nil, &errr
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		errr := errs.InternalServerError("Failed to commit transaction: ", err.Error())
+		return nil, &errr
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,82 @@
+package task
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimTasks(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewTaskRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	due := CreateTestTask(t, ctx, testDB, "capture_payment", 3)
+	future := time.Now().Add(time.Hour)
+	_, err := repo.EnqueueTask(ctx, &models.EnqueueTaskData{TaskType: "capture_payment", RunAfter: &future})
+	require.NoError(t, err)
+
+	claimed, err := repo.ClaimTasks(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, due.ID, claimed[0].ID)
+	assert.Equal(t, models.TaskStatusRunning, claimed[0].Status)
+	assert.Equal(t, 1, claimed[0].Attempts)
+	require.NotNil(t, claimed[0].LockedUntil)
+
+	// a claimed task is invisible to other workers until its claim expires
+	again, err := repo.ClaimTasks(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+}
+
+func TestClaimTasks_ReclaimsExpired(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewTaskRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	task := CreateTestTask(t, ctx, testDB, "capture_payment", 3)
+
+	claimed, err := repo.ClaimTasks(ctx, 10, -time.Second)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	reclaimed, err := repo.ClaimTasks(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, reclaimed, 1)
+	assert.Equal(t, task.ID, reclaimed[0].ID)
+	assert.Equal(t, 2, reclaimed[0].Attempts)
+
+	// the first worker's late result is rejected
+	require.Error(t, repo.CompleteTask(ctx, task.ID, 1))
+}
+
+func TestClaimTasks_DeadLettersExpiredFinalAttempt(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewTaskRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	task := CreateTestTask(t, ctx, testDB, "capture_payment", 1)
+
+	claimed, err := repo.ClaimTasks(ctx, 10, -time.Second)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	reclaimed, err := repo.ClaimTasks(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, reclaimed)
+
+	dead, err := repo.GetAllTasks(ctx, string(models.TaskStatusDead), "", defaultPagination)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, task.ID, dead[0].ID)
+	require.NotNil(t, dead[0].LastError)
+}
@@ -0,0 +1,11 @@
+package task
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+func (r *TaskRepository) CompleteTask(ctx context.Context, id uuid.UUID, attempt int) error {
+	return r.updateClaimedTask(ctx, "complete.sql", id, attempt)
+}
@@ -0,0 +1,50 @@
+package task
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompleteTask(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewTaskRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	task := CreateTestTask(t, ctx, testDB, "send_notification", 3)
+	claimed, err := repo.ClaimTasks(ctx, 1, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	require.NoError(t, repo.CompleteTask(ctx, task.ID, claimed[0].Attempts))
+
+	tasks, err := repo.GetAllTasks(ctx, string(models.TaskStatusSucceeded), "", defaultPagination)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.NotNil(t, tasks[0].CompletedAt)
+	assert.Nil(t, tasks[0].LockedUntil)
+}
+
+func TestCompleteTask_NotClaimed(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewTaskRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	task := CreateTestTask(t, ctx, testDB, "send_notification", 3)
+
+	err := repo.CompleteTask(ctx, task.ID, 1)
+	require.Error(t, err)
+
+	httpErr, ok := err.(*errs.HTTPError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusConflict, httpErr.Code)
+}
@@ -0,0 +1,12 @@
+package task
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// DeadLetterTask parks a task that will not be retried automatically
+func (r *TaskRepository) DeadLetterTask(ctx context.Context, id uuid.UUID, attempt int, lastError string) error {
+	return r.updateClaimedTask(ctx, "dead_letter.sql", id, attempt, lastError)
+}
@@ -0,0 +1,30 @@
+package task
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterTask(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewTaskRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	task := CreateTestTask(t, ctx, testDB, "capture_payment", 1)
+	_, err := repo.ClaimTasks(ctx, 1, time.Minute)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.DeadLetterTask(ctx, task.ID, 1, "card declined"))
+
+	tasks, err := repo.GetAllTasks(ctx, string(models.TaskStatusDead), "", defaultPagination)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "card declined", *tasks[0].LastError)
+}
@@ -0,0 +1,47 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// EnqueueTask adds a task to the queue. When a task with the same dedup key already
+// exists, in any state, nothing is enqueued and it returns nil without an error.
+func (r *TaskRepository) EnqueueTask(ctx context.Context, input *models.EnqueueTaskData) (*models.Task, error) {
+	query, err := schema.ReadSQLBaseScript("enqueue.sql", SqlTaskFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	payload := "{}"
+	if len(input.Payload) > 0 {
+		payload = string(input.Payload)
+	}
+	maxAttempts := input.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 5
+	}
+
+	rows, err := r.db.Query(ctx, query, input.TaskType, payload, input.DedupKey, maxAttempts, input.RunAfter)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to enqueue task: ", err.Error())
+		return nil, &errr
+	}
+
+	task, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.Task])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		errr := errs.InternalServerError("Failed to enqueue task: ", err.Error())
+		return nil, &errr
+	}
+
+	return &task, nil
+}
@@ -0,0 +1,66 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnqueueTask(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewTaskRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	dedupKey := "capture_payment:abc"
+	task, err := repo.EnqueueTask(ctx, &models.EnqueueTaskData{
+		TaskType: "capture_payment",
+		Payload:  json.RawMessage(`{"registration_id": "abc"}`),
+		DedupKey: &dedupKey,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, task)
+
+	assert.Equal(t, "capture_payment", task.TaskType)
+	assert.JSONEq(t, `{"registration_id": "abc"}`, string(task.Payload))
+	assert.Equal(t, models.TaskStatusPending, task.Status)
+	assert.Equal(t, 0, task.Attempts)
+	assert.Equal(t, 5, task.MaxAttempts)
+	assert.WithinDuration(t, time.Now(), task.RunAfter, time.Minute)
+}
+
+func TestEnqueueTask_Deduplicates(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewTaskRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	dedupKey := "send_notification:1"
+	first, err := repo.EnqueueTask(ctx, &models.EnqueueTaskData{TaskType: "send_notification", DedupKey: &dedupKey})
+	require.NoError(t, err)
+	require.NotNil(t, first)
+
+	second, err := repo.EnqueueTask(ctx, &models.EnqueueTaskData{TaskType: "send_notification", DedupKey: &dedupKey})
+	require.NoError(t, err)
+	assert.Nil(t, second)
+}
+
+func TestEnqueueTask_RunAfter(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewTaskRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	runAfter := time.Now().Add(2 * time.Hour)
+	task, err := repo.EnqueueTask(ctx, &models.EnqueueTaskData{TaskType: "send_notification", MaxAttempts: 2, RunAfter: &runAfter})
+	require.NoError(t, err)
+	assert.WithinDuration(t, runAfter, task.RunAfter, time.Second)
+	assert.Equal(t, 2, task.MaxAttempts)
+	assert.Nil(t, task.DedupKey)
+}
@@ -0,0 +1,34 @@
+package task
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+	"skillspark/internal/utils"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetAllTasks returns tasks most recently updated first. Empty status or taskType match all.
+func (r *TaskRepository) GetAllTasks(ctx context.Context, status string, taskType string, pagination utils.Pagination) ([]models.Task, error) {
+	query, err := schema.ReadSQLBaseScript("get_all.sql", SqlTaskFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, status, taskType, pagination.Limit, pagination.GetOffset())
+	if err != nil {
+		errr := errs.InternalServerError("Failed to fetch tasks: ", err.Error())
+		return nil, &errr
+	}
+
+	tasks, err := pgx.CollectRows(rows, pgx.RowToStructByName[models.Task])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to scan tasks: ", err.Error())
+		return nil, &errr
+	}
+
+	return tasks, nil
+}
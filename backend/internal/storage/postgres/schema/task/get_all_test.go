@@ -0,0 +1,36 @@
+package task
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/testutil"
+	"skillspark/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var defaultPagination = utils.Pagination{Page: 1, Limit: 100}
+
+func TestGetAllTasks(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewTaskRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	CreateTestTask(t, ctx, testDB, "capture_payment", 3)
+	CreateTestTask(t, ctx, testDB, "send_notification", 3)
+
+	all, err := repo.GetAllTasks(ctx, "", "", defaultPagination)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	captures, err := repo.GetAllTasks(ctx, "pending", "capture_payment", defaultPagination)
+	require.NoError(t, err)
+	require.Len(t, captures, 1)
+	assert.Equal(t, "capture_payment", captures[0].TaskType)
+
+	dead, err := repo.GetAllTasks(ctx, "dead", "", defaultPagination)
+	require.NoError(t, err)
+	assert.Empty(t, dead)
+}
@@ -0,0 +1,11 @@
+package task
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type TaskRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTaskRepository(db *pgxpool.Pool) *TaskRepository {
+	return &TaskRepository{db: db}
+}
@@ -0,0 +1,73 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// RequeueDeadTask gives a dead-lettered task a fresh set of attempts, due immediately
+func (r *TaskRepository) RequeueDeadTask(ctx context.Context, id uuid.UUID) (*models.Task, error) {
+	lockQuery, err := schema.ReadSQLBaseScript("lock_by_id.sql", SqlTaskFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	requeueQuery, err := schema.ReadSQLBaseScript("requeue.sql", SqlTaskFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to begin transaction: ", err.Error())
+		return nil, &errr
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var status models.TaskStatus
+	if err = tx.QueryRow(ctx, lockQuery, id).Scan(&status); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			errr := errs.NotFound("Task", "id", id)
+			return nil, &errr
+		}
+		errr := errs.InternalServerError("Failed to fetch task: ", err.Error())
+		return nil, &errr
+	}
+
+	if status != models.TaskStatusDead {
+		_ = tx.Rollback(ctx)
+		errr := errs.BadRequest("Only dead tasks can be retried")
+		return nil, &errr
+	}
+
+	rows, err := tx.Query(ctx, requeueQuery, id)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to requeue task: ", err.Error())
+		return nil, &errr
+	}
+
+	task, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.Task])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to requeue task: ", err.Error())
+		return nil, &errr
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		errr := errs.InternalServerError("Failed to commit transaction: ", err.Error())
+		return nil, &errr
+	}
+
+	return &task, nil
+}
@@ -0,0 +1,69 @@
+package task
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequeueDeadTask(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewTaskRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	task := CreateTestTask(t, ctx, testDB, "capture_payment", 1)
+	_, err := repo.ClaimTasks(ctx, 1, time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, repo.DeadLetterTask(ctx, task.ID, 1, "card declined"))
+
+	requeued, err := repo.RequeueDeadTask(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.TaskStatusPending, requeued.Status)
+	assert.Equal(t, 0, requeued.Attempts)
+
+	claimed, err := repo.ClaimTasks(ctx, 1, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, task.ID, claimed[0].ID)
+}
+
+func TestRequeueDeadTask_NotDead(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewTaskRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	task := CreateTestTask(t, ctx, testDB, "capture_payment", 3)
+
+	requeued, err := repo.RequeueDeadTask(ctx, task.ID)
+	require.Error(t, err)
+	assert.Nil(t, requeued)
+
+	httpErr, ok := err.(*errs.HTTPError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+func TestRequeueDeadTask_NotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewTaskRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	requeued, err := repo.RequeueDeadTask(ctx, uuid.New())
+	require.Error(t, err)
+	assert.Nil(t, requeued)
+
+	httpErr, ok := err.(*errs.HTTPError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, httpErr.Code)
+}
@@ -0,0 +1,13 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RetryTaskLater returns a failed task to the queue to be claimed again after runAfter
+func (r *TaskRepository) RetryTaskLater(ctx context.Context, id uuid.UUID, attempt int, runAfter time.Time, lastError string) error {
+	return r.updateClaimedTask(ctx, "retry_later.sql", id, attempt, runAfter, lastError)
+}
@@ -0,0 +1,39 @@
+package task
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryTaskLater(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewTaskRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	task := CreateTestTask(t, ctx, testDB, "capture_payment", 3)
+	_, err := repo.ClaimTasks(ctx, 1, time.Minute)
+	require.NoError(t, err)
+
+	runAfter := time.Now().Add(30 * time.Second)
+	require.NoError(t, repo.RetryTaskLater(ctx, task.ID, 1, runAfter, "card declined"))
+
+	// not due yet, so not claimable
+	claimed, err := repo.ClaimTasks(ctx, 1, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	tasks, err := repo.GetAllTasks(ctx, string(models.TaskStatusPending), "capture_payment", defaultPagination)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, 1, tasks[0].Attempts)
+	require.NotNil(t, tasks[0].LastError)
+	assert.Equal(t, "card declined", *tasks[0].LastError)
+	assert.WithinDuration(t, runAfter, tasks[0].RunAfter, time.Second)
+}
@@ -0,0 +1,14 @@
+UPDATE task
+SET status = 'running',
+    attempts = attempts + 1,
+    locked_until = NOW() + make_interval(secs => $2)
+WHERE id IN (
+    SELECT id
+    FROM task
+    WHERE (status = 'pending' AND run_after <= NOW())
+       OR (status = 'running' AND locked_until < NOW() AND attempts < max_attempts)
+    ORDER BY run_after
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, task_type, payload, dedup_key, status, attempts, max_attempts, run_after, locked_until, last_error, completed_at, created_at, updated_at;
@@ -0,0 +1,7 @@
+UPDATE task
+SET status = 'succeeded',
+    locked_until = NULL,
+    completed_at = NOW()
+WHERE id = $1
+  AND status = 'running'
+  AND attempts = $2;
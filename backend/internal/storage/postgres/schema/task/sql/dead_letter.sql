@@ -0,0 +1,7 @@
+UPDATE task
+SET status = 'dead',
+    locked_until = NULL,
+    last_error = $3
+WHERE id = $1
+  AND status = 'running'
+  AND attempts = $2;
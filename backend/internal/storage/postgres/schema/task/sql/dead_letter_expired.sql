@@ -0,0 +1,7 @@
+UPDATE task
+SET status = 'dead',
+    locked_until = NULL,
+    last_error = 'claim expired on the final attempt; the worker likely crashed or timed out'
+WHERE status = 'running'
+  AND locked_until < NOW()
+  AND attempts >= max_attempts;
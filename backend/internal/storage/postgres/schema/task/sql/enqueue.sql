@@ -0,0 +1,4 @@
+INSERT INTO task (task_type, payload, dedup_key, max_attempts, run_after)
+VALUES ($1, $2::jsonb, $3, $4, COALESCE($5, NOW()))
+ON CONFLICT (dedup_key) DO NOTHING
+RETURNING id, task_type, payload, dedup_key, status, attempts, max_attempts, run_after, locked_until, last_error, completed_at, created_at, updated_at;
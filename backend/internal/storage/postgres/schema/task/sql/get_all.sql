@@ -0,0 +1,6 @@
+SELECT id, task_type, payload, dedup_key, status, attempts, max_attempts, run_after, locked_until, last_error, completed_at, created_at, updated_at
+FROM task
+WHERE ($1 = '' OR status::text = $1)
+  AND ($2 = '' OR task_type = $2)
+ORDER BY updated_at DESC, id
+LIMIT $3 OFFSET $4;
@@ -0,0 +1,4 @@
+SELECT status
+FROM task
+WHERE id = $1
+FOR UPDATE;
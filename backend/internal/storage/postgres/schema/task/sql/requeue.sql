@@ -0,0 +1,7 @@
+UPDATE task
+SET status = 'pending',
+    attempts = 0,
+    run_after = NOW(),
+    locked_until = NULL
+WHERE id = $1
+RETURNING id, task_type, payload, dedup_key, status, attempts, max_attempts, run_after, locked_until, last_error, completed_at, created_at, updated_at;
@@ -0,0 +1,8 @@
+UPDATE task
+SET status = 'pending',
+    locked_until = NULL,
+    run_after = $3,
+    last_error = $4
+WHERE id = $1
+  AND status = 'running'
+  AND attempts = $2;
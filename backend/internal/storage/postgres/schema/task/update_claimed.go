@@ -0,0 +1,32 @@
+package task
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+)
+
+// updateClaimedTask applies an outcome to a task only while the caller still owns the
+// claim, identified by the attempt number it was claimed with. If the claim expired and
+// the task was taken by another worker, the update is rejected with a conflict.
+func (r *TaskRepository) updateClaimedTask(ctx context.Context, file string, id uuid.UUID, attempt int, args ...any) error {
+	query, err := schema.ReadSQLBaseScript(file, SqlTaskFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return &errr
+	}
+
+	tag, err := r.db.Exec(ctx, query, append([]any{id, attempt}, args...)...)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to update task: ", err.Error())
+		return &errr
+	}
+	if tag.RowsAffected() == 0 {
+		errr := errs.Conflict("Task is no longer claimed by this attempt", "id", id)
+		return &errr
+	}
+
+	return nil
+}
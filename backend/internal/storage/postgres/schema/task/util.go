@@ -0,0 +1,44 @@
+package task
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"skillspark/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed sql/*.sql
+var SqlTaskFiles embed.FS
+
+func CreateTestTask(
+	t *testing.T,
+	ctx context.Context,
+	db *pgxpool.Pool,
+	taskType string,
+	maxAttempts int,
+) *models.Task {
+	t.Helper()
+
+	repo := NewTaskRepository(db)
+
+	// due an hour ago so it is claimable straight away
+	runAfter := time.Now().Add(-time.Hour)
+	dedupKey := "test:" + uuid.NewString()
+	task, err := repo.EnqueueTask(ctx, &models.EnqueueTaskData{
+		TaskType:    taskType,
+		Payload:     json.RawMessage(`{"test": true}`),
+		DedupKey:    &dedupKey,
+		MaxAttempts: maxAttempts,
+		RunAfter:    &runAfter,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, task)
+
+	return task
+}
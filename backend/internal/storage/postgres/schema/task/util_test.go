@@ -0,0 +1,14 @@
+package task
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+)
+
+func Test_CreateTestTask(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	t.Parallel()
+	CreateTestTask(t, ctx, testDB, "capture_payment", 3)
+}
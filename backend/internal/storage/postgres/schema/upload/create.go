@@ -0,0 +1,65 @@
+package upload
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateUploadSession records a pending upload, after checking that what it will be
+// attached to exists so a client can't stage uploads for nothing
+func (r *UploadRepository) CreateUploadSession(ctx context.Context, data *models.CreateUploadSessionData) (*models.UploadSession, error) {
+	existsQuery, err := schema.ReadSQLBaseScript("target_exists.sql", SqlUploadFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	query, err := schema.ReadSQLBaseScript("create.sql", SqlUploadFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, existsQuery, data.Target, data.TargetID).Scan(&exists); err != nil {
+		errr := errs.InternalServerError("Failed to check upload target: ", err.Error())
+		return nil, &errr
+	}
+	if !exists {
+		errr := errs.NotFound(targetResource(data.Target), "id", data.TargetID)
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, data.Target, data.TargetID, data.StagingKey, data.ContentType, data.ContentLength, data.ExpiresAt)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to create upload session: ", err.Error())
+		return nil, &errr
+	}
+
+	session, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.UploadSession])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to create upload session: ", err.Error())
+		return nil, &errr
+	}
+
+	return &session, nil
+}
+
+func targetResource(target models.UploadTarget) string {
+	switch target {
+	case models.UploadTargetEventHeader:
+		return "Event"
+	case models.UploadTargetOrganizationPicture:
+		return "Organization"
+	case models.UploadTargetGuardianAvatar:
+		return "Guardian"
+	case models.UploadTargetReviewPhoto:
+		return "Review"
+	default:
+		return "Upload target"
+	}
+}
@@ -0,0 +1,66 @@
+package upload
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/guardian"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateUploadSession(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewUploadRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	expiresAt := time.Now().Add(time.Hour)
+
+	session, err := repo.CreateUploadSession(ctx, &models.CreateUploadSessionData{
+		Target:        models.UploadTargetGuardianAvatar,
+		TargetID:      g.ID,
+		StagingKey:    "uploads/" + uuid.NewString(),
+		ContentType:   "image/jpeg",
+		ContentLength: 2048,
+		ExpiresAt:     expiresAt,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, session)
+
+	assert.Equal(t, models.UploadTargetGuardianAvatar, session.Target)
+	assert.Equal(t, g.ID, session.TargetID)
+	assert.Equal(t, models.UploadSessionStatusPending, session.Status)
+	assert.Equal(t, int64(2048), session.ContentLength)
+	assert.WithinDuration(t, expiresAt, session.ExpiresAt, time.Second)
+	assert.Nil(t, session.FinalizedKey)
+}
+
+func TestCreateUploadSession_TargetNotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewUploadRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	session, err := repo.CreateUploadSession(ctx, &models.CreateUploadSessionData{
+		Target:        models.UploadTargetReviewPhoto,
+		TargetID:      uuid.New(),
+		StagingKey:    "uploads/" + uuid.NewString(),
+		ContentType:   "image/jpeg",
+		ContentLength: 2048,
+		ExpiresAt:     time.Now().Add(time.Hour),
+	})
+	require.Error(t, err)
+	assert.Nil(t, session)
+
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusNotFound, httpErr.Code)
+}
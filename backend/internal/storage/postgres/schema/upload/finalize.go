@@ -0,0 +1,86 @@
+package upload
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var attachFiles = map[models.UploadTarget]string{
+	models.UploadTargetEventHeader:         "attach_event_header.sql",
+	models.UploadTargetOrganizationPicture: "attach_organization_picture.sql",
+	models.UploadTargetGuardianAvatar:      "attach_guardian_avatar.sql",
+	models.UploadTargetReviewPhoto:         "attach_review_photo.sql",
+}
+
+// FinalizeUploadSession marks a pending session finalized and saves key on its target
+// in one transaction, reporting the key it replaced on the session. A session that was already finalized or has expired is a conflict,
+// so two finalize calls for one upload can't both attach it.
+func (r *UploadRepository) FinalizeUploadSession(ctx context.Context, id uuid.UUID, key string) (*models.UploadSession, error) {
+	query, err := schema.ReadSQLBaseScript("finalize.sql", SqlUploadFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to begin transaction: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := tx.Query(ctx, query, id, key)
+	if err != nil {
+		_ = tx.Rollback(ctx)
+		errr := errs.InternalServerError("Failed to finalize upload session: ", err.Error())
+		return nil, &errr
+	}
+
+	session, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.UploadSession])
+	if err != nil {
+		_ = tx.Rollback(ctx)
+		if errors.Is(err, pgx.ErrNoRows) {
+			errr := errs.Conflict("Upload session is not pending", "id", id)
+			return nil, &errr
+		}
+		errr := errs.InternalServerError("Failed to finalize upload session: ", err.Error())
+		return nil, &errr
+	}
+
+	attachFile, ok := attachFiles[session.Target]
+	if !ok {
+		_ = tx.Rollback(ctx)
+		errr := errs.InternalServerError("Failed to attach upload: ", string(session.Target))
+		return nil, &errr
+	}
+
+	attachQuery, err := schema.ReadSQLBaseScript(attachFile, SqlUploadFiles)
+	if err != nil {
+		_ = tx.Rollback(ctx)
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	if err := tx.QueryRow(ctx, attachQuery, session.TargetID, key).Scan(&session.ReplacedKey); err != nil {
+		_ = tx.Rollback(ctx)
+		if errors.Is(err, pgx.ErrNoRows) {
+			// the target was deleted after the session was created
+			errr := errs.NotFound(targetResource(session.Target), "id", session.TargetID)
+			return nil, &errr
+		}
+		errr := errs.InternalServerError("Failed to attach upload: ", err.Error())
+		return nil, &errr
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		errr := errs.InternalServerError("Failed to commit transaction: ", err.Error())
+		return nil, &errr
+	}
+
+	return &session, nil
+}
@@ -0,0 +1,108 @@
+package upload
+
+import (
+	"context"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/event"
+	"skillspark/internal/storage/postgres/schema/review"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinalizeUploadSession(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewUploadRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	created := CreateTestUploadSession(t, ctx, testDB, time.Now().Add(time.Hour))
+	key := "events/header-image/" + created.TargetID.String() + "/" + uuid.NewString() + "/original.png"
+
+	session, err := repo.FinalizeUploadSession(ctx, created.ID, key)
+	require.NoError(t, err)
+	assert.Equal(t, models.UploadSessionStatusFinalized, session.Status)
+	require.NotNil(t, session.FinalizedKey)
+	assert.Equal(t, key, *session.FinalizedKey)
+	assert.NotNil(t, session.FinalizedAt)
+
+	e, err := event.NewEventRepository(testDB).GetEventByID(ctx, created.TargetID, "en-US")
+	require.NoError(t, err)
+	require.NotNil(t, e.HeaderImageS3Key)
+	assert.Equal(t, key, *e.HeaderImageS3Key)
+	assert.Nil(t, session.ReplacedKey)
+
+	// a second finalize is rejected rather than attaching the upload again
+	_, err = repo.FinalizeUploadSession(ctx, created.ID, key)
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusConflict, httpErr.Code)
+
+	// a later upload for the same event reports the key it replaced
+	next, err := repo.CreateUploadSession(ctx, &models.CreateUploadSessionData{
+		Target:        models.UploadTargetEventHeader,
+		TargetID:      created.TargetID,
+		StagingKey:    "uploads/" + uuid.NewString(),
+		ContentType:   "image/png",
+		ContentLength: 4096,
+		ExpiresAt:     time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	nextKey := "events/header-image/" + created.TargetID.String() + "/" + uuid.NewString() + "/original.png"
+	session, err = repo.FinalizeUploadSession(ctx, next.ID, nextKey)
+	require.NoError(t, err)
+	require.NotNil(t, session.ReplacedKey)
+	assert.Equal(t, key, *session.ReplacedKey)
+}
+
+func TestFinalizeUploadSession_Expired(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewUploadRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	created := CreateTestUploadSession(t, ctx, testDB, time.Now().Add(-time.Minute))
+
+	_, err := repo.FinalizeUploadSession(ctx, created.ID, "events/header-image/x/original.png")
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusConflict, httpErr.Code)
+
+	e, err := event.NewEventRepository(testDB).GetEventByID(ctx, created.TargetID, "en-US")
+	require.NoError(t, err)
+	assert.Nil(t, e.HeaderImageS3Key)
+}
+
+func TestFinalizeUploadSession_ReviewPhoto(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewUploadRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	r := review.CreateTestReview(t, ctx, testDB)
+	created, err := repo.CreateUploadSession(ctx, &models.CreateUploadSessionData{
+		Target:        models.UploadTargetReviewPhoto,
+		TargetID:      r.ID,
+		StagingKey:    "uploads/" + uuid.NewString(),
+		ContentType:   "image/webp",
+		ContentLength: 4096,
+		ExpiresAt:     time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	key := "reviews/photo/" + r.ID.String() + "/" + uuid.NewString() + "/original.jpg"
+	_, err = repo.FinalizeUploadSession(ctx, created.ID, key)
+	require.NoError(t, err)
+
+	var photoKey *string
+	require.NoError(t, testDB.QueryRow(ctx, `SELECT photo_s3_key FROM review WHERE id = $1`, r.ID).Scan(&photoKey))
+	require.NotNil(t, photoKey)
+	assert.Equal(t, key, *photoKey)
+}
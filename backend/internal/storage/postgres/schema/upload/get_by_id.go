@@ -0,0 +1,38 @@
+package upload
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *UploadRepository) GetUploadSessionByID(ctx context.Context, id uuid.UUID) (*models.UploadSession, error) {
+	query, err := schema.ReadSQLBaseScript("get_by_id.sql", SqlUploadFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to get upload session: ", err.Error())
+		return nil, &errr
+	}
+
+	session, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.UploadSession])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			errr := errs.NotFound("Upload session", "id", id)
+			return nil, &errr
+		}
+		errr := errs.InternalServerError("Failed to get upload session: ", err.Error())
+		return nil, &errr
+	}
+
+	return &session, nil
+}
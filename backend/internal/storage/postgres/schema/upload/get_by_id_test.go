@@ -0,0 +1,29 @@
+package upload
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUploadSessionByID(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewUploadRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	created := CreateTestUploadSession(t, ctx, testDB, time.Now().Add(time.Hour))
+
+	session, err := repo.GetUploadSessionByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, session.ID)
+	assert.Equal(t, created.StagingKey, session.StagingKey)
+
+	_, err = repo.GetUploadSessionByID(ctx, uuid.New())
+	require.Error(t, err)
+}
@@ -0,0 +1,35 @@
+package upload
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetUploadSessionsToCleanUp returns sessions whose staging object is no longer needed:
+// finalized ones, whose image has been copied to its target, and pending ones that
+// expired without being finalized
+func (r *UploadRepository) GetUploadSessionsToCleanUp(ctx context.Context, limit int) ([]models.UploadSession, error) {
+	query, err := schema.ReadSQLBaseScript("get_to_clean_up.sql", SqlUploadFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to get upload sessions to clean up: ", err.Error())
+		return nil, &errr
+	}
+
+	sessions, err := pgx.CollectRows(rows, pgx.RowToStructByName[models.UploadSession])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to scan upload sessions: ", err.Error())
+		return nil, &errr
+	}
+
+	return sessions, nil
+}
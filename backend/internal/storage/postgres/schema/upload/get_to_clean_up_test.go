@@ -0,0 +1,37 @@
+package upload
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUploadSessionsToCleanUp(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewUploadRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	expired := CreateTestUploadSession(t, ctx, testDB, time.Now().Add(-time.Minute))
+	pending := CreateTestUploadSession(t, ctx, testDB, time.Now().Add(time.Hour))
+	finalized := CreateTestUploadSession(t, ctx, testDB, time.Now().Add(time.Hour))
+	_, err := repo.FinalizeUploadSession(ctx, finalized.ID, "events/header-image/x/original.png")
+	require.NoError(t, err)
+
+	sessions, err := repo.GetUploadSessionsToCleanUp(ctx, 10)
+	require.NoError(t, err)
+
+	var ids []uuid.UUID
+	for _, session := range sessions {
+		ids = append(ids, session.ID)
+	}
+	assert.Contains(t, ids, expired.ID)
+	assert.Contains(t, ids, finalized.ID)
+	// an upload that can still be finalized is left alone
+	assert.NotContains(t, ids, pending.ID)
+}
@@ -0,0 +1,31 @@
+package upload
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+)
+
+// MarkUploadSessionCleanedUp records that a session's staging object was deleted. A
+// pending session becomes expired, so it can no longer be finalized.
+func (r *UploadRepository) MarkUploadSessionCleanedUp(ctx context.Context, id uuid.UUID) error {
+	query, err := schema.ReadSQLBaseScript("mark_cleaned_up.sql", SqlUploadFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return &errr
+	}
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to mark upload session cleaned up: ", err.Error())
+		return &errr
+	}
+	if result.RowsAffected() == 0 {
+		errr := errs.NotFound("Upload session", "id", id)
+		return &errr
+	}
+
+	return nil
+}
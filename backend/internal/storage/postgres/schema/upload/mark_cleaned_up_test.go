@@ -0,0 +1,38 @@
+package upload
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkUploadSessionCleanedUp(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewUploadRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	expired := CreateTestUploadSession(t, ctx, testDB, time.Now().Add(-time.Minute))
+
+	require.NoError(t, repo.MarkUploadSessionCleanedUp(ctx, expired.ID))
+
+	session, err := repo.GetUploadSessionByID(ctx, expired.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.UploadSessionStatusExpired, session.Status)
+	assert.NotNil(t, session.CleanedUpAt)
+
+	sessions, err := repo.GetUploadSessionsToCleanUp(ctx, 10)
+	require.NoError(t, err)
+	for _, s := range sessions {
+		assert.NotEqual(t, expired.ID, s.ID)
+	}
+
+	// a session that can still be finalized is never cleaned up
+	pending := CreateTestUploadSession(t, ctx, testDB, time.Now().Add(time.Hour))
+	require.Error(t, repo.MarkUploadSessionCleanedUp(ctx, pending.ID))
+}
@@ -0,0 +1,11 @@
+package upload
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type UploadRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewUploadRepository(db *pgxpool.Pool) *UploadRepository {
+	return &UploadRepository{db: db}
+}
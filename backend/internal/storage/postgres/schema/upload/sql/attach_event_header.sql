@@ -0,0 +1,7 @@
+-- returns the key the upload replaces, so its objects can be deleted
+UPDATE event e
+SET header_image_s3_key = $2,
+    updated_at = NOW()
+FROM (SELECT id, header_image_s3_key FROM event WHERE id = $1 FOR UPDATE) previous
+WHERE e.id = previous.id
+RETURNING previous.header_image_s3_key;
@@ -0,0 +1,13 @@
+-- returns the key the upload replaces, so its objects can be deleted
+UPDATE "user" u
+SET profile_picture_s3_key = $2,
+    updated_at = NOW()
+FROM (
+    SELECT u.id, u.profile_picture_s3_key
+    FROM "user" u
+    JOIN guardian g ON g.user_id = u.id
+    WHERE g.id = $1
+    FOR UPDATE OF u
+) previous
+WHERE u.id = previous.id
+RETURNING previous.profile_picture_s3_key;
@@ -0,0 +1,7 @@
+-- returns the key the upload replaces, so its objects can be deleted
+UPDATE organization o
+SET pfp_s3_key = $2,
+    updated_at = NOW()
+FROM (SELECT id, pfp_s3_key FROM organization WHERE id = $1 FOR UPDATE) previous
+WHERE o.id = previous.id
+RETURNING previous.pfp_s3_key;
@@ -0,0 +1,7 @@
+-- returns the key the upload replaces, so its objects can be deleted
+UPDATE review r
+SET photo_s3_key = $2,
+    updated_at = NOW()
+FROM (SELECT id, photo_s3_key FROM review WHERE id = $1 FOR UPDATE) previous
+WHERE r.id = previous.id
+RETURNING previous.photo_s3_key;
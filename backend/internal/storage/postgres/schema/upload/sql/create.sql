@@ -0,0 +1,3 @@
+INSERT INTO upload_session (target, target_id, staging_key, content_type, content_length, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, target, target_id, staging_key, content_type, content_length, status, expires_at, finalized_key, finalized_at, cleaned_up_at, created_at;
@@ -0,0 +1,8 @@
+UPDATE upload_session
+SET status = 'finalized',
+    finalized_key = $2,
+    finalized_at = NOW()
+WHERE id = $1
+  AND status = 'pending'
+  AND expires_at > NOW()
+RETURNING id, target, target_id, staging_key, content_type, content_length, status, expires_at, finalized_key, finalized_at, cleaned_up_at, created_at;
@@ -0,0 +1,3 @@
+SELECT id, target, target_id, staging_key, content_type, content_length, status, expires_at, finalized_key, finalized_at, cleaned_up_at, created_at
+FROM upload_session
+WHERE id = $1;
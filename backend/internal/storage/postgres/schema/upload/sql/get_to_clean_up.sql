@@ -0,0 +1,6 @@
+SELECT id, target, target_id, staging_key, content_type, content_length, status, expires_at, finalized_key, finalized_at, cleaned_up_at, created_at
+FROM upload_session
+WHERE cleaned_up_at IS NULL
+  AND (status = 'finalized' OR expires_at < NOW())
+ORDER BY expires_at
+LIMIT $1;
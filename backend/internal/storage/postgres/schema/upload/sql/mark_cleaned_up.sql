@@ -0,0 +1,6 @@
+UPDATE upload_session
+SET cleaned_up_at = NOW(),
+    status = CASE WHEN status = 'pending' THEN 'expired'::upload_session_status ELSE status END
+WHERE id = $1
+  AND cleaned_up_at IS NULL
+  AND (status = 'finalized' OR expires_at < NOW());
@@ -0,0 +1,6 @@
+SELECT CASE $1::upload_target
+    WHEN 'event_header' THEN EXISTS (SELECT 1 FROM event WHERE id = $2)
+    WHEN 'organization_picture' THEN EXISTS (SELECT 1 FROM organization WHERE id = $2)
+    WHEN 'guardian_avatar' THEN EXISTS (SELECT 1 FROM guardian WHERE id = $2)
+    WHEN 'review_photo' THEN EXISTS (SELECT 1 FROM review WHERE id = $2)
+END;
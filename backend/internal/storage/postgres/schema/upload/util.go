@@ -0,0 +1,44 @@
+package upload
+
+import (
+	"context"
+	"embed"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/event"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed sql/*.sql
+var SqlUploadFiles embed.FS
+
+// CreateTestUploadSession creates a pending session for a new event's header image that
+// expires at expiresAt
+func CreateTestUploadSession(
+	t *testing.T,
+	ctx context.Context,
+	db *pgxpool.Pool,
+	expiresAt time.Time,
+) *models.UploadSession {
+	t.Helper()
+
+	repo := NewUploadRepository(db)
+	e := event.CreateTestEvent(t, ctx, db)
+
+	session, err := repo.CreateUploadSession(ctx, &models.CreateUploadSessionData{
+		Target:        models.UploadTargetEventHeader,
+		TargetID:      e.ID,
+		StagingKey:    "uploads/" + uuid.NewString(),
+		ContentType:   "image/png",
+		ContentLength: 1024,
+		ExpiresAt:     expiresAt,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, session)
+
+	return session
+}
@@ -0,0 +1,115 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ApplyCreditToRegistration spends up to maxAmount of the guardian's wallet credit on a
+// registration's payment and returns how much was applied. Credit is applied once per
+// registration: later calls return the amount applied the first time, so a retried
+// payment charges the same remainder however the balance has changed since.
+func (r *WalletRepository) ApplyCreditToRegistration(ctx context.Context, registrationID uuid.UUID, guardianID uuid.UUID, currency string, maxAmount int) (int, error) {
+	lockQuery, err := schema.ReadSQLBaseScript("lock_registered_registration.sql", SqlWalletFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return 0, &errr
+	}
+
+	appliedQuery, err := schema.ReadSQLBaseScript("get_payment_applied.sql", SqlWalletFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return 0, &errr
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to begin transaction: ", err.Error())
+		return 0, &errr
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var lockedID uuid.UUID
+	if err = tx.QueryRow(ctx, lockQuery, registrationID).Scan(&lockedID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			errr := errs.BadRequest("Registration is cancelled or does not exist")
+			return 0, &errr
+		}
+		errr := errs.InternalServerError("Failed to lock registration: ", err.Error())
+		return 0, &errr
+	}
+
+	// a retry gets back the credit applied the first time
+	var applied int
+	err = tx.QueryRow(ctx, appliedQuery, registrationID).Scan(&applied)
+	if errors.Is(err, pgx.ErrNoRows) {
+		applied, err = applyAvailableCredit(ctx, tx, registrationID, guardianID, currency, maxAmount)
+		if err != nil {
+			return 0, err
+		}
+	} else if err != nil {
+		errr := errs.InternalServerError("Failed to fetch applied wallet credit: ", err.Error())
+		return 0, &errr
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		errr := errs.InternalServerError("Failed to commit transaction: ", err.Error())
+		return 0, &errr
+	}
+
+	return applied, nil
+}
+
+// applyAvailableCredit posts as much of the guardian's balance as covers maxAmount to
+// the registration inside tx.
+func applyAvailableCredit(ctx context.Context, tx pgx.Tx, registrationID uuid.UUID, guardianID uuid.UUID, currency string, maxAmount int) (int, error) {
+	balanceQuery, err := schema.ReadSQLBaseScript("get_account_balance.sql", SqlWalletFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return 0, &errr
+	}
+
+	guardian := models.WalletAccountRef{Type: models.WalletAccountTypeGuardian, GuardianID: &guardianID}
+	accountID, err := ensureAccount(ctx, tx, guardian, currency)
+	if err != nil {
+		return 0, err
+	}
+
+	// locks the guardian's account, so the balance holds until the credit is posted
+	var balance int
+	if err := tx.QueryRow(ctx, balanceQuery, accountID).Scan(&balance); err != nil {
+		errr := errs.InternalServerError("Failed to fetch wallet balance: ", err.Error())
+		return 0, &errr
+	}
+
+	applied := min(balance, maxAmount)
+	if applied == 0 {
+		return 0, nil
+	}
+
+	description := "Applied to registration payment"
+	_, err = postTransaction(ctx, tx, &models.CreateWalletTransactionData{
+		TransactionType: models.WalletTransactionTypePaymentApplied,
+		Amount:          applied,
+		Currency:        currency,
+		From:            guardian,
+		To:              models.WalletAccountRef{Type: models.WalletAccountTypePayment},
+		Description:     &description,
+		RegistrationID:  &registrationID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,84 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/registration"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyCreditToRegistration(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	guardianID := reg.GuardianID
+	CreateTestGoodwillCredit(t, ctx, testDB, guardianID, 3000)
+
+	applied, err := repo.ApplyCreditToRegistration(ctx, reg.ID, guardianID, "thb", 10000)
+	require.NoError(t, err)
+	assert.Equal(t, 3000, applied)
+
+	balance, err := repo.GetBalance(ctx, guardianID, "thb")
+	require.NoError(t, err)
+	assert.Equal(t, 0, balance)
+
+	// a retry gets the first attempt's credit, even though the balance has grown since
+	CreateTestGoodwillCredit(t, ctx, testDB, guardianID, 5000)
+	applied, err = repo.ApplyCreditToRegistration(ctx, reg.ID, guardianID, "thb", 10000)
+	require.NoError(t, err)
+	assert.Equal(t, 3000, applied)
+
+	balance, err = repo.GetBalance(ctx, guardianID, "thb")
+	require.NoError(t, err)
+	assert.Equal(t, 5000, balance)
+}
+
+func TestApplyCreditToRegistration_CappedAtPrice(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	CreateTestGoodwillCredit(t, ctx, testDB, reg.GuardianID, 8000)
+
+	applied, err := repo.ApplyCreditToRegistration(ctx, reg.ID, reg.GuardianID, "thb", 5000)
+	require.NoError(t, err)
+	assert.Equal(t, 5000, applied)
+
+	balance, err := repo.GetBalance(ctx, reg.GuardianID, "thb")
+	require.NoError(t, err)
+	assert.Equal(t, 3000, balance)
+}
+
+func TestApplyCreditToRegistration_CancelledRegistration(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	CreateTestGoodwillCredit(t, ctx, testDB, reg.GuardianID, 1000)
+
+	_, err := registration.NewRegistrationRepository(testDB).CancelRegistration(ctx, &models.CancelRegistrationInput{ID: reg.ID})
+	require.NoError(t, err)
+
+	applied, err := repo.ApplyCreditToRegistration(ctx, reg.ID, reg.GuardianID, "thb", 1000)
+	require.Error(t, err)
+	assert.Equal(t, 0, applied)
+
+	_, err = repo.ApplyCreditToRegistration(ctx, uuid.New(), reg.GuardianID, "thb", 1000)
+	require.Error(t, err)
+
+	balance, err := repo.GetBalance(ctx, reg.GuardianID, "thb")
+	require.NoError(t, err)
+	assert.Equal(t, 1000, balance)
+}
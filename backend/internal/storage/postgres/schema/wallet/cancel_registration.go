@@ -0,0 +1,58 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/registration"
+)
+
+// CancelRegistrationWithCredit cancels a registration and, in the same transaction, restores
+// any wallet credit applied to its payment and credits refundAmount to the guardian's wallet.
+// A registration that is already cancelled is rejected before anything is credited, so
+// concurrent or retried cancellations pay back once.
+func (r *WalletRepository) CancelRegistrationWithCredit(ctx context.Context, input *models.CancelRegistrationInput, refundAmount int) (*models.CancelRegistrationOutput, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to begin transaction: ", err.Error())
+		return nil, &errr
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	output, err := registration.CancelRegistrationTx(ctx, tx, input)
+	if err != nil {
+		return nil, err
+	}
+	cancelled := output.Body.Registration
+
+	if _, err = reverseAppliedCredit(ctx, tx, cancelled.ID, cancelled.GuardianID); err != nil {
+		return nil, err
+	}
+
+	if refundAmount > 0 {
+		description := "Refund for cancelled registration"
+		_, err = postTransaction(ctx, tx, &models.CreateWalletTransactionData{
+			TransactionType: models.WalletTransactionTypeRefundCredit,
+			Amount:          refundAmount,
+			Currency:        cancelled.Currency,
+			From:            models.WalletAccountRef{Type: models.WalletAccountTypeRefund},
+			To:              models.WalletAccountRef{Type: models.WalletAccountTypeGuardian, GuardianID: &cancelled.GuardianID},
+			Description:     &description,
+			RegistrationID:  &cancelled.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		errr := errs.InternalServerError("Failed to commit transaction: ", err.Error())
+		return nil, &errr
+	}
+
+	return output, nil
+}
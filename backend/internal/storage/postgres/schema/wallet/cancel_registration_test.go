@@ -0,0 +1,94 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/registration"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelRegistrationWithCredit(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	guardianID := reg.GuardianID
+	CreateTestGoodwillCredit(t, ctx, testDB, guardianID, 800)
+
+	_, err := repo.CreateTransaction(ctx, &models.CreateWalletTransactionData{
+		TransactionType: models.WalletTransactionTypePaymentApplied,
+		Amount:          800,
+		Currency:        "thb",
+		From:            models.WalletAccountRef{Type: models.WalletAccountTypeGuardian, GuardianID: &guardianID},
+		To:              models.WalletAccountRef{Type: models.WalletAccountTypePayment},
+		RegistrationID:  &reg.ID,
+	})
+	require.NoError(t, err)
+
+	input := &models.CancelRegistrationInput{AcceptLanguage: "en-US", ID: reg.ID}
+	cancelled, err := repo.CancelRegistrationWithCredit(ctx, input, reg.TotalAmount)
+	require.NoError(t, err)
+	assert.Equal(t, models.RegistrationStatusCancelled, cancelled.Body.Registration.Status)
+
+	// the applied credit comes back in its own currency and the refund in the payment's
+	applied, err := repo.GetBalance(ctx, guardianID, "thb")
+	require.NoError(t, err)
+	assert.Equal(t, 800, applied)
+
+	refunded, err := repo.GetBalance(ctx, guardianID, reg.Currency)
+	require.NoError(t, err)
+	assert.Equal(t, reg.TotalAmount, refunded)
+
+	// a second cancellation credits nothing
+	_, err = repo.CancelRegistrationWithCredit(ctx, input, reg.TotalAmount)
+	require.Error(t, err)
+
+	refunded, err = repo.GetBalance(ctx, guardianID, reg.Currency)
+	require.NoError(t, err)
+	assert.Equal(t, reg.TotalAmount, refunded)
+}
+
+func TestCancelRegistrationWithCredit_RegistrationNotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	cancelled, err := repo.CancelRegistrationWithCredit(ctx, &models.CancelRegistrationInput{ID: uuid.New()}, 1000)
+	require.Error(t, err)
+	assert.Nil(t, cancelled)
+}
+
+func TestRefundCredit_OncePerRegistration(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	refund := &models.CreateWalletTransactionData{
+		TransactionType: models.WalletTransactionTypeRefundCredit,
+		Amount:          1000,
+		Currency:        "thb",
+		From:            models.WalletAccountRef{Type: models.WalletAccountTypeRefund},
+		To:              models.WalletAccountRef{Type: models.WalletAccountTypeGuardian, GuardianID: &reg.GuardianID},
+		RegistrationID:  &reg.ID,
+	}
+
+	_, err := repo.CreateTransaction(ctx, refund)
+	require.NoError(t, err)
+
+	_, err = repo.CreateTransaction(ctx, refund)
+	require.Error(t, err)
+
+	balance, err := repo.GetBalance(ctx, reg.GuardianID, "thb")
+	require.NoError(t, err)
+	assert.Equal(t, 1000, balance)
+}
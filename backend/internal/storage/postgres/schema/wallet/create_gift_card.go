@@ -0,0 +1,69 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateGiftCard stores a paid gift card and books its value from the stripe account
+// into the gift card liability account.
+func (r *WalletRepository) CreateGiftCard(ctx context.Context, input *models.CreateGiftCardData) (*models.GiftCard, error) {
+	query, err := schema.ReadSQLBaseScript("create_gift_card.sql", SqlWalletFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to begin transaction: ", err.Error())
+		return nil, &errr
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	rows, err := tx.Query(ctx, query,
+		input.Code,
+		input.Amount,
+		input.Currency,
+		input.PurchaserGuardianID,
+		input.RecipientEmail,
+		input.StripePaymentIntentID,
+	)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to create gift card: ", err.Error())
+		return nil, &errr
+	}
+
+	giftCard, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.GiftCard])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to create gift card: ", err.Error())
+		return nil, &errr
+	}
+
+	_, err = postTransaction(ctx, tx, &models.CreateWalletTransactionData{
+		TransactionType: models.WalletTransactionTypeGiftCardPurchase,
+		Amount:          giftCard.Amount,
+		Currency:        giftCard.Currency,
+		From:            models.WalletAccountRef{Type: models.WalletAccountTypeStripe},
+		To:              models.WalletAccountRef{Type: models.WalletAccountTypeGiftCard},
+		GiftCardID:      &giftCard.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		errr := errs.InternalServerError("Failed to commit transaction: ", err.Error())
+		return nil, &errr
+	}
+
+	return &giftCard, nil
+}
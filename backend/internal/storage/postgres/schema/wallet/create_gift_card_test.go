@@ -0,0 +1,63 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/guardian"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateGiftCard(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	email := "friend@example.com"
+	pi := "pi_" + uuid.NewString()
+
+	giftCard, err := repo.CreateGiftCard(ctx, &models.CreateGiftCardData{
+		Code:                  uuid.NewString()[:12],
+		Amount:                2000,
+		Currency:              "thb",
+		PurchaserGuardianID:   &g.ID,
+		RecipientEmail:        &email,
+		StripePaymentIntentID: &pi,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, giftCard)
+	assert.Equal(t, 2000, giftCard.Amount)
+	assert.Equal(t, &g.ID, giftCard.PurchaserGuardianID)
+	assert.Equal(t, &email, giftCard.RecipientEmail)
+	assert.Nil(t, giftCard.RedeemedAt)
+
+	// purchasing a gift card does not credit the purchaser's wallet
+	balance, err := repo.GetBalance(ctx, g.ID, "thb")
+	require.NoError(t, err)
+	assert.Equal(t, 0, balance)
+}
+
+func TestCreateGiftCard_DuplicateCode(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	existing := CreateTestGiftCard(t, ctx, testDB, 500)
+
+	giftCard, err := repo.CreateGiftCard(ctx, &models.CreateGiftCardData{
+		Code:     existing.Code,
+		Amount:   500,
+		Currency: "thb",
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, giftCard)
+}
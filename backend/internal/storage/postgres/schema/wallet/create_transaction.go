@@ -0,0 +1,153 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *WalletRepository) CreateTransaction(ctx context.Context, input *models.CreateWalletTransactionData) (*models.WalletTransaction, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to begin transaction: ", err.Error())
+		return nil, &errr
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	transaction, err := postTransaction(ctx, tx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		errr := errs.InternalServerError("Failed to commit transaction: ", err.Error())
+		return nil, &errr
+	}
+
+	return transaction, nil
+}
+
+// postTransaction moves input.Amount from input.From to input.To inside tx.
+// Guardian accounts are locked and may never go below zero.
+func postTransaction(ctx context.Context, tx pgx.Tx, input *models.CreateWalletTransactionData) (*models.WalletTransaction, error) {
+	if input.Amount <= 0 {
+		errr := errs.BadRequest("Wallet transaction amount must be positive")
+		return nil, &errr
+	}
+
+	fromID, err := ensureAccount(ctx, tx, input.From, input.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	toID, err := ensureAccount(ctx, tx, input.To, input.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.From.Type == models.WalletAccountTypeGuardian {
+		balanceQuery, err := schema.ReadSQLBaseScript("get_account_balance.sql", SqlWalletFiles)
+		if err != nil {
+			errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+			return nil, &errr
+		}
+
+		var balance int
+		if err := tx.QueryRow(ctx, balanceQuery, fromID).Scan(&balance); err != nil {
+			errr := errs.InternalServerError("Failed to fetch wallet balance: ", err.Error())
+			return nil, &errr
+		}
+
+		if balance < input.Amount {
+			errr := errs.BadRequest("Insufficient wallet balance")
+			return nil, &errr
+		}
+	}
+
+	transactionQuery, err := schema.ReadSQLBaseScript("create_transaction.sql", SqlWalletFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	var transaction models.WalletTransaction
+	err = tx.QueryRow(ctx, transactionQuery,
+		input.TransactionType,
+		input.Amount,
+		input.Currency,
+		input.Description,
+		input.RegistrationID,
+		input.GiftCardID,
+	).Scan(
+		&transaction.ID,
+		&transaction.TransactionType,
+		&transaction.Amount,
+		&transaction.Currency,
+		&transaction.Description,
+		&transaction.RegistrationID,
+		&transaction.GiftCardID,
+		&transaction.CreatedAt,
+	)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to create wallet transaction: ", err.Error())
+		return nil, &errr
+	}
+
+	entriesQuery, err := schema.ReadSQLBaseScript("create_entries.sql", SqlWalletFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := tx.Query(ctx, entriesQuery, transaction.ID, fromID, toID, input.Amount)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to create wallet entries: ", err.Error())
+		return nil, &errr
+	}
+
+	transaction.Entries, err = pgx.CollectRows(rows, pgx.RowToStructByName[models.WalletEntry])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to scan wallet entries: ", err.Error())
+		return nil, &errr
+	}
+
+	return &transaction, nil
+}
+
+// ensureAccount returns the id of the account for ref, creating it on first use.
+func ensureAccount(ctx context.Context, tx pgx.Tx, ref models.WalletAccountRef, currency string) (uuid.UUID, error) {
+	if (ref.Type == models.WalletAccountTypeGuardian) != (ref.GuardianID != nil) {
+		errr := errs.InternalServerError("Invalid wallet account reference: ", string(ref.Type))
+		return uuid.Nil, &errr
+	}
+
+	query, err := schema.ReadSQLBaseScript("ensure_account.sql", SqlWalletFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return uuid.Nil, &errr
+	}
+
+	var id uuid.UUID
+	// a concurrent insert of the same account is invisible to the first attempt's snapshot
+	for attempt := 0; attempt < 2; attempt++ {
+		err = tx.QueryRow(ctx, query, ref.Type, ref.GuardianID, currency).Scan(&id)
+		if !errors.Is(err, pgx.ErrNoRows) {
+			break
+		}
+	}
+	if err != nil {
+		errr := errs.InternalServerError("Failed to get wallet account: ", err.Error())
+		return uuid.Nil, &errr
+	}
+
+	return id, nil
+}
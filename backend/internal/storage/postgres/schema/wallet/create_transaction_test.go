@@ -0,0 +1,118 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/guardian"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTransaction(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+
+	transaction, err := repo.CreateTransaction(ctx, &models.CreateWalletTransactionData{
+		TransactionType: models.WalletTransactionTypeGoodwillCredit,
+		Amount:          750,
+		Currency:        "thb",
+		From:            models.WalletAccountRef{Type: models.WalletAccountTypeGoodwill},
+		To:              models.WalletAccountRef{Type: models.WalletAccountTypeGuardian, GuardianID: &g.ID},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, transaction)
+	assert.Equal(t, models.WalletTransactionTypeGoodwillCredit, transaction.TransactionType)
+	assert.Equal(t, 750, transaction.Amount)
+	require.Len(t, transaction.Entries, 2)
+
+	assert.Equal(t, -750, transaction.Entries[0].Amount)
+	assert.Equal(t, models.WalletAccountTypeGoodwill, transaction.Entries[0].AccountType)
+	assert.Equal(t, 750, transaction.Entries[1].Amount)
+	assert.Equal(t, models.WalletAccountTypeGuardian, transaction.Entries[1].AccountType)
+	require.NotNil(t, transaction.Entries[1].GuardianID)
+	assert.Equal(t, g.ID, *transaction.Entries[1].GuardianID)
+
+	balance, err := repo.GetBalance(ctx, g.ID, "thb")
+	require.NoError(t, err)
+	assert.Equal(t, 750, balance)
+}
+
+func TestCreateTransaction_InsufficientBalance(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	CreateTestGoodwillCredit(t, ctx, testDB, g.ID, 100)
+
+	transaction, err := repo.CreateTransaction(ctx, &models.CreateWalletTransactionData{
+		TransactionType: models.WalletTransactionTypePaymentApplied,
+		Amount:          101,
+		Currency:        "thb",
+		From:            models.WalletAccountRef{Type: models.WalletAccountTypeGuardian, GuardianID: &g.ID},
+		To:              models.WalletAccountRef{Type: models.WalletAccountTypePayment},
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, transaction)
+	httpErr, ok := err.(*errs.HTTPError)
+	require.True(t, ok)
+	assert.Equal(t, 400, httpErr.Code)
+
+	balance, err := repo.GetBalance(ctx, g.ID, "thb")
+	require.NoError(t, err)
+	assert.Equal(t, 100, balance)
+}
+
+func TestCreateTransaction_SpendsCredit(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	CreateTestGoodwillCredit(t, ctx, testDB, g.ID, 1000)
+
+	_, err := repo.CreateTransaction(ctx, &models.CreateWalletTransactionData{
+		TransactionType: models.WalletTransactionTypePaymentApplied,
+		Amount:          400,
+		Currency:        "thb",
+		From:            models.WalletAccountRef{Type: models.WalletAccountTypeGuardian, GuardianID: &g.ID},
+		To:              models.WalletAccountRef{Type: models.WalletAccountTypePayment},
+	})
+	require.NoError(t, err)
+
+	balance, err := repo.GetBalance(ctx, g.ID, "thb")
+	require.NoError(t, err)
+	assert.Equal(t, 600, balance)
+}
+
+func TestCreateTransaction_InvalidAmount(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+
+	transaction, err := repo.CreateTransaction(ctx, &models.CreateWalletTransactionData{
+		TransactionType: models.WalletTransactionTypeGoodwillCredit,
+		Amount:          0,
+		Currency:        "thb",
+		From:            models.WalletAccountRef{Type: models.WalletAccountTypeGoodwill},
+		To:              models.WalletAccountRef{Type: models.WalletAccountTypeGuardian, GuardianID: &g.ID},
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, transaction)
+}
@@ -0,0 +1,82 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+	"skillspark/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *WalletRepository) GetAllTransactions(ctx context.Context, pagination utils.Pagination) ([]models.WalletTransaction, error) {
+	query, err := schema.ReadSQLBaseScript("get_all.sql", SqlWalletFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, pagination.Limit, pagination.GetOffset())
+	if err != nil {
+		errr := errs.InternalServerError("Failed to fetch wallet transactions: ", err.Error())
+		return nil, &errr
+	}
+
+	transactions, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.WalletTransaction, error) {
+		var t models.WalletTransaction
+		err := row.Scan(
+			&t.ID,
+			&t.TransactionType,
+			&t.Amount,
+			&t.Currency,
+			&t.Description,
+			&t.RegistrationID,
+			&t.GiftCardID,
+			&t.CreatedAt,
+		)
+		return t, err
+	})
+	if err != nil {
+		errr := errs.InternalServerError("Failed to scan wallet transactions: ", err.Error())
+		return nil, &errr
+	}
+
+	if len(transactions) == 0 {
+		return transactions, nil
+	}
+
+	entriesQuery, err := schema.ReadSQLBaseScript("get_entries_by_transaction_ids.sql", SqlWalletFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	ids := make([]string, len(transactions))
+	for i, t := range transactions {
+		ids[i] = t.ID.String()
+	}
+
+	entryRows, err := r.db.Query(ctx, entriesQuery, ids)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to fetch wallet entries: ", err.Error())
+		return nil, &errr
+	}
+
+	entries, err := pgx.CollectRows(entryRows, pgx.RowToStructByName[models.WalletEntry])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to scan wallet entries: ", err.Error())
+		return nil, &errr
+	}
+
+	byTransaction := make(map[uuid.UUID][]models.WalletEntry, len(transactions))
+	for _, e := range entries {
+		byTransaction[e.TransactionID] = append(byTransaction[e.TransactionID], e)
+	}
+	for i := range transactions {
+		transactions[i].Entries = byTransaction[transactions[i].ID]
+	}
+
+	return transactions, nil
+}
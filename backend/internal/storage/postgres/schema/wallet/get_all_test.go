@@ -0,0 +1,36 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/schema/guardian"
+	"skillspark/internal/storage/postgres/testutil"
+	"skillspark/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllTransactions(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	created := CreateTestGoodwillCredit(t, ctx, testDB, g.ID, 250)
+
+	transactions, err := repo.GetAllTransactions(ctx, utils.Pagination{Page: 1, Limit: 100})
+	require.NoError(t, err)
+
+	var found bool
+	for _, tr := range transactions {
+		if tr.ID == created.ID {
+			found = true
+			assert.Equal(t, 250, tr.Amount)
+			require.Len(t, tr.Entries, 2)
+			assert.Equal(t, 0, tr.Entries[0].Amount+tr.Entries[1].Amount)
+		}
+	}
+	assert.True(t, found)
+}
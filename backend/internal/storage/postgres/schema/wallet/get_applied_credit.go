@@ -0,0 +1,27 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+)
+
+// GetAppliedCreditByRegistrationID returns the wallet credit currently applied to a registration's
+// payment, net of any reversals.
+func (r *WalletRepository) GetAppliedCreditByRegistrationID(ctx context.Context, registrationID uuid.UUID) (int, error) {
+	query, err := schema.ReadSQLBaseScript("get_applied_credit.sql", SqlWalletFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return 0, &errr
+	}
+
+	var applied int
+	if err := r.db.QueryRow(ctx, query, registrationID).Scan(&applied); err != nil {
+		errr := errs.InternalServerError("Failed to fetch applied wallet credit: ", err.Error())
+		return 0, &errr
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,52 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/guardian"
+	"skillspark/internal/storage/postgres/schema/registration"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAppliedCreditByRegistrationID(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	CreateTestGoodwillCredit(t, ctx, testDB, g.ID, 1000)
+
+	_, err := repo.CreateTransaction(ctx, &models.CreateWalletTransactionData{
+		TransactionType: models.WalletTransactionTypePaymentApplied,
+		Amount:          600,
+		Currency:        "thb",
+		From:            models.WalletAccountRef{Type: models.WalletAccountTypeGuardian, GuardianID: &g.ID},
+		To:              models.WalletAccountRef{Type: models.WalletAccountTypePayment},
+		RegistrationID:  &reg.ID,
+	})
+	require.NoError(t, err)
+
+	applied, err := repo.GetAppliedCreditByRegistrationID(ctx, reg.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 600, applied)
+
+	_, err = repo.CreateTransaction(ctx, &models.CreateWalletTransactionData{
+		TransactionType: models.WalletTransactionTypePaymentReversal,
+		Amount:          600,
+		Currency:        "thb",
+		From:            models.WalletAccountRef{Type: models.WalletAccountTypePayment},
+		To:              models.WalletAccountRef{Type: models.WalletAccountTypeGuardian, GuardianID: &g.ID},
+		RegistrationID:  &reg.ID,
+	})
+	require.NoError(t, err)
+
+	applied, err = repo.GetAppliedCreditByRegistrationID(ctx, reg.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, applied)
+}
@@ -0,0 +1,25 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+)
+
+func (r *WalletRepository) GetBalance(ctx context.Context, guardianID uuid.UUID, currency string) (int, error) {
+	query, err := schema.ReadSQLBaseScript("get_balance.sql", SqlWalletFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return 0, &errr
+	}
+
+	var balance int
+	if err := r.db.QueryRow(ctx, query, guardianID, currency).Scan(&balance); err != nil {
+		errr := errs.InternalServerError("Failed to fetch wallet balance: ", err.Error())
+		return 0, &errr
+	}
+
+	return balance, nil
+}
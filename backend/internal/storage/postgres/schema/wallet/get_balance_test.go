@@ -0,0 +1,42 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/schema/guardian"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBalance(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	CreateTestGoodwillCredit(t, ctx, testDB, g.ID, 300)
+	CreateTestGoodwillCredit(t, ctx, testDB, g.ID, 200)
+
+	balance, err := repo.GetBalance(ctx, g.ID, "thb")
+	require.NoError(t, err)
+	assert.Equal(t, 500, balance)
+
+	balance, err = repo.GetBalance(ctx, g.ID, "usd")
+	require.NoError(t, err)
+	assert.Equal(t, 0, balance)
+}
+
+func TestGetBalance_NoWallet(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	balance, err := repo.GetBalance(ctx, uuid.New(), "thb")
+	require.NoError(t, err)
+	assert.Equal(t, 0, balance)
+}
@@ -0,0 +1,35 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+	"skillspark/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *WalletRepository) GetTransactionsByGuardianID(ctx context.Context, guardianID uuid.UUID, currency string, pagination utils.Pagination) ([]models.GuardianWalletTransaction, error) {
+	query, err := schema.ReadSQLBaseScript("get_by_guardian_id.sql", SqlWalletFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	rows, err := r.db.Query(ctx, query, guardianID, currency, pagination.Limit, pagination.GetOffset())
+	if err != nil {
+		errr := errs.InternalServerError("Failed to fetch wallet transactions: ", err.Error())
+		return nil, &errr
+	}
+	defer rows.Close()
+
+	transactions, err := pgx.CollectRows(rows, pgx.RowToStructByName[models.GuardianWalletTransaction])
+	if err != nil {
+		errr := errs.InternalServerError("Failed to scan wallet transactions: ", err.Error())
+		return nil, &errr
+	}
+
+	return transactions, nil
+}
@@ -0,0 +1,59 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/guardian"
+	"skillspark/internal/storage/postgres/testutil"
+	"skillspark/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTransactionsByGuardianID(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	other := guardian.CreateTestGuardian(t, ctx, testDB)
+	CreateTestGoodwillCredit(t, ctx, testDB, g.ID, 500)
+	CreateTestGoodwillCredit(t, ctx, testDB, other.ID, 900)
+
+	_, err := repo.CreateTransaction(ctx, &models.CreateWalletTransactionData{
+		TransactionType: models.WalletTransactionTypePaymentApplied,
+		Amount:          200,
+		Currency:        "thb",
+		From:            models.WalletAccountRef{Type: models.WalletAccountTypeGuardian, GuardianID: &g.ID},
+		To:              models.WalletAccountRef{Type: models.WalletAccountTypePayment},
+	})
+	require.NoError(t, err)
+
+	transactions, err := repo.GetTransactionsByGuardianID(ctx, g.ID, "thb", utils.NewPagination())
+	require.NoError(t, err)
+	require.Len(t, transactions, 2)
+
+	assert.Equal(t, models.WalletTransactionTypePaymentApplied, transactions[0].TransactionType)
+	assert.Equal(t, -200, transactions[0].Amount)
+	assert.Equal(t, models.WalletTransactionTypeGoodwillCredit, transactions[1].TransactionType)
+	assert.Equal(t, 500, transactions[1].Amount)
+}
+
+func TestGetTransactionsByGuardianID_Pagination(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	for i := 0; i < 3; i++ {
+		CreateTestGoodwillCredit(t, ctx, testDB, g.ID, 100)
+	}
+
+	transactions, err := repo.GetTransactionsByGuardianID(ctx, g.ID, "thb", utils.Pagination{Page: 2, Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, transactions, 1)
+}
@@ -0,0 +1,85 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// RedeemGiftCard marks the gift card as used and credits its value to the guardian's wallet.
+func (r *WalletRepository) RedeemGiftCard(ctx context.Context, code string, guardianID uuid.UUID) (*models.WalletTransaction, error) {
+	lockQuery, err := schema.ReadSQLBaseScript("lock_gift_card.sql", SqlWalletFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	redeemQuery, err := schema.ReadSQLBaseScript("redeem_gift_card.sql", SqlWalletFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return nil, &errr
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to begin transaction: ", err.Error())
+		return nil, &errr
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	rows, err := tx.Query(ctx, lockQuery, code)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to fetch gift card: ", err.Error())
+		return nil, &errr
+	}
+
+	giftCard, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[models.GiftCard])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			errr := errs.NotFound("GiftCard", "code", code)
+			return nil, &errr
+		}
+		errr := errs.InternalServerError("Failed to fetch gift card: ", err.Error())
+		return nil, &errr
+	}
+
+	if giftCard.RedeemedAt != nil {
+		_ = tx.Rollback(ctx)
+		errr := errs.BadRequest("Gift card has already been redeemed")
+		return nil, &errr
+	}
+
+	var redeemedID uuid.UUID
+	if err = tx.QueryRow(ctx, redeemQuery, giftCard.ID, guardianID).Scan(&redeemedID); err != nil {
+		errr := errs.InternalServerError("Failed to redeem gift card: ", err.Error())
+		return nil, &errr
+	}
+
+	transaction, err := postTransaction(ctx, tx, &models.CreateWalletTransactionData{
+		TransactionType: models.WalletTransactionTypeGiftCardRedemption,
+		Amount:          giftCard.Amount,
+		Currency:        giftCard.Currency,
+		From:            models.WalletAccountRef{Type: models.WalletAccountTypeGiftCard},
+		To:              models.WalletAccountRef{Type: models.WalletAccountTypeGuardian, GuardianID: &guardianID},
+		GiftCardID:      &giftCard.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		errr := errs.InternalServerError("Failed to commit transaction: ", err.Error())
+		return nil, &errr
+	}
+
+	return transaction, nil
+}
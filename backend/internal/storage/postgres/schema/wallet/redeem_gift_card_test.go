@@ -0,0 +1,74 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/guardian"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedeemGiftCard(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	giftCard := CreateTestGiftCard(t, ctx, testDB, 1500)
+
+	transaction, err := repo.RedeemGiftCard(ctx, giftCard.Code, g.ID)
+	require.NoError(t, err)
+	require.NotNil(t, transaction)
+	assert.Equal(t, models.WalletTransactionTypeGiftCardRedemption, transaction.TransactionType)
+	assert.Equal(t, &giftCard.ID, transaction.GiftCardID)
+
+	balance, err := repo.GetBalance(ctx, g.ID, "thb")
+	require.NoError(t, err)
+	assert.Equal(t, 1500, balance)
+}
+
+func TestRedeemGiftCard_AlreadyRedeemed(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	other := guardian.CreateTestGuardian(t, ctx, testDB)
+	giftCard := CreateTestGiftCard(t, ctx, testDB, 1500)
+
+	_, err := repo.RedeemGiftCard(ctx, giftCard.Code, g.ID)
+	require.NoError(t, err)
+
+	transaction, err := repo.RedeemGiftCard(ctx, giftCard.Code, other.ID)
+	require.Error(t, err)
+	assert.Nil(t, transaction)
+	httpErr, ok := err.(*errs.HTTPError)
+	require.True(t, ok)
+	assert.Equal(t, 400, httpErr.Code)
+
+	balance, err := repo.GetBalance(ctx, other.ID, "thb")
+	require.NoError(t, err)
+	assert.Equal(t, 0, balance)
+}
+
+func TestRedeemGiftCard_NotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+
+	transaction, err := repo.RedeemGiftCard(ctx, "NOPE-NOPE-NOPE", g.ID)
+	require.Error(t, err)
+	assert.Nil(t, transaction)
+	httpErr, ok := err.(*errs.HTTPError)
+	require.True(t, ok)
+	assert.Equal(t, 404, httpErr.Code)
+}
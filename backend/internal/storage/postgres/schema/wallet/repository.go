@@ -0,0 +1,11 @@
+package wallet
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type WalletRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWalletRepository(db *pgxpool.Pool) *WalletRepository {
+	return &WalletRepository{db: db}
+}
@@ -0,0 +1,90 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ReverseAppliedCredit returns any wallet credit still applied to a registration back to the
+// guardian's wallet and reports how much was restored. The registration row is locked so
+// concurrent cancellations cannot restore the same credit twice.
+func (r *WalletRepository) ReverseAppliedCredit(ctx context.Context, registrationID uuid.UUID, guardianID uuid.UUID) (int, error) {
+	lockQuery, err := schema.ReadSQLBaseScript("lock_registration.sql", SqlWalletFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return 0, &errr
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to begin transaction: ", err.Error())
+		return 0, &errr
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var lockedID uuid.UUID
+	if err = tx.QueryRow(ctx, lockQuery, registrationID).Scan(&lockedID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			errr := errs.NotFound("Registration", "id", registrationID)
+			return 0, &errr
+		}
+		errr := errs.InternalServerError("Failed to lock registration: ", err.Error())
+		return 0, &errr
+	}
+
+	restored, err := reverseAppliedCredit(ctx, tx, registrationID, guardianID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		errr := errs.InternalServerError("Failed to commit transaction: ", err.Error())
+		return 0, &errr
+	}
+
+	return restored, nil
+}
+
+// reverseAppliedCredit moves the credit applied to a registration back to the guardian
+// inside tx, in the currency it was applied in. The caller must hold the registration lock.
+func reverseAppliedCredit(ctx context.Context, tx pgx.Tx, registrationID uuid.UUID, guardianID uuid.UUID) (int, error) {
+	query, err := schema.ReadSQLBaseScript("get_unreversed_credit.sql", SqlWalletFiles)
+	if err != nil {
+		errr := errs.InternalServerError("Failed to read base query: ", err.Error())
+		return 0, &errr
+	}
+
+	var applied int
+	var currency string
+	if err := tx.QueryRow(ctx, query, registrationID).Scan(&applied, &currency); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		errr := errs.InternalServerError("Failed to fetch applied wallet credit: ", err.Error())
+		return 0, &errr
+	}
+
+	_, err = postTransaction(ctx, tx, &models.CreateWalletTransactionData{
+		TransactionType: models.WalletTransactionTypePaymentReversal,
+		Amount:          applied,
+		Currency:        currency,
+		From:            models.WalletAccountRef{Type: models.WalletAccountTypePayment},
+		To:              models.WalletAccountRef{Type: models.WalletAccountTypeGuardian, GuardianID: &guardianID},
+		RegistrationID:  &registrationID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,58 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/registration"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReverseAppliedCredit(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	reg := registration.CreateTestRegistration(t, ctx, testDB)
+	guardianID := reg.GuardianID
+	CreateTestGoodwillCredit(t, ctx, testDB, guardianID, 800)
+
+	_, err := repo.CreateTransaction(ctx, &models.CreateWalletTransactionData{
+		TransactionType: models.WalletTransactionTypePaymentApplied,
+		Amount:          800,
+		Currency:        "thb",
+		From:            models.WalletAccountRef{Type: models.WalletAccountTypeGuardian, GuardianID: &guardianID},
+		To:              models.WalletAccountRef{Type: models.WalletAccountTypePayment},
+		RegistrationID:  &reg.ID,
+	})
+	require.NoError(t, err)
+
+	restored, err := repo.ReverseAppliedCredit(ctx, reg.ID, guardianID)
+	require.NoError(t, err)
+	assert.Equal(t, 800, restored)
+
+	balance, err := repo.GetBalance(ctx, guardianID, "thb")
+	require.NoError(t, err)
+	assert.Equal(t, 800, balance)
+
+	// a second reversal is a no-op
+	restored, err = repo.ReverseAppliedCredit(ctx, reg.ID, guardianID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, restored)
+}
+
+func TestReverseAppliedCredit_RegistrationNotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	repo := NewWalletRepository(testDB)
+	ctx := context.Background()
+	t.Parallel()
+
+	restored, err := repo.ReverseAppliedCredit(ctx, uuid.New(), uuid.New())
+	require.Error(t, err)
+	assert.Equal(t, 0, restored)
+}
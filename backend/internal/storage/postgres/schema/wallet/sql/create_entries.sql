@@ -0,0 +1,11 @@
+WITH inserted AS (
+    INSERT INTO wallet_entry (transaction_id, account_id, amount)
+    VALUES
+        ($1, $2, ($4::integer * -1)),
+        ($1, $3, $4::integer)
+    RETURNING id, transaction_id, account_id, amount, created_at
+)
+SELECT i.id, i.transaction_id, i.account_id, a.account_type, a.guardian_id, i.amount, i.created_at
+FROM inserted i
+JOIN wallet_account a ON a.id = i.account_id
+ORDER BY i.amount ASC;
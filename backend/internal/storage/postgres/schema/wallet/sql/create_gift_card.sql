@@ -0,0 +1,4 @@
+INSERT INTO gift_card (code, amount, currency, purchaser_guardian_id, recipient_email, stripe_payment_intent_id)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, code, amount, currency, purchaser_guardian_id, recipient_email, stripe_payment_intent_id,
+          redeemed_by_guardian_id, redeemed_at, created_at, updated_at;
@@ -0,0 +1,3 @@
+INSERT INTO wallet_transaction (transaction_type, amount, currency, description, registration_id, gift_card_id)
+VALUES ($1::wallet_transaction_type, $2, $3, $4, $5, $6)
+RETURNING id, transaction_type, amount, currency, description, registration_id, gift_card_id, created_at;
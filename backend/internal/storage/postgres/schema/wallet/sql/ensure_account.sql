@@ -0,0 +1,14 @@
+WITH inserted AS (
+    INSERT INTO wallet_account (account_type, guardian_id, currency)
+    VALUES ($1::wallet_account_type, $2::uuid, $3)
+    ON CONFLICT DO NOTHING
+    RETURNING id
+)
+SELECT id FROM inserted
+UNION ALL
+SELECT id
+FROM wallet_account
+WHERE account_type = $1::wallet_account_type
+  AND guardian_id IS NOT DISTINCT FROM $2::uuid
+  AND currency = $3
+LIMIT 1;
@@ -0,0 +1,9 @@
+WITH locked AS (
+    SELECT id
+    FROM wallet_account
+    WHERE id = $1
+    FOR UPDATE
+)
+SELECT COALESCE(SUM(e.amount), 0)::integer
+FROM locked l
+LEFT JOIN wallet_entry e ON e.account_id = l.id;
@@ -0,0 +1,4 @@
+SELECT id, transaction_type, amount, currency, description, registration_id, gift_card_id, created_at
+FROM wallet_transaction
+ORDER BY created_at DESC, id
+LIMIT $1 OFFSET $2;
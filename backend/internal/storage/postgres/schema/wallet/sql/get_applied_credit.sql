@@ -0,0 +1,9 @@
+SELECT COALESCE(SUM(
+    CASE
+        WHEN transaction_type = 'payment_applied' THEN amount
+        ELSE -amount
+    END
+), 0)::integer
+FROM wallet_transaction
+WHERE registration_id = $1
+  AND transaction_type IN ('payment_applied', 'payment_reversal');
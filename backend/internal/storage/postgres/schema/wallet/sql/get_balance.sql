@@ -0,0 +1,6 @@
+SELECT COALESCE(SUM(e.amount), 0)::integer
+FROM wallet_account a
+JOIN wallet_entry e ON e.account_id = a.id
+WHERE a.account_type = 'guardian'
+  AND a.guardian_id = $1
+  AND a.currency = $2;
@@ -0,0 +1,16 @@
+SELECT
+    t.id AS transaction_id,
+    t.transaction_type,
+    e.amount,
+    t.currency,
+    t.description,
+    t.registration_id,
+    t.created_at
+FROM wallet_entry e
+JOIN wallet_account a ON a.id = e.account_id
+JOIN wallet_transaction t ON t.id = e.transaction_id
+WHERE a.account_type = 'guardian'
+  AND a.guardian_id = $1
+  AND a.currency = $2
+ORDER BY t.created_at DESC, t.id
+LIMIT $3 OFFSET $4;
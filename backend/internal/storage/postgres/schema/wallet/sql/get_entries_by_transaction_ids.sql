@@ -0,0 +1,5 @@
+SELECT e.id, e.transaction_id, e.account_id, a.account_type, a.guardian_id, e.amount, e.created_at
+FROM wallet_entry e
+JOIN wallet_account a ON a.id = e.account_id
+WHERE e.transaction_id = ANY($1::uuid[])
+ORDER BY e.transaction_id, e.amount ASC;
@@ -0,0 +1,4 @@
+SELECT amount
+FROM wallet_transaction
+WHERE registration_id = $1
+  AND transaction_type = 'payment_applied';
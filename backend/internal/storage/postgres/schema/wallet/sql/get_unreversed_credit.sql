@@ -0,0 +1,10 @@
+SELECT applied.amount, applied.currency
+FROM wallet_transaction applied
+WHERE applied.registration_id = $1
+  AND applied.transaction_type = 'payment_applied'
+  AND NOT EXISTS (
+      SELECT 1
+      FROM wallet_transaction reversal
+      WHERE reversal.registration_id = applied.registration_id
+        AND reversal.transaction_type = 'payment_reversal'
+  );
@@ -0,0 +1,5 @@
+SELECT id, code, amount, currency, purchaser_guardian_id, recipient_email, stripe_payment_intent_id,
+       redeemed_by_guardian_id, redeemed_at, created_at, updated_at
+FROM gift_card
+WHERE code = $1
+FOR UPDATE;
@@ -0,0 +1,5 @@
+SELECT id
+FROM registration
+WHERE id = $1
+  AND status = 'registered'
+FOR UPDATE;
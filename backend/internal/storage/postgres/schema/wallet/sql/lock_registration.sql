@@ -0,0 +1,4 @@
+SELECT id
+FROM registration
+WHERE id = $1
+FOR UPDATE;
@@ -0,0 +1,6 @@
+UPDATE gift_card
+SET redeemed_by_guardian_id = $2,
+    redeemed_at = NOW()
+WHERE id = $1
+  AND redeemed_at IS NULL
+RETURNING id;
@@ -0,0 +1,64 @@
+package wallet
+
+import (
+	"context"
+	"embed"
+	"skillspark/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed sql/*.sql
+var SqlWalletFiles embed.FS
+
+func CreateTestGoodwillCredit(
+	t *testing.T,
+	ctx context.Context,
+	db *pgxpool.Pool,
+	guardianID uuid.UUID,
+	amount int,
+) *models.WalletTransaction {
+	t.Helper()
+
+	repo := NewWalletRepository(db)
+
+	description := "test credit"
+	transaction, err := repo.CreateTransaction(ctx, &models.CreateWalletTransactionData{
+		TransactionType: models.WalletTransactionTypeGoodwillCredit,
+		Amount:          amount,
+		Currency:        "thb",
+		From:            models.WalletAccountRef{Type: models.WalletAccountTypeGoodwill},
+		To:              models.WalletAccountRef{Type: models.WalletAccountTypeGuardian, GuardianID: &guardianID},
+		Description:     &description,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, transaction)
+
+	return transaction
+}
+
+func CreateTestGiftCard(
+	t *testing.T,
+	ctx context.Context,
+	db *pgxpool.Pool,
+	amount int,
+) *models.GiftCard {
+	t.Helper()
+
+	repo := NewWalletRepository(db)
+
+	giftCard, err := repo.CreateGiftCard(ctx, &models.CreateGiftCardData{
+		Code:     uuid.NewString()[:12],
+		Amount:   amount,
+		Currency: "thb",
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, giftCard)
+
+	return giftCard
+}
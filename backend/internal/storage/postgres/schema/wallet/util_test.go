@@ -0,0 +1,23 @@
+package wallet
+
+import (
+	"context"
+	"skillspark/internal/storage/postgres/schema/guardian"
+	"skillspark/internal/storage/postgres/testutil"
+	"testing"
+)
+
+func Test_CreateTestGoodwillCredit(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	t.Parallel()
+	g := guardian.CreateTestGuardian(t, ctx, testDB)
+	CreateTestGoodwillCredit(t, ctx, testDB, g.ID, 500)
+}
+
+func Test_CreateTestGiftCard(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	t.Parallel()
+	CreateTestGiftCard(t, ctx, testDB, 1000)
+}
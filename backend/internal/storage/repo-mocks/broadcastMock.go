@@ -0,0 +1,89 @@
+package repomocks
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBroadcastRepository struct {
+	mock.Mock
+}
+
+func (m *MockBroadcastRepository) CreateBroadcast(ctx context.Context, input *models.CreateBroadcastData) (*models.Broadcast, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Broadcast), args.Error(1)
+}
+
+func (m *MockBroadcastRepository) GetBroadcastByID(ctx context.Context, organizationID uuid.UUID, id uuid.UUID) (*models.Broadcast, error) {
+	args := m.Called(ctx, organizationID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Broadcast), args.Error(1)
+}
+
+func (m *MockBroadcastRepository) GetBroadcastsByOrganizationID(ctx context.Context, organizationID uuid.UUID, pagination utils.Pagination) ([]models.Broadcast, error) {
+	args := m.Called(ctx, organizationID, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Broadcast), args.Error(1)
+}
+
+func (m *MockBroadcastRepository) CountRecentBroadcasts(ctx context.Context, organizationID uuid.UUID, since time.Time) (int, error) {
+	args := m.Called(ctx, organizationID, since)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBroadcastRepository) CancelBroadcast(ctx context.Context, organizationID uuid.UUID, id uuid.UUID) (*models.Broadcast, error) {
+	args := m.Called(ctx, organizationID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Broadcast), args.Error(1)
+}
+
+func (m *MockBroadcastRepository) GetDueBroadcasts(ctx context.Context, limit int) ([]models.Broadcast, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Broadcast), args.Error(1)
+}
+
+func (m *MockBroadcastRepository) ClaimDueBroadcasts(ctx context.Context, limit int) ([]models.Broadcast, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Broadcast), args.Error(1)
+}
+
+func (m *MockBroadcastRepository) FinishBroadcast(ctx context.Context, id uuid.UUID, recipientCount int) error {
+	args := m.Called(ctx, id, recipientCount)
+	return args.Error(0)
+}
+
+func (m *MockBroadcastRepository) GetBroadcastRecipients(ctx context.Context, broadcast *models.Broadcast) ([]models.Guardian, error) {
+	args := m.Called(ctx, broadcast)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Guardian), args.Error(1)
+}
+
+func (m *MockBroadcastRepository) GetBroadcastStats(ctx context.Context, id uuid.UUID) ([]models.BroadcastChannelStats, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.BroadcastChannelStats), args.Error(1)
+}
@@ -0,0 +1,54 @@
+package repomocks
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDigestRepository struct {
+	mock.Mock
+}
+
+func (m *MockDigestRepository) GetDigestGuardians(ctx context.Context, afterID uuid.UUID, limit int) ([]models.Guardian, error) {
+	args := m.Called(ctx, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Guardian), args.Error(1)
+}
+
+func (m *MockDigestRepository) GetDigestChildren(ctx context.Context, guardianID uuid.UUID) ([]models.DigestChild, error) {
+	args := m.Called(ctx, guardianID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.DigestChild), args.Error(1)
+}
+
+func (m *MockDigestRepository) GetRecentlyDigestedEventIDs(ctx context.Context, guardianID uuid.UUID, since time.Time) ([]uuid.UUID, error) {
+	args := m.Called(ctx, guardianID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockDigestRepository) CreateDigest(ctx context.Context, input *models.CreateDigestData) (*models.Digest, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Digest), args.Error(1)
+}
+
+func (m *MockDigestRepository) RecordDigestClick(ctx context.Context, id uuid.UUID) (*models.DigestItem, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DigestItem), args.Error(1)
+}
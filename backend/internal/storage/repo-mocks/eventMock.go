@@ -4,6 +4,7 @@ import (
 	"context"
 	"skillspark/internal/models"
 	"skillspark/internal/utils"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -73,3 +74,38 @@ func (m *MockEventRepository) GetAllEvents(ctx context.Context, pagination utils
 	}
 	return args.Get(0).([]models.Event), nil
 }
+
+func (m *MockEventRepository) GetEventSearchDocuments(ctx context.Context, updatedSince *time.Time, afterID *uuid.UUID, limit int) ([]models.EventSearchDocument, error) {
+	args := m.Called(ctx, updatedSince, afterID, limit)
+	if args.Get(0) == nil {
+		if args.Get(1) == nil {
+			return nil, nil
+		}
+		return nil, args.Get(1).(error)
+	}
+	return args.Get(0).([]models.EventSearchDocument), nil
+}
+
+func (m *MockEventRepository) GetAllEventIDs(ctx context.Context) ([]uuid.UUID, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		if args.Get(1) == nil {
+			return nil, nil
+		}
+		return nil, args.Get(1).(error)
+	}
+	return args.Get(0).([]uuid.UUID), nil
+}
+
+func (m *MockEventRepository) SearchEvents(ctx context.Context, pagination utils.Pagination, acceptLanguage string, filters models.GetAllEventsFilter) ([]models.Event, int, error) {
+	args := m.Called(ctx, pagination, acceptLanguage, filters)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]models.Event), args.Int(1), args.Error(2)
+}
+
+func (m *MockEventRepository) SegmentThaiWords(ctx context.Context, all bool, batchSize int) (int, error) {
+	args := m.Called(ctx, all, batchSize)
+	return args.Int(0), args.Error(1)
+}
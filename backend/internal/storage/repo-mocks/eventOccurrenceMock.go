@@ -4,6 +4,7 @@ import (
 	"context"
 	"skillspark/internal/models"
 	"skillspark/internal/utils"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -78,3 +79,25 @@ func (m *MockEventOccurrenceRepository) CancelEventOccurrence(
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *MockEventOccurrenceRepository) GetEventOccurrenceSearchDocuments(ctx context.Context, updatedSince *time.Time, afterID *uuid.UUID, limit int) ([]models.EventOccurrenceSearchDocument, error) {
+	args := m.Called(ctx, updatedSince, afterID, limit)
+	if args.Get(0) == nil {
+		if args.Get(1) == nil {
+			return nil, nil
+		}
+		return nil, args.Get(1).(error)
+	}
+	return args.Get(0).([]models.EventOccurrenceSearchDocument), nil
+}
+
+func (m *MockEventOccurrenceRepository) GetScheduledEventOccurrenceIDs(ctx context.Context) ([]uuid.UUID, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		if args.Get(1) == nil {
+			return nil, nil
+		}
+		return nil, args.Get(1).(error)
+	}
+	return args.Get(0).([]uuid.UUID), nil
+}
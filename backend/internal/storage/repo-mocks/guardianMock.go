@@ -111,3 +111,28 @@ func (m *MockGuardianRepository) GetGuardianNotificationPreferences(ctx context.
 	}
 	return args.Get(0).(map[uuid.UUID]models.GuardianNotificationPreferences), args.Error(1)
 }
+
+func (m *MockGuardianRepository) UpdateGuardianNotificationPreferences(ctx context.Context, guardianID uuid.UUID, preferences []models.NotificationPreference) error {
+	args := m.Called(ctx, guardianID, preferences)
+	return args.Error(0)
+}
+
+func (m *MockGuardianRepository) UpdateGuardianQuietHours(ctx context.Context, guardianID uuid.UUID, quietHours *models.QuietHours) error {
+	args := m.Called(ctx, guardianID, quietHours)
+	return args.Error(0)
+}
+
+func (m *MockGuardianRepository) UpdateGuardianProfilePicture(ctx context.Context, guardianID uuid.UUID, key string) error {
+	args := m.Called(ctx, guardianID, key)
+	return args.Error(0)
+}
+
+func (m *MockGuardianRepository) DisableGuardianNotificationChannel(ctx context.Context, guardianID uuid.UUID, channel models.NotificationType) error {
+	args := m.Called(ctx, guardianID, channel)
+	return args.Error(0)
+}
+
+func (m *MockGuardianRepository) ClearExpoPushToken(ctx context.Context, token string) (int64, error) {
+	args := m.Called(ctx, token)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,58 @@
+package repomocks
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInboxRepository struct {
+	mock.Mock
+}
+
+func (m *MockInboxRepository) CreateInboxItem(ctx context.Context, input *models.CreateInboxItemData) (*models.InboxItem, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.InboxItem), args.Error(1)
+}
+
+func (m *MockInboxRepository) GetInboxItemsByGuardianID(ctx context.Context, guardianID uuid.UUID, unreadOnly bool, pagination utils.Pagination) ([]models.InboxItem, error) {
+	args := m.Called(ctx, guardianID, unreadOnly, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.InboxItem), args.Error(1)
+}
+
+func (m *MockInboxRepository) GetUnreadInboxCount(ctx context.Context, guardianID uuid.UUID) (int, error) {
+	args := m.Called(ctx, guardianID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockInboxRepository) MarkInboxItemRead(ctx context.Context, guardianID uuid.UUID, id uuid.UUID) (*models.InboxItem, error) {
+	args := m.Called(ctx, guardianID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.InboxItem), args.Error(1)
+}
+
+func (m *MockInboxRepository) MarkAllInboxItemsRead(ctx context.Context, guardianID uuid.UUID) (int, error) {
+	args := m.Called(ctx, guardianID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockInboxRepository) DeleteUpcomingInboxItemsByRegistrationID(ctx context.Context, registrationID uuid.UUID) error {
+	args := m.Called(ctx, registrationID)
+	return args.Error(0)
+}
+
+func (m *MockInboxRepository) DeleteUpcomingInboxItemsByEventOccurrenceID(ctx context.Context, eventOccurrenceID uuid.UUID) error {
+	args := m.Called(ctx, eventOccurrenceID)
+	return args.Error(0)
+}
@@ -0,0 +1,19 @@
+package repomocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockJobLockRepository struct {
+	mock.Mock
+}
+
+func (m *MockJobLockRepository) TryAcquireJobLock(ctx context.Context, name string) (func(), bool, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(func()), args.Bool(1), args.Error(2)
+}
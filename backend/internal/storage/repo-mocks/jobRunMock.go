@@ -0,0 +1,55 @@
+package repomocks
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockJobRunRepository struct {
+	mock.Mock
+}
+
+func (m *MockJobRunRepository) CreateJobRun(ctx context.Context, input *models.CreateJobRunData) (*models.JobRun, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.JobRun), args.Error(1)
+}
+
+func (m *MockJobRunRepository) ClaimScheduledJobRun(ctx context.Context, jobName string, scheduledFor time.Time) (*models.JobRun, bool, error) {
+	args := m.Called(ctx, jobName, scheduledFor)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(*models.JobRun), args.Bool(1), args.Error(2)
+}
+
+func (m *MockJobRunRepository) FinishJobRun(ctx context.Context, input *models.FinishJobRunData) (*models.JobRun, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.JobRun), args.Error(1)
+}
+
+func (m *MockJobRunRepository) GetAllJobRuns(ctx context.Context, jobName string, pagination utils.Pagination) ([]models.JobRun, error) {
+	args := m.Called(ctx, jobName, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.JobRun), args.Error(1)
+}
+
+func (m *MockJobRunRepository) GetJobRunByID(ctx context.Context, id uuid.UUID) (*models.JobRun, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.JobRun), args.Error(1)
+}
@@ -0,0 +1,87 @@
+package repomocks
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) CreateScheduledNotification(ctx context.Context, input *models.CreateScheduledNotificationInput) (*models.Notification, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Notification), args.Error(1)
+}
+
+func (m *MockNotificationRepository) GetPendingNotifications(ctx context.Context) ([]models.Notification, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Notification), args.Error(1)
+}
+
+func (m *MockNotificationRepository) GetNotificationByID(ctx context.Context, id uuid.UUID) (*models.Notification, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Notification), args.Error(1)
+}
+
+func (m *MockNotificationRepository) UpdateNotificationStatus(ctx context.Context, id uuid.UUID, status models.NotificationStatus) (*models.Notification, error) {
+	args := m.Called(ctx, id, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Notification), args.Error(1)
+}
+
+func (m *MockNotificationRepository) RecordNotificationDelivery(ctx context.Context, id uuid.UUID, result *models.NotificationDeliveryResult) error {
+	args := m.Called(ctx, id, result)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) ClaimNotificationDelivery(ctx context.Context, dedupID string, messageID string) (bool, error) {
+	args := m.Called(ctx, dedupID, messageID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockNotificationRepository) RecordNotificationOutcome(ctx context.Context, event *models.DeliveryOutcomeEvent) (*models.OutcomeNotification, error) {
+	args := m.Called(ctx, event)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OutcomeNotification), args.Error(1)
+}
+
+func (m *MockNotificationRepository) GetPushNotificationsAwaitingReceipt(ctx context.Context, limit int) ([]models.Notification, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Notification), args.Error(1)
+}
+
+func (m *MockNotificationRepository) DeletePendingNotificationsByRegistrationID(ctx context.Context, registrationID uuid.UUID) error {
+	args := m.Called(ctx, registrationID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) DeletePendingNotificationsByEventOccurrenceID(ctx context.Context, eventOccurrenceID uuid.UUID) error {
+	args := m.Called(ctx, eventOccurrenceID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) DeferNotification(ctx context.Context, id uuid.UUID, until time.Time) error {
+	args := m.Called(ctx, id, until)
+	return args.Error(0)
+}
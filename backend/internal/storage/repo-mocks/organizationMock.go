@@ -4,6 +4,7 @@ import (
 	"context"
 	"skillspark/internal/models"
 	"skillspark/internal/utils"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -101,3 +102,25 @@ func (m *MockOrganizationRepository) SetStripeAccountStatus(ctx context.Context,
 	}
 	return args.Get(0).(*models.Organization), nil
 }
+
+func (m *MockOrganizationRepository) GetOrganizationSearchDocuments(ctx context.Context, updatedSince *time.Time, afterID *uuid.UUID, limit int) ([]models.OrganizationSearchDocument, error) {
+	args := m.Called(ctx, updatedSince, afterID, limit)
+	if args.Get(0) == nil {
+		if args.Get(1) == nil {
+			return nil, nil
+		}
+		return nil, args.Get(1).(error)
+	}
+	return args.Get(0).([]models.OrganizationSearchDocument), nil
+}
+
+func (m *MockOrganizationRepository) GetActiveOrganizationIDs(ctx context.Context) ([]uuid.UUID, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		if args.Get(1) == nil {
+			return nil, nil
+		}
+		return nil, args.Get(1).(error)
+	}
+	return args.Get(0).([]uuid.UUID), nil
+}
@@ -0,0 +1,45 @@
+package repomocks
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+func (m *MockOutboxRepository) EnqueueOutboxMessage(ctx context.Context, input *models.EnqueueOutboxMessageData) (*models.OutboxMessage, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OutboxMessage), args.Error(1)
+}
+
+func (m *MockOutboxRepository) ClaimOutboxMessages(ctx context.Context, limit int, visibilityTimeout time.Duration) ([]models.OutboxMessage, error) {
+	args := m.Called(ctx, limit, visibilityTimeout)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.OutboxMessage), args.Error(1)
+}
+
+func (m *MockOutboxRepository) MarkOutboxMessagePublished(ctx context.Context, id uuid.UUID, attempt int) error {
+	args := m.Called(ctx, id, attempt)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) RetryOutboxMessageLater(ctx context.Context, id uuid.UUID, attempt int, availableAt time.Time, lastError string) error {
+	args := m.Called(ctx, id, attempt, availableAt, lastError)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) FailOutboxMessage(ctx context.Context, id uuid.UUID, attempt int, lastError string) error {
+	args := m.Called(ctx, id, attempt, lastError)
+	return args.Error(0)
+}
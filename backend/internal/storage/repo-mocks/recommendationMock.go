@@ -3,8 +3,8 @@ package repomocks
 import (
 	"context"
 	"skillspark/internal/models"
-	"skillspark/internal/utils"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -12,10 +12,23 @@ type MockRecommendationRepository struct {
 	mock.Mock
 }
 
-func (m *MockRecommendationRepository) GetRecommendationsByChildID(ctx context.Context, childInterests []string, childBirthYear int, acceptLanguage string, pagination utils.Pagination, filters models.RecommendationFilters) ([]models.Event, error) {
-	args := m.Called(ctx, childInterests, childBirthYear, acceptLanguage, pagination, filters)
+func (m *MockRecommendationRepository) GetRecommendationCandidates(ctx context.Context, childID uuid.UUID, childInterests []string, childBirthYear int, acceptLanguage string, limit int, filters models.RecommendationFilters) ([]models.RecommendationCandidate, error) {
+	args := m.Called(ctx, childID, childInterests, childBirthYear, acceptLanguage, limit, filters)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]models.Event), nil
+	return args.Get(0).([]models.RecommendationCandidate), nil
+}
+
+func (m *MockRecommendationRepository) GetRecommendationInteractions(ctx context.Context) ([]models.RecommendationInteraction, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.RecommendationInteraction), args.Error(1)
+}
+
+func (m *MockRecommendationRepository) ReplaceChildRecommendations(ctx context.Context, recommendations []models.ChildRecommendation) error {
+	args := m.Called(ctx, recommendations)
+	return args.Error(0)
 }
@@ -0,0 +1,37 @@
+package repomocks
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSearchLogRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchLogRepository) CreateSearchLog(ctx context.Context, input *models.CreateSearchLogData) (*models.SearchLog, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SearchLog), args.Error(1)
+}
+
+func (m *MockSearchLogRepository) RecordSearchClick(ctx context.Context, input *models.RecordSearchClickData) (*models.SearchLog, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SearchLog), args.Error(1)
+}
+
+func (m *MockSearchLogRepository) GetSearchAnalytics(ctx context.Context, since time.Time, until time.Time, limit int) (*models.SearchAnalytics, error) {
+	args := m.Called(ctx, since, until, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SearchAnalytics), args.Error(1)
+}
@@ -0,0 +1,62 @@
+package repomocks
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTaskRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskRepository) EnqueueTask(ctx context.Context, input *models.EnqueueTaskData) (*models.Task, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) ClaimTasks(ctx context.Context, limit int, visibilityTimeout time.Duration) ([]models.Task, error) {
+	args := m.Called(ctx, limit, visibilityTimeout)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) CompleteTask(ctx context.Context, id uuid.UUID, attempt int) error {
+	args := m.Called(ctx, id, attempt)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) RetryTaskLater(ctx context.Context, id uuid.UUID, attempt int, runAfter time.Time, lastError string) error {
+	args := m.Called(ctx, id, attempt, runAfter, lastError)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) DeadLetterTask(ctx context.Context, id uuid.UUID, attempt int, lastError string) error {
+	args := m.Called(ctx, id, attempt, lastError)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) GetAllTasks(ctx context.Context, status string, taskType string, pagination utils.Pagination) ([]models.Task, error) {
+	args := m.Called(ctx, status, taskType, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) RequeueDeadTask(ctx context.Context, id uuid.UUID) (*models.Task, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Task), args.Error(1)
+}
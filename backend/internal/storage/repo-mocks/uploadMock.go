@@ -0,0 +1,50 @@
+package repomocks
+
+import (
+	"context"
+	"skillspark/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUploadRepository struct {
+	mock.Mock
+}
+
+func (m *MockUploadRepository) CreateUploadSession(ctx context.Context, input *models.CreateUploadSessionData) (*models.UploadSession, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UploadSession), args.Error(1)
+}
+
+func (m *MockUploadRepository) GetUploadSessionByID(ctx context.Context, id uuid.UUID) (*models.UploadSession, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UploadSession), args.Error(1)
+}
+
+func (m *MockUploadRepository) FinalizeUploadSession(ctx context.Context, id uuid.UUID, key string) (*models.UploadSession, error) {
+	args := m.Called(ctx, id, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UploadSession), args.Error(1)
+}
+
+func (m *MockUploadRepository) GetUploadSessionsToCleanUp(ctx context.Context, limit int) ([]models.UploadSession, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.UploadSession), args.Error(1)
+}
+
+func (m *MockUploadRepository) MarkUploadSessionCleanedUp(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
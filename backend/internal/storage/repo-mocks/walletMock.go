@@ -0,0 +1,97 @@
+package repomocks
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWalletRepository struct {
+	mock.Mock
+}
+
+func (m *MockWalletRepository) CreateTransaction(ctx context.Context, input *models.CreateWalletTransactionData) (*models.WalletTransaction, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		if args.Get(1) == nil {
+			return nil, nil
+		}
+		return nil, args.Get(1).(error)
+	}
+	return args.Get(0).(*models.WalletTransaction), args.Error(1)
+}
+
+func (m *MockWalletRepository) GetBalance(ctx context.Context, guardianID uuid.UUID, currency string) (int, error) {
+	args := m.Called(ctx, guardianID, currency)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockWalletRepository) GetTransactionsByGuardianID(ctx context.Context, guardianID uuid.UUID, currency string, pagination utils.Pagination) ([]models.GuardianWalletTransaction, error) {
+	args := m.Called(ctx, guardianID, currency, pagination)
+	if args.Get(0) == nil {
+		if args.Get(1) == nil {
+			return nil, nil
+		}
+		return nil, args.Get(1).(error)
+	}
+	return args.Get(0).([]models.GuardianWalletTransaction), args.Error(1)
+}
+
+func (m *MockWalletRepository) GetAllTransactions(ctx context.Context, pagination utils.Pagination) ([]models.WalletTransaction, error) {
+	args := m.Called(ctx, pagination)
+	if args.Get(0) == nil {
+		if args.Get(1) == nil {
+			return nil, nil
+		}
+		return nil, args.Get(1).(error)
+	}
+	return args.Get(0).([]models.WalletTransaction), args.Error(1)
+}
+
+func (m *MockWalletRepository) GetAppliedCreditByRegistrationID(ctx context.Context, registrationID uuid.UUID) (int, error) {
+	args := m.Called(ctx, registrationID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockWalletRepository) ApplyCreditToRegistration(ctx context.Context, registrationID uuid.UUID, guardianID uuid.UUID, currency string, maxAmount int) (int, error) {
+	args := m.Called(ctx, registrationID, guardianID, currency, maxAmount)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockWalletRepository) ReverseAppliedCredit(ctx context.Context, registrationID uuid.UUID, guardianID uuid.UUID) (int, error) {
+	args := m.Called(ctx, registrationID, guardianID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockWalletRepository) CancelRegistrationWithCredit(ctx context.Context, input *models.CancelRegistrationInput, refundAmount int) (*models.CancelRegistrationOutput, error) {
+	args := m.Called(ctx, input, refundAmount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CancelRegistrationOutput), args.Error(1)
+}
+
+func (m *MockWalletRepository) CreateGiftCard(ctx context.Context, input *models.CreateGiftCardData) (*models.GiftCard, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		if args.Get(1) == nil {
+			return nil, nil
+		}
+		return nil, args.Get(1).(error)
+	}
+	return args.Get(0).(*models.GiftCard), args.Error(1)
+}
+
+func (m *MockWalletRepository) RedeemGiftCard(ctx context.Context, code string, guardianID uuid.UUID) (*models.WalletTransaction, error) {
+	args := m.Called(ctx, code, guardianID)
+	if args.Get(0) == nil {
+		if args.Get(1) == nil {
+			return nil, nil
+		}
+		return nil, args.Get(1).(error)
+	}
+	return args.Get(0).(*models.WalletTransaction), args.Error(1)
+}
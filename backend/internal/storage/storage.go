@@ -3,21 +3,31 @@ package storage
 import (
 	"context"
 	"skillspark/internal/models"
+	"skillspark/internal/storage/postgres/schema/broadcast"
 	"skillspark/internal/storage/postgres/schema/child"
+	"skillspark/internal/storage/postgres/schema/digest"
 	emergencycontact "skillspark/internal/storage/postgres/schema/emergency-contact"
 	"skillspark/internal/storage/postgres/schema/event"
 	eventoccurrence "skillspark/internal/storage/postgres/schema/event-occurrence"
 	"skillspark/internal/storage/postgres/schema/guardian"
+	"skillspark/internal/storage/postgres/schema/inbox"
+	joblock "skillspark/internal/storage/postgres/schema/job-lock"
+	jobrun "skillspark/internal/storage/postgres/schema/job-run"
 	"skillspark/internal/storage/postgres/schema/location"
 	"skillspark/internal/storage/postgres/schema/manager"
 	notification "skillspark/internal/storage/postgres/schema/notification"
 	"skillspark/internal/storage/postgres/schema/organization"
+	"skillspark/internal/storage/postgres/schema/outbox"
 	"skillspark/internal/storage/postgres/schema/recommendation"
 	"skillspark/internal/storage/postgres/schema/registration"
 	"skillspark/internal/storage/postgres/schema/review"
 	"skillspark/internal/storage/postgres/schema/saved"
 	"skillspark/internal/storage/postgres/schema/school"
+	searchlog "skillspark/internal/storage/postgres/schema/search-log"
+	"skillspark/internal/storage/postgres/schema/task"
+	"skillspark/internal/storage/postgres/schema/upload"
 	"skillspark/internal/storage/postgres/schema/user"
+	"skillspark/internal/storage/postgres/schema/wallet"
 	"skillspark/internal/utils"
 	"time"
 
@@ -49,6 +59,8 @@ type OrganizationRepository interface {
 	GetEventOccurrencesByOrganizationID(ctx context.Context, organization_id uuid.UUID, AcceptLanguage string) ([]models.EventOccurrence, error)
 	SetStripeAccountID(ctx context.Context, orgID uuid.UUID, stripeAccountID string) (*models.Organization, error)
 	SetStripeAccountStatus(ctx context.Context, stripeAccountID string, activated bool) (*models.Organization, error)
+	GetOrganizationSearchDocuments(ctx context.Context, updatedSince *time.Time, afterID *uuid.UUID, limit int) ([]models.OrganizationSearchDocument, error)
+	GetActiveOrganizationIDs(ctx context.Context) ([]uuid.UUID, error)
 }
 
 type ManagerRepository interface {
@@ -71,6 +83,11 @@ type GuardianRepository interface {
 	SetStripeCustomerID(ctx context.Context, guardianID uuid.UUID, stripeCustomerID string) (*models.Guardian, error)
 	DeleteGuardian(ctx context.Context, id uuid.UUID, tx pgx.Tx) (*models.Guardian, error)
 	GetGuardianNotificationPreferences(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]models.GuardianNotificationPreferences, error)
+	UpdateGuardianNotificationPreferences(ctx context.Context, guardianID uuid.UUID, preferences []models.NotificationPreference) error
+	UpdateGuardianQuietHours(ctx context.Context, guardianID uuid.UUID, quietHours *models.QuietHours) error
+	UpdateGuardianProfilePicture(ctx context.Context, guardianID uuid.UUID, key string) error
+	DisableGuardianNotificationChannel(ctx context.Context, guardianID uuid.UUID, channel models.NotificationType) error
+	ClearExpoPushToken(ctx context.Context, token string) (int64, error)
 }
 
 type EventRepository interface {
@@ -80,6 +97,10 @@ type EventRepository interface {
 	GetEventOccurrencesByEventID(ctx context.Context, event_id uuid.UUID, AcceptLanguage string) ([]models.EventOccurrence, error)
 	GetEventByID(ctx context.Context, id uuid.UUID, AcceptLanguage string) (*models.Event, error)
 	GetAllEvents(ctx context.Context, pagination utils.Pagination, AcceptLanguage string, filters models.GetAllEventsFilter) ([]models.Event, error)
+	GetEventSearchDocuments(ctx context.Context, updatedSince *time.Time, afterID *uuid.UUID, limit int) ([]models.EventSearchDocument, error)
+	GetAllEventIDs(ctx context.Context) ([]uuid.UUID, error)
+	SearchEvents(ctx context.Context, pagination utils.Pagination, AcceptLanguage string, filters models.GetAllEventsFilter) ([]models.Event, int, error)
+	SegmentThaiWords(ctx context.Context, all bool, batchSize int) (int, error)
 }
 
 type ChildRepository interface {
@@ -97,6 +118,8 @@ type EventOccurrenceRepository interface {
 	CreateEventOccurrence(ctx context.Context, input *models.CreateEventOccurrenceInput) (*models.EventOccurrence, error)
 	UpdateEventOccurrence(ctx context.Context, input *models.UpdateEventOccurrenceInput, tx *pgx.Tx) (*models.EventOccurrence, error)
 	CancelEventOccurrence(ctx context.Context, id uuid.UUID) error
+	GetEventOccurrenceSearchDocuments(ctx context.Context, updatedSince *time.Time, afterID *uuid.UUID, limit int) ([]models.EventOccurrenceSearchDocument, error)
+	GetScheduledEventOccurrenceIDs(ctx context.Context) ([]uuid.UUID, error)
 }
 
 type RegistrationRepository interface {
@@ -136,7 +159,15 @@ type UserRepository interface {
 type NotificationRepository interface {
 	CreateScheduledNotification(ctx context.Context, input *models.CreateScheduledNotificationInput) (*models.Notification, error)
 	GetPendingNotifications(ctx context.Context) ([]models.Notification, error)
+	GetNotificationByID(ctx context.Context, id uuid.UUID) (*models.Notification, error)
 	UpdateNotificationStatus(ctx context.Context, id uuid.UUID, status models.NotificationStatus) (*models.Notification, error)
+	RecordNotificationDelivery(ctx context.Context, id uuid.UUID, result *models.NotificationDeliveryResult) error
+	ClaimNotificationDelivery(ctx context.Context, dedupID string, messageID string) (bool, error)
+	RecordNotificationOutcome(ctx context.Context, event *models.DeliveryOutcomeEvent) (*models.OutcomeNotification, error)
+	GetPushNotificationsAwaitingReceipt(ctx context.Context, limit int) ([]models.Notification, error)
+	DeletePendingNotificationsByRegistrationID(ctx context.Context, registrationID uuid.UUID) error
+	DeletePendingNotificationsByEventOccurrenceID(ctx context.Context, eventOccurrenceID uuid.UUID) error
+	DeferNotification(ctx context.Context, id uuid.UUID, until time.Time) error
 }
 
 type SavedRepository interface {
@@ -153,7 +184,108 @@ type EmergencyContactRepository interface {
 }
 
 type RecommendationRepository interface {
-	GetRecommendationsByChildID(ctx context.Context, childInterests []string, childBirthYear int, acceptLanguage string, pagination utils.Pagination, filters models.RecommendationFilters) ([]models.Event, error)
+	GetRecommendationCandidates(ctx context.Context, childID uuid.UUID, childInterests []string, childBirthYear int, acceptLanguage string, limit int, filters models.RecommendationFilters) ([]models.RecommendationCandidate, error)
+	GetRecommendationInteractions(ctx context.Context) ([]models.RecommendationInteraction, error)
+	ReplaceChildRecommendations(ctx context.Context, recommendations []models.ChildRecommendation) error
+}
+
+type WalletRepository interface {
+	CreateTransaction(ctx context.Context, input *models.CreateWalletTransactionData) (*models.WalletTransaction, error)
+	GetBalance(ctx context.Context, guardianID uuid.UUID, currency string) (int, error)
+	GetTransactionsByGuardianID(ctx context.Context, guardianID uuid.UUID, currency string, pagination utils.Pagination) ([]models.GuardianWalletTransaction, error)
+	GetAllTransactions(ctx context.Context, pagination utils.Pagination) ([]models.WalletTransaction, error)
+	GetAppliedCreditByRegistrationID(ctx context.Context, registrationID uuid.UUID) (int, error)
+	ApplyCreditToRegistration(ctx context.Context, registrationID uuid.UUID, guardianID uuid.UUID, currency string, maxAmount int) (int, error)
+	ReverseAppliedCredit(ctx context.Context, registrationID uuid.UUID, guardianID uuid.UUID) (int, error)
+	CancelRegistrationWithCredit(ctx context.Context, input *models.CancelRegistrationInput, refundAmount int) (*models.CancelRegistrationOutput, error)
+	CreateGiftCard(ctx context.Context, input *models.CreateGiftCardData) (*models.GiftCard, error)
+	RedeemGiftCard(ctx context.Context, code string, guardianID uuid.UUID) (*models.WalletTransaction, error)
+}
+
+// JobLockRepository coordinates scheduled jobs across API and worker instances
+type JobLockRepository interface {
+	TryAcquireJobLock(ctx context.Context, name string) (func(), bool, error)
+}
+
+type JobRunRepository interface {
+	CreateJobRun(ctx context.Context, input *models.CreateJobRunData) (*models.JobRun, error)
+	ClaimScheduledJobRun(ctx context.Context, jobName string, scheduledFor time.Time) (*models.JobRun, bool, error)
+	FinishJobRun(ctx context.Context, input *models.FinishJobRunData) (*models.JobRun, error)
+	GetAllJobRuns(ctx context.Context, jobName string, pagination utils.Pagination) ([]models.JobRun, error)
+	GetJobRunByID(ctx context.Context, id uuid.UUID) (*models.JobRun, error)
+}
+
+// TaskRepository is the durable queue behind the background jobs
+type TaskRepository interface {
+	EnqueueTask(ctx context.Context, input *models.EnqueueTaskData) (*models.Task, error)
+	ClaimTasks(ctx context.Context, limit int, visibilityTimeout time.Duration) ([]models.Task, error)
+	CompleteTask(ctx context.Context, id uuid.UUID, attempt int) error
+	RetryTaskLater(ctx context.Context, id uuid.UUID, attempt int, runAfter time.Time, lastError string) error
+	DeadLetterTask(ctx context.Context, id uuid.UUID, attempt int, lastError string) error
+	GetAllTasks(ctx context.Context, status string, taskType string, pagination utils.Pagination) ([]models.Task, error)
+	RequeueDeadTask(ctx context.Context, id uuid.UUID) (*models.Task, error)
+}
+
+// OutboxRepository holds side effects recorded with the writes that cause them until the
+// relay publishes them
+type OutboxRepository interface {
+	EnqueueOutboxMessage(ctx context.Context, input *models.EnqueueOutboxMessageData) (*models.OutboxMessage, error)
+	ClaimOutboxMessages(ctx context.Context, limit int, visibilityTimeout time.Duration) ([]models.OutboxMessage, error)
+	MarkOutboxMessagePublished(ctx context.Context, id uuid.UUID, attempt int) error
+	RetryOutboxMessageLater(ctx context.Context, id uuid.UUID, attempt int, availableAt time.Time, lastError string) error
+	FailOutboxMessage(ctx context.Context, id uuid.UUID, attempt int, lastError string) error
+}
+
+// UploadRepository tracks direct-to-S3 uploads from the presigned URL until the image is
+// attached and its staging object removed
+type UploadRepository interface {
+	CreateUploadSession(ctx context.Context, input *models.CreateUploadSessionData) (*models.UploadSession, error)
+	GetUploadSessionByID(ctx context.Context, id uuid.UUID) (*models.UploadSession, error)
+	FinalizeUploadSession(ctx context.Context, id uuid.UUID, key string) (*models.UploadSession, error)
+	GetUploadSessionsToCleanUp(ctx context.Context, limit int) ([]models.UploadSession, error)
+	MarkUploadSessionCleanedUp(ctx context.Context, id uuid.UUID) error
+}
+
+// SearchLogRepository is the anonymous log of search requests behind the search analytics
+// report
+type SearchLogRepository interface {
+	CreateSearchLog(ctx context.Context, input *models.CreateSearchLogData) (*models.SearchLog, error)
+	RecordSearchClick(ctx context.Context, input *models.RecordSearchClickData) (*models.SearchLog, error)
+	GetSearchAnalytics(ctx context.Context, since time.Time, until time.Time, limit int) (*models.SearchAnalytics, error)
+}
+
+// InboxRepository is the guardian's in-app notification inbox
+type InboxRepository interface {
+	CreateInboxItem(ctx context.Context, input *models.CreateInboxItemData) (*models.InboxItem, error)
+	GetInboxItemsByGuardianID(ctx context.Context, guardianID uuid.UUID, unreadOnly bool, pagination utils.Pagination) ([]models.InboxItem, error)
+	GetUnreadInboxCount(ctx context.Context, guardianID uuid.UUID) (int, error)
+	MarkInboxItemRead(ctx context.Context, guardianID uuid.UUID, id uuid.UUID) (*models.InboxItem, error)
+	MarkAllInboxItemsRead(ctx context.Context, guardianID uuid.UUID) (int, error)
+	DeleteUpcomingInboxItemsByRegistrationID(ctx context.Context, registrationID uuid.UUID) error
+	DeleteUpcomingInboxItemsByEventOccurrenceID(ctx context.Context, eventOccurrenceID uuid.UUID) error
+}
+
+// BroadcastRepository stores organization broadcasts and finds who they go to
+type BroadcastRepository interface {
+	CreateBroadcast(ctx context.Context, input *models.CreateBroadcastData) (*models.Broadcast, error)
+	GetBroadcastByID(ctx context.Context, organizationID uuid.UUID, id uuid.UUID) (*models.Broadcast, error)
+	GetBroadcastsByOrganizationID(ctx context.Context, organizationID uuid.UUID, pagination utils.Pagination) ([]models.Broadcast, error)
+	CountRecentBroadcasts(ctx context.Context, organizationID uuid.UUID, since time.Time) (int, error)
+	CancelBroadcast(ctx context.Context, organizationID uuid.UUID, id uuid.UUID) (*models.Broadcast, error)
+	GetDueBroadcasts(ctx context.Context, limit int) ([]models.Broadcast, error)
+	ClaimDueBroadcasts(ctx context.Context, limit int) ([]models.Broadcast, error)
+	FinishBroadcast(ctx context.Context, id uuid.UUID, recipientCount int) error
+	GetBroadcastRecipients(ctx context.Context, broadcast *models.Broadcast) ([]models.Guardian, error)
+	GetBroadcastStats(ctx context.Context, id uuid.UUID) ([]models.BroadcastChannelStats, error)
+}
+
+// DigestRepository stores weekly digests and finds the families they go to
+type DigestRepository interface {
+	GetDigestGuardians(ctx context.Context, afterID uuid.UUID, limit int) ([]models.Guardian, error)
+	GetDigestChildren(ctx context.Context, guardianID uuid.UUID) ([]models.DigestChild, error)
+	GetRecentlyDigestedEventIDs(ctx context.Context, guardianID uuid.UUID, since time.Time) ([]uuid.UUID, error)
+	CreateDigest(ctx context.Context, input *models.CreateDigestData) (*models.Digest, error)
+	RecordDigestClick(ctx context.Context, id uuid.UUID) (*models.DigestItem, error)
 }
 
 type Repository struct {
@@ -170,9 +302,19 @@ type Repository struct {
 	Review           ReviewRepository
 	User             UserRepository
 	Notification     NotificationRepository
+	Inbox            InboxRepository
+	Broadcast        BroadcastRepository
+	Digest           DigestRepository
 	Saved            SavedRepository
 	EmergencyContact EmergencyContactRepository
 	Recommendation   RecommendationRepository
+	Wallet           WalletRepository
+	JobLock          JobLockRepository
+	JobRun           JobRunRepository
+	Task             TaskRepository
+	Outbox           OutboxRepository
+	Upload           UploadRepository
+	SearchLog        SearchLogRepository
 }
 
 // Close closes the database connection pool
@@ -202,8 +344,18 @@ func NewRepository(db *pgxpool.Pool) *Repository {
 		Registration:     registration.NewRegistrationRepository(db),
 		Review:           review.NewReviewRepository(db),
 		Notification:     notification.NewNotificationRepository(db),
+		Inbox:            inbox.NewInboxRepository(db),
+		Broadcast:        broadcast.NewBroadcastRepository(db),
+		Digest:           digest.NewDigestRepository(db),
 		Saved:            saved.NewSavedRepository(db),
 		EmergencyContact: emergencycontact.NewEmergencyContactRepository(db),
 		Recommendation:   recommendation.NewRecommendationRepository(db),
+		Wallet:           wallet.NewWalletRepository(db),
+		JobLock:          joblock.NewJobLockRepository(db),
+		JobRun:           jobrun.NewJobRunRepository(db),
+		Task:             task.NewTaskRepository(db),
+		Outbox:           outbox.NewOutboxRepository(db),
+		Upload:           upload.NewUploadRepository(db),
+		SearchLog:        searchlog.NewSearchLogRepository(db),
 	}
 }
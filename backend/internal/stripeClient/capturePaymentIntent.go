@@ -10,6 +10,9 @@ import (
 
 func (sc *StripeClient) CapturePaymentIntent(ctx context.Context, input *models.CapturePaymentIntentInput) (*models.CapturePaymentIntentOutput, error) {
 	params := &stripe.PaymentIntentCaptureParams{}
+	if input.IdempotencyKey != "" {
+		params.SetIdempotencyKey(input.IdempotencyKey)
+	}
 
 	pi, err := sc.client.V1PaymentIntents.Capture(ctx, input.PaymentIntentID, params)
 	if err != nil {
@@ -0,0 +1,36 @@
+package stripeClient
+
+import (
+	"context"
+	"skillspark/internal/models"
+
+	"github.com/stripe/stripe-go/v84"
+)
+
+// ChargeCustomer charges a saved payment method immediately. The funds stay on the
+// platform account, unlike CreatePaymentIntent which transfers to the organization.
+func (sc *StripeClient) ChargeCustomer(ctx context.Context, input *models.ChargeCustomerInput) (*models.ChargeCustomerOutput, error) {
+	params := &stripe.PaymentIntentCreateParams{
+		Amount:        stripe.Int64(input.Amount),
+		Currency:      stripe.String(input.Currency),
+		Customer:      stripe.String(input.CustomerID),
+		PaymentMethod: stripe.String(input.PaymentMethodID),
+		Description:   stripe.String(input.Description),
+		Metadata:      input.Metadata,
+		OffSession:    stripe.Bool(true),
+		Confirm:       stripe.Bool(true),
+	}
+
+	intent, err := sc.client.V1PaymentIntents.Create(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &models.ChargeCustomerOutput{}
+	output.Body.PaymentIntentID = intent.ID
+	output.Body.Status = string(intent.Status)
+	output.Body.Amount = intent.Amount
+	output.Body.Currency = string(intent.Currency)
+
+	return output, nil
+}
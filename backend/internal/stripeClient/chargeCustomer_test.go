@@ -0,0 +1,51 @@
+package stripeClient
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripeClient_ChargeCustomer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping Stripe integration test")
+	}
+
+	apiKey := getTestStripeAPIKey(t)
+	stripeCustomerID := getSeededGuardianStripeCustomerID(t)
+	client, _ := NewStripeClient(apiKey)
+	ctx := context.Background()
+
+	t.Run("successfully charges the customer", func(t *testing.T) {
+		result, err := client.ChargeCustomer(ctx, &models.ChargeCustomerInput{
+			CustomerID:      stripeCustomerID,
+			PaymentMethodID: "pm_card_visa",
+			Amount:          5000,
+			Currency:        "usd",
+			Description:     "SkillSpark gift card",
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Contains(t, result.Body.PaymentIntentID, "pi_")
+		assert.Equal(t, "succeeded", result.Body.Status)
+		assert.Equal(t, int64(5000), result.Body.Amount)
+		assert.Equal(t, "usd", result.Body.Currency)
+	})
+
+	t.Run("fails with a declined card", func(t *testing.T) {
+		result, err := client.ChargeCustomer(ctx, &models.ChargeCustomerInput{
+			CustomerID:      stripeCustomerID,
+			PaymentMethodID: "pm_card_chargeDeclined",
+			Amount:          5000,
+			Currency:        "usd",
+			Description:     "SkillSpark gift card",
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
@@ -28,6 +28,9 @@ func (sc *StripeClient) CreatePaymentIntent(ctx context.Context, input *models.C
 		Confirm:       stripe.Bool(true),
 		CaptureMethod: stripe.String("manual"),
 	}
+	if input.Body.IdempotencyKey != "" {
+		params.SetIdempotencyKey(input.Body.IdempotencyKey)
+	}
 
 	intent, err := sc.client.V1PaymentIntents.Create(ctx, params)
 	if err != nil {
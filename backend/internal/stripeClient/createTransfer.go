@@ -0,0 +1,35 @@
+package stripeClient
+
+import (
+	"context"
+	"skillspark/internal/models"
+
+	"github.com/stripe/stripe-go/v84"
+)
+
+// CreateTransfer pays an organization from the platform's balance, for bookings with no
+// destination charge to carry the organization's share.
+func (sc *StripeClient) CreateTransfer(ctx context.Context, input *models.CreateTransferInput) (*models.CreateTransferOutput, error) {
+	params := &stripe.TransferCreateParams{
+		Amount:      stripe.Int64(input.Amount),
+		Currency:    stripe.String(input.Currency),
+		Destination: stripe.String(input.DestinationAccountID),
+		Description: stripe.String(input.Description),
+		Metadata:    input.Metadata,
+	}
+	if input.IdempotencyKey != "" {
+		params.SetIdempotencyKey(input.IdempotencyKey)
+	}
+
+	transfer, err := sc.client.V1Transfers.Create(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &models.CreateTransferOutput{}
+	output.Body.TransferID = transfer.ID
+	output.Body.Amount = transfer.Amount
+	output.Body.Currency = string(transfer.Currency)
+
+	return output, nil
+}
@@ -0,0 +1,48 @@
+package stripeClient
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripeClient_CreateTransfer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping Stripe integration test")
+	}
+
+	apiKey := getTestStripeAPIKey(t)
+	stripeAccountID := getSeededOrgStripeAccountID(t)
+	client, _ := NewStripeClient(apiKey)
+	ctx := context.Background()
+
+	input := &models.CreateTransferInput{
+		Amount:               4500,
+		Currency:             "usd",
+		DestinationAccountID: stripeAccountID,
+		Description:          "Registration paid with wallet credit",
+		IdempotencyKey:       "transfer_test:" + uuid.NewString(),
+	}
+	result, err := client.CreateTransfer(ctx, input)
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Contains(t, result.Body.TransferID, "tr_")
+	assert.Equal(t, int64(4500), result.Body.Amount)
+	assert.Equal(t, "usd", result.Body.Currency)
+
+	// a retry gets the same transfer back
+	retried, err := client.CreateTransfer(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, result.Body.TransferID, retried.Body.TransferID)
+
+	// the transfer is reversed by its ID when there is no payment intent
+	reversal, err := client.ReverseTransfer(ctx, &models.ReverseTransferInput{TransferID: result.Body.TransferID})
+	require.NoError(t, err)
+	assert.Equal(t, result.Body.TransferID, reversal.Body.TransferID)
+	assert.Equal(t, int64(4500), reversal.Body.Amount)
+}
@@ -131,7 +131,31 @@ func (m *MockStripeClient) RefundPayment(ctx context.Context, input *models.Refu
 	return args.Get(0).(*models.RefundPaymentOutput), args.Error(1)
 }
 
+func (m *MockStripeClient) CreateTransfer(ctx context.Context, input *models.CreateTransferInput) (*models.CreateTransferOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CreateTransferOutput), args.Error(1)
+}
+
+func (m *MockStripeClient) ReverseTransfer(ctx context.Context, input *models.ReverseTransferInput) (*models.ReverseTransferOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReverseTransferOutput), args.Error(1)
+}
+
 func (m *MockStripeClient) AttachPaymentMethod(ctx context.Context, paymentMethodID string, customerID string) error {
 	args := m.Called(ctx, paymentMethodID, customerID)
 	return args.Error(0)
 }
+
+func (m *MockStripeClient) ChargeCustomer(ctx context.Context, input *models.ChargeCustomerInput) (*models.ChargeCustomerOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ChargeCustomerOutput), args.Error(1)
+}
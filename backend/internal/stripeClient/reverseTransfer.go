@@ -0,0 +1,48 @@
+package stripeClient
+
+import (
+	"context"
+	"fmt"
+	"skillspark/internal/models"
+
+	"github.com/stripe/stripe-go/v84"
+)
+
+// ReverseTransfer reverses the whole of a transfer to an organization. For a payment
+// intent's transfer the application fee is kept, so the platform holds the full charge.
+func (sc *StripeClient) ReverseTransfer(ctx context.Context, input *models.ReverseTransferInput) (*models.ReverseTransferOutput, error) {
+	transferID := input.TransferID
+	if transferID == "" {
+		retrieveParams := &stripe.PaymentIntentRetrieveParams{}
+		retrieveParams.AddExpand("latest_charge")
+
+		intent, err := sc.client.V1PaymentIntents.Retrieve(ctx, input.PaymentIntentID, retrieveParams)
+		if err != nil {
+			return nil, err
+		}
+		if intent.LatestCharge == nil || intent.LatestCharge.Transfer == nil {
+			return nil, fmt.Errorf("payment intent %s has no transfer to reverse", input.PaymentIntentID)
+		}
+		transferID = intent.LatestCharge.Transfer.ID
+	}
+
+	params := &stripe.TransferReversalCreateParams{
+		ID: stripe.String(transferID),
+	}
+	if input.IdempotencyKey != "" {
+		params.SetIdempotencyKey(input.IdempotencyKey)
+	}
+
+	reversal, err := sc.client.V1TransferReversals.Create(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &models.ReverseTransferOutput{}
+	output.Body.ReversalID = reversal.ID
+	output.Body.TransferID = transferID
+	output.Body.Amount = reversal.Amount
+	output.Body.Currency = string(reversal.Currency)
+
+	return output, nil
+}
@@ -0,0 +1,79 @@
+package stripeClient
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripeClient_ReverseTransfer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping Stripe integration test")
+	}
+
+	apiKey := getTestStripeAPIKey(t)
+	stripeAccountID := getSeededOrgStripeAccountID(t)
+	stripeCustomerID := getSeededGuardianStripeCustomerID(t)
+	client, _ := NewStripeClient(apiKey)
+	ctx := context.Background()
+
+	createIntent := func(t *testing.T) string {
+		t.Helper()
+
+		createInput := &models.CreatePaymentIntentInput{}
+		createInput.Body.Amount = 10000
+		createInput.Body.Currency = "usd"
+		createInput.Body.GuardianStripeID = stripeCustomerID
+		createInput.Body.OrgStripeID = stripeAccountID
+		createInput.Body.PaymentMethodID = "pm_card_visa"
+		createInput.Body.PlatformFeePercentage = 10
+
+		created, err := client.CreatePaymentIntent(ctx, createInput)
+		require.NoError(t, err)
+		require.Equal(t, "requires_capture", created.Body.Status)
+
+		return created.Body.PaymentIntentID
+	}
+
+	t.Run("reverses the organization's share of a captured payment", func(t *testing.T) {
+		paymentIntentID := createIntent(t)
+		_, err := client.CapturePaymentIntent(ctx, &models.CapturePaymentIntentInput{PaymentIntentID: paymentIntentID})
+		require.NoError(t, err)
+
+		input := &models.ReverseTransferInput{
+			PaymentIntentID: paymentIntentID,
+			IdempotencyKey:  "transfer_reversal_test:" + paymentIntentID,
+		}
+		result, err := client.ReverseTransfer(ctx, input)
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Contains(t, result.Body.ReversalID, "trr_")
+		assert.Contains(t, result.Body.TransferID, "tr_")
+		assert.Equal(t, int64(9000), result.Body.Amount)
+		assert.Equal(t, "usd", result.Body.Currency)
+
+		// a retry gets the same reversal back
+		retried, err := client.ReverseTransfer(ctx, input)
+		require.NoError(t, err)
+		assert.Equal(t, result.Body.ReversalID, retried.Body.ReversalID)
+	})
+
+	t.Run("fails when the payment is not captured", func(t *testing.T) {
+		result, err := client.ReverseTransfer(ctx, &models.ReverseTransferInput{PaymentIntentID: createIntent(t)})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("fails with invalid payment intent ID", func(t *testing.T) {
+		result, err := client.ReverseTransfer(ctx, &models.ReverseTransferInput{PaymentIntentID: "pi_invalid_id"})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "resource_missing")
+	})
+}
@@ -19,5 +19,8 @@ type StripeClientInterface interface {
 	CancelPaymentIntent(ctx context.Context, input *models.CancelPaymentIntentInput) (*models.CancelPaymentIntentOutput, error)
 	CapturePaymentIntent(ctx context.Context, input *models.CapturePaymentIntentInput) (*models.CapturePaymentIntentOutput, error)
 	RefundPayment(ctx context.Context, input *models.RefundPaymentInput) (*models.RefundPaymentOutput, error)
+	CreateTransfer(ctx context.Context, input *models.CreateTransferInput) (*models.CreateTransferOutput, error)
+	ReverseTransfer(ctx context.Context, input *models.ReverseTransferInput) (*models.ReverseTransferOutput, error)
 	AttachPaymentMethod(ctx context.Context, paymentMethodID string, customerID string) error
+	ChargeCustomer(ctx context.Context, input *models.ChargeCustomerInput) (*models.ChargeCustomerOutput, error)
 }
@@ -1,14 +1,207 @@
 import "jsr:@supabase/functions-js/edge-runtime.d.ts";
+import { createClient } from "jsr:@supabase/supabase-js@2";
 
 const OPENSEARCH_URL = Deno.env.get("OPENSEARCH_URL")!;
 const OPENSEARCH_USER = Deno.env.get("OPENSEARCH_USER")!;
 const OPENSEARCH_PASS = Deno.env.get("OPENSEARCH_PASS")!;
-const INDEX = "events";
+const EVENTS = "events";
+const OCCURRENCES = "occurrences";
+const ORGANIZATIONS = "organizations";
 
 const authHeader = "Basic " + btoa(`${OPENSEARCH_USER}:${OPENSEARCH_PASS}`);
 
-async function upsert(id: string, doc: Record<string, unknown>) {
-  const res = await fetch(`${OPENSEARCH_URL}/${INDEX}/_doc/${id}`, {
+const supabase = createClient(
+  Deno.env.get("SUPABASE_URL")!,
+  Deno.env.get("SUPABASE_SERVICE_ROLE_KEY")!,
+);
+
+type Document = Record<string, unknown>;
+
+// latest returns the newest of the timestamps, as the reindex command computes updated_at
+function latest(...timestamps: (string | null | undefined)[]): string {
+  return (timestamps.filter(Boolean) as string[])
+    .reduce((newest, t) => (Date.parse(t) > Date.parse(newest) ? t : newest));
+}
+
+// buildEventDocument loads an event with its organization's location and its scheduled
+// occurrences, in the shape of models.EventSearchDocument. Returns null when the event
+// no longer exists.
+async function buildEventDocument(eventId: string): Promise<Document | null> {
+  const { data: event, error } = await supabase
+    .from("event")
+    .select(`
+      id, organization_id, title_en, title_th, description_en, description_th,
+      category, header_image_s3_key, age_range_min, age_range_max, updated_at,
+      organization ( name, updated_at, location ( latitude, longitude, district, updated_at ) ),
+      event_occurrence ( id, start_time, end_time, price, curr_enrolled, max_attendees, status, updated_at )
+    `)
+    .eq("id", eventId)
+    .maybeSingle();
+  if (error) {
+    throw new Error(`Failed to load event ${eventId}: ${error.message}`);
+  }
+  if (!event) {
+    return null;
+  }
+
+  const location = event.organization?.location;
+  // the latest change to anything in the document
+  const updatedAt = latest(
+    event.updated_at,
+    event.organization?.updated_at,
+    location?.updated_at,
+    ...(event.event_occurrence ?? []).map((o: Record<string, any>) => o.updated_at),
+  );
+  const occurrences = (event.event_occurrence ?? [])
+    .filter((o: Record<string, any>) => o.status === "scheduled")
+    .map((o: Record<string, any>) => ({
+      id:               o.id,
+      start_time:       o.start_time,
+      end_time:         o.end_time,
+      price:            o.price,
+      duration_minutes: Math.round((Date.parse(o.end_time) - Date.parse(o.start_time)) / 60000),
+      sold_out:         o.curr_enrolled >= o.max_attendees,
+    }));
+
+  return {
+    id:                   event.id,
+    organization_id:      event.organization_id,
+    organization_name:    event.organization?.name,
+    title_en:             event.title_en,
+    title_th:             event.title_th,
+    description_en:       event.description_en,
+    description_th:       event.description_th,
+    category:             event.category,
+    header_image_s3_key:  event.header_image_s3_key,
+    age_range_min:        event.age_range_min,
+    age_range_max:        event.age_range_max,
+    ...(location && {
+      location: { lat: location.latitude, lon: location.longitude },
+      district: location.district,
+    }),
+    occurrences,
+    updated_at:           updatedAt,
+  };
+}
+
+// buildOccurrenceDocument loads a scheduled occurrence with its event and its
+// organization's location, in the shape of models.EventOccurrenceSearchDocument. Returns
+// null when the occurrence no longer exists or has been cancelled.
+async function buildOccurrenceDocument(occurrenceId: string): Promise<Document | null> {
+  const { data: occurrence, error } = await supabase
+    .from("event_occurrence")
+    .select(`
+      id, start_time, end_time, price, currency, language, curr_enrolled, max_attendees, status, updated_at,
+      event (
+        id, organization_id, title_en, title_th, description_en, description_th,
+        category, header_image_s3_key, age_range_min, age_range_max, updated_at,
+        organization ( name, updated_at, location ( latitude, longitude, district, updated_at ) )
+      )
+    `)
+    .eq("id", occurrenceId)
+    .maybeSingle();
+  if (error) {
+    throw new Error(`Failed to load event occurrence ${occurrenceId}: ${error.message}`);
+  }
+  if (!occurrence || occurrence.status !== "scheduled" || !occurrence.event) {
+    return null;
+  }
+
+  const event = occurrence.event;
+  const location = event.organization?.location;
+  const seatsLeft = Math.max(occurrence.max_attendees - occurrence.curr_enrolled, 0);
+
+  return {
+    id:                   occurrence.id,
+    event_id:             event.id,
+    organization_id:      event.organization_id,
+    organization_name:    event.organization?.name,
+    title_en:             event.title_en,
+    title_th:             event.title_th,
+    description_en:       event.description_en,
+    description_th:       event.description_th,
+    category:             event.category,
+    header_image_s3_key:  event.header_image_s3_key,
+    age_range_min:        event.age_range_min,
+    age_range_max:        event.age_range_max,
+    ...(location && {
+      location: { lat: location.latitude, lon: location.longitude },
+      district: location.district,
+    }),
+    start_time:           occurrence.start_time,
+    end_time:             occurrence.end_time,
+    duration_minutes:     Math.round((Date.parse(occurrence.end_time) - Date.parse(occurrence.start_time)) / 60000),
+    price:                occurrence.price,
+    currency:             occurrence.currency,
+    language:             occurrence.language,
+    max_attendees:        occurrence.max_attendees,
+    seats_left:           seatsLeft,
+    sold_out:             seatsLeft === 0,
+    updated_at:           latest(occurrence.updated_at, event.updated_at, event.organization?.updated_at, location?.updated_at),
+  };
+}
+
+// buildOrganizationDocument loads an active organization with its location, in the shape
+// of models.OrganizationSearchDocument. Returns null when the organization no longer
+// exists or has been deactivated.
+async function buildOrganizationDocument(organizationId: string): Promise<Document | null> {
+  const { data: organization, error } = await supabase
+    .from("organization")
+    .select(`
+      id, name, active, about_en, about_th, pfp_s3_key, updated_at,
+      location ( latitude, longitude, district, updated_at )
+    `)
+    .eq("id", organizationId)
+    .maybeSingle();
+  if (error) {
+    throw new Error(`Failed to load organization ${organizationId}: ${error.message}`);
+  }
+  if (!organization || !organization.active) {
+    return null;
+  }
+
+  const location = organization.location;
+  return {
+    id:          organization.id,
+    name:        organization.name,
+    about_en:    organization.about_en,
+    about_th:    organization.about_th,
+    pfp_s3_key:  organization.pfp_s3_key,
+    ...(location && {
+      location: { lat: location.latitude, lon: location.longitude },
+      district: location.district,
+    }),
+    updated_at:  latest(organization.updated_at, location?.updated_at),
+  };
+}
+
+// sync reindexes a document from Postgres, or removes it when its row no longer belongs
+// in the index
+async function sync(index: string, id: string, build: (id: string) => Promise<Document | null>) {
+  const doc = await build(id);
+  if (doc) {
+    await upsert(index, id, doc);
+  } else {
+    await remove(index, id);
+  }
+}
+
+// syncEventOccurrences reindexes every occurrence of an event, which carry a copy of it
+async function syncEventOccurrences(eventId: string) {
+  const { data, error } = await supabase
+    .from("event_occurrence")
+    .select("id")
+    .eq("event_id", eventId);
+  if (error) {
+    throw new Error(`Failed to load occurrences of event ${eventId}: ${error.message}`);
+  }
+  for (const occurrence of data ?? []) {
+    await sync(OCCURRENCES, occurrence.id, buildOccurrenceDocument);
+  }
+}
+
+async function upsert(index: string, id: string, doc: Document) {
+  const res = await fetch(`${OPENSEARCH_URL}/${index}/_doc/${id}`, {
     method: "PUT",
     headers: {
       "Content-Type": "application/json",
@@ -21,8 +214,8 @@ async function upsert(id: string, doc: Record<string, unknown>) {
   }
 }
 
-async function remove(id: string) {
-  const res = await fetch(`${OPENSEARCH_URL}/${INDEX}/_doc/${id}`, {
+async function remove(index: string, id: string) {
+  const res = await fetch(`${OPENSEARCH_URL}/${index}/_doc/${id}`, {
     method: "DELETE",
     headers: { "Authorization": authHeader },
   });
@@ -44,7 +237,7 @@ Deno.serve(async (req) => {
     try {
       const healthy = await checkConnection();
       return new Response(
-        JSON.stringify({ connected: healthy, index: INDEX }),
+        JSON.stringify({ connected: healthy, indices: [EVENTS, OCCURRENCES, ORGANIZATIONS] }),
         { status: healthy ? 200 : 503, headers: { "Content-Type": "application/json" } },
       );
     } catch (err) {
@@ -57,30 +250,32 @@ Deno.serve(async (req) => {
 
   try {
     const payload = await req.json();
-    const { type, record, old_record } = payload;
-
-    switch (type) {
-      case "INSERT":
-      case "UPDATE":
-        await upsert(record.id, {
-          id:                   record.id,
-          title_en:             record.title_en,
-          title_th:             record.title_th,
-          description_en:       record.description_en,
-          description_th:       record.description_th,
-          category:             record.category,
-          header_image_s3_key:  record.header_image_s3_key,
-          age_range_min:        record.age_range_min,
-          age_range_max:        record.age_range_max,
-        });
+    const { type, table, record, old_record } = payload;
+
+    if (!["INSERT", "UPDATE", "DELETE"].includes(type)) {
+      return new Response(`Unknown event type: ${type}`, { status: 400 });
+    }
+    const row = record ?? old_record;
+
+    switch (table) {
+      // occurrence changes reindex the occurrence and the event it belongs to
+      case "event_occurrence":
+        await sync(OCCURRENCES, row.id, buildOccurrenceDocument);
+        await sync(EVENTS, row.event_id, buildEventDocument);
         break;
 
-      case "DELETE":
-        await remove(old_record.id);
+      case "organization":
+        await sync(ORGANIZATIONS, row.id, buildOrganizationDocument);
         break;
 
+      // deleting an event deletes its occurrences, whose own triggers remove them
       default:
-        return new Response(`Unknown event type: ${type}`, { status: 400 });
+        if (type === "DELETE") {
+          await remove(EVENTS, old_record.id);
+        } else {
+          await sync(EVENTS, record.id, buildEventDocument);
+          await syncEventOccurrences(record.id);
+        }
     }
 
     return new Response("ok", { status: 200 });
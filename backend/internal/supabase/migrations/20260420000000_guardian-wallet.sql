@@ -0,0 +1,117 @@
+-- Guardian wallet: a double-entry credit ledger.
+-- Every wallet_transaction has exactly two wallet_entry rows whose amounts sum to zero.
+-- A guardian's balance is the sum of the entries on their 'guardian' account; the
+-- system accounts (goodwill, refund, gift_card, payment, stripe) hold the other side.
+CREATE TYPE wallet_account_type AS ENUM (
+    'guardian',
+    'goodwill',
+    'refund',
+    'gift_card',
+    'payment',
+    'stripe'
+);
+
+CREATE TYPE wallet_transaction_type AS ENUM (
+    'goodwill_credit',
+    'refund_credit',
+    'gift_card_purchase',
+    'gift_card_redemption',
+    'payment_applied',
+    'payment_reversal'
+);
+
+CREATE TABLE IF NOT EXISTS wallet_account (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_type wallet_account_type NOT NULL,
+    -- kept as NULL when a guardian is deleted so the ledger history stays balanced
+    guardian_id UUID REFERENCES guardian(id) ON DELETE SET NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'thb',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- one account per guardian per currency, one system account per type per currency
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wallet_account_guardian
+ON wallet_account(guardian_id, currency)
+WHERE account_type = 'guardian' AND guardian_id IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wallet_account_system
+ON wallet_account(account_type, currency)
+WHERE account_type <> 'guardian';
+
+CREATE TABLE IF NOT EXISTS gift_card (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(32) NOT NULL UNIQUE,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'thb',
+    purchaser_guardian_id UUID REFERENCES guardian(id) ON DELETE SET NULL,
+    recipient_email TEXT,
+    stripe_payment_intent_id VARCHAR(255) UNIQUE,
+    redeemed_by_guardian_id UUID REFERENCES guardian(id) ON DELETE SET NULL,
+    redeemed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS wallet_transaction (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    transaction_type wallet_transaction_type NOT NULL,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'thb',
+    description TEXT,
+    registration_id UUID REFERENCES registration(id) ON DELETE SET NULL,
+    gift_card_id UUID REFERENCES gift_card(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS wallet_entry (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    transaction_id UUID NOT NULL REFERENCES wallet_transaction(id) ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES wallet_account(id),
+    amount INTEGER NOT NULL CHECK (amount <> 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_wallet_entry_account ON wallet_entry(account_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_wallet_entry_transaction ON wallet_entry(transaction_id);
+CREATE INDEX IF NOT EXISTS idx_wallet_transaction_registration ON wallet_transaction(registration_id);
+CREATE INDEX IF NOT EXISTS idx_wallet_transaction_created_at ON wallet_transaction(created_at DESC);
+
+-- Reject any transaction whose entries do not balance. Deferred so both entries
+-- can be inserted before the check runs at commit.
+CREATE OR REPLACE FUNCTION check_wallet_transaction_balanced()
+RETURNS TRIGGER AS $$
+DECLARE
+    _sum INTEGER;
+BEGIN
+    SELECT COALESCE(SUM(amount), 0) INTO _sum
+    FROM wallet_entry
+    WHERE transaction_id = NEW.transaction_id;
+
+    IF _sum <> 0 THEN
+        RAISE EXCEPTION 'wallet transaction % is unbalanced (sum %)', NEW.transaction_id, _sum;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER wallet_entry_balanced
+AFTER INSERT ON wallet_entry
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW
+EXECUTE FUNCTION check_wallet_transaction_balanced();
+
+CREATE TRIGGER update_wallet_account_updated_at
+BEFORE UPDATE ON wallet_account
+FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at_column();
+
+CREATE TRIGGER update_gift_card_updated_at
+BEFORE UPDATE ON gift_card
+FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at_column();
+
+-- wallet credit applied to a registration's payment, in addition to the card charge in total_amount
+ALTER TABLE payment
+ADD COLUMN IF NOT EXISTS credit_amount INTEGER NOT NULL DEFAULT 0;
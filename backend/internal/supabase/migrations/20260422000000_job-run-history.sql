@@ -0,0 +1,32 @@
+-- History of background job runs, written by the worker and by admin triggers
+CREATE TYPE job_run_status AS ENUM (
+    'running',
+    'succeeded',
+    'partially_failed',
+    'failed'
+);
+
+CREATE TYPE job_run_trigger AS ENUM (
+    'schedule',
+    'manual'
+);
+
+CREATE TABLE IF NOT EXISTS job_run (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_name TEXT NOT NULL,
+    trigger job_run_trigger NOT NULL DEFAULT 'schedule',
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    status job_run_status NOT NULL DEFAULT 'running',
+    items_processed INTEGER NOT NULL DEFAULT 0,
+    items_succeeded INTEGER NOT NULL DEFAULT 0,
+    items_failed INTEGER NOT NULL DEFAULT 0,
+    -- [{"item_id": "...", "error": "..."}] for each item that failed
+    item_errors JSONB NOT NULL DEFAULT '[]'::jsonb,
+    -- set when the run failed as a whole, e.g. it could not load its work
+    error TEXT,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_run_started_at ON job_run(started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_job_run_job_name ON job_run(job_name, started_at DESC);
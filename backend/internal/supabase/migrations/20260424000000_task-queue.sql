@@ -0,0 +1,37 @@
+-- Durable work queue for the background jobs. The cron jobs enqueue one task per item
+-- (registration to capture, notification to send, ...) and the worker claims them with
+-- FOR UPDATE SKIP LOCKED, retrying failures with backoff until max_attempts.
+CREATE TYPE task_status AS ENUM (
+    'pending',
+    'running',
+    'succeeded',
+    'dead'
+);
+
+CREATE TABLE IF NOT EXISTS task (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_type TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    -- at most one task per key, ever, so the hourly jobs can enqueue the same item
+    -- repeatedly without duplicating work; dead tasks are retried by an admin
+    dedup_key TEXT UNIQUE,
+    status task_status NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 5 CHECK (max_attempts > 0),
+    run_after TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- while running, the claim expires at locked_until and another worker may take the task
+    locked_until TIMESTAMPTZ,
+    last_error TEXT,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_ready ON task(run_after) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_task_locked_until ON task(locked_until) WHERE status = 'running';
+CREATE INDEX IF NOT EXISTS idx_task_status ON task(status, updated_at DESC);
+
+CREATE TRIGGER update_task_updated_at
+BEFORE UPDATE ON task
+FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at_column();
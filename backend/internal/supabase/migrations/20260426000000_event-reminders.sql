@@ -0,0 +1,8 @@
+-- Link scheduled notifications to the registration they remind about, so pending
+-- reminders can be rescheduled or removed when the registration or occurrence changes
+ALTER TABLE scheduled_notification
+ADD COLUMN IF NOT EXISTS registration_id UUID REFERENCES registration(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_notification_registration_pending
+ON scheduled_notification(registration_id)
+WHERE status = 'pending' AND registration_id IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Rendered HTML body for email notifications; body keeps the plain-text version
+ALTER TABLE scheduled_notification
+ADD COLUMN IF NOT EXISTS html_body TEXT;
@@ -0,0 +1,29 @@
+-- In-app inbox: one row per notification a guardian receives, whatever channels it
+-- was delivered on. Scheduled notifications are added up front with visible_at set to
+-- their delivery time so they only appear once they have been sent.
+CREATE TABLE IF NOT EXISTS notification_inbox_item (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    guardian_id UUID NOT NULL REFERENCES guardian(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    -- deep-link target in the app
+    link_type TEXT CHECK (link_type IN ('registration', 'event', 'event_occurrence', 'review')),
+    link_id UUID,
+    deep_link TEXT,
+    metadata JSONB,
+    visible_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((link_type IS NULL) = (link_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_inbox_item_guardian
+ON notification_inbox_item(guardian_id, visible_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_notification_inbox_item_unread
+ON notification_inbox_item(guardian_id)
+WHERE read_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_notification_inbox_item_link
+ON notification_inbox_item(link_type, link_id);
@@ -0,0 +1,15 @@
+-- Per-topic notification preferences. A row exists only once a guardian changes a
+-- topic on a channel; everything else follows the defaults in models/notification_preference.go.
+-- The global guardian.push_notifications / email_notifications switches still apply on top.
+CREATE TABLE IF NOT EXISTS notification_preference (
+    guardian_id UUID NOT NULL REFERENCES guardian(id) ON DELETE CASCADE,
+    topic TEXT NOT NULL,
+    channel notification_type NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (guardian_id, topic, channel)
+);
+
+-- scheduled notifications remember their topic so preferences can be checked at send time
+ALTER TABLE scheduled_notification
+ADD COLUMN IF NOT EXISTS topic TEXT;
@@ -0,0 +1,9 @@
+-- The delivery worker records what happened to each scheduled notification after it left
+-- the queue. 'sent' still means handed to the queue; 'delivered' means a transport accepted it.
+ALTER TYPE notification_status ADD VALUE IF NOT EXISTS 'delivered';
+
+ALTER TABLE scheduled_notification
+ADD COLUMN IF NOT EXISTS delivered_at TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS delivery_transport TEXT,
+ADD COLUMN IF NOT EXISTS provider_message_id TEXT,
+ADD COLUMN IF NOT EXISTS delivery_error TEXT;
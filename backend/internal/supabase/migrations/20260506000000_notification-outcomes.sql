@@ -0,0 +1,35 @@
+-- Outcomes reported after a notification left us: provider webhooks, Expo push receipts
+-- and rejections seen by the delivery worker. Bounces, complaints and invalid push tokens
+-- also switch off the guardian's channel (see internal/delivery/outcomes.go).
+CREATE TYPE delivery_outcome AS ENUM ('delivered', 'bounced', 'complained', 'invalid_token', 'failed');
+
+ALTER TABLE scheduled_notification
+ADD COLUMN IF NOT EXISTS delivery_outcome delivery_outcome,
+ADD COLUMN IF NOT EXISTS outcome_at TIMESTAMPTZ;
+
+-- outcomes are matched to notifications by the provider's message id
+CREATE INDEX IF NOT EXISTS idx_scheduled_notification_provider_message_id
+ON scheduled_notification(provider_message_id)
+WHERE provider_message_id IS NOT NULL;
+
+-- push notifications whose Expo receipt hasn't been checked yet
+CREATE INDEX IF NOT EXISTS idx_scheduled_notification_awaiting_receipt
+ON scheduled_notification(delivered_at)
+WHERE notification_type = 'push' AND status = 'delivered' AND delivery_outcome IS NULL;
+
+-- every outcome, including those for immediate notifications that have no scheduled row
+CREATE TABLE IF NOT EXISTS notification_delivery_event (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    notification_id UUID REFERENCES scheduled_notification(id) ON DELETE SET NULL,
+    guardian_id UUID REFERENCES guardian(id) ON DELETE SET NULL,
+    channel notification_type NOT NULL,
+    outcome delivery_outcome NOT NULL,
+    provider TEXT NOT NULL,
+    provider_message_id TEXT,
+    recipient TEXT NOT NULL,
+    detail TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_delivery_event_guardian_id
+ON notification_delivery_event(guardian_id, created_at DESC);
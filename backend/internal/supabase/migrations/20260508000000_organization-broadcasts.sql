@@ -0,0 +1,53 @@
+-- Organization broadcasts: a message from an organization to the guardians registered
+-- for one occurrence, one event, or anything the organization runs. The send_broadcasts
+-- job fans each due broadcast out into one scheduled notification per guardian and
+-- channel, which are then sent like any other.
+CREATE TYPE broadcast_target_type AS ENUM ('event_occurrence', 'event', 'organization');
+CREATE TYPE broadcast_status AS ENUM ('scheduled', 'sending', 'sent', 'cancelled');
+
+CREATE TABLE IF NOT EXISTS broadcast (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+    manager_id UUID REFERENCES manager(id) ON DELETE SET NULL,
+    target_type broadcast_target_type NOT NULL,
+    target_id UUID NOT NULL,
+    subject_en TEXT NOT NULL,
+    body_en TEXT NOT NULL,
+    subject_th TEXT,
+    body_th TEXT,
+    scheduled_for TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    status broadcast_status NOT NULL DEFAULT 'scheduled',
+    recipient_count INT,
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((subject_th IS NULL) = (body_th IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_broadcast_organization
+ON broadcast(organization_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_broadcast_due
+ON broadcast(scheduled_for)
+WHERE status = 'scheduled';
+
+CREATE TRIGGER update_broadcast_updated_at
+BEFORE UPDATE ON broadcast
+FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at_column();
+
+-- the notifications a broadcast was fanned out into, for its delivery statistics
+ALTER TABLE scheduled_notification
+ADD COLUMN IF NOT EXISTS broadcast_id UUID REFERENCES broadcast(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_notification_broadcast
+ON scheduled_notification(broadcast_id)
+WHERE broadcast_id IS NOT NULL;
+
+-- broadcasts about an organization link to its page from the inbox
+ALTER TABLE notification_inbox_item
+DROP CONSTRAINT IF EXISTS notification_inbox_item_link_type_check;
+
+ALTER TABLE notification_inbox_item
+ADD CONSTRAINT notification_inbox_item_link_type_check
+CHECK (link_type IN ('registration', 'event', 'event_occurrence', 'review', 'organization'));
@@ -0,0 +1,34 @@
+-- Weekly digest of new activities. Guardians opt in through the weekly_digest notification
+-- topic. One digest is sent per guardian per week, listing recommended events for each of
+-- their children; each listed event is an item whose link counts clicks.
+CREATE TABLE IF NOT EXISTS notification_digest (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    guardian_id UUID NOT NULL REFERENCES guardian(id) ON DELETE CASCADE,
+    -- Monday of the digest's week in Bangkok
+    week_start DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (guardian_id, week_start)
+);
+
+CREATE TABLE IF NOT EXISTS notification_digest_item (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    digest_id UUID NOT NULL REFERENCES notification_digest(id) ON DELETE CASCADE,
+    child_id UUID REFERENCES child(id) ON DELETE SET NULL,
+    event_id UUID NOT NULL REFERENCES event(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    click_count INT NOT NULL DEFAULT 0,
+    first_clicked_at TIMESTAMPTZ,
+    last_clicked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_digest_item_digest
+ON notification_digest_item(digest_id);
+
+CREATE INDEX IF NOT EXISTS idx_notification_digest_item_event
+ON notification_digest_item(event_id);
+
+-- finds the guardians who turned the digest on
+CREATE INDEX IF NOT EXISTS idx_notification_preference_topic
+ON notification_preference(topic, channel)
+WHERE enabled;
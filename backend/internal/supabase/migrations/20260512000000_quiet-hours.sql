@@ -0,0 +1,11 @@
+-- Quiet hours: a daily window, in the guardian's own timezone, during which scheduled
+-- notifications are held back until the window ends. Everyone starts with 21:00-07:00.
+ALTER TABLE guardian
+ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT 'Asia/Bangkok',
+ADD COLUMN IF NOT EXISTS quiet_hours_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+ADD COLUMN IF NOT EXISTS quiet_hours_start TIME NOT NULL DEFAULT '21:00',
+ADD COLUMN IF NOT EXISTS quiet_hours_end TIME NOT NULL DEFAULT '07:00';
+
+-- urgent notifications, such as an occurrence being cancelled, go out during quiet hours
+ALTER TABLE scheduled_notification
+ADD COLUMN IF NOT EXISTS urgent BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,31 @@
+-- Transactional outbox. Side effects of a write (confirmations, notification messages for
+-- SQS, ...) are recorded here in the same transaction as the write itself, so they happen
+-- if and only if it commits. The worker's relay claims pending messages with
+-- FOR UPDATE SKIP LOCKED and publishes them at least once, retrying with backoff.
+CREATE TYPE outbox_status AS ENUM (
+    'pending',
+    'published',
+    'failed'
+);
+
+CREATE TABLE IF NOT EXISTS outbox_message (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    -- decides which sink the relay publishes the message to, e.g. notification.send
+    topic TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    -- a message with the same dedup_id is only ever recorded once; it is passed on to
+    -- the sink so consumers can drop the duplicates at-least-once delivery produces
+    dedup_id TEXT NOT NULL UNIQUE,
+    status outbox_status NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 10 CHECK (max_attempts > 0),
+    available_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- while a relay is publishing, its claim expires at locked_until
+    locked_until TIMESTAMPTZ,
+    last_error TEXT,
+    published_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_message_ready ON outbox_message(available_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_outbox_message_locked_until ON outbox_message(locked_until) WHERE locked_until IS NOT NULL;
@@ -0,0 +1,39 @@
+-- Direct-to-S3 uploads. Clients ask for an upload session, PUT the file straight to S3
+-- with the presigned URL it returns, then finalize the session, which checks the object,
+-- runs it through the image pipeline and attaches it to its target. Sessions that are
+-- never finalized are cleaned up by the worker, which deletes their staged object.
+CREATE TYPE upload_target AS ENUM (
+    'event_header',
+    'organization_picture',
+    'guardian_avatar',
+    'review_photo'
+);
+
+CREATE TYPE upload_session_status AS ENUM (
+    'pending',
+    'finalized',
+    'expired'
+);
+
+CREATE TABLE IF NOT EXISTS upload_session (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    target upload_target NOT NULL,
+    target_id UUID NOT NULL,
+    -- where the client uploads to; the processed image is stored elsewhere on finalize
+    staging_key TEXT NOT NULL UNIQUE,
+    content_type TEXT NOT NULL,
+    content_length BIGINT NOT NULL CHECK (content_length > 0),
+    status upload_session_status NOT NULL DEFAULT 'pending',
+    -- finalize is refused after this
+    expires_at TIMESTAMPTZ NOT NULL,
+    -- key of the processed original, set on finalize
+    finalized_key TEXT,
+    finalized_at TIMESTAMPTZ,
+    -- set once the staged object has been deleted
+    cleaned_up_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_upload_session_to_clean ON upload_session(expires_at) WHERE cleaned_up_at IS NULL;
+
+ALTER TABLE review ADD COLUMN IF NOT EXISTS photo_s3_key TEXT;
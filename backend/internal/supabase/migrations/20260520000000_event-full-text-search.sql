@@ -0,0 +1,15 @@
+-- Full-text search over events for when OpenSearch isn't configured. Postgres can't split
+-- Thai into words, so the API segments the Thai title and description in Go and stores
+-- the words; search_vector combines them with the English text, titles weighted above
+-- descriptions. Existing events get their words from cmd/segment.
+ALTER TABLE event
+  ADD COLUMN title_th_words text[],
+  ADD COLUMN description_th_words text[],
+  ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(title_en, '')), 'A') ||
+    setweight(array_to_tsvector(coalesce(title_th_words, '{}')), 'A') ||
+    setweight(to_tsvector('english', coalesce(description_en, '')), 'B') ||
+    setweight(array_to_tsvector(coalesce(description_th_words, '{}')), 'B')
+  ) STORED;
+
+CREATE INDEX event_search_vector_idx ON event USING GIN (search_vector);
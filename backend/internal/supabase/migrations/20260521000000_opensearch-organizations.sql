@@ -0,0 +1,7 @@
+-- Occurrences and organizations get their own indices for unified search. notify_opensearch
+-- already sends the table name, and the edge function reindexes occurrence documents on
+-- event and occurrence changes, so only organizations need a trigger.
+CREATE TRIGGER sync_organization_to_opensearch
+AFTER INSERT OR UPDATE OR DELETE ON organization
+FOR EACH ROW
+EXECUTE FUNCTION notify_opensearch();
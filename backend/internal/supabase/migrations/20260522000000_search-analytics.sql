@@ -0,0 +1,23 @@
+-- Anonymous log of search requests for the search analytics report. Nothing identifying the
+-- user, their device or their exact location is stored: a location filter is recorded only
+-- as having been used.
+CREATE TABLE IF NOT EXISTS search_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    -- 'events' for /search/events, 'all' for the unified /search
+    endpoint TEXT NOT NULL,
+    query TEXT NOT NULL,
+    -- lowercased with whitespace collapsed, so the report groups "Robotics " with "robotics"
+    normalized_query TEXT NOT NULL,
+    language TEXT NOT NULL,
+    filters JSONB NOT NULL DEFAULT '{}'::jsonb,
+    result_count INTEGER NOT NULL,
+    -- the first result opened from the results, if any
+    clicked_result_id UUID,
+    clicked_result_type TEXT,
+    clicked_position INTEGER,
+    clicked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_log_created_at ON search_log(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_search_log_normalized_query ON search_log(normalized_query, created_at DESC);
@@ -0,0 +1,12 @@
+-- Collaborative-filtering scores, learned from what families register for, save and
+-- review. The compute_recommendations job replaces every row on each run; recommendations
+-- blend these scores with how well an event matches the child's interests.
+CREATE TABLE IF NOT EXISTS child_recommendation (
+    child_id UUID NOT NULL REFERENCES child(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES event(id) ON DELETE CASCADE,
+    -- from 0 to 1, where 1 is the child's best match
+    score DOUBLE PRECISION NOT NULL CHECK (score > 0 AND score <= 1),
+    computed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (child_id, event_id)
+);
+
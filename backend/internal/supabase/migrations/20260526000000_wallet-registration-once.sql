@@ -0,0 +1,6 @@
+-- A registration moves credit at most once of each kind: it is paid with credit once, that
+-- credit is reversed once and a cancellation is refunded to the wallet once. The index makes
+-- a retried or concurrent write fail instead of crediting the guardian twice.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wallet_transaction_registration_type
+    ON wallet_transaction(registration_id, transaction_type)
+    WHERE registration_id IS NOT NULL;
@@ -0,0 +1,3 @@
+-- A booking paid entirely with wallet credit has no charge to carry the organization's
+-- share, so it is paid by a separate transfer recorded here.
+ALTER TABLE payment ADD COLUMN IF NOT EXISTS stripe_transfer_id TEXT;
@@ -0,0 +1,3 @@
+-- Notifications the guardian opted out of on their channel were marked 'sent', so they
+-- counted as handed to the queue. They now get their own status.
+ALTER TYPE notification_status ADD VALUE IF NOT EXISTS 'skipped';
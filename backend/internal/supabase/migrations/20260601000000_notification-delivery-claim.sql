@@ -0,0 +1,10 @@
+-- The relay can publish a notification message more than once and a standard queue can
+-- hand out a copy more than once. The delivery worker claims each message's dedup ID here
+-- before sending, so only the copy that holds the claim is delivered.
+CREATE TABLE IF NOT EXISTS notification_delivery_claim (
+    dedup_id TEXT PRIMARY KEY,
+    -- the SQS message ID of the copy that holds the claim; a redelivery of that copy
+    -- after a failed attempt keeps it
+    message_id TEXT NOT NULL,
+    claimed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
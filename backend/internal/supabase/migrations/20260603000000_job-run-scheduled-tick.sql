@@ -0,0 +1,8 @@
+-- A scheduled run records the cron tick it is for. The advisory lock only stops runs
+-- overlapping, so without this a worker that started a tick after another finished it
+-- ran the job again; now the second insert for the same tick fails and the run is skipped.
+ALTER TABLE job_run ADD COLUMN IF NOT EXISTS scheduled_for TIMESTAMPTZ;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_job_run_job_name_scheduled_for
+    ON job_run(job_name, scheduled_for)
+    WHERE scheduled_for IS NOT NULL;
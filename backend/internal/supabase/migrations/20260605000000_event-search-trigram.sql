@@ -0,0 +1,8 @@
+-- The Postgres search fallback also matches events containing the query as a substring,
+-- which a plain index can't answer; trigram indexes keep it from scanning every event.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX event_title_en_trgm_idx ON event USING GIN (title_en gin_trgm_ops);
+CREATE INDEX event_title_th_trgm_idx ON event USING GIN (title_th gin_trgm_ops);
+CREATE INDEX event_description_en_trgm_idx ON event USING GIN (description_en gin_trgm_ops);
+CREATE INDEX event_description_th_trgm_idx ON event USING GIN (description_th gin_trgm_ops);
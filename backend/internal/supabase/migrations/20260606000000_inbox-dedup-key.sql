@@ -0,0 +1,4 @@
+-- Inbox items added again when a notification's side effects are retried are only kept
+-- once; items without a key are never deduplicated.
+ALTER TABLE notification_inbox_item
+ADD COLUMN IF NOT EXISTS dedup_key TEXT UNIQUE;
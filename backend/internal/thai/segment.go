@@ -0,0 +1,219 @@
+// Package thai splits Thai text, which has no spaces between words, into words so Postgres
+// full-text search can index and match it.
+package thai
+
+import (
+	_ "embed"
+	"strings"
+	"unicode"
+)
+
+// icuWordList is the general Thai vocabulary and wordList the activity words it lacks
+var (
+	//go:embed icu_words.txt
+	icuWordList string
+	//go:embed words.txt
+	wordList string
+)
+
+var dictionary = newTrie(icuWordList, wordList)
+
+type trie struct {
+	children map[rune]*trie
+	word     bool
+}
+
+func newTrie(lists ...string) *trie {
+	root := &trie{}
+	for _, list := range lists {
+		for _, line := range strings.Split(list, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			node := root
+			for _, r := range normalize(line) {
+				next, ok := node.children[r]
+				if !ok {
+					// most nodes are leaves, so children are only allocated when needed
+					if node.children == nil {
+						node.children = map[rune]*trie{}
+					}
+					next = &trie{}
+					node.children[r] = next
+				}
+				node = next
+			}
+			node.word = true
+		}
+	}
+	return root
+}
+
+// Segment splits text into lowercase words. Thai runs are split with the dictionary,
+// preferring the split that leaves the fewest characters unknown and then the fewest
+// words, so dictionary words are kept whole rather than split into the shorter words they
+// contain; unknown characters between known words are kept together as one word. Other
+// text is split at anything that isn't a letter or digit.
+func Segment(text string) []string {
+	var words []string
+	runes := []rune(normalize(strings.ToLower(text)))
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case isThai(r):
+			j := i
+			for j < len(runes) && isThai(runes[j]) {
+				j++
+			}
+			parts, _ := segmentThai(runes[i:j], fewestWords)
+			words = append(words, parts...)
+			i = j
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && !isThai(runes[j]) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || unicode.IsMark(runes[j])) {
+				j++
+			}
+			words = append(words, string(runes[i:j]))
+			i = j
+		default:
+			i++
+		}
+	}
+	return words
+}
+
+// SegmentForIndex is Segment followed by the shorter dictionary words each Thai word is
+// made of, so a search for part of a compound, like สอน in การสอน, still finds it.
+// Queries are segmented with Segment and match the compound itself.
+func SegmentForIndex(text string) []string {
+	words := Segment(text)
+	for _, word := range words {
+		runes := []rune(word)
+		if !isThai(runes[0]) {
+			continue
+		}
+		if parts, unknown := segmentThai(runes, mostWords); len(parts) > 1 && unknown == 0 {
+			words = append(words, parts...)
+		}
+	}
+	return words
+}
+
+type split struct {
+	reachable bool
+	unknown   int
+	words     int
+	from      int
+	known     bool
+}
+
+// fewestWords and mostWords break ties between splits that leave as many characters unknown
+func fewestWords(s, than split) bool { return s.words < than.words }
+func mostWords(s, than split) bool   { return s.words > than.words }
+
+func (s split) better(than split, prefer func(s, than split) bool) bool {
+	if !than.reachable {
+		return true
+	}
+	if s.unknown != than.unknown {
+		return s.unknown < than.unknown
+	}
+	return prefer(s, than)
+}
+
+// segmentThai splits a Thai run and reports how many of its characters are unknown
+func segmentThai(run []rune, prefer func(s, than split) bool) ([]string, int) {
+	best := make([]split, len(run)+1)
+	best[0] = split{reachable: true}
+
+	for i := 0; i < len(run); i++ {
+		if !best[i].reachable || !canBreak(run, i) {
+			continue
+		}
+
+		node := dictionary
+		for j := i; j < len(run); j++ {
+			node = node.children[run[j]]
+			if node == nil {
+				break
+			}
+			if node.word && canBreak(run, j+1) {
+				candidate := split{reachable: true, unknown: best[i].unknown, words: best[i].words + 1, from: i, known: true}
+				if candidate.better(best[j+1], prefer) {
+					best[j+1] = candidate
+				}
+			}
+		}
+
+		next := i + 1
+		for !canBreak(run, next) {
+			next++
+		}
+		candidate := split{reachable: true, unknown: best[i].unknown + next - i, words: best[i].words + 1, from: i}
+		if candidate.better(best[next], prefer) {
+			best[next] = candidate
+		}
+	}
+
+	var words []string
+	for end := len(run); end > 0; {
+		start := best[end].from
+		// consecutive unknown clusters make up one unknown word
+		if !best[end].known {
+			for start > 0 && !best[start].known {
+				start = best[start].from
+			}
+		}
+		words = append(words, string(run[start:end]))
+		end = start
+	}
+
+	for i, j := 0, len(words)-1; i < j; i, j = i+1, j-1 {
+		words[i], words[j] = words[j], words[i]
+	}
+	return words, best[len(run)].unknown
+}
+
+// canBreak reports whether a word can start at i: not before a vowel or tone mark that
+// belongs to the previous consonant, not before a consonant silenced by a thanthakhat,
+// which ends the syllable before it, and not after a vowel written before its consonant
+func canBreak(run []rune, i int) bool {
+	if i <= 0 || i >= len(run) {
+		return true
+	}
+	if i+1 < len(run) && run[i+1] == 0x0E4C {
+		return false
+	}
+	return !isFollowing(run[i]) && !isLeadingVowel(run[i-1])
+}
+
+func isThai(r rune) bool {
+	return r >= 0x0E01 && r <= 0x0E5B
+}
+
+// isLeadingVowel matches เ แ โ ใ ไ, which are written before the consonant they follow
+func isLeadingVowel(r rune) bool {
+	return r >= 0x0E40 && r <= 0x0E44
+}
+
+// isFollowing matches the vowels, tone marks and signs that attach to the character before
+// them, and the repetition mark ๆ
+func isFollowing(r rune) bool {
+	switch {
+	case r == 0x0E30, r == 0x0E31, r == 0x0E32, r == 0x0E33, r == 0x0E45, r == 0x0E46:
+		return true
+	case r >= 0x0E34 && r <= 0x0E3A:
+		return true
+	case r >= 0x0E47 && r <= 0x0E4E:
+		return true
+	}
+	return false
+}
+
+// normalize writes sara am as one character, as keyboards sometimes type it as nikhahit
+// followed by sara aa
+func normalize(text string) string {
+	return strings.ReplaceAll(text, "ํา", "ำ")
+}
@@ -0,0 +1,119 @@
+package thai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSegment(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "thai title",
+			text:     "เวิร์คช็อปหุ่นยนต์สำหรับเด็ก",
+			expected: []string{"เวิร์คช็อป", "หุ่นยนต์", "สำหรับ", "เด็ก"},
+		},
+		{
+			name:     "dictionary compounds are kept whole",
+			text:     "การสอนว่ายน้ำ",
+			expected: []string{"การสอน", "ว่ายน้ำ"},
+		},
+		{
+			name:     "unknown words are kept whole",
+			text:     "ค่ายมาร์เบิลวูดเด็ก",
+			expected: []string{"ค่าย", "มาร์เบิลวูด", "เด็ก"},
+		},
+		{
+			name:     "mixed thai and english",
+			text:     "เขียนโค้ดด้วย Scratch!",
+			expected: []string{"เขียนโค้ด", "ด้วย", "scratch"},
+		},
+		{
+			name:     "spaces and digits",
+			text:     "เวิร์คช็อปโมเดล 3 มิติ",
+			expected: []string{"เวิร์คช็อป", "โมเดล", "3", "มิติ"},
+		},
+		{
+			name:     "repetition mark stays on its word",
+			text:     "เพลงง่ายๆ",
+			expected: []string{"เพลง", "ง่ายๆ"},
+		},
+		{
+			name:     "sara am typed as two characters",
+			text:     "ว่ายน้ํา",
+			expected: []string{"ว่ายน้ำ"},
+		},
+		{
+			name:     "english only",
+			text:     "Junior Robotics-Workshop",
+			expected: []string{"junior", "robotics", "workshop"},
+		},
+		{
+			name:     "empty",
+			text:     "  ",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Segment(tt.text))
+		})
+	}
+}
+
+func TestSegment_EventTitles(t *testing.T) {
+	// titles from the seed data
+	tests := map[string][]string{
+		"บทเรียนว่ายน้ำ":                     {"บทเรียน", "ว่ายน้ำ"},
+		"เคมีสำหรับเด็ก":                     {"เคมี", "สำหรับ", "เด็ก"},
+		"ชมรมดาราศาสตร์":                     {"ชมรม", "ดาราศาสตร์"},
+		"ฝึกทักษะฟุตบอล":                     {"ฝึก", "ทักษะ", "ฟุตบอล"},
+		"พื้นฐานบาสเกตบอล":                   {"พื้นฐาน", "บาสเกตบอล"},
+		"พื้นฐานกีตาร์":                      {"พื้นฐาน", "กีตาร์"},
+		"เวิร์คช็อปวาดภาพและระบายสี":         {"เวิร์คช็อป", "วาดภาพ", "และ", "ระบายสี"},
+		"เครื่องปั้นดินเผาสำหรับผู้เริ่มต้น": {"เครื่องปั้นดินเผา", "สำหรับ", "ผู้เริ่มต้น"},
+		"ศิลปะดิจิทัลและการออกแบบ":           {"ศิลปะ", "ดิจิทัล", "และ", "การออกแบบ"},
+		"เปียโนสำหรับผู้เริ่มต้น":            {"เปียโน", "สำหรับ", "ผู้เริ่มต้น"},
+		"คณะนักร้องประสานเสียงเด็ก":          {"คณะ", "นักร้องประสานเสียง", "เด็ก"},
+		"ค่ายฤดูร้อนภาษาอังกฤษ":              {"ค่าย", "ฤดูร้อน", "ภาษาอังกฤษ"},
+		"กระโดดเชือกเด็ก":                    {"กระโดด", "เชือก", "เด็ก"},
+	}
+
+	for text, expected := range tests {
+		t.Run(text, func(t *testing.T) {
+			assert.Equal(t, expected, Segment(text))
+		})
+	}
+}
+
+func TestSegmentForIndex(t *testing.T) {
+	words := SegmentForIndex("การสอนว่ายน้ำสำหรับเด็ก")
+
+	// the title's words, then the words of its compounds
+	assert.Equal(t, []string{"การสอน", "ว่ายน้ำ", "สำหรับ", "เด็ก"}, words[:4])
+	assert.Subset(t, words, []string{"การ", "สอน", "ว่าย", "น้ำ"})
+
+	// every word of a query for part of the title is indexed
+	assert.Subset(t, words, Segment("สอนว่ายน้ำเด็ก"))
+
+	// unknown words are not split further
+	assert.Equal(t, Segment("ค่ายมาร์เบิลวูด"), SegmentForIndex("ค่ายมาร์เบิลวูด"))
+}
+
+func TestSegment_NeverSplitsClusters(t *testing.T) {
+	// no word may start with a vowel or tone mark or a consonant silenced by a thanthakhat,
+	// or end with a leading vowel
+	for _, text := range []string{"เครื่องปั้นดินเผาสำหรับผู้เริ่มต้น", "ไม่รู้จักคำนี้เลย", "แอนิเมชันเกมใหม่", "ค่ายโรโบติกส์"} {
+		for _, word := range Segment(text) {
+			runes := []rune(word)
+			assert.False(t, isFollowing(runes[0]), "%q starts with %q", word, runes[0])
+			assert.False(t, len(runes) > 1 && runes[1] == 0x0E4C, "%q starts with a silent consonant", word)
+			assert.False(t, isLeadingVowel(runes[len(runes)-1]), "%q ends with %q", word, runes[len(runes)-1])
+		}
+	}
+}
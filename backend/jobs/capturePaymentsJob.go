@@ -2,57 +2,99 @@ package jobs
 
 import (
 	"context"
-	"log"
+	"fmt"
 	"skillspark/internal/models"
 	"time"
-)
 
-func (j *JobScheduler) CapturePaymentsJob() {
-	defer func() {
-		if r := recover(); r != nil {
-			log.Printf("CapturePaymentsJob panicked: %v", r)
-		}
-	}()
+	"github.com/google/uuid"
+)
 
-	ctx := context.Background()
+type capturePaymentPayload struct {
+	RegistrationID  uuid.UUID `json:"registration_id"`
+	PaymentIntentID string    `json:"payment_intent_id"`
+	// TransferID is the transfer of the organization's share of any credit applied
+	TransferID string `json:"transfer_id,omitempty"`
+}
 
+// CapturePaymentsJob queues a capture task for each authorized payment that is due
+func (j *JobScheduler) CapturePaymentsJob(ctx context.Context, run *RunTracker) {
 	now := time.Now()
 	startWindow := now.Add(-24 * time.Hour)
 	endWindow := now
 
 	registrations, err := j.repo.Registration.GetRegistrationsForCapture(ctx, startWindow, endWindow)
 	if err != nil {
-		log.Printf("Failed to get registrations for capture: %v", err)
+		run.Abortf("failed to get registrations for capture: %v", err)
 		return
 	}
 
 	for _, registration := range registrations {
-		stripeInput := &models.CapturePaymentIntentInput{
-			PaymentIntentID: registration.StripePaymentIntentID,
+		if run.DryRun() {
+			run.Succeed()
+			continue
 		}
 
-		stripeOutput, err := j.stripeClient.CapturePaymentIntent(ctx, stripeInput)
+		_, err := j.enqueueTask(ctx, capturePaymentTaskType, "capture_payment:"+registration.StripePaymentIntentID, capturePaymentPayload{
+			RegistrationID:  registration.ID,
+			PaymentIntentID: registration.StripePaymentIntentID,
+			TransferID:      registration.StripeTransferID,
+		})
 		if err != nil {
-			log.Printf("Failed to capture payment for registration %s, cancelling registration: %v", registration.ID, err)
-			_, cancelErr := j.repo.Registration.CancelRegistration(ctx, &models.CancelRegistrationInput{ID: registration.ID})
-			if cancelErr != nil {
-				log.Printf("Failed to cancel registration %s after capture failure: %v", registration.ID, cancelErr)
-			}
-			continue
-		}
-		if stripeOutput == nil {
-			log.Printf("Nil output for registration %s, skipping", registration.ID)
+			run.Failf(registration.ID, "failed to enqueue capture: %v", err)
 			continue
 		}
 
-		updateInput := &models.UpdateRegistrationPaymentStatusInput{
-			ID: registration.ID,
-		}
-		updateInput.Body.PaymentIntentStatus = stripeOutput.Body.Status
+		run.Succeed()
+	}
+}
 
-		_, err = j.repo.Registration.UpdateRegistrationPaymentStatus(ctx, updateInput)
-		if err != nil {
-			log.Printf("Failed to update payment status for registration %s: %v", registration.ID, err)
+// capturePaymentTask captures one payment intent. A failed capture is retried; only
+// when the last attempt fails is the registration cancelled, giving back any wallet
+// credit that was applied to the payment and taking back the organization's share of it.
+func (j *JobScheduler) capturePaymentTask(ctx context.Context, task models.Task) error {
+	var payload capturePaymentPayload
+	if err := decodeTaskPayload(task, &payload); err != nil {
+		return err
+	}
+
+	stripeInput := &models.CapturePaymentIntentInput{
+		PaymentIntentID: payload.PaymentIntentID,
+		// a retry after the capture went through gets Stripe's original response back
+		IdempotencyKey: "capture:" + payload.PaymentIntentID,
+	}
+
+	stripeOutput, err := j.stripeClient.CapturePaymentIntent(ctx, stripeInput)
+	if err != nil {
+		if !task.IsFinalAttempt() {
+			return fmt.Errorf("failed to capture payment: %w", err)
+		}
+		if payload.TransferID != "" {
+			_, reverseErr := j.stripeClient.ReverseTransfer(ctx, &models.ReverseTransferInput{
+				TransferID:     payload.TransferID,
+				IdempotencyKey: "transfer_reversal:" + payload.RegistrationID.String(),
+			})
+			if reverseErr != nil {
+				return fmt.Errorf("failed to capture payment (%v) and failed to reverse credit transfer: %w", err, reverseErr)
+			}
 		}
+		_, cancelErr := j.repo.Wallet.CancelRegistrationWithCredit(ctx, &models.CancelRegistrationInput{ID: payload.RegistrationID}, 0)
+		if cancelErr != nil {
+			return fmt.Errorf("failed to capture payment (%v) and failed to cancel registration: %w", err, cancelErr)
+		}
+		return fmt.Errorf("failed to capture payment, registration cancelled: %w", err)
+	}
+	if stripeOutput == nil {
+		return fmt.Errorf("nil capture output for payment intent %s", payload.PaymentIntentID)
+	}
+
+	updateInput := &models.UpdateRegistrationPaymentStatusInput{
+		ID: payload.RegistrationID,
 	}
+	updateInput.Body.PaymentIntentStatus = stripeOutput.Body.Status
+
+	if _, err := j.repo.Registration.UpdateRegistrationPaymentStatus(ctx, updateInput); err != nil {
+		return fmt.Errorf("failed to update payment status: %w", err)
+	}
+
+	return nil
 }
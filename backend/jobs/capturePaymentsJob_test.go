@@ -1,6 +1,7 @@
 package jobs
 
 import (
+	"context"
 	"skillspark/internal/models"
 	"skillspark/internal/storage"
 	repomocks "skillspark/internal/storage/repo-mocks"
@@ -11,33 +12,40 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
-func TestCapturePaymentsJob_Success(t *testing.T) {
-	mockRegRepo := new(repomocks.MockRegistrationRepository)
-	mockStripeClient := new(stripemocks.MockStripeClient)
-	mockRepo := &storage.Repository{
-		Registration: mockRegRepo,
+func capturedOutput(piID string) *models.CapturePaymentIntentOutput {
+	return &models.CapturePaymentIntentOutput{
+		Body: struct {
+			PaymentIntentID string `json:"payment_intent_id" doc:"Captured payment intent ID"`
+			Status          string `json:"status" doc:"Payment intent status (should be 'succeeded')"`
+			Amount          int64  `json:"amount" doc:"Amount captured in cents"`
+			Currency        string `json:"currency" doc:"Currency code"`
+		}{
+			PaymentIntentID: piID,
+			Status:          "succeeded",
+		},
 	}
+}
 
+func TestCapturePaymentsJob_EnqueuesTasks(t *testing.T) {
+	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	mockTaskRepo := new(repomocks.MockTaskRepository)
 	scheduler := &JobScheduler{
-		repo:         mockRepo,
-		stripeClient: mockStripeClient,
+		repo: &storage.Repository{Registration: mockRegRepo, Task: mockTaskRepo},
 	}
 
 	reg1 := models.Registration{
 		ID:                    uuid.New(),
 		StripePaymentIntentID: "pi_test_123",
-		OrgStripeAccountID:    "acct_test_123",
 		PaymentIntentStatus:   "requires_capture",
 		Status:                models.RegistrationStatusRegistered,
 		OccurrenceStartTime:   time.Now().Add(24 * time.Hour),
 	}
-
 	reg2 := models.Registration{
 		ID:                    uuid.New(),
 		StripePaymentIntentID: "pi_test_456",
-		OrgStripeAccountID:    "acct_test_456",
 		PaymentIntentStatus:   "requires_capture",
 		Status:                models.RegistrationStatusRegistered,
 		OccurrenceStartTime:   time.Now().Add(24 * time.Hour),
@@ -46,201 +54,251 @@ func TestCapturePaymentsJob_Success(t *testing.T) {
 	mockRegRepo.On("GetRegistrationsForCapture", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
 		Return([]models.Registration{reg1, reg2}, nil)
 
-	mockStripeClient.On("CapturePaymentIntent", mock.Anything, mock.MatchedBy(func(input *models.CapturePaymentIntentInput) bool {
-		return input.PaymentIntentID == "pi_test_123"
-	})).Return(&models.CapturePaymentIntentOutput{
-		Body: struct {
-			PaymentIntentID string `json:"payment_intent_id" doc:"Captured payment intent ID"`
-			Status          string `json:"status" doc:"Payment intent status (should be 'succeeded')"`
-			Amount          int64  `json:"amount" doc:"Amount captured in cents"`
-			Currency        string `json:"currency" doc:"Currency code"`
-		}{
-			PaymentIntentID: "pi_test_123",
-			Status:          "succeeded",
-		},
-	}, nil)
-
-	mockStripeClient.On("CapturePaymentIntent", mock.Anything, mock.MatchedBy(func(input *models.CapturePaymentIntentInput) bool {
-		return input.PaymentIntentID == "pi_test_456"
-	})).Return(&models.CapturePaymentIntentOutput{
-		Body: struct {
-			PaymentIntentID string `json:"payment_intent_id" doc:"Captured payment intent ID"`
-			Status          string `json:"status" doc:"Payment intent status (should be 'succeeded')"`
-			Amount          int64  `json:"amount" doc:"Amount captured in cents"`
-			Currency        string `json:"currency" doc:"Currency code"`
-		}{
-			PaymentIntentID: "pi_test_456",
-			Status:          "succeeded",
-		},
-	}, nil)
-
-	mockRegRepo.On("UpdateRegistrationPaymentStatus", mock.Anything, mock.AnythingOfType("*models.UpdateRegistrationPaymentStatusInput")).
-		Return(&models.UpdateRegistrationPaymentStatusOutput{}, nil).Times(2)
+	for _, reg := range []models.Registration{reg1, reg2} {
+		mockTaskRepo.On("EnqueueTask", mock.Anything, mock.MatchedBy(func(input *models.EnqueueTaskData) bool {
+			return input.TaskType == capturePaymentTaskType &&
+				*input.DedupKey == "capture_payment:"+reg.StripePaymentIntentID &&
+				input.MaxAttempts == defaultTaskMaxAttempts
+		})).Return(&models.Task{ID: uuid.New()}, nil).Once()
+	}
 
-	scheduler.CapturePaymentsJob()
+	run := NewRunTracker(capturePaymentsJobName, false)
+	scheduler.CapturePaymentsJob(context.Background(), run)
 
 	mockRegRepo.AssertExpectations(t)
-	mockStripeClient.AssertExpectations(t)
-	mockRegRepo.AssertNumberOfCalls(t, "UpdateRegistrationPaymentStatus", 2)
+	mockTaskRepo.AssertExpectations(t)
+	assert.Equal(t, 2, run.succeeded)
+	assert.Equal(t, 0, run.failed)
 }
 
 func TestCapturePaymentsJob_NoRegistrations(t *testing.T) {
 	mockRegRepo := new(repomocks.MockRegistrationRepository)
-	mockStripeClient := new(stripemocks.MockStripeClient)
-	mockRepo := &storage.Repository{
-		Registration: mockRegRepo,
-	}
-
+	mockTaskRepo := new(repomocks.MockTaskRepository)
 	scheduler := &JobScheduler{
-		repo:         mockRepo,
-		stripeClient: mockStripeClient,
+		repo: &storage.Repository{Registration: mockRegRepo, Task: mockTaskRepo},
 	}
 
 	mockRegRepo.On("GetRegistrationsForCapture", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
 		Return([]models.Registration{}, nil)
 
-	scheduler.CapturePaymentsJob()
+	run := NewRunTracker(capturePaymentsJobName, false)
+	scheduler.CapturePaymentsJob(context.Background(), run)
 
 	mockRegRepo.AssertExpectations(t)
-	mockStripeClient.AssertNotCalled(t, "CapturePaymentIntent")
-	mockRegRepo.AssertNotCalled(t, "UpdateRegistrationPaymentStatus")
+	mockTaskRepo.AssertNotCalled(t, "EnqueueTask", mock.Anything, mock.Anything)
+	assert.Equal(t, 0, run.processed)
+	assert.Equal(t, models.JobRunStatusSucceeded, run.status())
 }
 
-func TestCapturePaymentsJob_StripeCaptureFailure(t *testing.T) {
+func TestCapturePaymentsJob_EnqueueFailure(t *testing.T) {
 	mockRegRepo := new(repomocks.MockRegistrationRepository)
-	mockStripeClient := new(stripemocks.MockStripeClient)
-	mockRepo := &storage.Repository{
-		Registration: mockRegRepo,
+	mockTaskRepo := new(repomocks.MockTaskRepository)
+	scheduler := &JobScheduler{
+		repo: &storage.Repository{Registration: mockRegRepo, Task: mockTaskRepo},
 	}
 
+	reg := models.Registration{ID: uuid.New(), StripePaymentIntentID: "pi_test_123"}
+	mockRegRepo.On("GetRegistrationsForCapture", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return([]models.Registration{reg}, nil)
+	mockTaskRepo.On("EnqueueTask", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	run := NewRunTracker(capturePaymentsJobName, false)
+	scheduler.CapturePaymentsJob(context.Background(), run)
+
+	assert.Equal(t, 1, run.failed)
+	require.Len(t, run.itemErrors, 1)
+	assert.Equal(t, reg.ID, run.itemErrors[0].ItemID)
+}
+
+func TestCapturePaymentsJob_FetchError(t *testing.T) {
+	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	mockTaskRepo := new(repomocks.MockTaskRepository)
 	scheduler := &JobScheduler{
-		repo:         mockRepo,
-		stripeClient: mockStripeClient,
+		repo: &storage.Repository{Registration: mockRegRepo, Task: mockTaskRepo},
 	}
 
-	reg := models.Registration{
-		ID:                    uuid.New(),
-		StripePaymentIntentID: "pi_test_fail",
-		OrgStripeAccountID:    "acct_test_123",
-		PaymentIntentStatus:   "requires_capture",
-		Status:                models.RegistrationStatusRegistered,
+	mockRegRepo.On("GetRegistrationsForCapture", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(nil, assert.AnError)
+
+	run := NewRunTracker(capturePaymentsJobName, false)
+	scheduler.CapturePaymentsJob(context.Background(), run)
+
+	mockRegRepo.AssertExpectations(t)
+	mockTaskRepo.AssertNotCalled(t, "EnqueueTask", mock.Anything, mock.Anything)
+	assert.Error(t, run.err)
+	assert.Equal(t, models.JobRunStatusFailed, run.status())
+}
+
+func TestCapturePaymentsJob_DryRun(t *testing.T) {
+	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	mockTaskRepo := new(repomocks.MockTaskRepository)
+	scheduler := &JobScheduler{
+		repo: &storage.Repository{Registration: mockRegRepo, Task: mockTaskRepo},
 	}
 
 	mockRegRepo.On("GetRegistrationsForCapture", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
-		Return([]models.Registration{reg}, nil)
+		Return([]models.Registration{{ID: uuid.New(), StripePaymentIntentID: "pi_test_123"}}, nil)
 
-	mockStripeClient.On("CapturePaymentIntent", mock.Anything, mock.AnythingOfType("*models.CapturePaymentIntentInput")).
-		Return(nil, assert.AnError)
+	run := NewRunTracker(capturePaymentsJobName, true)
+	scheduler.CapturePaymentsJob(context.Background(), run)
+
+	mockRegRepo.AssertExpectations(t)
+	mockTaskRepo.AssertNotCalled(t, "EnqueueTask", mock.Anything, mock.Anything)
+	assert.Equal(t, 1, run.succeeded)
+}
+
+func TestCapturePaymentTask_Success(t *testing.T) {
+	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	mockStripeClient := new(stripemocks.MockStripeClient)
+	scheduler := &JobScheduler{
+		repo:         &storage.Repository{Registration: mockRegRepo},
+		stripeClient: mockStripeClient,
+	}
+
+	regID := uuid.New()
+	task := newTestTask(t, capturePaymentTaskType, capturePaymentPayload{RegistrationID: regID, PaymentIntentID: "pi_test_123"}, 1)
+
+	mockStripeClient.On("CapturePaymentIntent", mock.Anything, &models.CapturePaymentIntentInput{
+		PaymentIntentID: "pi_test_123",
+		IdempotencyKey:  "capture:pi_test_123",
+	}).Return(capturedOutput("pi_test_123"), nil)
 
-	mockRegRepo.On("CancelRegistration", mock.Anything, mock.MatchedBy(func(input *models.CancelRegistrationInput) bool {
-		return input.ID == reg.ID
-	})).Return(&models.CancelRegistrationOutput{}, nil)
+	mockRegRepo.On("UpdateRegistrationPaymentStatus", mock.Anything, mock.MatchedBy(func(input *models.UpdateRegistrationPaymentStatusInput) bool {
+		return input.ID == regID && input.Body.PaymentIntentStatus == "succeeded"
+	})).Return(&models.UpdateRegistrationPaymentStatusOutput{}, nil)
 
-	scheduler.CapturePaymentsJob()
+	err := scheduler.capturePaymentTask(context.Background(), task)
 
+	require.NoError(t, err)
 	mockRegRepo.AssertExpectations(t)
 	mockStripeClient.AssertExpectations(t)
-	mockRegRepo.AssertNotCalled(t, "UpdateRegistrationPaymentStatus")
 }
 
-func TestCapturePaymentsJob_StripeCaptureFailure_CancelError(t *testing.T) {
+func TestCapturePaymentTask_StripeFailureRetriesWithoutCancelling(t *testing.T) {
 	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	mockWalletRepo := new(repomocks.MockWalletRepository)
 	mockStripeClient := new(stripemocks.MockStripeClient)
-	mockRepo := &storage.Repository{
-		Registration: mockRegRepo,
+	scheduler := &JobScheduler{
+		repo:         &storage.Repository{Registration: mockRegRepo, Wallet: mockWalletRepo},
+		stripeClient: mockStripeClient,
 	}
 
+	task := newTestTask(t, capturePaymentTaskType, capturePaymentPayload{RegistrationID: uuid.New(), PaymentIntentID: "pi_test_fail"}, 1)
+
+	mockStripeClient.On("CapturePaymentIntent", mock.Anything, mock.AnythingOfType("*models.CapturePaymentIntentInput")).
+		Return(nil, assert.AnError)
+
+	err := scheduler.capturePaymentTask(context.Background(), task)
+
+	require.Error(t, err)
+	mockWalletRepo.AssertNotCalled(t, "CancelRegistrationWithCredit", mock.Anything, mock.Anything, mock.Anything)
+	mockRegRepo.AssertNotCalled(t, "UpdateRegistrationPaymentStatus", mock.Anything, mock.Anything)
+}
+
+func TestCapturePaymentTask_FinalFailureCancelsRegistration(t *testing.T) {
+	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	mockWalletRepo := new(repomocks.MockWalletRepository)
+	mockStripeClient := new(stripemocks.MockStripeClient)
 	scheduler := &JobScheduler{
-		repo:         mockRepo,
+		repo:         &storage.Repository{Registration: mockRegRepo, Wallet: mockWalletRepo},
 		stripeClient: mockStripeClient,
 	}
 
-	reg := models.Registration{
-		ID:                    uuid.New(),
-		StripePaymentIntentID: "pi_test_fail",
-		OrgStripeAccountID:    "acct_test_123",
-		PaymentIntentStatus:   "requires_capture",
-		Status:                models.RegistrationStatusRegistered,
+	regID := uuid.New()
+	task := newTestTask(t, capturePaymentTaskType, capturePaymentPayload{RegistrationID: regID, PaymentIntentID: "pi_test_fail"}, defaultTaskMaxAttempts)
+
+	mockStripeClient.On("CapturePaymentIntent", mock.Anything, mock.AnythingOfType("*models.CapturePaymentIntentInput")).
+		Return(nil, assert.AnError)
+
+	// the credit applied on top of the authorized card charge goes back to the wallet
+	mockWalletRepo.On("CancelRegistrationWithCredit", mock.Anything, mock.MatchedBy(func(input *models.CancelRegistrationInput) bool {
+		return input.ID == regID
+	}), 0).Return(&models.CancelRegistrationOutput{}, nil)
+
+	err := scheduler.capturePaymentTask(context.Background(), task)
+
+	require.Error(t, err)
+	mockWalletRepo.AssertExpectations(t)
+	mockStripeClient.AssertExpectations(t)
+	mockRegRepo.AssertNotCalled(t, "UpdateRegistrationPaymentStatus", mock.Anything, mock.Anything)
+}
+
+func TestCapturePaymentTask_FinalFailure_CancelError(t *testing.T) {
+	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	mockWalletRepo := new(repomocks.MockWalletRepository)
+	mockStripeClient := new(stripemocks.MockStripeClient)
+	scheduler := &JobScheduler{
+		repo:         &storage.Repository{Registration: mockRegRepo, Wallet: mockWalletRepo},
+		stripeClient: mockStripeClient,
 	}
 
-	mockRegRepo.On("GetRegistrationsForCapture", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
-		Return([]models.Registration{reg}, nil)
+	task := newTestTask(t, capturePaymentTaskType, capturePaymentPayload{RegistrationID: uuid.New(), PaymentIntentID: "pi_test_fail"}, defaultTaskMaxAttempts)
 
 	mockStripeClient.On("CapturePaymentIntent", mock.Anything, mock.AnythingOfType("*models.CapturePaymentIntentInput")).
 		Return(nil, assert.AnError)
 
-	mockRegRepo.On("CancelRegistration", mock.Anything, mock.AnythingOfType("*models.CancelRegistrationInput")).
+	mockWalletRepo.On("CancelRegistrationWithCredit", mock.Anything, mock.AnythingOfType("*models.CancelRegistrationInput"), 0).
 		Return(nil, assert.AnError)
 
 	// Should not panic even when both capture and cancel fail
-	scheduler.CapturePaymentsJob()
+	err := scheduler.capturePaymentTask(context.Background(), task)
 
-	mockRegRepo.AssertExpectations(t)
+	require.Error(t, err)
+	mockWalletRepo.AssertExpectations(t)
 	mockStripeClient.AssertExpectations(t)
 }
 
-func TestCapturePaymentsJob_DatabaseUpdateFailure(t *testing.T) {
+func TestCapturePaymentTask_DatabaseUpdateFailure(t *testing.T) {
 	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	mockWalletRepo := new(repomocks.MockWalletRepository)
 	mockStripeClient := new(stripemocks.MockStripeClient)
-	mockRepo := &storage.Repository{
-		Registration: mockRegRepo,
-	}
-
 	scheduler := &JobScheduler{
-		repo:         mockRepo,
+		repo:         &storage.Repository{Registration: mockRegRepo, Wallet: mockWalletRepo},
 		stripeClient: mockStripeClient,
 	}
 
-	reg := models.Registration{
-		ID:                    uuid.New(),
-		StripePaymentIntentID: "pi_test_123",
-		OrgStripeAccountID:    "acct_test_123",
-		PaymentIntentStatus:   "requires_capture",
-		Status:                models.RegistrationStatusRegistered,
-	}
-
-	mockRegRepo.On("GetRegistrationsForCapture", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
-		Return([]models.Registration{reg}, nil)
+	task := newTestTask(t, capturePaymentTaskType, capturePaymentPayload{RegistrationID: uuid.New(), PaymentIntentID: "pi_test_123"}, defaultTaskMaxAttempts)
 
 	mockStripeClient.On("CapturePaymentIntent", mock.Anything, mock.AnythingOfType("*models.CapturePaymentIntentInput")).
-		Return(&models.CapturePaymentIntentOutput{
-			Body: struct {
-				PaymentIntentID string `json:"payment_intent_id" doc:"Captured payment intent ID"`
-				Status          string `json:"status" doc:"Payment intent status (should be 'succeeded')"`
-				Amount          int64  `json:"amount" doc:"Amount captured in cents"`
-				Currency        string `json:"currency" doc:"Currency code"`
-			}{
-				PaymentIntentID: "pi_test_123",
-				Status:          "succeeded",
-			},
-		}, nil)
+		Return(capturedOutput("pi_test_123"), nil)
 
 	mockRegRepo.On("UpdateRegistrationPaymentStatus", mock.Anything, mock.AnythingOfType("*models.UpdateRegistrationPaymentStatusInput")).
 		Return(nil, assert.AnError)
 
-	scheduler.CapturePaymentsJob()
+	err := scheduler.capturePaymentTask(context.Background(), task)
 
+	// the capture went through, so even on the final attempt the registration is kept
+	require.Error(t, err)
 	mockRegRepo.AssertExpectations(t)
-	mockStripeClient.AssertExpectations(t)
+	mockWalletRepo.AssertNotCalled(t, "CancelRegistrationWithCredit", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestCapturePaymentsJob_FetchError(t *testing.T) {
-	mockRegRepo := new(repomocks.MockRegistrationRepository)
+func TestCapturePaymentTask_FinalFailureReversesCreditTransfer(t *testing.T) {
+	mockWalletRepo := new(repomocks.MockWalletRepository)
 	mockStripeClient := new(stripemocks.MockStripeClient)
-	mockRepo := &storage.Repository{
-		Registration: mockRegRepo,
-	}
-
 	scheduler := &JobScheduler{
-		repo:         mockRepo,
+		repo:         &storage.Repository{Wallet: mockWalletRepo},
 		stripeClient: mockStripeClient,
 	}
 
-	mockRegRepo.On("GetRegistrationsForCapture", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+	regID := uuid.New()
+	task := newTestTask(t, capturePaymentTaskType, capturePaymentPayload{RegistrationID: regID, PaymentIntentID: "pi_test_fail", TransferID: "tr_credit_share"}, defaultTaskMaxAttempts)
+
+	mockStripeClient.On("CapturePaymentIntent", mock.Anything, mock.AnythingOfType("*models.CapturePaymentIntentInput")).
 		Return(nil, assert.AnError)
 
-	scheduler.CapturePaymentsJob()
+	// the credit goes back to the wallet, so the organization's share of it is taken back
+	mockStripeClient.On("ReverseTransfer", mock.Anything, &models.ReverseTransferInput{
+		TransferID:     "tr_credit_share",
+		IdempotencyKey: "transfer_reversal:" + regID.String(),
+	}).Return(&models.ReverseTransferOutput{}, nil)
 
-	mockRegRepo.AssertExpectations(t)
-	mockStripeClient.AssertNotCalled(t, "CapturePaymentIntent")
+	mockWalletRepo.On("CancelRegistrationWithCredit", mock.Anything, mock.AnythingOfType("*models.CancelRegistrationInput"), 0).
+		Return(&models.CancelRegistrationOutput{}, nil)
+
+	err := scheduler.capturePaymentTask(context.Background(), task)
+
+	require.Error(t, err)
+	mockWalletRepo.AssertExpectations(t)
+	mockStripeClient.AssertExpectations(t)
 }
@@ -0,0 +1,87 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"skillspark/internal/delivery"
+	"skillspark/internal/models"
+)
+
+// pushReceiptBatchSize is how many delivered pushes one run checks
+const pushReceiptBatchSize = 1000
+
+type pushReceiptClient interface {
+	GetReceipts(ctx context.Context, ticketIDs []string) (map[string]delivery.ExpoReceipt, error)
+}
+
+// CheckPushReceiptsJob asks Expo what became of pushes it accepted. A push ticket only
+// means Expo took the message; the receipt, ready some minutes later, says whether Apple
+// or Google did. Tokens for uninstalled apps are cleared so they aren't sent to again.
+func (j *JobScheduler) CheckPushReceiptsJob(ctx context.Context, run *RunTracker) {
+	notifications, err := j.repo.Notification.GetPushNotificationsAwaitingReceipt(ctx, pushReceiptBatchSize)
+	if err != nil {
+		run.Abortf("failed to get pushes awaiting receipts: %v", err)
+		return
+	}
+
+	if len(notifications) == 0 {
+		slog.Info("No pushes awaiting receipts")
+		return
+	}
+
+	ticketIDs := make([]string, 0, len(notifications))
+	for _, notification := range notifications {
+		ticketIDs = append(ticketIDs, *notification.ProviderMessageID)
+	}
+
+	receipts, err := j.pushReceipts.GetReceipts(ctx, ticketIDs)
+	if err != nil {
+		run.Abortf("failed to get push receipts: %v", err)
+		return
+	}
+
+	slog.Info("Checking push receipts", "pushes", len(notifications), "receipts", len(receipts))
+
+	outcomes := delivery.NewOutcomeRecorder(j.repo.Notification, j.repo.Guardian)
+	for _, notification := range notifications {
+		// not ready yet; the next run asks again until the push ages out of the window
+		receipt, ok := receipts[*notification.ProviderMessageID]
+		if !ok {
+			continue
+		}
+
+		if run.DryRun() {
+			run.Succeed()
+			continue
+		}
+
+		event := &models.DeliveryOutcomeEvent{
+			Outcome:           models.DeliveryOutcomeDelivered,
+			Channel:           models.NotificationTypePush,
+			Provider:          "expo",
+			ProviderMessageID: notification.ProviderMessageID,
+			NotificationID:    &notification.ID,
+			GuardianID:        notification.GuardianID,
+		}
+		if notification.RecipientPushToken != nil {
+			event.Recipient = *notification.RecipientPushToken
+		}
+		if receipt.Status != "ok" {
+			event.Outcome = models.DeliveryOutcomeFailed
+			if receipt.Details.Error == delivery.ExpoErrorDeviceNotRegistered {
+				event.Outcome = models.DeliveryOutcomeInvalidToken
+			}
+			detail := receipt.Message
+			if receipt.Details.Error != "" {
+				detail = receipt.Details.Error + ": " + detail
+			}
+			event.Detail = &detail
+		}
+
+		if err := outcomes.Record(ctx, event); err != nil {
+			run.Failf(notification.ID, "failed to record push receipt: %v", err)
+			continue
+		}
+		run.Succeed()
+	}
+}
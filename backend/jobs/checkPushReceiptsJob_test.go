@@ -0,0 +1,87 @@
+package jobs
+
+import (
+	"context"
+	"skillspark/internal/delivery"
+	"skillspark/internal/models"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type fakePushReceipts struct {
+	receipts map[string]delivery.ExpoReceipt
+	err      error
+}
+
+func (f *fakePushReceipts) GetReceipts(ctx context.Context, ticketIDs []string) (map[string]delivery.ExpoReceipt, error) {
+	return f.receipts, f.err
+}
+
+func awaitingPush(ticketID, token string) models.Notification {
+	guardianID := uuid.New()
+	return models.Notification{
+		ID:                 uuid.New(),
+		NotificationType:   models.NotificationTypePush,
+		GuardianID:         &guardianID,
+		RecipientPushToken: &token,
+		ProviderMessageID:  &ticketID,
+	}
+}
+
+func TestCheckPushReceiptsJob(t *testing.T) {
+	delivered := awaitingPush("ticket-ok", "ExponentPushToken[ok]")
+	unregistered := awaitingPush("ticket-gone", "ExponentPushToken[gone]")
+	pending := awaitingPush("ticket-pending", "ExponentPushToken[pending]")
+
+	mockNotifRepo := new(repomocks.MockNotificationRepository)
+	mockGuardianRepo := new(repomocks.MockGuardianRepository)
+	gone := delivery.ExpoReceipt{Status: "error", Message: "not registered"}
+	gone.Details.Error = delivery.ExpoErrorDeviceNotRegistered
+	scheduler := &JobScheduler{
+		repo: &storage.Repository{Notification: mockNotifRepo, Guardian: mockGuardianRepo},
+		pushReceipts: &fakePushReceipts{receipts: map[string]delivery.ExpoReceipt{
+			"ticket-ok":   {Status: "ok"},
+			"ticket-gone": gone,
+		}},
+	}
+
+	mockNotifRepo.On("GetPushNotificationsAwaitingReceipt", mock.Anything, pushReceiptBatchSize).
+		Return([]models.Notification{delivered, unregistered, pending}, nil)
+	mockNotifRepo.On("RecordNotificationOutcome", mock.Anything, mock.MatchedBy(func(event *models.DeliveryOutcomeEvent) bool {
+		return *event.NotificationID == delivered.ID && event.Outcome == models.DeliveryOutcomeDelivered
+	})).Return(&models.OutcomeNotification{ID: delivered.ID}, nil).Once()
+	mockNotifRepo.On("RecordNotificationOutcome", mock.Anything, mock.MatchedBy(func(event *models.DeliveryOutcomeEvent) bool {
+		return *event.NotificationID == unregistered.ID && event.Outcome == models.DeliveryOutcomeInvalidToken
+	})).Return(&models.OutcomeNotification{ID: unregistered.ID}, nil).Once()
+	mockGuardianRepo.On("ClearExpoPushToken", mock.Anything, "ExponentPushToken[gone]").Return(int64(1), nil).Once()
+
+	run := NewRunTracker(checkPushReceiptsJobName, false)
+	scheduler.CheckPushReceiptsJob(context.Background(), run)
+
+	mockNotifRepo.AssertExpectations(t)
+	mockGuardianRepo.AssertExpectations(t)
+	assert.Equal(t, 2, run.succeeded)
+	assert.Equal(t, models.JobRunStatusSucceeded, run.status())
+}
+
+func TestCheckPushReceiptsJob_ReceiptError(t *testing.T) {
+	mockNotifRepo := new(repomocks.MockNotificationRepository)
+	scheduler := &JobScheduler{
+		repo:         &storage.Repository{Notification: mockNotifRepo},
+		pushReceipts: &fakePushReceipts{err: assert.AnError},
+	}
+
+	mockNotifRepo.On("GetPushNotificationsAwaitingReceipt", mock.Anything, pushReceiptBatchSize).
+		Return([]models.Notification{awaitingPush("ticket-1", "ExponentPushToken[1]")}, nil)
+
+	run := NewRunTracker(checkPushReceiptsJobName, false)
+	scheduler.CheckPushReceiptsJob(context.Background(), run)
+
+	mockNotifRepo.AssertNotCalled(t, "RecordNotificationOutcome", mock.Anything, mock.Anything)
+	assert.Equal(t, models.JobRunStatusFailed, run.status())
+}
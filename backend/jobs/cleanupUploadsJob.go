@@ -0,0 +1,51 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+)
+
+// uploadCleanupBatchSize is how many upload sessions one run cleans up
+const uploadCleanupBatchSize = 200
+
+// CleanupUploadsJob deletes the staging objects of direct uploads that are no longer
+// needed: uploads that were finalized, whose image now lives under its target, and uploads
+// that expired without being finalized. Finalize usually deletes its own staging object,
+// so finalized sessions only show up here when that failed.
+func (j *JobScheduler) CleanupUploadsJob(ctx context.Context, run *RunTracker) {
+	sessions, err := j.repo.Upload.GetUploadSessionsToCleanUp(ctx, uploadCleanupBatchSize)
+	if err != nil {
+		run.Abortf("failed to get upload sessions to clean up: %v", err)
+		return
+	}
+
+	if len(sessions) == 0 {
+		slog.Info("No uploads to clean up")
+		return
+	}
+
+	if run.DryRun() {
+		for range sessions {
+			run.Succeed()
+		}
+		return
+	}
+
+	slog.Info("Cleaning up uploads", "count", len(sessions))
+
+	for _, session := range sessions {
+		// deleting a key that was never uploaded to succeeds, so abandoned sessions are
+		// marked like the rest
+		if err := j.s3Client.DeleteObject(ctx, session.StagingKey); err != nil {
+			run.Failf(session.ID, "failed to delete staged upload: %v", err)
+			continue
+		}
+
+		if err := j.repo.Upload.MarkUploadSessionCleanedUp(ctx, session.ID); err != nil {
+			run.Failf(session.ID, "failed to mark upload session cleaned up: %v", err)
+			continue
+		}
+
+		run.Succeed()
+	}
+}
@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/models"
+	s3mocks "skillspark/internal/s3_client/mocks"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCleanupUploadsJob(t *testing.T) {
+	expired := models.UploadSession{ID: uuid.New(), StagingKey: "uploads/expired", Status: models.UploadSessionStatusPending}
+	finalized := models.UploadSession{ID: uuid.New(), StagingKey: "uploads/finalized", Status: models.UploadSessionStatusFinalized}
+	stuck := models.UploadSession{ID: uuid.New(), StagingKey: "uploads/stuck", Status: models.UploadSessionStatusPending}
+
+	mockUploadRepo := new(repomocks.MockUploadRepository)
+	mockS3 := new(s3mocks.S3ClientMock)
+	scheduler := &JobScheduler{
+		repo:     &storage.Repository{Upload: mockUploadRepo},
+		s3Client: mockS3,
+	}
+
+	mockUploadRepo.On("GetUploadSessionsToCleanUp", mock.Anything, uploadCleanupBatchSize).
+		Return([]models.UploadSession{expired, finalized, stuck}, nil)
+	mockS3.On("DeleteObject", mock.Anything, expired.StagingKey).Return(nil)
+	mockS3.On("DeleteObject", mock.Anything, finalized.StagingKey).Return(nil)
+	mockS3.On("DeleteObject", mock.Anything, stuck.StagingKey).Return(errors.New("access denied"))
+	mockUploadRepo.On("MarkUploadSessionCleanedUp", mock.Anything, expired.ID).Return(nil).Once()
+	mockUploadRepo.On("MarkUploadSessionCleanedUp", mock.Anything, finalized.ID).Return(nil).Once()
+
+	run := NewRunTracker(cleanupUploadsJobName, false)
+	scheduler.CleanupUploadsJob(context.Background(), run)
+
+	mockUploadRepo.AssertExpectations(t)
+	mockS3.AssertExpectations(t)
+	// a session whose object couldn't be deleted stays to be retried on the next run
+	mockUploadRepo.AssertNotCalled(t, "MarkUploadSessionCleanedUp", mock.Anything, stuck.ID)
+	assert.Equal(t, 2, run.succeeded)
+	assert.Equal(t, models.JobRunStatusPartiallyFailed, run.status())
+}
+
+func TestCleanupUploadsJob_DryRunDeletesNothing(t *testing.T) {
+	mockUploadRepo := new(repomocks.MockUploadRepository)
+	mockS3 := new(s3mocks.S3ClientMock)
+	scheduler := &JobScheduler{
+		repo:     &storage.Repository{Upload: mockUploadRepo},
+		s3Client: mockS3,
+	}
+
+	mockUploadRepo.On("GetUploadSessionsToCleanUp", mock.Anything, uploadCleanupBatchSize).
+		Return([]models.UploadSession{{ID: uuid.New(), StagingKey: "uploads/a"}}, nil)
+
+	run := NewRunTracker(cleanupUploadsJobName, true)
+	scheduler.CleanupUploadsJob(context.Background(), run)
+
+	mockS3.AssertNotCalled(t, "DeleteObject", mock.Anything, mock.Anything)
+	mockUploadRepo.AssertNotCalled(t, "MarkUploadSessionCleanedUp", mock.Anything, mock.Anything)
+	assert.Equal(t, 1, run.succeeded)
+}
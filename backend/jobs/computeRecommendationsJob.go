@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"skillspark/internal/models"
+	"skillspark/internal/recommend"
+)
+
+// recommendationsPerChild is how many collaborative-filtering scores are kept per child
+const recommendationsPerChild = 50
+
+// ComputeRecommendationsJob learns which events go together from every registration, save
+// and review, and replaces each child's stored collaborative-filtering scores, which
+// recommendations blend with the child's interests. Each child with scores counts as one
+// item of the run.
+func (j *JobScheduler) ComputeRecommendationsJob(ctx context.Context, run *RunTracker) {
+	interactions, err := j.repo.Recommendation.GetRecommendationInteractions(ctx)
+	if err != nil {
+		run.Abortf("failed to get recommendation interactions: %v", err)
+		return
+	}
+
+	recommendations := recommend.ForChildren(interactions, recommendationsPerChild)
+	children := countChildren(recommendations)
+
+	if run.DryRun() {
+		for range children {
+			run.Succeed()
+		}
+		return
+	}
+
+	slog.Info("Storing recommendations", "interactions", len(interactions), "children", children, "recommendations", len(recommendations))
+
+	// scores are replaced all at once, so they either all fail or all succeed
+	if err := j.repo.Recommendation.ReplaceChildRecommendations(ctx, recommendations); err != nil {
+		run.Abortf("failed to store recommendations: %v", err)
+		return
+	}
+
+	for range children {
+		run.Succeed()
+	}
+}
+
+func countChildren(recommendations []models.ChildRecommendation) int {
+	children := 0
+	for i, recommendation := range recommendations {
+		// recommendations are grouped by child
+		if i == 0 || recommendation.ChildID != recommendations[i-1].ChildID {
+			children++
+		}
+	}
+	return children
+}
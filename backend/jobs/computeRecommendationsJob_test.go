@@ -0,0 +1,80 @@
+package jobs
+
+import (
+	"context"
+	"skillspark/internal/models"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// robotics families also book coding; newcomer has only saved robotics
+func recommendationInteractions(newcomer uuid.UUID) []models.RecommendationInteraction {
+	robotics, coding := uuid.New(), uuid.New()
+	var interactions []models.RecommendationInteraction
+	for range 3 {
+		guardian, child := uuid.New(), uuid.New()
+		interactions = append(interactions,
+			models.RecommendationInteraction{GuardianID: guardian, ChildID: child, EventID: robotics, Kind: models.RecommendationInteractionRegistration, CreatedAt: time.Now()},
+			models.RecommendationInteraction{GuardianID: guardian, ChildID: child, EventID: coding, Kind: models.RecommendationInteractionRegistration, CreatedAt: time.Now()},
+		)
+	}
+	return append(interactions, models.RecommendationInteraction{GuardianID: uuid.New(), ChildID: newcomer, EventID: robotics, Kind: models.RecommendationInteractionSaved})
+}
+
+func TestComputeRecommendationsJob(t *testing.T) {
+	mockRecRepo := new(repomocks.MockRecommendationRepository)
+	scheduler := &JobScheduler{repo: &storage.Repository{Recommendation: mockRecRepo}}
+
+	newcomer := uuid.New()
+	mockRecRepo.On("GetRecommendationInteractions", mock.Anything).Return(recommendationInteractions(newcomer), nil)
+
+	var stored []models.ChildRecommendation
+	mockRecRepo.On("ReplaceChildRecommendations", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(1).([]models.ChildRecommendation) }).
+		Return(nil)
+
+	run := NewRunTracker(computeRecommendationsJobName, false)
+	scheduler.ComputeRecommendationsJob(context.Background(), run)
+
+	mockRecRepo.AssertExpectations(t)
+	// only the newcomer has an event left to recommend
+	require.Len(t, stored, 1)
+	assert.Equal(t, newcomer, stored[0].ChildID)
+	assert.Equal(t, 1.0, stored[0].Score)
+	assert.Equal(t, 1, run.succeeded)
+	assert.Equal(t, models.JobRunStatusSucceeded, run.status())
+}
+
+func TestComputeRecommendationsJob_DryRunWritesNothing(t *testing.T) {
+	mockRecRepo := new(repomocks.MockRecommendationRepository)
+	scheduler := &JobScheduler{repo: &storage.Repository{Recommendation: mockRecRepo}}
+
+	mockRecRepo.On("GetRecommendationInteractions", mock.Anything).Return(recommendationInteractions(uuid.New()), nil)
+
+	run := NewRunTracker(computeRecommendationsJobName, true)
+	scheduler.ComputeRecommendationsJob(context.Background(), run)
+
+	mockRecRepo.AssertNotCalled(t, "ReplaceChildRecommendations", mock.Anything, mock.Anything)
+	assert.Equal(t, 1, run.succeeded)
+}
+
+func TestComputeRecommendationsJob_StoreFails(t *testing.T) {
+	mockRecRepo := new(repomocks.MockRecommendationRepository)
+	scheduler := &JobScheduler{repo: &storage.Repository{Recommendation: mockRecRepo}}
+
+	mockRecRepo.On("GetRecommendationInteractions", mock.Anything).Return(recommendationInteractions(uuid.New()), nil)
+	mockRecRepo.On("ReplaceChildRecommendations", mock.Anything, mock.Anything).Return(assert.AnError)
+
+	run := NewRunTracker(computeRecommendationsJobName, false)
+	scheduler.ComputeRecommendationsJob(context.Background(), run)
+
+	assert.Equal(t, 0, run.succeeded)
+	assert.Equal(t, models.JobRunStatusFailed, run.status())
+}
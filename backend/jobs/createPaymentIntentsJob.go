@@ -2,112 +2,227 @@ package jobs
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"skillspark/internal/models"
+
+	"github.com/google/uuid"
 )
 
-func (j *JobScheduler) CreatePaymentIntentsJob() {
-	defer func() {
-		if r := recover(); r != nil {
-			log.Printf("CreatePaymentIntentsJob panicked: %v", r)
-		}
-	}()
+// platformFeePercentage is the platform's share of a booking's price, the rest goes to the organization
+const platformFeePercentage = 10
 
-	ctx := context.Background()
+type createPaymentIntentPayload struct {
+	RegistrationID    uuid.UUID `json:"registration_id"`
+	GuardianID        uuid.UUID `json:"guardian_id"`
+	EventOccurrenceID uuid.UUID `json:"event_occurrence_id"`
+}
 
+// CreatePaymentIntentsJob queues a payment task for each registration without a payment
+func (j *JobScheduler) CreatePaymentIntentsJob(ctx context.Context, run *RunTracker) {
 	registrations, err := j.repo.Registration.GetRegistrationsForPaymentCreation(ctx)
 	if err != nil {
-		log.Printf("CreatePaymentIntentsJob: failed to get registrations: %v", err)
+		run.Abortf("failed to get registrations: %v", err)
 		return
 	}
 
 	for _, reg := range registrations {
-		guardian, err := j.repo.Guardian.GetGuardianByID(ctx, reg.GuardianID)
-		if err != nil {
-			log.Printf("CreatePaymentIntentsJob: failed to get guardian %s for registration %s: %v", reg.GuardianID, reg.ID, err)
-			continue
-		}
-		if guardian == nil {
-			log.Printf("CreatePaymentIntentsJob: nil guardian for registration %s, skipping", reg.ID)
-			continue
-		}
-		if guardian.StripeCustomerID == nil {
-			log.Printf("CreatePaymentIntentsJob: guardian %s has no Stripe customer ID, skipping registration %s", reg.GuardianID, reg.ID)
+		if run.DryRun() {
+			run.Succeed()
 			continue
 		}
 
-		paymentMethods, err := j.stripeClient.GetPaymentMethodsByCustomerID(ctx, *guardian.StripeCustomerID)
+		_, err := j.enqueueTask(ctx, createPaymentIntentTaskType, "create_payment_intent:"+reg.ID.String(), createPaymentIntentPayload{
+			RegistrationID:    reg.ID,
+			GuardianID:        reg.GuardianID,
+			EventOccurrenceID: reg.EventOccurrenceID,
+		})
 		if err != nil {
-			log.Printf("CreatePaymentIntentsJob: failed to get payment methods for guardian %s: %v", reg.GuardianID, err)
-			continue
-		}
-		if paymentMethods == nil || len(paymentMethods.Body.PaymentMethods) == 0 {
-			log.Printf("CreatePaymentIntentsJob: guardian %s has no payment methods, skipping registration %s", reg.GuardianID, reg.ID)
+			run.Failf(reg.ID, "failed to enqueue payment creation: %v", err)
 			continue
 		}
-		paymentMethodID := paymentMethods.Body.PaymentMethods[0].ID
 
-		eventOccurrence, err := j.repo.EventOccurrence.GetEventOccurrenceByID(ctx, reg.EventOccurrenceID, "en-US")
-		if err != nil {
-			log.Printf("CreatePaymentIntentsJob: failed to get event occurrence %s for registration %s: %v", reg.EventOccurrenceID, reg.ID, err)
-			continue
-		}
-		if eventOccurrence == nil {
-			log.Printf("CreatePaymentIntentsJob: nil event occurrence %s for registration %s, skipping", reg.EventOccurrenceID, reg.ID)
-			continue
-		}
+		run.Succeed()
+	}
+}
 
-		org, err := j.repo.Organization.GetOrganizationByID(ctx, eventOccurrence.Event.OrganizationID, "en-US")
+func (j *JobScheduler) createPaymentIntentTask(ctx context.Context, task models.Task) error {
+	var payload createPaymentIntentPayload
+	if err := decodeTaskPayload(task, &payload); err != nil {
+		return err
+	}
+
+	return j.createPaymentIntent(ctx, models.RegistrationForPayment{
+		ID:                payload.RegistrationID,
+		GuardianID:        payload.GuardianID,
+		EventOccurrenceID: payload.EventOccurrenceID,
+	})
+}
+
+// createPaymentIntent pays for one registration, from wallet credit first and the
+// guardian's saved card for any remainder. The credit applied on the first attempt stays
+// applied across retries, so each attempt charges the same remainder; it goes back to the
+// wallet if the registration is cancelled. The card charge only carries the organization's
+// share of the remainder, so its share of the credit is transferred separately.
+func (j *JobScheduler) createPaymentIntent(ctx context.Context, reg models.RegistrationForPayment) error {
+	guardian, err := j.repo.Guardian.GetGuardianByID(ctx, reg.GuardianID)
+	if err != nil {
+		return fmt.Errorf("failed to get guardian %s: %w", reg.GuardianID, err)
+	}
+	if guardian == nil {
+		return fmt.Errorf("nil guardian %s", reg.GuardianID)
+	}
+
+	eventOccurrence, err := j.repo.EventOccurrence.GetEventOccurrenceByID(ctx, reg.EventOccurrenceID, "en-US")
+	if err != nil {
+		return fmt.Errorf("failed to get event occurrence %s: %w", reg.EventOccurrenceID, err)
+	}
+	if eventOccurrence == nil {
+		return fmt.Errorf("nil event occurrence %s", reg.EventOccurrenceID)
+	}
+
+	org, err := j.repo.Organization.GetOrganizationByID(ctx, eventOccurrence.Event.OrganizationID, "en-US")
+	if err != nil {
+		return fmt.Errorf("failed to get organization: %w", err)
+	}
+	if org == nil {
+		return errors.New("nil organization")
+	}
+	if org.StripeAccountID == nil {
+		return fmt.Errorf("organization %s has no Stripe account ID", eventOccurrence.Event.OrganizationID)
+	}
+
+	// wallet credit is spent before the card is charged
+	credit := 0
+	if eventOccurrence.Price > 0 {
+		credit, err = j.repo.Wallet.ApplyCreditToRegistration(ctx, reg.ID, reg.GuardianID, eventOccurrence.Currency, eventOccurrence.Price)
 		if err != nil {
-			log.Printf("CreatePaymentIntentsJob: failed to get organization for registration %s: %v", reg.ID, err)
-			continue
-		}
-		if org == nil {
-			log.Printf("CreatePaymentIntentsJob: nil organization for registration %s, skipping", reg.ID)
-			continue
-		}
-		if org.StripeAccountID == nil {
-			log.Printf("CreatePaymentIntentsJob: organization %s has no Stripe account ID, skipping registration %s", eventOccurrence.Event.OrganizationID, reg.ID)
-			continue
+			return fmt.Errorf("failed to apply wallet credit: %w", err)
 		}
+	}
+	remainder := eventOccurrence.Price - credit
+
+	if credit > 0 && remainder == 0 {
+		return j.payWithCredit(ctx, reg, guardian, org, eventOccurrence.Currency, credit)
+	}
+
+	if guardian.StripeCustomerID == nil {
+		return fmt.Errorf("guardian %s has no Stripe customer ID", reg.GuardianID)
+	}
+
+	paymentMethods, err := j.stripeClient.GetPaymentMethodsByCustomerID(ctx, *guardian.StripeCustomerID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment methods for guardian %s: %w", reg.GuardianID, err)
+	}
+	if paymentMethods == nil || len(paymentMethods.Body.PaymentMethods) == 0 {
+		return fmt.Errorf("guardian %s has no payment methods", reg.GuardianID)
+	}
+	paymentMethodID := paymentMethods.Body.PaymentMethods[0].ID
+
+	piInput := models.CreatePaymentIntentInput{}
+	piInput.Body.Amount = int64(remainder)
+	piInput.Body.Currency = eventOccurrence.Currency
+	piInput.Body.GuardianStripeID = *guardian.StripeCustomerID
+	piInput.Body.OrgStripeID = *org.StripeAccountID
+	piInput.Body.PaymentMethodID = paymentMethodID
+	piInput.Body.EventDate = eventOccurrence.StartTime
+	piInput.Body.PlatformFeePercentage = platformFeePercentage
+	// a retry after the intent was created but not stored gets the same intent back;
+	// the amount is part of the key so a different remainder can't reuse another's intent
+	piInput.Body.IdempotencyKey = fmt.Sprintf("payment_intent:%s:%d", reg.ID, remainder)
 
-		piInput := models.CreatePaymentIntentInput{}
-		piInput.Body.Amount = int64(eventOccurrence.Price)
-		piInput.Body.Currency = eventOccurrence.Currency
-		piInput.Body.GuardianStripeID = *guardian.StripeCustomerID
-		piInput.Body.OrgStripeID = *org.StripeAccountID
-		piInput.Body.PaymentMethodID = paymentMethodID
-		piInput.Body.EventDate = eventOccurrence.StartTime
-		piInput.Body.PlatformFeePercentage = 10
+	paymentIntent, err := j.stripeClient.CreatePaymentIntent(ctx, &piInput)
+	if err != nil {
+		return fmt.Errorf("failed to create payment intent: %w", err)
+	}
+	if paymentIntent == nil {
+		return errors.New("nil payment intent response")
+	}
+
+	paymentData := &models.CreatePaymentData{
+		RegistrationID:        reg.ID,
+		StripePaymentIntentID: paymentIntent.Body.PaymentIntentID,
+		StripeCustomerID:      *guardian.StripeCustomerID,
+		OrgStripeAccountID:    *org.StripeAccountID,
+		StripePaymentMethodID: paymentMethodID,
+		TotalAmount:           paymentIntent.Body.TotalAmount,
+		ProviderAmount:        paymentIntent.Body.ProviderAmount,
+		PlatformFeeAmount:     paymentIntent.Body.PlatformFeeAmount,
+		Currency:              paymentIntent.Body.Currency,
+		PaymentIntentStatus:   paymentIntent.Body.Status,
+		CreditAmount:          credit,
+	}
 
-		paymentIntent, err := j.stripeClient.CreatePaymentIntent(ctx, &piInput)
+	if credit > 0 {
+		paymentData.StripeTransferID, err = j.transferCreditShare(ctx, reg, org, eventOccurrence.Currency, credit)
 		if err != nil {
-			log.Printf("CreatePaymentIntentsJob: failed to create payment intent for registration %s: %v", reg.ID, err)
-			continue
-		}
-		if paymentIntent == nil {
-			log.Printf("CreatePaymentIntentsJob: nil payment intent response for registration %s, skipping", reg.ID)
-			continue
+			return err
 		}
+	}
 
-		paymentData := &models.CreatePaymentData{
-			RegistrationID:        reg.ID,
-			StripePaymentIntentID: paymentIntent.Body.PaymentIntentID,
-			StripeCustomerID:      *guardian.StripeCustomerID,
-			OrgStripeAccountID:    *org.StripeAccountID,
-			StripePaymentMethodID: paymentMethodID,
-			TotalAmount:           paymentIntent.Body.TotalAmount,
-			ProviderAmount:        paymentIntent.Body.ProviderAmount,
-			PlatformFeeAmount:     paymentIntent.Body.PlatformFeeAmount,
-			Currency:              paymentIntent.Body.Currency,
-			PaymentIntentStatus:   paymentIntent.Body.Status,
-		}
+	if err := j.repo.Registration.CreatePayment(ctx, paymentData); err != nil {
+		return fmt.Errorf("failed to store payment: %w", err)
+	}
 
-		if err := j.repo.Registration.CreatePayment(ctx, paymentData); err != nil {
-			log.Printf("CreatePaymentIntentsJob: failed to store payment for registration %s: %v", reg.ID, err)
-			continue
-		}
+	log.Printf("CreatePaymentIntentsJob: created payment intent %s for registration %s", paymentIntent.Body.PaymentIntentID, reg.ID)
+	return nil
+}
+
+// payWithCredit records a registration settled entirely from the guardian's wallet, without a
+// payment intent.
+func (j *JobScheduler) payWithCredit(ctx context.Context, reg models.RegistrationForPayment, guardian *models.Guardian, org *models.Organization, currency string, credit int) error {
+	platformFee := credit * platformFeePercentage / 100
 
-		log.Printf("CreatePaymentIntentsJob: created payment intent %s for registration %s", paymentIntent.Body.PaymentIntentID, reg.ID)
+	paymentData := &models.CreatePaymentData{
+		RegistrationID:      reg.ID,
+		OrgStripeAccountID:  *org.StripeAccountID,
+		ProviderAmount:      credit - platformFee,
+		PlatformFeeAmount:   platformFee,
+		Currency:            currency,
+		PaymentIntentStatus: "succeeded",
+		CreditAmount:        credit,
 	}
+	if guardian.StripeCustomerID != nil {
+		paymentData.StripeCustomerID = *guardian.StripeCustomerID
+	}
+
+	transferID, err := j.transferCreditShare(ctx, reg, org, currency, credit)
+	if err != nil {
+		return err
+	}
+	paymentData.StripeTransferID = transferID
+
+	if err := j.repo.Registration.CreatePayment(ctx, paymentData); err != nil {
+		return fmt.Errorf("failed to store credit payment: %w", err)
+	}
+
+	log.Printf("CreatePaymentIntentsJob: paid registration %s with %d wallet credit", reg.ID, credit)
+	return nil
+}
+
+// transferCreditShare pays the organization its share of the credit applied to a
+// registration. The credit sits with the platform, so the share is transferred to the
+// organization's connected account; the transfer is stored with the payment so a
+// cancellation can reverse it.
+func (j *JobScheduler) transferCreditShare(ctx context.Context, reg models.RegistrationForPayment, org *models.Organization, currency string, credit int) (string, error) {
+	providerAmount := credit - credit*platformFeePercentage/100
+	if providerAmount <= 0 {
+		return "", nil
+	}
+
+	transfer, err := j.stripeClient.CreateTransfer(ctx, &models.CreateTransferInput{
+		Amount:               int64(providerAmount),
+		Currency:             currency,
+		DestinationAccountID: *org.StripeAccountID,
+		Description:          "Registration paid with wallet credit",
+		Metadata:             map[string]string{"registration_id": reg.ID.String()},
+		// a retry after the transfer was made but the payment not stored gets the same transfer back
+		IdempotencyKey: "transfer:" + reg.ID.String(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to transfer credit payment: %w", err)
+	}
+
+	return transfer.Body.TransferID, nil
 }
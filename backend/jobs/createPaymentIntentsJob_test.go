@@ -1,6 +1,8 @@
 package jobs
 
 import (
+	"context"
+	"fmt"
 	"skillspark/internal/models"
 	"skillspark/internal/storage"
 	repomocks "skillspark/internal/storage/repo-mocks"
@@ -11,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func makeScheduler(
@@ -18,6 +21,7 @@ func makeScheduler(
 	mockGuardianRepo *repomocks.MockGuardianRepository,
 	mockEORepo *repomocks.MockEventOccurrenceRepository,
 	mockOrgRepo *repomocks.MockOrganizationRepository,
+	mockWalletRepo *repomocks.MockWalletRepository,
 	mockStripeClient *stripemocks.MockStripeClient,
 ) *JobScheduler {
 	return &JobScheduler{
@@ -26,18 +30,20 @@ func makeScheduler(
 			Guardian:        mockGuardianRepo,
 			EventOccurrence: mockEORepo,
 			Organization:    mockOrgRepo,
+			Wallet:          mockWalletRepo,
 		},
 		stripeClient: mockStripeClient,
 	}
 }
 
-func TestCreatePaymentIntentsJob_Success(t *testing.T) {
+func TestCreatePaymentIntent_Success(t *testing.T) {
 	mockRegRepo := new(repomocks.MockRegistrationRepository)
 	mockGuardianRepo := new(repomocks.MockGuardianRepository)
 	mockEORepo := new(repomocks.MockEventOccurrenceRepository)
 	mockOrgRepo := new(repomocks.MockOrganizationRepository)
+	mockWalletRepo := new(repomocks.MockWalletRepository)
 	mockStripeClient := new(stripemocks.MockStripeClient)
-	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockStripeClient)
+	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockWalletRepo, mockStripeClient)
 
 	guardianID := uuid.New()
 	eoID := uuid.New()
@@ -53,8 +59,7 @@ func TestCreatePaymentIntentsJob_Success(t *testing.T) {
 		EventOccurrenceID: eoID,
 	}}
 
-	mockRegRepo.On("GetRegistrationsForPaymentCreation", mock.Anything).
-		Return(pending, nil)
+	reg := pending[0]
 
 	mockGuardianRepo.On("GetGuardianByID", mock.Anything, guardianID).
 		Return(&models.Guardian{ID: guardianID, StripeCustomerID: &customerID}, nil)
@@ -77,6 +82,8 @@ func TestCreatePaymentIntentsJob_Success(t *testing.T) {
 			Event:     models.Event{OrganizationID: orgID},
 		}, nil)
 
+	mockWalletRepo.On("ApplyCreditToRegistration", mock.Anything, regID, guardianID, "usd", 10000).Return(0, nil)
+
 	mockOrgRepo.On("GetOrganizationByID", mock.Anything, orgID, mock.Anything).
 		Return(&models.Organization{ID: orgID, StripeAccountID: &accountID}, nil)
 
@@ -114,7 +121,9 @@ func TestCreatePaymentIntentsJob_Success(t *testing.T) {
 			input.StripePaymentMethodID == pmID
 	})).Return(nil)
 
-	scheduler.CreatePaymentIntentsJob()
+	err := scheduler.createPaymentIntent(context.Background(), reg)
+
+	require.NoError(t, err)
 
 	mockRegRepo.AssertExpectations(t)
 	mockGuardianRepo.AssertExpectations(t)
@@ -128,13 +137,15 @@ func TestCreatePaymentIntentsJob_NoRegistrations(t *testing.T) {
 	mockGuardianRepo := new(repomocks.MockGuardianRepository)
 	mockEORepo := new(repomocks.MockEventOccurrenceRepository)
 	mockOrgRepo := new(repomocks.MockOrganizationRepository)
+	mockWalletRepo := new(repomocks.MockWalletRepository)
 	mockStripeClient := new(stripemocks.MockStripeClient)
-	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockStripeClient)
+	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockWalletRepo, mockStripeClient)
 
 	mockRegRepo.On("GetRegistrationsForPaymentCreation", mock.Anything).
 		Return([]models.RegistrationForPayment{}, nil)
 
-	scheduler.CreatePaymentIntentsJob()
+	run := NewRunTracker(createPaymentIntentsJobName, false)
+	scheduler.CreatePaymentIntentsJob(context.Background(), run)
 
 	mockRegRepo.AssertExpectations(t)
 	mockGuardianRepo.AssertNotCalled(t, "GetGuardianByID")
@@ -147,63 +158,90 @@ func TestCreatePaymentIntentsJob_FetchError(t *testing.T) {
 	mockGuardianRepo := new(repomocks.MockGuardianRepository)
 	mockEORepo := new(repomocks.MockEventOccurrenceRepository)
 	mockOrgRepo := new(repomocks.MockOrganizationRepository)
+	mockWalletRepo := new(repomocks.MockWalletRepository)
 	mockStripeClient := new(stripemocks.MockStripeClient)
-	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockStripeClient)
+	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockWalletRepo, mockStripeClient)
 
 	mockRegRepo.On("GetRegistrationsForPaymentCreation", mock.Anything).
 		Return(nil, assert.AnError)
 
-	scheduler.CreatePaymentIntentsJob()
+	run := NewRunTracker(createPaymentIntentsJobName, false)
+	scheduler.CreatePaymentIntentsJob(context.Background(), run)
 
 	mockRegRepo.AssertExpectations(t)
 	mockGuardianRepo.AssertNotCalled(t, "GetGuardianByID")
 	mockStripeClient.AssertNotCalled(t, "CreatePaymentIntent")
+	assert.Equal(t, models.JobRunStatusFailed, run.status())
 }
 
-func TestCreatePaymentIntentsJob_SkipsGuardianWithNoStripeID(t *testing.T) {
+func TestCreatePaymentIntent_SkipsGuardianWithNoStripeID(t *testing.T) {
 	mockRegRepo := new(repomocks.MockRegistrationRepository)
 	mockGuardianRepo := new(repomocks.MockGuardianRepository)
 	mockEORepo := new(repomocks.MockEventOccurrenceRepository)
 	mockOrgRepo := new(repomocks.MockOrganizationRepository)
+	mockWalletRepo := new(repomocks.MockWalletRepository)
 	mockStripeClient := new(stripemocks.MockStripeClient)
-	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockStripeClient)
+	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockWalletRepo, mockStripeClient)
 
 	guardianID := uuid.New()
 	regID := uuid.New()
 	eoID := uuid.New()
+	orgID := uuid.New()
+	accountID := "acct_test_123"
 
-	mockRegRepo.On("GetRegistrationsForPaymentCreation", mock.Anything).
-		Return([]models.RegistrationForPayment{{ID: regID, GuardianID: guardianID, EventOccurrenceID: eoID}}, nil)
+	reg := models.RegistrationForPayment{ID: regID, GuardianID: guardianID, EventOccurrenceID: eoID}
 
 	mockGuardianRepo.On("GetGuardianByID", mock.Anything, guardianID).
 		Return(&models.Guardian{ID: guardianID, StripeCustomerID: nil}, nil)
 
-	scheduler.CreatePaymentIntentsJob()
+	mockEORepo.On("GetEventOccurrenceByID", mock.Anything, eoID, "en-US").
+		Return(&models.EventOccurrence{
+			ID:    eoID,
+			Event: models.Event{OrganizationID: orgID},
+		}, nil)
+
+	mockOrgRepo.On("GetOrganizationByID", mock.Anything, orgID, mock.Anything).
+		Return(&models.Organization{ID: orgID, StripeAccountID: &accountID}, nil)
+
+	err := scheduler.createPaymentIntent(context.Background(), reg)
+
+	require.Error(t, err)
 
 	mockStripeClient.AssertNotCalled(t, "GetPaymentMethodsByCustomerID")
 	mockStripeClient.AssertNotCalled(t, "CreatePaymentIntent")
 	mockRegRepo.AssertNotCalled(t, "CreatePayment")
 }
 
-func TestCreatePaymentIntentsJob_SkipsGuardianWithNoPaymentMethods(t *testing.T) {
+func TestCreatePaymentIntent_SkipsGuardianWithNoPaymentMethods(t *testing.T) {
 	mockRegRepo := new(repomocks.MockRegistrationRepository)
 	mockGuardianRepo := new(repomocks.MockGuardianRepository)
 	mockEORepo := new(repomocks.MockEventOccurrenceRepository)
 	mockOrgRepo := new(repomocks.MockOrganizationRepository)
+	mockWalletRepo := new(repomocks.MockWalletRepository)
 	mockStripeClient := new(stripemocks.MockStripeClient)
-	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockStripeClient)
+	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockWalletRepo, mockStripeClient)
 
 	guardianID := uuid.New()
 	regID := uuid.New()
 	eoID := uuid.New()
+	orgID := uuid.New()
+	accountID := "acct_test_123"
 	customerID := "cus_no_methods"
 
-	mockRegRepo.On("GetRegistrationsForPaymentCreation", mock.Anything).
-		Return([]models.RegistrationForPayment{{ID: regID, GuardianID: guardianID, EventOccurrenceID: eoID}}, nil)
+	reg := models.RegistrationForPayment{ID: regID, GuardianID: guardianID, EventOccurrenceID: eoID}
 
 	mockGuardianRepo.On("GetGuardianByID", mock.Anything, guardianID).
 		Return(&models.Guardian{ID: guardianID, StripeCustomerID: &customerID}, nil)
 
+	mockEORepo.On("GetEventOccurrenceByID", mock.Anything, eoID, "en-US").
+		Return(&models.EventOccurrence{
+			ID:    eoID,
+			Event: models.Event{OrganizationID: orgID},
+		}, nil)
+
+	mockOrgRepo.On("GetOrganizationByID", mock.Anything, orgID, mock.Anything).
+		Return(&models.Organization{ID: orgID, StripeAccountID: &accountID}, nil)
+
 	mockStripeClient.On("GetPaymentMethodsByCustomerID", mock.Anything, customerID).
 		Return(&models.GetPaymentMethodsByGuardianIDOutput{
 			Body: struct {
@@ -211,19 +249,22 @@ func TestCreatePaymentIntentsJob_SkipsGuardianWithNoPaymentMethods(t *testing.T)
 			}{},
 		}, nil)
 
-	scheduler.CreatePaymentIntentsJob()
+	err := scheduler.createPaymentIntent(context.Background(), reg)
+
+	require.Error(t, err)
 
 	mockStripeClient.AssertNotCalled(t, "CreatePaymentIntent")
 	mockRegRepo.AssertNotCalled(t, "CreatePayment")
 }
 
-func TestCreatePaymentIntentsJob_SkipsOrgWithNoStripeAccount(t *testing.T) {
+func TestCreatePaymentIntent_SkipsOrgWithNoStripeAccount(t *testing.T) {
 	mockRegRepo := new(repomocks.MockRegistrationRepository)
 	mockGuardianRepo := new(repomocks.MockGuardianRepository)
 	mockEORepo := new(repomocks.MockEventOccurrenceRepository)
 	mockOrgRepo := new(repomocks.MockOrganizationRepository)
+	mockWalletRepo := new(repomocks.MockWalletRepository)
 	mockStripeClient := new(stripemocks.MockStripeClient)
-	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockStripeClient)
+	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockWalletRepo, mockStripeClient)
 
 	guardianID := uuid.New()
 	eoID := uuid.New()
@@ -232,8 +273,7 @@ func TestCreatePaymentIntentsJob_SkipsOrgWithNoStripeAccount(t *testing.T) {
 	customerID := "cus_test_123"
 	pmID := "pm_test_123"
 
-	mockRegRepo.On("GetRegistrationsForPaymentCreation", mock.Anything).
-		Return([]models.RegistrationForPayment{{ID: regID, GuardianID: guardianID, EventOccurrenceID: eoID}}, nil)
+	reg := models.RegistrationForPayment{ID: regID, GuardianID: guardianID, EventOccurrenceID: eoID}
 
 	mockGuardianRepo.On("GetGuardianByID", mock.Anything, guardianID).
 		Return(&models.Guardian{ID: guardianID, StripeCustomerID: &customerID}, nil)
@@ -256,19 +296,22 @@ func TestCreatePaymentIntentsJob_SkipsOrgWithNoStripeAccount(t *testing.T) {
 	mockOrgRepo.On("GetOrganizationByID", mock.Anything, orgID, mock.Anything).
 		Return(&models.Organization{ID: orgID, StripeAccountID: nil}, nil)
 
-	scheduler.CreatePaymentIntentsJob()
+	err := scheduler.createPaymentIntent(context.Background(), reg)
+
+	require.Error(t, err)
 
 	mockStripeClient.AssertNotCalled(t, "CreatePaymentIntent")
 	mockRegRepo.AssertNotCalled(t, "CreatePayment")
 }
 
-func TestCreatePaymentIntentsJob_StripeCreateFailure(t *testing.T) {
+func TestCreatePaymentIntent_StripeCreateFailure(t *testing.T) {
 	mockRegRepo := new(repomocks.MockRegistrationRepository)
 	mockGuardianRepo := new(repomocks.MockGuardianRepository)
 	mockEORepo := new(repomocks.MockEventOccurrenceRepository)
 	mockOrgRepo := new(repomocks.MockOrganizationRepository)
+	mockWalletRepo := new(repomocks.MockWalletRepository)
 	mockStripeClient := new(stripemocks.MockStripeClient)
-	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockStripeClient)
+	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockWalletRepo, mockStripeClient)
 
 	guardianID := uuid.New()
 	eoID := uuid.New()
@@ -278,8 +321,7 @@ func TestCreatePaymentIntentsJob_StripeCreateFailure(t *testing.T) {
 	accountID := "acct_test_123"
 	pmID := "pm_test_123"
 
-	mockRegRepo.On("GetRegistrationsForPaymentCreation", mock.Anything).
-		Return([]models.RegistrationForPayment{{ID: regID, GuardianID: guardianID, EventOccurrenceID: eoID}}, nil)
+	reg := models.RegistrationForPayment{ID: regID, GuardianID: guardianID, EventOccurrenceID: eoID}
 
 	mockGuardianRepo.On("GetGuardianByID", mock.Anything, guardianID).
 		Return(&models.Guardian{ID: guardianID, StripeCustomerID: &customerID}, nil)
@@ -305,43 +347,158 @@ func TestCreatePaymentIntentsJob_StripeCreateFailure(t *testing.T) {
 	mockStripeClient.On("CreatePaymentIntent", mock.Anything, mock.AnythingOfType("*models.CreatePaymentIntentInput")).
 		Return(nil, assert.AnError)
 
-	scheduler.CreatePaymentIntentsJob()
+	err := scheduler.createPaymentIntent(context.Background(), reg)
+
+	require.Error(t, err)
+
+	mockRegRepo.AssertNotCalled(t, "CreatePayment")
+}
+
+func TestCreatePaymentIntent_CreditCoversFullPrice(t *testing.T) {
+	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	mockGuardianRepo := new(repomocks.MockGuardianRepository)
+	mockEORepo := new(repomocks.MockEventOccurrenceRepository)
+	mockOrgRepo := new(repomocks.MockOrganizationRepository)
+	mockWalletRepo := new(repomocks.MockWalletRepository)
+	mockStripeClient := new(stripemocks.MockStripeClient)
+	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockWalletRepo, mockStripeClient)
+
+	guardianID := uuid.New()
+	eoID := uuid.New()
+	orgID := uuid.New()
+	regID := uuid.New()
+	accountID := "acct_test_123"
+
+	reg := models.RegistrationForPayment{ID: regID, GuardianID: guardianID, EventOccurrenceID: eoID}
+
+	// guardians paying entirely with credit don't need a card on file
+	mockGuardianRepo.On("GetGuardianByID", mock.Anything, guardianID).
+		Return(&models.Guardian{ID: guardianID}, nil)
+
+	mockEORepo.On("GetEventOccurrenceByID", mock.Anything, eoID, "en-US").
+		Return(&models.EventOccurrence{
+			ID:       eoID,
+			Price:    5000,
+			Currency: "thb",
+			Event:    models.Event{OrganizationID: orgID},
+		}, nil)
+
+	mockOrgRepo.On("GetOrganizationByID", mock.Anything, orgID, mock.Anything).
+		Return(&models.Organization{ID: orgID, StripeAccountID: &accountID}, nil)
+
+	mockWalletRepo.On("ApplyCreditToRegistration", mock.Anything, regID, guardianID, "thb", 5000).Return(5000, nil)
+
+	// the organization's share is transferred since there is no charge to carry it
+	transfer := &models.CreateTransferOutput{}
+	transfer.Body.TransferID = "tr_test_123"
+	mockStripeClient.On("CreateTransfer", mock.Anything, mock.MatchedBy(func(input *models.CreateTransferInput) bool {
+		return input.Amount == 4500 &&
+			input.Currency == "thb" &&
+			input.DestinationAccountID == accountID &&
+			input.IdempotencyKey == "transfer:"+regID.String()
+	})).Return(transfer, nil)
 
+	mockRegRepo.On("CreatePayment", mock.Anything, mock.MatchedBy(func(input *models.CreatePaymentData) bool {
+		return input.RegistrationID == regID &&
+			input.StripePaymentIntentID == "" &&
+			input.StripeTransferID == "tr_test_123" &&
+			input.PaymentIntentStatus == "succeeded" &&
+			input.CreditAmount == 5000 &&
+			input.ProviderAmount == 4500 &&
+			input.PlatformFeeAmount == 500 &&
+			input.TotalAmount == 0
+	})).Return(nil)
+
+	err := scheduler.createPaymentIntent(context.Background(), reg)
+
+	require.NoError(t, err)
+
+	mockRegRepo.AssertExpectations(t)
+	mockWalletRepo.AssertExpectations(t)
+	mockStripeClient.AssertExpectations(t)
+	mockStripeClient.AssertNotCalled(t, "GetPaymentMethodsByCustomerID")
+	mockStripeClient.AssertNotCalled(t, "CreatePaymentIntent")
+}
+
+func TestCreatePaymentIntent_CreditTransferFails(t *testing.T) {
+	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	mockGuardianRepo := new(repomocks.MockGuardianRepository)
+	mockEORepo := new(repomocks.MockEventOccurrenceRepository)
+	mockOrgRepo := new(repomocks.MockOrganizationRepository)
+	mockWalletRepo := new(repomocks.MockWalletRepository)
+	mockStripeClient := new(stripemocks.MockStripeClient)
+	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockWalletRepo, mockStripeClient)
+
+	guardianID := uuid.New()
+	eoID := uuid.New()
+	orgID := uuid.New()
+	regID := uuid.New()
+	accountID := "acct_test_123"
+
+	reg := models.RegistrationForPayment{ID: regID, GuardianID: guardianID, EventOccurrenceID: eoID}
+
+	mockGuardianRepo.On("GetGuardianByID", mock.Anything, guardianID).
+		Return(&models.Guardian{ID: guardianID}, nil)
+
+	mockEORepo.On("GetEventOccurrenceByID", mock.Anything, eoID, "en-US").
+		Return(&models.EventOccurrence{
+			ID:       eoID,
+			Price:    5000,
+			Currency: "thb",
+			Event:    models.Event{OrganizationID: orgID},
+		}, nil)
+
+	mockOrgRepo.On("GetOrganizationByID", mock.Anything, orgID, mock.Anything).
+		Return(&models.Organization{ID: orgID, StripeAccountID: &accountID}, nil)
+
+	mockWalletRepo.On("ApplyCreditToRegistration", mock.Anything, regID, guardianID, "thb", 5000).Return(5000, nil)
+
+	mockStripeClient.On("CreateTransfer", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("stripe unavailable"))
+
+	err := scheduler.createPaymentIntent(context.Background(), reg)
+
+	// no payment is stored, so the retry applies the same credit and transfers again
+	require.Error(t, err)
 	mockRegRepo.AssertNotCalled(t, "CreatePayment")
 }
 
-func TestCreatePaymentIntentsJob_ContinuesAfterSingleFailure(t *testing.T) {
+func TestCreatePaymentIntent_PartialCreditChargesRemainder(t *testing.T) {
 	mockRegRepo := new(repomocks.MockRegistrationRepository)
 	mockGuardianRepo := new(repomocks.MockGuardianRepository)
 	mockEORepo := new(repomocks.MockEventOccurrenceRepository)
 	mockOrgRepo := new(repomocks.MockOrganizationRepository)
+	mockWalletRepo := new(repomocks.MockWalletRepository)
 	mockStripeClient := new(stripemocks.MockStripeClient)
-	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockStripeClient)
+	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockWalletRepo, mockStripeClient)
 
-	guardianID1 := uuid.New()
-	guardianID2 := uuid.New()
-	eoID1, eoID2 := uuid.New(), uuid.New()
+	guardianID := uuid.New()
+	eoID := uuid.New()
 	orgID := uuid.New()
-	reg1ID, reg2ID := uuid.New(), uuid.New()
-	customerID2 := "cus_test_456"
+	regID := uuid.New()
+	customerID := "cus_test_123"
 	accountID := "acct_test_123"
 	pmID := "pm_test_123"
 
-	pending := []models.RegistrationForPayment{
-		{ID: reg1ID, GuardianID: guardianID1, EventOccurrenceID: eoID1},
-		{ID: reg2ID, GuardianID: guardianID2, EventOccurrenceID: eoID2},
-	}
+	reg := models.RegistrationForPayment{ID: regID, GuardianID: guardianID, EventOccurrenceID: eoID}
 
-	mockRegRepo.On("GetRegistrationsForPaymentCreation", mock.Anything).Return(pending, nil)
+	mockGuardianRepo.On("GetGuardianByID", mock.Anything, guardianID).
+		Return(&models.Guardian{ID: guardianID, StripeCustomerID: &customerID}, nil)
+
+	mockEORepo.On("GetEventOccurrenceByID", mock.Anything, eoID, "en-US").
+		Return(&models.EventOccurrence{
+			ID:       eoID,
+			Price:    10000,
+			Currency: "thb",
+			Event:    models.Event{OrganizationID: orgID},
+		}, nil)
 
-	// First registration: guardian lookup fails
-	mockGuardianRepo.On("GetGuardianByID", mock.Anything, guardianID1).Return(nil, assert.AnError)
+	mockOrgRepo.On("GetOrganizationByID", mock.Anything, orgID, mock.Anything).
+		Return(&models.Organization{ID: orgID, StripeAccountID: &accountID}, nil)
 
-	// Second registration: succeeds
-	mockGuardianRepo.On("GetGuardianByID", mock.Anything, guardianID2).
-		Return(&models.Guardian{ID: guardianID2, StripeCustomerID: &customerID2}, nil)
+	mockWalletRepo.On("ApplyCreditToRegistration", mock.Anything, regID, guardianID, "thb", 10000).Return(3000, nil)
 
-	mockStripeClient.On("GetPaymentMethodsByCustomerID", mock.Anything, customerID2).
+	mockStripeClient.On("GetPaymentMethodsByCustomerID", mock.Anything, customerID).
 		Return(&models.GetPaymentMethodsByGuardianIDOutput{
 			Body: struct {
 				PaymentMethods []models.PaymentMethod `json:"payment_methods"`
@@ -350,38 +507,234 @@ func TestCreatePaymentIntentsJob_ContinuesAfterSingleFailure(t *testing.T) {
 			},
 		}, nil)
 
-	mockEORepo.On("GetEventOccurrenceByID", mock.Anything, eoID2, "en-US").
+	piOutput := &models.CreatePaymentIntentOutput{}
+	piOutput.Body.PaymentIntentID = "pi_remainder"
+	piOutput.Body.Status = "requires_capture"
+	piOutput.Body.TotalAmount = 7000
+	piOutput.Body.Currency = "thb"
+	mockStripeClient.On("CreatePaymentIntent", mock.Anything, mock.MatchedBy(func(in *models.CreatePaymentIntentInput) bool {
+		return in.Body.Amount == 7000
+	})).Return(piOutput, nil)
+
+	// the charge only carries the organization's share of the remainder
+	transfer := &models.CreateTransferOutput{}
+	transfer.Body.TransferID = "tr_credit_share"
+	mockStripeClient.On("CreateTransfer", mock.Anything, mock.MatchedBy(func(input *models.CreateTransferInput) bool {
+		return input.Amount == 2700 &&
+			input.DestinationAccountID == accountID &&
+			input.IdempotencyKey == "transfer:"+regID.String()
+	})).Return(transfer, nil)
+
+	mockRegRepo.On("CreatePayment", mock.Anything, mock.MatchedBy(func(input *models.CreatePaymentData) bool {
+		return input.StripePaymentIntentID == "pi_remainder" &&
+			input.StripeTransferID == "tr_credit_share" &&
+			input.TotalAmount == 7000 &&
+			input.CreditAmount == 3000
+	})).Return(nil)
+
+	err := scheduler.createPaymentIntent(context.Background(), reg)
+
+	require.NoError(t, err)
+
+	mockRegRepo.AssertExpectations(t)
+	mockWalletRepo.AssertExpectations(t)
+	mockStripeClient.AssertExpectations(t)
+}
+
+func TestCreatePaymentIntent_KeepsCreditAppliedWhenStripeFails(t *testing.T) {
+	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	mockGuardianRepo := new(repomocks.MockGuardianRepository)
+	mockEORepo := new(repomocks.MockEventOccurrenceRepository)
+	mockOrgRepo := new(repomocks.MockOrganizationRepository)
+	mockWalletRepo := new(repomocks.MockWalletRepository)
+	mockStripeClient := new(stripemocks.MockStripeClient)
+	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockWalletRepo, mockStripeClient)
+
+	guardianID := uuid.New()
+	eoID := uuid.New()
+	orgID := uuid.New()
+	regID := uuid.New()
+	customerID := "cus_test_123"
+	accountID := "acct_test_123"
+
+	reg := models.RegistrationForPayment{ID: regID, GuardianID: guardianID, EventOccurrenceID: eoID}
+
+	mockGuardianRepo.On("GetGuardianByID", mock.Anything, guardianID).
+		Return(&models.Guardian{ID: guardianID, StripeCustomerID: &customerID}, nil)
+
+	mockEORepo.On("GetEventOccurrenceByID", mock.Anything, eoID, "en-US").
 		Return(&models.EventOccurrence{
-			ID:    eoID2,
-			Event: models.Event{OrganizationID: orgID},
+			ID:       eoID,
+			Price:    10000,
+			Currency: "thb",
+			Event:    models.Event{OrganizationID: orgID},
 		}, nil)
 
 	mockOrgRepo.On("GetOrganizationByID", mock.Anything, orgID, mock.Anything).
 		Return(&models.Organization{ID: orgID, StripeAccountID: &accountID}, nil)
 
+	mockWalletRepo.On("ApplyCreditToRegistration", mock.Anything, regID, guardianID, "thb", 10000).Return(2500, nil)
+
+	mockStripeClient.On("GetPaymentMethodsByCustomerID", mock.Anything, customerID).
+		Return(&models.GetPaymentMethodsByGuardianIDOutput{
+			Body: struct {
+				PaymentMethods []models.PaymentMethod `json:"payment_methods"`
+			}{
+				PaymentMethods: []models.PaymentMethod{{ID: "pm_test_123"}},
+			},
+		}, nil)
+
 	mockStripeClient.On("CreatePaymentIntent", mock.Anything, mock.AnythingOfType("*models.CreatePaymentIntentInput")).
-		Return(&models.CreatePaymentIntentOutput{
+		Return(nil, assert.AnError)
+
+	err := scheduler.createPaymentIntent(context.Background(), reg)
+
+	require.Error(t, err)
+
+	// the retry reuses the applied credit, so the credit stays put
+	mockWalletRepo.AssertExpectations(t)
+	mockWalletRepo.AssertNotCalled(t, "ReverseAppliedCredit", mock.Anything, mock.Anything, mock.Anything)
+	mockStripeClient.AssertNotCalled(t, "CreateTransfer", mock.Anything, mock.Anything)
+	mockRegRepo.AssertNotCalled(t, "CreatePayment")
+}
+
+func TestCreatePaymentIntent_RetryChargesSameRemainder(t *testing.T) {
+	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	mockGuardianRepo := new(repomocks.MockGuardianRepository)
+	mockEORepo := new(repomocks.MockEventOccurrenceRepository)
+	mockOrgRepo := new(repomocks.MockOrganizationRepository)
+	mockWalletRepo := new(repomocks.MockWalletRepository)
+	mockStripeClient := new(stripemocks.MockStripeClient)
+	scheduler := makeScheduler(mockRegRepo, mockGuardianRepo, mockEORepo, mockOrgRepo, mockWalletRepo, mockStripeClient)
+
+	guardianID := uuid.New()
+	eoID := uuid.New()
+	orgID := uuid.New()
+	regID := uuid.New()
+	customerID := "cus_test_123"
+	accountID := "acct_test_123"
+
+	reg := models.RegistrationForPayment{ID: regID, GuardianID: guardianID, EventOccurrenceID: eoID}
+
+	mockGuardianRepo.On("GetGuardianByID", mock.Anything, guardianID).
+		Return(&models.Guardian{ID: guardianID, StripeCustomerID: &customerID}, nil)
+
+	mockEORepo.On("GetEventOccurrenceByID", mock.Anything, eoID, "en-US").
+		Return(&models.EventOccurrence{
+			ID:       eoID,
+			Price:    10000,
+			Currency: "thb",
+			Event:    models.Event{OrganizationID: orgID},
+		}, nil)
+
+	mockOrgRepo.On("GetOrganizationByID", mock.Anything, orgID, mock.Anything).
+		Return(&models.Organization{ID: orgID, StripeAccountID: &accountID}, nil)
+
+	// both attempts get the credit the first one applied, whatever the balance is now
+	mockWalletRepo.On("ApplyCreditToRegistration", mock.Anything, regID, guardianID, "thb", 10000).Return(2500, nil).Twice()
+
+	mockStripeClient.On("GetPaymentMethodsByCustomerID", mock.Anything, customerID).
+		Return(&models.GetPaymentMethodsByGuardianIDOutput{
 			Body: struct {
-				PaymentIntentID   string `json:"payment_intent_id" doc:"Stripe payment intent ID"`
-				ClientSecret      string `json:"client_secret" doc:"Client secret for frontend to confirm payment"`
-				Status            string `json:"status" doc:"Payment intent status"`
-				TotalAmount       int    `json:"total_amount" doc:"Total amount in cents"`
-				ProviderAmount    int    `json:"provider_amount" doc:"Amount provider receives in cents"`
-				PlatformFeeAmount int    `json:"platform_fee_amount" doc:"Platform fee in cents"`
-				Currency          string `json:"currency" doc:"Currency code"`
+				PaymentMethods []models.PaymentMethod `json:"payment_methods"`
 			}{
-				PaymentIntentID: "pi_new_456",
-				Status:          "requires_capture",
+				PaymentMethods: []models.PaymentMethod{{ID: "pm_test_123"}},
 			},
 		}, nil)
 
+	piOutput := &models.CreatePaymentIntentOutput{}
+	piOutput.Body.PaymentIntentID = "pi_remainder"
+	piOutput.Body.Status = "requires_capture"
+	piOutput.Body.TotalAmount = 7500
+	piOutput.Body.Currency = "thb"
+	mockStripeClient.On("CreatePaymentIntent", mock.Anything, mock.MatchedBy(func(in *models.CreatePaymentIntentInput) bool {
+		return in.Body.Amount == 7500 && in.Body.IdempotencyKey == fmt.Sprintf("payment_intent:%s:7500", regID)
+	})).Return(piOutput, nil).Twice()
+
+	transfer := &models.CreateTransferOutput{}
+	transfer.Body.TransferID = "tr_credit_share"
+	mockStripeClient.On("CreateTransfer", mock.Anything, mock.MatchedBy(func(input *models.CreateTransferInput) bool {
+		return input.Amount == 2250 && input.IdempotencyKey == "transfer:"+regID.String()
+	})).Return(transfer, nil).Twice()
+
+	mockRegRepo.On("CreatePayment", mock.Anything, mock.AnythingOfType("*models.CreatePaymentData")).
+		Return(assert.AnError).Once()
 	mockRegRepo.On("CreatePayment", mock.Anything, mock.MatchedBy(func(input *models.CreatePaymentData) bool {
-		return input.RegistrationID == reg2ID
-	})).Return(nil)
+		return input.StripePaymentIntentID == "pi_remainder" && input.CreditAmount == 2500
+	})).Return(nil).Once()
 
-	scheduler.CreatePaymentIntentsJob()
+	require.Error(t, scheduler.createPaymentIntent(context.Background(), reg))
+	require.NoError(t, scheduler.createPaymentIntent(context.Background(), reg))
 
 	mockRegRepo.AssertExpectations(t)
-	mockStripeClient.AssertNumberOfCalls(t, "CreatePaymentIntent", 1)
-	mockRegRepo.AssertNumberOfCalls(t, "CreatePayment", 1)
+	mockWalletRepo.AssertExpectations(t)
+	mockStripeClient.AssertExpectations(t)
+}
+
+func TestCreatePaymentIntentsJob_DryRun(t *testing.T) {
+	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	mockTaskRepo := new(repomocks.MockTaskRepository)
+	scheduler := &JobScheduler{
+		repo: &storage.Repository{Registration: mockRegRepo, Task: mockTaskRepo},
+	}
+
+	pending := []models.RegistrationForPayment{{ID: uuid.New(), GuardianID: uuid.New(), EventOccurrenceID: uuid.New()}}
+	mockRegRepo.On("GetRegistrationsForPaymentCreation", mock.Anything).Return(pending, nil)
+
+	run := NewRunTracker(createPaymentIntentsJobName, true)
+	scheduler.CreatePaymentIntentsJob(context.Background(), run)
+
+	mockTaskRepo.AssertNotCalled(t, "EnqueueTask", mock.Anything, mock.Anything)
+	assert.Equal(t, 1, run.succeeded)
+}
+
+func TestCreatePaymentIntentsJob_EnqueuesTasks(t *testing.T) {
+	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	mockTaskRepo := new(repomocks.MockTaskRepository)
+	scheduler := &JobScheduler{
+		repo: &storage.Repository{Registration: mockRegRepo, Task: mockTaskRepo},
+	}
+
+	reg1 := models.RegistrationForPayment{ID: uuid.New(), GuardianID: uuid.New(), EventOccurrenceID: uuid.New()}
+	reg2 := models.RegistrationForPayment{ID: uuid.New(), GuardianID: uuid.New(), EventOccurrenceID: uuid.New()}
+	mockRegRepo.On("GetRegistrationsForPaymentCreation", mock.Anything).
+		Return([]models.RegistrationForPayment{reg1, reg2}, nil)
+
+	mockTaskRepo.On("EnqueueTask", mock.Anything, mock.MatchedBy(func(input *models.EnqueueTaskData) bool {
+		return input.TaskType == createPaymentIntentTaskType && *input.DedupKey == "create_payment_intent:"+reg1.ID.String()
+	})).Return(&models.Task{ID: uuid.New()}, nil)
+	mockTaskRepo.On("EnqueueTask", mock.Anything, mock.MatchedBy(func(input *models.EnqueueTaskData) bool {
+		return *input.DedupKey == "create_payment_intent:"+reg2.ID.String()
+	})).Return(nil, assert.AnError)
+
+	run := NewRunTracker(createPaymentIntentsJobName, false)
+	scheduler.CreatePaymentIntentsJob(context.Background(), run)
+
+	mockTaskRepo.AssertExpectations(t)
+	assert.Equal(t, 2, run.processed)
+	assert.Equal(t, 1, run.succeeded)
+	assert.Equal(t, 1, run.failed)
+	assert.Equal(t, reg2.ID, run.itemErrors[0].ItemID)
+	assert.Equal(t, models.JobRunStatusPartiallyFailed, run.status())
+}
+
+func TestCreatePaymentIntentTask_DecodesPayload(t *testing.T) {
+	mockGuardianRepo := new(repomocks.MockGuardianRepository)
+	scheduler := &JobScheduler{
+		repo: &storage.Repository{Guardian: mockGuardianRepo},
+	}
+
+	guardianID := uuid.New()
+	task := newTestTask(t, createPaymentIntentTaskType, createPaymentIntentPayload{
+		RegistrationID:    uuid.New(),
+		GuardianID:        guardianID,
+		EventOccurrenceID: uuid.New(),
+	}, 1)
+
+	mockGuardianRepo.On("GetGuardianByID", mock.Anything, guardianID).Return(nil, assert.AnError)
+
+	err := scheduler.createPaymentIntentTask(context.Background(), task)
+
+	require.Error(t, err)
+	mockGuardianRepo.AssertExpectations(t)
 }
@@ -0,0 +1,171 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"time"
+)
+
+const (
+	outboxBatchSize = 20
+	// a claimed message that has not been published within this time is assumed lost
+	outboxVisibilityTimeout = time.Minute
+	// bounds one RelayOutbox call so shutdown is not held up by a long backlog
+	outboxMaxBatchesPerRun = 10
+)
+
+// outboxSink publishes one outbox message. Sinks can see a message more than once, so they
+// must be idempotent or pass the message's dedup ID on.
+type outboxSink func(ctx context.Context, message models.OutboxMessage) error
+
+func (j *JobScheduler) outboxSinks() map[string]outboxSink {
+	return map[string]outboxSink{
+		models.OutboxTopicNotification:          j.publishNotification,
+		models.OutboxTopicRegistrationCreated:   j.registrationCreated,
+		models.OutboxTopicRegistrationCancelled: j.registrationCancelled,
+	}
+}
+
+// RelayOutbox publishes pending outbox messages to their sinks until the outbox is drained
+// or the batch limit is hit. Failures are retried with the task queue's backoff; relays on
+// different workers claim different messages.
+func (j *JobScheduler) RelayOutbox(ctx context.Context) {
+	for range outboxMaxBatchesPerRun {
+		messages, err := j.repo.Outbox.ClaimOutboxMessages(ctx, outboxBatchSize, outboxVisibilityTimeout)
+		if err != nil {
+			log.Printf("RelayOutbox: failed to claim messages: %v", err)
+			return
+		}
+		if len(messages) == 0 {
+			return
+		}
+
+		for _, message := range messages {
+			j.relayMessage(ctx, message)
+		}
+	}
+}
+
+func (j *JobScheduler) relayMessage(ctx context.Context, message models.OutboxMessage) {
+	err := j.callOutboxSink(ctx, message)
+
+	switch {
+	case err == nil:
+		err = j.repo.Outbox.MarkOutboxMessagePublished(ctx, message.ID, message.Attempts)
+	case message.IsFinalAttempt() || errors.As(err, new(*permanentError)):
+		log.Printf("RelayOutbox: %s message %s failed after %d attempts: %v", message.Topic, message.ID, message.Attempts, err)
+		err = j.repo.Outbox.FailOutboxMessage(ctx, message.ID, message.Attempts, err.Error())
+	default:
+		log.Printf("RelayOutbox: %s message %s failed attempt %d, retrying: %v", message.Topic, message.ID, message.Attempts, err)
+		err = j.repo.Outbox.RetryOutboxMessageLater(ctx, message.ID, message.Attempts, time.Now().Add(taskBackoff(message.Attempts)), err.Error())
+	}
+	if err != nil {
+		// most likely the claim expired and another relay has the message now
+		log.Printf("RelayOutbox: failed to record outcome of %s message %s: %v", message.Topic, message.ID, err)
+	}
+}
+
+func (j *JobScheduler) callOutboxSink(ctx context.Context, message models.OutboxMessage) (err error) {
+	sink, ok := j.outboxSinks()[message.Topic]
+	if !ok {
+		return permanent(fmt.Errorf("unknown outbox topic %q", message.Topic))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panicked: %v", r)
+		}
+	}()
+
+	return sink(ctx, message)
+}
+
+// publishNotification hands a notification to the SQS delivery queue under the message's
+// dedup ID
+func (j *JobScheduler) publishNotification(ctx context.Context, message models.OutboxMessage) error {
+	if j.queue == nil {
+		return errors.New("no delivery queue configured")
+	}
+	return j.queue.SendMessage(ctx, message.Payload, message.DedupID)
+}
+
+// registrationCreated sends the confirmation and schedules the reminders of a new
+// registration. Reminders are replaced rather than added to, and the confirmation's
+// messages and inbox item carry dedup keys, so a retry does not send anything twice.
+func (j *JobScheduler) registrationCreated(ctx context.Context, message models.OutboxMessage) error {
+	registration, err := j.outboxRegistration(ctx, message)
+	if err != nil || registration == nil {
+		return err
+	}
+	// cancelled before the relay got to it; the cancellation has its own message
+	if registration.Status != models.RegistrationStatusRegistered {
+		return nil
+	}
+
+	guardian, err := j.repo.Guardian.GetGuardianByID(ctx, registration.GuardianID)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := j.notifService.CancelEventReminders(ctx, registration.ID); err != nil {
+		return fmt.Errorf("failed to clear event reminders: %w", err)
+	}
+	if err := j.notifService.ScheduleEventReminders(ctx, registration, guardian); err != nil {
+		return fmt.Errorf("failed to schedule event reminders: %w", err)
+	}
+	if err := j.notifService.SendRegistrationConfirmation(ctx, registration, guardian); err != nil {
+		return fmt.Errorf("failed to send registration confirmation: %w", err)
+	}
+	return nil
+}
+
+// registrationCancelled removes the unsent reminders of a cancelled registration
+func (j *JobScheduler) registrationCancelled(ctx context.Context, message models.OutboxMessage) error {
+	var payload models.RegistrationOutboxPayload
+	if err := decodeOutboxPayload(message, &payload); err != nil {
+		return err
+	}
+	return j.notifService.CancelEventReminders(ctx, payload.RegistrationID)
+}
+
+// outboxRegistration loads the registration a registration message is about. It returns
+// nil when the registration no longer exists, which leaves nothing to do.
+func (j *JobScheduler) outboxRegistration(ctx context.Context, message models.OutboxMessage) (*models.Registration, error) {
+	var payload models.RegistrationOutboxPayload
+	if err := decodeOutboxPayload(message, &payload); err != nil {
+		return nil, err
+	}
+
+	output, err := j.repo.Registration.GetRegistrationByID(ctx, &models.GetRegistrationByIDInput{
+		ID:             payload.RegistrationID,
+		AcceptLanguage: "en-US",
+	}, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &output.Body, nil
+}
+
+func decodeOutboxPayload(message models.OutboxMessage, payload any) error {
+	if err := json.Unmarshal(message.Payload, payload); err != nil {
+		return permanent(fmt.Errorf("invalid %s payload: %w", message.Topic, err))
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var httpErr *errs.HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusNotFound
+}
@@ -0,0 +1,246 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	notificationmocks "skillspark/internal/notification/mocks"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type sentMessage struct {
+	body    json.RawMessage
+	dedupID string
+}
+
+// recordingQueue stands in for the SQS delivery queue
+type recordingQueue struct {
+	sent []sentMessage
+	err  error
+}
+
+func (q *recordingQueue) SendMessage(ctx context.Context, messageBody interface{}, dedupID string) error {
+	if q.err != nil {
+		return q.err
+	}
+	q.sent = append(q.sent, sentMessage{body: messageBody.(json.RawMessage), dedupID: dedupID})
+	return nil
+}
+
+func newTestOutboxMessage(t *testing.T, topic string, dedupID string, payload any, attempts int) models.OutboxMessage {
+	t.Helper()
+
+	encoded, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	return models.OutboxMessage{
+		ID:          uuid.New(),
+		Topic:       topic,
+		Payload:     encoded,
+		DedupID:     dedupID,
+		Status:      models.OutboxStatusPending,
+		Attempts:    attempts,
+		MaxAttempts: 10,
+	}
+}
+
+// expectClaim has the outbox hand out messages once and then report it is drained
+func expectClaim(mockOutboxRepo *repomocks.MockOutboxRepository, messages ...models.OutboxMessage) {
+	mockOutboxRepo.On("ClaimOutboxMessages", mock.Anything, outboxBatchSize, outboxVisibilityTimeout).Return(messages, nil).Once()
+	mockOutboxRepo.On("ClaimOutboxMessages", mock.Anything, outboxBatchSize, outboxVisibilityTimeout).Return([]models.OutboxMessage{}, nil).Once()
+}
+
+func TestRelayOutbox_PublishesNotificationWithDedupID(t *testing.T) {
+	mockOutboxRepo := new(repomocks.MockOutboxRepository)
+	queue := &recordingQueue{}
+	scheduler := &JobScheduler{repo: &storage.Repository{Outbox: mockOutboxRepo}, queue: queue}
+
+	message := newTestOutboxMessage(t, models.OutboxTopicNotification, "registration_confirmed:abc:email",
+		models.NotificationMessage{NotificationType: models.NotificationTypeEmail, Body: "You're registered", DedupID: "registration_confirmed:abc:email"}, 1)
+	expectClaim(mockOutboxRepo, message)
+	mockOutboxRepo.On("MarkOutboxMessagePublished", mock.Anything, message.ID, 1).Return(nil)
+
+	scheduler.RelayOutbox(context.Background())
+
+	require.Len(t, queue.sent, 1)
+	assert.Equal(t, "registration_confirmed:abc:email", queue.sent[0].dedupID)
+	assert.JSONEq(t, string(message.Payload), string(queue.sent[0].body))
+	mockOutboxRepo.AssertExpectations(t)
+}
+
+func TestRelayOutbox_RetriesWhenQueueIsDown(t *testing.T) {
+	mockOutboxRepo := new(repomocks.MockOutboxRepository)
+	scheduler := &JobScheduler{
+		repo:  &storage.Repository{Outbox: mockOutboxRepo},
+		queue: &recordingQueue{err: errors.New("connection refused")},
+	}
+
+	message := newTestOutboxMessage(t, models.OutboxTopicNotification, "notification:1", models.NotificationMessage{}, 2)
+	expectClaim(mockOutboxRepo, message)
+
+	before := time.Now()
+	mockOutboxRepo.On("RetryOutboxMessageLater", mock.Anything, message.ID, 2, mock.MatchedBy(func(availableAt time.Time) bool {
+		delay := availableAt.Sub(before)
+		return delay >= 2*time.Minute && delay < 3*time.Minute
+	}), "connection refused").Return(nil)
+
+	scheduler.RelayOutbox(context.Background())
+
+	mockOutboxRepo.AssertExpectations(t)
+	mockOutboxRepo.AssertNotCalled(t, "MarkOutboxMessagePublished", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRelayOutbox_FailsOnFinalAttempt(t *testing.T) {
+	mockOutboxRepo := new(repomocks.MockOutboxRepository)
+	scheduler := &JobScheduler{
+		repo:  &storage.Repository{Outbox: mockOutboxRepo},
+		queue: &recordingQueue{err: errors.New("connection refused")},
+	}
+
+	message := newTestOutboxMessage(t, models.OutboxTopicNotification, "notification:1", models.NotificationMessage{}, 10)
+	expectClaim(mockOutboxRepo, message)
+	mockOutboxRepo.On("FailOutboxMessage", mock.Anything, message.ID, 10, "connection refused").Return(nil)
+
+	scheduler.RelayOutbox(context.Background())
+
+	mockOutboxRepo.AssertExpectations(t)
+}
+
+func TestRelayOutbox_FailsUnknownTopic(t *testing.T) {
+	mockOutboxRepo := new(repomocks.MockOutboxRepository)
+	scheduler := &JobScheduler{repo: &storage.Repository{Outbox: mockOutboxRepo}}
+
+	message := newTestOutboxMessage(t, "payment.refunded", "payment.refunded:1", map[string]string{}, 1)
+	expectClaim(mockOutboxRepo, message)
+	mockOutboxRepo.On("FailOutboxMessage", mock.Anything, message.ID, 1, `unknown outbox topic "payment.refunded"`).Return(nil)
+
+	scheduler.RelayOutbox(context.Background())
+
+	mockOutboxRepo.AssertExpectations(t)
+}
+
+func TestRelayOutbox_RegistrationCreated(t *testing.T) {
+	guardian := &models.Guardian{ID: uuid.New(), Name: "Alex"}
+	registrationID := uuid.New()
+
+	tests := []struct {
+		name        string
+		lookup      func(*repomocks.MockRegistrationRepository)
+		wantNotices bool
+	}{
+		{
+			name: "sends the confirmation and schedules reminders",
+			lookup: func(regRepo *repomocks.MockRegistrationRepository) {
+				regRepo.On("GetRegistrationByID", mock.Anything, mock.Anything, mock.Anything).Return(&models.GetRegistrationByIDOutput{
+					Body: models.Registration{ID: registrationID, GuardianID: guardian.ID, Status: models.RegistrationStatusRegistered},
+				}, nil)
+			},
+			wantNotices: true,
+		},
+		{
+			name: "cancelled before the relay got to it",
+			lookup: func(regRepo *repomocks.MockRegistrationRepository) {
+				regRepo.On("GetRegistrationByID", mock.Anything, mock.Anything, mock.Anything).Return(&models.GetRegistrationByIDOutput{
+					Body: models.Registration{ID: registrationID, GuardianID: guardian.ID, Status: models.RegistrationStatusCancelled},
+				}, nil)
+			},
+		},
+		{
+			name: "registration no longer exists",
+			lookup: func(regRepo *repomocks.MockRegistrationRepository) {
+				notFound := errs.NotFound("Registration", "id", registrationID)
+				regRepo.On("GetRegistrationByID", mock.Anything, mock.Anything, mock.Anything).Return(nil, &notFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOutboxRepo := new(repomocks.MockOutboxRepository)
+			mockRegRepo := new(repomocks.MockRegistrationRepository)
+			mockGuardianRepo := new(repomocks.MockGuardianRepository)
+			mockNotifService := new(notificationmocks.MockNotificationService)
+			scheduler := &JobScheduler{
+				repo:         &storage.Repository{Outbox: mockOutboxRepo, Registration: mockRegRepo, Guardian: mockGuardianRepo},
+				notifService: mockNotifService,
+			}
+
+			message := newTestOutboxMessage(t, models.OutboxTopicRegistrationCreated, "registration.created:"+registrationID.String(),
+				models.RegistrationOutboxPayload{RegistrationID: registrationID}, 1)
+			expectClaim(mockOutboxRepo, message)
+			tt.lookup(mockRegRepo)
+			if tt.wantNotices {
+				mockGuardianRepo.On("GetGuardianByID", mock.Anything, guardian.ID).Return(guardian, nil)
+				isRegistration := mock.MatchedBy(func(r *models.Registration) bool { return r.ID == registrationID })
+				mockNotifService.On("CancelEventReminders", mock.Anything, registrationID).Return(nil)
+				mockNotifService.On("ScheduleEventReminders", mock.Anything, isRegistration, guardian).Return(nil)
+				mockNotifService.On("SendRegistrationConfirmation", mock.Anything, isRegistration, guardian).Return(nil)
+			}
+			mockOutboxRepo.On("MarkOutboxMessagePublished", mock.Anything, message.ID, 1).Return(nil)
+
+			scheduler.RelayOutbox(context.Background())
+
+			mockOutboxRepo.AssertExpectations(t)
+			mockNotifService.AssertExpectations(t)
+			if !tt.wantNotices {
+				mockNotifService.AssertNotCalled(t, "SendRegistrationConfirmation", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRelayOutbox_RegistrationCreatedRetriesFailedConfirmation(t *testing.T) {
+	guardian := &models.Guardian{ID: uuid.New()}
+	registration := models.Registration{ID: uuid.New(), GuardianID: guardian.ID, Status: models.RegistrationStatusRegistered}
+
+	mockOutboxRepo := new(repomocks.MockOutboxRepository)
+	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	mockGuardianRepo := new(repomocks.MockGuardianRepository)
+	mockNotifService := new(notificationmocks.MockNotificationService)
+	scheduler := &JobScheduler{
+		repo:         &storage.Repository{Outbox: mockOutboxRepo, Registration: mockRegRepo, Guardian: mockGuardianRepo},
+		notifService: mockNotifService,
+	}
+
+	message := newTestOutboxMessage(t, models.OutboxTopicRegistrationCreated, "registration.created:"+registration.ID.String(),
+		models.RegistrationOutboxPayload{RegistrationID: registration.ID}, 1)
+	expectClaim(mockOutboxRepo, message)
+	mockRegRepo.On("GetRegistrationByID", mock.Anything, mock.Anything, mock.Anything).Return(&models.GetRegistrationByIDOutput{Body: registration}, nil)
+	mockGuardianRepo.On("GetGuardianByID", mock.Anything, guardian.ID).Return(guardian, nil)
+	mockNotifService.On("CancelEventReminders", mock.Anything, registration.ID).Return(nil)
+	mockNotifService.On("ScheduleEventReminders", mock.Anything, mock.Anything, guardian).Return(nil)
+	mockNotifService.On("SendRegistrationConfirmation", mock.Anything, mock.Anything, guardian).Return(assert.AnError)
+	mockOutboxRepo.On("RetryOutboxMessageLater", mock.Anything, message.ID, 1, mock.Anything, mock.AnythingOfType("string")).Return(nil)
+
+	scheduler.RelayOutbox(context.Background())
+
+	mockOutboxRepo.AssertExpectations(t)
+}
+
+func TestRelayOutbox_RegistrationCancelled(t *testing.T) {
+	mockOutboxRepo := new(repomocks.MockOutboxRepository)
+	mockNotifService := new(notificationmocks.MockNotificationService)
+	scheduler := &JobScheduler{repo: &storage.Repository{Outbox: mockOutboxRepo}, notifService: mockNotifService}
+
+	registrationID := uuid.New()
+	message := newTestOutboxMessage(t, models.OutboxTopicRegistrationCancelled, "registration.cancelled:"+registrationID.String(),
+		models.RegistrationOutboxPayload{RegistrationID: registrationID}, 1)
+	expectClaim(mockOutboxRepo, message)
+	mockNotifService.On("CancelEventReminders", mock.Anything, registrationID).Return(nil)
+	mockOutboxRepo.On("MarkOutboxMessagePublished", mock.Anything, message.ID, 1).Return(nil)
+
+	scheduler.RelayOutbox(context.Background())
+
+	mockOutboxRepo.AssertExpectations(t)
+	mockNotifService.AssertExpectations(t)
+}
@@ -0,0 +1,87 @@
+package jobs
+
+import (
+	"fmt"
+	"log"
+	"skillspark/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// maxRunItemErrors bounds how many per-item errors are stored on one job run so a run
+// that fails on every item does not write an unbounded row. Counts are always exact.
+const maxRunItemErrors = 100
+
+// RunTracker collects the outcome of one job run for the job run history. Jobs call
+// Succeed or Failf once per item they process, and Abortf when the run cannot continue.
+type RunTracker struct {
+	jobName    string
+	dryRun     bool
+	processed  int
+	succeeded  int
+	failed     int
+	itemErrors []models.JobRunItemError
+	err        error
+}
+
+func NewRunTracker(jobName string, dryRun bool) *RunTracker {
+	return &RunTracker{jobName: jobName, dryRun: dryRun}
+}
+
+// DryRun reports whether the job should only read and count, without charging,
+// cancelling, writing or sending anything.
+func (t *RunTracker) DryRun() bool {
+	return t.dryRun
+}
+
+func (t *RunTracker) Succeed() {
+	t.processed++
+	t.succeeded++
+}
+
+// Failf logs and records a failed item
+func (t *RunTracker) Failf(itemID uuid.UUID, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	log.Printf("%s: %s", t.jobName, msg)
+
+	t.processed++
+	t.failed++
+	if len(t.itemErrors) < maxRunItemErrors {
+		t.itemErrors = append(t.itemErrors, models.JobRunItemError{ItemID: itemID, Error: msg})
+	}
+}
+
+// Abortf logs and records an error that stopped the whole run
+func (t *RunTracker) Abortf(format string, args ...any) {
+	t.err = fmt.Errorf(format, args...)
+	log.Printf("%s: %v", t.jobName, t.err)
+}
+
+func (t *RunTracker) status() models.JobRunStatus {
+	switch {
+	case t.err != nil:
+		return models.JobRunStatusFailed
+	case t.failed > 0 && t.succeeded == 0:
+		return models.JobRunStatusFailed
+	case t.failed > 0:
+		return models.JobRunStatusPartiallyFailed
+	default:
+		return models.JobRunStatusSucceeded
+	}
+}
+
+func (t *RunTracker) finishData(runID uuid.UUID) *models.FinishJobRunData {
+	data := &models.FinishJobRunData{
+		ID:             runID,
+		Status:         t.status(),
+		ItemsProcessed: t.processed,
+		ItemsSucceeded: t.succeeded,
+		ItemsFailed:    t.failed,
+		ItemErrors:     t.itemErrors,
+	}
+	if t.err != nil {
+		msg := t.err.Error()
+		data.Error = &msg
+	}
+	return data
+}
@@ -1,63 +1,271 @@
 package jobs
 
 import (
+	"context"
 	"log"
+	"skillspark/internal/delivery"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
 	"skillspark/internal/notification"
+	"skillspark/internal/s3_client"
+	"skillspark/internal/sqs_client"
 	"skillspark/internal/storage"
 	"skillspark/internal/stripeClient"
+	"time"
 
 	"github.com/robfig/cron/v3"
 )
 
+// Job names, used as advisory lock keys and in the job run history. Each run takes the
+// matching lock so a job only runs on one worker instance at a time, and each scheduled
+// run claims its tick in the history so the tick runs once.
+const (
+	capturePaymentsJobName            = "capture_payments"
+	sendScheduledNotificationsJobName = "send_scheduled_notifications"
+	createPaymentIntentsJobName       = "create_payment_intents"
+	checkPushReceiptsJobName          = "check_push_receipts"
+	sendBroadcastsJobName             = "send_broadcasts"
+	sendWeeklyDigestJobName           = "send_weekly_digest"
+	cleanupUploadsJobName             = "cleanup_uploads"
+	computeRecommendationsJobName     = "compute_recommendations"
+)
+
+// bangkok is the timezone job schedules are written in, whatever the server's is. Thailand
+// has no daylight saving, so a fixed offset is exact.
+var bangkok = time.FixedZone("ICT", 7*60*60)
+
+type jobFunc func(ctx context.Context, run *RunTracker)
+
 type JobScheduler struct {
 	cron         *cron.Cron
 	repo         *storage.Repository
 	stripeClient stripeClient.StripeClientInterface
-	notifService notification.Service
+	notifService notification.NotificationServiceInterface
+	pushReceipts pushReceiptClient
+	// queue is the SQS delivery queue the outbox relay publishes notifications to
+	queue    sqs_client.SQSInterface
+	s3Client s3_client.S3Interface
 }
 
-func NewJobScheduler(repo *storage.Repository, sc stripeClient.StripeClientInterface, notif notification.Service) *JobScheduler {
+// NewJobScheduler creates the scheduler. queue may be nil when the scheduler is only used
+// to trigger jobs, which never publish to it.
+func NewJobScheduler(repo *storage.Repository, sc stripeClient.StripeClientInterface, notif notification.NotificationServiceInterface, queue sqs_client.SQSInterface, s3Client s3_client.S3Interface) *JobScheduler {
 	return &JobScheduler{
-		cron:         cron.New(),
+		cron:         cron.New(cron.WithLocation(bangkok)),
 		repo:         repo,
 		stripeClient: sc,
 		notifService: notif,
+		pushReceipts: delivery.NewExpoTransport(""),
+		queue:        queue,
+		s3Client:     s3Client,
+	}
+}
+
+func (j *JobScheduler) jobs() map[string]jobFunc {
+	return map[string]jobFunc{
+		capturePaymentsJobName:            j.CapturePaymentsJob,
+		sendScheduledNotificationsJobName: j.SendScheduledNotificationsJob,
+		createPaymentIntentsJobName:       j.CreatePaymentIntentsJob,
+		checkPushReceiptsJobName:          j.CheckPushReceiptsJob,
+		sendBroadcastsJobName:             j.SendBroadcastsJob,
+		sendWeeklyDigestJobName:           j.SendWeeklyDigestJob,
+		cleanupUploadsJobName:             j.CleanupUploadsJob,
+		computeRecommendationsJobName:     j.ComputeRecommendationsJob,
+	}
+}
+
+// scheduledJob is a job run on a cron spec, read in Bangkok time
+type scheduledJob struct {
+	name string
+	spec string
+	job  jobFunc
+	// catchUp runs the job at startup if its latest tick passed without a run, e.g. while
+	// no worker was up
+	catchUp bool
+}
+
+func (j *JobScheduler) schedule() []scheduledJob {
+	return []scheduledJob{
+		{name: capturePaymentsJobName, spec: "0 * * * *", job: j.CapturePaymentsJob, catchUp: true},
+		{name: sendScheduledNotificationsJobName, spec: "*/5 * * * *", job: j.SendScheduledNotificationsJob, catchUp: true},
+		{name: createPaymentIntentsJobName, spec: "0 * * * *", job: j.CreatePaymentIntentsJob, catchUp: true},
+		{name: checkPushReceiptsJobName, spec: "*/15 * * * *", job: j.CheckPushReceiptsJob, catchUp: true},
+		{name: sendBroadcastsJobName, spec: "* * * * *", job: j.SendBroadcastsJob, catchUp: true},
+		// 9am Monday. Not caught up, since a worker started mid-week would send Monday's
+		// digest days late.
+		{name: sendWeeklyDigestJobName, spec: "0 9 * * 1", job: j.SendWeeklyDigestJob},
+		{name: cleanupUploadsJobName, spec: "*/30 * * * *", job: j.CleanupUploadsJob},
+		// 3am, when few families are browsing. Caught up so a new deployment doesn't serve
+		// a day without scores.
+		{name: computeRecommendationsJobName, spec: "0 3 * * *", job: j.ComputeRecommendationsJob, catchUp: true},
 	}
 }
 
 func (j *JobScheduler) Start() {
-	_, err := j.cron.AddFunc("0 * * * *", func() {
-		log.Println("Running payment capture job...")
-		j.CapturePaymentsJob()
-	})
-	if err != nil {
-		log.Fatalf("Failed to schedule payment capture job: %v", err)
+	// standard specs take their timezone from the time they are evaluated at, as the cron
+	// does with its location
+	now := time.Now().In(bangkok)
+	type missedRun struct {
+		job  scheduledJob
+		tick time.Time
+	}
+	var missed []missedRun
+
+	for _, sj := range j.schedule() {
+		schedule, err := cron.ParseStandard(sj.spec)
+		if err != nil {
+			log.Fatalf("Failed to parse schedule of job %s: %v", sj.name, err)
+		}
+		j.cron.Schedule(schedule, cron.FuncJob(func() {
+			log.Printf("Running job %s...", sj.name)
+			// cron fires at the start of the minute, so truncating recovers the tick
+			j.runScheduled(sj.name, time.Now().Truncate(time.Minute), sj.job)
+		}))
+
+		if sj.catchUp {
+			if tick, ok := previousTick(schedule, now); ok {
+				missed = append(missed, missedRun{job: sj, tick: tick})
+			}
+		}
 	}
 
-	_, err = j.cron.AddFunc("*/5 * * * *", func() {
-		log.Println("Running scheduled notification job...")
-		j.SendScheduledNotificationsJob()
+	// tasks are claimed individually, so every worker processes the queue without a job lock
+	_, err := j.cron.AddFunc("@every 15s", func() {
+		j.ProcessTasks(context.Background())
 	})
 	if err != nil {
-		log.Fatalf("Failed to schedule notification job: %v", err)
+		log.Fatalf("Failed to schedule task processing: %v", err)
 	}
 
-	_, err = j.cron.AddFunc("0 * * * *", func() {
-		log.Println("Running payment intent creation job...")
-		j.CreatePaymentIntentsJob()
+	// like tasks, outbox messages are claimed individually
+	_, err = j.cron.AddFunc("@every 5s", func() {
+		j.RelayOutbox(context.Background())
 	})
 	if err != nil {
-		log.Fatalf("Failed to schedule payment intent creation job: %v", err)
+		log.Fatalf("Failed to schedule outbox relay: %v", err)
 	}
 
 	j.cron.Start()
 	log.Println("Cron jobs started")
 
-	j.CapturePaymentsJob()
-	j.SendScheduledNotificationsJob()
-	j.CreatePaymentIntentsJob()
+	// the latest tick is claimed like any other, so it only runs if no worker ran it
+	for _, m := range missed {
+		j.runScheduled(m.job.name, m.tick, m.job.job)
+	}
+}
+
+// catchUpLookback bounds how far back previousTick looks; every schedule ticks at least weekly
+const catchUpLookback = 8 * 24 * time.Hour
+
+// previousTick returns the latest time at or before now that schedule fired
+func previousTick(schedule cron.Schedule, now time.Time) (time.Time, bool) {
+	var tick time.Time
+	found := false
+	for next := schedule.Next(now.Add(-catchUpLookback)); !next.After(now); next = schedule.Next(next) {
+		tick = next
+		found = true
+	}
+	return tick, found
 }
 
+// Stop stops scheduling new runs and waits for any running job to finish
 func (j *JobScheduler) Stop() {
-	j.cron.Stop()
+	<-j.cron.Stop().Done()
+}
+
+// Trigger starts a manual run of the named job in the background and returns its run
+// record, which is updated when the run finishes. Real runs take the same lock as
+// scheduled runs and fail with a conflict while the job is already running; dry runs
+// only read, so they skip the lock.
+func (j *JobScheduler) Trigger(ctx context.Context, name string, dryRun bool) (*models.JobRun, error) {
+	job, ok := j.jobs()[name]
+	if !ok {
+		errr := errs.NotFound("Job", "name", name)
+		return nil, &errr
+	}
+
+	release := func() {}
+	if !dryRun {
+		lockRelease, acquired, err := j.repo.JobLock.TryAcquireJobLock(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if !acquired {
+			errr := errs.Conflict("Job is already running", "name", name)
+			return nil, &errr
+		}
+		release = lockRelease
+	}
+
+	run, err := j.repo.JobRun.CreateJobRun(ctx, &models.CreateJobRunData{
+		JobName: name,
+		Trigger: models.JobRunTriggerManual,
+		DryRun:  dryRun,
+	})
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	go func() {
+		defer release()
+		// the request context ends with the response; the run must outlive it
+		j.execute(context.Background(), name, dryRun, run, job)
+	}()
+
+	return run, nil
+}
+
+// runScheduled runs job for the cron tick scheduledFor, unless another instance currently
+// holds its lock or has already claimed that tick. A skipped run is not queued: the jobs
+// select their work from the database each time, so the next tick picks up anything that
+// was missed.
+func (j *JobScheduler) runScheduled(name string, scheduledFor time.Time, job jobFunc) {
+	ctx := context.Background()
+
+	release, acquired, err := j.repo.JobLock.TryAcquireJobLock(ctx, name)
+	if err != nil {
+		log.Printf("Skipping job %s: failed to acquire lock: %v", name, err)
+		return
+	}
+	if !acquired {
+		log.Printf("Skipping job %s: already running on another instance", name)
+		return
+	}
+	defer release()
+
+	// the lock is released after each run, so the claim is what stops a worker whose
+	// clock or start-up lags from running a tick another worker has finished
+	run, claimed, err := j.repo.JobRun.ClaimScheduledJobRun(ctx, name, scheduledFor)
+	if err != nil {
+		log.Printf("Skipping job %s: failed to claim run for %s: %v", name, scheduledFor, err)
+		return
+	}
+	if !claimed {
+		log.Printf("Skipping job %s: its run for %s has already started", name, scheduledFor)
+		return
+	}
+
+	j.execute(ctx, name, false, run, job)
+}
+
+// execute runs job and writes its outcome to the run record, if there is one
+func (j *JobScheduler) execute(ctx context.Context, name string, dryRun bool, run *models.JobRun, job jobFunc) {
+	tracker := NewRunTracker(name, dryRun)
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				tracker.Abortf("panicked: %v", r)
+			}
+		}()
+		job(ctx, tracker)
+	}()
+
+	if run == nil {
+		return
+	}
+	if _, err := j.repo.JobRun.FinishJobRun(ctx, tracker.finishData(run.ID)); err != nil {
+		log.Printf("Failed to record outcome of job %s run %s: %v", name, run.ID, err)
+	}
 }
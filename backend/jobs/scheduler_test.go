@@ -0,0 +1,251 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func makeLockedScheduler(mockJobLock *repomocks.MockJobLockRepository, mockJobRun *repomocks.MockJobRunRepository) *JobScheduler {
+	return &JobScheduler{repo: &storage.Repository{JobLock: mockJobLock, JobRun: mockJobRun}}
+}
+
+var testTick = time.Date(2026, time.March, 6, 9, 0, 0, 0, time.UTC)
+
+func TestRunScheduled_RecordsRunAndReleasesLock(t *testing.T) {
+	mockJobLock := new(repomocks.MockJobLockRepository)
+	mockJobRun := new(repomocks.MockJobRunRepository)
+	scheduler := makeLockedScheduler(mockJobLock, mockJobRun)
+
+	released := false
+	mockJobLock.On("TryAcquireJobLock", mock.Anything, capturePaymentsJobName).
+		Return(func() { released = true }, true, nil)
+
+	runID := uuid.New()
+	mockJobRun.On("ClaimScheduledJobRun", mock.Anything, capturePaymentsJobName, testTick).
+		Return(&models.JobRun{ID: runID, JobName: capturePaymentsJobName}, true, nil)
+
+	itemID := uuid.New()
+	mockJobRun.On("FinishJobRun", mock.Anything, mock.MatchedBy(func(input *models.FinishJobRunData) bool {
+		return input.ID == runID &&
+			input.Status == models.JobRunStatusPartiallyFailed &&
+			input.ItemsProcessed == 2 &&
+			input.ItemsSucceeded == 1 &&
+			input.ItemsFailed == 1 &&
+			len(input.ItemErrors) == 1 &&
+			input.ItemErrors[0].ItemID == itemID
+	})).Return(&models.JobRun{ID: runID}, nil)
+
+	scheduler.runScheduled(capturePaymentsJobName, testTick, func(ctx context.Context, run *RunTracker) {
+		assert.False(t, released, "lock must be held while the job runs")
+		run.Succeed()
+		run.Failf(itemID, "card declined")
+	})
+
+	assert.True(t, released)
+	mockJobLock.AssertExpectations(t)
+	mockJobRun.AssertExpectations(t)
+}
+
+func TestRunScheduled_SkipsWhenLockHeldElsewhere(t *testing.T) {
+	mockJobLock := new(repomocks.MockJobLockRepository)
+	mockJobRun := new(repomocks.MockJobRunRepository)
+	scheduler := makeLockedScheduler(mockJobLock, mockJobRun)
+
+	mockJobLock.On("TryAcquireJobLock", mock.Anything, createPaymentIntentsJobName).
+		Return(nil, false, nil)
+
+	ran := false
+	scheduler.runScheduled(createPaymentIntentsJobName, testTick, func(ctx context.Context, run *RunTracker) { ran = true })
+
+	assert.False(t, ran)
+	mockJobLock.AssertExpectations(t)
+	mockJobRun.AssertNotCalled(t, "ClaimScheduledJobRun", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRunScheduled_SkipsWhenLockErrors(t *testing.T) {
+	mockJobLock := new(repomocks.MockJobLockRepository)
+	mockJobRun := new(repomocks.MockJobRunRepository)
+	scheduler := makeLockedScheduler(mockJobLock, mockJobRun)
+
+	mockJobLock.On("TryAcquireJobLock", mock.Anything, sendScheduledNotificationsJobName).
+		Return(nil, false, errors.New("connection refused"))
+
+	ran := false
+	scheduler.runScheduled(sendScheduledNotificationsJobName, testTick, func(ctx context.Context, run *RunTracker) { ran = true })
+
+	assert.False(t, ran)
+	mockJobLock.AssertExpectations(t)
+}
+
+func TestRunScheduled_SkipsTickAlreadyClaimed(t *testing.T) {
+	mockJobLock := new(repomocks.MockJobLockRepository)
+	mockJobRun := new(repomocks.MockJobRunRepository)
+	scheduler := makeLockedScheduler(mockJobLock, mockJobRun)
+
+	// another worker ran this tick and released the lock before this one got to it
+	mockJobLock.On("TryAcquireJobLock", mock.Anything, capturePaymentsJobName).
+		Return(func() {}, true, nil)
+	mockJobRun.On("ClaimScheduledJobRun", mock.Anything, capturePaymentsJobName, testTick).Return(nil, false, nil)
+
+	ran := false
+	scheduler.runScheduled(capturePaymentsJobName, testTick, func(ctx context.Context, run *RunTracker) { ran = true })
+
+	assert.False(t, ran)
+	mockJobRun.AssertExpectations(t)
+	mockJobRun.AssertNotCalled(t, "FinishJobRun", mock.Anything, mock.Anything)
+}
+
+func TestRunScheduled_SkipsWhenClaimFails(t *testing.T) {
+	mockJobLock := new(repomocks.MockJobLockRepository)
+	mockJobRun := new(repomocks.MockJobRunRepository)
+	scheduler := makeLockedScheduler(mockJobLock, mockJobRun)
+
+	mockJobLock.On("TryAcquireJobLock", mock.Anything, capturePaymentsJobName).
+		Return(func() {}, true, nil)
+	mockJobRun.On("ClaimScheduledJobRun", mock.Anything, capturePaymentsJobName, testTick).Return(nil, false, errors.New("db down"))
+
+	ran := false
+	scheduler.runScheduled(capturePaymentsJobName, testTick, func(ctx context.Context, run *RunTracker) { ran = true })
+
+	assert.False(t, ran)
+	mockJobRun.AssertNotCalled(t, "FinishJobRun", mock.Anything, mock.Anything)
+}
+
+func TestRunScheduled_RecordsPanicAsFailure(t *testing.T) {
+	mockJobLock := new(repomocks.MockJobLockRepository)
+	mockJobRun := new(repomocks.MockJobRunRepository)
+	scheduler := makeLockedScheduler(mockJobLock, mockJobRun)
+
+	mockJobLock.On("TryAcquireJobLock", mock.Anything, capturePaymentsJobName).
+		Return(func() {}, true, nil)
+	runID := uuid.New()
+	mockJobRun.On("ClaimScheduledJobRun", mock.Anything, capturePaymentsJobName, testTick).Return(&models.JobRun{ID: runID}, true, nil)
+	mockJobRun.On("FinishJobRun", mock.Anything, mock.MatchedBy(func(input *models.FinishJobRunData) bool {
+		return input.ID == runID && input.Status == models.JobRunStatusFailed && input.Error != nil
+	})).Return(&models.JobRun{ID: runID}, nil)
+
+	scheduler.runScheduled(capturePaymentsJobName, testTick, func(ctx context.Context, run *RunTracker) {
+		panic("boom")
+	})
+
+	mockJobRun.AssertExpectations(t)
+}
+
+func TestTrigger_UnknownJob(t *testing.T) {
+	scheduler := makeLockedScheduler(new(repomocks.MockJobLockRepository), new(repomocks.MockJobRunRepository))
+
+	run, err := scheduler.Trigger(context.Background(), "does_not_exist", false)
+	require.Error(t, err)
+	assert.Nil(t, run)
+
+	httpErr, ok := err.(*errs.HTTPError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, httpErr.Code)
+}
+
+func TestTrigger_ConflictWhenAlreadyRunning(t *testing.T) {
+	mockJobLock := new(repomocks.MockJobLockRepository)
+	mockJobRun := new(repomocks.MockJobRunRepository)
+	scheduler := makeLockedScheduler(mockJobLock, mockJobRun)
+
+	mockJobLock.On("TryAcquireJobLock", mock.Anything, capturePaymentsJobName).Return(nil, false, nil)
+
+	run, err := scheduler.Trigger(context.Background(), capturePaymentsJobName, false)
+	require.Error(t, err)
+	assert.Nil(t, run)
+
+	httpErr, ok := err.(*errs.HTTPError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusConflict, httpErr.Code)
+	mockJobRun.AssertNotCalled(t, "ClaimScheduledJobRun", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTrigger_DryRunSkipsLockAndRecordsRun(t *testing.T) {
+	mockJobLock := new(repomocks.MockJobLockRepository)
+	mockJobRun := new(repomocks.MockJobRunRepository)
+	mockRegRepo := new(repomocks.MockRegistrationRepository)
+	scheduler := makeLockedScheduler(mockJobLock, mockJobRun)
+	scheduler.repo.Registration = mockRegRepo
+
+	runID := uuid.New()
+	mockJobRun.On("CreateJobRun", mock.Anything, &models.CreateJobRunData{
+		JobName: capturePaymentsJobName,
+		Trigger: models.JobRunTriggerManual,
+		DryRun:  true,
+	}).Return(&models.JobRun{ID: runID, JobName: capturePaymentsJobName, DryRun: true}, nil)
+
+	mockRegRepo.On("GetRegistrationsForCapture", mock.Anything, mock.Anything, mock.Anything).
+		Return([]models.Registration{{ID: uuid.New()}}, nil)
+
+	finished := make(chan *models.FinishJobRunData, 1)
+	mockJobRun.On("FinishJobRun", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { finished <- args.Get(1).(*models.FinishJobRunData) }).
+		Return(&models.JobRun{ID: runID}, nil)
+
+	run, err := scheduler.Trigger(context.Background(), capturePaymentsJobName, true)
+	require.NoError(t, err)
+	assert.Equal(t, runID, run.ID)
+
+	select {
+	case data := <-finished:
+		assert.Equal(t, models.JobRunStatusSucceeded, data.Status)
+		assert.Equal(t, 1, data.ItemsSucceeded)
+	case <-time.After(5 * time.Second):
+		t.Fatal("dry run did not finish")
+	}
+
+	mockJobLock.AssertNotCalled(t, "TryAcquireJobLock", mock.Anything, mock.Anything)
+}
+
+func TestPreviousTick(t *testing.T) {
+	hourly, err := cron.ParseStandard("CRON_TZ=UTC 0 * * * *")
+	require.NoError(t, err)
+	weekly, err := cron.ParseStandard("CRON_TZ=UTC 0 2 * * 1")
+	require.NoError(t, err)
+
+	now := time.Date(2026, time.March, 6, 9, 30, 0, 0, time.UTC) // a Friday
+
+	tick, ok := previousTick(hourly, now)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, time.March, 6, 9, 0, 0, 0, time.UTC), tick)
+
+	// a tick exactly at now counts as passed
+	tick, ok = previousTick(hourly, time.Date(2026, time.March, 6, 9, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, time.March, 6, 9, 0, 0, 0, time.UTC), tick)
+
+	tick, ok = previousTick(weekly, now)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, time.March, 2, 2, 0, 0, 0, time.UTC), tick)
+}
+
+func TestSchedule_RunsInBangkokTime(t *testing.T) {
+	specs := map[string]string{}
+	for _, job := range (&JobScheduler{}).schedule() {
+		specs[job.name] = job.spec
+	}
+
+	// evaluated the way Start evaluates them, whatever the server's timezone
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC).In(bangkok) // a Sunday
+
+	digest, err := cron.ParseStandard(specs[sendWeeklyDigestJobName])
+	require.NoError(t, err)
+	assert.True(t, time.Date(2026, time.March, 2, 2, 0, 0, 0, time.UTC).Equal(digest.Next(now)))
+
+	recommendations, err := cron.ParseStandard(specs[computeRecommendationsJobName])
+	require.NoError(t, err)
+	assert.True(t, time.Date(2026, time.March, 1, 20, 0, 0, 0, time.UTC).Equal(recommendations.Next(now)))
+}
@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+)
+
+// broadcastBatchSize is how many due broadcasts one run sends
+const broadcastBatchSize = 20
+
+// SendBroadcastsJob sends the broadcasts whose scheduled time has come. Each broadcast is
+// claimed before it is fanned out, so it is sent once even if runs overlap. A broadcast that
+// fails part way is still marked sent with the guardians it reached: sending it again would
+// message the rest of its recipients twice.
+func (j *JobScheduler) SendBroadcastsJob(ctx context.Context, run *RunTracker) {
+	if run.DryRun() {
+		broadcasts, err := j.repo.Broadcast.GetDueBroadcasts(ctx, broadcastBatchSize)
+		if err != nil {
+			run.Abortf("failed to get due broadcasts: %v", err)
+			return
+		}
+		for range broadcasts {
+			run.Succeed()
+		}
+		return
+	}
+
+	broadcasts, err := j.repo.Broadcast.ClaimDueBroadcasts(ctx, broadcastBatchSize)
+	if err != nil {
+		run.Abortf("failed to claim due broadcasts: %v", err)
+		return
+	}
+
+	if len(broadcasts) == 0 {
+		slog.Info("No due broadcasts found")
+		return
+	}
+
+	slog.Info("Sending broadcasts", "count", len(broadcasts))
+
+	for _, broadcast := range broadcasts {
+		sent, sendErr := j.notifService.SendBroadcast(ctx, &broadcast)
+
+		if err := j.repo.Broadcast.FinishBroadcast(ctx, broadcast.ID, sent); err != nil {
+			run.Failf(broadcast.ID, "failed to mark broadcast sent: %v", err)
+			continue
+		}
+		if sendErr != nil {
+			run.Failf(broadcast.ID, "broadcast reached %d guardians: %v", sent, sendErr)
+			continue
+		}
+
+		slog.Info("Sent broadcast", "id", broadcast.ID, "organization_id", broadcast.OrganizationID, "recipients", sent)
+		run.Succeed()
+	}
+}
@@ -0,0 +1,73 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/models"
+	notificationmocks "skillspark/internal/notification/mocks"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSendBroadcastsJob(t *testing.T) {
+	delivered := models.Broadcast{ID: uuid.New(), OrganizationID: uuid.New(), Status: models.BroadcastStatusSending}
+	partial := models.Broadcast{ID: uuid.New(), OrganizationID: uuid.New(), Status: models.BroadcastStatusSending}
+
+	mockBroadcastRepo := new(repomocks.MockBroadcastRepository)
+	mockNotifService := new(notificationmocks.MockNotificationService)
+	scheduler := &JobScheduler{
+		repo:         &storage.Repository{Broadcast: mockBroadcastRepo},
+		notifService: mockNotifService,
+	}
+
+	mockBroadcastRepo.On("ClaimDueBroadcasts", mock.Anything, broadcastBatchSize).Return([]models.Broadcast{delivered, partial}, nil)
+	mockNotifService.On("SendBroadcast", mock.Anything, mock.MatchedBy(func(b *models.Broadcast) bool { return b.ID == delivered.ID })).Return(4, nil)
+	mockNotifService.On("SendBroadcast", mock.Anything, mock.MatchedBy(func(b *models.Broadcast) bool { return b.ID == partial.ID })).Return(2, errors.New("inbox unavailable"))
+	// a partly sent broadcast is still finished so it isn't sent to anyone twice
+	mockBroadcastRepo.On("FinishBroadcast", mock.Anything, delivered.ID, 4).Return(nil).Once()
+	mockBroadcastRepo.On("FinishBroadcast", mock.Anything, partial.ID, 2).Return(nil).Once()
+
+	run := NewRunTracker(sendBroadcastsJobName, false)
+	scheduler.SendBroadcastsJob(context.Background(), run)
+
+	mockBroadcastRepo.AssertExpectations(t)
+	mockNotifService.AssertExpectations(t)
+	assert.Equal(t, 1, run.succeeded)
+	assert.Equal(t, models.JobRunStatusPartiallyFailed, run.status())
+}
+
+func TestSendBroadcastsJob_DryRunDoesNotClaim(t *testing.T) {
+	mockBroadcastRepo := new(repomocks.MockBroadcastRepository)
+	mockNotifService := new(notificationmocks.MockNotificationService)
+	scheduler := &JobScheduler{
+		repo:         &storage.Repository{Broadcast: mockBroadcastRepo},
+		notifService: mockNotifService,
+	}
+
+	mockBroadcastRepo.On("GetDueBroadcasts", mock.Anything, broadcastBatchSize).
+		Return([]models.Broadcast{{ID: uuid.New()}, {ID: uuid.New()}}, nil)
+
+	run := NewRunTracker(sendBroadcastsJobName, true)
+	scheduler.SendBroadcastsJob(context.Background(), run)
+
+	mockBroadcastRepo.AssertNotCalled(t, "ClaimDueBroadcasts", mock.Anything, mock.Anything)
+	mockNotifService.AssertNotCalled(t, "SendBroadcast", mock.Anything, mock.Anything)
+	assert.Equal(t, 2, run.succeeded)
+}
+
+func TestSendBroadcastsJob_ClaimError(t *testing.T) {
+	mockBroadcastRepo := new(repomocks.MockBroadcastRepository)
+	scheduler := &JobScheduler{repo: &storage.Repository{Broadcast: mockBroadcastRepo}}
+
+	mockBroadcastRepo.On("ClaimDueBroadcasts", mock.Anything, broadcastBatchSize).Return(nil, errors.New("db down"))
+
+	run := NewRunTracker(sendBroadcastsJobName, false)
+	scheduler.SendBroadcastsJob(context.Background(), run)
+
+	assert.Equal(t, models.JobRunStatusFailed, run.status())
+}
@@ -2,26 +2,29 @@ package jobs
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"github.com/google/uuid"
-	"log"
 	"log/slog"
 	"skillspark/internal/models"
-)
+	notificationpkg "skillspark/internal/notification"
+	"time"
 
-func (j *JobScheduler) SendScheduledNotificationsJob() {
-	defer func() {
-		if r := recover(); r != nil {
-			log.Printf("CapturePaymentsJob panicked: %v", r)
-		}
-	}()
+	"github.com/google/uuid"
+)
 
-	ctx := context.Background()
+// sendNotificationPayload names the notification to send and the send time it was queued
+// for; the task reloads the row so it never sends a stale copy
+type sendNotificationPayload struct {
+	NotificationID uuid.UUID `json:"notification_id"`
+	ScheduledFor   time.Time `json:"scheduled_for"`
+}
 
+// SendScheduledNotificationsJob queues a send task for each notification that is due
+func (j *JobScheduler) SendScheduledNotificationsJob(ctx context.Context, run *RunTracker) {
 	// Get pending notifications that are due
 	notifications, err := j.repo.Notification.GetPendingNotifications(ctx)
 	if err != nil {
-		slog.Error("Failed to get pending notifications", "error", err)
+		run.Abortf("failed to get pending notifications: %v", err)
 		return
 	}
 
@@ -32,86 +35,113 @@ func (j *JobScheduler) SendScheduledNotificationsJob() {
 
 	slog.Info("Found pending notifications", "count", len(notifications))
 
-	// Collect unique guardian IDs from notifications that have one
-	seen := make(map[uuid.UUID]bool)
-	guardianIDs := make([]uuid.UUID, 0)
-	for _, n := range notifications {
-		if n.GuardianID != nil && !seen[*n.GuardianID] {
-			guardianIDs = append(guardianIDs, *n.GuardianID)
-			seen[*n.GuardianID] = true
+	for _, notification := range notifications {
+		if run.DryRun() {
+			run.Succeed()
+			continue
 		}
-	}
 
-	// Bulk-fetch notification preferences for all relevant guardians
-	var guardianPrefs map[uuid.UUID]models.GuardianNotificationPreferences
-	if len(guardianIDs) > 0 {
-		guardianPrefs, err = j.repo.Guardian.GetGuardianNotificationPreferences(ctx, guardianIDs)
+		// notifications stay pending until their task has run, so later ticks see them
+		// again; the dedup key keeps them from being queued twice. It includes the send
+		// time so a notification deferred past quiet hours is queued again when it is due.
+		dedupKey := fmt.Sprintf("send_notification:%s:%d", notification.ID, notification.ScheduledFor.Unix())
+		payload := sendNotificationPayload{NotificationID: notification.ID, ScheduledFor: notification.ScheduledFor}
+		_, err := j.enqueueTask(ctx, sendNotificationTaskType, dedupKey, payload)
 		if err != nil {
-			slog.Error("Failed to get guardian notification preferences", "error", err)
-			return
+			run.Failf(notification.ID, "failed to enqueue notification: %v", err)
+			continue
 		}
+
+		run.Succeed()
 	}
+}
 
-	// Process each notification
-	for _, notification := range notifications {
-		// Check guardian's notification preferences before sending
-		if notification.GuardianID != nil {
-			if prefs, ok := guardianPrefs[*notification.GuardianID]; ok {
-				if notification.NotificationType == models.NotificationTypeEmail && !prefs.EmailNotifications {
-					slog.Info("Skipping notification: guardian has email notifications disabled", "id", notification.ID, "guardian_id", *notification.GuardianID)
-					_, updateErr := j.repo.Notification.UpdateNotificationStatus(ctx, notification.ID, models.NotificationStatusSent)
-					if updateErr != nil {
-						slog.Error("Failed to update skipped notification status", "id", notification.ID, "error", updateErr)
-					}
-					continue
-				}
-				if notification.NotificationType == models.NotificationTypePush && !prefs.PushNotifications {
-					slog.Info("Skipping notification: guardian has push notifications disabled", "id", notification.ID, "guardian_id", *notification.GuardianID)
-					_, updateErr := j.repo.Notification.UpdateNotificationStatus(ctx, notification.ID, models.NotificationStatusSent)
-					if updateErr != nil {
-						slog.Error("Failed to update skipped notification status", "id", notification.ID, "error", updateErr)
-					}
-					continue
-				}
+// sendNotificationTask queues one scheduled notification for delivery, unless the guardian
+// has turned its topic off on that channel, in which case it is marked skipped. The row is
+// reloaded first and left alone if it was deleted, already handled or rescheduled since the
+// task was queued. A notification that falls in the guardian's quiet hours is left pending
+// and moved to the end of them, which also covers retries. The notification is marked failed
+// only once the last attempt fails.
+func (j *JobScheduler) sendNotificationTask(ctx context.Context, task models.Task) error {
+	var payload sendNotificationPayload
+	if err := decodeTaskPayload(task, &payload); err != nil {
+		return err
+	}
+
+	current, err := j.repo.Notification.GetNotificationByID(ctx, payload.NotificationID)
+	if err != nil {
+		if isNotFound(err) {
+			slog.Info("Skipping notification: it was deleted", "id", payload.NotificationID)
+			return nil
+		}
+		return fmt.Errorf("failed to load notification: %w", err)
+	}
+	if current.Status != models.NotificationStatusPending || !current.ScheduledFor.Equal(payload.ScheduledFor) {
+		slog.Info("Skipping notification: it changed since it was queued", "id", current.ID, "status", current.Status, "scheduled_for", current.ScheduledFor)
+		return nil
+	}
+	notification := *current
+
+	if err := j.processNotification(ctx, notification); err != nil {
+		var quietHours *notificationpkg.QuietHoursError
+		if errors.As(err, &quietHours) {
+			slog.Info("Deferring notification until the guardian's quiet hours end", "id", notification.ID, "guardian_id", notification.GuardianID, "until", quietHours.Until)
+			if err := j.repo.Notification.DeferNotification(ctx, notification.ID, quietHours.Until); err != nil {
+				return fmt.Errorf("failed to defer notification: %w", err)
+			}
+			return nil
+		}
+
+		// the guardian's preferences are checked by SendNotification
+		if errors.Is(err, notificationpkg.ErrChannelDisabled) {
+			slog.Info("Skipping notification: guardian has this channel disabled", "id", notification.ID, "guardian_id", notification.GuardianID, "type", notification.NotificationType)
+			if _, err := j.repo.Notification.UpdateNotificationStatus(ctx, notification.ID, models.NotificationStatusSkipped); err != nil {
+				return fmt.Errorf("failed to update skipped notification status: %w", err)
 			}
+			return nil
 		}
 
-		if err := j.processNotification(ctx, notification); err != nil {
-			slog.Error("Failed to process notification", "id", notification.ID, "error", err)
-			// Update status to failed
+		if task.IsFinalAttempt() {
 			_, updateErr := j.repo.Notification.UpdateNotificationStatus(ctx, notification.ID, models.NotificationStatusFailed)
 			if updateErr != nil {
 				slog.Error("Failed to update notification status to failed", "id", notification.ID, "error", updateErr)
 			}
-			continue
 		}
+		return err
+	}
 
-		// Update status to sent (even though it's just queued, we mark it as sent to prevent reprocessing)
-		// The actual sending will be handled by Lambda
-		_, err := j.repo.Notification.UpdateNotificationStatus(ctx, notification.ID, models.NotificationStatusSent)
-		if err != nil {
-			slog.Error("Failed to update notification status", "id", notification.ID, "error", err)
-			// Continue processing other notifications even if status update fails
-		} else {
-			slog.Info("Notification forwarded to SQS", "id", notification.ID)
-		}
+	// Mark it sent (handed to the queue) so it isn't picked up again; the delivery worker
+	// records the final delivered or failed status
+	if _, err := j.repo.Notification.UpdateNotificationStatus(ctx, notification.ID, models.NotificationStatusSent); err != nil {
+		// not returned: the message is already queued, and the job would only find it again
+		slog.Error("Failed to update notification status", "id", notification.ID, "error", err)
+		return nil
 	}
+	slog.Info("Notification queued for delivery", "id", notification.ID)
+
+	return nil
 }
 
 func (j *JobScheduler) processNotification(ctx context.Context, notification models.Notification) error {
-	// Create notification message for SQS
+	// the notification ID doubles as the message's dedup ID, so a retried task queues it once
 	message := &models.SendNotificationInput{
 		NotificationType:   notification.NotificationType,
 		RecipientEmail:     notification.RecipientEmail,
 		RecipientPushToken: notification.RecipientPushToken,
 		Subject:            notification.Subject,
 		Body:               notification.Body,
+		HTMLBody:           notification.HTMLBody,
 		Metadata:           notification.Metadata,
+		GuardianID:         notification.GuardianID,
+		NotificationID:     &notification.ID,
+		Urgent:             notification.Urgent,
+	}
+	if notification.Topic != nil {
+		message.Topic = *notification.Topic
 	}
 
-	// Send to SQS
 	if err := j.notifService.SendNotification(ctx, message); err != nil {
-		return fmt.Errorf("failed to send notification to SQS: %w", err)
+		return fmt.Errorf("failed to queue notification: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,244 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"skillspark/internal/errs"
+	"skillspark/internal/models"
+	"skillspark/internal/notification"
+	notificationmocks "skillspark/internal/notification/mocks"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendScheduledNotificationsJob_EnqueuesTasks(t *testing.T) {
+	mockNotifRepo := new(repomocks.MockNotificationRepository)
+	mockTaskRepo := new(repomocks.MockTaskRepository)
+	scheduler := &JobScheduler{
+		repo: &storage.Repository{Notification: mockNotifRepo, Task: mockTaskRepo},
+	}
+
+	scheduledFor := time.Date(2026, time.March, 6, 0, 0, 0, 0, time.UTC)
+	notification := models.Notification{ID: uuid.New(), NotificationType: models.NotificationTypePush, Body: "hi", ScheduledFor: scheduledFor}
+	mockNotifRepo.On("GetPendingNotifications", mock.Anything).Return([]models.Notification{notification}, nil)
+	mockTaskRepo.On("EnqueueTask", mock.Anything, mock.MatchedBy(func(input *models.EnqueueTaskData) bool {
+		// only the ID and send time are queued, the task reloads the rest
+		var payload map[string]any
+		if err := json.Unmarshal(input.Payload, &payload); err != nil {
+			return false
+		}
+		return input.TaskType == sendNotificationTaskType &&
+			*input.DedupKey == fmt.Sprintf("send_notification:%s:%d", notification.ID, scheduledFor.Unix()) &&
+			payload["notification_id"] == notification.ID.String() &&
+			len(payload) == 2
+	})).Return(nil, nil)
+
+	run := NewRunTracker(sendScheduledNotificationsJobName, false)
+	scheduler.SendScheduledNotificationsJob(context.Background(), run)
+
+	mockTaskRepo.AssertExpectations(t)
+	assert.Equal(t, 1, run.succeeded)
+}
+
+func TestSendScheduledNotificationsJob_FetchError(t *testing.T) {
+	mockNotifRepo := new(repomocks.MockNotificationRepository)
+	mockTaskRepo := new(repomocks.MockTaskRepository)
+	scheduler := &JobScheduler{
+		repo: &storage.Repository{Notification: mockNotifRepo, Task: mockTaskRepo},
+	}
+
+	mockNotifRepo.On("GetPendingNotifications", mock.Anything).Return(nil, assert.AnError)
+
+	run := NewRunTracker(sendScheduledNotificationsJobName, false)
+	scheduler.SendScheduledNotificationsJob(context.Background(), run)
+
+	mockTaskRepo.AssertNotCalled(t, "EnqueueTask", mock.Anything, mock.Anything)
+	assert.Equal(t, models.JobRunStatusFailed, run.status())
+}
+
+func TestSendNotificationTask(t *testing.T) {
+	guardianID := uuid.New()
+	topic := models.NotificationTopicEventReminders
+	scheduledFor := time.Date(2026, time.March, 6, 0, 0, 0, 0, time.UTC)
+	scheduled := models.Notification{
+		ID:               uuid.New(),
+		NotificationType: models.NotificationTypeEmail,
+		Body:             "Your class starts soon",
+		GuardianID:       &guardianID,
+		Topic:            &topic,
+		ScheduledFor:     scheduledFor,
+		Status:           models.NotificationStatusPending,
+	}
+	quietUntil := time.Date(2026, time.March, 7, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		attempts       int
+		current        func() *models.Notification
+		loadErr        error
+		sendErr        error
+		updateErr      error
+		expectSend     bool
+		expectDefer    bool
+		deferErr       error
+		expectedStatus models.NotificationStatus
+		wantErr        bool
+	}{
+		{
+			name:           "sends and marks sent",
+			attempts:       1,
+			expectSend:     true,
+			expectedStatus: models.NotificationStatusSent,
+		},
+		{
+			name:           "skips disabled channel",
+			attempts:       1,
+			sendErr:        notification.ErrChannelDisabled,
+			expectSend:     true,
+			expectedStatus: models.NotificationStatusSkipped,
+		},
+		{
+			name:           "disabled channel on final attempt is still a skip",
+			attempts:       defaultTaskMaxAttempts,
+			sendErr:        notification.ErrChannelDisabled,
+			expectSend:     true,
+			expectedStatus: models.NotificationStatusSkipped,
+		},
+		{
+			name:        "quiet hours defer the notification",
+			attempts:    1,
+			sendErr:     &notification.QuietHoursError{Until: quietUntil},
+			expectSend:  true,
+			expectDefer: true,
+		},
+		{
+			name:        "a retry during quiet hours is deferred rather than failed",
+			attempts:    defaultTaskMaxAttempts,
+			sendErr:     &notification.QuietHoursError{Until: quietUntil},
+			expectSend:  true,
+			expectDefer: true,
+		},
+		{
+			name:        "defer failure is retried",
+			attempts:    1,
+			sendErr:     &notification.QuietHoursError{Until: quietUntil},
+			expectSend:  true,
+			expectDefer: true,
+			deferErr:    assert.AnError,
+			wantErr:     true,
+		},
+		{
+			name:       "send failure before final attempt leaves notification pending",
+			attempts:   1,
+			sendErr:    assert.AnError,
+			expectSend: true,
+			wantErr:    true,
+		},
+		{
+			name:           "send failure on final attempt marks failed",
+			attempts:       defaultTaskMaxAttempts,
+			sendErr:        assert.AnError,
+			expectSend:     true,
+			expectedStatus: models.NotificationStatusFailed,
+			wantErr:        true,
+		},
+		{
+			name:           "status update failure after send is not retried",
+			attempts:       1,
+			updateErr:      assert.AnError,
+			expectSend:     true,
+			expectedStatus: models.NotificationStatusSent,
+		},
+		{
+			name:     "deleted notification is not sent",
+			attempts: 1,
+			loadErr:  &errs.HTTPError{Code: 404, Message: "Notification not found"},
+		},
+		{
+			name:     "load failure is retried",
+			attempts: 1,
+			loadErr:  assert.AnError,
+			wantErr:  true,
+		},
+		{
+			name:     "notification already sent is not sent again",
+			attempts: 1,
+			current: func() *models.Notification {
+				sent := scheduled
+				sent.Status = models.NotificationStatusSent
+				return &sent
+			},
+		},
+		{
+			name:     "rescheduled notification waits for its new time",
+			attempts: 1,
+			current: func() *models.Notification {
+				moved := scheduled
+				moved.ScheduledFor = scheduledFor.Add(8 * time.Hour)
+				return &moved
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNotifRepo := new(repomocks.MockNotificationRepository)
+			mockNotifService := new(notificationmocks.MockNotificationService)
+			scheduler := &JobScheduler{
+				repo:         &storage.Repository{Notification: mockNotifRepo},
+				notifService: mockNotifService,
+			}
+
+			current := &scheduled
+			if tt.current != nil {
+				current = tt.current()
+			}
+			if tt.loadErr != nil {
+				mockNotifRepo.On("GetNotificationByID", mock.Anything, scheduled.ID).Return(nil, tt.loadErr)
+			} else {
+				mockNotifRepo.On("GetNotificationByID", mock.Anything, scheduled.ID).Return(current, nil)
+			}
+			if tt.expectSend {
+				mockNotifService.On("SendNotification", mock.Anything, mock.MatchedBy(func(input *models.SendNotificationInput) bool {
+					return input.Body == scheduled.Body &&
+						*input.GuardianID == guardianID &&
+						input.Topic == models.NotificationTopicEventReminders &&
+						*input.NotificationID == scheduled.ID
+				})).Return(tt.sendErr)
+			}
+			if tt.expectDefer {
+				mockNotifRepo.On("DeferNotification", mock.Anything, scheduled.ID, quietUntil).Return(tt.deferErr)
+			}
+			if tt.expectedStatus != "" {
+				if tt.updateErr != nil {
+					mockNotifRepo.On("UpdateNotificationStatus", mock.Anything, scheduled.ID, tt.expectedStatus).Return(nil, tt.updateErr)
+				} else {
+					mockNotifRepo.On("UpdateNotificationStatus", mock.Anything, scheduled.ID, tt.expectedStatus).Return(&models.Notification{}, nil)
+				}
+			}
+
+			err := scheduler.sendNotificationTask(context.Background(), newTestTask(t, sendNotificationTaskType, sendNotificationPayload{NotificationID: scheduled.ID, ScheduledFor: scheduledFor}, tt.attempts))
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mockNotifService.AssertExpectations(t)
+			mockNotifRepo.AssertExpectations(t)
+			if !tt.expectSend {
+				mockNotifService.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything)
+			}
+			if tt.expectedStatus == "" {
+				mockNotifRepo.AssertNotCalled(t, "UpdateNotificationStatus", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
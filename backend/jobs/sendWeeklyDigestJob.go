@@ -0,0 +1,52 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"skillspark/internal/notification"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// digestGuardianBatchSize is how many opted-in guardians are loaded at a time
+const digestGuardianBatchSize = 100
+
+// SendWeeklyDigestJob sends this week's digest to every guardian who opted in. Guardians
+// already sent this week's digest are skipped, so a rerun only reaches those a failed run
+// missed. Guardians with no good matches for any child are skipped without a digest.
+func (j *JobScheduler) SendWeeklyDigestJob(ctx context.Context, run *RunTracker) {
+	weekStart := notification.DigestWeekStart(time.Now())
+
+	after := uuid.Nil
+	for {
+		guardians, err := j.repo.Digest.GetDigestGuardians(ctx, after, digestGuardianBatchSize)
+		if err != nil {
+			run.Abortf("failed to get digest guardians: %v", err)
+			return
+		}
+
+		for _, guardian := range guardians {
+			if run.DryRun() {
+				run.Succeed()
+				continue
+			}
+
+			sent, err := j.notifService.SendWeeklyDigest(ctx, &guardian, weekStart)
+			if err != nil {
+				run.Failf(guardian.ID, "failed to send weekly digest: %v", err)
+				continue
+			}
+			if sent {
+				run.Succeed()
+			}
+		}
+
+		if len(guardians) < digestGuardianBatchSize {
+			break
+		}
+		after = guardians[len(guardians)-1].ID
+	}
+
+	slog.Info("Weekly digest run finished", "week_start", weekStart.Format(time.DateOnly))
+}
@@ -0,0 +1,79 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"skillspark/internal/models"
+	notificationmocks "skillspark/internal/notification/mocks"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSendWeeklyDigestJob(t *testing.T) {
+	sent := models.Guardian{ID: uuid.New()}
+	noMatches := models.Guardian{ID: uuid.New()}
+	failing := models.Guardian{ID: uuid.New()}
+
+	mockDigestRepo := new(repomocks.MockDigestRepository)
+	mockNotifService := new(notificationmocks.MockNotificationService)
+	scheduler := &JobScheduler{
+		repo:         &storage.Repository{Digest: mockDigestRepo},
+		notifService: mockNotifService,
+	}
+
+	mockDigestRepo.On("GetDigestGuardians", mock.Anything, uuid.Nil, digestGuardianBatchSize).
+		Return([]models.Guardian{sent, noMatches, failing}, nil).Once()
+	mockNotifService.On("SendWeeklyDigest", mock.Anything, mock.MatchedBy(func(g *models.Guardian) bool { return g.ID == sent.ID }), mock.AnythingOfType("time.Time")).Return(true, nil)
+	mockNotifService.On("SendWeeklyDigest", mock.Anything, mock.MatchedBy(func(g *models.Guardian) bool { return g.ID == noMatches.ID }), mock.Anything).Return(false, nil)
+	mockNotifService.On("SendWeeklyDigest", mock.Anything, mock.MatchedBy(func(g *models.Guardian) bool { return g.ID == failing.ID }), mock.Anything).Return(false, errors.New("render failed"))
+
+	run := NewRunTracker(sendWeeklyDigestJobName, false)
+	scheduler.SendWeeklyDigestJob(context.Background(), run)
+
+	mockDigestRepo.AssertExpectations(t)
+	mockNotifService.AssertExpectations(t)
+	assert.Equal(t, 1, run.succeeded)
+	assert.Equal(t, models.JobRunStatusPartiallyFailed, run.status())
+}
+
+func TestSendWeeklyDigestJob_PagesThroughGuardians(t *testing.T) {
+	firstPage := make([]models.Guardian, digestGuardianBatchSize)
+	for i := range firstPage {
+		firstPage[i] = models.Guardian{ID: uuid.New()}
+	}
+	last := firstPage[len(firstPage)-1].ID
+
+	mockDigestRepo := new(repomocks.MockDigestRepository)
+	mockNotifService := new(notificationmocks.MockNotificationService)
+	scheduler := &JobScheduler{
+		repo:         &storage.Repository{Digest: mockDigestRepo},
+		notifService: mockNotifService,
+	}
+
+	mockDigestRepo.On("GetDigestGuardians", mock.Anything, uuid.Nil, digestGuardianBatchSize).Return(firstPage, nil).Once()
+	mockDigestRepo.On("GetDigestGuardians", mock.Anything, last, digestGuardianBatchSize).Return([]models.Guardian{{ID: uuid.New()}}, nil).Once()
+
+	run := NewRunTracker(sendWeeklyDigestJobName, true)
+	scheduler.SendWeeklyDigestJob(context.Background(), run)
+
+	mockDigestRepo.AssertExpectations(t)
+	mockNotifService.AssertNotCalled(t, "SendWeeklyDigest", mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, digestGuardianBatchSize+1, run.succeeded)
+}
+
+func TestSendWeeklyDigestJob_GuardianQueryError(t *testing.T) {
+	mockDigestRepo := new(repomocks.MockDigestRepository)
+	scheduler := &JobScheduler{repo: &storage.Repository{Digest: mockDigestRepo}}
+
+	mockDigestRepo.On("GetDigestGuardians", mock.Anything, uuid.Nil, digestGuardianBatchSize).Return(nil, errors.New("db down"))
+
+	run := NewRunTracker(sendWeeklyDigestJobName, false)
+	scheduler.SendWeeklyDigestJob(context.Background(), run)
+
+	assert.Equal(t, models.JobRunStatusFailed, run.status())
+}
@@ -0,0 +1,146 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"skillspark/internal/models"
+	"time"
+)
+
+// Task types processed from the durable task queue. The cron jobs only find work and
+// enqueue one task per item; ProcessTasks does the work, retrying failures with backoff.
+const (
+	capturePaymentTaskType      = "capture_payment"
+	createPaymentIntentTaskType = "create_payment_intent"
+	sendNotificationTaskType    = "send_notification"
+)
+
+const (
+	// with backoff doubling from one minute, eight attempts span roughly two hours
+	defaultTaskMaxAttempts = 8
+	taskBaseBackoff        = time.Minute
+	taskMaxBackoff         = time.Hour
+	// a claimed task that has not finished within this time is assumed lost and retried
+	taskVisibilityTimeout = 5 * time.Minute
+	taskBatchSize         = 20
+	// bounds one ProcessTasks call so shutdown is not held up by a long backlog
+	taskMaxBatchesPerRun = 10
+)
+
+type taskHandler func(ctx context.Context, task models.Task) error
+
+// permanentError marks a failure that retrying cannot fix, such as a malformed payload
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+func permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+func (j *JobScheduler) taskHandlers() map[string]taskHandler {
+	return map[string]taskHandler{
+		capturePaymentTaskType:      j.capturePaymentTask,
+		createPaymentIntentTaskType: j.createPaymentIntentTask,
+		sendNotificationTaskType:    j.sendNotificationTask,
+	}
+}
+
+// enqueueTask queues one item of work. It reports false when a task with the same dedup
+// key was queued before, which is the normal case for items the hourly jobs see again.
+func (j *JobScheduler) enqueueTask(ctx context.Context, taskType string, dedupKey string, payload any) (bool, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode %s payload: %w", taskType, err)
+	}
+
+	task, err := j.repo.Task.EnqueueTask(ctx, &models.EnqueueTaskData{
+		TaskType:    taskType,
+		Payload:     encoded,
+		DedupKey:    &dedupKey,
+		MaxAttempts: defaultTaskMaxAttempts,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return task != nil, nil
+}
+
+// ProcessTasks claims due tasks and runs them until the queue is drained or the batch
+// limit is hit. Workers process in parallel; claims keep them off each other's tasks.
+func (j *JobScheduler) ProcessTasks(ctx context.Context) {
+	for range taskMaxBatchesPerRun {
+		tasks, err := j.repo.Task.ClaimTasks(ctx, taskBatchSize, taskVisibilityTimeout)
+		if err != nil {
+			log.Printf("ProcessTasks: failed to claim tasks: %v", err)
+			return
+		}
+		if len(tasks) == 0 {
+			return
+		}
+
+		for _, task := range tasks {
+			j.runTask(ctx, task)
+		}
+	}
+}
+
+func (j *JobScheduler) runTask(ctx context.Context, task models.Task) {
+	err := j.callTaskHandler(ctx, task)
+
+	switch {
+	case err == nil:
+		err = j.repo.Task.CompleteTask(ctx, task.ID, task.Attempts)
+	case task.IsFinalAttempt() || errors.As(err, new(*permanentError)):
+		log.Printf("ProcessTasks: %s task %s dead-lettered after %d attempts: %v", task.TaskType, task.ID, task.Attempts, err)
+		err = j.repo.Task.DeadLetterTask(ctx, task.ID, task.Attempts, err.Error())
+	default:
+		log.Printf("ProcessTasks: %s task %s failed attempt %d, retrying: %v", task.TaskType, task.ID, task.Attempts, err)
+		err = j.repo.Task.RetryTaskLater(ctx, task.ID, task.Attempts, time.Now().Add(taskBackoff(task.Attempts)), err.Error())
+	}
+	if err != nil {
+		// most likely the claim expired and another worker has the task now
+		log.Printf("ProcessTasks: failed to record outcome of %s task %s: %v", task.TaskType, task.ID, err)
+	}
+}
+
+func (j *JobScheduler) callTaskHandler(ctx context.Context, task models.Task) (err error) {
+	handler, ok := j.taskHandlers()[task.TaskType]
+	if !ok {
+		return permanent(fmt.Errorf("unknown task type %q", task.TaskType))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, task)
+}
+
+// taskBackoff is the delay before retrying after the given attempt: one minute, doubling
+// each attempt, capped at an hour.
+func taskBackoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	if attempt > 7 {
+		return taskMaxBackoff
+	}
+	return min(taskBaseBackoff<<(attempt-1), taskMaxBackoff)
+}
+
+func decodeTaskPayload(task models.Task, payload any) error {
+	if err := json.Unmarshal(task.Payload, payload); err != nil {
+		return permanent(fmt.Errorf("invalid %s payload: %w", task.TaskType, err))
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"skillspark/internal/models"
+	"skillspark/internal/notification"
+	notificationmocks "skillspark/internal/notification/mocks"
+	"skillspark/internal/storage"
+	repomocks "skillspark/internal/storage/repo-mocks"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTask(t *testing.T, taskType string, payload any, attempts int) models.Task {
+	t.Helper()
+
+	encoded, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	return models.Task{
+		ID:          uuid.New(),
+		TaskType:    taskType,
+		Payload:     encoded,
+		Status:      models.TaskStatusRunning,
+		Attempts:    attempts,
+		MaxAttempts: defaultTaskMaxAttempts,
+	}
+}
+
+func TestEnqueueTask(t *testing.T) {
+	mockTaskRepo := new(repomocks.MockTaskRepository)
+	scheduler := &JobScheduler{repo: &storage.Repository{Task: mockTaskRepo}}
+
+	mockTaskRepo.On("EnqueueTask", mock.Anything, mock.MatchedBy(func(input *models.EnqueueTaskData) bool {
+		return *input.DedupKey == "new" && string(input.Payload) == `{"registration_id":"00000000-0000-0000-0000-000000000000","payment_intent_id":"pi_1"}`
+	})).Return(&models.Task{ID: uuid.New()}, nil)
+	mockTaskRepo.On("EnqueueTask", mock.Anything, mock.MatchedBy(func(input *models.EnqueueTaskData) bool {
+		return *input.DedupKey == "seen"
+	})).Return(nil, nil)
+
+	queued, err := scheduler.enqueueTask(context.Background(), capturePaymentTaskType, "new", capturePaymentPayload{PaymentIntentID: "pi_1"})
+	require.NoError(t, err)
+	assert.True(t, queued)
+
+	queued, err = scheduler.enqueueTask(context.Background(), capturePaymentTaskType, "seen", capturePaymentPayload{PaymentIntentID: "pi_1"})
+	require.NoError(t, err)
+	assert.False(t, queued)
+}
+
+func TestProcessTasks_CompletesSuccessfulTask(t *testing.T) {
+	mockTaskRepo := new(repomocks.MockTaskRepository)
+	mockNotifRepo := new(repomocks.MockNotificationRepository)
+	mockNotifService := new(notificationmocks.MockNotificationService)
+	scheduler := &JobScheduler{
+		repo:         &storage.Repository{Task: mockTaskRepo, Notification: mockNotifRepo},
+		notifService: mockNotifService,
+	}
+
+	guardianID := uuid.New()
+	scheduled := models.Notification{ID: uuid.New(), NotificationType: models.NotificationTypeEmail, GuardianID: &guardianID, Status: models.NotificationStatusPending}
+	task := newTestTask(t, sendNotificationTaskType, sendNotificationPayload{NotificationID: scheduled.ID}, 1)
+
+	mockTaskRepo.On("ClaimTasks", mock.Anything, taskBatchSize, taskVisibilityTimeout).Return([]models.Task{task}, nil).Once()
+	mockTaskRepo.On("ClaimTasks", mock.Anything, taskBatchSize, taskVisibilityTimeout).Return([]models.Task{}, nil).Once()
+	mockNotifRepo.On("GetNotificationByID", mock.Anything, scheduled.ID).Return(&scheduled, nil)
+	mockNotifService.On("SendNotification", mock.Anything, mock.Anything).Return(notification.ErrChannelDisabled)
+	mockNotifRepo.On("UpdateNotificationStatus", mock.Anything, scheduled.ID, models.NotificationStatusSkipped).
+		Return(&models.Notification{}, nil)
+	mockTaskRepo.On("CompleteTask", mock.Anything, task.ID, 1).Return(nil)
+
+	scheduler.ProcessTasks(context.Background())
+
+	mockTaskRepo.AssertExpectations(t)
+	mockNotifRepo.AssertExpectations(t)
+}
+
+func TestProcessTasks_RetriesFailedTaskWithBackoff(t *testing.T) {
+	mockTaskRepo := new(repomocks.MockTaskRepository)
+	mockGuardianRepo := new(repomocks.MockGuardianRepository)
+	scheduler := &JobScheduler{
+		repo: &storage.Repository{Task: mockTaskRepo, Guardian: mockGuardianRepo},
+	}
+
+	task := newTestTask(t, createPaymentIntentTaskType, createPaymentIntentPayload{GuardianID: uuid.New()}, 3)
+
+	mockTaskRepo.On("ClaimTasks", mock.Anything, taskBatchSize, taskVisibilityTimeout).Return([]models.Task{task}, nil).Once()
+	mockTaskRepo.On("ClaimTasks", mock.Anything, taskBatchSize, taskVisibilityTimeout).Return([]models.Task{}, nil).Once()
+	mockGuardianRepo.On("GetGuardianByID", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	before := time.Now()
+	mockTaskRepo.On("RetryTaskLater", mock.Anything, task.ID, 3, mock.MatchedBy(func(runAfter time.Time) bool {
+		delay := runAfter.Sub(before)
+		return delay >= 4*time.Minute && delay < 5*time.Minute
+	}), mock.AnythingOfType("string")).Return(nil)
+
+	scheduler.ProcessTasks(context.Background())
+
+	mockTaskRepo.AssertExpectations(t)
+	mockTaskRepo.AssertNotCalled(t, "DeadLetterTask", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProcessTasks_DeadLettersOnFinalAttempt(t *testing.T) {
+	mockTaskRepo := new(repomocks.MockTaskRepository)
+	mockGuardianRepo := new(repomocks.MockGuardianRepository)
+	scheduler := &JobScheduler{
+		repo: &storage.Repository{Task: mockTaskRepo, Guardian: mockGuardianRepo},
+	}
+
+	task := newTestTask(t, createPaymentIntentTaskType, createPaymentIntentPayload{GuardianID: uuid.New()}, defaultTaskMaxAttempts)
+
+	mockTaskRepo.On("ClaimTasks", mock.Anything, taskBatchSize, taskVisibilityTimeout).Return([]models.Task{task}, nil).Once()
+	mockTaskRepo.On("ClaimTasks", mock.Anything, taskBatchSize, taskVisibilityTimeout).Return([]models.Task{}, nil).Once()
+	mockGuardianRepo.On("GetGuardianByID", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+	mockTaskRepo.On("DeadLetterTask", mock.Anything, task.ID, defaultTaskMaxAttempts, mock.AnythingOfType("string")).Return(nil)
+
+	scheduler.ProcessTasks(context.Background())
+
+	mockTaskRepo.AssertExpectations(t)
+	mockTaskRepo.AssertNotCalled(t, "RetryTaskLater", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProcessTasks_DeadLettersPermanentFailures(t *testing.T) {
+	mockTaskRepo := new(repomocks.MockTaskRepository)
+	scheduler := &JobScheduler{repo: &storage.Repository{Task: mockTaskRepo}}
+
+	malformed := newTestTask(t, capturePaymentTaskType, nil, 1)
+	malformed.Payload = json.RawMessage(`"not an object"`)
+	unknown := newTestTask(t, "unknown_type", struct{}{}, 1)
+
+	mockTaskRepo.On("ClaimTasks", mock.Anything, taskBatchSize, taskVisibilityTimeout).Return([]models.Task{malformed, unknown}, nil).Once()
+	mockTaskRepo.On("ClaimTasks", mock.Anything, taskBatchSize, taskVisibilityTimeout).Return([]models.Task{}, nil).Once()
+	mockTaskRepo.On("DeadLetterTask", mock.Anything, malformed.ID, 1, mock.AnythingOfType("string")).Return(nil)
+	mockTaskRepo.On("DeadLetterTask", mock.Anything, unknown.ID, 1, `unknown task type "unknown_type"`).Return(nil)
+
+	scheduler.ProcessTasks(context.Background())
+
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestProcessTasks_RecoversFromPanic(t *testing.T) {
+	mockTaskRepo := new(repomocks.MockTaskRepository)
+	// no guardian repository, so the handler panics on a nil interface
+	scheduler := &JobScheduler{repo: &storage.Repository{Task: mockTaskRepo}}
+
+	task := newTestTask(t, createPaymentIntentTaskType, createPaymentIntentPayload{GuardianID: uuid.New()}, 1)
+
+	mockTaskRepo.On("ClaimTasks", mock.Anything, taskBatchSize, taskVisibilityTimeout).Return([]models.Task{task}, nil).Once()
+	mockTaskRepo.On("ClaimTasks", mock.Anything, taskBatchSize, taskVisibilityTimeout).Return([]models.Task{}, nil).Once()
+	mockTaskRepo.On("RetryTaskLater", mock.Anything, task.ID, 1, mock.AnythingOfType("time.Time"), mock.AnythingOfType("string")).Return(nil)
+
+	scheduler.ProcessTasks(context.Background())
+
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestProcessTasks_StopsOnClaimError(t *testing.T) {
+	mockTaskRepo := new(repomocks.MockTaskRepository)
+	scheduler := &JobScheduler{repo: &storage.Repository{Task: mockTaskRepo}}
+
+	mockTaskRepo.On("ClaimTasks", mock.Anything, taskBatchSize, taskVisibilityTimeout).Return(nil, assert.AnError).Once()
+
+	scheduler.ProcessTasks(context.Background())
+
+	mockTaskRepo.AssertNumberOfCalls(t, "ClaimTasks", 1)
+}
+
+func TestTaskBackoff(t *testing.T) {
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{0, time.Minute},
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{3, 4 * time.Minute},
+		{6, 32 * time.Minute},
+		{7, time.Hour},
+		{50, time.Hour},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, taskBackoff(tt.attempt), "attempt %d", tt.attempt)
+	}
+}
@@ -1,4 +1,9 @@
-import { getAllEvents, searchEvents, type Event } from "@skillspark/api-client";
+import {
+  getAllEvents,
+  recordSearchClick,
+  searchEvents,
+  type Event,
+} from "@skillspark/api-client";
 import { useLocalSearchParams, useRouter } from "expo-router";
 import { useMemo, useState } from "react";
 import {
@@ -33,7 +38,7 @@ export default function SearchScreen() {
 
   const hasQuery = debouncedSearch.trim().length > 0;
 
-  // Fuzzy OpenSearch — only when there is a search query
+  // Fuzzy OpenSearch — only when there is a search query. Filters are applied server side.
   const {
     data: searchData,
     isLoading: searchLoading,
@@ -41,16 +46,32 @@ export default function SearchScreen() {
     hasNextPage: hasNextSearch,
     isFetchingNextPage: isFetchingNextSearch,
   } = useInfiniteQuery({
-    queryKey: ["search", "events", debouncedSearch],
+    queryKey: [
+      "search",
+      "events",
+      debouncedSearch,
+      filters.category,
+      filters.min_age,
+      filters.max_age,
+    ],
     queryFn: ({ pageParam, signal }) =>
       searchEvents(
-        { q: debouncedSearch, page: pageParam, limit: PAGE_SIZE },
+        {
+          q: debouncedSearch,
+          category: filters.category,
+          min_age: filters.min_age,
+          max_age: filters.max_age,
+          page: pageParam,
+          limit: PAGE_SIZE,
+        },
         { signal },
       ),
     initialPageParam: 1,
     getNextPageParam: (lastPage, allPages) => {
-      const items = lastPage.data;
-      if (Array.isArray(items) && items.length === PAGE_SIZE) {
+      if (
+        lastPage.status === 200 &&
+        lastPage.data.results.length === PAGE_SIZE
+      ) {
         return allPages.length + 1;
       }
       return undefined;
@@ -104,31 +125,11 @@ export default function SearchScreen() {
 
   const results: Event[] = useMemo(() => {
     if (hasQuery) {
-      const raw =
+      return (
         searchData?.pages.flatMap((page) =>
-          Array.isArray(page.data) ? (page.data as Event[]) : [],
-        ) ?? [];
-
-      // Client-side apply active filters on top of fuzzy results
-      return raw.filter((event) => {
-        if (filters.category) {
-          const dbCats = filters.category.split(",");
-          if (!event.category?.some((c) => dbCats.includes(c))) return false;
-        }
-        if (
-          filters.min_age != null &&
-          event.age_range_max != null &&
-          event.age_range_max < filters.min_age
-        )
-          return false;
-        if (
-          filters.max_age != null &&
-          event.age_range_min != null &&
-          event.age_range_min > filters.max_age
-        )
-          return false;
-        return true;
-      });
+          page.status === 200 ? page.data.results : [],
+        ) ?? []
+      );
     }
 
     return (
@@ -136,7 +137,29 @@ export default function SearchScreen() {
         Array.isArray(page.data) ? (page.data as Event[]) : [],
       ) ?? []
     );
-  }, [hasQuery, searchData, allEventsData, filters]);
+  }, [hasQuery, searchData, allEventsData]);
+
+  // a spelling correction, offered when the query matched nothing
+  const firstSearchPage = searchData?.pages[0];
+  const didYouMean =
+    hasQuery && firstSearchPage?.status === 200
+      ? firstSearchPage.data.did_you_mean
+      : undefined;
+
+  // the id the first page of the search was logged under, for search analytics
+  const searchId =
+    hasQuery && firstSearchPage?.status === 200
+      ? firstSearchPage.data.search_id
+      : undefined;
+
+  const reportClick = (event: Event, position: number) => {
+    if (!searchId) return;
+    recordSearchClick(searchId, {
+      result_id: event.id,
+      result_type: "event",
+      position,
+    }).catch(() => {});
+  };
 
   return (
     <View className="flex-1 bg-white" style={{ paddingTop: insets.top }}>
@@ -179,9 +202,17 @@ export default function SearchScreen() {
           <Text className="font-nunito-bold text-[15px] text-[#111]">
             {translate("search.noResults")}
           </Text>
-          <Text className="font-nunito text-sm text-[#6B7280]">
-            {translate("search.tryDifferent")}
-          </Text>
+          {didYouMean ? (
+            <Pressable onPress={() => setSearchText(didYouMean)} hitSlop={8}>
+              <Text className="font-nunito-bold text-sm text-[#2563EB]">
+                {translate("search.didYouMean", { query: didYouMean })}
+              </Text>
+            </Pressable>
+          ) : (
+            <Text className="font-nunito text-sm text-[#6B7280]">
+              {translate("search.tryDifferent")}
+            </Text>
+          )}
         </View>
       ) : (
         <FlatList
@@ -193,7 +224,12 @@ export default function SearchScreen() {
             paddingBottom: FLOATING_TAB_BAR_SCROLL_PADDING,
             gap: 12,
           }}
-          renderItem={({ item }) => <SearchResultCard event={item} />}
+          renderItem={({ item, index }) => (
+            <SearchResultCard
+              event={item}
+              onPress={() => reportClick(item, index)}
+            />
+          )}
           keyboardShouldPersistTaps="handled"
           keyboardDismissMode="on-drag"
           onEndReached={() => {
@@ -4,7 +4,13 @@ import { useRouter } from "expo-router";
 import { type Event } from "@skillspark/api-client";
 import { AppColors, FontFamilies, FontSizes } from "@/constants/theme";
 
-export function SearchResultCard({ event }: { event: Event }) {
+export function SearchResultCard({
+  event,
+  onPress,
+}: {
+  event: Event;
+  onPress?: () => void;
+}) {
   const router = useRouter();
   const badge = event.category?.[0];
   const ageLabel =
@@ -16,7 +22,10 @@ export function SearchResultCard({ event }: { event: Event }) {
 
   return (
     <Pressable
-      onPress={() => router.push(`/event/${event.id}`)}
+      onPress={() => {
+        onPress?.();
+        router.push(`/event/${event.id}`);
+      }}
       style={{
         height: 118,
         borderRadius: 12,
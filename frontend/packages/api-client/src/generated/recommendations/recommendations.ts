@@ -20,8 +20,8 @@ import type {
 
 import type {
   ErrorModel,
-  Event,
   GetRecommendationsByChildIdParams,
+  Recommendation,
 } from "../skillSparkAPI.schemas";
 
 import { customInstance } from "../../apiClient";
@@ -71,11 +71,11 @@ export type HTTPStatusCodes =
   | HTTPStatusCode5xx;
 
 /**
- * Returns a list of recommended events for a child
+ * Returns recommended events for a child, best first, spread across organizations, categories and days. Each comes with its score components and the reasons it was recommended. Events the child is registered for are left out, and only the best 200 are ranked.
  * @summary Get recommendations by child ID
  */
 export type getRecommendationsByChildIdResponse200 = {
-  data: Event[];
+  data: Recommendation[];
   status: 200;
 };
 
@@ -5,27 +5,66 @@
  * API for the SkillSpark application
  * OpenAPI spec version: 1.0.0
  */
-import { useQuery } from "@tanstack/react-query";
+import { useMutation, useQuery } from "@tanstack/react-query";
 import type {
   DataTag,
   DefinedInitialDataOptions,
   DefinedUseQueryResult,
+  MutationFunction,
   QueryClient,
   QueryFunction,
   QueryKey,
   UndefinedInitialDataOptions,
+  UseMutationOptions,
+  UseMutationResult,
   UseQueryOptions,
   UseQueryResult,
 } from "@tanstack/react-query";
 
 import type {
   ErrorModel,
-  Event,
+  GetSearchAnalyticsParams,
+  RecordSearchClickInputBody,
+  SearchAnalytics,
   SearchEventsParams,
+  SearchEventsResult,
+  SearchLog,
+  SearchParams,
+  SearchSuggestions,
+  SuggestSearchParams,
+  UnifiedSearchResult,
 } from "../skillSparkAPI.schemas";
 
 import { customInstance } from "../../apiClient";
 
+// https://stackoverflow.com/questions/49579094/typescript-conditional-types-filter-out-readonly-properties-pick-only-requir/49579497#49579497
+type IfEquals<X, Y, A = X, B = never> =
+  (<T>() => T extends X ? 1 : 2) extends <T>() => T extends Y ? 1 : 2 ? A : B;
+
+type WritableKeys<T> = {
+  [P in keyof T]-?: IfEquals<
+    { [Q in P]: T[P] },
+    { -readonly [Q in P]: T[P] },
+    P
+  >;
+}[keyof T];
+
+type UnionToIntersection<U> = (U extends any ? (k: U) => void : never) extends (
+  k: infer I,
+) => void
+  ? I
+  : never;
+type DistributeReadOnlyOverUnions<T> = T extends any ? NonReadonly<T> : never;
+
+type Writable<T> = Pick<T, WritableKeys<T>>;
+type NonReadonly<T> = [T] extends [UnionToIntersection<T>]
+  ? {
+      [P in keyof Writable<T>]: T[P] extends object
+        ? NonReadonly<NonNullable<T[P]>>
+        : T[P];
+    }
+  : DistributeReadOnlyOverUnions<T>;
+
 type SecondParameter<T extends (...args: never) => unknown> = Parameters<T>[1];
 
 export type HTTPStatusCode1xx = 100 | 101 | 102 | 103;
@@ -71,11 +110,396 @@ export type HTTPStatusCodes =
   | HTTPStatusCode5xx;
 
 /**
- * Returns events matching the search query using fuzzy full-text search
- * @summary Fuzzy search events
+ * Reports the most common search queries, the queries that found nothing and the click-through rate of each, over the last 30 days unless since and until are given
+ * @summary Get search analytics
+ */
+export type getSearchAnalyticsResponse200 = {
+  data: SearchAnalytics;
+  status: 200;
+};
+
+export type getSearchAnalyticsResponseDefault = {
+  data: ErrorModel;
+  status: Exclude<HTTPStatusCodes, 200>;
+};
+
+export type getSearchAnalyticsResponseSuccess =
+  getSearchAnalyticsResponse200 & {
+    headers: Headers;
+  };
+export type getSearchAnalyticsResponseError =
+  getSearchAnalyticsResponseDefault & {
+    headers: Headers;
+  };
+
+export type getSearchAnalyticsResponse =
+  | getSearchAnalyticsResponseSuccess
+  | getSearchAnalyticsResponseError;
+
+export const getGetSearchAnalyticsUrl = (params?: GetSearchAnalyticsParams) => {
+  const normalizedParams = new URLSearchParams();
+
+  Object.entries(params || {}).forEach(([key, value]) => {
+    if (value !== undefined) {
+      normalizedParams.append(key, value === null ? "null" : value.toString());
+    }
+  });
+
+  const stringifiedParams = normalizedParams.toString();
+
+  return stringifiedParams.length > 0
+    ? `/api/v1/admin/search/analytics?${stringifiedParams}`
+    : `/api/v1/admin/search/analytics`;
+};
+
+export const getSearchAnalytics = async (
+  params?: GetSearchAnalyticsParams,
+  options?: RequestInit,
+): Promise<getSearchAnalyticsResponse> => {
+  return customInstance<getSearchAnalyticsResponse>(
+    getGetSearchAnalyticsUrl(params),
+    {
+      ...options,
+      method: "GET",
+    },
+  );
+};
+
+export const getGetSearchAnalyticsQueryKey = (
+  params?: GetSearchAnalyticsParams,
+) => {
+  return [
+    `/api/v1/admin/search/analytics`,
+    ...(params ? [params] : []),
+  ] as const;
+};
+
+export const getGetSearchAnalyticsQueryOptions = <
+  TData = Awaited<ReturnType<typeof getSearchAnalytics>>,
+  TError = ErrorModel,
+>(
+  params?: GetSearchAnalyticsParams,
+  options?: {
+    query?: Partial<
+      UseQueryOptions<
+        Awaited<ReturnType<typeof getSearchAnalytics>>,
+        TError,
+        TData
+      >
+    >;
+    request?: SecondParameter<typeof customInstance>;
+  },
+) => {
+  const { query: queryOptions, request: requestOptions } = options ?? {};
+
+  const queryKey =
+    queryOptions?.queryKey ?? getGetSearchAnalyticsQueryKey(params);
+
+  const queryFn: QueryFunction<
+    Awaited<ReturnType<typeof getSearchAnalytics>>
+  > = ({ signal }) => getSearchAnalytics(params, { signal, ...requestOptions });
+
+  return { queryKey, queryFn, ...queryOptions } as UseQueryOptions<
+    Awaited<ReturnType<typeof getSearchAnalytics>>,
+    TError,
+    TData
+  > & { queryKey: DataTag<QueryKey, TData, TError> };
+};
+
+export type GetSearchAnalyticsQueryResult = NonNullable<
+  Awaited<ReturnType<typeof getSearchAnalytics>>
+>;
+export type GetSearchAnalyticsQueryError = ErrorModel;
+
+export function useGetSearchAnalytics<
+  TData = Awaited<ReturnType<typeof getSearchAnalytics>>,
+  TError = ErrorModel,
+>(
+  params: undefined | GetSearchAnalyticsParams,
+  options: {
+    query: Partial<
+      UseQueryOptions<
+        Awaited<ReturnType<typeof getSearchAnalytics>>,
+        TError,
+        TData
+      >
+    > &
+      Pick<
+        DefinedInitialDataOptions<
+          Awaited<ReturnType<typeof getSearchAnalytics>>,
+          TError,
+          Awaited<ReturnType<typeof getSearchAnalytics>>
+        >,
+        "initialData"
+      >;
+    request?: SecondParameter<typeof customInstance>;
+  },
+  queryClient?: QueryClient,
+): DefinedUseQueryResult<TData, TError> & {
+  queryKey: DataTag<QueryKey, TData, TError>;
+};
+export function useGetSearchAnalytics<
+  TData = Awaited<ReturnType<typeof getSearchAnalytics>>,
+  TError = ErrorModel,
+>(
+  params?: GetSearchAnalyticsParams,
+  options?: {
+    query?: Partial<
+      UseQueryOptions<
+        Awaited<ReturnType<typeof getSearchAnalytics>>,
+        TError,
+        TData
+      >
+    > &
+      Pick<
+        UndefinedInitialDataOptions<
+          Awaited<ReturnType<typeof getSearchAnalytics>>,
+          TError,
+          Awaited<ReturnType<typeof getSearchAnalytics>>
+        >,
+        "initialData"
+      >;
+    request?: SecondParameter<typeof customInstance>;
+  },
+  queryClient?: QueryClient,
+): UseQueryResult<TData, TError> & {
+  queryKey: DataTag<QueryKey, TData, TError>;
+};
+export function useGetSearchAnalytics<
+  TData = Awaited<ReturnType<typeof getSearchAnalytics>>,
+  TError = ErrorModel,
+>(
+  params?: GetSearchAnalyticsParams,
+  options?: {
+    query?: Partial<
+      UseQueryOptions<
+        Awaited<ReturnType<typeof getSearchAnalytics>>,
+        TError,
+        TData
+      >
+    >;
+    request?: SecondParameter<typeof customInstance>;
+  },
+  queryClient?: QueryClient,
+): UseQueryResult<TData, TError> & {
+  queryKey: DataTag<QueryKey, TData, TError>;
+};
+/**
+ * @summary Get search analytics
+ */
+
+export function useGetSearchAnalytics<
+  TData = Awaited<ReturnType<typeof getSearchAnalytics>>,
+  TError = ErrorModel,
+>(
+  params?: GetSearchAnalyticsParams,
+  options?: {
+    query?: Partial<
+      UseQueryOptions<
+        Awaited<ReturnType<typeof getSearchAnalytics>>,
+        TError,
+        TData
+      >
+    >;
+    request?: SecondParameter<typeof customInstance>;
+  },
+  queryClient?: QueryClient,
+): UseQueryResult<TData, TError> & {
+  queryKey: DataTag<QueryKey, TData, TError>;
+} {
+  const queryOptions = getGetSearchAnalyticsQueryOptions(params, options);
+
+  const query = useQuery(queryOptions, queryClient) as UseQueryResult<
+    TData,
+    TError
+  > & { queryKey: DataTag<QueryKey, TData, TError> };
+
+  return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * Returns upcoming event occurrences with their dates, price and seats left, events, and organizations matching the search query, grouped by type. types limits which are searched and limit applies to each type
+ * @summary Search occurrences, events and organizations
+ */
+export type searchResponse200 = {
+  data: UnifiedSearchResult;
+  status: 200;
+};
+
+export type searchResponseDefault = {
+  data: ErrorModel;
+  status: Exclude<HTTPStatusCodes, 200>;
+};
+
+export type searchResponseSuccess = searchResponse200 & {
+  headers: Headers;
+};
+export type searchResponseError = searchResponseDefault & {
+  headers: Headers;
+};
+
+export type searchResponse =
+  | searchResponseSuccess
+  | searchResponseError;
+
+export const getSearchUrl = (params: SearchParams) => {
+  const normalizedParams = new URLSearchParams();
+
+  Object.entries(params || {}).forEach(([key, value]) => {
+    if (value !== undefined) {
+      normalizedParams.append(key, value === null ? "null" : value.toString());
+    }
+  });
+
+  const stringifiedParams = normalizedParams.toString();
+
+  return stringifiedParams.length > 0
+    ? `/api/v1/search?${stringifiedParams}`
+    : `/api/v1/search`;
+};
+
+export const search = async (
+  params: SearchParams,
+  options?: RequestInit,
+): Promise<searchResponse> => {
+  return customInstance<searchResponse>(getSearchUrl(params), {
+    ...options,
+    method: "GET",
+  });
+};
+
+export const getSearchQueryKey = (params: SearchParams) => {
+  return [`/api/v1/search`, ...(params ? [params] : [])] as const;
+};
+
+export const getSearchQueryOptions = <
+  TData = Awaited<ReturnType<typeof search>>,
+  TError = ErrorModel,
+>(
+  params: SearchParams,
+  options?: {
+    query?: Partial<
+      UseQueryOptions<Awaited<ReturnType<typeof search>>, TError, TData>
+    >;
+    request?: SecondParameter<typeof customInstance>;
+  },
+) => {
+  const { query: queryOptions, request: requestOptions } = options ?? {};
+
+  const queryKey = queryOptions?.queryKey ?? getSearchQueryKey(params);
+
+  const queryFn: QueryFunction<Awaited<ReturnType<typeof search>>> = ({
+    signal,
+  }) => search(params, { signal, ...requestOptions });
+
+  return { queryKey, queryFn, ...queryOptions } as UseQueryOptions<
+    Awaited<ReturnType<typeof search>>,
+    TError,
+    TData
+  > & { queryKey: DataTag<QueryKey, TData, TError> };
+};
+
+export type SearchQueryResult = NonNullable<
+  Awaited<ReturnType<typeof search>>
+>;
+export type SearchQueryError = ErrorModel;
+
+export function useSearch<
+  TData = Awaited<ReturnType<typeof search>>,
+  TError = ErrorModel,
+>(
+  params: SearchParams,
+  options: {
+    query: Partial<
+      UseQueryOptions<Awaited<ReturnType<typeof search>>, TError, TData>
+    > &
+      Pick<
+        DefinedInitialDataOptions<
+          Awaited<ReturnType<typeof search>>,
+          TError,
+          Awaited<ReturnType<typeof search>>
+        >,
+        "initialData"
+      >;
+    request?: SecondParameter<typeof customInstance>;
+  },
+  queryClient?: QueryClient,
+): DefinedUseQueryResult<TData, TError> & {
+  queryKey: DataTag<QueryKey, TData, TError>;
+};
+export function useSearch<
+  TData = Awaited<ReturnType<typeof search>>,
+  TError = ErrorModel,
+>(
+  params: SearchParams,
+  options?: {
+    query?: Partial<
+      UseQueryOptions<Awaited<ReturnType<typeof search>>, TError, TData>
+    > &
+      Pick<
+        UndefinedInitialDataOptions<
+          Awaited<ReturnType<typeof search>>,
+          TError,
+          Awaited<ReturnType<typeof search>>
+        >,
+        "initialData"
+      >;
+    request?: SecondParameter<typeof customInstance>;
+  },
+  queryClient?: QueryClient,
+): UseQueryResult<TData, TError> & {
+  queryKey: DataTag<QueryKey, TData, TError>;
+};
+export function useSearch<
+  TData = Awaited<ReturnType<typeof search>>,
+  TError = ErrorModel,
+>(
+  params: SearchParams,
+  options?: {
+    query?: Partial<
+      UseQueryOptions<Awaited<ReturnType<typeof search>>, TError, TData>
+    >;
+    request?: SecondParameter<typeof customInstance>;
+  },
+  queryClient?: QueryClient,
+): UseQueryResult<TData, TError> & {
+  queryKey: DataTag<QueryKey, TData, TError>;
+};
+/**
+ * @summary Search occurrences, events and organizations
+ */
+
+export function useSearch<
+  TData = Awaited<ReturnType<typeof search>>,
+  TError = ErrorModel,
+>(
+  params: SearchParams,
+  options?: {
+    query?: Partial<
+      UseQueryOptions<Awaited<ReturnType<typeof search>>, TError, TData>
+    >;
+    request?: SecondParameter<typeof customInstance>;
+  },
+  queryClient?: QueryClient,
+): UseQueryResult<TData, TError> & {
+  queryKey: DataTag<QueryKey, TData, TError>;
+} {
+  const queryOptions = getSearchQueryOptions(params, options);
+
+  const query = useQuery(queryOptions, queryClient) as UseQueryResult<
+    TData,
+    TError
+  > & { queryKey: DataTag<QueryKey, TData, TError> };
+
+  return { ...query, queryKey: queryOptions.queryKey };
+}
+
+/**
+ * Returns events matching the search query using fuzzy full-text search, narrowed by the same filters as event occurrences, with facet counts for category, age band, price band and district. When a text query matches nothing, did_you_mean suggests a correction
+ * @summary Search events
  */
 export type searchEventsResponse200 = {
-  data: Event[];
+  data: SearchEventsResult;
   status: 200;
 };
 
@@ -219,7 +643,7 @@ export function useSearchEvents<
   queryKey: DataTag<QueryKey, TData, TError>;
 };
 /**
- * @summary Fuzzy search events
+ * @summary Search events
  */
 
 export function useSearchEvents<
@@ -246,3 +670,293 @@ export function useSearchEvents<
 
   return { ...query, queryKey: queryOptions.queryKey };
 }
+
+/**
+ * Returns events, organizations and categories matching a partially typed query, in English or Thai, for the search bar to show as the user types
+ * @summary Suggest search terms
+ */
+export type suggestSearchResponse200 = {
+  data: SearchSuggestions;
+  status: 200;
+};
+
+export type suggestSearchResponseDefault = {
+  data: ErrorModel;
+  status: Exclude<HTTPStatusCodes, 200>;
+};
+
+export type suggestSearchResponseSuccess = suggestSearchResponse200 & {
+  headers: Headers;
+};
+export type suggestSearchResponseError = suggestSearchResponseDefault & {
+  headers: Headers;
+};
+
+export type suggestSearchResponse =
+  | suggestSearchResponseSuccess
+  | suggestSearchResponseError;
+
+export const getSuggestSearchUrl = (params: SuggestSearchParams) => {
+  const normalizedParams = new URLSearchParams();
+
+  Object.entries(params || {}).forEach(([key, value]) => {
+    if (value !== undefined) {
+      normalizedParams.append(key, value === null ? "null" : value.toString());
+    }
+  });
+
+  const stringifiedParams = normalizedParams.toString();
+
+  return stringifiedParams.length > 0
+    ? `/api/v1/search/suggest?${stringifiedParams}`
+    : `/api/v1/search/suggest`;
+};
+
+export const suggestSearch = async (
+  params: SuggestSearchParams,
+  options?: RequestInit,
+): Promise<suggestSearchResponse> => {
+  return customInstance<suggestSearchResponse>(getSuggestSearchUrl(params), {
+    ...options,
+    method: "GET",
+  });
+};
+
+export const getSuggestSearchQueryKey = (params: SuggestSearchParams) => {
+  return [`/api/v1/search/suggest`, ...(params ? [params] : [])] as const;
+};
+
+export const getSuggestSearchQueryOptions = <
+  TData = Awaited<ReturnType<typeof suggestSearch>>,
+  TError = ErrorModel,
+>(
+  params: SuggestSearchParams,
+  options?: {
+    query?: Partial<
+      UseQueryOptions<Awaited<ReturnType<typeof suggestSearch>>, TError, TData>
+    >;
+    request?: SecondParameter<typeof customInstance>;
+  },
+) => {
+  const { query: queryOptions, request: requestOptions } = options ?? {};
+
+  const queryKey = queryOptions?.queryKey ?? getSuggestSearchQueryKey(params);
+
+  const queryFn: QueryFunction<Awaited<ReturnType<typeof suggestSearch>>> = ({
+    signal,
+  }) => suggestSearch(params, { signal, ...requestOptions });
+
+  return { queryKey, queryFn, ...queryOptions } as UseQueryOptions<
+    Awaited<ReturnType<typeof suggestSearch>>,
+    TError,
+    TData
+  > & { queryKey: DataTag<QueryKey, TData, TError> };
+};
+
+export type SuggestSearchQueryResult = NonNullable<
+  Awaited<ReturnType<typeof suggestSearch>>
+>;
+export type SuggestSearchQueryError = ErrorModel;
+
+export function useSuggestSearch<
+  TData = Awaited<ReturnType<typeof suggestSearch>>,
+  TError = ErrorModel,
+>(
+  params: SuggestSearchParams,
+  options: {
+    query: Partial<
+      UseQueryOptions<Awaited<ReturnType<typeof suggestSearch>>, TError, TData>
+    > &
+      Pick<
+        DefinedInitialDataOptions<
+          Awaited<ReturnType<typeof suggestSearch>>,
+          TError,
+          Awaited<ReturnType<typeof suggestSearch>>
+        >,
+        "initialData"
+      >;
+    request?: SecondParameter<typeof customInstance>;
+  },
+  queryClient?: QueryClient,
+): DefinedUseQueryResult<TData, TError> & {
+  queryKey: DataTag<QueryKey, TData, TError>;
+};
+export function useSuggestSearch<
+  TData = Awaited<ReturnType<typeof suggestSearch>>,
+  TError = ErrorModel,
+>(
+  params: SuggestSearchParams,
+  options?: {
+    query?: Partial<
+      UseQueryOptions<Awaited<ReturnType<typeof suggestSearch>>, TError, TData>
+    > &
+      Pick<
+        UndefinedInitialDataOptions<
+          Awaited<ReturnType<typeof suggestSearch>>,
+          TError,
+          Awaited<ReturnType<typeof suggestSearch>>
+        >,
+        "initialData"
+      >;
+    request?: SecondParameter<typeof customInstance>;
+  },
+  queryClient?: QueryClient,
+): UseQueryResult<TData, TError> & {
+  queryKey: DataTag<QueryKey, TData, TError>;
+};
+export function useSuggestSearch<
+  TData = Awaited<ReturnType<typeof suggestSearch>>,
+  TError = ErrorModel,
+>(
+  params: SuggestSearchParams,
+  options?: {
+    query?: Partial<
+      UseQueryOptions<Awaited<ReturnType<typeof suggestSearch>>, TError, TData>
+    >;
+    request?: SecondParameter<typeof customInstance>;
+  },
+  queryClient?: QueryClient,
+): UseQueryResult<TData, TError> & {
+  queryKey: DataTag<QueryKey, TData, TError>;
+};
+/**
+ * @summary Suggest search terms
+ */
+
+export function useSuggestSearch<
+  TData = Awaited<ReturnType<typeof suggestSearch>>,
+  TError = ErrorModel,
+>(
+  params: SuggestSearchParams,
+  options?: {
+    query?: Partial<
+      UseQueryOptions<Awaited<ReturnType<typeof suggestSearch>>, TError, TData>
+    >;
+    request?: SecondParameter<typeof customInstance>;
+  },
+  queryClient?: QueryClient,
+): UseQueryResult<TData, TError> & {
+  queryKey: DataTag<QueryKey, TData, TError>;
+} {
+  const queryOptions = getSuggestSearchQueryOptions(params, options);
+
+  const query = useQuery(queryOptions, queryClient) as UseQueryResult<
+    TData,
+    TError
+  > & { queryKey: DataTag<QueryKey, TData, TError> };
+
+  return { ...query, queryKey: queryOptions.queryKey };
+}
+/**
+ * Records which result was opened from a search, using the search_id returned with the results. Only the first result opened from a search is kept
+ * @summary Record a search result click
+ */
+export type recordSearchClickResponse200 = {
+  data: SearchLog;
+  status: 200;
+};
+
+export type recordSearchClickResponseDefault = {
+  data: ErrorModel;
+  status: Exclude<HTTPStatusCodes, 200>;
+};
+
+export type recordSearchClickResponseSuccess = recordSearchClickResponse200 & {
+  headers: Headers;
+};
+export type recordSearchClickResponseError =
+  recordSearchClickResponseDefault & {
+    headers: Headers;
+  };
+
+export type recordSearchClickResponse =
+  | recordSearchClickResponseSuccess
+  | recordSearchClickResponseError;
+
+export const getRecordSearchClickUrl = (id: string) => {
+  return `/api/v1/search/${id}/click`;
+};
+
+export const recordSearchClick = async (
+  id: string,
+  recordSearchClickInputBody: NonReadonly<RecordSearchClickInputBody>,
+  options?: RequestInit,
+): Promise<recordSearchClickResponse> => {
+  return customInstance<recordSearchClickResponse>(
+    getRecordSearchClickUrl(id),
+    {
+      ...options,
+      method: "POST",
+      headers: { "Content-Type": "application/json", ...options?.headers },
+      body: JSON.stringify(recordSearchClickInputBody),
+    },
+  );
+};
+
+export const getRecordSearchClickMutationOptions = <
+  TError = ErrorModel,
+  TContext = unknown,
+>(options?: {
+  mutation?: UseMutationOptions<
+    Awaited<ReturnType<typeof recordSearchClick>>,
+    TError,
+    { id: string; data: NonReadonly<RecordSearchClickInputBody> },
+    TContext
+  >;
+  request?: SecondParameter<typeof customInstance>;
+}): UseMutationOptions<
+  Awaited<ReturnType<typeof recordSearchClick>>,
+  TError,
+  { id: string; data: NonReadonly<RecordSearchClickInputBody> },
+  TContext
+> => {
+  const mutationKey = ["recordSearchClick"];
+  const { mutation: mutationOptions, request: requestOptions } = options
+    ? options.mutation &&
+      "mutationKey" in options.mutation &&
+      options.mutation.mutationKey
+      ? options
+      : { ...options, mutation: { ...options.mutation, mutationKey } }
+    : { mutation: { mutationKey }, request: undefined };
+
+  const mutationFn: MutationFunction<
+    Awaited<ReturnType<typeof recordSearchClick>>,
+    { id: string; data: NonReadonly<RecordSearchClickInputBody> }
+  > = (props) => {
+    const { id, data } = props ?? {};
+
+    return recordSearchClick(id, data, requestOptions);
+  };
+
+  return { mutationFn, ...mutationOptions };
+};
+
+export type RecordSearchClickMutationResult = NonNullable<
+  Awaited<ReturnType<typeof recordSearchClick>>
+>;
+export type RecordSearchClickMutationBody =
+  NonReadonly<RecordSearchClickInputBody>;
+export type RecordSearchClickMutationError = ErrorModel;
+
+/**
+ * @summary Record a search result click
+ */
+export const useRecordSearchClick = <TError = ErrorModel, TContext = unknown>(
+  options?: {
+    mutation?: UseMutationOptions<
+      Awaited<ReturnType<typeof recordSearchClick>>,
+      TError,
+      { id: string; data: NonReadonly<RecordSearchClickInputBody> },
+      TContext
+    >;
+    request?: SecondParameter<typeof customInstance>;
+  },
+  queryClient?: QueryClient,
+): UseMutationResult<
+  Awaited<ReturnType<typeof recordSearchClick>>,
+  TError,
+  { id: string; data: NonReadonly<RecordSearchClickInputBody> },
+  TContext
+> => {
+  return useMutation(getRecordSearchClickMutationOptions(options), queryClient);
+};
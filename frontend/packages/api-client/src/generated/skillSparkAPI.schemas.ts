@@ -78,6 +78,8 @@ export interface Registration {
   stripe_payment_intent_id: string;
   /** Stripe payment method ID */
   stripe_payment_method_id: string;
+  /** Stripe transfer paying the organization for a registration paid with wallet credit */
+  stripe_transfer_id?: string;
   /** Total amount in cents */
   total_amount: number;
   /** Timestamp when registration was last updated */
@@ -508,6 +510,18 @@ export interface EventOccurrence {
   updated_at: string;
 }
 
+export interface EventSuggestion {
+  id: string;
+  title: string;
+}
+
+export interface FacetBucket {
+  /** Number of matching events in the bucket */
+  count: number;
+  /** Category, district or band name */
+  key: string;
+}
+
 export interface ForgotPasswordInputBody {
   /** A URL to the JSON Schema for this object. */
   readonly $schema?: string;
@@ -540,6 +554,43 @@ export interface GeocodeAddressOutputBody {
   longitude: number;
 }
 
+export interface OccurrenceSearchResult {
+  age_range_max?: number;
+  age_range_min?: number;
+  category: string[];
+  currency: string;
+  description: string;
+  district?: string;
+  end_time: string;
+  event_id: string;
+  header_image_s3_key?: string;
+  id: string;
+  language: string;
+  organization_id: string;
+  organization_name?: string;
+  presigned_url?: string;
+  /** Price in cents */
+  price: number;
+  seats_left: number;
+  sold_out: boolean;
+  start_time: string;
+  title: string;
+}
+
+export interface OrganizationSearchResult {
+  about?: string;
+  district?: string;
+  id: string;
+  name: string;
+  pfp_s3_key?: string;
+  presigned_url?: string;
+}
+
+export interface OrganizationSuggestion {
+  id: string;
+  name: string;
+}
+
 export interface PaymentMethodCard {
   brand: string;
   exp_month: number;
@@ -715,6 +766,84 @@ export interface PatchManagerInputBody {
   username?: string;
 }
 
+export interface Recommendation {
+  age_range_max: number;
+  age_range_min: number;
+  category: string[];
+  created_at: string;
+  description: string;
+  /** Reasons the event was recommended; there is always at least one */
+  explanations: RecommendationExplanation[];
+  header_image_s3_key: string;
+  id: string;
+  organization_id: string;
+  presigned_url: string;
+  /** Sum of the score components; recommendations are ordered by it */
+  score: number;
+  /** What the score adds up from */
+  score_components: RecommendationScoreComponents;
+  title: string;
+  updated_at: string;
+}
+
+/**
+ * Why the event was recommended
+ */
+export type RecommendationExplanationReason =
+  (typeof RecommendationExplanationReason)[keyof typeof RecommendationExplanationReason];
+
+export const RecommendationExplanationReason = {
+  matches_interest: "matches_interest",
+  similar_families: "similar_families",
+  popular_near_you: "popular_near_you",
+  popular: "popular",
+  upcoming: "upcoming",
+} as const;
+
+export interface RecommendationExplanation {
+  /** Why the event was recommended */
+  reason: RecommendationExplanationReason;
+  /** The reason in the requested language, e.g. 'Matches interest: robotics' */
+  text: string;
+}
+
+export interface RecommendationScoreComponents {
+  /** How strongly families like the child's booked the event, from 0 to 2 */
+  collaborative: number;
+  /** Penalty, 0 or less, for sharing an organization, categories or a day with better recommendations */
+  diversity: number;
+  /** One point for each of the event's categories the child is interested in */
+  interest: number;
+  /** How many families registered for the event recently, from 0 to 1 */
+  popularity: number;
+}
+
+/**
+ * Type of the result opened
+ */
+export type RecordSearchClickInputBodyResultType =
+  (typeof RecordSearchClickInputBodyResultType)[keyof typeof RecordSearchClickInputBodyResultType];
+
+export const RecordSearchClickInputBodyResultType = {
+  event: "event",
+  occurrence: "occurrence",
+  organization: "organization",
+} as const;
+
+export interface RecordSearchClickInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /**
+   * Zero-based position of the result in the list shown
+   * @minimum 0
+   */
+  position: number;
+  /** ID of the result opened */
+  result_id: string;
+  /** Type of the result opened */
+  result_type: RecordSearchClickInputBodyResultType;
+}
+
 export interface ResetPasswordInputBody {
   /** A URL to the JSON Schema for this object. */
   readonly $schema?: string;
@@ -780,6 +909,128 @@ export interface School {
   updated_at: string;
 }
 
+export interface SearchQueryStats {
+  /** clicks / searches */
+  click_through_rate: number;
+  /** Number of searches followed by opening a result */
+  clicks: number;
+  last_searched_at: string;
+  /** Normalized query */
+  query: string;
+  /** Number of searches */
+  searches: number;
+  /** Number of searches that found nothing */
+  zero_result_searches: number;
+}
+
+export interface SearchAnalytics {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  click_through_rate: number;
+  clicks: number;
+  /** Searches with a text query in the window */
+  searches: number;
+  since: string;
+  /** Most searched queries */
+  top_queries: SearchQueryStats[];
+  until: string;
+  /** Queries that most often found nothing, the activities to recruit organizations for */
+  zero_result_queries: SearchQueryStats[];
+  zero_result_searches: number;
+}
+
+export interface SearchFacets {
+  /** Events whose age range overlaps each band */
+  age_band: FacetBucket[];
+  category: FacetBucket[];
+  /** District of the event's organization */
+  district: FacetBucket[];
+  /** Events with an occurrence priced within each band */
+  price_band: FacetBucket[];
+}
+
+export interface SearchEventsResult {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** A spelling correction of the query, when it matched no events */
+  did_you_mean?: string;
+  /** Counts over every matching event, not only this page */
+  facets: SearchFacets;
+  results: Event[];
+  /** Send with the result the user opens to POST /api/v1/search/{id}/click */
+  search_id?: string;
+  /** Number of events matching the query and filters */
+  total: number;
+}
+
+/**
+ * Type of the result opened
+ */
+export type SearchLogClickedResultType =
+  (typeof SearchLogClickedResultType)[keyof typeof SearchLogClickedResultType];
+
+export const SearchLogClickedResultType = {
+  event: "event",
+  occurrence: "occurrence",
+  organization: "organization",
+} as const;
+
+/**
+ * Which search endpoint was called
+ */
+export type SearchLogEndpoint =
+  (typeof SearchLogEndpoint)[keyof typeof SearchLogEndpoint];
+
+export const SearchLogEndpoint = {
+  events: "events",
+  all: "all",
+} as const;
+
+/**
+ * Filters applied to the search
+ */
+export type SearchLogFilters = { [key: string]: unknown };
+
+export interface SearchLog {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Timestamp when the result was opened */
+  clicked_at?: string;
+  /** Zero-based position of the result opened */
+  clicked_position?: number;
+  /** ID of the result opened from the results */
+  clicked_result_id?: string;
+  /** Type of the result opened */
+  clicked_result_type?: SearchLogClickedResultType;
+  /** Timestamp of the search */
+  created_at: string;
+  /** Which search endpoint was called */
+  endpoint: SearchLogEndpoint;
+  /** Filters applied to the search */
+  filters: SearchLogFilters;
+  /** Search ID, returned with the results as search_id */
+  id: string;
+  /** Accept-Language of the request */
+  language: string;
+  /** The query lowercased with whitespace collapsed */
+  normalized_query: string;
+  /** The query as typed */
+  query: string;
+  /** Number of matching results */
+  result_count: number;
+}
+
+export interface SearchSuggestions {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Category values with a word starting with the query */
+  categories: string[];
+  /** Events whose title starts with or closely matches the query */
+  events: EventSuggestion[];
+  /** Organizations whose name matches the query, most events first */
+  organizations: OrganizationSuggestion[];
+}
+
 export interface SimpleReviewAggregate {
   average_rating: number;
   event: Event;
@@ -922,6 +1173,20 @@ export interface UpdateRegistrationPaymentStatusInputBody {
   payment_intent_status: string;
 }
 
+export interface UnifiedSearchResult {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  events: Event[];
+  events_total: number;
+  /** Upcoming scheduled occurrences, best match first */
+  occurrences: OccurrenceSearchResult[];
+  occurrences_total: number;
+  organizations: OrganizationSearchResult[];
+  organizations_total: number;
+  /** Send with the result the user opens to POST /api/v1/search/{id}/click */
+  search_id?: string;
+}
+
 export interface UsernameExistsOutputBody {
   /** A URL to the JSON Schema for this object. */
   readonly $schema?: string;
@@ -1194,10 +1459,45 @@ export type GetAllSchoolsParams = {
   limit?: number;
 };
 
-export type SearchEventsParams = {
+export type GetSearchAnalyticsParams = {
+  /**
+   * Start of the reporting window; defaults to 30 days ago
+   */
+  since?: string;
+  /**
+   * End of the reporting window; defaults to now
+   */
+  until?: string;
+  /**
+   * Number of queries in each list
+   * @minimum 1
+   * @maximum 100
+   */
+  limit?: number;
+};
+
+export type SearchParams = {
   /**
    * Search query string
    * @minLength 1
+   * @maxLength 200
+   */
+  q: string;
+  /**
+   * Comma-separated list of result types to search: occurrences, events, organizations
+   */
+  types?: string;
+  /**
+   * Maximum number of results of each type
+   * @minimum 1
+   * @maximum 20
+   */
+  limit?: number;
+};
+
+export type SearchEventsParams = {
+  /**
+   * Search query string; leave empty to browse with filters only
    */
   q?: string;
   /**
@@ -1211,6 +1511,72 @@ export type SearchEventsParams = {
    * @maximum 100
    */
   limit?: number;
+  /**
+   * The user's latitude, used by radius_km and the distance sort
+   */
+  lat?: string;
+  /**
+   * The user's longitude, used by radius_km and the distance sort
+   */
+  lng?: string;
+  /**
+   * Only return events whose organization is within this many km
+   */
+  radius_km?: number;
+  /**
+   * Minimum occurrence price in cents
+   */
+  min_price?: number;
+  /**
+   * Maximum occurrence price in cents (exclusive)
+   */
+  max_price?: number;
+  /**
+   * Minimum occurrence duration in minutes
+   */
+  min_duration?: number;
+  /**
+   * Maximum occurrence duration in minutes
+   */
+  max_duration?: number;
+  min_age?: number;
+  max_age?: number;
+  /**
+   * Comma-separated list of category values
+   */
+  category?: string;
+  soldout?: boolean;
+  min_date?: string;
+  max_date?: string;
+  /**
+   * distance needs lat and lng; date and price use each event's earliest matching upcoming occurrence
+   */
+  sort?: SearchEventsSort;
+};
+
+export type SearchEventsSort =
+  (typeof SearchEventsSort)[keyof typeof SearchEventsSort];
+
+export const SearchEventsSort = {
+  relevance: "relevance",
+  distance: "distance",
+  date: "date",
+  price: "price",
+} as const;
+
+export type SuggestSearchParams = {
+  /**
+   * What the user has typed so far
+   * @minLength 1
+   * @maxLength 100
+   */
+  q: string;
+  /**
+   * Maximum number of suggestions in each group
+   * @minimum 1
+   * @maximum 10
+   */
+  limit?: number;
 };
 
 export type GetTrendingEventOccurrencesParams = {
@@ -65,7 +65,7 @@ type ExpoPushTicket struct {
 }
 
 // SendPushNotification sends a push notification via Expo API
-func (c *ExpoClient) SendPushNotification(ctx context.Context, token string, body string, metadata json.RawMessage) error {
+func (c *ExpoClient) SendPushNotification(ctx context.Context, token string, title string, body string, metadata json.RawMessage) error {
 	if token == "" {
 		return fmt.Errorf("push token is required")
 	}
@@ -73,6 +73,7 @@ func (c *ExpoClient) SendPushNotification(ctx context.Context, token string, bod
 	// Create request payload
 	reqBody := ExpoPushRequest{
 		To:    []string{token},
+		Title: title,
 		Body:  body,
 		Data:  metadata,
 		Sound: "default",
@@ -16,11 +16,15 @@ const (
 // NotificationMessage represents the payload structure sent to SQS
 // This matches the backend models.NotificationMessage structure
 type NotificationMessage struct {
+	NotificationID     *string          `json:"notification_id,omitempty"`
+	GuardianID         *string          `json:"guardian_id,omitempty"`
 	NotificationType   NotificationType `json:"notification_type"`
 	RecipientEmail     *string          `json:"recipient_email,omitempty"`
 	RecipientPushToken *string          `json:"recipient_push_token,omitempty"`
 	Subject            *string          `json:"subject,omitempty"`
 	Body               string           `json:"body"`
+	HTMLBody           *string          `json:"html_body,omitempty"`
+	UnsubscribeURL     *string          `json:"unsubscribe_url,omitempty"`
 	Metadata           json.RawMessage  `json:"metadata,omitempty"`
 }
 
@@ -62,11 +66,20 @@ type ResendClient struct {
 
 // ResendEmailRequest represents the request payload for Resend API
 type ResendEmailRequest struct {
-	From    string   `json:"from"`
-	To      []string `json:"to"`
-	Subject string   `json:"subject"`
-	Text    string   `json:"text"`
-	HTML    string   `json:"html,omitempty"`
+	From    string            `json:"from"`
+	To      []string          `json:"to"`
+	Subject string            `json:"subject"`
+	Text    string            `json:"text"`
+	HTML    string            `json:"html,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Tags    []ResendTag       `json:"tags,omitempty"`
+}
+
+// ResendTag is a name/value pair Resend attaches to an email and echoes back in its
+// webhooks, which is how the backend matches delivery events to notifications
+type ResendTag struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 // ResendEmailResponse represents the response from Resend API
@@ -45,7 +45,7 @@ func (p *NotificationProcessor) ProcessNotification(ctx context.Context, message
 			return fmt.Errorf("recipient email is required for email notification")
 		}
 
-		if err := p.resendClient.SendEmail(ctx, *message.RecipientEmail, subject, message.Body); err != nil {
+		if err := p.resendClient.SendEmail(ctx, *message.RecipientEmail, subject, message.Body, message.HTMLBody, message.UnsubscribeURL, resendTags(&message)); err != nil {
 			return fmt.Errorf("failed to send email: %w", err)
 		}
 
@@ -61,7 +61,13 @@ func (p *NotificationProcessor) ProcessNotification(ctx context.Context, message
 			return fmt.Errorf("recipient push token is required for push notification")
 		}
 
-		if err := p.expoClient.SendPushNotification(ctx, *message.RecipientPushToken, message.Body, message.Metadata); err != nil {
+		// Push notifications carry their title in the subject field
+		title := ""
+		if message.Subject != nil {
+			title = *message.Subject
+		}
+
+		if err := p.expoClient.SendPushNotification(ctx, *message.RecipientPushToken, title, message.Body, message.Metadata); err != nil {
 			return fmt.Errorf("failed to send push notification: %w", err)
 		}
 
@@ -70,3 +76,16 @@ func (p *NotificationProcessor) ProcessNotification(ctx context.Context, message
 
 	return nil
 }
+
+// resendTags identifies the notification and guardian an email was sent for, so the
+// backend's Resend webhook can record bounces and complaints against them
+func resendTags(message *NotificationMessage) []ResendTag {
+	var tags []ResendTag
+	if message.NotificationID != nil {
+		tags = append(tags, ResendTag{Name: "notification_id", Value: *message.NotificationID})
+	}
+	if message.GuardianID != nil {
+		tags = append(tags, ResendTag{Name: "guardian_id", Value: *message.GuardianID})
+	}
+	return tags
+}
@@ -37,19 +37,33 @@ func NewResendClient() (*ResendClient, error) {
 	}, nil
 }
 
-// SendEmail sends an email via Resend API
-func (c *ResendClient) SendEmail(ctx context.Context, recipient string, subject string, body string) error {
+// SendEmail sends an email via Resend API. When no rendered HTML body is given, the
+// plain-text body is wrapped in a paragraph. A non-empty unsubscribeURL is advertised with
+// one-click List-Unsubscribe headers (RFC 8058). tags are returned in Resend's webhooks.
+func (c *ResendClient) SendEmail(ctx context.Context, recipient string, subject string, body string, htmlBody *string, unsubscribeURL *string, tags []ResendTag) error {
 	if recipient == "" {
 		return fmt.Errorf("recipient email is required")
 	}
 
+	html := fmt.Sprintf("<p>%s</p>", body) // Simple HTML version
+	if htmlBody != nil && *htmlBody != "" {
+		html = *htmlBody
+	}
+
 	// Create request payload
 	reqBody := ResendEmailRequest{
 		From:    c.from,
 		To:      []string{recipient},
 		Subject: subject,
 		Text:    body,
-		HTML:    fmt.Sprintf("<p>%s</p>", body), // Simple HTML version
+		HTML:    html,
+		Tags:    tags,
+	}
+	if unsubscribeURL != nil && *unsubscribeURL != "" {
+		reqBody.Headers = map[string]string{
+			"List-Unsubscribe":      "<" + *unsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		}
 	}
 
 	jsonData, err := json.Marshal(reqBody)